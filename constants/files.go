@@ -21,4 +21,7 @@ const (
 
 	// FileOperationCreateDir method name of create directory
 	FileOperationCreateDir = "createdir"
+
+	// FileOperationUpdateAttrs method name of update file attributes
+	FileOperationUpdateAttrs = "update_attrs"
 )
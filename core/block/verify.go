@@ -0,0 +1,50 @@
+package block
+
+import (
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+)
+
+// GetMiner returns the miner with the given id if it's part of the miner set of the magic block.
+//   - id: the id of the miner
+func (mb *MagicBlock) GetMiner(id string) (Node, bool) {
+	if mb == nil || mb.Miners == nil {
+		return Node{}, false
+	}
+	n, ok := mb.Miners.Nodes[id]
+	return n, ok
+}
+
+// VerifyBlockSignature verifies that the block was generated by a miner of the magic block,
+// i.e. the signature of the block hash matches the public key of the miner in the magic block.
+//   - b: the block to verify
+func (mb *MagicBlock) VerifyBlockSignature(b *Block) error {
+	if b == nil {
+		return errors.New("verify_block", "nil block")
+	}
+	if b.MagicBlockHash != "" && mb.Hash != "" && b.MagicBlockHash != mb.Hash {
+		return errors.Newf("verify_block", "block magic block %s doesn't match magic block %s", b.MagicBlockHash, mb.Hash)
+	}
+	if b.Header != nil && (b.Header.Hash != string(b.Hash) || b.Header.MinerID != string(b.MinerID)) {
+		return errors.New("verify_block", "block header doesn't match the block")
+	}
+
+	miner, ok := mb.GetMiner(string(b.MinerID))
+	if !ok {
+		return errors.Newf("verify_block", "miner %s is not in the miner set of magic block %d", b.MinerID, mb.MagicBlockNumber)
+	}
+	if b.Signature == "" {
+		return errors.New("verify_block", "block is not signed")
+	}
+	if sys.VerifyWith == nil {
+		return errors.New("verify_block", "signature verification is not initialized")
+	}
+	ok, err := sys.VerifyWith(miner.PublicKey, b.Signature, string(b.Hash))
+	if err != nil {
+		return errors.Wrap(err, "verify_block: invalid block signature")
+	}
+	if !ok {
+		return errors.Newf("verify_block", "invalid signature of block %s by miner %s", b.Hash, b.MinerID)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockSignature(t *testing.T) {
+	verifyWith := sys.VerifyWith
+	defer func() { sys.VerifyWith = verifyWith }()
+	sys.VerifyWith = func(pk, signature, msg string) (bool, error) {
+		return signature == pk+":"+msg, nil
+	}
+
+	mb := &MagicBlock{
+		Hash:             "mb",
+		MagicBlockNumber: 2,
+		Miners: &NodePool{Nodes: map[string]Node{
+			"miner1": {ID: "miner1", PublicKey: "pk1"},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		block   *Block
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			block: &Block{MinerID: "miner1", Hash: "h", Signature: "pk1:h", MagicBlockHash: "mb"},
+		},
+		{
+			name:    "unknown miner",
+			block:   &Block{MinerID: "miner2", Hash: "h", Signature: "pk1:h"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid signature",
+			block:   &Block{MinerID: "miner1", Hash: "h", Signature: "pk1:other"},
+			wantErr: true,
+		},
+		{
+			name:    "not signed",
+			block:   &Block{MinerID: "miner1", Hash: "h"},
+			wantErr: true,
+		},
+		{
+			name:    "other magic block",
+			block:   &Block{MinerID: "miner1", Hash: "h", Signature: "pk1:h", MagicBlockHash: "mb2"},
+			wantErr: true,
+		},
+		{
+			name:    "header mismatch",
+			block:   &Block{MinerID: "miner1", Hash: "h", Signature: "pk1:h", Header: &Header{Hash: "h2", MinerID: "miner1"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mb.VerifyBlockSignature(tt.block)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
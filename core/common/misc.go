@@ -257,3 +257,46 @@ func AutoFormatStatic(amount int64) (string, error) {
 	token := Balance(amount)
 	return token.AutoFormat()
 }
+
+/* WhoPays */
+
+// WhoPays is the payer of the reads of a file.
+type WhoPays int
+
+const (
+	// WhoPaysOwner is the default, the owner of the allocation pays for the reads of the file.
+	WhoPaysOwner WhoPays = iota
+	// WhoPays3rdParty is the 3rd party user downloading the file pays for its reads.
+	WhoPays3rdParty
+)
+
+// String implements fmt.Stringer interface
+func (wp WhoPays) String() string {
+	switch wp {
+	case WhoPaysOwner:
+		return "owner"
+	case WhoPays3rdParty:
+		return "3rd_party"
+	}
+	return fmt.Sprintf("WhoPays(%d)", int(wp))
+}
+
+// Validate returns an error if the payer is unknown.
+func (wp WhoPays) Validate() error {
+	switch wp {
+	case WhoPaysOwner, WhoPays3rdParty:
+		return nil
+	}
+	return fmt.Errorf("unknown who_pays value: %d", int(wp))
+}
+
+// ParseWhoPays parses the string representation of a payer, see WhoPays.String.
+func ParseWhoPays(s string) (WhoPays, error) {
+	switch s {
+	case "owner":
+		return WhoPaysOwner, nil
+	case "3rd_party":
+		return WhoPays3rdParty, nil
+	}
+	return 0, errors.New("unknown who_pays value: " + s)
+}
@@ -133,3 +133,16 @@ func TestToToken(t *testing.T) {
 	require.Equal(t, 0.00012938, token)
 	require.NoError(t, err)
 }
+
+func TestWhoPays(t *testing.T) {
+	for _, wp := range []WhoPays{WhoPaysOwner, WhoPays3rdParty} {
+		require.NoError(t, wp.Validate())
+		parsed, err := ParseWhoPays(wp.String())
+		require.NoError(t, err)
+		require.Equal(t, wp, parsed)
+	}
+	require.Error(t, WhoPays(2).Validate())
+	require.Equal(t, "WhoPays(2)", WhoPays(2).String())
+	_, err := ParseWhoPays("anyone")
+	require.Error(t, err)
+}
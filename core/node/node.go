@@ -117,6 +117,18 @@ func (h *NodeHolder) All() (res []string) {
 	return h.nodes
 }
 
+// Weights returns the current weight of each node, higher weight means a healthier node.
+func (h *NodeHolder) Weights() map[string]int64 {
+	h.guard.Lock()
+	defer h.guard.Unlock()
+
+	weights := make(map[string]int64, len(h.stats))
+	for id, n := range h.stats {
+		weights[id] = n.weight
+	}
+	return weights
+}
+
 const consensusThresh = 25
 const (
 	GET_BALANCE        = `/v1/client/get/balance?client_id=`
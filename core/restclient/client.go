@@ -0,0 +1,170 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/0chain/errors"
+)
+
+// DefaultPageSize is the default number of items requested per page of a paginated endpoint.
+const DefaultPageSize = 20
+
+// RetryPolicy is the policy of the retries of the failed queries. The error responses of the smart
+// contracts, see ResponseError, and the canceled queries are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a query, 1 disables the retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled at each retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the default retry policy of a Client.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     500 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+}
+
+// Client queries the smart contract REST endpoints of the sharders with typed responses.
+type Client struct {
+	transport Transport
+	retry     RetryPolicy
+	pageSize  int
+}
+
+// Option customizes a Client.
+type Option func(c *Client)
+
+// WithRetryPolicy sets the retry policy of the client, DefaultRetryPolicy by default.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithPageSize sets the number of items requested per page of the paginated endpoints,
+// DefaultPageSize by default.
+func WithPageSize(pageSize int) Option {
+	return func(c *Client) {
+		if pageSize > 0 {
+			c.pageSize = pageSize
+		}
+	}
+}
+
+// New returns a client querying the sharders through a transport.
+//   - transport: the transport of the queries, e.g. a QuorumTransport.
+//   - opts: the options of the client.
+func New(transport Transport, opts ...Option) *Client {
+	c := &Client{
+		transport: transport,
+		retry:     DefaultRetryPolicy,
+		pageSize:  DefaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Query queries a smart contract endpoint and returns its raw response, retrying the failed queries.
+//   - ctx: the context of the query.
+//   - scAddress: the smart contract address.
+//   - relativePath: the relative path of the endpoint.
+//   - params: the query parameters.
+func (c *Client) Query(ctx context.Context, scAddress, relativePath string, params map[string]string) ([]byte, error) {
+	backoff := c.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		b, err := c.transport.Query(ctx, scAddress, relativePath, params)
+		if err == nil || attempt >= c.retry.MaxAttempts || !retryable(ctx, err) {
+			return b, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if c.retry.MaxBackoff > 0 && backoff > c.retry.MaxBackoff {
+			backoff = c.retry.MaxBackoff
+		}
+	}
+}
+
+func retryable(ctx context.Context, err error) bool {
+	var respErr *ResponseError
+	return ctx.Err() == nil && !errors.As(err, &respErr)
+}
+
+// Get queries a smart contract endpoint and decodes its json response.
+//   - ctx: the context of the query.
+//   - c: the client.
+//   - scAddress: the smart contract address.
+//   - relativePath: the relative path of the endpoint.
+//   - params: the query parameters.
+func Get[T any](ctx context.Context, c *Client, scAddress, relativePath string, params map[string]string) (T, error) {
+	var v T
+	b, err := c.Query(ctx, scAddress, relativePath, params)
+	if err != nil {
+		return v, err
+	}
+	if len(b) == 0 {
+		return v, errors.New("empty_response", "empty response from sharders")
+	}
+	if err = json.Unmarshal(b, &v); err != nil {
+		return v, errors.Wrap(err, "error decoding response")
+	}
+	return v, nil
+}
+
+// Paginate queries the pages of a smart contract endpoint paginated with the limit and offset query
+// parameters and collects their items, until a page has less items than requested.
+//   - ctx: the context of the queries.
+//   - c: the client.
+//   - scAddress: the smart contract address.
+//   - relativePath: the relative path of the endpoint.
+//   - params: the query parameters, the limit and the offset excluded.
+//   - decode: decodes the items of a page.
+func Paginate[T any](ctx context.Context, c *Client, scAddress, relativePath string, params map[string]string, decode func([]byte) ([]T, error)) ([]T, error) {
+	pageParams := make(map[string]string, len(params)+2)
+	for k, v := range params {
+		pageParams[k] = v
+	}
+	pageParams["limit"] = strconv.Itoa(c.pageSize)
+
+	var items []T
+	for offset := 0; ; offset += c.pageSize {
+		pageParams["offset"] = strconv.Itoa(offset)
+		b, err := c.Query(ctx, scAddress, relativePath, pageParams)
+		if err != nil {
+			return items, err
+		}
+		page, err := decode(b)
+		if err != nil {
+			return items, errors.Wrap(err, "error decoding response")
+		}
+		items = append(items, page...)
+		if len(page) < c.pageSize {
+			return items, nil
+		}
+	}
+}
+
+// GetAll queries all the pages of a smart contract endpoint returning json arrays, see Paginate.
+//   - ctx: the context of the queries.
+//   - c: the client.
+//   - scAddress: the smart contract address.
+//   - relativePath: the relative path of the endpoint.
+//   - params: the query parameters, the limit and the offset excluded.
+func GetAll[T any](ctx context.Context, c *Client, scAddress, relativePath string, params map[string]string) ([]T, error) {
+	return Paginate(ctx, c, scAddress, relativePath, params, func(b []byte) ([]T, error) {
+		var page []T
+		err := json.Unmarshal(b, &page)
+		return page, err
+	})
+}
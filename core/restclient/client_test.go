@@ -0,0 +1,114 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumTransport(t *testing.T) {
+	newSharder := func(status int, body string) string {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/screst/sc/allocation", r.URL.Path)
+			require.Equal(t, "alloc", r.URL.Query().Get("allocation"))
+			w.WriteHeader(status)
+			w.Write([]byte(body)) //nolint: errcheck
+		}))
+		t.Cleanup(s.Close)
+		return s.URL
+	}
+
+	sharders := []string{
+		newSharder(http.StatusOK, `{"id":"alloc","size":10}`),
+		newSharder(http.StatusOK, `{"size": 10, "id": "alloc"}`),
+		newSharder(http.StatusOK, `{"id":"alloc","size":20}`),
+		newSharder(http.StatusInternalServerError, `unavailable`),
+	}
+	var succeeded, failed []string
+	transport := &QuorumTransport{
+		Sharders:  func() []string { return sharders },
+		OnSuccess: func(sharder string) { succeeded = append(succeeded, sharder) },
+		OnFailure: func(sharder string) { failed = append(failed, sharder) },
+	}
+	params := map[string]string{"allocation": "alloc"}
+
+	// 2 of 4 sharders agree, the default quorum is a majority of 3
+	_, err := transport.Query(context.Background(), "sc", "/allocation", params)
+	require.Error(t, err)
+
+	succeeded, failed = nil, nil
+	transport.Quorum = 2
+	resp, err := transport.Query(context.Background(), "sc", "/allocation", params)
+	require.NoError(t, err)
+	require.Equal(t, CanonicalJSONHash([]byte(`{"id":"alloc","size":10}`)), CanonicalJSONHash(resp))
+	require.ElementsMatch(t, sharders[:2], succeeded)
+
+	transport.Quorum = 5
+	_, err = transport.Query(context.Background(), "sc", "/allocation", params)
+	require.Error(t, err)
+
+	transport.Sharders = func() []string {
+		return []string{newSharder(http.StatusBadRequest, `{"error":"allocation not found"}`)}
+	}
+	transport.Quorum = 0
+	_, err = transport.Query(context.Background(), "sc", "/allocation", params)
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	require.Equal(t, http.StatusBadRequest, respErr.StatusCode)
+	require.Equal(t, "allocation not found", respErr.Error())
+}
+
+func TestClient(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	var attempts int
+	flaky := TransportFunc(func(_ context.Context, _, _ string, _ map[string]string) ([]byte, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("consensus_failed")
+		}
+		return []byte(`{"id":"b1"}`), nil
+	})
+	type blobber struct {
+		ID string `json:"id"`
+	}
+	b, err := Get[blobber](context.Background(), New(flaky, WithRetryPolicy(policy)), "sc", "/getBlobber", nil)
+	require.NoError(t, err)
+	require.Equal(t, "b1", b.ID)
+	require.Equal(t, 3, attempts)
+
+	// the error responses of the smart contract aren't retried
+	attempts = 0
+	notFound := TransportFunc(func(_ context.Context, _, _ string, _ map[string]string) ([]byte, error) {
+		attempts++
+		return nil, &ResponseError{StatusCode: http.StatusBadRequest, Message: "not found"}
+	})
+	_, err = Get[blobber](context.Background(), New(notFound, WithRetryPolicy(policy)), "sc", "/getBlobber", nil)
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+
+	var offsets []string
+	pages := TransportFunc(func(_ context.Context, _, _ string, params map[string]string) ([]byte, error) {
+		require.Equal(t, "true", params["active"])
+		require.Equal(t, "2", params["limit"])
+		offsets = append(offsets, params["offset"])
+		offset, _ := strconv.Atoi(params["offset"])
+		var page []blobber
+		for i := offset; i < offset+2 && i < 5; i++ {
+			page = append(page, blobber{ID: strconv.Itoa(i)})
+		}
+		return json.Marshal(page)
+	})
+	all, err := GetAll[blobber](context.Background(), New(pages, WithPageSize(2)), "sc", "/getblobbers", map[string]string{"active": "true"})
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+	require.Equal(t, "4", all[4].ID)
+	require.Equal(t, []string{"0", "2", "4"}, offsets)
+}
@@ -0,0 +1,6 @@
+// Package restclient is the client of the smart contract REST endpoints of the sharders.
+//
+// A Client queries the sharders through a Transport, e.g. a QuorumTransport requiring a quorum of
+// identical responses, retries the failed queries with its RetryPolicy, decodes the typed responses
+// and collects the pages of the paginated endpoints.
+package restclient
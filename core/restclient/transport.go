@@ -0,0 +1,242 @@
+package restclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+)
+
+// SCRestAPIPath is the path of the smart contract REST endpoints of the sharders.
+const SCRestAPIPath = "v1/screst/"
+
+// DefaultQuorumTimeout is the default timeout of a query of a QuorumTransport.
+const DefaultQuorumTimeout = 30 * time.Second
+
+// Transport queries a smart contract REST endpoint of the sharders and returns its response.
+type Transport interface {
+	Query(ctx context.Context, scAddress, relativePath string, params map[string]string) ([]byte, error)
+}
+
+// TransportFunc is a function implementing Transport.
+type TransportFunc func(ctx context.Context, scAddress, relativePath string, params map[string]string) ([]byte, error)
+
+// Query implements Transport.
+func (f TransportFunc) Query(ctx context.Context, scAddress, relativePath string, params map[string]string) ([]byte, error) {
+	return f(ctx, scAddress, relativePath, params)
+}
+
+// Doer sends HTTP requests, e.g. *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ResponseError is the error response of a smart contract endpoint the sharders agreed on. It's a
+// response of the smart contract, so it's never retried.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return e.Message
+}
+
+// QuorumTransport fans out the queries to the sharders and returns the response only if enough
+// sharders agree on it. Responses are compared on the hash of their canonical json, so the order of
+// the keys and the formatting don't matter. The server errors never count toward the quorum.
+type QuorumTransport struct {
+	// Sharders returns the sharders, the healthiest first.
+	Sharders func() []string
+	// HTTPClient sends the requests, http.DefaultClient if nil.
+	HTTPClient Doer
+	// MaxSharders is the number of sharders queried, all of them if not positive.
+	MaxSharders int
+	// Quorum is the number of identical responses required, a majority of the queried sharders if
+	// not positive. 1 returns the first response.
+	Quorum int
+	// Timeout is the timeout of a query, DefaultQuorumTimeout if not positive.
+	Timeout time.Duration
+	// OnSuccess and OnFailure, if set, get the sharders agreeing with the quorum and the other ones,
+	// e.g. to track the health of the sharders.
+	OnSuccess func(sharder string)
+	OnFailure func(sharder string)
+}
+
+type sharderResponse struct {
+	sharder string
+	status  int
+	body    []byte
+	hash    string
+	err     error
+}
+
+// Query implements Transport.
+func (t *QuorumTransport) Query(ctx context.Context, scAddress, relativePath string, params map[string]string) ([]byte, error) {
+	var sharders []string
+	if t.Sharders != nil {
+		sharders = t.Sharders()
+	}
+	if t.MaxSharders > 0 && t.MaxSharders < len(sharders) {
+		sharders = sharders[:t.MaxSharders]
+	}
+	if len(sharders) == 0 {
+		return nil, errors.New("quorum_not_met", "no sharders available")
+	}
+	quorum := t.Quorum
+	if quorum <= 0 {
+		quorum = len(sharders)/2 + 1
+	}
+	if quorum > len(sharders) {
+		return nil, errors.Newf("quorum_not_met", "quorum %d is larger than the number of sharders %d", quorum, len(sharders))
+	}
+	timeout := t.Timeout
+	if timeout <= 0 {
+		timeout = DefaultQuorumTimeout
+	}
+	var doer Doer = http.DefaultClient
+	if t.HTTPClient != nil {
+		doer = t.HTTPClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	respCh := make(chan *sharderResponse, len(sharders))
+	for _, sharder := range sharders {
+		go func(sharder string) {
+			respCh <- querySharder(ctx, doer, sharder, scAddress, relativePath, params)
+		}(sharder)
+	}
+
+	agreed := make(map[string][]*sharderResponse)
+	failed := make(map[string]string)
+	for range sharders {
+		resp := <-respCh
+		if resp.err != nil {
+			failed[resp.sharder] = resp.err.Error()
+			if ctx.Err() == nil {
+				t.feedback(resp.sharder, false)
+			}
+			continue
+		}
+		agreed[resp.hash] = append(agreed[resp.hash], resp)
+		if len(agreed[resp.hash]) < quorum {
+			continue
+		}
+
+		for hash, group := range agreed {
+			for _, r := range group {
+				t.feedback(r.sharder, hash == resp.hash)
+			}
+		}
+		if resp.status != http.StatusOK {
+			return nil, &ResponseError{StatusCode: resp.status, Message: parseSCRestError(resp.body)}
+		}
+		return resp.body, nil
+	}
+
+	maxAgreed := 0
+	for _, group := range agreed {
+		if len(group) > maxAgreed {
+			maxAgreed = len(group)
+		}
+	}
+	return nil, errors.Newf("quorum_not_met", "required %d identical responses, got %d of %d sharders, %d failed: %v",
+		quorum, maxAgreed, len(sharders), len(failed), failed)
+}
+
+func (t *QuorumTransport) feedback(sharder string, ok bool) {
+	if ok && t.OnSuccess != nil {
+		t.OnSuccess(sharder)
+	} else if !ok && t.OnFailure != nil {
+		t.OnFailure(sharder)
+	}
+}
+
+// SCRestURL returns the url of a smart contract REST endpoint of a sharder.
+//   - sharder: the base url of the sharder.
+//   - scAddress: the smart contract address.
+//   - relativePath: the relative path of the endpoint, with or without query parameters.
+//   - params: the query parameters added to the ones of the relative path.
+func SCRestURL(sharder, scAddress, relativePath string, params map[string]string) (string, error) {
+	urlObj, err := url.Parse(fmt.Sprintf("%v/%v%v%v", sharder, SCRestAPIPath, scAddress, relativePath))
+	if err != nil {
+		return "", err
+	}
+	q := urlObj.Query()
+	for k, v := range params {
+		q.Add(k, v)
+	}
+	urlObj.RawQuery = q.Encode()
+	return urlObj.String(), nil
+}
+
+func querySharder(ctx context.Context, doer Doer, sharder, scAddress, relativePath string, params map[string]string) *sharderResponse {
+	resp := &sharderResponse{sharder: sharder}
+
+	u, err := SCRestURL(sharder, scAddress, relativePath, params)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+	httpResp, err := doer.Do(req)
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+	defer httpResp.Body.Close()
+
+	resp.status = httpResp.StatusCode
+	if resp.body, err = io.ReadAll(httpResp.Body); err != nil {
+		resp.err = err
+		return resp
+	}
+	// server errors are not a response of the smart contract, they never count toward the quorum
+	if resp.status >= http.StatusInternalServerError {
+		resp.err = errors.Newf("", "status %d: %s", resp.status, string(resp.body))
+		return resp
+	}
+	resp.hash = fmt.Sprintf("%d:%s", resp.status, CanonicalJSONHash(resp.body))
+	return resp
+}
+
+// CanonicalJSONHash returns the hash of the canonical form of a json document: keys sorted and
+// no insignificant whitespace. Data that is not valid json is hashed as is.
+func CanonicalJSONHash(data []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return encryption.Hash(data)
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return encryption.Hash(data)
+	}
+	return encryption.Hash(canonical)
+}
+
+func parseSCRestError(body []byte) string {
+	var objmap map[string]json.RawMessage
+	if err := json.Unmarshal(body, &objmap); err != nil {
+		return string(body)
+	}
+	var parsed string
+	if err := json.Unmarshal(objmap["error"], &parsed); err != nil || parsed == "" {
+		return string(body)
+	}
+	return parsed
+}
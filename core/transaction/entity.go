@@ -128,6 +128,7 @@ const (
 	STORAGESC_SHUTDOWN_VALIDATOR        = "shutdown_validator"
 	STORAGESC_RESET_BLOBBER_STATS       = "reset_blobber_stats"
 	STORAGESC_RESET_ALLOCATION_STATS    = "reset_allocation_stats"
+	STORAGESC_SET_RETENTION_LOCK        = "set_retention_lock"
 
 	MINERSC_LOCK             = "addToDelegatePool"
 	MINERSC_UNLOCK           = "deleteFromDelegatePool"
@@ -142,6 +143,7 @@ const (
 	MINERSC_KILL_SHARDER     = "kill_sharder"
 
 	// Faucet SC
+	FAUCETSC_POUR            = "pour"
 	FAUCETSC_UPDATE_SETTINGS = "update-settings"
 
 	// ZCNSC smart contract
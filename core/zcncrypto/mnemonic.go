@@ -0,0 +1,108 @@
+package zcncrypto
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/0chain/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// maxWordSuggestions is the maximum number of words suggested for a word not in the BIP-39 word list.
+const maxWordSuggestions = 5
+
+// ErrMnemonicChecksum is returned by ValidateMnemonic when all the words of the mnemonic are valid
+// but its checksum isn't, e.g. two words are swapped or a word is replaced by another valid word.
+var ErrMnemonicChecksum = errors.New("invalid_mnemonic_checksum", "the mnemonic checksum is incorrect, check the words and their order")
+
+// MnemonicWordError is returned by ValidateMnemonic for a word of the mnemonic not in the BIP-39
+// word list, with the closest words of the list.
+type MnemonicWordError struct {
+	// Position is the position of the word in the mnemonic, from 1.
+	Position int    `json:"position"`
+	Word     string `json:"word"`
+	// Suggestions are the words of the list the word is the closest to, the closest first.
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+func (e *MnemonicWordError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("invalid mnemonic word %d: %q", e.Position, e.Word)
+	}
+	return fmt.Sprintf("invalid mnemonic word %d: %q, did you mean %s?", e.Position, e.Word, strings.Join(e.Suggestions, ", "))
+}
+
+// ValidateMnemonic checks a BIP-39 mnemonic, unlike IsMnemonicValid it tells what's wrong with it.
+// It returns a *MnemonicWordError for the first word not in the word list, ErrMnemonicChecksum if
+// the checksum is incorrect.
+//   - mnemonic: the mnemonic to check
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return errors.New("invalid_mnemonic", fmt.Sprintf("the mnemonic has %d words, it should have 12, 15, 18, 21 or 24", len(words)))
+	}
+	for i, word := range words {
+		if _, ok := bip39.GetWordIndex(word); !ok {
+			return &MnemonicWordError{Position: i + 1, Word: word, Suggestions: SuggestMnemonicWords(word)}
+		}
+	}
+	if _, err := bip39.EntropyFromMnemonic(strings.Join(words, " ")); err != nil {
+		if err == bip39.ErrChecksumIncorrect {
+			return ErrMnemonicChecksum
+		}
+		return errors.Wrap(err, "invalid_mnemonic")
+	}
+	return nil
+}
+
+// SuggestMnemonicWords returns the words of the BIP-39 word list close to a mistyped word, the
+// closest first: the words at an edit distance of at most 2, or sharing the first 4 letters which
+// identify the words of the list.
+//   - word: the mistyped word
+func SuggestMnemonicWords(word string) []string {
+	word = strings.ToLower(word)
+	type suggestion struct {
+		word     string
+		distance int
+	}
+	var suggestions []suggestion
+	for _, w := range bip39.GetWordList() {
+		d := editDistance(word, w)
+		if d <= 2 || (len(word) >= 4 && strings.HasPrefix(w, word[:4])) {
+			suggestions = append(suggestions, suggestion{word: w, distance: d})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	words := make([]string, 0, maxWordSuggestions)
+	for i := 0; i < len(suggestions) && i < maxWordSuggestions; i++ {
+		words = append(words, suggestions[i].word)
+	}
+	return words
+}
+
+// editDistance returns the Levenshtein distance of two ASCII words.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,36 @@
+package zcncrypto
+
+import (
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMnemonic(t *testing.T) {
+	const mnemonic = "silent tape impulse glimpse state craft sheriff embody bonus clay confirm column swift kingdom door stove mad switch chalk theory pause canoe insane struggle"
+	require.NoError(t, ValidateMnemonic(mnemonic))
+	require.NoError(t, ValidateMnemonic("  "+mnemonic+"\n"))
+
+	// a typo.
+	err := ValidateMnemonic("silent tape impluse" + mnemonic[len("silent tape impulse"):])
+	var werr *MnemonicWordError
+	require.True(t, errors.As(err, &werr))
+	require.Equal(t, 3, werr.Position)
+	require.Equal(t, "impluse", werr.Word)
+	require.Contains(t, werr.Suggestions, "impulse")
+
+	// swapped words.
+	err = ValidateMnemonic("tape silent" + mnemonic[len("silent tape"):])
+	require.Equal(t, ErrMnemonicChecksum, err)
+
+	// a missing word.
+	require.Error(t, ValidateMnemonic(mnemonic[len("silent "):]))
+}
+
+func TestSuggestMnemonicWords(t *testing.T) {
+	require.Equal(t, "abandon", SuggestMnemonicWords("abandn")[0])
+	require.Contains(t, SuggestMnemonicWords("struggel"), "struggle")
+	require.Empty(t, SuggestMnemonicWords("zzzzzzzz"))
+	require.LessOrEqual(t, len(SuggestMnemonicWords("ab")), maxWordSuggestions)
+}
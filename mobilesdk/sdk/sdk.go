@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/sys"
 	"github.com/pkg/errors"
 
@@ -319,6 +320,15 @@ func (s *StorageSDK) CancelAllocation(allocationID string) (string, error) {
 	return hash, err
 }
 
+// SetRetentionLock locks a file, a directory or the whole allocation until a date, they can't be updated, deleted, renamed or moved
+//   - allocationID: allocation ID
+//   - remotePath: the remote path to lock, empty to lock the whole allocation
+//   - until: the unix timestamp the lock expires at
+func (s *StorageSDK) SetRetentionLock(allocationID, remotePath string, until int64) (string, error) {
+	hash, _, err := sdk.SetRetentionLock(allocationID, remotePath, common.Timestamp(until))
+	return hash, err
+}
+
 // GetReadPoolInfo is to get information about the read pool for the allocation
 //   - clientID: client ID
 func (s *StorageSDK) GetReadPoolInfo(clientID string) (string, error) {
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"errors"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+// ErrInvalidMnemonic is returned when recovering a wallet from an invalid mnemonic.
+var ErrInvalidMnemonic = errors.New("invalid_mnemonic")
+
+// CreateWallet creates a new wallet offline, return json string of wallet, see SplitKeys for its format.
+// The wallet is registered on the blockchain by its first transaction.
+//   - signatureScheme: signature scheme of the wallet, "bls0chain" or "ed25519"
+func CreateWallet(signatureScheme string) (string, error) {
+	signScheme, err := newSignatureScheme(signatureScheme)
+	if err != nil {
+		return "", err
+	}
+	wallet, err := signScheme.GenerateKeys()
+	if err != nil {
+		return "", err
+	}
+	return wallet.Marshal()
+}
+
+// RecoverWallet recovers a wallet from its mnemonic, return json string of wallet.
+//   - mnemonic: mnemonic of the wallet
+//   - signatureScheme: signature scheme of the wallet, "bls0chain" or "ed25519"
+func RecoverWallet(mnemonic, signatureScheme string) (string, error) {
+	if !zcncrypto.IsMnemonicValid(mnemonic) {
+		return "", ErrInvalidMnemonic
+	}
+	signScheme, err := newSignatureScheme(signatureScheme)
+	if err != nil {
+		return "", err
+	}
+	wallet, err := signScheme.RecoverKeys(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	return wallet.Marshal()
+}
+
+// IsMnemonicValid checks if the mnemonic is valid
+//   - mnemonic: mnemonic to check
+func IsMnemonicValid(mnemonic string) bool {
+	return zcncrypto.IsMnemonicValid(mnemonic)
+}
+
+func newSignatureScheme(signatureScheme string) (zcncrypto.SignatureScheme, error) {
+	switch signatureScheme {
+	case "bls0chain", "ed25519":
+		return zcncrypto.NewSignatureScheme(signatureScheme), nil
+	default:
+		return nil, ErrInvalidSignatureScheme
+	}
+}
@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWallet(t *testing.T) {
+	walletJSON, err := CreateWallet(signatureScheme)
+	require.NoError(t, err)
+
+	var wallet zcncrypto.Wallet
+	require.NoError(t, json.Unmarshal([]byte(walletJSON), &wallet))
+	require.NotEmpty(t, wallet.ClientID)
+	require.True(t, IsMnemonicValid(wallet.Mnemonic))
+
+	recoveredJSON, err := RecoverWallet(wallet.Mnemonic, signatureScheme)
+	require.NoError(t, err)
+	var recovered zcncrypto.Wallet
+	require.NoError(t, json.Unmarshal([]byte(recoveredJSON), &recovered))
+	require.Equal(t, wallet.ClientID, recovered.ClientID)
+	require.Equal(t, wallet.ClientKey, recovered.ClientKey)
+
+	_, err = RecoverWallet("invalid mnemonic", signatureScheme)
+	require.ErrorIs(t, err, ErrInvalidMnemonic)
+
+	_, err = CreateWallet("unknown")
+	require.ErrorIs(t, err, ErrInvalidSignatureScheme)
+}
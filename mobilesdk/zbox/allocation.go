@@ -96,6 +96,28 @@ func (a *Allocation) GetFileMeta(path string) (string, error) {
 	return string(retBytes), nil
 }
 
+// GetFileMetaBatch - getting file meta details of many files as a json object by path, the paths not found are omitted
+//   - pathsJson: json array of the remote paths
+func (a *Allocation) GetFileMetaBatch(pathsJson string) (string, error) {
+	if a == nil || a.sdkAllocation == nil {
+		return "", ErrInvalidAllocation
+	}
+	var paths []string
+	if err := json.Unmarshal([]byte(pathsJson), &paths); err != nil {
+		return "", err
+	}
+	fileMetas, err := a.sdkAllocation.GetFileMetaBatch(paths)
+	var batchErr *sdk.FileMetaBatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return "", err
+	}
+	retBytes, err := json.Marshal(fileMetas)
+	if err != nil {
+		return "", err
+	}
+	return string(retBytes), nil
+}
+
 // GetFileMetaFromAuthTicket - getting file meta details from file path and auth ticket
 func (a *Allocation) GetFileMetaFromAuthTicket(authTicket string, lookupHash string) (string, error) {
 	if a == nil || a.sdkAllocation == nil {
@@ -119,7 +141,7 @@ func (a *Allocation) DownloadFile(remotePath, localPath string, statusCb StatusC
 	if a == nil || a.sdkAllocation == nil {
 		return ErrInvalidAllocation
 	}
-	return a.sdkAllocation.DownloadFile(localPath, remotePath, false, &StatusCallbackWrapped{Callback: statusCb}, isFinal)
+	return startDownload(a.sdkAllocation, remotePath, localPath, statusCb, isFinal)
 }
 
 // DownloadFileByBlock - start download file from remote path to localpath by blocks number
@@ -169,7 +191,7 @@ func (a *Allocation) UploadFile(workdir, localPath, remotePath, thumbnailPath st
 	if a == nil || a.sdkAllocation == nil {
 		return ErrInvalidAllocation
 	}
-	return a.sdkAllocation.StartChunkedUpload(workdir, localPath, remotePath, &StatusCallbackWrapped{Callback: statusCb}, false, false, thumbnailPath, encrypt, webStreaming)
+	return startUpload(a.sdkAllocation, workdir, localPath, remotePath, thumbnailPath, encrypt, webStreaming, false, statusCb)
 }
 
 // UploadFile - update file/thumbnail from local path to remote path
@@ -185,7 +207,7 @@ func (a *Allocation) UpdateFile(workdir, localPath, remotePath, thumbnailPath st
 		return ErrInvalidAllocation
 	}
 
-	return a.sdkAllocation.StartChunkedUpload(workdir, localPath, remotePath, &StatusCallbackWrapped{Callback: statusCb}, true, false, thumbnailPath, encrypt, webStreaming)
+	return startUpload(a.sdkAllocation, workdir, localPath, remotePath, thumbnailPath, encrypt, webStreaming, true, statusCb)
 }
 
 // DeleteFile - delete file from remote path
@@ -203,6 +225,16 @@ func (a *Allocation) DeleteFile(remotePath string) error {
 	})
 }
 
+// DeleteDir - delete a directory with all its files and subdirectories, the progress is reported to the callback
+//   - remotePath: the remote path of the directory
+//   - statusCb: callback of status
+func (a *Allocation) DeleteDir(remotePath string, statusCb StatusCallbackMocked) error {
+	if a == nil || a.sdkAllocation == nil {
+		return ErrInvalidAllocation
+	}
+	return a.sdkAllocation.DeleteDir(remotePath, &StatusCallbackWrapped{Callback: statusCb})
+}
+
 // RenameObject - rename or move file
 func (a *Allocation) RenameObject(remotePath string, destName string) error {
 	if a == nil || a.sdkAllocation == nil {
@@ -217,6 +249,27 @@ func (a *Allocation) RenameObject(remotePath string, destName string) error {
 	})
 }
 
+// GetUsage - get the usage of the allocation as json, see sdk.AllocationUsage
+//   - refresh: compute the sizes of the directories again instead of using the cached ones
+func (a *Allocation) GetUsage(refresh bool) (string, error) {
+	if a == nil || a.sdkAllocation == nil {
+		return "", ErrInvalidAllocation
+	}
+	var opts []sdk.UsageOption
+	if refresh {
+		opts = append(opts, sdk.WithUsageRefresh())
+	}
+	usage, err := a.sdkAllocation.GetUsage(opts...)
+	if err != nil {
+		return "", err
+	}
+	retBytes, err := json.Marshal(usage)
+	if err != nil {
+		return "", err
+	}
+	return string(retBytes), nil
+}
+
 // GetStatistics - get allocation stats
 func (a *Allocation) GetAllocationStats() (string, error) {
 	if a == nil || a.sdkAllocation == nil {
@@ -280,7 +333,7 @@ func (a *Allocation) DownloadFromAuthTicket(localPath string, authTicket string,
 	if a == nil || a.sdkAllocation == nil {
 		return ErrInvalidAllocation
 	}
-	return a.sdkAllocation.DownloadFromAuthTicket(localPath, authTicket, remoteLookupHash, remoteFilename, false, &StatusCallbackWrapped{Callback: status}, isFinal)
+	return startDownloadFromAuthTicket(a.sdkAllocation, localPath, authTicket, remoteLookupHash, remoteFilename, status, isFinal)
 }
 
 // DownloadFromAuthTicketByBlocks - download file from Auth ticket by blocks number
@@ -411,6 +464,34 @@ func (a *Allocation) MoveObject(path string, destPath string) error {
 	})
 }
 
+// DryRun - get the impact of a delete, rename, copy or move as json without running it, see sdk.ImpactReport
+//   - operationType: the operation, delete, rename, copy or move
+//   - remotePath: the remote path of the object
+//   - dest: the new name of a rename, the destination path of a copy or move
+func (a *Allocation) DryRun(operationType, remotePath, dest string) (string, error) {
+	if a == nil || a.sdkAllocation == nil {
+		return "", ErrInvalidAllocation
+	}
+	op := sdk.OperationRequest{
+		OperationType: operationType,
+		RemotePath:    remotePath,
+		DestPath:      dest,
+	}
+	if operationType == constants.FileOperationRename {
+		op.DestName = dest
+		op.DestPath = ""
+	}
+	report, err := a.sdkAllocation.DryRun(op)
+	if err != nil {
+		return "", err
+	}
+	retBytes, err := json.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	return string(retBytes), nil
+}
+
 // GetMinWriteRead - getting back cost for allocation
 func (a *Allocation) GetMinWriteRead() (string, error) {
 	if a == nil || a.sdkAllocation == nil {
@@ -457,6 +538,19 @@ func (a *Allocation) GetMinStorageCost(size int64) (string, error) {
 	return fmt.Sprintf("%f", float64(cost)), err
 }
 
+// EstimateUploadCost - getting back the cost in ZCN of uploading a file of the given size
+func (a *Allocation) EstimateUploadCost(size int64) (string, error) {
+	if a == nil || a.sdkAllocation == nil {
+		return "", ErrInvalidAllocation
+	}
+	cost, err := a.sdkAllocation.EstimateUploadCost(size)
+	if err != nil {
+		return "", err
+	}
+	tokens, err := cost.ToToken()
+	return fmt.Sprintf("%f", tokens), err
+}
+
 // GetMaxStorageCostWithBlobbers - getting cost for listed blobbers
 func (a *Allocation) GetMaxStorageCostWithBlobbers(size int64, blobbersJson string) (string, error) {
 	if a == nil || a.sdkAllocation == nil {
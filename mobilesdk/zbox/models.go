@@ -15,21 +15,35 @@ type StatusCallbackMocked interface {
 
 type StatusCallbackWrapped struct {
 	Callback StatusCallbackMocked
+	// journal records the transfer until it's completed, see SetTransferJournal
+	journal *journaledTransfer
 }
 
 func (c *StatusCallbackWrapped) Started(allocationId, filePath string, op int, totalBytes int) {
+	if c.journal != nil {
+		c.journal.started(totalBytes)
+	}
 	c.Callback.Started(allocationId, filePath, op, totalBytes)
 }
 
 func (c *StatusCallbackWrapped) InProgress(allocationId, filePath string, op int, completedBytes int, data []byte) {
+	if c.journal != nil {
+		c.journal.progress(completedBytes)
+	}
 	c.Callback.InProgress(allocationId, filePath, op, completedBytes, data)
 }
 
 func (c *StatusCallbackWrapped) Error(allocationID string, filePath string, op int, err error) {
+	if c.journal != nil {
+		c.journal.failed()
+	}
 	c.Callback.Error(allocationID, filePath, op, err)
 }
 
 func (c *StatusCallbackWrapped) Completed(allocationId, filePath string, filename string, mimetype string, size int, op int) {
+	if c.journal != nil {
+		c.journal.completed()
+	}
 	c.Callback.Completed(allocationId, filePath, filename, mimetype, size, op)
 }
 
@@ -213,7 +213,7 @@ func DownloadFile(allocationID, remotePath, localPath string, statusCb StatusCal
 	if err != nil {
 		return err
 	}
-	return a.DownloadFile(localPath, remotePath, false, &StatusCallbackWrapped{Callback: statusCb}, isFinal)
+	return startDownload(a, remotePath, localPath, statusCb, isFinal)
 }
 
 // DownloadFileByBlock - start download file from remote path to localpath by blocks number
@@ -419,7 +419,7 @@ func UploadFile(allocationID, workdir, localPath, remotePath, thumbnailPath stri
 	if err != nil {
 		return err
 	}
-	return a.StartChunkedUpload(workdir, localPath, remotePath, &StatusCallbackWrapped{Callback: statusCb}, false, false, thumbnailPath, encrypt, webStreaming)
+	return startUpload(a, workdir, localPath, remotePath, thumbnailPath, encrypt, webStreaming, false, statusCb)
 }
 
 // UploadFile - update file/thumbnail from local path to remote path
@@ -440,7 +440,7 @@ func UpdateFile(allocationID, workdir, localPath, remotePath, thumbnailPath stri
 		return err
 	}
 
-	return a.StartChunkedUpload(workdir, localPath, remotePath, &StatusCallbackWrapped{Callback: statusCb}, true, false, thumbnailPath, encrypt, webStreaming)
+	return startUpload(a, workdir, localPath, remotePath, thumbnailPath, encrypt, webStreaming, true, statusCb)
 }
 
 // DeleteFile - delete file from remote path
@@ -582,7 +582,7 @@ func DownloadFromAuthTicket(allocationID, localPath string, authTicket string, r
 		remoteFilename = fileMeta.Name
 	}
 
-	return a.DownloadFromAuthTicket(localPath, authTicket, remoteLookupHash, remoteFilename, false, &StatusCallbackWrapped{Callback: status}, isFinal)
+	return startDownloadFromAuthTicket(a, localPath, authTicket, remoteLookupHash, remoteFilename, status, isFinal)
 }
 
 // DownloadFromAuthTicketByBlocks - download file from Auth ticket by blocks number
@@ -0,0 +1,295 @@
+package zbox
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+)
+
+// TransferJournalStore stores the records of the in-progress transfers, e.g. in a SQLite table of
+// the app. The records are json strings.
+type TransferJournalStore interface {
+	// Put creates or replaces the record of a transfer.
+	Put(id string, record string) error
+	// Delete removes the record of a transfer.
+	Delete(id string) error
+	// List returns the json array of the records.
+	List() (string, error)
+}
+
+// journalSaveInterval is the interval the offsets of the transfers in progress are saved at.
+const journalSaveInterval = 2 * time.Second
+
+var (
+	journalMu      sync.RWMutex
+	journal        sdk.TransferJournal
+	journalWorkdir string
+)
+
+// SetTransferJournal records the in-progress uploads and downloads, so ResumeTransfers can restart
+// them on the next launch if the app has been killed by the OS. The chunks already transferred are
+// not transferred again.
+//   - workdir: set a workdir as ~/.zcn on mobile apps, the progress of the downloads is saved in it
+//   - store: the store of the records, they are saved in workdir/transfers if nil
+func SetTransferJournal(workdir string, store TransferJournalStore) error {
+	var j sdk.TransferJournal
+	if store != nil {
+		j = &storeJournal{store: store}
+	} else {
+		fsj, err := sdk.NewFsTransferJournal(filepath.Join(workdir, "transfers"))
+		if err != nil {
+			return err
+		}
+		j = fsj
+	}
+
+	journalMu.Lock()
+	defer journalMu.Unlock()
+	journal = j
+	journalWorkdir = workdir
+	return nil
+}
+
+// DisableTransferJournal stops recording the transfers.
+func DisableTransferJournal() {
+	journalMu.Lock()
+	defer journalMu.Unlock()
+	journal = nil
+	journalWorkdir = ""
+}
+
+func getTransferJournal() (sdk.TransferJournal, string) {
+	journalMu.RLock()
+	defer journalMu.RUnlock()
+	return journal, journalWorkdir
+}
+
+// ListTransfers returns the json array of the transfers not completed, see sdk.TransferRecord.
+func ListTransfers() (string, error) {
+	j, _ := getTransferJournal()
+	if j == nil {
+		return "[]", nil
+	}
+	records, err := j.List()
+	if err != nil {
+		return "", err
+	}
+	if records == nil {
+		records = []sdk.TransferRecord{}
+	}
+	buf, err := json.Marshal(records)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// RemoveTransfer removes a transfer from the journal, it won't be resumed.
+//   - id: the id of the transfer
+func RemoveTransfer(id string) error {
+	j, _ := getTransferJournal()
+	if j == nil {
+		return nil
+	}
+	return j.Remove(id)
+}
+
+// ResumeTransfers restarts the transfers not completed, usually on the launch of the app. The
+// transfers run in the background, their status is reported to the callback.
+//   - statusCb: callback of status
+//
+// ## Outputs
+//   - the number of transfers resumed
+//   - error
+func ResumeTransfers(statusCb StatusCallbackMocked) (int, error) {
+	j, _ := getTransferJournal()
+	if j == nil {
+		return 0, errors.New("transfer journal is not set")
+	}
+	records, err := j.List()
+	if err != nil {
+		return 0, err
+	}
+
+	go func() {
+		for _, rec := range records {
+			if err := resumeTransfer(rec, statusCb); err != nil {
+				logger.Logger.Error("resume transfer ", rec.LocalPath, ": ", err)
+				statusCb.Error(rec.AllocationID, rec.RemotePath, 0, err)
+			}
+		}
+	}()
+	return len(records), nil
+}
+
+func resumeTransfer(rec sdk.TransferRecord, statusCb StatusCallbackMocked) error {
+	if rec.AuthTicket != "" {
+		a, err := sdk.GetAllocationFromAuthTicket(rec.AuthTicket)
+		if err != nil {
+			return err
+		}
+		return startDownloadFromAuthTicket(a, rec.LocalPath, rec.AuthTicket, rec.LookupHash, rec.RemoteFileName, statusCb, true)
+	}
+
+	a, err := getAllocation(rec.AllocationID)
+	if err != nil {
+		return err
+	}
+	switch rec.Direction {
+	case sdk.TransferUpload:
+		return startUpload(a, rec.Workdir, rec.LocalPath, rec.RemotePath, rec.ThumbnailPath, rec.Encrypt, rec.WebStreaming, rec.IsUpdate, statusCb)
+	case sdk.TransferDownload:
+		return startDownload(a, rec.RemotePath, rec.LocalPath, statusCb, true)
+	default:
+		return errors.New("unknown transfer direction: " + rec.Direction)
+	}
+}
+
+func startUpload(a *sdk.Allocation, workdir, localPath, remotePath, thumbnailPath string, encrypt, webStreaming, isUpdate bool, statusCb StatusCallbackMocked) error {
+	cb := newJournaledCallback(statusCb, sdk.TransferRecord{
+		ID:            sdk.TransferID(sdk.TransferUpload, a.ID, remotePath, localPath),
+		Direction:     sdk.TransferUpload,
+		AllocationID:  a.ID,
+		RemotePath:    remotePath,
+		LocalPath:     localPath,
+		Workdir:       workdir,
+		ThumbnailPath: thumbnailPath,
+		Encrypt:       encrypt,
+		WebStreaming:  webStreaming,
+		IsUpdate:      isUpdate,
+	})
+	return a.StartChunkedUpload(workdir, localPath, remotePath, cb, isUpdate, false, thumbnailPath, encrypt, webStreaming)
+}
+
+func startDownload(a *sdk.Allocation, remotePath, localPath string, statusCb StatusCallbackMocked, isFinal bool) error {
+	cb := newJournaledCallback(statusCb, sdk.TransferRecord{
+		ID:           sdk.TransferID(sdk.TransferDownload, a.ID, remotePath, localPath),
+		Direction:    sdk.TransferDownload,
+		AllocationID: a.ID,
+		RemotePath:   remotePath,
+		LocalPath:    localPath,
+	})
+	return a.DownloadFile(localPath, remotePath, false, cb, isFinal, downloadJournalOptions()...)
+}
+
+func startDownloadFromAuthTicket(a *sdk.Allocation, localPath, authTicket, remoteLookupHash, remoteFilename string, statusCb StatusCallbackMocked, isFinal bool) error {
+	cb := newJournaledCallback(statusCb, sdk.TransferRecord{
+		ID:             sdk.TransferID(sdk.TransferDownload, a.ID, remoteLookupHash, localPath),
+		Direction:      sdk.TransferDownload,
+		AllocationID:   a.ID,
+		LocalPath:      localPath,
+		AuthTicket:     authTicket,
+		LookupHash:     remoteLookupHash,
+		RemoteFileName: remoteFilename,
+	})
+	return a.DownloadFromAuthTicket(localPath, authTicket, remoteLookupHash, remoteFilename, false, cb, isFinal, downloadJournalOptions()...)
+}
+
+// downloadJournalOptions saves the progress of the downloads when the transfers are journaled, so
+// they resume from the last written block.
+func downloadJournalOptions() []sdk.DownloadRequestOption {
+	j, workdir := getTransferJournal()
+	if j == nil {
+		return nil
+	}
+	return []sdk.DownloadRequestOption{
+		sdk.WithDownloadProgressStorer(sdk.CreateFsDownloadProgress()),
+		sdk.WithWorkDir(workdir),
+	}
+}
+
+// newJournaledCallback records the transfer in the journal until it's completed, if the journal is set.
+func newJournaledCallback(statusCb StatusCallbackMocked, rec sdk.TransferRecord) *StatusCallbackWrapped {
+	cb := &StatusCallbackWrapped{Callback: statusCb}
+	j, _ := getTransferJournal()
+	if j == nil {
+		return cb
+	}
+	if err := j.Save(rec); err != nil {
+		logger.Logger.Error("save transfer record: ", err)
+		return cb
+	}
+	cb.journal = &journaledTransfer{journal: j, record: rec, lastSaved: time.Now()}
+	return cb
+}
+
+// journaledTransfer keeps the record of a transfer up to date.
+type journaledTransfer struct {
+	mu        sync.Mutex
+	journal   sdk.TransferJournal
+	record    sdk.TransferRecord
+	lastSaved time.Time
+}
+
+func (t *journaledTransfer) started(totalBytes int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.record.TotalBytes = int64(totalBytes)
+	t.save()
+}
+
+func (t *journaledTransfer) progress(completedBytes int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.record.Offset = int64(completedBytes)
+	if time.Since(t.lastSaved) >= journalSaveInterval {
+		t.save()
+	}
+}
+
+func (t *journaledTransfer) failed() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// the record is kept so the transfer can be resumed
+	t.save()
+}
+
+func (t *journaledTransfer) completed() {
+	if err := t.journal.Remove(t.record.ID); err != nil {
+		logger.Logger.Error("remove transfer record: ", err)
+	}
+}
+
+func (t *journaledTransfer) save() {
+	t.lastSaved = time.Now()
+	if err := t.journal.Save(t.record); err != nil {
+		logger.Logger.Error("save transfer record: ", err)
+	}
+}
+
+// storeJournal is a sdk.TransferJournal backed by a TransferJournalStore of the app.
+type storeJournal struct {
+	store TransferJournalStore
+}
+
+func (j *storeJournal) Save(rec sdk.TransferRecord) error {
+	buf, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return j.store.Put(rec.ID, string(buf))
+}
+
+func (j *storeJournal) Remove(id string) error {
+	return j.store.Delete(id)
+}
+
+func (j *storeJournal) List() ([]sdk.TransferRecord, error) {
+	list, err := j.store.List()
+	if err != nil {
+		return nil, err
+	}
+	if list == "" {
+		return nil, nil
+	}
+	var records []sdk.TransferRecord
+	if err = json.Unmarshal([]byte(list), &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,48 @@
+package zbox
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+type nopStatusCallback struct{}
+
+func (nopStatusCallback) Started(allocationId, filePath string, op int, totalBytes int) {}
+func (nopStatusCallback) InProgress(allocationId, filePath string, op int, completedBytes int, data []byte) {
+}
+func (nopStatusCallback) Error(allocationID string, filePath string, op int, err error) {}
+func (nopStatusCallback) Completed(allocationId, filePath string, filename string, mimetype string, size int, op int) {
+}
+func (nopStatusCallback) CommitMetaCompleted(request, response string, err error) {}
+func (nopStatusCallback) RepairCompleted(filesRepaired int)                       {}
+
+var _ sdk.StatusCallback = &StatusCallbackWrapped{}
+
+func TestTransferJournal(t *testing.T) {
+	list, err := ListTransfers()
+	require.NoError(t, err)
+	require.Equal(t, "[]", list)
+
+	require.NoError(t, SetTransferJournal(t.TempDir(), nil))
+	defer DisableTransferJournal()
+
+	rec := sdk.TransferRecord{ID: "t1", Direction: sdk.TransferUpload, AllocationID: "alloc", RemotePath: "/a.txt", LocalPath: "/tmp/a.txt"}
+	cb := newJournaledCallback(nopStatusCallback{}, rec)
+	cb.Started("alloc", "/a.txt", 0, 100)
+	cb.InProgress("alloc", "/a.txt", 0, 50, nil)
+	cb.Error("alloc", "/a.txt", 0, nil)
+
+	j, _ := getTransferJournal()
+	records, err := j.List()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, int64(100), records[0].TotalBytes)
+	require.Equal(t, int64(50), records[0].Offset, "the record is saved on errors so the transfer can be resumed")
+
+	cb.Completed("alloc", "/a.txt", "a.txt", "text/plain", 100, 0)
+	list, err = ListTransfers()
+	require.NoError(t, err)
+	require.Equal(t, "[]", list)
+}
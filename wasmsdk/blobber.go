@@ -1012,6 +1012,10 @@ func downloadBlocks(allocId string, remotePath, authTicket, lookupHash string, s
 		return nil, err
 	}
 
+	return downloadBlocksWithAllocation(alloc, remotePath, authTicket, lookupHash, startBlock, endBlock)
+}
+
+func downloadBlocksWithAllocation(alloc *sdk.Allocation, remotePath, authTicket, lookupHash string, startBlock, endBlock int64) ([]byte, error) {
 	var (
 		wg        = &sync.WaitGroup{}
 		statusBar = &StatusBar{wg: wg, totalBytesMap: make(map[string]int)}
@@ -1050,6 +1054,74 @@ func downloadBlocks(allocId string, remotePath, authTicket, lookupHash string, s
 	return mf.Buffer, nil
 }
 
+const defaultBlobBlocksPerChunk = 100
+
+// downloadToBlob downloads a file chunk by chunk and returns it as a js Blob. The chunks are copied
+// to js as soon as they are downloaded, so the whole file is never held in the wasm memory, and each
+// chunk is passed to the callback so it can be streamed, e.g. to a service worker.
+//   - allocationID : allocation ID of the file
+//   - remotePath : remote path of the file
+//   - authTicket : auth ticket of the file, if the file is shared
+//   - lookupHash : lookup hash of the file, used with the auth ticket
+//   - mimeType : type of the Blob, the mime type of the file if empty
+//   - blocksPerChunk : number of 64KB blocks downloaded per chunk, defaults to 100
+//   - callbackFuncName : name of the global js function called with (completedBytes, totalBytes, chunk) after each chunk, chunk is a Uint8Array backed by its own transferable ArrayBuffer
+func downloadToBlob(allocationID, remotePath, authTicket, lookupHash, mimeType string, blocksPerChunk int, callbackFuncName string) (js.Value, error) {
+	if len(remotePath) == 0 && len(authTicket) == 0 {
+		return js.Null(), RequiredArg("remotePath/authTicket")
+	}
+
+	alloc, err := getAllocation(allocationID)
+	if err != nil {
+		PrintError("Error fetching the allocation", err)
+		return js.Null(), err
+	}
+
+	var meta *sdk.ConsolidatedFileMeta
+	if authTicket != "" {
+		meta, err = alloc.GetFileMetaFromAuthTicket(authTicket, lookupHash)
+	} else {
+		meta, err = alloc.GetFileMeta(remotePath)
+	}
+	if err != nil {
+		return js.Null(), err
+	}
+	if mimeType == "" {
+		mimeType = meta.MimeType
+	}
+	if blocksPerChunk <= 0 {
+		blocksPerChunk = defaultBlobBlocksPerChunk
+	}
+
+	var callback js.Value
+	if callbackFuncName != "" {
+		callback = js.Global().Get(callbackFuncName)
+	}
+
+	parts := js.Global().Get("Array").New()
+	completed := 0
+	for start := int64(1); start <= meta.NumBlocks; start += int64(blocksPerChunk) {
+		end := start + int64(blocksPerChunk) - 1
+		if end > meta.NumBlocks {
+			end = meta.NumBlocks
+		}
+		buf, err := downloadBlocksWithAllocation(alloc, remotePath, authTicket, lookupHash, start, end)
+		if err != nil {
+			return js.Null(), err
+		}
+		chunk := jsbridge.NewBytes(buf)
+		parts.Call("push", chunk)
+		completed += len(buf)
+		if callbackFuncName != "" {
+			callback.Invoke(completed, meta.ActualFileSize, chunk)
+		}
+	}
+
+	options := js.Global().Get("Object").New()
+	options.Set("type", mimeType)
+	return js.Global().Get("Blob").New(parts, options), nil
+}
+
 // getBlobbers get list of active blobbers, and format them as array json string
 //   - stakable : flag to get only stakable blobbers
 func getBlobbers(stakable bool) ([]*sdk.Blobber, error) {
@@ -0,0 +1,337 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall/js"
+
+	"github.com/0chain/gosdk/wasmsdk/jsbridge"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/hack-pad/go-webworkers/worker"
+	"github.com/hack-pad/safejs"
+)
+
+// computeWorker is a web worker running the sdk in compute mode.
+type computeWorker interface {
+	PostMessage(data safejs.Value, transfers []safejs.Value) error
+}
+
+// computeWorkerPool is a sdk.FragmentEncoder posting the chunks of the uploads to web workers,
+// so the erasure coding and the encryption don't block the main thread.
+type computeWorkerPool struct {
+	workers []computeWorker
+	// terminate stops the workers spawned by the sdk
+	terminate func()
+
+	next   uint32
+	lastID uint64
+
+	mu      sync.Mutex
+	pending map[string]chan encodeResult
+}
+
+type encodeResult struct {
+	fragments [][]byte
+	err       error
+}
+
+var computePool *computeWorkerPool
+
+// enableComputeWorkers spawns web workers running the erasure coding and the encryption of the uploads.
+//   - count: the number of workers, the uploads are encoded in the main thread again if it's zero.
+func enableComputeWorkers(count int) error {
+	disableComputeWorkers()
+	if count <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &computeWorkerPool{pending: make(map[string]chan encodeResult)}
+	var spawned []*jsbridge.WasmWebWorker
+	pool.terminate = func() {
+		cancel()
+		for _, w := range spawned {
+			w.Terminate()
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		w, err := jsbridge.NewComputeWorker("compute-" + strconv.Itoa(i))
+		if err != nil {
+			pool.terminate()
+			return err
+		}
+		spawned = append(spawned, w)
+		events, err := w.Listen(ctx)
+		if err != nil {
+			pool.terminate()
+			return err
+		}
+		go func() {
+			for event := range events {
+				pool.handleEvent(event)
+			}
+		}()
+		pool.workers = append(pool.workers, w)
+	}
+
+	setComputePool(pool)
+	return nil
+}
+
+// setComputeWorkers uses web workers created by the host page to run the erasure coding and the
+// encryption of the uploads. The workers must run the sdk with MODE=compute.
+//   - workers: an array of Worker
+func setComputeWorkers(workers js.Value) error {
+	disableComputeWorkers()
+	n := workers.Length()
+	if n == 0 {
+		return nil
+	}
+
+	pool := &computeWorkerPool{pending: make(map[string]chan encodeResult)}
+	var listeners []js.Func
+	pool.terminate = func() {
+		for i, l := range listeners {
+			workers.Index(i).Call("removeEventListener", "message", l)
+			l.Release()
+		}
+	}
+	for i := 0; i < n; i++ {
+		w := workers.Index(i)
+		listener := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			data := safejs.Safe(args[0].Get("data"))
+			go pool.handleData(&data)
+			return nil
+		})
+		w.Call("addEventListener", "message", listener)
+		listeners = append(listeners, listener)
+		pool.workers = append(pool.workers, hostWorker(w))
+	}
+
+	setComputePool(pool)
+	return nil
+}
+
+// disableComputeWorkers stops the compute workers, the uploads are encoded in the main thread again.
+func disableComputeWorkers() {
+	if computePool != nil {
+		computePool.terminate()
+		computePool.failPending(errors.New("compute workers disabled"))
+		computePool = nil
+	}
+	sdk.SetFragmentEncoder(nil)
+}
+
+func setComputePool(pool *computeWorkerPool) {
+	computePool = pool
+	sdk.SetFragmentEncoder(pool)
+}
+
+// EncodeFragments posts the chunk to the next worker and waits for its fragments.
+func (p *computeWorkerPool) EncodeFragments(req *sdk.EncodeFragmentsRequest) ([][]byte, error) {
+	reqJSON, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strconv.FormatUint(atomic.AddUint64(&p.lastID, 1), 10)
+	rspCh := make(chan encodeResult, 1)
+	p.mu.Lock()
+	p.pending[id] = rspCh
+	p.mu.Unlock()
+
+	obj := js.Global().Get("Object").New()
+	jsbridge.SetMsgType(&obj, jsbridge.MsgTypeEncode)
+	obj.Set("id", toUint8Array([]byte(id)))
+	obj.Set("req", toUint8Array(reqJSON))
+	data := toUint8Array(req.Data)
+	obj.Set("data", data)
+
+	w := p.workers[atomic.AddUint32(&p.next, 1)%uint32(len(p.workers))]
+	// the buffer of the chunk is transferred, not copied
+	if err = w.PostMessage(safejs.Safe(obj), []safejs.Value{safejs.Safe(data.Get("buffer"))}); err != nil {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return nil, err
+	}
+
+	rsp := <-rspCh
+	return rsp.fragments, rsp.err
+}
+
+func (p *computeWorkerPool) handleEvent(event worker.MessageEvent) {
+	data, err := event.Data()
+	if err != nil {
+		PrintError("compute worker:", err)
+		return
+	}
+	p.handleData(&data)
+}
+
+// handleData dispatches the response of a worker to the pending request.
+func (p *computeWorkerPool) handleData(data *safejs.Value) {
+	msgType, err := jsbridge.ParseEventDataField(data, "msgType")
+	if err != nil || msgType != jsbridge.MsgTypeEncodeRsp {
+		// e.g. the startListener message of the worker
+		return
+	}
+	id, err := jsbridge.ParseEventDataField(data, "id")
+	if err != nil {
+		PrintError("compute worker: missing id", err)
+		return
+	}
+
+	p.mu.Lock()
+	rspCh, ok := p.pending[id]
+	delete(p.pending, id)
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	var result encodeResult
+	if errMsg, _ := jsbridge.ParseEventDataField(data, "error"); errMsg != "" {
+		result.err = errors.New(errMsg)
+	} else {
+		result.fragments, result.err = parseFragments(data)
+	}
+	rspCh <- result
+}
+
+func (p *computeWorkerPool) failPending(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, ch := range p.pending {
+		ch <- encodeResult{err: err}
+		delete(p.pending, id)
+	}
+}
+
+func parseFragments(data *safejs.Value) ([][]byte, error) {
+	arr, err := data.Get("fragments")
+	if err != nil {
+		return nil, err
+	}
+	n, err := arr.Length()
+	if err != nil {
+		return nil, err
+	}
+	fragments := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		f, err := arr.Index(i)
+		if err != nil {
+			return nil, err
+		}
+		l, err := f.Length()
+		if err != nil {
+			return nil, err
+		}
+		fragments[i] = make([]byte, l)
+		safejs.CopyBytesToGo(fragments[i], f)
+	}
+	return fragments, nil
+}
+
+// processEncodeEvent encodes a chunk in a compute worker and posts the fragments back.
+func processEncodeEvent(self *worker.GlobalSelf, data *safejs.Value) {
+	id, err := jsbridge.ParseEventDataField(data, "id")
+	if err != nil {
+		PrintError("Error in parsing id from event", err)
+		return
+	}
+
+	obj := js.Global().Get("Object").New()
+	jsbridge.SetMsgType(&obj, jsbridge.MsgTypeEncodeRsp)
+	obj.Set("id", toUint8Array([]byte(id)))
+
+	var transfers []safejs.Value
+	fragments, err := encodeEventData(data)
+	if err != nil {
+		obj.Set("error", toUint8Array([]byte(err.Error())))
+	} else {
+		arr := js.Global().Get("Array").New(len(fragments))
+		for i, f := range fragments {
+			u := toUint8Array(f)
+			arr.SetIndex(i, u)
+			transfers = append(transfers, safejs.Safe(u.Get("buffer")))
+		}
+		obj.Set("fragments", arr)
+	}
+
+	if err = self.PostMessage(safejs.Safe(obj), transfers); err != nil {
+		PrintError("Error in posting encoded fragments", err)
+	}
+}
+
+func encodeEventData(data *safejs.Value) ([][]byte, error) {
+	reqJSON, err := jsbridge.ParseEventDataField(data, "req")
+	if err != nil {
+		return nil, err
+	}
+	req := &sdk.EncodeFragmentsRequest{}
+	if err = json.Unmarshal([]byte(reqJSON), req); err != nil {
+		return nil, fmt.Errorf("invalid encode request: %v", err)
+	}
+	chunk, err := jsbridge.ParseEventDataField(data, "data")
+	if err != nil {
+		return nil, err
+	}
+	req.Data = []byte(chunk)
+	return sdk.EncodeFragments(req)
+}
+
+// startComputeListener runs the compute worker, it encodes the chunks posted by the main thread.
+func startComputeListener() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	self, err := jsbridge.NewSelfWorker()
+	if err != nil {
+		return err
+	}
+	listener, err := self.Listen(ctx)
+	if err != nil {
+		return err
+	}
+	for event := range listener {
+		msgType, data, err := jsbridge.GetMsgType(event)
+		if err != nil {
+			PrintError("Error in getting data from event", err)
+			continue
+		}
+		if msgType != jsbridge.MsgTypeEncode {
+			PrintError("Unknown message type", msgType)
+			continue
+		}
+		go processEncodeEvent(self, data)
+	}
+	return nil
+}
+
+// hostWorker is a Worker created by the host page.
+type hostWorker js.Value
+
+func (w hostWorker) PostMessage(data safejs.Value, transfers []safejs.Value) error {
+	arr := js.Global().Get("Array").New(len(transfers))
+	for i, t := range transfers {
+		arr.SetIndex(i, safejs.Unsafe(t))
+	}
+	js.Value(w).Call("postMessage", safejs.Unsafe(data), arr)
+	return nil
+}
+
+func toUint8Array(b []byte) js.Value {
+	u := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(u, b)
+	return u
+}
@@ -0,0 +1,87 @@
+// Service worker streaming the downloads of the sdk to the download manager of the browser.
+//
+// The page transfers one end of a MessageChannel with an id, navigates to /zcn-download/<id>
+// and passes the other end to the sdk:
+//
+//   const { port1, port2 } = new MessageChannel();
+//   navigator.serviceWorker.controller.postMessage({ type: 'zcn-download', id }, [port2]);
+//   const iframe = document.createElement('iframe');
+//   iframe.hidden = true;
+//   iframe.src = '/zcn-download/' + id;
+//   document.body.appendChild(iframe);
+//   await goWasm.sdk.streamDownload(allocationId, remotePath, '', '', 0, port1);
+
+const downloads = new Map();
+
+self.addEventListener('install', () => self.skipWaiting());
+self.addEventListener('activate', (e) => e.waitUntil(self.clients.claim()));
+
+self.addEventListener('message', (e) => {
+  if (!e.data || e.data.type !== 'zcn-download') {
+    return;
+  }
+  const port = e.ports[0];
+  let controller;
+  const stream = new ReadableStream({
+    start(c) {
+      controller = c;
+    },
+    // the sdk downloads the next chunk only when the stream pulls it
+    pull() {
+      port.postMessage({ type: 'pull' });
+    },
+    cancel() {
+      port.postMessage({ type: 'cancel' });
+    },
+  }, { highWaterMark: 1 });
+
+  let resolveMeta, rejectMeta;
+  const meta = new Promise((resolve, reject) => {
+    resolveMeta = resolve;
+    rejectMeta = reject;
+  });
+
+  port.onmessage = (msg) => {
+    const data = msg.data;
+    switch (data.type) {
+      case 'meta':
+        resolveMeta(data);
+        break;
+      case 'chunk':
+        controller.enqueue(data.data);
+        break;
+      case 'end':
+        controller.close();
+        break;
+      case 'error':
+        rejectMeta(new Error(data.message));
+        controller.error(new Error(data.message));
+        break;
+    }
+  };
+
+  downloads.set(e.data.id, { stream, meta });
+});
+
+self.addEventListener('fetch', (e) => {
+  const url = new URL(e.request.url);
+  const prefix = '/zcn-download/';
+  if (!url.pathname.startsWith(prefix)) {
+    return;
+  }
+  const id = url.pathname.substring(prefix.length);
+  const download = downloads.get(id);
+  if (!download) {
+    e.respondWith(new Response('download not found', { status: 404 }));
+    return;
+  }
+  downloads.delete(id);
+
+  e.respondWith(download.meta.then((meta) => new Response(download.stream, {
+    headers: {
+      'Content-Type': meta.mimeType || 'application/octet-stream',
+      'Content-Length': String(meta.size),
+      'Content-Disposition': "attachment; filename*=UTF-8''" + encodeURIComponent(meta.name),
+    },
+  }), (err) => new Response(err.message, { status: 500 })));
+});
@@ -84,6 +84,8 @@ func (b *InputBuilder) Build() (InputBinder, error) {
 			b.binders[i] = jsValueToStringSlice
 		case *[]byte:
 			b.binders[i] = jsValueToBytes
+		case *js.Value:
+			b.binders[i] = jsValueToJsValue
 		default:
 			fmt.Printf("TYPE: %#v\n", reflect.TypeOf(v))
 			return nil, ErrBinderNotImplemented
@@ -283,3 +285,8 @@ func jsValueToBytes(jv js.Value) (val reflect.Value, err error) {
 	val = reflect.ValueOf(buf)
 	return
 }
+
+// jsValueToJsValue passes the js value as is, e.g. an array of Worker.
+func jsValueToJsValue(jv js.Value) (reflect.Value, error) {
+	return reflect.ValueOf(jv), nil
+}
@@ -75,6 +75,10 @@ func (b *OutputBuilder) Build() (OutputBinder, error) {
 				return NewBytes(buf)
 
 			}
+		case TypeJsValue:
+			b.binders[i] = func(rv reflect.Value) js.Value {
+				return rv.Interface().(js.Value)
+			}
 		default:
 			b.binders[i] = func(rv reflect.Value) js.Value {
 
@@ -17,6 +17,8 @@ var (
 	TypeError  = "error"
 	TypeString = reflect.TypeOf("string").String()
 	TypeBytes  = reflect.TypeOf([]byte{}).String()
+	// TypeJsValue is returned to js as is, e.g. a Blob built on the js side.
+	TypeJsValue = reflect.TypeOf(js.Value{}).String()
 )
 
 func Close() {
@@ -21,8 +21,13 @@ const (
 	MsgTypeAuthRsp      = "auth_rsp"
 	MsgTypeUpload       = "upload"
 	MsgTypeUpdateWallet = "update_wallet"
+	MsgTypeEncode       = "encode"
+	MsgTypeEncodeRsp    = "encode_rsp"
 )
 
+// ComputeWorkerMode is the MODE of the workers running the erasure coding and the encryption of the uploads.
+const ComputeWorkerMode = "compute"
+
 type WasmWebWorker struct {
 	// Name specifies an identifying name for the DedicatedWorkerGlobalScope representing the scope of the worker, which is mainly useful for debugging purposes.
 	// If this is not specified, `Start` will create a UUIDv4 for it and populate back.
@@ -93,6 +98,22 @@ func NewWasmWebWorker(blobberID, blobberURL, clientID, clientKey, peerPublicKey,
 	return w, created, nil
 }
 
+// NewComputeWorker starts a worker running the sdk in compute mode. Unlike the blobber workers it has
+// no wallet and isn't registered in the workers of the blobbers.
+//   - name: the name of the worker, mainly useful for debugging
+func NewComputeWorker(name string) (*WasmWebWorker, error) {
+	w := &WasmWebWorker{
+		Name:        name,
+		Env:         []string{"MODE=" + ComputeWorkerMode},
+		Path:        "zcn.wasm",
+		subscribers: make(map[string]chan worker.MessageEvent),
+	}
+	if err := w.Start(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
 func GetWorker(blobberID string) *WasmWebWorker {
 	return workers[blobberID]
 }
@@ -219,16 +219,24 @@ func main() {
 
 				//blobber
 				"delete":                    Delete,
+				"rename":                    Rename,
+				"copy":                      Copy,
+				"move":                      Move,
 				"share":                     Share,
 				"multiDownload":             multiDownload,
 				"upload":                    upload,
 				"setUploadMode":             setUploadMode,
 				"multiUpload":               multiUpload,
+				"enableComputeWorkers":      enableComputeWorkers,
+				"setComputeWorkers":         setComputeWorkers,
+				"disableComputeWorkers":     disableComputeWorkers,
 				"multiOperation":            MultiOperation,
 				"listObjects":               listObjects,
 				"listObjectsFromAuthTicket": listObjectsFromAuthTicket,
 				"createDir":                 createDir,
 				"downloadBlocks":            downloadBlocks,
+				"streamDownload":            streamDownload,
+				"downloadToBlob":            downloadToBlob,
 				"getFileStats":              getFileStats,
 				"updateBlobberSettings":     updateBlobberSettings,
 				"getRemoteFileMap":          getRemoteFileMap,
@@ -349,6 +357,14 @@ func main() {
 		fmt.Println("zcn is not null - signWithAuth:", sys.SignWithAuth)
 	}
 
+	if mode == jsbridge.ComputeWorkerMode {
+		hideLogs()
+		if err := startComputeListener(); err != nil {
+			fmt.Println("Error starting compute listener", err)
+		}
+		return
+	}
+
 	if mode != "" {
 		respChan := make(chan string, 1)
 		jsProxy := window.Get("__zcn_worker_wasm__")
@@ -0,0 +1,126 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"errors"
+	"sync"
+	"syscall/js"
+
+	"github.com/0chain/gosdk/wasmsdk/jsbridge"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+)
+
+// streamDownload streams the decrypted bytes of a file to a MessagePort, usually transferred to a
+// service worker that answers a download request of the page with a ReadableStream fed by the port,
+// see demo/zcn-download-sw.js. The chunks are only downloaded when the stream pulls them, so the
+// file is never buffered in memory and files of several GB can be downloaded.
+//
+// The messages posted to the port:
+//   - {type: "meta", name, size, mimeType}: first message, the headers of the download
+//   - {type: "chunk", data}: a Uint8Array of the file, its buffer is transferred
+//   - {type: "end"}: the file is complete
+//   - {type: "error", message}: the download failed
+//
+// The port receives {type: "pull"} when the stream wants the next chunk and {type: "cancel"} when the
+// user cancels the download.
+//   - allocationID : allocation ID of the file
+//   - remotePath : remote path of the file
+//   - authTicket : auth ticket of the file, if the file is shared
+//   - lookupHash : lookup hash of the file, if the file is shared
+//   - blocksPerChunk : the number of blocks downloaded per chunk, 64KB each
+//   - port : the MessagePort to stream the file to
+func streamDownload(allocationID, remotePath, authTicket, lookupHash string, blocksPerChunk int, port js.Value) error {
+	if len(remotePath) == 0 && len(authTicket) == 0 {
+		return RequiredArg("remotePath/authTicket")
+	}
+	if port.IsUndefined() || port.IsNull() {
+		return RequiredArg("port")
+	}
+
+	pulls := make(chan struct{}, 1)
+	canceled := make(chan struct{})
+	var cancelOnce sync.Once
+	onMessage := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		switch args[0].Get("data").Get("type").String() {
+		case "pull":
+			select {
+			case pulls <- struct{}{}:
+			default: // a chunk is already requested
+			}
+		case "cancel":
+			cancelOnce.Do(func() { close(canceled) })
+		}
+		return nil
+	})
+	port.Set("onmessage", onMessage)
+	defer func() {
+		port.Set("onmessage", js.Null())
+		onMessage.Release()
+	}()
+
+	err := streamDownloadToPort(allocationID, remotePath, authTicket, lookupHash, blocksPerChunk, port, pulls, canceled)
+	if err != nil {
+		msg := js.Global().Get("Object").New()
+		msg.Set("type", "error")
+		msg.Set("message", err.Error())
+		port.Call("postMessage", msg)
+	}
+	return err
+}
+
+func streamDownloadToPort(allocationID, remotePath, authTicket, lookupHash string, blocksPerChunk int, port js.Value, pulls, canceled <-chan struct{}) error {
+	alloc, err := getAllocation(allocationID)
+	if err != nil {
+		PrintError("Error fetching the allocation", err)
+		return err
+	}
+
+	var meta *sdk.ConsolidatedFileMeta
+	if authTicket != "" {
+		meta, err = alloc.GetFileMetaFromAuthTicket(authTicket, lookupHash)
+	} else {
+		meta, err = alloc.GetFileMeta(remotePath)
+	}
+	if err != nil {
+		return err
+	}
+	if blocksPerChunk <= 0 {
+		blocksPerChunk = defaultBlobBlocksPerChunk
+	}
+
+	msg := js.Global().Get("Object").New()
+	msg.Set("type", "meta")
+	msg.Set("name", meta.Name)
+	msg.Set("size", meta.ActualFileSize)
+	msg.Set("mimeType", meta.MimeType)
+	port.Call("postMessage", msg)
+
+	for start := int64(1); start <= meta.NumBlocks; start += int64(blocksPerChunk) {
+		select {
+		case <-pulls:
+		case <-canceled:
+			return errors.New("download canceled")
+		}
+		end := start + int64(blocksPerChunk) - 1
+		if end > meta.NumBlocks {
+			end = meta.NumBlocks
+		}
+		buf, err := downloadBlocksWithAllocation(alloc, remotePath, authTicket, lookupHash, start, end)
+		if err != nil {
+			return err
+		}
+
+		chunk := jsbridge.NewBytes(buf)
+		msg := js.Global().Get("Object").New()
+		msg.Set("type", "chunk")
+		msg.Set("data", chunk)
+		port.Call("postMessage", msg, []interface{}{chunk.Get("buffer")})
+	}
+
+	msg = js.Global().Get("Object").New()
+	msg.Set("type", "end")
+	port.Call("postMessage", msg)
+	return nil
+}
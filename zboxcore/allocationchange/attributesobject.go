@@ -0,0 +1,63 @@
+package allocationchange
+
+import (
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/pathutil"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+)
+
+type AttributesChange struct {
+	change
+	RemotePath string
+	Attributes fileref.Attributes
+}
+
+func (ch *AttributesChange) ProcessChange(rootRef *fileref.Ref, _ map[string]string) (err error) {
+	fields, err := common.GetPathFields(pathutil.Dir(ch.RemotePath))
+	if err != nil {
+		return
+	}
+
+	rootRef.HashToBeComputed = true
+	dirRef := rootRef
+	for i := 0; i < len(fields); i++ {
+		found := false
+		for _, child := range dirRef.Children {
+			if child.GetName() == fields[i] {
+				var ok bool
+				dirRef, ok = child.(*fileref.Ref)
+				if !ok {
+					err = errors.New("invalid_reference_path", "Invalid reference path from the blobber")
+					return
+				}
+				dirRef.HashToBeComputed = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			err = errors.New("invalid_reference_path", "Invalid reference path from the blobber")
+			return
+		}
+	}
+
+	for _, child := range dirRef.Children {
+		if child.GetType() == fileref.FILE && child.GetPath() == ch.RemotePath {
+			fileRef := child.(*fileref.FileRef)
+			fileRef.Attributes = ch.Attributes
+			fileRef.HashToBeComputed = true
+			return
+		}
+	}
+	return errors.New("file_not_found", "File to update not found in blobber")
+}
+
+func (ch *AttributesChange) GetAffectedPath() []string {
+	return []string{ch.RemotePath}
+}
+
+func (ch *AttributesChange) GetSize() int64 {
+	return 0
+}
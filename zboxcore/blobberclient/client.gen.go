@@ -0,0 +1,338 @@
+// Code generated by oapigen from openapi.yaml. DO NOT EDIT.
+
+package blobberclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Attributes are the attributes of a file.
+type Attributes struct {
+	WhoPaysForReads int `json:"who_pays_for_reads"`
+}
+
+// Capabilities are the optional features of a blobber.
+type Capabilities struct {
+	// MinChunkSize is the minimum chunk size of the uploads, no limit if 0.
+	MinChunkSize int64 `json:"min_chunk_size"`
+	// MaxChunkSize is the maximum chunk size of the uploads, no limit if 0.
+	MaxChunkSize int64 `json:"max_chunk_size"`
+}
+
+// FileStats is the statistics of a file on a blobber.
+type FileStats struct {
+	Name                  string `json:"name"`
+	Size                  int64  `json:"size"`
+	PathHash              string `json:"path_hash"`
+	Path                  string `json:"path"`
+	NumOfBlocks           int64  `json:"num_of_blocks"`
+	NumOfUpdates          int64  `json:"num_of_updates"`
+	NumOfBlockDownloads   int64  `json:"num_of_block_downloads"`
+	NumOfChallenges       int64  `json:"num_of_challenges"`
+	NumOfFailedChallenges int64  `json:"num_of_failed_challenges"`
+	LastChallengeTxn      string `json:"last_challenge_txn"`
+	WriteMarkerTxn        string `json:"write_marker_txn"`
+	BlockchainAware       bool   `json:"blockchain_aware"`
+	FileID                string `json:"file_id"`
+}
+
+// LatestWriteMarker is the latest write marker of an allocation on a blobber and the previous one.
+type LatestWriteMarker struct {
+	LatestWriteMarker *WriteMarker `json:"latest_write_marker"`
+	PrevWriteMarker   *WriteMarker `json:"prev_write_marker"`
+	Version           string       `json:"version"`
+}
+
+// ObjectTree is the object tree of a path with the latest write marker of the allocation.
+type ObjectTree struct {
+	MetaData          map[string]interface{} `json:"meta_data"`
+	List              []*ReferencePath       `json:"list"`
+	LatestWriteMarker *WriteMarker           `json:"latest_write_marker"`
+	Version           string                 `json:"version"`
+}
+
+// RecentRefsResult is a page of the references recently added to an allocation.
+type RecentRefsResult struct {
+	Offset int    `json:"offset"`
+	Refs   []*Ref `json:"refs"`
+}
+
+// Ref is a file or directory reference.
+type Ref struct {
+	ID                  int64       `json:"id"`
+	FileID              string      `json:"file_id"`
+	FileMetaHash        string      `json:"file_meta_hash"`
+	Type                string      `json:"type"`
+	AllocationID        string      `json:"allocation_id"`
+	LookupHash          string      `json:"lookup_hash"`
+	Name                string      `json:"name"`
+	Path                string      `json:"path"`
+	PathHash            string      `json:"path_hash"`
+	ParentPath          string      `json:"parent_path"`
+	Level               int         `json:"level"`
+	Size                int64       `json:"size"`
+	EncryptedKey        string      `json:"encrypted_key"`
+	EncryptedKeyPoint   string      `json:"encrypted_key_point"`
+	ActualFileSize      int64       `json:"actual_file_size"`
+	ActualFileHash      string      `json:"actual_file_hash"`
+	Mimetype            string      `json:"mimetype"`
+	ActualThumbnailSize int64       `json:"actual_thumbnail_size"`
+	ActualThumbnailHash string      `json:"actual_thumbnail_hash"`
+	CustomMeta          string      `json:"custom_meta"`
+	Attributes          *Attributes `json:"attributes"`
+	CreatedAt           int64       `json:"created_at"`
+	UpdatedAt           int64       `json:"updated_at"`
+}
+
+// ReferencePath is a node of the object tree, the metadata of a reference and its children.
+type ReferencePath struct {
+	MetaData map[string]interface{} `json:"meta_data"`
+	List     []*ReferencePath       `json:"list"`
+}
+
+// RefsResult is a page of the references under a path.
+type RefsResult struct {
+	TotalPages int64  `json:"total_pages"`
+	OffsetPath string `json:"offset_path"`
+	OffsetDate string `json:"offset_date"`
+	Refs       []*Ref `json:"refs"`
+}
+
+// WriteMarker is a write marker committed to a blobber.
+type WriteMarker struct {
+	AllocationRoot     string `json:"allocation_root"`
+	PrevAllocationRoot string `json:"prev_allocation_root"`
+	FileMetaRoot       string `json:"file_meta_root"`
+	AllocationID       string `json:"allocation_id"`
+	Size               int64  `json:"size"`
+	ChainSize          int64  `json:"chain_size"`
+	ChainHash          string `json:"chain_hash"`
+	ChainLength        int    `json:"chain_length"`
+	BlobberID          string `json:"blobber_id"`
+	Timestamp          int64  `json:"timestamp"`
+	ClientID           string `json:"client_id"`
+	Signature          string `json:"signature"`
+}
+
+// ClientInterface is the interface of the blobber API, implemented by Client.
+type ClientInterface interface {
+	// GetCapabilities returns the optional features and the limits of the blobber, e.g. its chunk size bounds.
+	GetCapabilities(ctx context.Context, editors ...RequestEditorFn) (*Capabilities, error)
+	// GetFileStats returns the statistics of a file.
+	GetFileStats(ctx context.Context, allocation string, params *GetFileStatsParams, editors ...RequestEditorFn) (*FileStats, error)
+	// GetLatestWriteMarker returns the latest write marker of the allocation and the previous one.
+	GetLatestWriteMarker(ctx context.Context, allocation string, editors ...RequestEditorFn) (*LatestWriteMarker, error)
+	// GetObjectTree returns the object tree of a path, the path and all its ancestors.
+	GetObjectTree(ctx context.Context, allocation string, params *GetObjectTreeParams, editors ...RequestEditorFn) (*ObjectTree, error)
+	// GetRecentRefs returns a page of the references recently added to the allocation.
+	GetRecentRefs(ctx context.Context, allocation string, params *GetRecentRefsParams, editors ...RequestEditorFn) (*RecentRefsResult, error)
+	// GetRefs returns a page of the references under a path.
+	GetRefs(ctx context.Context, allocation string, params *GetRefsParams, editors ...RequestEditorFn) (*RefsResult, error)
+}
+
+// GetCapabilities returns the optional features and the limits of the blobber, e.g. its chunk size bounds.
+//   - ctx: the context of the request.
+//   - editors: the editors of the request, run after the ones of the client.
+func (c *Client) GetCapabilities(ctx context.Context, editors ...RequestEditorFn) (*Capabilities, error) {
+	r := &request{
+		method: "GET",
+		path:   "/v1/capabilities",
+	}
+
+	var result Capabilities
+	if err := c.do(ctx, r, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFileStatsParams are the parameters of GetFileStats.
+type GetFileStatsParams struct {
+	// Path is the remote path, or path_hash.
+	Path string
+	// PathHash is the lookup hash of the path.
+	PathHash string
+}
+
+// GetFileStats returns the statistics of a file.
+//   - ctx: the context of the request.
+//   - allocation: the allocation transaction.
+//   - params: the parameters of the request.
+//   - editors: the editors of the request, run after the ones of the client.
+func (c *Client) GetFileStats(ctx context.Context, allocation string, params *GetFileStatsParams, editors ...RequestEditorFn) (*FileStats, error) {
+	if params == nil {
+		params = &GetFileStatsParams{}
+	}
+	r := &request{
+		method: "POST",
+		path:   "/v1/file/stats/" + url.PathEscape(allocation),
+		form:   url.Values{},
+	}
+	if params.Path != "" {
+		r.form.Set("path", params.Path)
+	}
+	if params.PathHash != "" {
+		r.form.Set("path_hash", params.PathHash)
+	}
+
+	var result FileStats
+	if err := c.do(ctx, r, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetLatestWriteMarker returns the latest write marker of the allocation and the previous one.
+//   - ctx: the context of the request.
+//   - allocation: the allocation transaction.
+//   - editors: the editors of the request, run after the ones of the client.
+func (c *Client) GetLatestWriteMarker(ctx context.Context, allocation string, editors ...RequestEditorFn) (*LatestWriteMarker, error) {
+	r := &request{
+		method: "GET",
+		path:   "/v1/file/latestwritemarker/" + url.PathEscape(allocation),
+	}
+
+	var result LatestWriteMarker
+	if err := c.do(ctx, r, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetObjectTreeParams are the parameters of GetObjectTree.
+type GetObjectTreeParams struct {
+	// Path is the remote path.
+	Path string
+}
+
+// GetObjectTree returns the object tree of a path, the path and all its ancestors.
+//   - ctx: the context of the request.
+//   - allocation: the allocation transaction.
+//   - params: the parameters of the request.
+//   - editors: the editors of the request, run after the ones of the client.
+func (c *Client) GetObjectTree(ctx context.Context, allocation string, params *GetObjectTreeParams, editors ...RequestEditorFn) (*ObjectTree, error) {
+	if params == nil {
+		params = &GetObjectTreeParams{}
+	}
+	r := &request{
+		method: "GET",
+		path:   "/v1/file/objecttree/" + url.PathEscape(allocation),
+		query:  url.Values{},
+	}
+	r.query.Set("path", params.Path)
+
+	var result ObjectTree
+	if err := c.do(ctx, r, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetRecentRefsParams are the parameters of GetRecentRefs.
+type GetRecentRefsParams struct {
+	// Limit is the maximum number of references of the page.
+	Limit int
+	// Offset is the offset of the page.
+	Offset int64
+	// FromDate is the unix timestamp, only the references added after it are returned.
+	FromDate int64
+}
+
+// GetRecentRefs returns a page of the references recently added to the allocation.
+//   - ctx: the context of the request.
+//   - allocation: the allocation id.
+//   - params: the parameters of the request.
+//   - editors: the editors of the request, run after the ones of the client.
+func (c *Client) GetRecentRefs(ctx context.Context, allocation string, params *GetRecentRefsParams, editors ...RequestEditorFn) (*RecentRefsResult, error) {
+	if params == nil {
+		params = &GetRecentRefsParams{}
+	}
+	r := &request{
+		method: "GET",
+		path:   "/v1/file/refs/recent/" + url.PathEscape(allocation),
+		query:  url.Values{},
+	}
+	r.query.Set("limit", strconv.Itoa(params.Limit))
+	r.query.Set("offset", strconv.FormatInt(params.Offset, 10))
+	r.query.Set("from-date", strconv.FormatInt(params.FromDate, 10))
+
+	var result RecentRefsResult
+	if err := c.do(ctx, r, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetRefsParams are the parameters of GetRefs.
+type GetRefsParams struct {
+	// Path is the remote path, or path_hash.
+	Path string
+	// PathHash is the lookup hash of the path.
+	PathHash string
+	// AuthToken is the auth ticket of a shared path.
+	AuthToken string
+	// OffsetPath is the path of the last reference of the previous page.
+	OffsetPath string
+	// UpdatedDate is the date, only the references updated after it are returned.
+	UpdatedDate string
+	// OffsetDate is the date of the last reference of the previous page.
+	OffsetDate string
+	// FileType is the file type, only the references of this type are returned.
+	FileType string
+	// RefType is the type of the listing, regular or updated.
+	RefType string
+	// Level is the level, only the references of this level are returned.
+	Level int
+	// PageLimit is the maximum number of references of the page.
+	PageLimit int
+}
+
+// GetRefs returns a page of the references under a path.
+//   - ctx: the context of the request.
+//   - allocation: the allocation transaction.
+//   - params: the parameters of the request.
+//   - editors: the editors of the request, run after the ones of the client.
+func (c *Client) GetRefs(ctx context.Context, allocation string, params *GetRefsParams, editors ...RequestEditorFn) (*RefsResult, error) {
+	if params == nil {
+		params = &GetRefsParams{}
+	}
+	r := &request{
+		method: "GET",
+		path:   "/v1/file/refs/" + url.PathEscape(allocation),
+		query:  url.Values{},
+	}
+	if params.Path != "" {
+		r.query.Set("path", params.Path)
+	}
+	if params.PathHash != "" {
+		r.query.Set("path_hash", params.PathHash)
+	}
+	if params.AuthToken != "" {
+		r.query.Set("auth_token", params.AuthToken)
+	}
+	if params.OffsetPath != "" {
+		r.query.Set("offsetPath", params.OffsetPath)
+	}
+	if params.UpdatedDate != "" {
+		r.query.Set("updatedDate", params.UpdatedDate)
+	}
+	if params.OffsetDate != "" {
+		r.query.Set("offsetDate", params.OffsetDate)
+	}
+	if params.FileType != "" {
+		r.query.Set("fileType", params.FileType)
+	}
+	r.query.Set("refType", params.RefType)
+	if params.Level != 0 {
+		r.query.Set("level", strconv.Itoa(params.Level))
+	}
+	r.query.Set("pageLimit", strconv.Itoa(params.PageLimit))
+
+	var result RefsResult
+	if err := c.do(ctx, r, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,148 @@
+package blobberclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/0chain/errors"
+)
+
+// Doer sends HTTP requests, e.g. *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RequestEditorFn edits a request before it's sent, e.g. to add the authentication headers.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ResponseError is an error response of a blobber.
+type ResponseError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *ResponseError) Error() string {
+	return http.StatusText(e.StatusCode) + ": " + strings.TrimSpace(string(e.Body))
+}
+
+// Client is the client of the API of a blobber, see ClientInterface.
+type Client struct {
+	baseURL        string
+	httpClient     Doer
+	requestEditors []RequestEditorFn
+}
+
+var _ ClientInterface = (*Client)(nil)
+
+// ClientOption customizes a Client.
+type ClientOption func(c *Client)
+
+// WithHTTPClient sets the client sending the requests, http.DefaultClient by default.
+func WithHTTPClient(doer Doer) ClientOption {
+	return func(c *Client) {
+		c.httpClient = doer
+	}
+}
+
+// WithRequestEditorFn adds an editor of all the requests of the client.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) {
+		c.requestEditors = append(c.requestEditors, fn)
+	}
+}
+
+// NewClient returns the client of a blobber.
+//   - baseURL: the base url of the blobber.
+//   - opts: the options of the client.
+func NewClient(baseURL string, opts ...ClientOption) (*Client, error) {
+	if _, err := url.Parse(baseURL); err != nil {
+		return nil, errors.Wrap(err, "invalid blobber url")
+	}
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// request is a request of an operation, built by the generated code.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	// form are the fields of the multipart form body, no body if nil.
+	form url.Values
+}
+
+// do sends a request and decodes its json response in result.
+func (c *Client) do(ctx context.Context, r *request, result interface{}, editors []RequestEditorFn) error {
+	u := c.baseURL + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+
+	var body io.Reader
+	var contentType string
+	if r.form != nil {
+		buf := new(bytes.Buffer)
+		formWriter := multipart.NewWriter(buf)
+		keys := make([]string, 0, len(r.form))
+		for k := range r.form {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			for _, v := range r.form[k] {
+				if err := formWriter.WriteField(k, v); err != nil {
+					return err
+				}
+			}
+		}
+		if err := formWriter.Close(); err != nil {
+			return err
+		}
+		body = buf
+		contentType = formWriter.FormDataContentType()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for _, edit := range append(c.requestEditors[:len(c.requestEditors):len(c.requestEditors)], editors...) {
+		if err := edit(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "error reading response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &ResponseError{StatusCode: resp.StatusCode, Body: respBody}
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return errors.Wrap(err, "error decoding response")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package blobberclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "client", r.Header.Get("X-App-Client-ID"))
+		switch r.URL.Path {
+		case "/v1/file/refs/recent/alloc":
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, "10", r.URL.Query().Get("limit"))
+			require.Equal(t, "0", r.URL.Query().Get("offset"))
+			require.Equal(t, "100", r.URL.Query().Get("from-date"))
+			w.Write([]byte(`{"offset":1,"refs":[{"id":1,"path":"/a.txt","attributes":{"who_pays_for_reads":1}}]}`)) //nolint: errcheck
+		case "/v1/file/refs/tx":
+			require.Equal(t, "/dir", r.URL.Query().Get("path"))
+			require.Equal(t, "regular", r.URL.Query().Get("refType"))
+			// the optional parameters aren't sent if not set
+			require.NotContains(t, r.URL.Query(), "level")
+			w.Write([]byte(`{"total_pages":2,"offset_path":"/dir/b.txt","refs":[]}`)) //nolint: errcheck
+		case "/v1/file/stats/tx":
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "hash", r.FormValue("path_hash"))
+			w.Write([]byte(`{"name":"a.txt","num_of_blocks":3}`)) //nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found\n")) //nolint: errcheck
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL+"/", WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("X-App-Client-ID", "client")
+		return nil
+	}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	recent, err := c.GetRecentRefs(ctx, "alloc", &GetRecentRefsParams{Limit: 10, FromDate: 100})
+	require.NoError(t, err)
+	require.Equal(t, 1, recent.Offset)
+	require.Len(t, recent.Refs, 1)
+	require.Equal(t, "/a.txt", recent.Refs[0].Path)
+	require.Equal(t, 1, recent.Refs[0].Attributes.WhoPaysForReads)
+
+	refs, err := c.GetRefs(ctx, "tx", &GetRefsParams{Path: "/dir", RefType: "regular", PageLimit: 5})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), refs.TotalPages)
+	require.Equal(t, "/dir/b.txt", refs.OffsetPath)
+
+	stats, err := c.GetFileStats(ctx, "tx", &GetFileStatsParams{PathHash: "hash"})
+	require.NoError(t, err)
+	require.Equal(t, "a.txt", stats.Name)
+	require.Equal(t, int64(3), stats.NumOfBlocks)
+
+	_, err = c.GetLatestWriteMarker(ctx, "unknown")
+	var respErr *ResponseError
+	require.ErrorAs(t, err, &respErr)
+	require.Equal(t, http.StatusNotFound, respErr.StatusCode)
+	require.Equal(t, "Not Found: not found", respErr.Error())
+
+	// the editors of a request run after the ones of the client
+	_, err = c.GetLatestWriteMarker(ctx, "tx", func(_ context.Context, req *http.Request) error {
+		require.Equal(t, "client", req.Header.Get("X-App-Client-ID"))
+		return context.Canceled
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,9 @@
+// Package blobberclient is the client of the HTTP API of the blobbers, generated from its OpenAPI
+// definition in openapi.yaml. A new blobber endpoint is added to the definition and the client is
+// regenerated with go generate, the typed requests and responses are never written by hand.
+//
+// The requests aren't authenticated by the client, the SDK adds the client and signature headers
+// with a RequestEditorFn, see Allocation.BlobberClient in zboxcore/sdk.
+package blobberclient
+
+//go:generate go run ./internal/oapigen -spec openapi.yaml -out client.gen.go
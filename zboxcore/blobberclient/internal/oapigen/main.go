@@ -0,0 +1,531 @@
+// Command oapigen generates the blobber API client from its OpenAPI definition.
+//
+//	go run ./internal/oapigen -spec openapi.yaml -out client.gen.go
+//
+// It supports the subset of OpenAPI 3 used by the blobber API:
+//   - schemas: objects whose properties are strings, integers, numbers, booleans, arrays,
+//     references to other schemas and free-form objects (additionalProperties)
+//   - operations: path and query parameters, multipart form bodies and a json 200 response
+//     referencing a schema
+//
+// Each schema generates a struct, each operation a method of Client and ClientInterface
+// with a struct <Operation>Params of its query and form parameters.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+type spec struct {
+	Paths      map[string]map[string]*operation `yaml:"paths"`
+	Components struct {
+		Schemas properties `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type operation struct {
+	OperationID string      `yaml:"operationId"`
+	Summary     string      `yaml:"summary"`
+	Parameters  []parameter `yaml:"parameters"`
+	RequestBody *struct {
+		Content map[string]mediaType `yaml:"content"`
+	} `yaml:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]mediaType `yaml:"content"`
+	} `yaml:"responses"`
+}
+
+type parameter struct {
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"`
+	Required    bool    `yaml:"required"`
+	Description string  `yaml:"description"`
+	Schema      *schema `yaml:"schema"`
+}
+
+type mediaType struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref                  string      `yaml:"$ref"`
+	Type                 string      `yaml:"type"`
+	Format               string      `yaml:"format"`
+	Description          string      `yaml:"description"`
+	Required             []string    `yaml:"required"`
+	Properties           properties  `yaml:"properties"`
+	Items                *schema     `yaml:"items"`
+	AdditionalProperties interface{} `yaml:"additionalProperties"`
+	GoName               string      `yaml:"x-go-name"`
+}
+
+// properties are the named schemas of an object, in the order of the definition.
+type properties []namedSchema
+
+type namedSchema struct {
+	Name   string
+	Schema *schema
+}
+
+func (p *properties) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", n.Line)
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		var s schema
+		if err := n.Content[i+1].Decode(&s); err != nil {
+			return err
+		}
+		*p = append(*p, namedSchema{Name: n.Content[i].Value, Schema: &s})
+	}
+	return nil
+}
+
+// goStruct is a struct generated from a schema.
+type goStruct struct {
+	Name   string
+	Doc    string
+	Fields []goField
+}
+
+type goField struct {
+	Name string
+	Type string
+	JSON string
+	Doc  string
+}
+
+// goOperation is a method of the client generated from an operation.
+type goOperation struct {
+	Name       string
+	Summary    string
+	Method     string
+	Path       string
+	Result     string
+	PathParams []goParam
+	Params     []goParam
+	HasQuery   bool
+	HasForm    bool
+}
+
+// goParam is a path, query or form parameter of an operation.
+type goParam struct {
+	Name     string
+	Key      string
+	In       string
+	Type     string
+	Doc      string
+	Required bool
+}
+
+func main() {
+	specPath := flag.String("spec", "openapi.yaml", "the OpenAPI definition of the blobber API")
+	out := flag.String("out", "client.gen.go", "the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var s spec
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		log.Fatalf("parse %s: %v", *specPath, err)
+	}
+	src, err := generate(&s, *specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(s *spec, specPath string) ([]byte, error) {
+	structs := make([]goStruct, 0, len(s.Components.Schemas))
+	for _, ns := range s.Components.Schemas {
+		st, err := structOf(ns)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, st)
+	}
+	sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+
+	var ops []goOperation
+	imports := map[string]bool{"context": true, "net/url": true}
+	seen := make(map[string]bool)
+	for path, methods := range s.Paths {
+		for method, op := range methods {
+			gop, err := operationOf(path, method, op)
+			if err != nil {
+				return nil, err
+			}
+			if seen[gop.Name] {
+				return nil, fmt.Errorf("duplicate operation %s", gop.Name)
+			}
+			seen[gop.Name] = true
+			for _, p := range gop.Params {
+				if p.Type != "string" {
+					imports["strconv"] = true
+				}
+			}
+			ops = append(ops, gop)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Name < ops[j].Name })
+
+	std := make([]string, 0, len(imports))
+	for p := range imports {
+		std = append(std, p)
+	}
+	sort.Strings(std)
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]interface{}{
+		"Spec":       specPath,
+		"Imports":    std,
+		"Structs":    structs,
+		"Operations": ops,
+	})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format the generated code: %v\n%s", err, buf.String())
+	}
+	return src, nil
+}
+
+func structOf(ns namedSchema) (goStruct, error) {
+	if ns.Schema.Type != "object" {
+		return goStruct{}, fmt.Errorf("schema %s: only object schemas are supported", ns.Name)
+	}
+	st := goStruct{Name: goName(ns.Name, ns.Schema.GoName), Doc: ns.Schema.Description}
+	if st.Doc == "" {
+		st.Doc = st.Name + " is the " + ns.Name + " schema."
+	}
+	for _, p := range ns.Schema.Properties {
+		typ, err := goType(p.Schema)
+		if err != nil {
+			return goStruct{}, fmt.Errorf("schema %s, property %s: %v", ns.Name, p.Name, err)
+		}
+		st.Fields = append(st.Fields, goField{
+			Name: goName(p.Name, p.Schema.GoName),
+			Type: typ,
+			JSON: p.Name,
+			Doc:  p.Schema.Description,
+		})
+	}
+	return st, nil
+}
+
+var pathParamRe = regexp.MustCompile(`\{([^}]+)\}`)
+
+func operationOf(path, method string, op *operation) (goOperation, error) {
+	if op.OperationID == "" {
+		return goOperation{}, fmt.Errorf("%s %s: operationId is required", method, path)
+	}
+	gop := goOperation{
+		Name:    goName(op.OperationID, ""),
+		Summary: op.Summary,
+		Method:  strings.ToUpper(method),
+	}
+	if gop.Summary == "" {
+		gop.Summary = "calls " + gop.Method + " " + path + "."
+	}
+
+	pathParams := make(map[string]goParam)
+	for _, p := range op.Parameters {
+		typ, err := paramType(p.Schema)
+		if err != nil {
+			return goOperation{}, fmt.Errorf("%s, parameter %s: %v", gop.Name, p.Name, err)
+		}
+		gp := goParam{Name: goName(p.Name, ""), Key: p.Name, In: p.In, Type: typ, Doc: p.Description, Required: p.Required}
+		switch p.In {
+		case "path":
+			if typ != "string" {
+				return goOperation{}, fmt.Errorf("%s, parameter %s: path parameters must be strings", gop.Name, p.Name)
+			}
+			gp.Name = lowerFirst(gp.Name)
+			pathParams[p.Name] = gp
+		case "query":
+			gop.HasQuery = true
+			gop.Params = append(gop.Params, gp)
+		default:
+			return goOperation{}, fmt.Errorf("%s, parameter %s: %s parameters are not supported", gop.Name, p.Name, p.In)
+		}
+	}
+
+	if op.RequestBody != nil {
+		for contentType, mt := range op.RequestBody.Content {
+			if contentType != "multipart/form-data" || mt.Schema == nil {
+				return goOperation{}, fmt.Errorf("%s: only multipart/form-data bodies are supported", gop.Name)
+			}
+			for _, p := range mt.Schema.Properties {
+				typ, err := paramType(p.Schema)
+				if err != nil {
+					return goOperation{}, fmt.Errorf("%s, form field %s: %v", gop.Name, p.Name, err)
+				}
+				gop.HasForm = true
+				gop.Params = append(gop.Params, goParam{
+					Name:     goName(p.Name, p.Schema.GoName),
+					Key:      p.Name,
+					In:       "form",
+					Type:     typ,
+					Doc:      p.Schema.Description,
+					Required: contains(mt.Schema.Required, p.Name),
+				})
+			}
+		}
+	}
+
+	ok, found := op.Responses["200"]
+	if !found || ok.Content["application/json"].Schema == nil || ok.Content["application/json"].Schema.Ref == "" {
+		return goOperation{}, fmt.Errorf("%s: a json 200 response referencing a schema is required", gop.Name)
+	}
+	gop.Result = refName(ok.Content["application/json"].Schema.Ref)
+
+	// the path expression, e.g. "/v1/file/refs/" + url.PathEscape(allocation)
+	var parts []string
+	last := 0
+	for _, m := range pathParamRe.FindAllStringSubmatchIndex(path, -1) {
+		p, ok := pathParams[path[m[2]:m[3]]]
+		if !ok {
+			return goOperation{}, fmt.Errorf("%s: path parameter %s is not defined", gop.Name, path[m[2]:m[3]])
+		}
+		if m[0] > last {
+			parts = append(parts, fmt.Sprintf("%q", path[last:m[0]]))
+		}
+		parts = append(parts, "url.PathEscape("+p.Name+")")
+		gop.PathParams = append(gop.PathParams, p)
+		last = m[1]
+	}
+	if last < len(path) {
+		parts = append(parts, fmt.Sprintf("%q", path[last:]))
+	}
+	if len(gop.PathParams) != len(pathParams) {
+		return goOperation{}, fmt.Errorf("%s: path parameters not in the path %s", gop.Name, path)
+	}
+	gop.Path = strings.Join(parts, " + ")
+	return gop, nil
+}
+
+// goType returns the Go type of a property.
+func goType(s *schema) (string, error) {
+	if s.Ref != "" {
+		return "*" + refName(s.Ref), nil
+	}
+	switch s.Type {
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if s.AdditionalProperties == nil || len(s.Properties) > 0 {
+			return "", fmt.Errorf("nested objects must reference a schema")
+		}
+		return "map[string]interface{}", nil
+	}
+	return scalarType(s)
+}
+
+// paramType returns the Go type of a parameter, only scalars are supported.
+func paramType(s *schema) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("schema is required")
+	}
+	return scalarType(s)
+}
+
+func scalarType(s *schema) (string, error) {
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "number":
+		return "float64", nil
+	case "integer":
+		switch s.Format {
+		case "int64", "int32":
+			return s.Format, nil
+		case "":
+			return "int", nil
+		}
+		return "", fmt.Errorf("unsupported integer format %s", s.Format)
+	}
+	return "", fmt.Errorf("unsupported type %q", s.Type)
+}
+
+func refName(ref string) string {
+	return goName(strings.TrimPrefix(ref, "#/components/schemas/"), "")
+}
+
+// initialisms are the words of the names written in upper case.
+var initialisms = map[string]bool{"id": true, "url": true, "json": true, "http": true, "api": true}
+
+// goName returns the exported Go name of an OpenAPI name, e.g. path_hash is PathHash and
+// blobber_id is BlobberID, unless it's overridden with x-go-name.
+func goName(name, override string) string {
+	if override != "" {
+		return override
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(name string) string {
+	if strings.ToUpper(name) == name {
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// encode returns the expression encoding a parameter as a string.
+func encode(p goParam) string {
+	v := "params." + p.Name
+	switch p.Type {
+	case "int":
+		return "strconv.Itoa(" + v + ")"
+	case "int32":
+		return "strconv.FormatInt(int64(" + v + "), 10)"
+	case "int64":
+		return "strconv.FormatInt(" + v + ", 10)"
+	case "float64":
+		return "strconv.FormatFloat(" + v + ", 'f', -1, 64)"
+	case "bool":
+		return "strconv.FormatBool(" + v + ")"
+	}
+	return v
+}
+
+// isSet returns the condition of an optional parameter being sent, not the zero value.
+func isSet(p goParam) string {
+	switch p.Type {
+	case "string":
+		return "params." + p.Name + ` != ""`
+	case "bool":
+		return "params." + p.Name
+	}
+	return "params." + p.Name + " != 0"
+}
+
+var tmpl = template.Must(template.New("client").Funcs(template.FuncMap{
+	"encode": encode,
+	"isSet":  isSet,
+}).Parse(`// Code generated by oapigen from {{.Spec}}. DO NOT EDIT.
+
+package blobberclient
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range .Structs}}
+// {{.Doc}}
+type {{.Name}} struct {
+{{- range .Fields}}
+{{- if .Doc}}
+	// {{.Name}} is {{.Doc}}
+{{- end}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + `
+{{- end}}
+}
+{{end}}
+// ClientInterface is the interface of the blobber API, implemented by Client.
+type ClientInterface interface {
+{{- range .Operations}}
+	// {{.Name}} {{.Summary}}
+	{{.Name}}(ctx context.Context{{range .PathParams}}, {{.Name}} string{{end}}{{if .Params}}, params *{{.Name}}Params{{end}}, editors ...RequestEditorFn) (*{{.Result}}, error)
+{{- end}}
+}
+{{range $op := .Operations}}{{if .Params}}
+// {{.Name}}Params are the parameters of {{.Name}}.
+type {{.Name}}Params struct {
+{{- range .Params}}
+{{- if .Doc}}
+	// {{.Name}} is {{.Doc}}
+{{- end}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+{{end}}
+// {{.Name}} {{.Summary}}
+//   - ctx: the context of the request.
+{{- range .PathParams}}
+//   - {{.Name}}: {{.Doc}}
+{{- end}}
+{{- if .Params}}
+//   - params: the parameters of the request.
+{{- end}}
+//   - editors: the editors of the request, run after the ones of the client.
+func (c *Client) {{.Name}}(ctx context.Context{{range .PathParams}}, {{.Name}} string{{end}}{{if .Params}}, params *{{.Name}}Params{{end}}, editors ...RequestEditorFn) (*{{.Result}}, error) {
+{{- if .Params}}
+	if params == nil {
+		params = &{{.Name}}Params{}
+	}
+{{- end}}
+	r := &request{
+		method: "{{.Method}}",
+		path:   {{.Path}},
+{{- if .HasQuery}}
+		query:  url.Values{},
+{{- end}}
+{{- if .HasForm}}
+		form:   url.Values{},
+{{- end}}
+	}
+{{- range .Params}}
+{{- if .Required}}
+	r.{{.In}}.Set("{{.Key}}", {{encode .}})
+{{- else}}
+	if {{isSet .}} {
+		r.{{.In}}.Set("{{.Key}}", {{encode .}})
+	}
+{{- end}}
+{{- end}}
+
+	var result {{.Result}}
+	if err := c.do(ctx, r, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+{{end}}`))
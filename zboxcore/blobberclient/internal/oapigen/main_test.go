@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// TestGeneratedClientUpToDate fails if client.gen.go wasn't regenerated after a change of the
+// definition, run go generate ./zboxcore/blobberclient to fix it.
+func TestGeneratedClientUpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../openapi.yaml")
+	require.NoError(t, err)
+	var s spec
+	require.NoError(t, yaml.Unmarshal(data, &s))
+
+	src, err := generate(&s, "openapi.yaml")
+	require.NoError(t, err)
+	generated, err := os.ReadFile("../../client.gen.go")
+	require.NoError(t, err)
+	require.Equal(t, string(generated), string(src))
+}
+
+func TestGenerate_InvalidSpec(t *testing.T) {
+	for name, def := range map[string]string{
+		"no operation id": `
+paths:
+  /v1/file/meta/{allocation}:
+    get:
+      responses:
+        '200': {content: {application/json: {schema: {$ref: '#/components/schemas/Meta'}}}}
+`,
+		"undefined path parameter": `
+paths:
+  /v1/file/meta/{allocation}:
+    get:
+      operationId: GetMeta
+      responses:
+        '200': {content: {application/json: {schema: {$ref: '#/components/schemas/Meta'}}}}
+`,
+		"nested object": `
+components:
+  schemas:
+    Meta:
+      type: object
+      properties:
+        owner:
+          type: object
+          properties:
+            id: {type: string}
+`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var s spec
+			require.NoError(t, yaml.Unmarshal([]byte(def), &s))
+			_, err := generate(&s, "openapi.yaml")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	require.Equal(t, "PathHash", goName("path_hash", ""))
+	require.Equal(t, "BlobberID", goName("blobber_id", ""))
+	require.Equal(t, "FromDate", goName("from-date", ""))
+	require.Equal(t, "OffsetPath", goName("offsetPath", ""))
+	require.Equal(t, "Custom", goName("custom_meta", "Custom"))
+}
@@ -43,6 +43,18 @@ func ResetStableMiners() {
 	miners = util.GetRandom(chain.Miners, getMinMinersSubmit())
 }
 
+// SetHealthyMiners replaces the stable miners with random miners picked from the healthy ones,
+// so that the transactions are not submitted to miners that are down.
+//   - healthy: the miners that passed the last health check
+func SetHealthyMiners(healthy []string) {
+	if len(healthy) == 0 {
+		return
+	}
+	mGuard.Lock()
+	defer mGuard.Unlock()
+	miners = util.GetRandom(healthy, getMinMinersSubmit())
+}
+
 type ChainConfig struct {
 	BlockWorker     string
 	Sharders        []string
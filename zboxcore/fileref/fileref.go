@@ -32,14 +32,33 @@ type Collaborator struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// Attributes are the attributes of a file.
+type Attributes struct {
+	// WhoPaysForReads is the payer of the reads of the file, the owner by default.
+	WhoPaysForReads common.WhoPays `json:"who_pays_for_reads,omitempty" mapstructure:"who_pays_for_reads"`
+}
+
+// IsZero returns true if the attributes are the default ones.
+func (a Attributes) IsZero() bool {
+	return a == Attributes{}
+}
+
+// Validate returns an error if an attribute is invalid.
+func (a Attributes) Validate() error {
+	return a.WhoPaysForReads.Validate()
+}
+
 type FileRef struct {
 	Ref        `mapstructure:",squash"`
 	CustomMeta string `json:"custom_meta" mapstructure:"custom_meta"`
+	// ValidationRoot is the root of the validation merkle tree of the shard stored on the blobber
+	ValidationRoot string `json:"validation_root" mapstructure:"validation_root"`
 	// ValidationRootSignature is signature signed by client for hash_of(ActualFileHashSignature + ValidationRoot)
-	ThumbnailSize  int64  `json:"thumbnail_size" mapstructure:"thumbnail_size"`
-	ThumbnailHash  string `json:"thumbnail_hash" mapstructure:"thumbnail_hash"`
-	ActualFileSize int64  `json:"actual_file_size" mapstructure:"actual_file_size"`
-	ActualFileHash string `json:"actual_file_hash" mapstructure:"actual_file_hash"`
+	ValidationRootSignature string `json:"validation_root_signature" mapstructure:"validation_root_signature"`
+	ThumbnailSize           int64  `json:"thumbnail_size" mapstructure:"thumbnail_size"`
+	ThumbnailHash           string `json:"thumbnail_hash" mapstructure:"thumbnail_hash"`
+	ActualFileSize          int64  `json:"actual_file_size" mapstructure:"actual_file_size"`
+	ActualFileHash          string `json:"actual_file_hash" mapstructure:"actual_file_hash"`
 	// ActualFileHashSignature is signature signed by client for ActualFileHash
 	ActualFileHashSignature string         `json:"actual_file_hash_signature" mapstructure:"actual_file_hash_signature"`
 	ActualThumbnailSize     int64          `json:"actual_thumbnail_size" mapstructure:"actual_thumbnail_size"`
@@ -48,6 +67,7 @@ type FileRef struct {
 	EncryptedKey            string         `json:"encrypted_key" mapstructure:"encrypted_key"`
 	EncryptedKeyPoint       string         `json:"encrypted_key_point" mapstructure:"encrypted_key_point"`
 	Collaborators           []Collaborator `json:"collaborators" mapstructure:"collaborators"`
+	Attributes              Attributes     `json:"attributes" mapstructure:"attributes"`
 }
 
 func (fRef *FileRef) MetaID() string {
@@ -124,6 +144,11 @@ func GetFileRef(key string) (FileRef, bool) {
 	return FileRef{}, false
 }
 
+// CacheLen returns the number of cached file refs.
+func CacheLen() int {
+	return fileCache.Len()
+}
+
 func DeleteFileRef(key string) {
 	fileCache.Remove(key)
 }
@@ -276,7 +301,11 @@ func (fr *FileRef) GetHash() string {
 func (fr *FileRef) CalculateHash() string {
 	fr.Hash = encryption.Hash(fr.GetHashData())
 	fr.FileMetaHash = encryption.Hash(fr.GetFileMetaHashData())
-	fr.NumBlocks = int64(math.Ceil(float64(fr.Size*1.0) / CHUNK_SIZE))
+	chunkSize := fr.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = CHUNK_SIZE
+	}
+	fr.NumBlocks = int64(math.Ceil(float64(fr.Size*1.0) / float64(chunkSize)))
 	return fr.Hash
 }
 
@@ -0,0 +1,48 @@
+package marker
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// FreeStorageMarker is issued and signed by a free storage assigner to let the recipient
+// create a free allocation (txn: `storagesc.free_allocation_request`).
+type FreeStorageMarker struct {
+	Assigner   string  `json:"assigner"`
+	Recipient  string  `json:"recipient"`
+	FreeTokens float64 `json:"free_tokens"`
+	Nonce      int64   `json:"nonce"`
+	Signature  string  `json:"signature"`
+}
+
+// GetHashData returns the data signed by the assigner, as expected by the storage smart contract.
+func (fm *FreeStorageMarker) GetHashData() string {
+	return hex.EncodeToString([]byte(fmt.Sprintf("%s:%f:%d", fm.Recipient, fm.FreeTokens, fm.Nonce)))
+}
+
+// Sign signs the marker with the current client wallet, which must be the assigner wallet.
+func (fm *FreeStorageMarker) Sign() error {
+	var err error
+	fm.Signature, err = client.Sign(fm.GetHashData())
+	return err
+}
+
+// VerifySignature verifies the marker is signed by the given assigner public key.
+//   - assignerPublicKey: public key of the free storage assigner.
+func (fm *FreeStorageMarker) VerifySignature(assignerPublicKey string) error {
+	if fm.Signature == "" {
+		return errors.New("free_storage_marker_validation_failed", "marker is not signed")
+	}
+	sigOK, err := sys.VerifyWith(assignerPublicKey, fm.Signature, fm.GetHashData())
+	if err != nil {
+		return errors.New("free_storage_marker_validation_failed", "Error during verifying signature. "+err.Error())
+	}
+	if !sigOK {
+		return errors.New("free_storage_marker_validation_failed", "Free storage marker signature is not valid")
+	}
+	return nil
+}
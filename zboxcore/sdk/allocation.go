@@ -15,6 +15,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -46,6 +47,12 @@ var (
 	RepairBatchSize        = 50
 	Workdir                string
 	multiOpRepairBatchSize = 10
+	// CommitRetryAttempts is the number of background retries of the commits of an operation failed
+	// on some blobbers while it reached the consensus, 0, the default, disables them. See
+	// EventCommitRetryDone.
+	CommitRetryAttempts = 0
+	// ErrPreconditionFailed is returned by the conditional updates when the remote file changed, see WithIfMatch.
+	ErrPreconditionFailed = errors.New("precondition_failed", "the remote file doesn't match the expected hash")
 )
 
 const (
@@ -114,11 +121,48 @@ type ConsolidatedFileMeta struct {
 	ActualFileSize  int64
 	ActualNumBlocks int64
 	EncryptedKey    string
+	// ChunkSize is the size of the chunks the file was uploaded with, see WithChunkSize.
+	ChunkSize int64
 
 	ActualThumbnailSize int64
 	ActualThumbnailHash string
 
 	Collaborators []fileref.Collaborator
+
+	// CustomMeta is the custom meta data of the file, see FileMeta.
+	CustomMeta string
+	// Attributes are the attributes of the file, e.g. who pays for its reads.
+	Attributes fileref.Attributes
+
+	// Shards contains the metadata of the file shard stored on each blobber of the allocation.
+	// It's only populated if GetFileMeta is called with WithBlobberShardMeta option.
+	Shards []*BlobberShardMeta
+}
+
+// BlobberShardMeta represents the metadata of a file shard as stored on a single blobber.
+type BlobberShardMeta struct {
+	BlobberID  string
+	BlobberURL string
+	// Found is true if the blobber returned the file metadata.
+	Found bool
+	// InConsensus is true if the blobber's metadata matches the consolidated file metadata.
+	InConsensus bool
+	// Error is the error returned by the blobber, if any.
+	Error string
+
+	Hash         string
+	FileMetaHash string
+	Size         int64
+	NumBlocks    int64
+	// AllocationVersion is the allocation version (write marker) the shard was last written with.
+	AllocationVersion int64
+	// WriteMarkerRedeemTxn is the transaction that redeemed the write marker of the shard.
+	WriteMarkerRedeemTxn string
+
+	SuccessChallenges int64
+	FailedChallenges  int64
+	// LastChallengeResponseTxn is the transaction of the last challenge response for the shard.
+	LastChallengeResponseTxn string
 }
 
 type ConsolidatedFileMetaByName struct {
@@ -140,6 +184,7 @@ type ConsolidatedFileMetaByName struct {
 	Collaborators       []fileref.Collaborator
 	CreatedAt           common.Timestamp
 	UpdatedAt           common.Timestamp
+	ChunkSize           int64
 }
 
 type AllocationStats struct {
@@ -285,6 +330,14 @@ type Allocation struct {
 	// 		00100000 - 32 - rename
 	ThirdPartyExtendable bool `json:"third_party_extendable"`
 
+	// RetentionLocks are the retention locks of the allocation and its files, the locked objects
+	// can't be updated, deleted, renamed or moved until the locks expire. See SetRetentionLock.
+	RetentionLocks []RetentionLock `json:"retention_locks,omitempty"`
+
+	// PublicRead is the flag to indicate if the files of the allocation can be read by anonymous users,
+	// the reads being paid by the payer of the allocation. See GetPublicAllocation.
+	PublicRead bool `json:"public_read,omitempty"`
+
 	numBlockDownloads       int
 	downloadChan            chan *DownloadRequest
 	repairChan              chan *RepairRequest
@@ -301,8 +354,27 @@ type Allocation struct {
 	// conseususes
 	consensusThreshold int
 	fullconsensus      int
-	allocationVersion  int64
-	sig                string `json:"-"`
+	// allocationVersion is the version of the allocation, accessed atomically, see getAllocationVersion.
+	allocationVersion int64
+	sig               string `json:"-"`
+	// fs is the file system of the local files, sys.Files if nil
+	fs sys.FS
+	// packedFiles are the files packed in archive containers by remote path, guarded by mutex.
+	// See UploadArchive.
+	packedFiles map[string]*packedFile
+	// archiveDirs are the directories whose archive containers are registered in packedFiles, guarded by mutex.
+	archiveDirs map[string]bool
+	// fileLocks are the advisory locks of the files held by the allocation by remote path, guarded by
+	// mutex. See LockFile.
+	fileLocks map[string]*FileLock
+	// lifecycle is the state of the workers of the allocation, see Shutdown.
+	lifecycle *allocationLifecycle
+	// blobberLatencies are the round trip times to the blobbers by index, guarded by mutex. See
+	// ProbeBlobberLatencies.
+	blobberLatencies []time.Duration
+	// latencyProbeStarted is set once the latency probe of the first download is started, guarded by
+	// mutex. See SetLatencyProbe.
+	latencyProbeStarted bool
 }
 
 // OperationRequest represents an operation request with its related options.
@@ -326,7 +398,12 @@ type OperationRequest struct {
 	StreamUpload    bool              // Required for streaming file when actualSize is not available
 	CancelCauseFunc context.CancelCauseFunc
 	Opts            []ChunkedUploadOption
-	CopyDirOnly     bool
+	CopyDirOnly     bool               // Copies the directory itself and not its children
+	Attributes      fileref.Attributes // Required for update attributes operation
+
+	// skipRetentionLock is set on the repairs and on the operations on the children of a directory,
+	// checked already, see checkRetentionLock.
+	skipRetentionLock bool
 }
 
 // GetReadPriceRange returns the read price range from the global configuration.
@@ -431,15 +508,37 @@ func (a *Allocation) InitAllocation() {
 			}
 		}
 	}
-	a.startWorker(a.ctx)
-	InitCommitWorker(a.Blobbers)
-	InitBlockDownloader(a.Blobbers, downloadWorkerCount)
+	for _, b := range a.Blobbers {
+		zboxutil.RegisterFixtureBlobber(b.Baseurl, b.ID)
+	}
+	a.initLifecycle(allocationIdleTimeout)
 	a.CheckAllocStatus() //nolint:errcheck
 	a.initialized = true
 }
 
+// getAllocationVersion returns the version of the allocation, the version of its last commit.
+func (a *Allocation) getAllocationVersion() int64 {
+	return atomic.LoadInt64(&a.allocationVersion)
+}
+
 func (a *Allocation) isInitialized() bool {
-	return a.initialized && sdkInitialized
+	return a.initialized && sdkInitialized && a.touch()
+}
+
+// SetFS sets the file system the local files of the uploads and downloads of the allocation are read
+// from and written to, e.g. a backend on IndexedDB in browsers or on the Storage Access Framework on
+// Android. sys.Files is used by default.
+//   - fs: the file system, nil restores sys.Files
+func (a *Allocation) SetFS(fs sys.FS) {
+	a.fs = fs
+}
+
+// getFS returns the file system of the local files.
+func (a *Allocation) getFS() sys.FS {
+	if a.fs != nil {
+		return a.fs
+	}
+	return sys.Files
 }
 
 func (a *Allocation) startWorker(ctx context.Context) {
@@ -502,6 +601,36 @@ func (a *Allocation) UpdateFile(workdir, localpath string, remotepath string,
 	return a.StartChunkedUpload(workdir, localpath, remotepath, status, true, false, "", false, false)
 }
 
+// UpdateFileIfMatch updates a file only if its content is the expected one, for optimistic concurrency,
+// e.g. in sync clients. It fails with ErrPreconditionFailed if the actual hash of the remote file isn't
+// expectedHash, see WithIfMatch.
+//   - workdir: the working directory used by the chunked uploader.
+//   - localpath: the local path of the new content of the file.
+//   - remotepath: the remote path of the file.
+//   - expectedHash: the expected actual hash of the remote file, e.g. ListResult.Hash.
+//   - status: the status callback of the update.
+//   - opts: the options of the update.
+func (a *Allocation) UpdateFileIfMatch(workdir, localpath, remotepath, expectedHash string,
+	status StatusCallback, opts ...ChunkedUploadOption) error {
+	if expectedHash == "" {
+		return errors.New("invalid_hash", "the expected hash is required")
+	}
+	opts = append(opts, WithIfMatch(expectedHash))
+	return a.StartChunkedUpload(workdir, localpath, remotepath, status, true, false, "", false, false, opts...)
+}
+
+// checkIfMatch returns ErrPreconditionFailed if the actual hash of a remote file isn't the expected one.
+func (a *Allocation) checkIfMatch(remotePath, expectedHash string) error {
+	meta, err := a.GetFileMeta(remotePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to check the precondition")
+	}
+	if meta.Hash != expectedHash {
+		return errors.Wrap(ErrPreconditionFailed, fmt.Sprintf("expected hash %s, got %s", expectedHash, meta.Hash))
+	}
+	return nil
+}
+
 // UploadFile [Deprecated]please use CreateChunkedUpload
 func (a *Allocation) UploadFile(workdir, localpath string, remotepath string,
 	status StatusCallback) error {
@@ -526,6 +655,7 @@ func (a *Allocation) RepairFile(file sys.File, remotepath string, statusCallback
 		RemoteName: ref.Name,
 		RemotePath: remotepath,
 		CustomMeta: ref.CustomMeta,
+		Attributes: ref.Attributes,
 	}
 	var opts []ChunkedUploadOption
 	if ref.EncryptedKey != "" {
@@ -544,15 +674,16 @@ func (a *Allocation) RepairFile(file sys.File, remotepath string, statusCallback
 		}
 	}
 	op := &OperationRequest{
-		OperationType: constants.FileOperationInsert,
-		IsRepair:      true,
-		RemotePath:    remotepath,
-		Workdir:       idr,
-		FileMeta:      fileMeta,
-		Opts:          opts,
-		FileReader:    file,
-		Mask:          &mask,
-		EncryptedKey:  ref.EncryptedKey,
+		OperationType:     constants.FileOperationInsert,
+		IsRepair:          true,
+		RemotePath:        remotepath,
+		Workdir:           idr,
+		FileMeta:          fileMeta,
+		Opts:              opts,
+		FileReader:        file,
+		Mask:              &mask,
+		skipRetentionLock: true,
+		EncryptedKey:      ref.EncryptedKey,
 	}
 	if ref.ActualFileHash == emptyFileDataHash {
 		op.FileMeta.ActualSize = 0
@@ -664,7 +795,7 @@ func (a *Allocation) StartMultiUpload(workdir string, localPaths []string, fileN
 			err := thrown.New("invalid_path", "Path should be valid and absolute")
 			return err
 		}
-		fileReader, err := os.Open(localPath)
+		fileReader, err := a.getFS().Open(localPath)
 		if err != nil {
 			return err
 		}
@@ -767,12 +898,13 @@ func (a *Allocation) StartChunkedUpload(workdir, localPath string,
 	if !a.isInitialized() {
 		return notInitialized
 	}
+	defer a.beginOp()()
 
-	if (!isUpdate && !a.CanUpload()) || (isUpdate && !a.CanUpdate()) {
+	if !isUpdate && !a.CanUpload() {
 		return constants.ErrFileOptionNotPermitted
 	}
 
-	fileReader, err := os.Open(localPath)
+	fileReader, err := a.getFS().Open(localPath)
 	if err != nil {
 		return err
 	}
@@ -981,8 +1113,23 @@ func (a *Allocation) RepairRequired(remotepath string) (zboxutil.Uint128, zboxut
 	return found, deleteMask, !found.Equals(uploadMask), fileRef, nil
 }
 
+// UpdateObjectAttributes updates the attributes of a file of the allocation, e.g. to change who pays
+// for its reads, see WithWhoPaysForReads to set them at upload.
+//   - remotePath: the remote path of the file.
+//   - attributes: the new attributes of the file.
+func (a *Allocation) UpdateObjectAttributes(remotePath string, attributes fileref.Attributes) error {
+	return a.DoMultiOperation([]OperationRequest{{
+		OperationType: constants.FileOperationUpdateAttrs,
+		RemotePath:    remotePath,
+		Attributes:    attributes,
+	}})
+}
+
 // DoMultiOperation performs multiple operations on the allocation.
 // The operations are performed in parallel.
+// The children of a directory renamed, copied or moved are processed by pages, each page being
+// committed atomically, see WithDirOperationProgress to follow them. The directory isn't: the pages
+// committed before a failure stay processed, run the operation again to process the rest.
 //   - operations: the operations to perform.
 //   - opts: the options of the multi operation as operation functions that customize the multi operation.
 func (a *Allocation) DoMultiOperation(operations []OperationRequest, opts ...MultiOperationOption) error {
@@ -992,16 +1139,27 @@ func (a *Allocation) DoMultiOperation(operations []OperationRequest, opts ...Mul
 	if !a.isInitialized() {
 		return notInitialized
 	}
+	defer a.beginOp()()
+	for _, op := range operations {
+		if err := a.checkRetentionLock(op); err != nil {
+			return err
+		}
+	}
+	defer invalidateUsage(a.ID)
 	connectionID := zboxutil.NewConnectionId()
 	var mo MultiOperation
 	mo.allocationObj = a
 
+	// the timeout bounds all the batches
+	ctx, cancel := withTimeout(a.ctx, multiOperationTimeout(opts))
+	defer cancel()
+
 	for i := 0; i < len(operations); {
 		// resetting multi operation and previous paths for every batch
 		mo.operationMask = zboxutil.NewUint128(0)
 		mo.maskMU = &sync.Mutex{}
 		mo.connectionID = connectionID
-		mo.ctx, mo.ctxCncl = context.WithCancelCause(a.ctx)
+		mo.ctx, mo.ctxCncl = context.WithCancelCause(ctx)
 		mo.Consensus = Consensus{
 			RWMutex:         &sync.RWMutex{},
 			consensusThresh: a.consensusThreshold,
@@ -1010,6 +1168,7 @@ func (a *Allocation) DoMultiOperation(operations []OperationRequest, opts ...Mul
 		for _, opt := range opts {
 			opt(&mo)
 		}
+		mo.result.reset(a.Blobbers)
 		previousPaths := make(map[string]bool)
 		connectionErrors := make([]error, len(mo.allocationObj.Blobbers))
 
@@ -1027,6 +1186,7 @@ func (a *Allocation) DoMultiOperation(operations []OperationRequest, opts ...Mul
 		}
 		wg.Wait()
 		// Check consensus
+		mo.result.setConsensus(mo.operationMask.CountOnes(), mo.consensusThresh)
 		if mo.operationMask.CountOnes() < mo.consensusThresh {
 			l.Logger.Error("Multioperation: create connection failed. Required consensus not met",
 				zap.Int("consensusThresh", mo.consensusThresh),
@@ -1073,13 +1233,19 @@ func (a *Allocation) DoMultiOperation(operations []OperationRequest, opts ...Mul
 
 			switch op.OperationType {
 			case constants.FileOperationRename:
-				operation = NewRenameOperation(op.RemotePath, op.DestName, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, mo.ctx)
+				ro := NewRenameOperation(op.RemotePath, op.DestName, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, mo.ctx)
+				ro.progress = mo.dirProgress
+				operation = ro
 
 			case constants.FileOperationCopy:
-				operation = NewCopyOperation(op.RemotePath, op.DestPath, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, op.CopyDirOnly, mo.ctx)
+				co := NewCopyOperation(op.RemotePath, op.DestPath, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, op.CopyDirOnly, mo.ctx)
+				co.progress = mo.dirProgress
+				operation = co
 
 			case constants.FileOperationMove:
-				operation = NewMoveOperation(op.RemotePath, op.DestPath, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, mo.ctx)
+				mvo := NewMoveOperation(op.RemotePath, op.DestPath, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, mo.ctx)
+				mvo.progress = mo.dirProgress
+				operation = mvo
 
 			case constants.FileOperationInsert:
 				cancelLock.Lock()
@@ -1103,6 +1269,9 @@ func (a *Allocation) DoMultiOperation(operations []OperationRequest, opts ...Mul
 			case constants.FileOperationCreateDir:
 				operation = NewDirOperation(op.RemotePath, op.FileMeta.CustomMeta, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, mo.ctx)
 
+			case constants.FileOperationUpdateAttrs:
+				operation = NewAttributesOperation(op.RemotePath, op.Attributes, mo.operationMask, mo.maskMU, mo.consensusThresh, mo.fullconsensus, mo.ctx)
+
 			default:
 				return errors.New("invalid_operation", "Operation is not valid")
 			}
@@ -1130,7 +1299,7 @@ func (a *Allocation) DoMultiOperation(operations []OperationRequest, opts ...Mul
 			err := mo.Process()
 			if err != nil {
 				logger.Logger.Error("Error in multi operation", zap.Error(err))
-				return err
+				return timeoutError(ctx, err)
 			}
 
 			mo.operations = nil
@@ -1242,7 +1411,7 @@ func (a *Allocation) DownloadFile(localPath string, remotePath string, verifyDow
 		numBlockDownloads, verifyDownload, status, isFinal, localFilePath, downloadReqOpts...)
 	if err != nil {
 		if !toKeep {
-			os.Remove(localFilePath) //nolint: errcheck
+			a.getFS().Remove(localFilePath) //nolint: errcheck
 		}
 		f.Close() //nolint: errcheck
 		return err
@@ -1278,7 +1447,7 @@ func (a *Allocation) DownloadFileByBlock(
 		numBlockDownloads, verifyDownload, status, isFinal, localFilePath, downloadReqOpts...)
 	if err != nil {
 		if !toKeep {
-			os.Remove(localFilePath) //nolint: errcheck
+			a.getFS().Remove(localFilePath) //nolint: errcheck
 		}
 		f.Close() //nolint: errcheck
 		return err
@@ -1306,7 +1475,7 @@ func (a *Allocation) DownloadThumbnail(localPath string, remotePath string, veri
 		}))
 	if err != nil {
 		if !toKeep {
-			os.Remove(localFilePath) //nolint: errcheck
+			a.getFS().Remove(localFilePath) //nolint: errcheck
 		}
 		f.Close() //nolint: errcheck
 		return err
@@ -1333,6 +1502,7 @@ func (a *Allocation) generateDownloadRequest(
 	downloadReq.maskMu = &sync.Mutex{}
 	downloadReq.allocationID = a.ID
 	downloadReq.allocationTx = a.Tx
+	downloadReq.localFS = a.getFS()
 	downloadReq.allocOwnerID = a.Owner
 	downloadReq.sig = a.sig
 	downloadReq.allocOwnerPubKey = a.OwnerPublicKey
@@ -1340,7 +1510,7 @@ func (a *Allocation) generateDownloadRequest(
 	downloadReq.fileHandler = fileHandler
 	downloadReq.localFilePath = localFilePath
 	downloadReq.remotefilepath = remotePath
-	downloadReq.statusCallback = status
+	downloadReq.statusCallback = withEventBus(status)
 	downloadReq.downloadMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
 	downloadReq.blobbers = a.Blobbers
 	downloadReq.datashards = a.DataShards
@@ -1367,7 +1537,9 @@ func (a *Allocation) generateDownloadRequest(
 	for i := 0; i < len(a.Blobbers); i++ {
 		downloadReq.downloadQueue[i].timeTaken = 1000000
 	}
+	downloadReq.blobberLatencies = a.getBlobberLatencies()
 	downloadReq.isEnterprise = a.IsEnterprise
+	downloadReq.hooks = append([]DownloadHook(nil), getDownloadHooks()...)
 
 	return downloadReq, nil
 }
@@ -1383,12 +1555,37 @@ func (a *Allocation) addAndGenerateDownloadRequest(
 	localFilePath string,
 	downloadReqOpts ...DownloadRequestOption,
 ) error {
+	downloadPath := remotePath
+	var packedRange *byteRange
+	if contentMode == DOWNLOAD_CONTENT_FULL && startBlock <= 1 && endBlock == 0 {
+		if packed := a.resolvePackedFile(remotePath); packed != nil {
+			// the blocks of the container holding the file are downloaded and the file is extracted from them once completed.
+			fileHandler, status, packedRange = packed.downloadTarget(remotePath, fileHandler, status)
+			downloadPath, localFilePath = packed.container, ""
+		}
+	}
 	downloadReq, err := a.generateDownloadRequest(
-		fileHandler, remotePath, contentMode, startBlock, endBlock,
+		fileHandler, downloadPath, contentMode, startBlock, endBlock,
 		numBlocks, verifyDownload, status, "", localFilePath)
 	if err != nil {
 		return err
 	}
+	if packedRange != nil {
+		downloadReq.byteRange = packedRange
+		downloadReq.completedCallback = func(string, string) {
+			a.mutex.Lock()
+			defer a.mutex.Unlock()
+			delete(a.downloadProgressMap, remotePath)
+		}
+	}
+	for _, opt := range downloadReqOpts {
+		opt(downloadReq)
+	}
+	if downloadReq.checkReadPool {
+		if err = a.checkReadPool(downloadPath, startBlock, endBlock); err != nil {
+			return err
+		}
+	}
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 	if len(a.downloadRequests) > 0 {
@@ -1396,9 +1593,6 @@ func (a *Allocation) addAndGenerateDownloadRequest(
 	} else {
 		downloadReq.connectionID = zboxutil.NewConnectionId()
 	}
-	for _, opt := range downloadReqOpts {
-		opt(downloadReq)
-	}
 	downloadReq.workdir = filepath.Join(downloadReq.workdir, ".zcn")
 	a.downloadProgressMap[remotePath] = downloadReq
 	a.downloadRequests = append(a.downloadRequests, downloadReq)
@@ -1422,7 +1616,7 @@ func (a *Allocation) processReadMarker(drs []*DownloadRequest) {
 		wg.Add(1)
 		go func(dr *DownloadRequest) {
 			defer wg.Done()
-			if a.readFree {
+			if a.skipReadMarkers() {
 				dr.freeRead = true
 			}
 			dr.processDownloadRequest()
@@ -1441,7 +1635,7 @@ func (a *Allocation) processReadMarker(drs []*DownloadRequest) {
 	elapsedProcessDownloadRequest := time.Since(now)
 
 	// Do not send readmarkers for free reads
-	if a.readFree {
+	if a.skipReadMarkers() {
 		for _, dr := range drs {
 			if dr.skip {
 				continue
@@ -1500,7 +1694,7 @@ func (a *Allocation) processReadMarker(drs []*DownloadRequest) {
 	}
 }
 
-func (a *Allocation) prepareAndOpenLocalFile(localPath string, remotePath string) (*os.File, string, bool, error) {
+func (a *Allocation) prepareAndOpenLocalFile(localPath string, remotePath string) (sys.File, string, bool, error) {
 	var toKeep bool
 
 	if !a.isInitialized() {
@@ -1518,22 +1712,23 @@ func (a *Allocation) prepareAndOpenLocalFile(localPath string, remotePath string
 	}
 
 	// Create necessary directories if they do not exist
+	fs := a.getFS()
 	dir := filepath.Dir(localFilePath)
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		if err := os.MkdirAll(dir, 0744); err != nil {
+	if _, err := fs.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		if err := fs.MkdirAll(dir, 0744); err != nil {
 			return nil, "", toKeep, err
 		}
 	}
 
-	var f *os.File
-	info, err := os.Stat(localFilePath)
+	var f sys.File
+	info, err := fs.Stat(localFilePath)
 	if errors.Is(err, os.ErrNotExist) {
-		f, err = os.OpenFile(localFilePath, os.O_WRONLY|os.O_CREATE, 0644)
+		f, err = fs.OpenFile(localFilePath, os.O_WRONLY|os.O_CREATE, 0644)
 		if err != nil {
 			return nil, "", toKeep, errors.Wrap(err, "Can't create local file")
 		}
 	} else {
-		f, err = os.OpenFile(localFilePath, os.O_WRONLY, 0644)
+		f, err = fs.OpenFile(localFilePath, os.O_WRONLY, 0644)
 		if err != nil {
 			return nil, "", toKeep, errors.Wrap(err, "Can't open local file in append mode")
 		}
@@ -1581,10 +1776,14 @@ func (a *Allocation) ListDirFromAuthTicket(authTicket string, lookupHash string,
 	for _, opt := range opts {
 		opt(listReq)
 	}
+	listReq.consensusThresh = listReq.consensusPolicy.threshold(listReq.consensusThresh, listReq.fullconsensus)
+	var cancel context.CancelFunc
+	listReq.ctx, cancel = withTimeout(listReq.ctx, listReq.timeout)
+	defer cancel()
 	ref, err := listReq.GetListFromBlobbers()
 
 	if err != nil {
-		return nil, err
+		return nil, timeoutError(listReq.ctx, err)
 	}
 
 	if ref != nil {
@@ -1620,12 +1819,19 @@ func (a *Allocation) ListDir(path string, opts ...ListRequestOptions) (*ListResu
 	for _, opt := range opts {
 		opt(listReq)
 	}
+	listReq.consensusThresh = listReq.consensusPolicy.threshold(listReq.consensusThresh, listReq.fullconsensus)
+	var cancel context.CancelFunc
+	listReq.ctx, cancel = withTimeout(listReq.ctx, listReq.timeout)
+	defer cancel()
 	ref, err := listReq.GetListFromBlobbers()
 	if err != nil {
-		return nil, err
+		return nil, timeoutError(listReq.ctx, err)
 	}
 
 	if ref != nil {
+		if !listReq.rawArchives {
+			a.expandArchives(ref)
+		}
 		return ref, nil
 	}
 	return nil, errors.New("list_request_failed", "Failed to get list response from the blobbers")
@@ -1709,7 +1915,7 @@ func (a *Allocation) DownloadFromBlobber(blobberID, localPath, remotePath string
 		status, zboxutil.NewConnectionId(), localFilePath)
 	if err != nil {
 		if !toKeep {
-			os.Remove(localFilePath) //nolint: errcheck
+			a.getFS().Remove(localFilePath) //nolint: errcheck
 		}
 		f.Close() //nolint: errcheck
 		return err
@@ -1887,44 +2093,143 @@ func (a *Allocation) GetRecentlyAddedRefs(page int, fromDate int64, pageLimit in
 // GetFileMeta retrieves the file meta data of a file in the allocation.
 // The file meta data includes the file type, name, hash, lookup hash, mime type, path, size, number of blocks, encrypted key, collaborators, actual file size, actual thumbnail hash, and actual thumbnail size.
 //   - path: the path of the file to get the meta data.
-func (a *Allocation) GetFileMeta(path string) (*ConsolidatedFileMeta, error) {
+//   - opts: the options of the request, e.g. WithBlobberShardMeta to get the per-blobber shard metadata.
+func (a *Allocation) GetFileMeta(path string, opts ...FileMetaOption) (*ConsolidatedFileMeta, error) {
 	if !a.isInitialized() {
 		return nil, notInitialized
 	}
 
-	result := &ConsolidatedFileMeta{}
+	var options fileMetaOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	listReq := &ListRequest{Consensus: Consensus{RWMutex: &sync.RWMutex{}}}
 	listReq.allocationID = a.ID
 	listReq.allocationTx = a.Tx
 	listReq.sig = a.sig
 	listReq.blobbers = a.Blobbers
 	listReq.fullconsensus = a.fullconsensus
-	listReq.consensusThresh = a.consensusThreshold
-	listReq.ctx = a.ctx
+	listReq.consensusThresh = options.consensus.threshold(a.consensusThreshold, a.fullconsensus)
 	listReq.remotefilepath = path
-	_, _, ref, _ := listReq.getFileConsensusFromBlobbers()
+	ctx, cancel := withTimeout(a.ctx, options.timeout)
+	defer cancel()
+	listReq.ctx = ctx
+	foundMask, _, ref, lR := listReq.getFileConsensusFromBlobbers()
 	if ref != nil {
-		result.Type = ref.Type
-		result.Name = ref.Name
-		result.Hash = ref.ActualFileHash
-		result.LookupHash = ref.LookupHash
-		result.MimeType = ref.MimeType
-		result.Path = ref.Path
-		result.Size = ref.Size
-		result.NumBlocks = ref.NumBlocks
-		result.EncryptedKey = ref.EncryptedKey
-		result.Collaborators = ref.Collaborators
-		result.ActualFileSize = ref.ActualFileSize
-		result.ActualThumbnailHash = ref.ActualThumbnailHash
-		result.ActualThumbnailSize = ref.ActualThumbnailSize
-		if result.ActualFileSize > 0 {
-			result.ActualNumBlocks = (ref.ActualFileSize + CHUNK_SIZE - 1) / CHUNK_SIZE
+		result := consolidatedFileMetaOf(ref)
+		if options.blobberShardMeta {
+			result.Shards = getBlobberShardMeta(a.Blobbers, lR, foundMask, listReq.getFileStatsFromBlobbers())
 		}
 		return result, nil
 	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, ErrRequestTimeout
+	}
 	return nil, errors.New("file_meta_error", "Error getting the file meta data from blobbers")
 }
 
+// fileMetaBatchConcurrency is the number of paths GetFileMetaBatch looks up at the same time.
+var fileMetaBatchConcurrency = 10
+
+// FileMetaBatchError is returned by GetFileMetaBatch when the meta data of some paths couldn't be retrieved.
+type FileMetaBatchError struct {
+	// Failed are the errors by remote path.
+	Failed map[string]error
+}
+
+func (e *FileMetaBatchError) Error() string {
+	paths := make([]string, 0, len(e.Failed))
+	for p := range e.Failed {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return fmt.Sprintf("file_meta_error: %d paths failed, first: %s: %v", len(paths), paths[0], e.Failed[paths[0]])
+}
+
+// GetFileMetaBatch retrieves the file meta data of many files, e.g. to render a gallery. The paths
+// are looked up in parallel and the consensus of the blobbers is checked for each path.
+// If some paths fail, the meta data of the others is returned with a FileMetaBatchError.
+//   - paths: the remote paths of the files.
+//   - opts: the options of each lookup, e.g. WithFileMetaTimeout.
+func (a *Allocation) GetFileMetaBatch(paths []string, opts ...FileMetaOption) (map[string]*ConsolidatedFileMeta, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*ConsolidatedFileMeta, len(paths))
+		failed  = make(map[string]error)
+		seen    = make(map[string]bool, len(paths))
+		sem     = make(chan struct{}, fileMetaBatchConcurrency)
+	)
+	for _, p := range paths {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(p string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			meta, err := a.GetFileMeta(p, opts...)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[p] = err
+				return
+			}
+			results[p] = meta
+		}(p)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return results, &FileMetaBatchError{Failed: failed}
+	}
+	return results, nil
+}
+
+func consolidatedFileMetaOf(ref *fileref.FileRef) *ConsolidatedFileMeta {
+	result := &ConsolidatedFileMeta{
+		Type:                ref.Type,
+		Name:                ref.Name,
+		Hash:                ref.ActualFileHash,
+		LookupHash:          ref.LookupHash,
+		MimeType:            ref.MimeType,
+		Path:                ref.Path,
+		Size:                ref.Size,
+		NumBlocks:           ref.NumBlocks,
+		EncryptedKey:        ref.EncryptedKey,
+		Collaborators:       ref.Collaborators,
+		ActualFileSize:      ref.ActualFileSize,
+		ActualThumbnailHash: ref.ActualThumbnailHash,
+		ActualThumbnailSize: ref.ActualThumbnailSize,
+		CustomMeta:          ref.CustomMeta,
+		Attributes:          ref.Attributes,
+		ChunkSize:           ref.ChunkSize,
+	}
+	result.ActualNumBlocks = actualNumBlocks(ref.ActualFileSize, ref.ChunkSize)
+	return result
+}
+
+// actualNumBlocks returns the number of blocks of a file of size bytes uploaded with a chunk size,
+// CHUNK_SIZE if unknown.
+func actualNumBlocks(size, chunkSize int64) int64 {
+	if size <= 0 {
+		return 0
+	}
+	if chunkSize <= 0 {
+		chunkSize = CHUNK_SIZE
+	}
+	return (size + chunkSize - 1) / chunkSize
+}
+
 // GetFileMetaByName retrieve consolidated file metadata given its name (its full path starting from root "/").
 //   - fileName: full file path starting from the allocation root.
 //   - fileName: full file path starting from the allocation root.
@@ -1964,9 +2269,8 @@ func (a *Allocation) GetFileMetaByName(fileName string) ([]*ConsolidatedFileMeta
 				result.ThumbnailHash = ref.ThumbnailHash
 				result.CreatedAt = ref.CreatedAt
 				result.UpdatedAt = ref.UpdatedAt
-				if result.ActualFileSize > 0 {
-					result.ActualNumBlocks = (ref.ActualFileSize + CHUNK_SIZE - 1) / CHUNK_SIZE
-				}
+				result.ChunkSize = ref.ChunkSize
+				result.ActualNumBlocks = actualNumBlocks(ref.ActualFileSize, ref.ChunkSize)
 			}
 			resultArr = append(resultArr, result)
 		}
@@ -2036,9 +2340,8 @@ func (a *Allocation) GetFileMetaFromAuthTicket(authTicket string, lookupHash str
 		result.ActualFileSize = ref.ActualFileSize
 		result.ActualThumbnailHash = ref.ActualThumbnailHash
 		result.ActualThumbnailSize = ref.ActualThumbnailSize
-		if result.ActualFileSize > 0 {
-			result.ActualNumBlocks = (result.ActualFileSize + CHUNK_SIZE - 1) / CHUNK_SIZE
-		}
+		result.ChunkSize = ref.ChunkSize
+		result.ActualNumBlocks = actualNumBlocks(result.ActualFileSize, ref.ChunkSize)
 		return result, nil
 	}
 	return nil, errors.New("file_meta_error", "Error getting the file meta data from blobbers")
@@ -2099,6 +2402,10 @@ func (a *Allocation) deleteFile(path string, threshConsensus, fullConsensus int,
 	if !isabs {
 		return errors.New("invalid_path", "Path should be valid and absolute")
 	}
+	if err := a.retentionLockError(constants.FileOperationDelete, path); err != nil {
+		return err
+	}
+	defer invalidateUsage(a.ID)
 
 	req := &DeleteRequest{consensus: Consensus{RWMutex: &sync.RWMutex{}}}
 	req.allocationObj = a
@@ -2424,7 +2731,8 @@ func (a *Allocation) DownloadFromReader(
 	blocksPerMarker uint,
 ) error {
 
-	finfo, err := os.Stat(localPath)
+	fs := a.getFS()
+	finfo, err := fs.Stat(localPath)
 	if err != nil {
 		return err
 	}
@@ -2447,17 +2755,17 @@ func (a *Allocation) DownloadFromReader(
 		localFPath = filepath.Join(localPath, fileName)
 	}
 
-	finfo, err = os.Stat(localFPath)
+	finfo, err = fs.Stat(localFPath)
 
-	var f *os.File
+	var f sys.File
 	if errors.Is(err, os.ErrNotExist) {
-		f, err = os.Create(localFPath)
+		f, err = fs.OpenFile(localFPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
 	} else {
 		_, err = r.Seek(finfo.Size(), io.SeekStart)
 		if err != nil {
 			return err
 		}
-		f, err = os.OpenFile(localFPath, os.O_WRONLY|os.O_APPEND, 0644)
+		f, err = fs.OpenFile(localFPath, os.O_WRONLY|os.O_APPEND, 0644)
 	}
 
 	if err != nil {
@@ -2657,7 +2965,7 @@ func (a *Allocation) DownloadThumbnailFromAuthTicket(
 		DOWNLOAD_CONTENT_THUMB, verifyDownload, status, isFinal, localFilePath, downloadReqOpts...)
 	if err != nil {
 		if !toKeep {
-			os.Remove(localFilePath) //nolint: errcheck
+			a.getFS().Remove(localFilePath) //nolint: errcheck
 		}
 		f.Close() //nolint: errcheck
 		return err
@@ -2690,7 +2998,7 @@ func (a *Allocation) DownloadFromAuthTicket(localPath string, authTicket string,
 		DOWNLOAD_CONTENT_FULL, verifyDownload, status, isFinal, localFilePath, downloadReqOpts...)
 	if err != nil {
 		if !toKeep {
-			os.Remove(localFilePath) //nolint: errcheck
+			a.getFS().Remove(localFilePath) //nolint: errcheck
 		}
 		f.Close() //nolint: errcheck
 		return err
@@ -2730,7 +3038,7 @@ func (a *Allocation) DownloadFromAuthTicketByBlocks(localPath string,
 		DOWNLOAD_CONTENT_FULL, verifyDownload, status, isFinal, localFilePath, downloadReqOpts...)
 	if err != nil {
 		if !toKeep {
-			os.Remove(localFilePath) //nolint: errcheck
+			a.getFS().Remove(localFilePath) //nolint: errcheck
 		}
 		f.Close() //nolint: errcheck
 		return err
@@ -2761,6 +3069,7 @@ func (a *Allocation) downloadFromAuthTicket(fileHandler sys.File, authTicket str
 	downloadReq.maskMu = &sync.Mutex{}
 	downloadReq.allocationID = a.ID
 	downloadReq.allocationTx = a.Tx
+	downloadReq.localFS = a.getFS()
 	downloadReq.sig = a.sig
 	downloadReq.allocOwnerID = a.Owner
 	downloadReq.allocOwnerPubKey = a.OwnerPublicKey
@@ -2769,7 +3078,7 @@ func (a *Allocation) downloadFromAuthTicket(fileHandler sys.File, authTicket str
 	downloadReq.localFilePath = localFilePath
 	downloadReq.remotefilepathhash = remoteLookupHash
 	downloadReq.authTicket = at
-	downloadReq.statusCallback = status
+	downloadReq.statusCallback = withEventBus(status)
 	downloadReq.downloadMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
 	downloadReq.blobbers = a.Blobbers
 	downloadReq.datashards = a.DataShards
@@ -2786,6 +3095,7 @@ func (a *Allocation) downloadFromAuthTicket(fileHandler sys.File, authTicket str
 	for i := 0; i < len(a.Blobbers); i++ {
 		downloadReq.downloadQueue[i].timeTaken = 1000000
 	}
+	downloadReq.blobberLatencies = a.getBlobberLatencies()
 	downloadReq.connectionID = zboxutil.NewConnectionId()
 	downloadReq.completedCallback = func(remotepath string, remotepathHash string) {
 		a.mutex.Lock()
@@ -2824,8 +3134,15 @@ func (a *Allocation) downloadFromAuthTicket(fileHandler sys.File, authTicket str
 //   - pathToRepair: The path to repair in the allocation.
 //   - statusCB: A callback function to receive status updates during the repair operation.
 func (a *Allocation) StartRepair(localRootPath, pathToRepair string, statusCB StatusCallback) error {
+	_, _, err := a.startRepair(localRootPath, pathToRepair, statusCB, nil)
+	return err
+}
+
+// startRepair starts the repair of a path, the uploads of the repair being limited by bandwidth if
+// not nil. It returns the repair request and a channel closed once the repair is completed.
+func (a *Allocation) startRepair(localRootPath, pathToRepair string, statusCB StatusCallback, bandwidth *bandwidthLimiter) (*RepairRequest, <-chan struct{}, error) {
 	if !a.isInitialized() {
-		return notInitialized
+		return nil, nil, notInitialized
 	}
 
 	listDir, err := a.ListDir(pathToRepair,
@@ -2833,7 +3150,7 @@ func (a *Allocation) StartRepair(localRootPath, pathToRepair string, statusCB St
 		WithListRequestPageLimit(-1),
 	)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	a.CheckAllocStatus() //nolint:errcheck
 
@@ -2842,12 +3159,15 @@ func (a *Allocation) StartRepair(localRootPath, pathToRepair string, statusCB St
 		localRootPath: localRootPath,
 		statusCB:      statusCB,
 		repairPath:    pathToRepair,
+		bandwidth:     bandwidth,
 	}
 
+	done := make(chan struct{})
 	repairReq.completedCallback = func() {
 		a.mutex.Lock()
 		defer a.mutex.Unlock()
 		a.repairRequestInProgress = nil
+		close(done)
 	}
 
 	go func() {
@@ -2856,7 +3176,7 @@ func (a *Allocation) StartRepair(localRootPath, pathToRepair string, statusCB St
 		defer a.mutex.Unlock()
 		a.repairRequestInProgress = repairReq
 	}()
-	return nil
+	return repairReq, done, nil
 }
 
 // RepairAlloc repairs all the files in allocation
@@ -2896,6 +3216,9 @@ func (a *Allocation) RepairSize(remotePath string) (RepairSize, error) {
 
 // CancelUpload cancels the upload operation for the specified remote path.
 // It cancels the upload operation and returns an error if the remote path is not found.
+// The upload stops at the next chunk, its requests in flight are abandoned and it fails with
+// ErrCancelUpload, or an UploadCanceledError wrapping it flagging the blobbers with abandoned
+// requests. The blobbers discard the shards uploaded once the connection expires.
 //   - remotePath: The remote path to cancel the upload operation.
 func (a *Allocation) CancelUpload(remotePath string) error {
 	cancelLock.Lock()
@@ -2904,7 +3227,7 @@ func (a *Allocation) CancelUpload(remotePath string) error {
 	if !ok {
 		return errors.New("remote_path_not_found", "Invalid path. No upload in progress for the path "+remotePath)
 	} else {
-		cancelFunc(fmt.Errorf("upload canceled by user"))
+		cancelFunc(ErrCancelUpload)
 	}
 	return nil
 }
@@ -3052,6 +3375,34 @@ func (a *Allocation) GetMinStorageCost(size int64) (common.Balance, error) {
 	return a.uploadCostForBlobber(minW, size, a.DataShards, a.ParityShards), nil
 }
 
+// EstimateUploadCost returns the write cost of uploading a file of the given size to the allocation.
+// Each blobber stores a shard of the file, parity shards included, at its write price. A blobber
+// whose min lock demand isn't spent yet costs at least its unspent min lock demand.
+//   - size: The size of the file to upload.
+func (a *Allocation) EstimateUploadCost(size int64) (common.Balance, error) {
+	if size < 0 {
+		return 0, errors.New("invalid_size", "size should not be negative")
+	}
+	if a.DataShards <= 0 {
+		return 0, errors.New("invalid_allocation", "allocation has no data shards")
+	}
+
+	shardSize := a.sizeInGB((size + int64(a.DataShards) - 1) / int64(a.DataShards))
+	var cost common.Balance
+	for _, d := range a.BlobberDetails {
+		blobberCost := common.Balance(float64(d.Terms.WritePrice) * shardSize)
+		if unspent := d.MinLockDemand - d.Spent; unspent > blobberCost {
+			blobberCost = unspent
+		}
+		var err error
+		cost, err = common.AddBalance(cost, blobberCost)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return cost, nil
+}
+
 func (a *Allocation) uploadCostForBlobber(price float64, size int64, data, parity int) (
 	cost common.Balance) {
 
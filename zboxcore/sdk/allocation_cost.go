@@ -0,0 +1,135 @@
+package sdk
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// AllocationCostEstimate is the estimated cost of a new allocation, see EstimateAllocationCost.
+// The costs are in SAS.
+type AllocationCostEstimate struct {
+	// Min is the cost of the allocation on the cheapest blobbers eligible.
+	Min common.Balance `json:"min"`
+	// Avg is the cost of the allocation at the average write price of the blobbers eligible.
+	Avg common.Balance `json:"avg"`
+	// Max is the cost of the allocation on the most expensive blobbers eligible.
+	Max common.Balance `json:"max"`
+	// MinLockDemand is the minimum lock required to create the allocation, for a time unit on the
+	// most expensive blobbers eligible as the blobbers are picked among all the eligible ones.
+	MinLockDemand common.Balance `json:"min_lock_demand"`
+	// EligibleBlobbers is the number of blobbers the allocation can be created on.
+	EligibleBlobbers int `json:"eligible_blobbers"`
+	// TimeUnit is the time unit the write prices of the blobbers are for.
+	TimeUnit time.Duration `json:"time_unit"`
+}
+
+// EstimateAllocationCost estimates the cost of a new allocation from the current terms of the
+// active blobbers, before creating it with CreateAllocationWith.
+//   - dataShards: the number of data shards of the allocation.
+//   - parityShards: the number of parity shards of the allocation.
+//   - size: the size of the allocation in bytes.
+//   - duration: the duration to estimate the cost for, a time unit of the storage smart contract if 0.
+//   - readPrice: the read price range of the blobbers.
+//   - writePrice: the write price range of the blobbers.
+func EstimateAllocationCost(dataShards, parityShards int, size int64, duration time.Duration, readPrice, writePrice PriceRange) (*AllocationCostEstimate, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+	if dataShards <= 0 || parityShards < 0 {
+		return nil, errors.New("invalid_shards", "invalid number of data or parity shards")
+	}
+	if size <= 0 {
+		return nil, errors.New("invalid_size", "the size of the allocation should be positive")
+	}
+	if duration < 0 {
+		return nil, errors.New("invalid_duration", "the duration can't be negative")
+	}
+	if !readPrice.IsValid() || !writePrice.IsValid() {
+		return nil, errors.New("invalid_price_range", "invalid read or write price range")
+	}
+
+	timeUnit, err := getStorageTimeUnit()
+	if err != nil {
+		return nil, err
+	}
+	if duration == 0 {
+		duration = timeUnit
+	}
+	blobbers, err := GetBlobbers(true, false)
+	if err != nil {
+		return nil, err
+	}
+	return estimateAllocationCost(blobbers, dataShards, parityShards, size, duration, timeUnit, readPrice, writePrice)
+}
+
+// estimateAllocationCost estimates the cost of an allocation on the eligible blobbers.
+func estimateAllocationCost(blobbers []*Blobber, dataShards, parityShards int, size int64, duration, timeUnit time.Duration, readPrice, writePrice PriceRange) (*AllocationCostEstimate, error) {
+	n := dataShards + parityShards
+	blobberSize := int64(math.Ceil(float64(size) / float64(dataShards)))
+
+	var prices []common.Balance
+	for _, b := range blobbers {
+		if b.NotAvailable || b.IsShutdown || b.IsKilled || b.IsRestricted {
+			continue
+		}
+		if int64(b.Capacity-b.Allocated) < blobberSize {
+			continue
+		}
+		if uint64(b.Terms.ReadPrice) < readPrice.Min || uint64(b.Terms.ReadPrice) > readPrice.Max ||
+			uint64(b.Terms.WritePrice) < writePrice.Min || uint64(b.Terms.WritePrice) > writePrice.Max {
+			continue
+		}
+		prices = append(prices, b.Terms.WritePrice)
+	}
+	if len(prices) < n {
+		return nil, errors.Newf("not_enough_blobbers", "%d blobbers eligible, %d required", len(prices), n)
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+
+	var sum, cheapest, priciest common.Balance
+	for i, p := range prices {
+		sum += p
+		if i < n {
+			cheapest += p
+		}
+		if i >= len(prices)-n {
+			priciest += p
+		}
+	}
+
+	// the write prices are per GB and time unit.
+	sizeInGB := float64(blobberSize) / GB
+	periods := float64(duration) / float64(timeUnit)
+	cost := func(price float64) common.Balance {
+		return common.Balance(math.Ceil(price * sizeInGB * periods))
+	}
+	return &AllocationCostEstimate{
+		Min:              cost(float64(cheapest)),
+		Avg:              cost(float64(sum) / float64(len(prices)) * float64(n)),
+		Max:              cost(float64(priciest)),
+		MinLockDemand:    common.Balance(math.Ceil(float64(priciest) * sizeInGB)),
+		EligibleBlobbers: len(prices),
+		TimeUnit:         timeUnit,
+	}, nil
+}
+
+// getStorageTimeUnit gets the time unit of the storage smart contract, the write prices are for.
+func getStorageTimeUnit() (time.Duration, error) {
+	conf, err := GetStorageSCConfig()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := conf.Fields["time_unit"].(string)
+	if !ok {
+		return 0, errors.New("invalid_config", "time_unit not found in the storage SC config")
+	}
+	timeUnit, err := time.ParseDuration(v)
+	if err != nil || timeUnit <= 0 {
+		return 0, errors.New("invalid_config", "invalid time_unit of the storage SC config: "+v)
+	}
+	return timeUnit, nil
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateAllocationCost(t *testing.T) {
+	blobber := func(writePrice common.Balance, free common.Size) *Blobber {
+		return &Blobber{Terms: Terms{WritePrice: writePrice}, Capacity: 10 * GB, Allocated: 10*GB - free}
+	}
+	blobbers := []*Blobber{
+		blobber(100, GB),
+		blobber(300, GB),
+		blobber(200, 2*GB),
+		blobber(400, GB),
+		blobber(10, GB/2),   // not enough free capacity
+		blobber(1000, 5*GB), // out of the price range
+		{Terms: Terms{WritePrice: 100}, Capacity: 10 * GB, IsShutdown: true},
+	}
+	readPrice := PriceRange{Max: 100}
+	writePrice := PriceRange{Min: 50, Max: 500}
+	const unit = 720 * time.Hour
+
+	// 2 GB on 2 data shards, 1 GB by blobber, for 2 time units.
+	est, err := estimateAllocationCost(blobbers, 2, 1, 2*GB, 2*unit, unit, readPrice, writePrice)
+	require.NoError(t, err)
+	require.Equal(t, &AllocationCostEstimate{
+		Min:              1200, // (100 + 200 + 300) * 2
+		Avg:              1500, // 250 * 3 * 2
+		Max:              1800, // (200 + 300 + 400) * 2
+		MinLockDemand:    900,
+		EligibleBlobbers: 4,
+		TimeUnit:         unit,
+	}, est)
+
+	_, err = estimateAllocationCost(blobbers, 4, 1, 2*GB, unit, unit, readPrice, writePrice)
+	require.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package sdk
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/logger"
+	"go.uber.org/zap"
+)
+
+// FileEventType is the type of a change of the files of an allocation.
+type FileEventType string
+
+const (
+	// FileAdded is emitted when a file or a directory is added to the allocation.
+	FileAdded FileEventType = "file_added"
+	// FileUpdated is emitted when the content or the metadata of a file changes.
+	FileUpdated FileEventType = "file_updated"
+	// FileDeleted is emitted when a file or a directory is removed from the allocation.
+	// A renamed or moved file is deleted from its old path and added to its new path.
+	FileDeleted FileEventType = "file_deleted"
+)
+
+// DefaultFileEventPollInterval is the interval the allocation root is polled at for changes.
+const DefaultFileEventPollInterval = 30 * time.Second
+
+const fileEventBufferSize = 64
+
+// FileEvent is a change of a file or a directory of an allocation.
+type FileEvent struct {
+	Type         FileEventType `json:"type"`
+	AllocationID string        `json:"allocation_id"`
+	Path         string        `json:"path"`
+	// FileType is fileref.FILE or fileref.DIRECTORY.
+	FileType string `json:"file_type"`
+	// Hash, Size and UpdatedAt are the ones of the file after the change, before it for a deletion.
+	Hash      string           `json:"hash,omitempty"`
+	Size      int64            `json:"size"`
+	UpdatedAt common.Timestamp `json:"updated_at"`
+	// Time is the time the change was detected at.
+	Time time.Time `json:"time"`
+}
+
+type fileEventOptions struct {
+	pollInterval time.Duration
+}
+
+// FileEventOption is an option of Allocation.SubscribeEvents.
+type FileEventOption func(*fileEventOptions)
+
+// WithFileEventPollInterval sets the interval the allocation root is polled at for changes,
+// DefaultFileEventPollInterval by default.
+//   - interval: the poll interval.
+func WithFileEventPollInterval(interval time.Duration) FileEventOption {
+	return func(o *fileEventOptions) {
+		o.pollInterval = interval
+	}
+}
+
+var (
+	getAllocationRootHash = func(a *Allocation) (string, error) {
+		ref, err := a.ListDir("/")
+		if err != nil {
+			return "", err
+		}
+		return ref.Hash, nil
+	}
+	getAllocationFileMap = func(a *Allocation) (map[string]FileInfo, error) {
+		return a.GetRemoteFileMap(nil, "/")
+	}
+)
+
+// SubscribeEvents watches the files of the allocation and emits an event for every file or directory
+// added, updated or deleted, so sync clients and UIs can react to the changes without listing the
+// whole allocation themselves. The allocation root is polled and the allocation is listed again
+// only when its hash changes. The channel is closed when the context is done.
+//   - ctx: the context of the subscription, cancel it to stop watching.
+//   - opts: the options of the subscription, e.g. WithFileEventPollInterval.
+func (a *Allocation) SubscribeEvents(ctx context.Context, opts ...FileEventOption) (<-chan FileEvent, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	o := fileEventOptions{pollInterval: DefaultFileEventPollInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pollInterval <= 0 {
+		return nil, errors.New("invalid_poll_interval", "the poll interval should be positive")
+	}
+
+	rootHash, err := getAllocationRootHash(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get allocation root")
+	}
+	files, err := getAllocationFileMap(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list allocation")
+	}
+
+	ch := make(chan FileEvent, fileEventBufferSize)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(o.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			hash, err := getAllocationRootHash(a)
+			if err != nil {
+				logger.Logger.Error("failed to get allocation root", zap.String("allocation_id", a.ID), zap.Error(err))
+				continue
+			}
+			if hash != "" && hash == rootHash {
+				continue
+			}
+			current, err := getAllocationFileMap(a)
+			if err != nil {
+				logger.Logger.Error("failed to list allocation", zap.String("allocation_id", a.ID), zap.Error(err))
+				continue
+			}
+
+			for _, e := range diffFileMaps(a.ID, files, current) {
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			rootHash, files = hash, current
+		}
+	}()
+	return ch, nil
+}
+
+// diffFileMaps returns the events of the changes between two listings of an allocation, sorted by path
+// so the events of a directory come before the ones of its files. Directories are only added or deleted,
+// their hash changes with the ones of their files.
+func diffFileMaps(allocationID string, prev, current map[string]FileInfo) []FileEvent {
+	now := time.Now()
+	event := func(t FileEventType, path string, f FileInfo) FileEvent {
+		return FileEvent{
+			Type:         t,
+			AllocationID: allocationID,
+			Path:         path,
+			FileType:     f.Type,
+			Hash:         f.Hash,
+			Size:         f.ActualSize,
+			UpdatedAt:    f.UpdatedAt,
+			Time:         now,
+		}
+	}
+
+	var events []FileEvent
+	for path, f := range current {
+		old, ok := prev[path]
+		switch {
+		case !ok:
+			events = append(events, event(FileAdded, path, f))
+		case old.Type != f.Type:
+			events = append(events, event(FileDeleted, path, old), event(FileAdded, path, f))
+		case f.Type != fileref.DIRECTORY && (old.Hash != f.Hash || old.UpdatedAt != f.UpdatedAt):
+			events = append(events, event(FileUpdated, path, f))
+		}
+	}
+	for path, f := range prev {
+		if _, ok := current[path]; !ok {
+			events = append(events, event(FileDeleted, path, f))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+	return events
+}
@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationSubscribeEvents(t *testing.T) {
+	rootHash, listHash := getAllocationRootHash, getAllocationFileMap
+	defer func() {
+		getAllocationRootHash, getAllocationFileMap = rootHash, listHash
+	}()
+
+	snapshots := make(chan map[string]FileInfo, 1)
+	var listed int
+	hashes := []string{"h1", "h1", "h2"}
+	getAllocationRootHash = func(a *Allocation) (string, error) {
+		h := hashes[0]
+		if len(hashes) > 1 {
+			hashes = hashes[1:]
+		}
+		return h, nil
+	}
+	getAllocationFileMap = func(a *Allocation) (map[string]FileInfo, error) {
+		listed++
+		return <-snapshots, nil
+	}
+
+	sdkInitialized = true
+	a := &Allocation{ID: "alloc", initialized: true}
+
+	_, err := a.SubscribeEvents(context.Background(), WithFileEventPollInterval(0))
+	require.Error(t, err)
+
+	snapshots <- map[string]FileInfo{
+		"/docs":       {Type: fileref.DIRECTORY, Hash: "d1"},
+		"/docs/a.txt": {Type: fileref.FILE, Hash: "a1", ActualSize: 10},
+		"/b.txt":      {Type: fileref.FILE, Hash: "b1"},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := a.SubscribeEvents(ctx, WithFileEventPollInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	snapshots <- map[string]FileInfo{
+		"/docs":       {Type: fileref.DIRECTORY, Hash: "d2"},
+		"/docs/a.txt": {Type: fileref.FILE, Hash: "a2", ActualSize: 20},
+		"/docs/c.txt": {Type: fileref.FILE, Hash: "c1"},
+	}
+
+	var got []FileEvent
+	for len(got) < 3 {
+		got = append(got, <-events)
+	}
+	require.Equal(t, FileDeleted, got[0].Type)
+	require.Equal(t, "/b.txt", got[0].Path)
+	require.Equal(t, FileUpdated, got[1].Type)
+	require.Equal(t, "/docs/a.txt", got[1].Path)
+	require.Equal(t, int64(20), got[1].Size)
+	require.Equal(t, FileAdded, got[2].Type)
+	require.Equal(t, "/docs/c.txt", got[2].Path)
+	require.Equal(t, "alloc", got[2].AllocationID)
+
+	// the root hash didn't change since, the allocation isn't listed again
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, 2, listed)
+
+	cancel()
+	for range events {
+	}
+}
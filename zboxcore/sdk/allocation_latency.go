@@ -0,0 +1,141 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// latencyProbeCount is the number of requests of a latency probe, the fastest one is kept as the first
+// request also connects to the blobber.
+const latencyProbeCount = 2
+
+var (
+	// latencyProbeTimeout is the timeout of the latency probe run by the first download of an
+	// allocation, 0 to disable it.
+	latencyProbeTimeout time.Duration
+	// latencyProbeClient is the client of the latency probes, zboxutil.Client if nil.
+	latencyProbeClient zboxutil.HttpClient
+)
+
+// SetLatencyProbe runs a latency probe of the blobbers on the first download of the allocations, so the
+// files are downloaded from the nearest blobbers first, see Allocation.ProbeBlobberLatencies. The probe
+// runs in the background, the downloads started before it's done use the blobbers in their order.
+//   - timeout: the timeout of the requests of the probe, 0, the default, disables the probe.
+func SetLatencyProbe(timeout time.Duration) {
+	latencyProbeTimeout = timeout
+}
+
+// ProbeBlobberLatencies measures the round trip time to each blobber of the allocation, used to
+// download the files from the nearest blobbers first, and returns them by blobber id. The unreachable
+// blobbers are ranked last and left out of the result. It can be called again, e.g. once the network of
+// the device changed.
+//   - ctx: the context of the probe.
+//   - timeout: the timeout of each request of the probe.
+func (a *Allocation) ProbeBlobberLatencies(ctx context.Context, timeout time.Duration) (map[string]time.Duration, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	latencies := make([]time.Duration, len(a.Blobbers))
+	wg := &sync.WaitGroup{}
+	for i, b := range a.Blobbers {
+		wg.Add(1)
+		go func(i int, baseURL string) {
+			defer wg.Done()
+			latencies[i] = probeLatency(ctx, baseURL, timeout)
+		}(i, b.Baseurl)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	a.mutex.Lock()
+	a.blobberLatencies = latencies
+	a.mutex.Unlock()
+
+	result := make(map[string]time.Duration, len(latencies))
+	for i, latency := range latencies {
+		if latency > 0 {
+			result[a.Blobbers[i].ID] = latency
+		}
+	}
+	return result, nil
+}
+
+// probeLatency returns the round trip time to a blobber, 0 if unreachable.
+func probeLatency(ctx context.Context, baseURL string, timeout time.Duration) time.Duration {
+	httpClient := latencyProbeClient
+	if httpClient == nil {
+		httpClient = zboxutil.Client
+	}
+	var latency time.Duration
+	for i := 0; i < latencyProbeCount; i++ {
+		pctx, cncl := context.WithTimeout(ctx, timeout)
+		req, err := http.NewRequestWithContext(pctx, http.MethodGet, baseURL+"/_stats", nil)
+		if err != nil {
+			cncl()
+			break
+		}
+		start := time.Now()
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			cncl()
+			l.Logger.Debug("blobber latency probe failed: ", baseURL, " ", err)
+			continue
+		}
+		io.Copy(io.Discard, resp.Body) //nolint: errcheck
+		resp.Body.Close()
+		cncl()
+		if d := time.Since(start); latency == 0 || d < latency {
+			latency = d
+		}
+	}
+	return latency
+}
+
+// getBlobberLatencies returns the latencies of the blobbers by index, nil if not probed. The first call
+// starts the latency probe if enabled, see SetLatencyProbe.
+func (a *Allocation) getBlobberLatencies() []time.Duration {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if latencyProbeTimeout > 0 && !a.latencyProbeStarted {
+		a.latencyProbeStarted = true
+		go a.ProbeBlobberLatencies(a.ctx, latencyProbeTimeout) //nolint: errcheck
+	}
+	return a.blobberLatencies
+}
+
+// sortNearestFirst sorts the file meta responses by the latency of their blobbers, the unreachable
+// blobbers last. The order is kept if the latencies weren't probed.
+func (req *DownloadRequest) sortNearestFirst(fMetaResp []*fileMetaResponse) []*fileMetaResponse {
+	if len(req.blobberLatencies) == 0 {
+		return fMetaResp
+	}
+	rank := func(fmr *fileMetaResponse) time.Duration {
+		if fmr.blobberIdx < len(req.blobberLatencies) && req.blobberLatencies[fmr.blobberIdx] > 0 {
+			return req.blobberLatencies[fmr.blobberIdx]
+		}
+		return time.Duration(math.MaxInt64)
+	}
+	sorted := append([]*fileMetaResponse(nil), fMetaResp...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i]) < rank(sorted[j])
+	})
+	return sorted
+}
+
+// initialTimeTaken returns the initial priority of a blobber in the download queue, its latency in
+// milliseconds if probed.
+func (req *DownloadRequest) initialTimeTaken(blobberIdx int) int64 {
+	if blobberIdx < len(req.blobberLatencies) && req.blobberLatencies[blobberIdx] > 0 {
+		return req.blobberLatencies[blobberIdx].Milliseconds()
+	}
+	return 60000
+}
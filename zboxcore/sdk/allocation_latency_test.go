@@ -0,0 +1,55 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_ProbeBlobberLatencies(t *testing.T) {
+	handler := func(delay time.Duration) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(delay)
+		}
+	}
+	far := httptest.NewServer(handler(50 * time.Millisecond))
+	defer far.Close()
+	near := httptest.NewServer(handler(0))
+	defer near.Close()
+
+	prevClient := latencyProbeClient
+	latencyProbeClient = http.DefaultClient
+	defer func() { latencyProbeClient = prevClient }()
+
+	a := newLifecycleTestAllocation("latency-alloc", 0,
+		&blockchain.StorageNode{ID: "far", Baseurl: far.URL},
+		&blockchain.StorageNode{ID: "unreachable", Baseurl: "http://127.0.0.1:1"},
+		&blockchain.StorageNode{ID: "near", Baseurl: near.URL},
+	)
+	defer a.Shutdown()
+	a.initialized = true
+	sdkInitialized = true
+
+	latencies, err := a.ProbeBlobberLatencies(context.Background(), time.Second)
+	require.NoError(t, err)
+	require.Len(t, latencies, 2)
+	require.Less(t, latencies["near"], latencies["far"])
+
+	// the file is downloaded from the nearest blobbers first, the unreachable ones last.
+	req := &DownloadRequest{blobberLatencies: a.getBlobberLatencies()}
+	fMetaResp := []*fileMetaResponse{{blobberIdx: 0}, {blobberIdx: 1}, {blobberIdx: 2}}
+	sorted := req.sortNearestFirst(fMetaResp)
+	require.Equal(t, []int{2, 0, 1}, []int{sorted[0].blobberIdx, sorted[1].blobberIdx, sorted[2].blobberIdx})
+	require.Equal(t, 0, fMetaResp[0].blobberIdx)
+	require.Less(t, req.initialTimeTaken(2), req.initialTimeTaken(0))
+	require.EqualValues(t, 60000, req.initialTimeTaken(1))
+
+	// the order is kept if the latencies weren't probed.
+	req.blobberLatencies = nil
+	require.Equal(t, fMetaResp, req.sortNearestFirst(fMetaResp))
+}
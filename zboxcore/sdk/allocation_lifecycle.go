@@ -0,0 +1,226 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+var (
+	// allocationIdleTimeout is the idle timeout of the allocations, 0 to keep their workers running.
+	allocationIdleTimeout time.Duration
+
+	blobberWorkersMu sync.Mutex
+	// blobberWorkerRefs is the number of running allocations using the commit and block download
+	// workers of each blobber.
+	blobberWorkerRefs = make(map[string]int)
+)
+
+// SetAllocationIdleTimeout sets the idle timeout of the allocations initialized afterwards. Once no
+// operation ran on an allocation for the timeout, its download dispatcher is stopped and the workers
+// and connections of its blobbers are released if no other allocation uses them. The next operation
+// on the allocation restarts them. 0, the default, keeps them running until Shutdown.
+//   - timeout: the idle timeout of the allocations.
+func SetAllocationIdleTimeout(timeout time.Duration) {
+	allocationIdleTimeout = timeout
+}
+
+// allocationLifecycle is the state of the workers of an initialized allocation.
+type allocationLifecycle struct {
+	mu          sync.Mutex
+	idleTimeout time.Duration
+	idleTimer   *time.Timer
+	// lastActivity is the time of the last operation, in unix nanoseconds.
+	lastActivity atomic.Int64
+	// activeOps is the number of operations running, see beginOp.
+	activeOps atomic.Int32
+	// stopped is set once the workers are stopped, while idle or shut down.
+	stopped       atomic.Bool
+	shutdown      bool
+	cancelWorkers context.CancelFunc
+	// restart is startWorkers, called through a field as the operations would otherwise refer to it
+	// in the initialization of the package variables.
+	restart func()
+}
+
+// Shutdown stops the workers of the allocation, cancels its running operations and releases the
+// workers and connections of its blobbers no other allocation uses. The operations on the allocation
+// fail afterwards, it should be shut down once they're done, e.g. by a server dropping an allocation
+// from its cache.
+func (a *Allocation) Shutdown() {
+	lc := a.lifecycle
+	if lc == nil {
+		return
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.shutdown {
+		return
+	}
+	lc.shutdown = true
+	if !lc.stopped.Load() {
+		a.stopWorkers()
+	}
+	a.ctxCancelF()
+}
+
+// initLifecycle starts the workers of the allocation.
+//   - idleTimeout: the idle timeout of the allocation, see SetAllocationIdleTimeout.
+func (a *Allocation) initLifecycle(idleTimeout time.Duration) {
+	a.lifecycle = &allocationLifecycle{idleTimeout: idleTimeout, restart: a.startWorkers}
+	a.lifecycle.mu.Lock()
+	defer a.lifecycle.mu.Unlock()
+	a.startWorkers()
+}
+
+// startWorkers starts the download dispatcher of the allocation and acquires the workers of its
+// blobbers, with lifecycle.mu held.
+func (a *Allocation) startWorkers() {
+	lc := a.lifecycle
+	ctx, cancel := context.WithCancel(a.ctx)
+	lc.cancelWorkers = cancel
+	a.startWorker(ctx)
+	acquireBlobberWorkers(a.Blobbers)
+	trackAllocation(a)
+
+	lc.lastActivity.Store(time.Now().UnixNano())
+	lc.stopped.Store(false)
+	if lc.idleTimeout > 0 {
+		lc.idleTimer = time.AfterFunc(lc.idleTimeout, a.checkIdle)
+	}
+}
+
+// stopWorkers stops the download dispatcher of the allocation and releases the workers of its
+// blobbers, with lifecycle.mu held.
+func (a *Allocation) stopWorkers() {
+	lc := a.lifecycle
+	lc.stopped.Store(true)
+	if lc.idleTimer != nil {
+		lc.idleTimer.Stop()
+		lc.idleTimer = nil
+	}
+	lc.cancelWorkers()
+	releaseBlobberWorkers(a.Blobbers)
+	untrackAllocation(a)
+}
+
+// touch records an activity on the allocation and restarts its workers if stopped while idle. It
+// returns false once the allocation is shut down.
+func (a *Allocation) touch() bool {
+	lc := a.lifecycle
+	if lc == nil {
+		// not initialized by InitAllocation.
+		return true
+	}
+	// checkIdle sets stopped before checking the last activity, so either it sees this activity or
+	// the workers are restarted below.
+	lc.lastActivity.Store(time.Now().UnixNano())
+	if !lc.stopped.Load() {
+		return true
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.shutdown {
+		return false
+	}
+	if lc.stopped.Load() {
+		l.Logger.Info("restarting the workers of the allocation ", a.ID)
+		lc.restart()
+	}
+	return true
+}
+
+// beginOp records an operation running on the allocation, so it isn't considered idle until the
+// returned function is called.
+func (a *Allocation) beginOp() func() {
+	lc := a.lifecycle
+	if lc == nil {
+		return func() {}
+	}
+	lc.activeOps.Add(1)
+	return func() {
+		lc.lastActivity.Store(time.Now().UnixNano())
+		lc.activeOps.Add(-1)
+	}
+}
+
+// checkIdle stops the workers of the allocation if no operation ran for the idle timeout, or checks
+// again later.
+func (a *Allocation) checkIdle() {
+	lc := a.lifecycle
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.shutdown || lc.stopped.Load() {
+		return
+	}
+
+	lc.stopped.Store(true)
+	idle := time.Since(time.Unix(0, lc.lastActivity.Load()))
+	if idle < lc.idleTimeout {
+		lc.stopped.Store(false)
+		lc.idleTimer.Reset(lc.idleTimeout - idle)
+		return
+	}
+	if a.isBusy() {
+		lc.stopped.Store(false)
+		lc.idleTimer.Reset(lc.idleTimeout)
+		return
+	}
+	l.Logger.Info("stopping the workers of the idle allocation ", a.ID)
+	a.stopWorkers()
+}
+
+// isBusy returns true if operations are running or queued on the allocation.
+func (a *Allocation) isBusy() bool {
+	if a.lifecycle.activeOps.Load() > 0 || len(a.downloadChan) > 0 || len(a.repairChan) > 0 {
+		return true
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return len(a.downloadProgressMap) > 0 || len(a.downloadRequests) > 0 || a.repairRequestInProgress != nil
+}
+
+// acquireBlobberWorkers starts the commit and block download workers of blobbers, unless already
+// running for another allocation.
+func acquireBlobberWorkers(blobbers []*blockchain.StorageNode) {
+	blobberWorkersMu.Lock()
+	defer blobberWorkersMu.Unlock()
+	for _, b := range blobbers {
+		blobberWorkerRefs[b.ID]++
+	}
+	InitCommitWorker(blobbers)
+	InitBlockDownloader(blobbers, downloadWorkerCount)
+}
+
+// releaseBlobberWorkers stops the commit and block download workers of the blobbers no other
+// allocation uses, and closes their connections.
+func releaseBlobberWorkers(blobbers []*blockchain.StorageNode) {
+	blobberWorkersMu.Lock()
+	defer blobberWorkersMu.Unlock()
+	var released []*blockchain.StorageNode
+	for _, b := range blobbers {
+		refs, ok := blobberWorkerRefs[b.ID]
+		if !ok {
+			continue
+		}
+		if refs > 1 {
+			blobberWorkerRefs[b.ID] = refs - 1
+			continue
+		}
+		delete(blobberWorkerRefs, b.ID)
+		released = append(released, b)
+	}
+	if len(released) == 0 {
+		return
+	}
+
+	stopCommitWorkers(released)
+	stopBlockDownloadWorkers(released)
+	zboxutil.DefaultTransport.CloseIdleConnections()
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/mocks"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newLifecycleTestAllocation(id string, idleTimeout time.Duration, blobbers ...*blockchain.StorageNode) *Allocation {
+	a := &Allocation{
+		ID:                  id,
+		Blobbers:            blobbers,
+		downloadChan:        make(chan *DownloadRequest, 100),
+		repairChan:          make(chan *RepairRequest, 1),
+		mutex:               &sync.Mutex{},
+		downloadProgressMap: make(map[string]*DownloadRequest),
+	}
+	a.ctx, a.ctxCancelF = context.WithCancel(context.Background())
+	a.initLifecycle(idleTimeout)
+	return a
+}
+
+func hasBlobberWorkers(blobberID string) bool {
+	initCommitMutex.RLock()
+	_, commit := commitChan[blobberID]
+	initCommitMutex.RUnlock()
+	initDownloadMutex.RLock()
+	_, download := downloadBlockChan[blobberID]
+	initDownloadMutex.RUnlock()
+	return commit && download
+}
+
+func TestAllocationIdleTimeout(t *testing.T) {
+	blobber := &blockchain.StorageNode{ID: "lifecycle-idle-blobber", Baseurl: "http://127.0.0.1:1"}
+	a := newLifecycleTestAllocation("alloc", 50*time.Millisecond, blobber)
+	defer a.Shutdown()
+	require.True(t, hasBlobberWorkers(blobber.ID))
+
+	// a running operation keeps the allocation active.
+	done := a.beginOp()
+	time.Sleep(150 * time.Millisecond)
+	require.False(t, a.lifecycle.stopped.Load())
+	done()
+
+	require.Eventually(t, a.lifecycle.stopped.Load, time.Second, 10*time.Millisecond)
+	require.False(t, hasBlobberWorkers(blobber.ID))
+
+	// the next operation restarts the workers.
+	require.True(t, a.touch())
+	require.False(t, a.lifecycle.stopped.Load())
+	require.True(t, hasBlobberWorkers(blobber.ID))
+}
+
+func TestAllocationShutdown(t *testing.T) {
+	blobber := &blockchain.StorageNode{ID: "lifecycle-shutdown-blobber", Baseurl: "http://127.0.0.1:1"}
+	a1 := newLifecycleTestAllocation("alloc1", 0, blobber)
+	a2 := newLifecycleTestAllocation("alloc2", 0, blobber)
+
+	// the workers of the blobber are kept for the other allocation.
+	a1.Shutdown()
+	require.Error(t, a1.ctx.Err())
+	require.False(t, a1.touch())
+	require.True(t, hasBlobberWorkers(blobber.ID))
+
+	a2.Shutdown()
+	require.False(t, hasBlobberWorkers(blobber.ID))
+	a2.Shutdown()
+}
+
+func TestAllocationDebugTracking(t *testing.T) {
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+	tracked := func(a *Allocation) bool {
+		debugAllocationsMu.Lock()
+		defer debugAllocationsMu.Unlock()
+		return debugAllocations[a.ID] == a
+	}
+
+	blobber := &blockchain.StorageNode{ID: "lifecycle-debug-blobber", Baseurl: "http://127.0.0.1:1"}
+	a := newLifecycleTestAllocation("alloc-debug", 50*time.Millisecond, blobber)
+	defer a.Shutdown()
+	require.True(t, tracked(a))
+
+	// the allocation is released once its workers are stopped.
+	require.Eventually(t, a.lifecycle.stopped.Load, time.Second, 10*time.Millisecond)
+	require.False(t, tracked(a))
+	require.True(t, a.touch())
+	require.True(t, tracked(a))
+	a.Shutdown()
+	require.False(t, tracked(a))
+}
+
+func TestAddCommitRequestStoppedWorkers(t *testing.T) {
+	blobber := &blockchain.StorageNode{ID: "lifecycle-commit-blobber", Baseurl: "http://127.0.0.1:1"}
+	wg := &sync.WaitGroup{}
+	rawClient := zboxutil.Client
+	mockClient := &mocks.HttpClient{}
+	mockClient.On("Do", mock.Anything).Return(nil, errors.New("unreachable"))
+	zboxutil.Client = mockClient
+	defer func() { zboxutil.Client = rawClient }()
+
+	// the worker restarted for a request is released once the request is committed.
+	wg.Add(1)
+	req := &CommitRequest{blobber: blobber, wg: wg}
+	AddCommitRequest(req)
+	wg.Wait()
+	require.NotNil(t, req.result)
+	require.Eventually(t, func() bool { return !hasBlobberWorkers(blobber.ID) }, time.Second, 10*time.Millisecond)
+
+	// the workers stopped while the requests are sent don't panic.
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go AddCommitRequest(&CommitRequest{blobber: blobber, wg: wg})
+		go func() {
+			defer wg.Done()
+			acquireBlobberWorkers([]*blockchain.StorageNode{blobber})
+			releaseBlobberWorkers([]*blockchain.StorageNode{blobber})
+		}()
+	}
+	wg.Wait()
+	require.Eventually(t, func() bool { return !hasBlobberWorkers(blobber.ID) }, time.Second, 10*time.Millisecond)
+}
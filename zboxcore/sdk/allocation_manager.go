@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+)
+
+// AllocationManager caches the initialized allocations by id, so the servers serving many allocations,
+// e.g. gateways, reuse them instead of fetching and initializing them on every request. The least
+// recently used allocations are evicted once the cache is full, and shut down once released by the
+// users holding them, see Allocation.Shutdown.
+type AllocationManager struct {
+	mu    sync.Mutex
+	cache *simplelru.LRU[string, *managedAllocation]
+	// getAllocation fetches and initializes an allocation, GetAllocation unless replaced in the tests.
+	getAllocation func(allocationID string) (*Allocation, error)
+}
+
+// managedAllocation is an allocation of the cache, guarded by the mutex of the manager.
+type managedAllocation struct {
+	allocation *Allocation
+	err        error
+	// ready is closed once the allocation is fetched.
+	ready chan struct{}
+	// refs is the number of users holding the allocation.
+	refs    int
+	evicted bool
+}
+
+// NewAllocationManager creates an allocation manager.
+//   - size: the maximum number of allocations cached.
+func NewAllocationManager(size int) (*AllocationManager, error) {
+	m := &AllocationManager{getAllocation: GetAllocation}
+	cache, err := simplelru.NewLRU[string, *managedAllocation](size, m.onEvict)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid allocation cache size")
+	}
+	m.cache = cache
+	return m, nil
+}
+
+// Acquire returns the allocation from the cache, fetching and initializing it if not cached. The
+// allocation isn't shut down while held, the returned release function should be called once done
+// with it.
+//   - allocationID: the id of the allocation.
+func (m *AllocationManager) Acquire(allocationID string) (*Allocation, func(), error) {
+	m.mu.Lock()
+	ma, ok := m.cache.Get(allocationID)
+	if !ok {
+		ma = &managedAllocation{ready: make(chan struct{})}
+		m.cache.Add(allocationID, ma)
+	}
+	ma.refs++
+	m.mu.Unlock()
+
+	if !ok {
+		// the concurrent requests of the allocation wait for this fetch.
+		ma.allocation, ma.err = m.getAllocation(allocationID)
+		if ma.err != nil {
+			m.mu.Lock()
+			if cur, found := m.cache.Peek(allocationID); found && cur == ma {
+				m.cache.Remove(allocationID)
+			}
+			m.mu.Unlock()
+		}
+		close(ma.ready)
+	}
+	<-ma.ready
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { m.release(ma) })
+	}
+	if ma.err != nil {
+		release()
+		return nil, nil, ma.err
+	}
+	return ma.allocation, release, nil
+}
+
+// Evict removes an allocation from the cache, e.g. once updated or finalized. It's shut down once
+// released by its users.
+//   - allocationID: the id of the allocation.
+func (m *AllocationManager) Evict(allocationID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache.Remove(allocationID)
+}
+
+// Purge removes all the allocations from the cache, they're shut down once released by their users.
+func (m *AllocationManager) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache.Purge()
+}
+
+// Len returns the number of allocations cached.
+func (m *AllocationManager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cache.Len()
+}
+
+func (m *AllocationManager) release(ma *managedAllocation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ma.refs--
+	if ma.refs == 0 && ma.evicted {
+		ma.shutdown()
+	}
+}
+
+// onEvict is called by the cache, with the mutex held.
+func (m *AllocationManager) onEvict(_ string, ma *managedAllocation) {
+	ma.evicted = true
+	if ma.refs == 0 {
+		ma.shutdown()
+	}
+}
+
+func (ma *managedAllocation) shutdown() {
+	if ma.allocation != nil {
+		ma.allocation.Shutdown()
+	}
+}
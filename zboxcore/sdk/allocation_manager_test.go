@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAllocationManager(t *testing.T, size int) (*AllocationManager, *atomic.Int32) {
+	m, err := NewAllocationManager(size)
+	require.NoError(t, err)
+	var fetches atomic.Int32
+	m.getAllocation = func(allocationID string) (*Allocation, error) {
+		fetches.Add(1)
+		if allocationID == "missing" {
+			return nil, errors.New("allocation_fetch_error", "not found")
+		}
+		return newLifecycleTestAllocation(allocationID, 0), nil
+	}
+	return m, &fetches
+}
+
+func TestAllocationManagerAcquire(t *testing.T) {
+	m, fetches := newTestAllocationManager(t, 2)
+
+	var wg sync.WaitGroup
+	allocs := make([]*Allocation, 10)
+	errs := make([]error, len(allocs))
+	for i := range allocs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var release func()
+			allocs[i], release, errs[i] = m.Acquire("alloc1")
+			if errs[i] == nil {
+				release()
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, fetches.Load())
+	for i, a := range allocs {
+		require.NoError(t, errs[i])
+		require.Same(t, allocs[0], a)
+	}
+
+	_, _, err := m.Acquire("missing")
+	require.Error(t, err)
+	require.Equal(t, 1, m.Len())
+}
+
+func TestAllocationManagerEviction(t *testing.T) {
+	m, fetches := newTestAllocationManager(t, 2)
+
+	held, release, err := m.Acquire("alloc1")
+	require.NoError(t, err)
+	a2, release2, err := m.Acquire("alloc2")
+	require.NoError(t, err)
+	release2()
+
+	// the least recently used allocation is evicted, and shut down once released.
+	_, release3, err := m.Acquire("alloc3")
+	require.NoError(t, err)
+	defer release3()
+	require.Equal(t, 2, m.Len())
+	require.NoError(t, held.ctx.Err())
+	release()
+	release()
+	require.Error(t, held.ctx.Err())
+
+	_, release4, err := m.Acquire("alloc4")
+	require.NoError(t, err)
+	defer release4()
+	require.Error(t, a2.ctx.Err())
+
+	m.Evict("alloc4")
+	require.Equal(t, 1, m.Len())
+	_, release5, err := m.Acquire("alloc4")
+	require.NoError(t, err)
+	release5()
+	require.EqualValues(t, 5, fetches.Load())
+}
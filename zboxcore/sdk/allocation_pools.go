@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// AllocationPoolsInfo represents the token pools related to an allocation.
+type AllocationPoolsInfo struct {
+	// WritePool is the balance of the write pool of the allocation.
+	WritePool common.Balance `json:"write_pool"`
+	// WritePoolExpiration is the time the write pool tokens can be unlocked, i.e. the allocation expiration.
+	WritePoolExpiration common.Timestamp `json:"write_pool_expiration"`
+	// ReadPool is the read pool of the current client.
+	ReadPool *ReadPool `json:"read_pool"`
+	// ChallengePool is the challenge pool of the allocation.
+	ChallengePool *ChallengePoolInfo `json:"challenge_pool"`
+}
+
+// GetPoolsInfo retrieves the write pool, read pool and challenge pool balances related to the allocation.
+// The write pool balance is fetched from the latest allocation state on chain.
+func (a *Allocation) GetPoolsInfo() (*AllocationPoolsInfo, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+
+	alloc, err := GetAllocation(a.ID)
+	if err != nil {
+		return nil, err
+	}
+	a.WritePool = alloc.WritePool
+	a.Expiration = alloc.Expiration
+
+	info := &AllocationPoolsInfo{
+		WritePool:           alloc.WritePool,
+		WritePoolExpiration: common.Timestamp(alloc.Expiration),
+	}
+
+	if info.ReadPool, err = GetReadPoolInfo(""); err != nil {
+		return nil, errors.Wrap(err, "read pool")
+	}
+
+	if info.ChallengePool, err = GetChallengePoolInfo(a.ID); err != nil {
+		return nil, errors.Wrap(err, "challenge pool")
+	}
+
+	return info, nil
+}
+
+// checkWritePoolDuration checks the write pool tokens can stay locked for the given duration.
+// Write pool tokens are locked until the allocation expires, so duration can't exceed its remaining lifetime.
+func (a *Allocation) checkWritePoolDuration(duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+	if time.Now().Add(duration).Unix() > a.Expiration {
+		return errors.New("write_pool_lock", "allocation expires before the lock duration ends, update the allocation expiration first")
+	}
+	return nil
+}
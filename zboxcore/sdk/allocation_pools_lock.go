@@ -0,0 +1,51 @@
+//go:build !mobile
+// +build !mobile
+
+package sdk
+
+import (
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// LockWritePool locks tokens in the write pool of the allocation (txn: `storagesc.write_pool_lock`).
+// The tokens stay locked until the allocation expires.
+//   - tokens: number of tokens to lock, in SAS.
+//   - duration: minimum duration the tokens are expected to stay locked, zero to skip the check.
+func (a *Allocation) LockWritePool(tokens uint64, duration time.Duration) (hash string, nonce int64, err error) {
+	if !a.isInitialized() {
+		return "", 0, notInitialized
+	}
+	if err = a.checkWritePoolDuration(duration); err != nil {
+		return "", 0, err
+	}
+	return WritePoolLock(a.ID, tokens, client.TxnFee())
+}
+
+// LockReadPool locks tokens in the read pool of the current client (txn: `storagesc.read_pool_lock`).
+//   - tokens: number of tokens to lock, in SAS.
+func (a *Allocation) LockReadPool(tokens uint64) (hash string, nonce int64, err error) {
+	if !a.isInitialized() {
+		return "", 0, notInitialized
+	}
+	return ReadPoolLock(tokens, client.TxnFee())
+}
+
+// UnlockPools unlocks the read pool tokens of the current client and the write pool tokens of the allocation.
+// The write pool can only be unlocked once the allocation is finalized or canceled.
+//
+// returns the hashes of the read pool and write pool unlock transactions.
+func (a *Allocation) UnlockPools() (readPoolHash, writePoolHash string, err error) {
+	if !a.isInitialized() {
+		return "", "", notInitialized
+	}
+	if readPoolHash, _, err = ReadPoolUnlock(client.TxnFee()); err != nil {
+		return "", "", err
+	}
+	if !a.Finalized && !a.Canceled {
+		return readPoolHash, "", nil
+	}
+	writePoolHash, _, err = WritePoolUnlock(a.ID, client.TxnFee())
+	return
+}
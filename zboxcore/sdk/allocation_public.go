@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// GetPublicAllocation gets an allocation flagged for public reads, see Allocation.PublicRead. Unlike
+// GetAllocation it doesn't need a wallet, the SDK can be initialized with an empty wallet, e.g. by a
+// website serving its assets from the allocation. Without a wallet, the files are downloaded
+// anonymously: no read marker is signed, the reads being paid by the payer of the allocation.
+//   - allocationID: the id of the allocation
+func GetPublicAllocation(allocationID string) (*Allocation, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+	allocationObj, err := fetchAllocation(allocationID)
+	if err != nil {
+		return nil, err
+	}
+	if !allocationObj.PublicRead {
+		return nil, errors.New("allocation_not_public", "the allocation isn't flagged for public reads")
+	}
+	if client.GetClientID() != "" {
+		hashdata := allocationObj.Tx
+		sig, ok := zboxutil.SignCache.Get(hashdata)
+		if !ok {
+			sig, err = client.Sign(encryption.Hash(hashdata))
+			if err != nil {
+				return nil, err
+			}
+			zboxutil.SignCache.Add(hashdata, sig)
+		}
+		allocationObj.sig = sig
+	}
+	allocationObj.numBlockDownloads = numBlockDownloads
+	allocationObj.InitAllocation()
+	return allocationObj, nil
+}
+
+// isAnonymousRead returns true if the files of the allocation are read by an anonymous user, without
+// a wallet.
+func (a *Allocation) isAnonymousRead() bool {
+	return a.PublicRead && client.GetClientID() == ""
+}
+
+// skipReadMarkers returns true if the downloads don't send read markers, for the free reads or the
+// anonymous reads of a public allocation.
+func (a *Allocation) skipReadMarkers() bool {
+	return a.readFree || a.isAnonymousRead()
+}
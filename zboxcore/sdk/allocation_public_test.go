@@ -0,0 +1,25 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_SkipReadMarkers(t *testing.T) {
+	c := client.GetClient()
+	prev := c.Wallet
+	defer func() { c.Wallet = prev }()
+
+	// the anonymous users don't send read markers to the public allocations.
+	c.Wallet = &zcncrypto.Wallet{}
+	require.True(t, (&Allocation{PublicRead: true}).skipReadMarkers())
+	require.False(t, (&Allocation{}).skipReadMarkers())
+	require.True(t, (&Allocation{readFree: true}).skipReadMarkers())
+
+	// the users with a wallet pay for their reads.
+	c.Wallet = &zcncrypto.Wallet{ClientID: "reader"}
+	require.False(t, (&Allocation{PublicRead: true}).skipReadMarkers())
+}
@@ -0,0 +1,280 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+const (
+	defaultProbeCount       = 3
+	defaultProbeTimeout     = 5 * time.Second
+	defaultRecommendations  = 3
+	defaultLatencyWeight    = 0.5
+	defaultPriceWeight      = 0.3
+	defaultReputationWeight = 0.2
+)
+
+// AllocationRequirements describes the allocation a client wants to create.
+// It's used by RecommendAllocation to select and rank candidate blobbers.
+type AllocationRequirements struct {
+	DataShards   int
+	ParityShards int
+	Size         int64
+	ReadPrice    PriceRange
+	WritePrice   PriceRange
+
+	// MaxLatency excludes blobbers whose average probe latency is above it. Zero means no limit.
+	MaxLatency time.Duration
+	// ProbeCount is the number of latency probes sent to each blobber. Defaults to 3.
+	ProbeCount int
+	// ProbeTimeout is the timeout of a single probe. Defaults to 5 seconds.
+	ProbeTimeout time.Duration
+	// Recommendations is the number of ranked compositions to return. Defaults to 3.
+	Recommendations int
+
+	// LatencyWeight, PriceWeight and ReputationWeight control how the blobber score is computed.
+	// If all of them are zero, defaults of 0.5, 0.3 and 0.2 are used.
+	LatencyWeight    float64
+	PriceWeight      float64
+	ReputationWeight float64
+}
+
+// BlobberCandidate is a blobber measured from the client's location.
+type BlobberCandidate struct {
+	Blobber *Blobber
+	// Latency is the average round trip time of the successful probes.
+	Latency time.Duration
+	// Throughput is the observed download rate of the probes, in bytes per second.
+	Throughput float64
+	// Reachable is false if none of the probes succeeded.
+	Reachable bool
+	// Score is the weighted score of the blobber in range [0, 1], higher is better.
+	Score float64
+}
+
+// AllocationRecommendation is a ranked blobber composition for a new allocation.
+type AllocationRecommendation struct {
+	BlobberIDs []string
+	Blobbers   []*BlobberCandidate
+	// Score is the average score of the blobbers in the composition.
+	Score float64
+	// AverageLatency is the average latency of the blobbers in the composition.
+	AverageLatency time.Duration
+	// Cost is the estimated cost (in SAS) of storing the allocation size on this composition.
+	Cost common.Balance
+}
+
+func (r *AllocationRequirements) setDefaults() {
+	if r.ProbeCount <= 0 {
+		r.ProbeCount = defaultProbeCount
+	}
+	if r.ProbeTimeout <= 0 {
+		r.ProbeTimeout = defaultProbeTimeout
+	}
+	if r.Recommendations <= 0 {
+		r.Recommendations = defaultRecommendations
+	}
+	if r.LatencyWeight == 0 && r.PriceWeight == 0 && r.ReputationWeight == 0 {
+		r.LatencyWeight = defaultLatencyWeight
+		r.PriceWeight = defaultPriceWeight
+		r.ReputationWeight = defaultReputationWeight
+	}
+}
+
+// RecommendAllocation probes the active blobbers from the client's location and returns
+// ranked blobber compositions matching the given requirements, best first.
+// Each composition can be passed as BlobberIds of CreateAllocationOptions.
+//   - req: the allocation requirements.
+func RecommendAllocation(req AllocationRequirements) ([]*AllocationRecommendation, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+	if req.DataShards < 1 || req.ParityShards < 1 {
+		return nil, errors.New("allocation_validation_failed", "atleast 1 data and 1 parity shards are required")
+	}
+	req.setDefaults()
+
+	blobbers, err := GetBlobbers(true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := probeBlobbers(context.Background(), filterBlobbers(blobbers, &req), &req)
+	return recommendCompositions(candidates, &req)
+}
+
+// filterBlobbers drops the blobbers that can't be used for the requested allocation.
+func filterBlobbers(blobbers []*Blobber, req *AllocationRequirements) []*Blobber {
+	shardSize := (req.Size + int64(req.DataShards) - 1) / int64(req.DataShards)
+	filtered := make([]*Blobber, 0, len(blobbers))
+	for _, b := range blobbers {
+		if b.IsKilled || b.IsShutdown || b.NotAvailable || b.IsRestricted {
+			continue
+		}
+		if int64(b.Capacity)-int64(b.Allocated) < shardSize {
+			continue
+		}
+		if req.WritePrice.Max > 0 && (uint64(b.Terms.WritePrice) < req.WritePrice.Min || uint64(b.Terms.WritePrice) > req.WritePrice.Max) {
+			continue
+		}
+		if req.ReadPrice.Max > 0 && (uint64(b.Terms.ReadPrice) < req.ReadPrice.Min || uint64(b.Terms.ReadPrice) > req.ReadPrice.Max) {
+			continue
+		}
+		filtered = append(filtered, b)
+	}
+	return filtered
+}
+
+func probeBlobbers(ctx context.Context, blobbers []*Blobber, req *AllocationRequirements) []*BlobberCandidate {
+	candidates := make([]*BlobberCandidate, len(blobbers))
+	wg := &sync.WaitGroup{}
+	for i, b := range blobbers {
+		wg.Add(1)
+		go func(i int, b *Blobber) {
+			defer wg.Done()
+			candidates[i] = probeBlobber(ctx, b, req.ProbeCount, req.ProbeTimeout)
+		}(i, b)
+	}
+	wg.Wait()
+	return candidates
+}
+
+func probeBlobber(ctx context.Context, b *Blobber, count int, timeout time.Duration) *BlobberCandidate {
+	c := &BlobberCandidate{Blobber: b}
+	var (
+		total   time.Duration
+		bytes   int64
+		success int
+	)
+	for i := 0; i < count; i++ {
+		req, err := http.NewRequest(http.MethodGet, b.BaseURL+"/_stats", nil)
+		if err != nil {
+			break
+		}
+		pctx, cncl := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		resp, err := zboxutil.Client.Do(req.WithContext(pctx))
+		if err != nil {
+			cncl()
+			l.Logger.Debug("blobber probe failed: ", b.BaseURL, " ", err)
+			continue
+		}
+		n, _ := io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		cncl()
+		total += time.Since(start)
+		bytes += n
+		success++
+	}
+	if success == 0 {
+		return c
+	}
+	c.Reachable = true
+	c.Latency = total / time.Duration(success)
+	if total > 0 {
+		c.Throughput = float64(bytes) / total.Seconds()
+	}
+	return c
+}
+
+// scoreCandidates computes the score of each reachable candidate. Latency and price are
+// normalized against the worst candidate, reputation is based on the stake of the blobber.
+func scoreCandidates(candidates []*BlobberCandidate, req *AllocationRequirements) {
+	var maxLatency time.Duration
+	var maxPrice common.Balance
+	var maxStake int64
+	for _, c := range candidates {
+		if c.Latency > maxLatency {
+			maxLatency = c.Latency
+		}
+		if c.Blobber.Terms.WritePrice > maxPrice {
+			maxPrice = c.Blobber.Terms.WritePrice
+		}
+		if c.Blobber.TotalStake > maxStake {
+			maxStake = c.Blobber.TotalStake
+		}
+	}
+
+	totalWeight := req.LatencyWeight + req.PriceWeight + req.ReputationWeight
+	for _, c := range candidates {
+		latencyScore, priceScore, reputationScore := 1.0, 1.0, 1.0
+		if maxLatency > 0 {
+			latencyScore = 1 - float64(c.Latency)/float64(maxLatency)
+		}
+		if maxPrice > 0 {
+			priceScore = 1 - float64(c.Blobber.Terms.WritePrice)/float64(maxPrice)
+		}
+		if maxStake > 0 {
+			reputationScore = float64(c.Blobber.TotalStake) / float64(maxStake)
+		}
+		c.Score = (req.LatencyWeight*latencyScore +
+			req.PriceWeight*priceScore +
+			req.ReputationWeight*reputationScore) / totalWeight
+	}
+}
+
+// recommendCompositions ranks the candidates and builds up to req.Recommendations compositions.
+// The first composition is made of the best scored blobbers, every next one swaps its
+// weakest blobber for the next best unused candidate.
+func recommendCompositions(candidates []*BlobberCandidate, req *AllocationRequirements) ([]*AllocationRecommendation, error) {
+	usable := make([]*BlobberCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if !c.Reachable {
+			continue
+		}
+		if req.MaxLatency > 0 && c.Latency > req.MaxLatency {
+			continue
+		}
+		usable = append(usable, c)
+	}
+
+	n := req.DataShards + req.ParityShards
+	if len(usable) < n {
+		return nil, errors.Newf("not_enough_blobbers", "found %d usable blobbers, %d required", len(usable), n)
+	}
+
+	scoreCandidates(usable, req)
+	sort.SliceStable(usable, func(i, j int) bool {
+		return usable[i].Score > usable[j].Score
+	})
+
+	composition := make([]*BlobberCandidate, n)
+	copy(composition, usable[:n])
+
+	recommendations := make([]*AllocationRecommendation, 0, req.Recommendations)
+	recommendations = append(recommendations, newAllocationRecommendation(composition, req))
+	for next := n; next < len(usable) && len(recommendations) < req.Recommendations; next++ {
+		composition = append(composition[:n-1:n-1], usable[next])
+		recommendations = append(recommendations, newAllocationRecommendation(composition, req))
+	}
+	return recommendations, nil
+}
+
+func newAllocationRecommendation(blobbers []*BlobberCandidate, req *AllocationRequirements) *AllocationRecommendation {
+	r := &AllocationRecommendation{
+		BlobberIDs: make([]string, 0, len(blobbers)),
+		Blobbers:   make([]*BlobberCandidate, len(blobbers)),
+	}
+	copy(r.Blobbers, blobbers)
+
+	shardSize := (req.Size + int64(req.DataShards) - 1) / int64(req.DataShards)
+	var latency time.Duration
+	for _, c := range blobbers {
+		r.BlobberIDs = append(r.BlobberIDs, string(c.Blobber.ID))
+		r.Score += c.Score
+		latency += c.Latency
+		r.Cost += common.Balance(float64(c.Blobber.Terms.WritePrice) * float64(shardSize) / GB)
+	}
+	r.Score /= float64(len(blobbers))
+	r.AverageLatency = latency / time.Duration(len(blobbers))
+	return r
+}
@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendCompositions(t *testing.T) {
+	newCandidate := func(i int, latency time.Duration, price common.Balance, reachable bool) *BlobberCandidate {
+		return &BlobberCandidate{
+			Blobber: &Blobber{
+				ID:         common.Key("blobber" + strconv.Itoa(i)),
+				Terms:      Terms{WritePrice: price},
+				TotalStake: 100,
+			},
+			Latency:   latency,
+			Reachable: reachable,
+		}
+	}
+
+	req := &AllocationRequirements{
+		DataShards:   2,
+		ParityShards: 1,
+		Size:         2 * GB,
+	}
+	req.setDefaults()
+
+	t.Run("not enough blobbers", func(t *testing.T) {
+		candidates := []*BlobberCandidate{
+			newCandidate(0, time.Millisecond, 1, true),
+			newCandidate(1, time.Millisecond, 1, true),
+			newCandidate(2, time.Millisecond, 1, false),
+		}
+		_, err := recommendCompositions(candidates, req)
+		require.Error(t, err)
+	})
+
+	t.Run("ranked compositions", func(t *testing.T) {
+		candidates := []*BlobberCandidate{
+			newCandidate(0, 400*time.Millisecond, 10, true),
+			newCandidate(1, 10*time.Millisecond, 10, true),
+			newCandidate(2, 20*time.Millisecond, 10, true),
+			newCandidate(3, 30*time.Millisecond, 10, true),
+			newCandidate(4, 300*time.Millisecond, 10, true),
+		}
+		recs, err := recommendCompositions(candidates, req)
+		require.NoError(t, err)
+		require.Len(t, recs, 3)
+		require.Equal(t, []string{"blobber1", "blobber2", "blobber3"}, recs[0].BlobberIDs)
+		require.Equal(t, []string{"blobber1", "blobber2", "blobber4"}, recs[1].BlobberIDs)
+		require.Equal(t, []string{"blobber1", "blobber2", "blobber0"}, recs[2].BlobberIDs)
+		require.GreaterOrEqual(t, recs[0].Score, recs[1].Score)
+		require.Equal(t, common.Balance(30), recs[0].Cost)
+	})
+
+	t.Run("max latency", func(t *testing.T) {
+		r := *req
+		r.MaxLatency = 100 * time.Millisecond
+		candidates := []*BlobberCandidate{
+			newCandidate(0, 400*time.Millisecond, 10, true),
+			newCandidate(1, 10*time.Millisecond, 10, true),
+			newCandidate(2, 20*time.Millisecond, 10, true),
+			newCandidate(3, 30*time.Millisecond, 10, true),
+		}
+		recs, err := recommendCompositions(candidates, &r)
+		require.NoError(t, err)
+		require.Len(t, recs, 1)
+	})
+}
@@ -994,7 +994,9 @@ func TestAllocation_downloadFile(t *testing.T) {
 
 			f, localFilePath, _, err := a.prepareAndOpenLocalFile(tt.parameters.localPath, tt.parameters.remotePath)
 			defer func() {
-				f.Close()                //nolint: errcheck
+				if f != nil {
+					f.Close() //nolint: errcheck
+				}
 				os.Remove(localFilePath) //nolint: errcheck
 			}()
 
@@ -1770,7 +1772,9 @@ func TestAllocation_downloadFromAuthTicket(t *testing.T) {
 
 			f, localFilePath, _, err := a.prepareAndOpenLocalFile(tt.parameters.localPath, tt.parameters.remoteFilename)
 			defer func() {
-				f.Close()                   //nolint: errcheck
+				if f != nil {
+					f.Close() //nolint: errcheck
+				}
 				os.RemoveAll(mockLocalPath) //nolint: errcheck
 			}()
 
@@ -2447,3 +2451,126 @@ func getMockAuthTicket(t *testing.T) string {
 	require.NotEmptyf(t, authTicket, "unexpected empty auth ticket")
 	return authTicket
 }
+
+type recordingFS struct {
+	sys.FS
+	opened []string
+}
+
+func (r *recordingFS) OpenFile(name string, flag int, perm os.FileMode) (sys.File, error) {
+	r.opened = append(r.opened, name)
+	return r.FS.OpenFile(name, flag, perm)
+}
+
+func TestAllocation_SetFS(t *testing.T) {
+	prev := sdkInitialized
+	sdkInitialized = true
+	defer func() { sdkInitialized = prev }()
+
+	a := &Allocation{initialized: true}
+	rfs := &recordingFS{FS: sys.NewDiskFS()}
+	a.SetFS(rfs)
+
+	dir := t.TempDir()
+	f, localFilePath, toKeep, err := a.prepareAndOpenLocalFile(dir+"/sub", "/remote/a.txt")
+	require.NoError(t, err)
+	defer f.Close() //nolint: errcheck
+	require.False(t, toKeep)
+	require.Equal(t, []string{localFilePath}, rfs.opened, "the local file is opened with the fs of the allocation")
+
+	a.SetFS(nil)
+	require.Equal(t, sys.Files, a.getFS())
+}
+
+func TestAllocation_readCostForBlocks(t *testing.T) {
+	a := newTestAllocation()
+	a.DataShards = 2
+	a.ParityShards = 1
+	a.BlobberDetails = []*BlobberAllocation{
+		{Terms: Terms{ReadPrice: common.Balance(1e10)}},
+		{Terms: Terms{ReadPrice: common.Balance(3e10)}},
+		{Terms: Terms{ReadPrice: common.Balance(2e10)}},
+	}
+
+	// 1 GB read from each of the 2 most expensive blobbers
+	cost, err := a.readCostForBlocks(GB / CHUNK_SIZE)
+	require.NoError(t, err)
+	require.Equal(t, common.Balance(5e10), cost)
+
+	cost, err = a.readCostForBlocks(0)
+	require.NoError(t, err)
+	require.Zero(t, cost)
+}
+
+func TestDownloadCostEstimate_Sufficient(t *testing.T) {
+	require.True(t, (&DownloadCostEstimate{Cost: 10, ReadPoolBalance: 10}).Sufficient())
+	require.False(t, (&DownloadCostEstimate{Cost: 11, ReadPoolBalance: 10}).Sufficient())
+	require.True(t, (&DownloadCostEstimate{Cost: 11, Free: true}).Sufficient())
+}
+
+func TestAllocation_EstimateUploadCost(t *testing.T) {
+	a := newTestAllocation()
+	a.DataShards = 2
+	a.ParityShards = 1
+	a.BlobberDetails = []*BlobberAllocation{
+		{Terms: Terms{WritePrice: common.Balance(1e10)}},
+		{Terms: Terms{WritePrice: common.Balance(2e10)}},
+		{Terms: Terms{WritePrice: common.Balance(3e10)}, MinLockDemand: 5e10, Spent: 1e10},
+	}
+
+	// 1 GB shard on each blobber, the last one costs its unspent min lock demand
+	cost, err := a.EstimateUploadCost(2 * GB)
+	require.NoError(t, err)
+	require.Equal(t, common.Balance(7e10), cost)
+
+	_, err = a.EstimateUploadCost(-1)
+	require.Error(t, err)
+}
+
+func TestAllocation_GetFileMetaBatch(t *testing.T) {
+	prevSign := zclient.Sign
+	zclient.Sign = func(hash string) (string, error) { return "sig", nil }
+	defer func() { zclient.Sign = prevSign }()
+
+	var mockClient = mocks.HttpClient{}
+	rawClient := zboxutil.Client
+	zboxutil.Client = &mockClient
+	defer func() { zboxutil.Client = rawClient }()
+
+	a := &Allocation{ID: "batch_alloc", Tx: "tx", DataShards: 2, ParityShards: 1, initialized: true}
+	for i := 0; i < 3; i++ {
+		a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{ID: strconv.Itoa(i), Baseurl: "http://batch" + strconv.Itoa(i)})
+	}
+	a.ctx = context.Background()
+	a.fullconsensus, a.consensusThreshold = a.getConsensuses()
+	prevInitialized := sdkInitialized
+	sdkInitialized = true
+	defer func() { sdkInitialized = prevInitialized }()
+
+	found := map[string]string{
+		fileref.GetReferenceLookup(a.ID, "/1.jpg"): "/1.jpg",
+		fileref.GetReferenceLookup(a.ID, "/2.jpg"): "/2.jpg",
+	}
+	mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return strings.HasPrefix(req.URL.String(), "http://batch")
+	})).Return(func(req *http.Request) *http.Response {
+		if err := req.ParseMultipartForm(1 << 20); err == nil {
+			if p, ok := found[req.FormValue("path_hash")]; ok {
+				body, _ := json.Marshal(&fileref.FileRef{Ref: fileref.Ref{Type: fileref.FILE, Path: p, FileMetaHash: p}, ActualFileSize: 1})
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}
+			}
+		}
+		return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(`{"error":"not found"}`))}
+	}, nil)
+
+	metas, err := a.GetFileMetaBatch([]string{"/1.jpg", "/2.jpg", "/1.jpg", "/3.jpg"})
+	require.Len(t, metas, 2)
+	require.Equal(t, "/1.jpg", metas["/1.jpg"].Path)
+	require.Equal(t, "/2.jpg", metas["/2.jpg"].Path)
+	require.Equal(t, int64(1), metas["/2.jpg"].ActualNumBlocks)
+
+	var batchErr *FileMetaBatchError
+	require.True(t, errors.As(err, &batchErr))
+	require.Len(t, batchErr.Failed, 1)
+	require.Contains(t, batchErr.Failed, "/3.jpg")
+}
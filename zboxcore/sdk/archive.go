@@ -0,0 +1,481 @@
+package sdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+const (
+	// ArchivePrefix is the prefix of the names of the archive containers, see UploadArchive.
+	ArchivePrefix = ".zcnpack-"
+
+	// DefaultArchiveMaxFileSize is the size of the largest file packed in a container by default.
+	DefaultArchiveMaxFileSize = 256 * KB
+	// DefaultArchiveMaxSize is the maximum size of a container by default.
+	DefaultArchiveMaxSize = 16 * MB
+	// DefaultArchiveMaxEntries is the maximum number of files of a container by default.
+	DefaultArchiveMaxEntries = 1000
+
+	// archiveIndexKey is the key of the index of a container in its custom meta.
+	archiveIndexKey = "archive_index"
+	// archiveMimeType is the mime type of the containers.
+	archiveMimeType = "application/octet-stream"
+)
+
+// ArchiveFile is a file uploaded with UploadArchive.
+type ArchiveFile struct {
+	// Name is the name of the file in the remote directory.
+	Name   string
+	Reader io.Reader
+	Size   int64
+	// MimeType is detected from the extension of the file if empty.
+	MimeType string
+}
+
+// ArchiveEntry is a file packed in an archive container, at Offset in the container.
+type ArchiveEntry struct {
+	Name     string `json:"name"`
+	Offset   int64  `json:"offset"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimetype,omitempty"`
+	// Hash is the SHA-256 of the file, hex encoded.
+	Hash string `json:"hash"`
+}
+
+// ArchiveOption customizes UploadArchive.
+type ArchiveOption func(o *archiveOptions)
+
+type archiveOptions struct {
+	maxFileSize int64
+	maxSize     int64
+	maxEntries  int
+	uploadOpts  []ChunkedUploadOption
+}
+
+// WithArchiveMaxFileSize sets the size of the largest file packed in a container, the larger files
+// are uploaded as regular files.
+//   - size: the size in bytes, DefaultArchiveMaxFileSize by default.
+func WithArchiveMaxFileSize(size int64) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.maxFileSize = size
+	}
+}
+
+// WithArchiveMaxSize sets the maximum size of a container.
+//   - size: the size in bytes, DefaultArchiveMaxSize by default.
+func WithArchiveMaxSize(size int64) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.maxSize = size
+	}
+}
+
+// WithArchiveMaxEntries sets the maximum number of files of a container.
+//   - n: the number of files, DefaultArchiveMaxEntries by default.
+func WithArchiveMaxEntries(n int) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.maxEntries = n
+	}
+}
+
+// WithArchiveUploadOptions sets the options of the upload operations of the containers and of the
+// files too large to be packed, e.g. WithEncrypt.
+//   - opts: the options of the upload operations.
+func WithArchiveUploadOptions(opts ...ChunkedUploadOption) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.uploadOpts = append(o.uploadOpts, opts...)
+	}
+}
+
+// archiveContainer is a container built by packArchive.
+type archiveContainer struct {
+	data    bytes.Buffer
+	entries []ArchiveEntry
+}
+
+// packedFile is a file packed in an archive container.
+type packedFile struct {
+	ArchiveEntry
+	// container is the remote path of the container.
+	container string
+}
+
+// byteRange is a range of bytes of a remote file, the download of the file is restricted to the blocks
+// holding it, see DownloadRequest.byteRange.
+type byteRange struct {
+	offset int64
+	size   int64
+	// start is the offset in the file of the first downloaded byte, set once the blocks are known.
+	start int64
+}
+
+// blocks returns the blocks holding the range.
+//   - blockSize: the size of the file data held by a block.
+//   - numBlocks: the number of blocks of the file.
+func (r *byteRange) blocks(blockSize, numBlocks int64) (startBlock, endBlock int64) {
+	startBlock = r.offset / blockSize
+	if startBlock >= numBlocks {
+		// an empty range at the end of the file
+		startBlock = numBlocks - 1
+	}
+	if startBlock < 0 {
+		startBlock = 0
+	}
+	endBlock = (r.offset + r.size + blockSize - 1) / blockSize
+	if endBlock <= startBlock {
+		endBlock = startBlock + 1
+	}
+	r.start = startBlock * blockSize
+	return startBlock, endBlock
+}
+
+// UploadArchive uploads many small files to a directory with a much lower per-file overhead. The files
+// not larger than the maximum file size are packed in container files named with ArchivePrefix, whose
+// custom meta holds the index of the packed files. The larger files are uploaded as regular files.
+//
+// ListDir lists the packed files like regular files, with the path of their container in
+// ListResult.Archive. DownloadFile looks up the indexes of the containers of a directory the first time
+// a file of it is downloaded, and downloads only the blocks of the container holding the file. The packed files can't be updated, renamed, moved or deleted on
+// their own, only their container.
+//   - workdir: the working directory used by the chunked uploader.
+//   - remoteDir: the absolute remote directory the files are uploaded to.
+//   - files: the files to upload.
+//   - opts: the options of the archive, see ArchiveOption.
+//
+// returns the remote paths of the containers.
+func (a *Allocation) UploadArchive(workdir, remoteDir string, files []ArchiveFile, opts ...ArchiveOption) ([]string, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if !a.CanUpload() {
+		return nil, constants.ErrFileOptionNotPermitted
+	}
+	remoteDir = zboxutil.RemoteClean(remoteDir)
+	if !zboxutil.IsRemoteAbs(remoteDir) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	o := archiveOptions{
+		maxFileSize: DefaultArchiveMaxFileSize,
+		maxSize:     DefaultArchiveMaxSize,
+		maxEntries:  DefaultArchiveMaxEntries,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxFileSize > o.maxSize {
+		return nil, errors.New("invalid_archive_options", "the maximum file size exceeds the maximum container size")
+	}
+
+	containers, large, err := packArchive(files, o)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]OperationRequest, 0, len(containers)+len(large))
+	paths := make([]string, 0, len(containers))
+	for _, c := range containers {
+		customMeta, err := archiveCustomMeta(c.entries)
+		if err != nil {
+			return nil, err
+		}
+		name := ArchivePrefix + zboxutil.NewConnectionId()
+		remotePath := path.Join(remoteDir, name)
+		paths = append(paths, remotePath)
+		ops = append(ops, OperationRequest{
+			OperationType: constants.FileOperationInsert,
+			Workdir:       workdir,
+			RemotePath:    remotePath,
+			FileMeta: FileMeta{
+				ActualSize: int64(c.data.Len()),
+				MimeType:   archiveMimeType,
+				RemoteName: name,
+				RemotePath: remotePath,
+				CustomMeta: customMeta,
+			},
+			FileReader: bytes.NewReader(c.data.Bytes()),
+			Opts:       o.uploadOpts,
+		})
+	}
+	for _, f := range large {
+		remotePath := path.Join(remoteDir, f.Name)
+		ops = append(ops, OperationRequest{
+			OperationType: constants.FileOperationInsert,
+			Workdir:       workdir,
+			RemotePath:    remotePath,
+			FileMeta: FileMeta{
+				ActualSize: f.Size,
+				MimeType:   f.MimeType,
+				RemoteName: f.Name,
+				RemotePath: remotePath,
+			},
+			FileReader: f.Reader,
+			Opts:       o.uploadOpts,
+		})
+	}
+	if err := a.DoMultiOperation(ops); err != nil {
+		return nil, err
+	}
+
+	for i, c := range containers {
+		a.addPackedFiles(paths[i], c.entries)
+	}
+	return paths, nil
+}
+
+// packArchive packs the small files in containers, and returns the files too large to be packed.
+func packArchive(files []ArchiveFile, o archiveOptions) ([]*archiveContainer, []ArchiveFile, error) {
+	var (
+		containers []*archiveContainer
+		large      []ArchiveFile
+		c          *archiveContainer
+		names      = make(map[string]bool, len(files))
+	)
+	for _, f := range files {
+		if f.Name == "" || strings.Contains(f.Name, "/") || strings.HasPrefix(f.Name, ArchivePrefix) {
+			return nil, nil, errors.New("invalid_name", "invalid archive file name: "+f.Name)
+		}
+		if names[f.Name] {
+			return nil, nil, errors.New("duplicate_name", "duplicate archive file name: "+f.Name)
+		}
+		names[f.Name] = true
+		if f.MimeType == "" {
+			f.MimeType = mime.TypeByExtension(path.Ext(f.Name))
+		}
+		if f.MimeType == "" {
+			f.MimeType = archiveMimeType
+		}
+		if f.Size > o.maxFileSize {
+			large = append(large, f)
+			continue
+		}
+
+		if c == nil || len(c.entries) >= o.maxEntries || int64(c.data.Len())+f.Size > o.maxSize {
+			c = &archiveContainer{}
+			containers = append(containers, c)
+		}
+		offset := int64(c.data.Len())
+		h := sha256.New()
+		n, err := io.Copy(io.MultiWriter(&c.data, h), io.LimitReader(f.Reader, f.Size+1))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read "+f.Name)
+		}
+		if n != f.Size {
+			return nil, nil, errors.New("invalid_size", "the size of "+f.Name+" doesn't match its content")
+		}
+		c.entries = append(c.entries, ArchiveEntry{
+			Name:     f.Name,
+			Offset:   offset,
+			Size:     f.Size,
+			MimeType: f.MimeType,
+			Hash:     hex.EncodeToString(h.Sum(nil)),
+		})
+	}
+	return containers, large, nil
+}
+
+// archiveCustomMeta returns the custom meta of a container holding its index.
+func archiveCustomMeta(entries []ArchiveEntry) (string, error) {
+	data, err := json.Marshal(map[string][]ArchiveEntry{archiveIndexKey: entries})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ArchiveIndexOf returns the index of an archive container stored in its custom meta.
+//   - customMeta: the custom meta of the container.
+func ArchiveIndexOf(customMeta string) ([]ArchiveEntry, error) {
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(customMeta), &meta); err != nil || meta[archiveIndexKey] == nil {
+		return nil, errors.New("no_archive_index", "the file isn't an archive container")
+	}
+	var entries []ArchiveEntry
+	if err := json.Unmarshal(meta[archiveIndexKey], &entries); err != nil {
+		return nil, errors.Wrap(err, "invalid archive index")
+	}
+	return entries, nil
+}
+
+// expandArchives replaces the archive containers of a directory listing with the files packed in them.
+func (a *Allocation) expandArchives(lr *ListResult) {
+	var children []*ListResult
+	for _, child := range lr.Children {
+		if child.Type != fileref.FILE || !strings.HasPrefix(child.Name, ArchivePrefix) {
+			children = append(children, child)
+			continue
+		}
+		entries, err := ArchiveIndexOf(child.CustomMeta)
+		if err != nil {
+			children = append(children, child)
+			continue
+		}
+		a.addPackedFiles(child.Path, entries)
+		for _, e := range entries {
+			remotePath := path.Join(path.Dir(child.Path), e.Name)
+			children = append(children, &ListResult{
+				Name:            e.Name,
+				Path:            remotePath,
+				Type:            fileref.FILE,
+				Size:            e.Size,
+				Hash:            e.Hash,
+				MimeType:        e.MimeType,
+				LookupHash:      fileref.GetReferenceLookup(a.ID, remotePath),
+				EncryptionKey:   child.EncryptionKey,
+				ActualSize:      e.Size,
+				ActualNumBlocks: (e.Size + CHUNK_SIZE - 1) / CHUNK_SIZE,
+				Archive:         child.Path,
+				CreatedAt:       child.CreatedAt,
+				UpdatedAt:       child.UpdatedAt,
+				Consensus:       child.Consensus,
+			})
+		}
+	}
+	lr.Children = children
+	if lr.Type == fileref.DIRECTORY && a.mutex != nil {
+		a.mutex.Lock()
+		if a.archiveDirs == nil {
+			a.archiveDirs = make(map[string]bool)
+		}
+		a.archiveDirs[zboxutil.RemoteClean(lr.Path)] = true
+		a.mutex.Unlock()
+	}
+}
+
+// addPackedFiles registers the files packed in a container, so they can be downloaded.
+func (a *Allocation) addPackedFiles(container string, entries []ArchiveEntry) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.packedFiles == nil {
+		a.packedFiles = make(map[string]*packedFile)
+	}
+	dir := path.Dir(container)
+	for _, e := range entries {
+		a.packedFiles[path.Join(dir, e.Name)] = &packedFile{ArchiveEntry: e, container: container}
+	}
+}
+
+// packedFile returns the packed file of a remote path, nil if the path isn't a known packed file.
+func (a *Allocation) packedFile(remotePath string) *packedFile {
+	if a.mutex == nil {
+		return nil
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.packedFiles[zboxutil.RemoteClean(remotePath)]
+}
+
+// resolvePackedFile returns the packed file of a remote path, nil if the path isn't a packed file.
+// The indexes of the containers of the directory are looked up from the blobbers if the directory
+// wasn't listed yet.
+func (a *Allocation) resolvePackedFile(remotePath string) *packedFile {
+	if packed := a.packedFile(remotePath); packed != nil || a.mutex == nil {
+		return packed
+	}
+	dir := path.Dir(zboxutil.RemoteClean(remotePath))
+	a.mutex.Lock()
+	listed := a.archiveDirs[dir]
+	a.mutex.Unlock()
+	if listed {
+		return nil
+	}
+	lr, err := a.ListDir(dir, WithListRequestRawArchives(true))
+	if err != nil {
+		logger.Logger.Error("archive lookup failed: ", dir, " ", err)
+		return nil
+	}
+	a.expandArchives(lr)
+	return a.packedFile(remotePath)
+}
+
+// downloadTarget returns the file handler the blocks of the container holding the packed file are
+// downloaded to, the status callback extracting the packed file from them to fileHandler once the
+// download is completed, and the range of the container to download.
+func (p *packedFile) downloadTarget(remotePath string, fileHandler sys.File, status StatusCallback) (sys.File, StatusCallback, *byteRange) {
+	container := &sys.MemFile{Name: p.Name, Mode: fs.ModePerm, ModTime: time.Now()}
+	rng := &byteRange{offset: p.Offset, size: p.Size}
+	return container, &packedStatusCallback{
+		next:        status,
+		file:        p,
+		remotePath:  remotePath,
+		container:   container,
+		rng:         rng,
+		fileHandler: fileHandler,
+	}, rng
+}
+
+// packedStatusCallback reports the download of a packed file to next, with its path, size and mime type
+// instead of the ones of its container.
+type packedStatusCallback struct {
+	next        StatusCallback
+	file        *packedFile
+	remotePath  string
+	container   *sys.MemFile
+	rng         *byteRange
+	fileHandler sys.File
+}
+
+func (cb *packedStatusCallback) Started(allocationID, _ string, op int, _ int) {
+	if cb.next != nil {
+		cb.next.Started(allocationID, cb.remotePath, op, int(cb.file.Size))
+	}
+}
+
+func (cb *packedStatusCallback) InProgress(string, string, int, int, []byte) {}
+
+func (cb *packedStatusCallback) Error(allocationID string, _ string, op int, err error) {
+	if cb.next != nil {
+		cb.next.Error(allocationID, cb.remotePath, op, err)
+	}
+}
+
+func (cb *packedStatusCallback) Completed(allocationID, _ string, _ string, _ string, _ int, op int) {
+	if err := cb.extract(); err != nil {
+		cb.Error(allocationID, "", op, err)
+		return
+	}
+	if cb.next != nil {
+		cb.next.InProgress(allocationID, cb.remotePath, op, int(cb.file.Size), nil)
+		cb.next.Completed(allocationID, cb.remotePath, cb.file.Name, cb.file.MimeType, int(cb.file.Size), op)
+	}
+}
+
+func (cb *packedStatusCallback) RepairCompleted(filesRepaired int) {
+	if cb.next != nil {
+		cb.next.RepairCompleted(filesRepaired)
+	}
+}
+
+// extract writes the packed file from the downloaded blocks of the container to the file handler and
+// verifies its hash.
+func (cb *packedStatusCallback) extract() error {
+	data := cb.container.Buffer
+	offset := cb.file.Offset - cb.rng.start
+	end := offset + cb.file.Size
+	if offset < 0 || end > int64(len(data)) {
+		return errors.New("invalid_archive", "the packed file is out of the bounds of its container")
+	}
+	data = data[offset:end]
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != cb.file.Hash {
+		return errors.New("hash_mismatch", "the hash of the packed file doesn't match its index")
+	}
+	if _, err := cb.fileHandler.Write(data); err != nil {
+		return errors.Wrap(err, "Write file failed")
+	}
+	return cb.fileHandler.Sync()
+}
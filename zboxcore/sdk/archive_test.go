@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func archiveFile(name, content string) ArchiveFile {
+	return ArchiveFile{Name: name, Reader: strings.NewReader(content), Size: int64(len(content))}
+}
+
+func TestPackArchive(t *testing.T) {
+	o := archiveOptions{maxFileSize: 4, maxSize: 8, maxEntries: 2}
+	files := []ArchiveFile{
+		archiveFile("a.txt", "aaa"),
+		archiveFile("b", "bbbb"),
+		archiveFile("c", "c"),
+		archiveFile("large", "large"),
+		archiveFile("d", "dd"),
+		archiveFile("e", "eeee"),
+	}
+	containers, large, err := packArchive(files, o)
+	require.NoError(t, err)
+	require.Len(t, large, 1)
+	require.Equal(t, "large", large[0].Name)
+	require.Equal(t, archiveMimeType, large[0].MimeType)
+
+	require.Len(t, containers, 3)
+	require.Equal(t, "aaabbbb", containers[0].data.String())
+	require.Equal(t, "cdd", containers[1].data.String())
+	require.Equal(t, "eeee", containers[2].data.String())
+
+	b := containers[0].entries[1]
+	require.Equal(t, "b", b.Name)
+	require.EqualValues(t, 3, b.Offset)
+	require.EqualValues(t, 4, b.Size)
+	sum := sha256.Sum256([]byte("bbbb"))
+	require.Equal(t, hex.EncodeToString(sum[:]), b.Hash)
+	require.Equal(t, "text/plain; charset=utf-8", containers[0].entries[0].MimeType)
+
+	_, _, err = packArchive([]ArchiveFile{archiveFile("a", "a"), archiveFile("a", "b")}, o)
+	require.Error(t, err)
+	_, _, err = packArchive([]ArchiveFile{archiveFile("dir/a", "a")}, o)
+	require.Error(t, err)
+	_, _, err = packArchive([]ArchiveFile{{Name: "a", Reader: strings.NewReader("abc"), Size: 2}}, o)
+	require.Error(t, err)
+}
+
+func TestArchiveIndexOf(t *testing.T) {
+	entries := []ArchiveEntry{{Name: "a", Offset: 0, Size: 3, Hash: "h"}}
+	customMeta, err := archiveCustomMeta(entries)
+	require.NoError(t, err)
+	got, err := ArchiveIndexOf(customMeta)
+	require.NoError(t, err)
+	require.Equal(t, entries, got)
+
+	_, err = ArchiveIndexOf(`{"chunk_checksums":{}}`)
+	require.Error(t, err)
+	_, err = ArchiveIndexOf("")
+	require.Error(t, err)
+}
+
+type recordingStatusCallback struct {
+	StatusCallback
+	path     string
+	size     int
+	mimeType string
+	err      error
+}
+
+func (cb *recordingStatusCallback) Started(_, _ string, _ int, _ int) {}
+
+func (cb *recordingStatusCallback) InProgress(_, _ string, _ int, _ int, _ []byte) {}
+
+func (cb *recordingStatusCallback) Error(_ string, filePath string, _ int, err error) {
+	cb.path, cb.err = filePath, err
+}
+
+func (cb *recordingStatusCallback) Completed(_, filePath string, _ string, mimeType string, size int, _ int) {
+	cb.path, cb.mimeType, cb.size = filePath, mimeType, size
+}
+
+func TestAllocation_PackedFiles(t *testing.T) {
+	containers, _, err := packArchive([]ArchiveFile{archiveFile("a", "aaa"), archiveFile("b.json", "{}")},
+		archiveOptions{maxFileSize: 4, maxSize: 8, maxEntries: 10})
+	require.NoError(t, err)
+	customMeta, err := archiveCustomMeta(containers[0].entries)
+	require.NoError(t, err)
+
+	a := &Allocation{ID: "alloc", mutex: &sync.Mutex{}}
+	lr := &ListResult{Children: []*ListResult{
+		{Name: "dir", Path: "/d/dir", Type: fileref.DIRECTORY},
+		{Name: ArchivePrefix + "1", Path: "/d/" + ArchivePrefix + "1", Type: fileref.FILE, CustomMeta: customMeta},
+		{Name: ArchivePrefix + "2", Path: "/d/" + ArchivePrefix + "2", Type: fileref.FILE},
+	}}
+	a.expandArchives(lr)
+	require.Len(t, lr.Children, 4)
+	require.Equal(t, "dir", lr.Children[0].Name)
+	require.Equal(t, "/d/a", lr.Children[1].Path)
+	require.Equal(t, "/d/"+ArchivePrefix+"1", lr.Children[1].Archive)
+	require.EqualValues(t, 3, lr.Children[1].ActualSize)
+	require.Equal(t, "/d/b.json", lr.Children[2].Path)
+	require.Equal(t, "application/json", lr.Children[2].MimeType)
+	require.Equal(t, ArchivePrefix+"2", lr.Children[3].Name)
+
+	require.Nil(t, a.packedFile("/d/c"))
+	packed := a.packedFile("/d/b.json")
+	require.NotNil(t, packed)
+	require.Equal(t, "/d/"+ArchivePrefix+"1", packed.container)
+
+	require.Nil(t, a.resolvePackedFile("/d/c"), "the listed directories aren't looked up again")
+
+	out := &sys.MemFile{}
+	status := &recordingStatusCallback{}
+	container, cb, rng := packed.downloadTarget("/d/b.json", out, status)
+	startBlock, endBlock := rng.blocks(2, 3)
+	require.EqualValues(t, 1, startBlock)
+	require.EqualValues(t, 3, endBlock)
+	_, err = container.Write(containers[0].data.Bytes()[rng.start:])
+	require.NoError(t, err)
+	cb.Completed("alloc", packed.container, ArchivePrefix+"1", archiveMimeType, 5, OpDownload)
+	require.NoError(t, status.err)
+	require.Equal(t, "/d/b.json", status.path)
+	require.Equal(t, 2, status.size)
+	require.Equal(t, "{}", string(out.Buffer))
+
+	out = &sys.MemFile{}
+	container, cb, rng = packed.downloadTarget("/d/b.json", out, status)
+	rng.blocks(64, 1)
+	_, err = container.Write(bytes.Repeat([]byte("x"), 5))
+	require.NoError(t, err)
+	cb.Completed("alloc", packed.container, ArchivePrefix+"1", archiveMimeType, 5, OpDownload)
+	require.Error(t, status.err)
+	require.Empty(t, out.Buffer)
+}
+
+func TestByteRange_blocks(t *testing.T) {
+	for _, tc := range []struct {
+		offset, size, numBlocks int64
+		start, end              int64
+	}{
+		{offset: 0, size: 10, numBlocks: 4, start: 0, end: 1},
+		{offset: 10, size: 10, numBlocks: 4, start: 1, end: 2},
+		{offset: 15, size: 10, numBlocks: 4, start: 1, end: 3},
+		{offset: 40, size: 0, numBlocks: 4, start: 3, end: 4},
+	} {
+		rng := &byteRange{offset: tc.offset, size: tc.size}
+		start, end := rng.blocks(10, tc.numBlocks)
+		require.Equal(t, tc.start, start)
+		require.Equal(t, tc.end, end)
+		require.Equal(t, tc.start*10, rng.start)
+	}
+}
@@ -0,0 +1,300 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/google/uuid"
+
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/zboxcore/allocationchange"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+type AttributesRequest struct {
+	allocationID   string
+	allocationTx   string
+	sig            string
+	blobbers       []*blockchain.StorageNode
+	remotefilepath string
+	attributes     fileref.Attributes
+	ctx            context.Context
+	wg             *sync.WaitGroup
+	attributesMask zboxutil.Uint128
+	maskMU         *sync.Mutex
+	connectionID   string
+	consensus      Consensus
+}
+
+func (req *AttributesRequest) getFileMetaFromBlobber(pos int) (fileRef *fileref.FileRef, err error) {
+	listReq := &ListRequest{
+		allocationID:   req.allocationID,
+		allocationTx:   req.allocationTx,
+		blobbers:       req.blobbers,
+		remotefilepath: req.remotefilepath,
+		ctx:            req.ctx,
+	}
+	respChan := make(chan *fileMetaResponse)
+	go listReq.getFileMetaInfoFromBlobber(req.blobbers[pos], pos, respChan)
+	refRes := <-respChan
+	if refRes.err != nil {
+		err = refRes.err
+		return
+	}
+	fileRef = refRes.fileref
+	return
+}
+
+func (req *AttributesRequest) updateBlobberObjectAttributes(
+	blobber *blockchain.StorageNode, blobberIdx int) (err error) {
+
+	defer func() {
+		if err != nil {
+			req.consensus.Reject(uint64(blobberIdx), err)
+			req.maskMU.Lock()
+			req.attributesMask = req.attributesMask.And(zboxutil.NewUint128(1).Lsh(uint64(blobberIdx)).Not())
+			req.maskMU.Unlock()
+		}
+	}()
+
+	attributes, err := json.Marshal(req.attributes)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal attributes")
+	}
+
+	var (
+		shouldContinue   bool
+		latestRespMsg    string
+		latestStatusCode int
+	)
+
+	for i := 0; i < 3; i++ {
+		err, shouldContinue = func() (err error, shouldContinue bool) {
+			body := new(bytes.Buffer)
+			formWriter := multipart.NewWriter(body)
+
+			err = formWriter.WriteField("connection_id", req.connectionID)
+			if err != nil {
+				return err, false
+			}
+
+			err = formWriter.WriteField("path", req.remotefilepath)
+			if err != nil {
+				return err, false
+			}
+
+			err = formWriter.WriteField("attributes", string(attributes))
+			if err != nil {
+				return err, false
+			}
+
+			formWriter.Close()
+
+			var httpreq *http.Request
+			httpreq, err = zboxutil.NewAttributesRequest(blobber.Baseurl, req.allocationID, req.allocationTx, req.sig, body)
+			if err != nil {
+				l.Logger.Error(blobber.Baseurl, "Error creating attributes request", err)
+				return
+			}
+
+			httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
+			ctx, cncl := context.WithTimeout(req.ctx, DefaultUploadTimeOut)
+			defer cncl()
+
+			var resp *http.Response
+			resp, err = zboxutil.Client.Do(httpreq.WithContext(ctx))
+			if err != nil {
+				l.Logger.Error("Update attributes: ", err)
+				return
+			}
+
+			if resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			var respBody []byte
+			respBody, err = ioutil.ReadAll(resp.Body)
+			if err != nil {
+				l.Logger.Error("Error: Resp ", err)
+				return
+			}
+
+			latestRespMsg = string(respBody)
+			latestStatusCode = resp.StatusCode
+
+			if resp.StatusCode == http.StatusOK {
+				req.consensus.Accept(uint64(blobberIdx))
+				l.Logger.Info(blobber.Baseurl, " "+req.remotefilepath, " attributes updated.")
+				return
+			}
+
+			if resp.StatusCode == http.StatusTooManyRequests {
+				l.Logger.Error("Got too many request error")
+				var r int
+				r, err = zboxutil.GetRateLimitValue(resp)
+				if err != nil {
+					l.Logger.Error(err)
+					return
+				}
+				time.Sleep(time.Duration(r) * time.Second)
+				shouldContinue = true
+				return
+			}
+			l.Logger.Error(blobber.Baseurl, "Response: ", string(respBody))
+			err = errors.New("response_error", string(respBody))
+			return
+		}()
+
+		if err != nil {
+			return
+		}
+		if shouldContinue {
+			continue
+		}
+		return
+	}
+
+	err = errors.New("unknown_issue",
+		fmt.Sprintf("last status code: %d, last response message: %s", latestStatusCode, latestRespMsg))
+	return
+}
+
+func (req *AttributesRequest) ProcessWithBlobbers() ([]fileref.RefEntity, error) {
+	var pos uint64
+	numList := len(req.blobbers)
+	objectTreeRefs := make([]fileref.RefEntity, numList)
+	blobberErrors := make([]error, numList)
+	req.wg = &sync.WaitGroup{}
+	for i := req.attributesMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
+		pos = uint64(i.TrailingZeros())
+		req.wg.Add(1)
+		go func(blobberIdx int) {
+			defer req.wg.Done()
+			refEntity, err := req.getFileMetaFromBlobber(blobberIdx)
+			if err == nil && refEntity.Type != fileref.FILE {
+				err = errors.New("invalid_operation", "attributes can only be updated on files")
+			}
+			if err == nil {
+				err = req.updateBlobberObjectAttributes(req.blobbers[blobberIdx], blobberIdx)
+			}
+			if err != nil {
+				blobberErrors[blobberIdx] = err
+				l.Logger.Debug(err.Error())
+				return
+			}
+			objectTreeRefs[blobberIdx] = refEntity
+		}(int(pos))
+	}
+	req.wg.Wait()
+
+	return objectTreeRefs, zboxutil.MajorError(blobberErrors)
+}
+
+type AttributesOperation struct {
+	remotefilepath string
+	attributes     fileref.Attributes
+	ctx            context.Context
+	ctxCncl        context.CancelFunc
+	attributesMask zboxutil.Uint128
+	maskMU         *sync.Mutex
+
+	consensus Consensus
+}
+
+func (ao *AttributesOperation) Process(allocObj *Allocation, connectionID string) ([]fileref.RefEntity, zboxutil.Uint128, error) {
+	aR := &AttributesRequest{
+		allocationID:   allocObj.ID,
+		allocationTx:   allocObj.Tx,
+		sig:            allocObj.sig,
+		connectionID:   connectionID,
+		blobbers:       allocObj.Blobbers,
+		remotefilepath: ao.remotefilepath,
+		attributes:     ao.attributes,
+		ctx:            ao.ctx,
+		attributesMask: ao.attributesMask,
+		maskMU:         ao.maskMU,
+		wg:             &sync.WaitGroup{},
+		consensus:      Consensus{RWMutex: &sync.RWMutex{}},
+	}
+	aR.consensus.fullconsensus = ao.consensus.fullconsensus
+	aR.consensus.consensusThresh = ao.consensus.consensusThresh
+
+	objectTreeRefs, err := aR.ProcessWithBlobbers()
+
+	if !aR.consensus.isConsensusOk() {
+		if err != nil {
+			return nil, aR.attributesMask, errors.New("update_attributes_failed", fmt.Sprintf("Update attributes failed. %s", err.Error()))
+		}
+
+		return nil, aR.attributesMask, aR.consensus.consensusError("Update attributes")
+	}
+	return objectTreeRefs, aR.attributesMask, nil
+}
+
+func (ao *AttributesOperation) buildChange(refs []fileref.RefEntity, uid uuid.UUID) []allocationchange.AllocationChange {
+	changes := make([]allocationchange.AllocationChange, len(refs))
+
+	for idx, ref := range refs {
+		if ref == nil {
+			changes[idx] = &allocationchange.EmptyFileChange{}
+			continue
+		}
+		newChange := &allocationchange.AttributesChange{
+			RemotePath: ao.remotefilepath,
+			Attributes: ao.attributes,
+		}
+		newChange.Operation = constants.FileOperationUpdateAttrs
+		newChange.Size = 0
+		changes[idx] = newChange
+	}
+	return changes
+}
+
+func (ao *AttributesOperation) Verify(a *Allocation) error {
+	if !a.CanUpdate() {
+		return constants.ErrFileOptionNotPermitted
+	}
+
+	if ao.remotefilepath == "" || ao.remotefilepath == "/" {
+		return errors.New("invalid_path", "Invalid path for the file")
+	}
+
+	if !zboxutil.IsRemoteAbs(ao.remotefilepath) {
+		return errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	if err := ao.attributes.Validate(); err != nil {
+		return errors.New("invalid_attributes", err.Error())
+	}
+	return nil
+}
+
+func (ao *AttributesOperation) Completed(allocObj *Allocation) {
+
+}
+
+func (ao *AttributesOperation) Error(allocObj *Allocation, consensus int, err error) {
+
+}
+
+func NewAttributesOperation(remotePath string, attributes fileref.Attributes, attributesMask zboxutil.Uint128, maskMU *sync.Mutex, consensusTh int, fullConsensus int, ctx context.Context) *AttributesOperation {
+	ao := &AttributesOperation{}
+	ao.remotefilepath = zboxutil.RemoteClean(remotePath)
+	ao.attributes = attributes
+	ao.attributesMask = attributesMask
+	ao.maskMU = maskMU
+	ao.consensus.consensusThresh = consensusTh
+	ao.consensus.fullconsensus = fullConsensus
+	ao.ctx, ao.ctxCncl = context.WithCancel(ctx)
+	return ao
+}
@@ -0,0 +1,43 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/allocationchange"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributesOperation(t *testing.T) {
+	a := &Allocation{FileOptions: 63}
+	attrs := fileref.Attributes{WhoPaysForReads: common.WhoPays3rdParty}
+	newOp := func(remotePath string, attrs fileref.Attributes) *AttributesOperation {
+		return NewAttributesOperation(remotePath, attrs, zboxutil.NewUint128(3), &sync.Mutex{}, 2, 2, context.Background())
+	}
+
+	require.NoError(t, newOp("/docs/a.txt", attrs).Verify(a))
+	require.Error(t, newOp("/", attrs).Verify(a))
+	require.Error(t, newOp("docs/a.txt", attrs).Verify(a))
+	require.Error(t, newOp("/docs/a.txt", fileref.Attributes{WhoPaysForReads: 2}).Verify(a))
+
+	file := &fileref.FileRef{Ref: fileref.Ref{Type: fileref.FILE, Name: "a.txt", Path: "/docs/a.txt"}}
+	dir := &fileref.Ref{Type: fileref.DIRECTORY, Name: "docs", Path: "/docs"}
+	dir.AddChild(file)
+	root := &fileref.Ref{Type: fileref.DIRECTORY, Name: "/", Path: "/"}
+	root.AddChild(dir)
+
+	changes := newOp("/docs/a.txt", attrs).buildChange([]fileref.RefEntity{file, nil}, uuid.New())
+	require.IsType(t, &allocationchange.EmptyFileChange{}, changes[1])
+	require.NoError(t, changes[0].ProcessChange(root, nil))
+	require.Equal(t, common.WhoPays3rdParty, file.Attributes.WhoPaysForReads)
+	require.True(t, file.HashToBeComputed)
+	require.True(t, dir.HashToBeComputed)
+
+	missing := newOp("/docs/b.txt", attrs).buildChange([]fileref.RefEntity{file}, uuid.New())
+	require.Error(t, missing[0].ProcessChange(root, nil))
+}
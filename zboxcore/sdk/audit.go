@@ -0,0 +1,230 @@
+package sdk
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// BlobberIntegrity is the integrity score of a blobber audited by AuditRandomSample.
+type BlobberIntegrity struct {
+	BlobberID string `json:"blobber_id"`
+	Baseurl   string `json:"url"`
+	// Audited is the number of blocks audited, Passed the number of them the blobber proved.
+	Audited int `json:"audited"`
+	Passed  int `json:"passed"`
+	// Score is the ratio of the blocks proved, from 0 to 1. It's 0 if no block was audited.
+	Score float64 `json:"score"`
+	// Failures are the errors of the failed audits by remote path.
+	Failures map[string]string `json:"failures,omitempty"`
+}
+
+// record records the audit of a block of a file.
+func (bi *BlobberIntegrity) record(remotePath string, err error) {
+	bi.Audited++
+	if err == nil {
+		bi.Passed++
+	} else {
+		if bi.Failures == nil {
+			bi.Failures = make(map[string]string)
+		}
+		bi.Failures[remotePath] = err.Error()
+	}
+	bi.Score = float64(bi.Passed) / float64(bi.Audited)
+}
+
+// AuditRandomSample audits the integrity of the data stored by the blobbers, before it's needed.
+// It picks n random files of the allocation and a random block of each of them on every blobber,
+// then downloads the blocks with their merkle proofs and verifies them against the validation roots
+// signed by the owner of the allocation. The blocks are downloaded, so the audit costs read tokens
+// unless the reads of the allocation are free.
+// Returns the integrity score of each blobber, in the order of the blobbers of the allocation.
+//   - n: the number of files to audit, all the files are audited if the allocation has fewer of them.
+func (a *Allocation) AuditRandomSample(n int) ([]*BlobberIntegrity, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if n <= 0 {
+		return nil, errors.New("invalid_sample_size", "the sample size must be positive")
+	}
+
+	paths, err := a.sampleFiles(n)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("no_files", "the allocation has no file to audit")
+	}
+
+	report := make([]*BlobberIntegrity, len(a.Blobbers))
+	for i, b := range a.Blobbers {
+		report[i] = &BlobberIntegrity{BlobberID: b.ID, Baseurl: b.Baseurl}
+	}
+	for _, remotePath := range paths {
+		for i, err := range a.auditFile(remotePath) {
+			report[i].record(remotePath, err)
+		}
+	}
+	return report, nil
+}
+
+// sampleFiles picks up to n random non-empty files of the allocation.
+func (a *Allocation) sampleFiles(n int) ([]string, error) {
+	ctx, cancel := context.WithCancel(a.ctx)
+	defer cancel()
+
+	var (
+		sample []string
+		seen   int
+	)
+	for ref := range a.ListObjects(ctx, "/", "", "", "", fileref.FILE, fileref.REGULAR, 0, getRefPageLimit) {
+		if ref.Err != nil {
+			return nil, ref.Err
+		}
+		if ref.ActualFileSize == 0 {
+			continue
+		}
+		seen++
+		if len(sample) < n {
+			sample = append(sample, ref.Path)
+		} else if j := rand.Intn(seen); j < n {
+			sample[j] = ref.Path
+		}
+	}
+	return sample, nil
+}
+
+// auditFile downloads a random block of a file from every blobber and verifies its merkle proof.
+// Returns the error of the audit of each blobber, nil if the blobber proved the block.
+func (a *Allocation) auditFile(remotePath string) []error {
+	req := &DownloadRequest{Consensus: Consensus{RWMutex: &sync.RWMutex{}}}
+	req.maskMu = &sync.Mutex{}
+	req.allocationID = a.ID
+	req.allocationTx = a.Tx
+	req.allocOwnerID = a.Owner
+	req.allocOwnerPubKey = a.OwnerPublicKey
+	req.sig = a.sig
+	req.blobbers = a.Blobbers
+	req.datashards = a.DataShards
+	req.remotefilepath = remotePath
+	req.remotefilepathhash = fileref.GetReferenceLookup(a.ID, remotePath)
+	req.connectionID = zboxutil.NewConnectionId()
+	req.ctx, req.ctxCncl = context.WithCancel(a.ctx)
+	defer req.ctxCncl()
+
+	listReq := &ListRequest{
+		remotefilepath:     req.remotefilepath,
+		remotefilepathhash: req.remotefilepathhash,
+		allocationID:       req.allocationID,
+		allocationTx:       req.allocationTx,
+		sig:                req.sig,
+		blobbers:           req.blobbers,
+		Consensus: Consensus{
+			RWMutex:         &sync.RWMutex{},
+			fullconsensus:   a.fullconsensus,
+			consensusThresh: a.consensusThreshold,
+		},
+		ctx: req.ctx,
+	}
+
+	errs := make([]error, len(a.Blobbers))
+	var wg sync.WaitGroup
+	for _, fmr := range listReq.getFileMetaFromBlobbers() {
+		if fmr.err != nil {
+			errs[fmr.blobberIdx] = fmr.err
+			continue
+		}
+		if fmr.fileref == nil {
+			errs[fmr.blobberIdx] = errors.New("file_not_found", "the blobber didn't return the file")
+			continue
+		}
+		wg.Add(1)
+		go func(blobberIdx int, fRef *fileref.FileRef) {
+			defer wg.Done()
+			errs[blobberIdx] = a.auditBlock(req, blobberIdx, fRef)
+			if errs[blobberIdx] != nil {
+				l.Logger.Error("audit of ", remotePath, " on blobber ", a.Blobbers[blobberIdx].Baseurl, ": ", errs[blobberIdx])
+			}
+		}(fmr.blobberIdx, fmr.fileref)
+	}
+	wg.Wait()
+	return errs
+}
+
+// auditBlock downloads a random block of the blobber's shard of a file and verifies its merkle proof.
+func (a *Allocation) auditBlock(req *DownloadRequest, blobberIdx int, fRef *fileref.FileRef) error {
+	isValid, err := sys.VerifyWith(req.allocOwnerPubKey, fRef.ActualFileHashSignature, fRef.ActualFileHash)
+	if err != nil {
+		return err
+	}
+	if !isValid {
+		return errors.New("invalid_signature", "invalid actual file hash signature")
+	}
+	req.maskMu.Lock()
+	err = req.addValidationRoot(blobberIdx, fRef)
+	bf := req.validationRoots[blobberIdx]
+	req.maskMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	effectiveBlockSize := fRef.ChunkSize
+	if fRef.EncryptedKey != "" {
+		effectiveBlockSize -= EncryptionHeaderSize + EncryptedDataPaddingSize
+	}
+	if effectiveBlockSize <= 0 {
+		return errors.New("invalid_chunk_size", "invalid chunk size of the file")
+	}
+	shardSize := (fRef.ActualFileSize + int64(a.DataShards) - 1) / int64(a.DataShards)
+	chunksPerShard := (shardSize + effectiveBlockSize - 1) / effectiveBlockSize
+	if chunksPerShard <= 0 {
+		return errors.New("empty_shard", "the blobber's shard of the file is empty")
+	}
+
+	blobber := a.Blobbers[blobberIdx]
+	if !a.skipReadMarkers() {
+		if err := req.submitReadMarker(blobber, 1); err != nil {
+			return err
+		}
+	}
+
+	rspCh := make(chan *downloadBlock, 1)
+	AddBlockDownloadReq(req.ctx, &BlockDownloadRequest{
+		allocationID:       req.allocationID,
+		allocationTx:       req.allocationTx,
+		allocOwnerID:       req.allocOwnerID,
+		blobber:            blobber,
+		blobberIdx:         blobberIdx,
+		chunkSize:          int(fRef.ChunkSize),
+		blockNum:           rand.Int63n(chunksPerShard),
+		contentMode:        DOWNLOAD_CONTENT_FULL,
+		result:             rspCh,
+		ctx:                req.ctx,
+		remotefilepathhash: req.remotefilepathhash,
+		numBlocks:          1,
+		encryptedKey:       fRef.EncryptedKey,
+		connectionID:       req.connectionID,
+		shouldVerify:       true,
+		validationRoot:     bf.validationRoot,
+		shardSize:          bf.size,
+	}, nil, int(effectiveBlockSize))
+
+	select {
+	case <-req.ctx.Done():
+		return req.ctx.Err()
+	case result := <-rspCh:
+		if !result.Success {
+			if result.err != nil {
+				return result.err
+			}
+			return errors.New("download_failed", "the blobber failed to return the block")
+		}
+	}
+	return nil
+}
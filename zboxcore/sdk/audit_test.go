@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_AuditRandomSample_Invalid(t *testing.T) {
+	a := &Allocation{}
+	_, err := a.AuditRandomSample(1)
+	require.Equal(t, notInitialized, err)
+
+	setupMockAllocation(t, a)
+	_, err = a.AuditRandomSample(0)
+	require.Error(t, err)
+}
+
+func TestBlobberIntegrity_record(t *testing.T) {
+	bi := &BlobberIntegrity{BlobberID: "b"}
+	bi.record("/a.txt", nil)
+	require.Equal(t, 1.0, bi.Score)
+	require.Empty(t, bi.Failures)
+
+	bi.record("/b.txt", errors.New("merkle_verification_failed", "invalid proof"))
+	bi.record("/c.txt", nil)
+	bi.record("/d.txt", nil)
+	require.Equal(t, 4, bi.Audited)
+	require.Equal(t, 3, bi.Passed)
+	require.Equal(t, 0.75, bi.Score)
+	require.Len(t, bi.Failures, 1)
+	require.Contains(t, bi.Failures["/b.txt"], "invalid proof")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/transaction"
 	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
 )
 
 // CreateAllocationForOwner creates a new allocation with the given options (txn: `storagesc.new_allocation_request`).
@@ -69,7 +70,8 @@ func CreateAllocationForOwner(
 }
 
 // CreateFreeAllocation creates a new free allocation (txn: `storagesc.free_allocation_request`).
-//   - marker is the marker for the free allocation.
+// The marker is checked to be issued to the current client before sending the transaction.
+//   - marker is the marker for the free allocation, see GenerateFreeStorageMarker.
 //   - value is the value of the free allocation.
 //
 // returns the hash of the transaction, the nonce of the transaction and an error if any.
@@ -78,6 +80,14 @@ func CreateFreeAllocation(marker string, value string) (string, int64, error) {
 		return "", 0, sdkNotInitialized
 	}
 
+	m, err := parseFreeStorageMarker(marker)
+	if err != nil {
+		return "", 0, err
+	}
+	if m.Recipient != client.GetClientID() {
+		return "", 0, errors.New("invalid_free_storage_marker", "marker is not issued to the current client")
+	}
+
 	recipientPublicKey := client.GetClientPublicKey()
 
 	var input = map[string]interface{}{
@@ -263,6 +273,32 @@ func StakePoolUnlock(providerType ProviderType, providerID string, fee string) (
 	return spuu.Amount, nonce, nil
 }
 
+// StakeBlobber locks tokens in the stake pool of a blobber, using the fee set during sdk initialization.
+//   - blobberID: blobber ID
+//   - tokens: number of tokens to stake, in SAS
+func StakeBlobber(blobberID string, tokens string) (hash string, nonce int64, err error) {
+	return StakePoolLock(ProviderBlobber, blobberID, tokens, strconv.FormatUint(client.TxnFee(), 10))
+}
+
+// UnstakeBlobber unlocks the tokens staked by the current client in the stake pool of a blobber.
+//   - blobberID: blobber ID
+func UnstakeBlobber(blobberID string) (unstake int64, nonce int64, err error) {
+	return StakePoolUnlock(ProviderBlobber, blobberID, strconv.FormatUint(client.TxnFee(), 10))
+}
+
+// StakeValidator locks tokens in the stake pool of a validator, using the fee set during sdk initialization.
+//   - validatorID: validator ID
+//   - tokens: number of tokens to stake, in SAS
+func StakeValidator(validatorID string, tokens string) (hash string, nonce int64, err error) {
+	return StakePoolLock(ProviderValidator, validatorID, tokens, strconv.FormatUint(client.TxnFee(), 10))
+}
+
+// UnstakeValidator unlocks the tokens staked by the current client in the stake pool of a validator.
+//   - validatorID: validator ID
+func UnstakeValidator(validatorID string) (unstake int64, nonce int64, err error) {
+	return StakePoolUnlock(ProviderValidator, validatorID, strconv.FormatUint(client.TxnFee(), 10))
+}
+
 // ReadPoolLock locks given number of tokes for given duration in read pool.
 //   - tokens: number of tokens to lock
 //   - fee: transaction fee
@@ -390,6 +426,8 @@ func smartContractTxnValueFeeWithRetry(scAddress string, sn transaction.SmartCon
 func smartContractTxnValueFee(scAddress string, sn transaction.SmartContractTxnData,
 	value, fee string) (hash, out string, nonce int64, t *transaction.Transaction, err error) {
 	t, err = ExecuteSmartContract(scAddress, sn, value, fee)
+	// the transaction may have changed the state of the smart contract
+	zboxutil.InvalidateResponseCache(scAddress)
 	if err != nil {
 		if t != nil {
 			return "", "", t.TransactionNonce, nil, err
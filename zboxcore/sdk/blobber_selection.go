@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// errBlobberNotSelected is the vote of the blobbers excluded from a request by WithExcludedBlobbers
+// or not in the blobbers of WithPinnedBlobbers.
+var errBlobberNotSelected = errors.New("blobber_not_selected", "the blobber is excluded from the request")
+
+// blobberSelection restricts the blobbers a request is sent to. The zero value selects all the blobbers.
+type blobberSelection struct {
+	excluded map[string]bool
+	pinned   map[string]bool
+}
+
+func (s *blobberSelection) exclude(ids []string) {
+	if s.excluded == nil {
+		s.excluded = make(map[string]bool, len(ids))
+	}
+	for _, id := range ids {
+		s.excluded[id] = true
+	}
+}
+
+func (s *blobberSelection) pin(ids []string) {
+	if s.pinned == nil {
+		s.pinned = make(map[string]bool, len(ids))
+	}
+	for _, id := range ids {
+		s.pinned[id] = true
+	}
+}
+
+// selects returns true if the request can be sent to the blobber.
+func (s *blobberSelection) selects(blobberID string) bool {
+	if s.excluded[blobberID] {
+		return false
+	}
+	return len(s.pinned) == 0 || s.pinned[blobberID]
+}
+
+// mask returns the mask of the selected blobbers.
+func (s *blobberSelection) mask(blobbers []*blockchain.StorageNode) zboxutil.Uint128 {
+	mask := zboxutil.NewUint128(0)
+	for i, b := range blobbers {
+		if s.selects(b.ID) {
+			mask = mask.Or(zboxutil.NewUint128(1).Lsh(uint64(i)))
+		}
+	}
+	return mask
+}
+
+// WithExcludedBlobbers excludes blobbers from the download, e.g. a blobber known to be slow. The
+// file is downloaded from the other blobbers, it fails if they aren't enough to reach the consensus.
+//   - blobberIDs: the ids of the blobbers to exclude
+func WithExcludedBlobbers(blobberIDs ...string) DownloadRequestOption {
+	return func(dr *DownloadRequest) {
+		dr.selection.exclude(blobberIDs)
+	}
+}
+
+// WithPinnedBlobbers downloads the file from the given blobbers only, e.g. the blobbers of a
+// region. The download fails if they aren't enough to reach the consensus.
+//   - blobberIDs: the ids of the blobbers to download from
+func WithPinnedBlobbers(blobberIDs ...string) DownloadRequestOption {
+	return func(dr *DownloadRequest) {
+		dr.selection.pin(blobberIDs)
+	}
+}
+
+// WithListExcludedBlobbers excludes blobbers from the list request.
+//   - blobberIDs: the ids of the blobbers to exclude
+func WithListExcludedBlobbers(blobberIDs ...string) ListRequestOptions {
+	return func(req *ListRequest) {
+		req.selection.exclude(blobberIDs)
+	}
+}
+
+// WithListPinnedBlobbers sends the list request to the given blobbers only.
+//   - blobberIDs: the ids of the blobbers to list from
+func WithListPinnedBlobbers(blobberIDs ...string) ListRequestOptions {
+	return func(req *ListRequest) {
+		req.selection.pin(blobberIDs)
+	}
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/mocks"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberSelection_Selects(t *testing.T) {
+	var s blobberSelection
+	require.True(t, s.selects("b0"))
+
+	s.exclude([]string{"b1"})
+	require.True(t, s.selects("b0"))
+	require.False(t, s.selects("b1"))
+
+	s.pin([]string{"b1", "b2"})
+	require.False(t, s.selects("b0"))
+	require.False(t, s.selects("b1"))
+	require.True(t, s.selects("b2"))
+}
+
+func TestListRequest_BlobberSelection(t *testing.T) {
+	const mockBlobberUrl = "TestListRequest_BlobberSelection"
+
+	var mockClient = mocks.HttpClient{}
+	zboxutil.Client = &mockClient
+	zclient.GetClient().Wallet = &zcncrypto.Wallet{ClientID: "mock client id", ClientKey: "mock client key"}
+
+	// the threshold isn't reached with the selected blobbers, so all the requests are awaited
+	var calls [4]int32
+	for i := range calls {
+		i := i
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return strings.HasPrefix(req.URL.Path, mockBlobberUrl+strconv.Itoa(i))
+		})).Return(func(*http.Request) *http.Response {
+			atomic.AddInt32(&calls[i], 1)
+			body, _ := json.Marshal(&fileref.ListResult{
+				Meta: map[string]interface{}{"type": fileref.DIRECTORY, "file_meta_hash": "mock file meta hash"},
+			})
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(body))}
+		}, nil)
+	}
+
+	newReq := func(opts ...ListRequestOptions) *ListRequest {
+		req := &ListRequest{
+			allocationID: "mock allocation id",
+			allocationTx: "mock transaction id",
+			ctx:          context.TODO(),
+			Consensus: Consensus{
+				RWMutex:         &sync.RWMutex{},
+				consensusThresh: len(calls),
+				fullconsensus:   len(calls),
+			},
+			listOnly: true,
+		}
+		for i := range calls {
+			req.blobbers = append(req.blobbers, &blockchain.StorageNode{
+				ID:      "b" + strconv.Itoa(i),
+				Baseurl: mockBlobberUrl + strconv.Itoa(i),
+			})
+		}
+		for _, opt := range opts {
+			opt(req)
+		}
+		return req
+	}
+
+	got, err := newReq(WithListExcludedBlobbers("b1")).GetListFromBlobbers()
+	require.NoError(t, err)
+	require.Equal(t, [4]int32{1, 0, 1, 1}, calls)
+	require.Equal(t, zboxutil.NewUint128(0b1101), got.deleteMask)
+
+	calls = [4]int32{}
+	lR, err := newReq(WithListPinnedBlobbers("b2", "b3")).getlistFromBlobbers()
+	require.NoError(t, err)
+	require.Equal(t, [4]int32{0, 0, 1, 1}, calls)
+	var excluded int
+	for _, r := range lR {
+		if r.err != nil {
+			require.True(t, errors.Is(r.err, errBlobberNotSelected))
+			excluded++
+		}
+	}
+	require.Equal(t, 2, excluded)
+}
@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/0chain/gosdk/zboxcore/blobberclient"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// BlobberClient returns the typed client of the API of a blobber of the allocation, see the
+// blobberclient package. The requests are signed for the allocation.
+//   - blobber: the blobber.
+func (a *Allocation) BlobberClient(blobber *blockchain.StorageNode) (*blobberclient.Client, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	return blobberclient.NewClient(blobber.Baseurl,
+		blobberclient.WithHTTPClient(zboxutil.Client),
+		blobberclient.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.Header.Set(zboxutil.ALLOCATION_ID_HEADER, a.ID)
+			return zboxutil.SignBlobberRequest(req, a.sig, a.Tx, blobber.Baseurl)
+		}),
+	)
+}
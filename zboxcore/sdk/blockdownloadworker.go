@@ -46,6 +46,11 @@ type BlockDownloadRequest struct {
 	shouldVerify       bool
 	connectionID       string
 	respBuf            []byte
+	// validationRoot is the validation root of the shard the merkle proofs are verified against.
+	validationRoot []byte
+	shardSize      int64
+	// release releases the worker restarted for the request once it's done, see AddBlockDownloadReq.
+	release func()
 }
 
 type downloadResponse struct {
@@ -65,7 +70,10 @@ type downloadBlock struct {
 }
 
 var downloadBlockChan map[string]chan *BlockDownloadRequest
-var initDownloadMutex sync.Mutex
+
+// initDownloadMutex guards downloadBlockChan, it's read locked while sending to the channels so they
+// aren't closed meanwhile by stopBlockDownloadWorkers.
+var initDownloadMutex sync.RWMutex
 
 func InitBlockDownloader(blobbers []*blockchain.StorageNode, workerCount int) {
 	initDownloadMutex.Lock()
@@ -82,9 +90,47 @@ func InitBlockDownloader(blobbers []*blockchain.StorageNode, workerCount int) {
 	}
 }
 
+// stopBlockDownloadWorkers stops the block download workers of blobbers, once their pending
+// requests are sent.
+func stopBlockDownloadWorkers(blobbers []*blockchain.StorageNode) {
+	initDownloadMutex.Lock()
+	defer initDownloadMutex.Unlock()
+	for _, blobber := range blobbers {
+		if ch, ok := downloadBlockChan[blobber.ID]; ok {
+			close(ch)
+			delete(downloadBlockChan, blobber.ID)
+		}
+	}
+}
+
+// sendBlockDownloadReq sends a request to the block download worker of its blobber. If the worker
+// was stopped, see SetAllocationIdleTimeout, it's acquired for the request, so it isn't stopped until
+// the request is done, and released afterwards.
+func sendBlockDownloadReq(req *BlockDownloadRequest) {
+	if trySendBlockDownloadReq(req) {
+		return
+	}
+	blobbers := []*blockchain.StorageNode{req.blobber}
+	acquireBlobberWorkers(blobbers)
+	req.release = func() { releaseBlobberWorkers(blobbers) }
+	trySendBlockDownloadReq(req)
+}
+
+// trySendBlockDownloadReq sends a request to the block download worker of its blobber, false if the
+// worker is stopped. The worker is looked up and sent to under the same lock, so it can't be stopped
+// in between.
+func trySendBlockDownloadReq(req *BlockDownloadRequest) bool {
+	initDownloadMutex.RLock()
+	defer initDownloadMutex.RUnlock()
+	ch, ok := downloadBlockChan[req.blobber.ID]
+	if ok {
+		ch <- req
+	}
+	return ok
+}
+
 func startBlockDownloadWorker(blobberChan chan *BlockDownloadRequest, workers int) {
 	sem := semaphore.NewWeighted(int64(workers))
-	fastClient := zboxutil.GetFastHTTPClient()
 	for {
 		blockDownloadReq, open := <-blobberChan
 		if !open {
@@ -92,11 +138,17 @@ func startBlockDownloadWorker(blobberChan chan *BlockDownloadRequest, workers in
 		}
 		if err := sem.Acquire(blockDownloadReq.ctx, 1); err != nil {
 			blockDownloadReq.result <- &downloadBlock{Success: false, idx: blockDownloadReq.blobberIdx, err: err}
+			if blockDownloadReq.release != nil {
+				go blockDownloadReq.release()
+			}
 			continue
 		}
 		go func() {
-			blockDownloadReq.downloadBlobberBlock(fastClient)
+			blockDownloadReq.downloadBlobberBlock(zboxutil.GetFastHTTPClient())
 			sem.Release(1)
+			if blockDownloadReq.release != nil {
+				blockDownloadReq.release()
+			}
 		}()
 	}
 }
@@ -155,7 +207,7 @@ func (req *BlockDownloadRequest) downloadBlobberBlock(fastClient *fasthttp.Clien
 
 		err = func() error {
 			now := time.Now()
-			statuscode, respBuf, err := fastClient.GetWithRequest(httpreq, req.respBuf)
+			statuscode, respBuf, err := zboxutil.FastGetWithRequest(fastClient, httpreq, req.respBuf)
 			fasthttp.ReleaseRequest(httpreq)
 			timeTaken := time.Since(now).Milliseconds()
 			if err != nil {
@@ -196,8 +248,11 @@ func (req *BlockDownloadRequest) downloadBlobberBlock(fastClient *fasthttp.Clien
 			} else {
 				dR.Data = respBuf
 			}
-			if req.contentMode == DOWNLOAD_CONTENT_FULL && req.shouldVerify {
-				zlogger.Logger.Info("verifying multiple blocks")
+			if req.contentMode == DOWNLOAD_CONTENT_FULL && req.shouldVerify && req.validationRoot != nil {
+				if err = verifyBlockProofs(&dR, req.validationRoot, req.shardSize); err != nil {
+					zlogger.Logger.Error(fmt.Sprintf("downloadBlobberBlock merkle verification failed - blobberID: %v, blockNum: %d: %v", req.blobber.ID, header.BlockNum, err))
+					return err
+				}
 			}
 
 			rspData.idx = req.blobberIdx
@@ -257,5 +312,5 @@ func AddBlockDownloadReq(ctx context.Context, req *BlockDownloadRequest, rb zbox
 	} else {
 		req.respBuf = make([]byte, int(req.numBlocks)*effectiveBlockSize)
 	}
-	downloadBlockChan[req.blobber.ID] <- req
+	sendBlockDownloadReq(req)
 }
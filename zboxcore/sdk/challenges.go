@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// StorageChallenge represents a challenge issued by the storage smart contract to a blobber.
+type StorageChallenge struct {
+	ID             string           `json:"challenge_id"`
+	CreatedAt      common.Timestamp `json:"created_at"`
+	AllocationID   string           `json:"allocation_id"`
+	BlobberID      string           `json:"blobber_id"`
+	ValidatorsID   string           `json:"validators_id"`
+	Seed           int64            `json:"seed"`
+	AllocationRoot string           `json:"allocation_root"`
+	// Responded is 0 while the challenge is open, 1 if the blobber responded and 2 if it expired.
+	Responded      int64            `json:"responded"`
+	Passed         bool             `json:"passed"`
+	RoundResponded int64            `json:"round_responded"`
+	Timestamp      common.Timestamp `json:"timestamp"`
+}
+
+// IsOpen returns true if the challenge is still waiting for the blobber response.
+func (c *StorageChallenge) IsOpen() bool {
+	return c.Responded == 0
+}
+
+// BlobberOpenChallenges represents the open challenges of a blobber.
+type BlobberOpenChallenges struct {
+	BlobberID  string              `json:"blobber_id"`
+	Challenges []*StorageChallenge `json:"challenges"`
+}
+
+// GetBlobberOpenChallenges retrieves the open challenges of a blobber from the sharders.
+//   - blobberID: blobber ID
+//   - offset: offset
+//   - limit: limit
+func GetBlobberOpenChallenges(blobberID string, offset, limit int) (*BlobberOpenChallenges, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+
+	b, err := zboxutil.MakeSCRestAPICall(STORAGE_SCADDRESS, "/openchallenges", map[string]string{
+		"blobber": blobberID,
+		"offset":  strconv.Itoa(offset),
+		"limit":   strconv.Itoa(limit),
+	}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting open challenges:")
+	}
+	if len(b) == 0 {
+		return nil, errors.New("", "empty response")
+	}
+
+	info := new(BlobberOpenChallenges)
+	if err = json.Unmarshal(b, info); err != nil {
+		return nil, errors.Wrap(err, "error decoding response:")
+	}
+	return info, nil
+}
+
+// GetChallenges retrieves the challenge history of the allocation from the sharders, newest first.
+//   - offset: offset
+//   - limit: limit
+func (a *Allocation) GetChallenges(offset, limit int) ([]*StorageChallenge, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+
+	b, err := zboxutil.MakeSCRestAPICall(STORAGE_SCADDRESS, "/allocation-challenges", map[string]string{
+		"allocation_id": a.ID,
+		"offset":        strconv.Itoa(offset),
+		"limit":         strconv.Itoa(limit),
+		"sort":          "desc",
+	}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting allocation challenges:")
+	}
+	if len(b) == 0 {
+		return nil, errors.New("", "empty response")
+	}
+
+	var challenges []*StorageChallenge
+	if err = json.Unmarshal(b, &challenges); err != nil {
+		return nil, errors.Wrap(err, "error decoding response:")
+	}
+	return challenges, nil
+}
+
+// ChallengeSummary aggregates the challenge results of an allocation per blobber.
+type ChallengeSummary struct {
+	BlobberID string `json:"blobber_id"`
+	Open      int    `json:"open"`
+	Passed    int    `json:"passed"`
+	Failed    int    `json:"failed"`
+}
+
+// SummarizeChallenges aggregates the given challenges per blobber.
+//   - challenges: the challenges to aggregate, e.g. returned by Allocation.GetChallenges.
+func SummarizeChallenges(challenges []*StorageChallenge) map[string]*ChallengeSummary {
+	summary := make(map[string]*ChallengeSummary)
+	for _, c := range challenges {
+		s, ok := summary[c.BlobberID]
+		if !ok {
+			s = &ChallengeSummary{BlobberID: c.BlobberID}
+			summary[c.BlobberID] = s
+		}
+		switch {
+		case c.IsOpen():
+			s.Open++
+		case c.Passed:
+			s.Passed++
+		default:
+			s.Failed++
+		}
+	}
+	return summary
+}
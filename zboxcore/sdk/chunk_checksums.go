@@ -0,0 +1,266 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"math/bits"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/logger"
+	"go.uber.org/zap"
+)
+
+// chunkChecksumsKey is the key of the chunk checksums in the custom meta of a file.
+const chunkChecksumsKey = "chunk_checksums"
+
+const (
+	// minChecksumChunkAvgSize is the smallest average size of the content-defined chunks.
+	minChecksumChunkAvgSize = 1 << 20
+	// maxChecksumChunks is the number of chunks the average size of the chunks of a file targets.
+	maxChecksumChunks = 1024
+	// checksumHashLen is the number of bytes of the SHA-256 of a chunk kept in its checksum.
+	checksumHashLen = 8
+)
+
+// gearTable is the table of the rolling hash finding the chunk boundaries. It's generated from a fixed
+// seed and must never change, the checksums stored with the files depending on it.
+var gearTable = func() (table [256]uint64) {
+	seed := uint64(0x5a5a_2019_0c4a_17e5)
+	for i := range table {
+		// splitmix64
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return
+}()
+
+// ChunkChecksums are the checksums of the content-defined chunks of a file. The chunk boundaries depend
+// on the content only, an insertion or a deletion in a file changes the checksums of its chunks only.
+type ChunkChecksums struct {
+	// AvgSize is the average size of the chunks, a power of two.
+	AvgSize int64 `json:"avg_size"`
+	// Sizes are the sizes of the chunks.
+	Sizes []int64 `json:"sizes"`
+	// Hashes are the truncated SHA-256 of the chunks, hex encoded.
+	Hashes []string `json:"hashes"`
+}
+
+// chunkChecksumAvgSize returns the average size of the chunks of a file, so a large file doesn't have
+// more than about maxChecksumChunks chunks.
+func chunkChecksumAvgSize(fileSize int64) int64 {
+	avg := int64(minChecksumChunkAvgSize)
+	if fileSize > avg*maxChecksumChunks {
+		avg = 1 << bits.Len64(uint64((fileSize+maxChecksumChunks-1)/maxChecksumChunks-1))
+	}
+	return avg
+}
+
+// cdcChunker splits a stream in content-defined chunks and computes their checksums.
+type cdcChunker struct {
+	min, max  int64
+	mask      uint64
+	fp        uint64
+	size      int64
+	total     int64
+	sum       hash.Hash
+	checksums ChunkChecksums
+}
+
+func newCDCChunker(avgSize int64) *cdcChunker {
+	return &cdcChunker{
+		min:       avgSize / 4,
+		max:       avgSize * 4,
+		mask:      uint64(avgSize - 1),
+		sum:       sha256.New(),
+		checksums: ChunkChecksums{AvgSize: avgSize},
+	}
+}
+
+func (c *cdcChunker) Write(p []byte) (int, error) {
+	start := 0
+	for i, b := range p {
+		c.fp = (c.fp << 1) + gearTable[b]
+		c.size++
+		if c.size >= c.max || (c.size >= c.min && c.fp&c.mask == 0) {
+			c.sum.Write(p[start : i+1]) //nolint: errcheck
+			start = i + 1
+			c.cut()
+		}
+	}
+	c.sum.Write(p[start:]) //nolint: errcheck
+	return len(p), nil
+}
+
+func (c *cdcChunker) cut() {
+	c.checksums.Sizes = append(c.checksums.Sizes, c.size)
+	c.checksums.Hashes = append(c.checksums.Hashes, hex.EncodeToString(c.sum.Sum(nil)[:checksumHashLen]))
+	c.total += c.size
+	c.size, c.fp = 0, 0
+	c.sum.Reset()
+}
+
+// Checksums returns the checksums of the chunks, the last chunk included.
+func (c *cdcChunker) Checksums() *ChunkChecksums {
+	if c.size > 0 {
+		c.cut()
+	}
+	return &c.checksums
+}
+
+// checksumHasher computes the chunk checksums of the file as it's hashed.
+type checksumHasher struct {
+	Hasher
+	chunker *cdcChunker
+}
+
+func (h *checksumHasher) WriteToFile(buf []byte) error {
+	h.chunker.Write(buf) //nolint: errcheck
+	return h.Hasher.WriteToFile(buf)
+}
+
+// WithChunkChecksums stores the checksums of the content-defined chunks of the file in its custom meta,
+// so VerifyLocalCopy can tell which regions of a local copy differ from the remote file without
+// downloading it. The custom meta of the file must be empty or a JSON object. A resumed upload doesn't
+// store the checksums.
+func WithChunkChecksums() ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.chunkChecksums = true
+	}
+}
+
+// setChunkChecksums sets the chunk checksums in the custom meta once the file is read.
+func (su *ChunkedUpload) setChunkChecksums(chunker *cdcChunker) {
+	// waits for the file hasher
+	if _, err := su.chunkReader.GetFileHash(); err != nil {
+		logger.Logger.Error("chunk checksums skipped", zap.String("remote_path", su.fileMeta.RemotePath), zap.Error(err))
+		return
+	}
+	checksums := chunker.Checksums()
+	if chunker.total != su.fileMeta.ActualSize {
+		logger.Logger.Error("chunk checksums skipped, the file wasn't read from the start",
+			zap.String("remote_path", su.fileMeta.RemotePath))
+		return
+	}
+	customMeta, err := addChunkChecksums(su.fileMeta.CustomMeta, checksums)
+	if err != nil {
+		logger.Logger.Error("chunk checksums skipped", zap.String("remote_path", su.fileMeta.RemotePath), zap.Error(err))
+		return
+	}
+	su.fileMeta.CustomMeta = customMeta
+}
+
+// addChunkChecksums adds the chunk checksums to a custom meta, empty or a JSON object.
+func addChunkChecksums(customMeta string, checksums *ChunkChecksums) (string, error) {
+	meta := make(map[string]json.RawMessage)
+	if customMeta != "" {
+		if err := json.Unmarshal([]byte(customMeta), &meta); err != nil {
+			return "", errors.New("invalid_custom_meta", "the custom meta isn't a JSON object")
+		}
+	}
+	data, err := json.Marshal(checksums)
+	if err != nil {
+		return "", err
+	}
+	meta[chunkChecksumsKey] = data
+	data, err = json.Marshal(meta)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ChunkChecksumsOf returns the chunk checksums stored in the custom meta of a file.
+//   - customMeta: the custom meta of the file.
+func ChunkChecksumsOf(customMeta string) (*ChunkChecksums, error) {
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(customMeta), &meta); err != nil || meta[chunkChecksumsKey] == nil {
+		return nil, errors.New("no_chunk_checksums", "the file has no chunk checksums, see WithChunkChecksums")
+	}
+	checksums := &ChunkChecksums{}
+	if err := json.Unmarshal(meta[chunkChecksumsKey], checksums); err != nil {
+		return nil, errors.Wrap(err, "invalid chunk checksums")
+	}
+	if checksums.AvgSize <= 0 || checksums.AvgSize&(checksums.AvgSize-1) != 0 || len(checksums.Sizes) != len(checksums.Hashes) {
+		return nil, errors.New("invalid_chunk_checksums", "invalid chunk checksums")
+	}
+	return checksums, nil
+}
+
+// FileRegion is a region of a file.
+type FileRegion struct {
+	Offset int64 `json:"offset"`
+	Size   int64 `json:"size"`
+}
+
+// LocalCopyDiff is the comparison of a local copy of a file with the remote file.
+type LocalCopyDiff struct {
+	// Identical is true if the local copy has the content of the remote file.
+	Identical bool `json:"identical"`
+	// Regions are the regions of the local copy whose content isn't in the remote file.
+	Regions    []FileRegion `json:"regions"`
+	LocalSize  int64        `json:"local_size"`
+	RemoteSize int64        `json:"remote_size"`
+}
+
+// VerifyLocalCopy compares a local copy of a file with the remote file using the chunk checksums stored
+// at its upload, see WithChunkChecksums, and returns the regions of the local copy that differ, without
+// downloading the remote file.
+//   - localPath: the path of the local copy.
+//   - remotePath: the remote path of the file.
+func (a *Allocation) VerifyLocalCopy(localPath, remotePath string) (*LocalCopyDiff, error) {
+	meta, err := a.GetFileMeta(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	checksums, err := ChunkChecksumsOf(meta.CustomMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := a.getFS().Open(localPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open local file")
+	}
+	defer f.Close()
+	return diffChunkChecksums(f, checksums)
+}
+
+// diffChunkChecksums compares the content of a reader with chunk checksums.
+func diffChunkChecksums(r io.Reader, remote *ChunkChecksums) (*LocalCopyDiff, error) {
+	chunker := newCDCChunker(remote.AvgSize)
+	if _, err := io.Copy(chunker, r); err != nil {
+		return nil, errors.Wrap(err, "failed to read local file")
+	}
+	local := chunker.Checksums()
+
+	diff := &LocalCopyDiff{LocalSize: chunker.total}
+	remoteChunks := make(map[string]int64, len(remote.Hashes))
+	for i, h := range remote.Hashes {
+		remoteChunks[h] = remote.Sizes[i]
+		diff.RemoteSize += remote.Sizes[i]
+	}
+
+	diff.Identical = len(local.Hashes) == len(remote.Hashes)
+	var offset int64
+	for i, h := range local.Hashes {
+		size := local.Sizes[i]
+		if diff.Identical && (h != remote.Hashes[i] || size != remote.Sizes[i]) {
+			diff.Identical = false
+		}
+		if remoteSize, ok := remoteChunks[h]; !ok || remoteSize != size {
+			if n := len(diff.Regions); n > 0 && diff.Regions[n-1].Offset+diff.Regions[n-1].Size == offset {
+				diff.Regions[n-1].Size += size
+			} else {
+				diff.Regions = append(diff.Regions, FileRegion{Offset: offset, Size: size})
+			}
+		}
+		offset += size
+	}
+	return diff, nil
+}
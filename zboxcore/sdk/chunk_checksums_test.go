@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkChecksums(t *testing.T) {
+	require.Equal(t, int64(1<<20), chunkChecksumAvgSize(0))
+	require.Equal(t, int64(1<<20), chunkChecksumAvgSize(1<<30))
+	require.Equal(t, int64(1<<21), chunkChecksumAvgSize(1<<30+1))
+
+	data := make([]byte, 8<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	chunker := newCDCChunker(chunkChecksumAvgSize(int64(len(data))))
+	h := &checksumHasher{Hasher: CreateFileHasher(), chunker: chunker}
+	for i := 0; i < len(data); i += 64 * 1024 {
+		require.NoError(t, h.WriteToFile(data[i:i+64*1024]))
+	}
+	remote := chunker.Checksums()
+	require.Equal(t, int64(len(data)), chunker.total)
+	require.Greater(t, len(remote.Sizes), 2)
+
+	customMeta, err := addChunkChecksums(`{"tag":"backup"}`, remote)
+	require.NoError(t, err)
+	require.Contains(t, customMeta, `"tag":"backup"`)
+	_, err = addChunkChecksums("not json", remote)
+	require.Error(t, err)
+	stored, err := ChunkChecksumsOf(customMeta)
+	require.NoError(t, err)
+	require.Equal(t, remote, stored)
+	_, err = ChunkChecksumsOf(`{"tag":"backup"}`)
+	require.Error(t, err)
+
+	diff, err := diffChunkChecksums(bytes.NewReader(data), stored)
+	require.NoError(t, err)
+	require.True(t, diff.Identical)
+	require.Empty(t, diff.Regions)
+	require.Equal(t, int64(len(data)), diff.RemoteSize)
+
+	// bytes inserted in the middle only change the chunks around them
+	const at = 3 << 20
+	edited := append(append(append([]byte{}, data[:at]...), []byte("inserted")...), data[at:]...)
+	diff, err = diffChunkChecksums(bytes.NewReader(edited), stored)
+	require.NoError(t, err)
+	require.False(t, diff.Identical)
+	require.Equal(t, int64(len(edited)), diff.LocalSize)
+	require.Len(t, diff.Regions, 1)
+	require.LessOrEqual(t, diff.Regions[0].Offset, int64(at))
+	require.Greater(t, diff.Regions[0].Offset+diff.Regions[0].Size, int64(at))
+	require.Less(t, diff.Regions[0].Size, int64(len(data)/2))
+}
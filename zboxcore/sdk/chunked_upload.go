@@ -1,12 +1,14 @@
 package sdk
 
 import (
+	"bufio"
 	"context"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -22,6 +24,7 @@ import (
 	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/core/util"
 	"github.com/0chain/gosdk/zboxcore/allocationchange"
+	"github.com/0chain/gosdk/zboxcore/blobberclient"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/encryption"
@@ -55,6 +58,16 @@ var (
 // DefaultChunkSize default chunk size for file and thumbnail
 const DefaultChunkSize = 64 * 1024
 
+// Bounds of the chunk size of an upload, see WithChunkSize. The blobbers may advertise narrower bounds.
+const (
+	MinChunkSize = 4 * 1024
+	MaxChunkSize = 16 * 1024 * 1024
+)
+
+// maxUploadRequestSize is the maximum size of the chunks of an upload request to a blobber, the chunk
+// number of the uploads with large chunks is reduced to fit.
+const maxUploadRequestSize = 100 * DefaultChunkSize
+
 const (
 	// EncryptedDataPaddingSize additional bytes to save encrypted data
 	EncryptedDataPaddingSize = 16
@@ -120,10 +133,16 @@ func CreateChunkedUpload(
 		return nil, thrown.Throw(constants.ErrInvalidParameter, "allocationObj")
 	}
 
-	if !isUpdate && !allocationObj.CanUpload() || isUpdate && !allocationObj.CanUpdate() {
+	if !isUpdate && !allocationObj.CanUpload() || isUpdate && !allocationObj.canUpdateFile(zboxutil.RemoteClean(fileMeta.RemotePath)) {
 		return nil, thrown.Throw(constants.ErrFileOptionNotPermitted, "file_option_not_permitted ")
 	}
 
+	if isUpdate {
+		if err := allocationObj.retentionLockError(constants.FileOperationUpdate, fileMeta.RemotePath); err != nil {
+			return nil, err
+		}
+	}
+
 	if webStreaming {
 		newFileReader, newFileMeta, f, err := TranscodeWebStreaming(workdir, fileReader, fileMeta)
 		defer os.Remove(f)
@@ -160,6 +179,7 @@ func CreateChunkedUpload(
 		client:        zboxutil.Client,
 		fileMeta:      fileMeta,
 		fileReader:    fileReader,
+		hooks:         append([]UploadHook(nil), getUploadHooks()...),
 
 		uploadMask:      uploadMask,
 		chunkSize:       DefaultChunkSize,
@@ -210,6 +230,39 @@ func CreateChunkedUpload(
 	for _, opt := range opts {
 		opt(su)
 	}
+	su.statusCallback = withEventBus(su.statusCallback)
+
+	if err := su.fileMeta.Attributes.Validate(); err != nil {
+		return nil, thrown.Wrap(err, "invalid file attributes")
+	}
+
+	if su.fileMeta.IfMatch != "" {
+		if !isUpdate || isRepair {
+			return nil, thrown.New("invalid_precondition", "only the updates can be conditional")
+		}
+		if err := allocationObj.checkIfMatch(su.fileMeta.RemotePath, su.fileMeta.IfMatch); err != nil {
+			return nil, err
+		}
+	}
+
+	if su.chunkSize != DefaultChunkSize {
+		if err := allocationObj.validateChunkSize(su.ctx, su.chunkSize); err != nil {
+			return nil, err
+		}
+		if limit := int(maxUploadRequestSize / su.chunkSize); su.chunkNumber > limit {
+			su.chunkNumber = max(limit, 1)
+		}
+	}
+
+	if !isRepair && len(su.hooks) > 0 {
+		if err := su.runBeforeUpload(); err != nil {
+			return nil, err
+		}
+	}
+
+	if su.fileMeta.MimeType == "" && su.fileReader != nil {
+		su.fileMeta.MimeType, su.fileReader = sniffMimeType(su.fileMeta.RemoteName, su.fileReader)
+	}
 
 	if isRepair {
 		opCode = OpUpdate
@@ -221,11 +274,22 @@ func CreateChunkedUpload(
 		su.progressStorer = createFsChunkedUploadProgress(su.ctx)
 	}
 
+	if su.deterministicConnectionID {
+		if su.fileMeta.ActualHash == "" {
+			return nil, thrown.New("invalid_connection_id", "the content hash of the file is required to derive the connection id")
+		}
+		connectionId = zboxutil.NewDeterministicConnectionId(allocationObj.ID, su.fileMeta.RemotePath, su.fileMeta.ActualHash)
+	}
+
 	su.loadProgress()
-	su.shardSize = getShardSize(su.fileMeta.ActualSize, su.allocationObj.DataShards, su.encryptOnUpload)
+	su.shardSize = getShardSize(su.fileMeta.ActualSize, su.allocationObj.DataShards, su.encryptOnUpload, su.chunkSize)
 	if su.fileHasher == nil {
 		su.fileHasher = CreateFileHasher()
 	}
+	if su.chunkChecksums {
+		su.checksumChunker = newCDCChunker(chunkChecksumAvgSize(su.fileMeta.ActualSize))
+		su.fileHasher = &checksumHasher{Hasher: su.fileHasher, chunker: su.checksumChunker}
+	}
 
 	// encrypt option has been changed. upload it from scratch
 	// chunkSize has been changed. upload it from scratch
@@ -233,6 +297,10 @@ func CreateChunkedUpload(
 	if su.progress.ChunkSize != su.chunkSize || su.progress.EncryptOnUpload != su.encryptOnUpload || su.progress.ActualSize != su.fileMeta.ActualSize || su.progress.ChunkNumber != su.chunkNumber || su.progress.ConnectionID == "" {
 		su.progress.ChunkSize = 0 // reset chunk size
 	}
+	// the content changed since the progress was saved, the upload starts over on its own connection.
+	if su.deterministicConnectionID && su.progress.ConnectionID != connectionId {
+		su.progress.ChunkSize = 0
+	}
 
 	su.createUploadProgress(connectionId)
 
@@ -281,15 +349,32 @@ func CreateChunkedUpload(
 					Type:         fileref.FILE,
 					AllocationID: su.allocationObj.ID,
 				},
+				MimeType:   su.fileMeta.MimeType,
+				Attributes: su.fileMeta.Attributes,
 			},
 		}
 	}
-	cReader, err := createChunkReader(su.fileReader, fileMeta.ActualSize, int64(su.chunkSize), su.allocationObj.DataShards, su.allocationObj.ParityShards, su.encryptOnUpload, su.uploadMask, su.fileErasureEncoder, su.fileEncscheme, su.fileHasher, su.chunkNumber)
+	reader := su.fileReader
+	if su.bandwidth != nil {
+		reader = su.bandwidth.reader(su.ctx, reader)
+	}
+	cReader, err := createChunkReader(reader, su.fileMeta.ActualSize, int64(su.chunkSize), su.allocationObj.DataShards, su.allocationObj.ParityShards, su.encryptOnUpload, su.uploadMask, su.fileErasureEncoder, su.fileEncscheme, su.fileHasher, su.chunkNumber)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if enc := getFragmentEncoder(); enc != nil {
+		if r, ok := cReader.(*chunkedUploadChunkReader); ok {
+			r.setFragmentEncoder(enc, su.progress.EncryptPrivateKey, su.progress.EncryptedKeyPoint)
+		}
+	}
+	if onChunk := su.onChunk(); onChunk != nil && !isRepair {
+		if r, ok := cReader.(*chunkedUploadChunkReader); ok {
+			r.onChunk = onChunk
+		}
+	}
+
 	su.chunkReader = cReader
 
 	su.formBuilder = CreateChunkedUploadFormBuilder()
@@ -415,6 +500,14 @@ func (su *ChunkedUpload) process() error {
 	defer su.chunkReader.Close()
 	defer su.ctxCncl(nil)
 	for {
+		// the cancellation is honored at the chunk boundaries, see CancelUpload.
+		if su.ctx.Err() != nil {
+			err := context.Cause(su.ctx)
+			if su.statusCallback != nil {
+				su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.RemotePath, su.opCode, err)
+			}
+			return err
+		}
 
 		chunks, err := su.readChunks(su.chunkNumber)
 
@@ -442,13 +535,16 @@ func (su *ChunkedUpload) process() error {
 			}
 			if su.fileMeta.ActualSize == 0 {
 				su.fileMeta.ActualSize = su.progress.ReadLength
-				su.shardSize = getShardSize(su.fileMeta.ActualSize, su.allocationObj.DataShards, su.encryptOnUpload)
+				su.shardSize = getShardSize(su.fileMeta.ActualSize, su.allocationObj.DataShards, su.encryptOnUpload, su.chunkSize)
 			} else if su.fileMeta.ActualSize != su.progress.ReadLength && su.thumbnailBytes == nil {
 				if su.statusCallback != nil {
 					su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.RemotePath, su.opCode, thrown.New("upload_failed", "Upload failed. Uploaded size does not match with actual size: "+fmt.Sprintf("%d != %d", su.fileMeta.ActualSize, su.progress.ReadLength)))
 				}
 				return thrown.New("upload_failed", "Upload failed. Uploaded size does not match with actual size: "+fmt.Sprintf("%d != %d", su.fileMeta.ActualSize, su.progress.ReadLength))
 			}
+			if su.checksumChunker != nil {
+				su.setChunkChecksums(su.checksumChunker)
+			}
 		}
 
 		err = su.processUpload(
@@ -469,15 +565,79 @@ func (su *ChunkedUpload) process() error {
 			break
 		}
 	}
+	if err := su.verifyShardHashes(); err != nil {
+		if su.statusCallback != nil {
+			su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.RemotePath, su.opCode, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// verifyShardHashes checks the hashes of the shards computed by the blobbers against the local ones,
+// before the write markers are sent. The blobbers which stored a corrupted shard are left out of the
+// commit, so it doesn't fail the challenges later, and the upload fails if too few blobbers are left.
+// The blobbers not returning the hash of the shard can't be checked, EventShardHashUnverified is
+// published for them.
+func (su *ChunkedUpload) verifyShardHashes() error {
+	su.maskMu.Lock()
+	defer su.maskMu.Unlock()
+	var pos uint64
+	var corrupted int
+	for i := su.uploadMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
+		pos = uint64(i.TrailingZeros())
+		sb := su.blobbers[pos]
+		if sb.storedDataHash == "" {
+			logger.Logger.Info("shard hash not verified on ", sb.blobber.Baseurl,
+				": the blobber didn't return it, connectionID: ", su.progress.ConnectionID)
+			ev := Event{Type: EventShardHashUnverified, RemotePath: su.fileMeta.RemotePath, BlobberID: sb.blobber.ID}
+			if su.allocationObj != nil {
+				ev.AllocationID = su.allocationObj.ID
+			}
+			publishEvent(ev)
+			continue
+		}
+		if sb.storedDataHash == sb.dataHash {
+			continue
+		}
+		logger.Logger.Error("shard hash mismatch on ", sb.blobber.Baseurl, ": expected ", sb.dataHash,
+			", stored ", sb.storedDataHash, " connectionID: ", su.progress.ConnectionID)
+		su.uploadMask = su.uploadMask.And(zboxutil.NewUint128(1).Lsh(pos).Not())
+		corrupted++
+	}
+	if corrupted > 0 && su.uploadMask.CountOnes() < su.consensus.consensusThresh {
+		return thrown.New("shard_hash_mismatch", fmt.Sprintf("Upload failed. %d blobbers stored a corrupted shard, required consensus atleast %d, got %d",
+			corrupted, su.consensus.consensusThresh, su.uploadMask.CountOnes()))
+	}
 	return nil
 }
 
 // Start start/resume upload
+// sniffMimeType detects the mime type of a file from its name and its first bytes. It returns the reader
+// of the whole file, the bytes read included.
+func sniffMimeType(name string, r io.Reader) (string, io.Reader) {
+	br := bufio.NewReaderSize(r, zboxutil.ContentTypeSniffLen)
+	head, _ := br.Peek(zboxutil.ContentTypeSniffLen)
+	return zboxutil.DetectContentType(path.Ext(name), head), br
+}
+
 func (su *ChunkedUpload) Start() error {
 	now := time.Now()
 
+	// the upload can be canceled with CancelUpload until it's committed.
+	cancelLock.Lock()
+	CancelOpCtx[su.fileMeta.RemotePath] = su.ctxCncl
+	cancelLock.Unlock()
 	err := su.process()
+	cancelLock.Lock()
+	delete(CancelOpCtx, su.fileMeta.RemotePath)
+	cancelLock.Unlock()
 	if err != nil {
+		if errors.Is(err, ErrCancelUpload) {
+			// a canceled upload isn't resumed.
+			su.removeProgress()
+			return su.canceledError()
+		}
 		return err
 	}
 	su.ctx, su.ctxCncl = context.WithCancelCause(su.allocationObj.ctx)
@@ -628,16 +788,16 @@ func (su *ChunkedUpload) processCommit() error {
 }
 
 // getShardSize will return the size of data of a file each blobber is getting.
-func getShardSize(dataSize int64, dataShards int, isEncrypted bool) int64 {
+func getShardSize(dataSize int64, dataShards int, isEncrypted bool, chunkSize int64) int64 {
 	if dataSize == 0 {
 		return 0
 	}
-	chunkSize := int64(DefaultChunkSize)
+	chunkDataSize := chunkSize
 	if isEncrypted {
-		chunkSize -= (EncryptedDataPaddingSize + EncryptionHeaderSize)
+		chunkDataSize -= (EncryptedDataPaddingSize + EncryptionHeaderSize)
 	}
 
-	totalChunkSize := chunkSize * int64(dataShards)
+	totalChunkSize := chunkDataSize * int64(dataShards)
 
 	n := dataSize / totalChunkSize
 	r := dataSize % totalChunkSize
@@ -648,13 +808,73 @@ func getShardSize(dataSize int64, dataShards int, isEncrypted bool) int64 {
 	} else {
 		remainderShards = (r + int64(dataShards) - 1) / int64(dataShards)
 	}
-	return n*DefaultChunkSize + remainderShards
+	return n*chunkSize + remainderShards
+}
+
+// capabilitiesTimeout is the timeout of the capabilities request to a blobber.
+const capabilitiesTimeout = 5 * time.Second
+
+// getBlobberCapabilities returns the capabilities of a blobber, nil if the blobber predates them. It's a
+// variable to be replaced in the tests.
+var getBlobberCapabilities = func(ctx context.Context, blobber *blockchain.StorageNode) (*blobberclient.Capabilities, error) {
+	c, err := blobberclient.NewClient(blobber.Baseurl, blobberclient.WithHTTPClient(zboxutil.Client))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, capabilitiesTimeout)
+	defer cancel()
+	caps, err := c.GetCapabilities(ctx)
+	var respErr *blobberclient.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, thrown.Wrap(err, "capabilities request failed")
+	}
+	return caps, nil
+}
+
+// validateChunkSize checks the chunk size of an upload against MinChunkSize and MaxChunkSize, and against
+// the bounds of the blobbers of the allocation advertising them in their capabilities. The blobbers
+// predating the capabilities accept any chunk size.
+func (a *Allocation) validateChunkSize(ctx context.Context, chunkSize int64) error {
+	if chunkSize < MinChunkSize || chunkSize > MaxChunkSize {
+		return thrown.Newf("invalid_chunk_size", "chunk size %d should be between %d and %d", chunkSize, MinChunkSize, MaxChunkSize)
+	}
+
+	errs := make([]error, len(a.Blobbers))
+	wg := &sync.WaitGroup{}
+	for i, blobber := range a.Blobbers {
+		wg.Add(1)
+		go func(i int, blobber *blockchain.StorageNode) {
+			defer wg.Done()
+			caps, err := getBlobberCapabilities(ctx, blobber)
+			switch {
+			case err != nil:
+				// the upload to an unreachable blobber fails anyway, the consensus decides
+				logger.Logger.Error("failed to check the chunk size with ", blobber.Baseurl, ": ", err)
+			case caps == nil:
+			case caps.MinChunkSize > 0 && chunkSize < caps.MinChunkSize:
+				errs[i] = thrown.Newf("invalid_chunk_size", "chunk size %d is smaller than the minimum %d of blobber %s", chunkSize, caps.MinChunkSize, blobber.Baseurl)
+			case caps.MaxChunkSize > 0 && chunkSize > caps.MaxChunkSize:
+				errs[i] = thrown.Newf("invalid_chunk_size", "chunk size %d is larger than the maximum %d of blobber %s", chunkSize, caps.MaxChunkSize, blobber.Baseurl)
+			}
+		}(i, blobber)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (su *ChunkedUpload) uploadProcessor() {
 	for {
 		select {
 		case <-su.ctx.Done():
+			su.dropUploads()
 			return
 		case uploadData, ok := <-su.uploadChan:
 			if !ok {
@@ -666,6 +886,53 @@ func (su *ChunkedUpload) uploadProcessor() {
 	}
 }
 
+// canceledError returns the error of the canceled upload, an UploadCanceledError flagging the blobbers
+// with requests abandoned in flight if any.
+func (su *ChunkedUpload) canceledError() error {
+	var blobberIDs []string
+	for _, sb := range su.blobbers {
+		if atomic.LoadInt32(&sb.inFlight) > 0 {
+			blobberIDs = append(blobberIDs, sb.blobber.ID)
+		}
+	}
+	if len(blobberIDs) == 0 {
+		return ErrCancelUpload
+	}
+	return &UploadCanceledError{ConnectionID: su.progress.ConnectionID, BlobberIDs: blobberIDs}
+}
+
+// dropUploads drops the chunks queued for upload once the upload is canceled.
+func (su *ChunkedUpload) dropUploads() {
+	for {
+		select {
+		case _, ok := <-su.uploadChan:
+			if !ok {
+				return
+			}
+			su.uploadWG.Done()
+		default:
+			return
+		}
+	}
+}
+
+// waitUploads waits for the chunks queued for upload to be uploaded, or for the upload to be canceled.
+func (su *ChunkedUpload) waitUploads() error {
+	done := make(chan struct{})
+	go func() {
+		su.uploadWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-su.ctx.Done():
+	}
+	if su.ctx.Err() != nil {
+		return context.Cause(su.ctx)
+	}
+	return nil
+}
+
 func (su *ChunkedUpload) uploadToBlobbers(uploadData UploadData) error {
 	select {
 	case <-su.ctx.Done():
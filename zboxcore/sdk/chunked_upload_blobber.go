@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -36,6 +37,13 @@ type ChunkedUploadBlobber struct {
 
 	commitChanges []allocationchange.AllocationChange
 	commitResult  *CommitResult
+	// dataHash is the hash of the shard computed locally, storedDataHash the one computed by the blobber,
+	// see verifyShardHashes.
+	dataHash       string
+	storedDataHash string
+	// inFlight is the number of upload requests in flight, the ones abandoned on cancellation
+	// included, accessed atomically.
+	inFlight int32
 }
 
 func (sb *ChunkedUploadBlobber) sendUploadRequest(
@@ -79,6 +87,10 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 			)
 			var req *fasthttp.Request
 			for i := 0; i < 3; i++ {
+				if ctx.Err() != nil {
+					// canceled, e.g. by CancelUpload, no more request is sent.
+					return context.Cause(ctx)
+				}
 				req, err = zboxutil.NewFastUploadRequest(
 					sb.blobber.Baseurl, su.allocationObj.ID, su.allocationObj.Tx, dataBuffers[ind].Bytes(), su.httpMethod)
 				if err != nil {
@@ -89,9 +101,16 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 				err, shouldContinue = func() (err error, shouldContinue bool) {
 					resp := fasthttp.AcquireResponse()
 					defer fasthttp.ReleaseResponse(resp)
-					err = zboxutil.FastHttpClient.DoTimeout(req, resp, su.uploadTimeOut)
-					fasthttp.ReleaseRequest(req)
+					atomic.AddInt32(&sb.inFlight, 1)
+					err = zboxutil.FastDoContext(ctx, req, resp, su.uploadTimeOut)
+					var abandoned *zboxutil.AbandonedRequestError
+					if !errors.As(err, &abandoned) {
+						atomic.AddInt32(&sb.inFlight, -1)
+					}
 					if err != nil {
+						if ctx.Err() != nil {
+							return err, false
+						}
 						logger.Logger.Error("Upload : ", err)
 						if errors.Is(err, fasthttp.ErrConnectionClosed) || errors.Is(err, syscall.EPIPE) {
 							return err, true
@@ -100,6 +119,9 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 					}
 
 					if resp.StatusCode() == http.StatusOK {
+						if isFinal && ind == len(dataBuffers)-1 {
+							sb.recordShardHash(formData.DataHash, resp.Body())
+						}
 						return
 					}
 
@@ -112,7 +134,12 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 							logger.Logger.Error(err)
 							return
 						}
-						time.Sleep(time.Duration(r) * time.Second)
+						select {
+						case <-ctx.Done():
+							err = context.Cause(ctx)
+							return
+						case <-time.After(time.Duration(r) * time.Second):
+						}
 						shouldContinue = true
 						return
 					}
@@ -121,6 +148,11 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 					logger.Logger.Error(sb.blobber.Baseurl,
 						" Upload error response: ", resp.StatusCode(),
 						"err message: ", msg)
+					if resp.StatusCode() == http.StatusPreconditionFailed {
+						// the file changed since the precondition was checked, see WithIfMatch.
+						err = thrown.Wrap(ErrPreconditionFailed, msg)
+						return
+					}
 					err = errors.Throw(constants.ErrBadRequest, msg)
 					return
 				}()
@@ -128,10 +160,13 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 				if shouldContinue {
 					continue
 				}
-				buff := &bytebufferpool.ByteBuffer{
-					B: dataBuffers[ind].Bytes(),
+				if ctx.Err() == nil {
+					// the buffer may still be sent by a request abandoned on cancellation.
+					buff := &bytebufferpool.ByteBuffer{
+						B: dataBuffers[ind].Bytes(),
+					}
+					formDataPool.Put(buff)
 				}
-				formDataPool.Put(buff)
 
 				if err != nil {
 					return err
@@ -172,6 +207,17 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 	return nil
 }
 
+// recordShardHash records the hashes of the shard once uploaded, from the response to the final request.
+func (sb *ChunkedUploadBlobber) recordShardHash(dataHash string, respBody []byte) {
+	sb.dataHash = dataHash
+	var result UploadResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		logger.Logger.Debug("invalid upload response from ", sb.blobber.Baseurl, ": ", err)
+		return
+	}
+	sb.storedDataHash = result.DataHash
+}
+
 func (sb *ChunkedUploadBlobber) processCommit(ctx context.Context, su *ChunkedUpload, pos uint64, timestamp int64) (err error) {
 	defer func() {
 		if err != nil {
@@ -68,7 +68,8 @@ type chunkedUploadChunkReader struct {
 	// nextChunkIndex next index for reading
 	nextChunkIndex int
 
-	dataShards int
+	dataShards   int
+	parityShards int
 
 	// encryptOnUpload enccrypt data on upload
 	encryptOnUpload bool
@@ -78,6 +79,12 @@ type chunkedUploadChunkReader struct {
 	erasureEncoder reedsolomon.Encoder
 	// encscheme encryption scheme
 	encscheme encryption.EncryptionScheme
+	// fragmentEncoder offloads the encoding of the chunks, see SetFragmentEncoder
+	fragmentEncoder   FragmentEncoder
+	encryptPrivateKey string
+	encryptedKeyPoint string
+	// onChunk runs the upload hooks on the data of the chunks, see UploadHook.
+	onChunk func(index int, data []byte) error
 	// hasher to calculate actual file hash, validation root and fixed merkle root
 	hasher         Hasher
 	hasherDataChan chan []byte
@@ -111,6 +118,7 @@ func createChunkReader(fileReader io.Reader, size, chunkSize int64, dataShards,
 		chunkSize:       chunkSize,
 		nextChunkIndex:  0,
 		dataShards:      dataShards,
+		parityShards:    parityShards,
 		encryptOnUpload: encryptOnUpload,
 		uploadMask:      uploadMask,
 		erasureEncoder:  erasureEncoder,
@@ -234,35 +242,23 @@ func (r *chunkedUploadChunkReader) Next() (*ChunkData, error) {
 		return chunk, r.hasherError
 	}
 
+	if r.onChunk != nil {
+		if err := r.onChunk(chunk.Index, chunkBytes); err != nil {
+			return nil, err
+		}
+	}
+
 	if CurrentMode == UploadModeHigh {
 		r.hasherDataChan <- chunkBytes
 	} else {
 		_ = r.hasher.WriteToFile(chunkBytes)
 	}
 
-	fragments, err := r.erasureEncoder.Split(chunkBytes)
+	fragments, err := r.encodeFragments(chunkBytes)
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.erasureEncoder.Encode(fragments)
-	if err != nil {
-		return nil, err
-	}
-	var pos uint64
-	if r.encryptOnUpload {
-		for i := r.uploadMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
-			pos = uint64(i.TrailingZeros())
-			encMsg, err := r.encscheme.Encrypt(fragments[pos])
-			if err != nil {
-				return nil, err
-			}
-			fragments[pos] = make([]byte, len(encMsg.EncryptedData)+EncryptionHeaderSize)
-			n := copy(fragments[pos], encMsg.MessageChecksum+encMsg.OverallChecksum)
-			copy(fragments[pos][n:], encMsg.EncryptedData)
-		}
-	}
-
 	chunk.Fragments = fragments
 	r.nextChunkIndex++
 	r.offset += r.totalChunkDataSizePerRead
@@ -280,31 +276,40 @@ func (r *chunkedUploadChunkReader) Read(buf []byte) ([][]byte, error) {
 		return nil, errors.Throw(constants.ErrInvalidParameter, "r")
 	}
 
-	fragments, err := r.erasureEncoder.Split(buf)
-	if err != nil {
-		return nil, err
-	}
+	return r.encodeFragments(buf)
+}
 
-	err = r.erasureEncoder.Encode(fragments)
-	if err != nil {
-		return nil, err
+// encodeFragments erasure codes and encrypts the data with the fragment encoder if one is set,
+// in process otherwise.
+func (r *chunkedUploadChunkReader) encodeFragments(data []byte) ([][]byte, error) {
+	if r.fragmentEncoder != nil {
+		req := &EncodeFragmentsRequest{
+			Data:         data,
+			DataShards:   r.dataShards,
+			ParityShards: r.parityShards,
+			EncryptMask:  r.uploadMask,
+		}
+		if r.encryptOnUpload {
+			req.EncryptPrivateKey = r.encryptPrivateKey
+			req.EncryptedKeyPoint = r.encryptedKeyPoint
+		}
+		return r.fragmentEncoder.EncodeFragments(req)
 	}
 
-	var pos uint64
+	var encscheme encryption.EncryptionScheme
 	if r.encryptOnUpload {
-		for i := r.uploadMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
-			pos = uint64(i.TrailingZeros())
-			encMsg, err := r.encscheme.Encrypt(fragments[pos])
-			if err != nil {
-				return nil, err
-			}
-			fragments[pos] = make([]byte, len(encMsg.EncryptedData)+EncryptionHeaderSize)
-			n := copy(fragments[pos], encMsg.MessageChecksum+encMsg.OverallChecksum)
-			copy(fragments[pos][n:], encMsg.EncryptedData)
-		}
+		encscheme = r.encscheme
 	}
+	return encodeFragments(r.erasureEncoder, encscheme, r.uploadMask, data)
+}
 
-	return fragments, nil
+// setFragmentEncoder offloads the encoding of the chunks to the fragment encoder.
+//   - enc: the fragment encoder
+//   - encryptPrivateKey, encryptedKeyPoint: the encryption key of the file, if it's encrypted
+func (r *chunkedUploadChunkReader) setFragmentEncoder(enc FragmentEncoder, encryptPrivateKey, encryptedKeyPoint string) {
+	r.fragmentEncoder = enc
+	r.encryptPrivateKey = encryptPrivateKey
+	r.encryptedKeyPoint = encryptedKeyPoint
 }
 
 func (r *chunkedUploadChunkReader) Reset() {
@@ -83,7 +83,7 @@ func TestReadChunks(t *testing.T) {
 				int64(test.ChunkSize), test.DataShards, test.ParityShards,
 				test.EncryptOnUpload, uploadMask,
 				erasureEncoder, encscheme,
-				CreateHasher(getShardSize(test.Size, test.DataShards, test.EncryptOnUpload)), 100,
+				CreateHasher(getShardSize(test.Size, test.DataShards, test.EncryptOnUpload, DefaultChunkSize)), 100,
 			)
 			require.Nil(err)
 
@@ -87,6 +87,10 @@ func (b *chunkedUploadFormBuilder) Build(
 		EncryptedKeyPoint: encryptedKeyPoint,
 		EncryptedKey:      encryptedKey,
 		CustomMeta:        fileMeta.CustomMeta,
+		IfMatch:           fileMeta.IfMatch,
+	}
+	if !fileMeta.Attributes.IsZero() {
+		formData.Attributes = &fileMeta.Attributes
 	}
 
 	for i := 0; i < numBodies; i++ {
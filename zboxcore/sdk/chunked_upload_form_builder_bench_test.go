@@ -57,7 +57,7 @@ func BenchmarkChunkedUploadFormBuilder(b *testing.B) {
 
 				isFinal := false
 
-				hasher := CreateHasher(getShardSize(fileMeta.ActualSize, 1, false))
+				hasher := CreateHasher(getShardSize(fileMeta.ActualSize, 1, false, DefaultChunkSize))
 				for chunkIndex := 0; ; chunkIndex++ {
 					begin := int64(chunkIndex * bm.ChunkSize)
 					end := int64(chunkIndex*bm.ChunkSize + bm.ChunkSize)
@@ -68,7 +68,7 @@ func BenchmarkChunkedUploadFormBuilder(b *testing.B) {
 
 					fileBytes := buf[begin:end]
 
-					_, err := builder.Build(fileMeta, hasher, "connectionID", int64(bm.ChunkSize), chunkIndex, chunkIndex, isFinal, "", "", [][]byte{fileBytes}, nil, getShardSize(fileMeta.ActualSize, 1, false))
+					_, err := builder.Build(fileMeta, hasher, "connectionID", int64(bm.ChunkSize), chunkIndex, chunkIndex, isFinal, "", "", [][]byte{fileBytes}, nil, getShardSize(fileMeta.ActualSize, 1, false, DefaultChunkSize))
 					if err != nil {
 						b.Fatal(err)
 						return
@@ -43,6 +43,15 @@ type ChunkedUpload struct {
 	fileErasureEncoder reedsolomon.Encoder
 	fileEncscheme      encryption.EncryptionScheme
 	fileHasher         Hasher
+	// checksumChunker computes the chunk checksums of the file, see WithChunkChecksums.
+	checksumChunker *cdcChunker
+	chunkChecksums  bool
+	// deterministicConnectionID derives the connection id from the upload, see WithDeterministicConnectionID.
+	deterministicConnectionID bool
+	// hooks process the file before it's uploaded, see UploadHook.
+	hooks []UploadHook
+	// bandwidth limits the rate the file is read at, nil for no limit, see Repairer.
+	bandwidth *bandwidthLimiter
 
 	thumbnailBytes         []byte
 	thumbailErasureEncoder reedsolomon.Encoder
@@ -121,6 +130,11 @@ type FileMeta struct {
 	RemotePath string
 	// CustomMeta custom meta data
 	CustomMeta string
+	// Attributes attributes of the file, e.g. who pays for its reads
+	Attributes fileref.Attributes
+	// IfMatch is the expected actual hash of the remote file of an update, the update fails with
+	// ErrPreconditionFailed if the file changed. No precondition if empty, see WithIfMatch.
+	IfMatch string
 }
 
 // FileID generate id of progress on local cache
@@ -153,10 +167,12 @@ type UploadFormData struct {
 	// ActualThumbnailHash hash of original thumbnail (un-encoded, un-encrypted)
 	ActualThumbHash string `json:"actual_thumb_hash,omitempty"`
 
-	MimeType          string `json:"mimetype,omitempty"`
-	CustomMeta        string `json:"custom_meta,omitempty"`
-	EncryptedKey      string `json:"encrypted_key,omitempty"`
-	EncryptedKeyPoint string `json:"encrypted_key_point,omitempty"`
+	MimeType          string              `json:"mimetype,omitempty"`
+	CustomMeta        string              `json:"custom_meta,omitempty"`
+	IfMatch           string              `json:"if_match,omitempty"`
+	Attributes        *fileref.Attributes `json:"attributes,omitempty"`
+	EncryptedKey      string              `json:"encrypted_key,omitempty"`
+	EncryptedKeyPoint string              `json:"encrypted_key_point,omitempty"`
 
 	IsFinal           bool   `json:"is_final,omitempty"`          // all of chunks are uploaded
 	ChunkStartIndex   int    `json:"chunk_start_index,omitempty"` // start index of chunks.
@@ -4,9 +4,10 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"math"
-	"os"
 	"time"
 
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/zboxutil"
 	"github.com/klauspost/reedsolomon"
 )
@@ -41,7 +42,7 @@ func WithThumbnail(buf []byte) ChunkedUploadOption {
 // 		- fileName: file name of the thumbnail, which will be read and uploaded
 func WithThumbnailFile(fileName string) ChunkedUploadOption {
 
-	buf, _ := os.ReadFile(fileName)
+	buf, _ := sys.Files.ReadFile(fileName)
 
 	return WithThumbnail(buf)
 }
@@ -56,6 +57,42 @@ func WithChunkNumber(num int) ChunkedUploadOption {
 	}
 }
 
+// WithChunkSize sets the size of the chunks of the upload, the erasure coded blocks sent to each blobber.
+// Small chunks suit many tiny files, large chunks huge files like videos. The size is recorded in the
+// metadata of the file, so the downloads use it. ignore if size <= 0, DefaultChunkSize by default.
+// The chunk number is reduced for the large chunks, so an upload request doesn't carry more than 100
+// chunks of the default size.
+// 		- size: the chunk size in bytes, between MinChunkSize and MaxChunkSize and in the bounds of the blobbers
+func WithChunkSize(size int64) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		if size > 0 {
+			su.chunkSize = size
+		}
+	}
+}
+
+// WithIfMatch makes an update conditional on the current content of the remote file, for optimistic
+// concurrency. The update fails with ErrPreconditionFailed if the actual hash of the remote file isn't
+// the expected one, e.g. the file was updated by another client since it was last read. The hash is
+// checked before the upload and sent to the blobbers too, so they reject the upload if the file
+// changed meanwhile.
+// 		- hash: the expected actual hash of the remote file, e.g. ListResult.Hash
+func WithIfMatch(hash string) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.fileMeta.IfMatch = hash
+	}
+}
+
+// WithDeterministicConnectionID derives the connection id of the upload from the allocation, the remote
+// path and the content hash of the file, instead of a random one. An upload retried after a crash then
+// reuses the connection of the previous attempt even if its progress was lost, and the blobbers
+// deduplicate the shards already received. The content hash is required, see WithActualHash.
+func WithDeterministicConnectionID() ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.deterministicConnectionID = true
+	}
+}
+
 // WithEncrypt turn on/off encrypt on upload. It is turn off as default.
 // 		- on: true to turn on, false to turn off
 func WithEncrypt(on bool) ChunkedUploadOption {
@@ -114,6 +151,25 @@ func WithActualHash(hash string) ChunkedUploadOption {
 	}
 }
 
+// WithMimeType return a wrapper option function to override the mime type of the file, detected from
+// its extension and its content otherwise.
+//   - mimeType: the mime type of the file, e.g. "application/pdf".
+func WithMimeType(mimeType string) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.fileMeta.MimeType = mimeType
+	}
+}
+
+// WithWhoPaysForReads return a wrapper option function to set who pays for the reads of the file. The owner
+// pays by default, a publisher hosting freely downloadable content pays at its own read cost this way, and
+// the 3rd party users downloading the file pay with WhoPays3rdParty.
+//   - whoPays: the payer of the reads of the file.
+func WithWhoPaysForReads(whoPays common.WhoPays) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.fileMeta.Attributes.WhoPaysForReads = whoPays
+	}
+}
+
 // WithActualSize return a wrapper option function to set the file hasher used in the chunked upload instance
 func WithFileHasher(h Hasher) ChunkedUploadOption {
 	return func(su *ChunkedUpload) {
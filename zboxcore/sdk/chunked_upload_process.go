@@ -43,6 +43,10 @@ func (su *ChunkedUpload) processUpload(chunkStartIndex, chunkEndIndex int,
 	fileShards []blobberShards, thumbnailShards blobberShards,
 	isFinal bool, uploadLength int64) error {
 
+	if su.ctx.Err() != nil {
+		return context.Cause(su.ctx)
+	}
+
 	//chunk has not be uploaded yet
 	if chunkEndIndex <= su.progress.ChunkIndex {
 		// Write data to hashers
@@ -136,6 +140,7 @@ func (su *ChunkedUpload) processUpload(chunkStartIndex, chunkEndIndex int,
 		su.uploadWG.Add(1)
 		select {
 		case <-su.ctx.Done():
+			su.uploadWG.Done()
 			return context.Cause(su.ctx)
 		case su.uploadChan <- blobberUpload:
 		}
@@ -143,11 +148,8 @@ func (su *ChunkedUpload) processUpload(chunkStartIndex, chunkEndIndex int,
 
 	if isFinal {
 		close(su.uploadChan)
-		su.uploadWG.Wait()
-		select {
-		case <-su.ctx.Done():
-			return context.Cause(su.ctx)
-		default:
+		if err := su.waitUploads(); err != nil {
+			return err
 		}
 		blobberUpload.uploadBody = finalBuffer
 		return su.uploadToBlobbers(blobberUpload)
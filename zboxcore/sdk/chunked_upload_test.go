@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blobberclient"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetShardSize_ChunkSize(t *testing.T) {
+	// 2 full chunks of 4 data shards and a remainder of 100 bytes
+	size := int64(2*4*MinChunkSize + 100)
+	require.Equal(t, int64(2*MinChunkSize+25), getShardSize(size, 4, false, MinChunkSize))
+	require.Equal(t, (size+3)/4, getShardSize(size, 4, false, DefaultChunkSize))
+
+	require.Equal(t, int64(3), actualNumBlocks(2*MinChunkSize+1, MinChunkSize))
+	require.Equal(t, int64(1), actualNumBlocks(2*MinChunkSize+1, 0))
+}
+
+func TestAllocation_ValidateChunkSize(t *testing.T) {
+	a := &Allocation{Blobbers: []*blockchain.StorageNode{{Baseurl: "http://b1"}, {Baseurl: "http://b2"}, {Baseurl: "http://b3"}}}
+	caps := map[string]*blobberclient.Capabilities{
+		"http://b1": {MaxChunkSize: 1024 * 1024},
+		"http://b2": {MinChunkSize: 8 * 1024},
+		// b3 predates the capabilities
+	}
+	getCaps := getBlobberCapabilities
+	getBlobberCapabilities = func(_ context.Context, blobber *blockchain.StorageNode) (*blobberclient.Capabilities, error) {
+		return caps[blobber.Baseurl], nil
+	}
+	defer func() { getBlobberCapabilities = getCaps }()
+
+	ctx := context.Background()
+	require.NoError(t, a.validateChunkSize(ctx, 256*1024))
+	require.Error(t, a.validateChunkSize(ctx, 2*1024*1024))
+	require.Error(t, a.validateChunkSize(ctx, 4*1024))
+	require.Error(t, a.validateChunkSize(ctx, MaxChunkSize+1))
+}
+
+func TestAllocation_UpdateFileIfMatch(t *testing.T) {
+	a := &Allocation{}
+	setupMockAllocation(t, a)
+	require.Error(t, a.UpdateFileIfMatch("", "/tmp/a.txt", "/a.txt", "", nil))
+
+	su := &ChunkedUpload{}
+	WithIfMatch("hash")(su)
+	require.Equal(t, "hash", su.fileMeta.IfMatch)
+
+	data, err := json.Marshal(UploadFormData{IfMatch: su.fileMeta.IfMatch})
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"if_match":"hash"`)
+	data, err = json.Marshal(UploadFormData{})
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "if_match")
+}
+
+func TestChunkedUpload_Cancel(t *testing.T) {
+	su := &ChunkedUpload{uploadChan: make(chan UploadData, 4)}
+	su.ctx, su.ctxCncl = context.WithCancelCause(context.Background())
+
+	// a chunk is queued, then the upload is canceled before it's uploaded.
+	su.uploadWG.Add(1)
+	su.uploadChan <- UploadData{}
+	su.ctxCncl(ErrCancelUpload)
+
+	done := make(chan struct{})
+	go func() {
+		su.uploadProcessor()
+		close(done)
+	}()
+	<-done
+	require.ErrorIs(t, su.waitUploads(), ErrCancelUpload)
+	su.uploadWG.Wait()
+
+	err := su.processUpload(0, 0, []blobberShards{{}}, nil, false, 0)
+	require.ErrorIs(t, err, ErrCancelUpload)
+
+	// the blobbers with requests abandoned in flight are flagged.
+	su.progress.ConnectionID = "conn"
+	su.blobbers = []*ChunkedUploadBlobber{
+		{blobber: &blockchain.StorageNode{ID: "b0"}},
+		{blobber: &blockchain.StorageNode{ID: "b1"}, inFlight: 1},
+	}
+	err = su.canceledError()
+	require.ErrorIs(t, err, ErrCancelUpload)
+	var canceled *UploadCanceledError
+	require.ErrorAs(t, err, &canceled)
+	require.Equal(t, "conn", canceled.ConnectionID)
+	require.Equal(t, []string{"b1"}, canceled.BlobberIDs)
+	su.blobbers[1].inFlight = 0
+	require.Equal(t, ErrCancelUpload, su.canceledError())
+}
+
+func TestChunkedUpload_VerifyShardHashes(t *testing.T) {
+	su := &ChunkedUpload{uploadMask: zboxutil.NewUint128(1).Lsh(4).Sub64(1), maskMu: &sync.Mutex{}}
+	su.consensus.consensusThresh = 3
+	for i := 0; i < 4; i++ {
+		su.blobbers = append(su.blobbers, &ChunkedUploadBlobber{
+			blobber:  &blockchain.StorageNode{Baseurl: "http://blobber"},
+			dataHash: "hash",
+		})
+	}
+	// the blobbers not returning the hash aren't checked, an event reports them.
+	su.blobbers[0].storedDataHash = "hash"
+	su.blobbers[2].storedDataHash = "corrupted"
+	sub := SubscribeEvents(4, EventShardHashUnverified)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, su.verifyShardHashes())
+	require.Len(t, sub.Events(), 2)
+	require.Equal(t, 3, su.uploadMask.CountOnes())
+	require.True(t, su.uploadMask.And(zboxutil.NewUint128(1).Lsh(2)).Equals64(0))
+
+	su.blobbers[3].storedDataHash = "corrupted"
+	err := su.verifyShardHashes()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "shard_hash_mismatch")
+	require.Equal(t, 2, su.uploadMask.CountOnes())
+}
@@ -0,0 +1,241 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// CollaboratorRequest is the request of the collaborators of a file to the blobbers of the allocation.
+type CollaboratorRequest struct {
+	a              *Allocation
+	path           string
+	collaboratorID string
+}
+
+func (req *CollaboratorRequest) updateCollaboratorToBlobber(blobber *blockchain.StorageNode) error {
+	body := new(bytes.Buffer)
+	formWriter := multipart.NewWriter(body)
+	if err := formWriter.WriteField("path", req.path); err != nil {
+		return err
+	}
+	if err := formWriter.WriteField("collab_id", req.collaboratorID); err != nil {
+		return err
+	}
+	formWriter.Close()
+
+	httpreq, err := zboxutil.NewCollaboratorRequest(blobber.Baseurl, req.a.ID, req.a.Tx, req.a.sig, body)
+	if err != nil {
+		l.Logger.Error(blobber.Baseurl, "Error creating collaborator request", err)
+		return err
+	}
+	httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
+	return req.do(httpreq, nil)
+}
+
+func (req *CollaboratorRequest) removeCollaboratorFromBlobber(blobber *blockchain.StorageNode) error {
+	query := &url.Values{}
+	query.Add("path", req.path)
+	query.Add("collab_id", req.collaboratorID)
+
+	httpreq, err := zboxutil.DeleteCollaboratorRequest(blobber.Baseurl, req.a.ID, req.a.Tx, req.a.sig, query)
+	if err != nil {
+		l.Logger.Error(blobber.Baseurl, "Error creating collaborator request", err)
+		return err
+	}
+	return req.do(httpreq, nil)
+}
+
+func (req *CollaboratorRequest) getCollaboratorsFromBlobber(blobber *blockchain.StorageNode) ([]fileref.Collaborator, error) {
+	query := &url.Values{}
+	query.Add("path", req.path)
+
+	httpreq, err := zboxutil.GetCollaboratorsRequest(blobber.Baseurl, req.a.ID, req.a.Tx, req.a.sig, query)
+	if err != nil {
+		l.Logger.Error(blobber.Baseurl, "Error creating collaborator request", err)
+		return nil, err
+	}
+	var collaborators []fileref.Collaborator
+	err = req.do(httpreq, &collaborators)
+	return collaborators, err
+}
+
+// do sends a request to a blobber and decodes its response in v if not nil.
+func (req *CollaboratorRequest) do(httpreq *http.Request, v interface{}) error {
+	ctx, cncl := context.WithTimeout(req.a.ctx, (time.Second * 30))
+	defer cncl()
+	return zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+		if err != nil {
+			l.Logger.Error("Collaborator : ", err)
+			return err
+		}
+		defer resp.Body.Close()
+		respBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "Error: Resp")
+		}
+		if resp.StatusCode != http.StatusOK {
+			return errors.New("response_error", string(respBody))
+		}
+		if v != nil {
+			if err = json.Unmarshal(respBody, v); err != nil {
+				return errors.Wrap(err, "collaborators response parse error")
+			}
+		}
+		return nil
+	})
+}
+
+// toBlobbers runs fn for each blobber of the allocation in parallel and returns their errors, by blobber.
+func (req *CollaboratorRequest) toBlobbers(fn func(blobberIdx int, blobber *blockchain.StorageNode) error) []error {
+	blobberErrors := make([]error, len(req.a.Blobbers))
+	wg := &sync.WaitGroup{}
+	for i, blobber := range req.a.Blobbers {
+		wg.Add(1)
+		go func(blobberIdx int, blobber *blockchain.StorageNode) {
+			defer wg.Done()
+			blobberErrors[blobberIdx] = fn(blobberIdx, blobber)
+		}(i, blobber)
+	}
+	wg.Wait()
+	return blobberErrors
+}
+
+func (a *Allocation) newCollaboratorRequest(filePath, collaboratorID string) (*CollaboratorRequest, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	filePath = zboxutil.RemoteClean(filePath)
+	if !zboxutil.IsRemoteAbs(filePath) || filePath == "/" {
+		return nil, errors.New("invalid_path", "Path should be the valid and absolute path of a file")
+	}
+	return &CollaboratorRequest{a: a, path: filePath, collaboratorID: collaboratorID}, nil
+}
+
+// AddCollaborator adds a collaborator to a file of the allocation, so the collaborator's wallet can update
+// the file even if the allocation doesn't grant the update operation. Only the owner of the allocation can
+// add collaborators. The collaborator is added on all the blobbers, an error is returned otherwise.
+//   - filePath: the remote path of the file.
+//   - collaboratorID: the client id of the collaborator.
+func (a *Allocation) AddCollaborator(filePath, collaboratorID string) error {
+	if collaboratorID == "" {
+		return errors.New("invalid_collaborator", "Collaborator id is required")
+	}
+	req, err := a.newCollaboratorRequest(filePath, collaboratorID)
+	if err != nil {
+		return err
+	}
+	blobberErrors := req.toBlobbers(func(_ int, blobber *blockchain.StorageNode) error {
+		return req.updateCollaboratorToBlobber(blobber)
+	})
+	if err := zboxutil.MajorError(blobberErrors); err != nil {
+		return errors.Wrap(err, "add collaborator failed on some blobbers")
+	}
+	return nil
+}
+
+// RemoveCollaborator removes a collaborator from a file of the allocation, see AddCollaborator.
+//   - filePath: the remote path of the file.
+//   - collaboratorID: the client id of the collaborator.
+func (a *Allocation) RemoveCollaborator(filePath, collaboratorID string) error {
+	if collaboratorID == "" {
+		return errors.New("invalid_collaborator", "Collaborator id is required")
+	}
+	req, err := a.newCollaboratorRequest(filePath, collaboratorID)
+	if err != nil {
+		return err
+	}
+	blobberErrors := req.toBlobbers(func(_ int, blobber *blockchain.StorageNode) error {
+		return req.removeCollaboratorFromBlobber(blobber)
+	})
+	if err := zboxutil.MajorError(blobberErrors); err != nil {
+		return errors.Wrap(err, "remove collaborator failed on some blobbers")
+	}
+	return nil
+}
+
+// ListCollaborators lists the collaborators of a file of the allocation, the ones listed by the consensus
+// of the blobbers, by client id.
+//   - filePath: the remote path of the file.
+func (a *Allocation) ListCollaborators(filePath string) ([]fileref.Collaborator, error) {
+	req, err := a.newCollaboratorRequest(filePath, "")
+	if err != nil {
+		return nil, err
+	}
+	responses := make([][]fileref.Collaborator, len(a.Blobbers))
+	blobberErrors := req.toBlobbers(func(blobberIdx int, blobber *blockchain.StorageNode) (err error) {
+		responses[blobberIdx], err = req.getCollaboratorsFromBlobber(blobber)
+		return
+	})
+	var succeeded int
+	for _, err := range blobberErrors {
+		if err == nil {
+			succeeded++
+		}
+	}
+	if succeeded < a.consensusThreshold {
+		return nil, errors.Wrap(zboxutil.MajorError(blobberErrors), "list collaborators failed")
+	}
+	return collaboratorsConsensus(responses, a.consensusThreshold), nil
+}
+
+// collaboratorsConsensus returns the collaborators listed by at least threshold blobbers, by client id.
+func collaboratorsConsensus(responses [][]fileref.Collaborator, threshold int) []fileref.Collaborator {
+	counts := make(map[string]int)
+	found := make(map[string]fileref.Collaborator)
+	for _, collaborators := range responses {
+		for _, c := range collaborators {
+			counts[c.ClientID]++
+			found[c.ClientID] = c
+		}
+	}
+	result := make([]fileref.Collaborator, 0, len(found))
+	for clientID, c := range found {
+		if counts[clientID] >= threshold {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClientID < result[j].ClientID
+	})
+	return result
+}
+
+// listFileCollaborators lists the collaborators of a file, a variable to be replaced in the tests.
+var listFileCollaborators = func(a *Allocation, remotePath string) ([]fileref.Collaborator, error) {
+	return a.ListCollaborators(remotePath)
+}
+
+// canUpdateFile returns true if the allocation grants the update operation or if the client is
+// a collaborator of the file.
+func (a *Allocation) canUpdateFile(remotePath string) bool {
+	if a.CanUpdate() {
+		return true
+	}
+	collaborators, err := listFileCollaborators(a, remotePath)
+	if err != nil {
+		l.Logger.Error("failed to list the collaborators of ", remotePath, ": ", err)
+		return false
+	}
+	clientID := client.GetClientID()
+	for _, c := range collaborators {
+		if c.ClientID == clientID {
+			return true
+		}
+	}
+	return false
+}
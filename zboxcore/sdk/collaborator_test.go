@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollaboratorsConsensus(t *testing.T) {
+	a := fileref.Collaborator{ClientID: "a"}
+	b := fileref.Collaborator{ClientID: "b"}
+	c := fileref.Collaborator{ClientID: "c"}
+	responses := [][]fileref.Collaborator{{b, a}, {a, b, c}, {a}, nil}
+	require.Equal(t, []fileref.Collaborator{a, b}, collaboratorsConsensus(responses, 2))
+	require.Equal(t, []fileref.Collaborator{a}, collaboratorsConsensus(responses, 3))
+	require.Empty(t, collaboratorsConsensus(responses, 4))
+}
+
+func TestAllocation_canUpdateFile(t *testing.T) {
+	listCollaborators := listFileCollaborators
+	defer func() {
+		listFileCollaborators = listCollaborators
+	}()
+
+	w, err := zcncrypto.NewSignatureScheme("bls0chain").GenerateKeys()
+	require.NoError(t, err)
+	walletJSON, err := json.Marshal(w)
+	require.NoError(t, err)
+	require.NoError(t, client.PopulateClient(string(walletJSON), "bls0chain"))
+
+	var listed []string
+	listFileCollaborators = func(a *Allocation, remotePath string) ([]fileref.Collaborator, error) {
+		listed = append(listed, remotePath)
+		if remotePath == "/shared.txt" {
+			return []fileref.Collaborator{{ClientID: "other"}, {ClientID: w.ClientID}}, nil
+		}
+		if remotePath == "/error.txt" {
+			return nil, errors.New("consensus_failed")
+		}
+		return []fileref.Collaborator{{ClientID: "other"}}, nil
+	}
+
+	require.True(t, (&Allocation{FileOptions: CanUpdateMask}).canUpdateFile("/private.txt"))
+	require.Empty(t, listed)
+
+	a := &Allocation{FileOptions: CanUploadMask}
+	require.True(t, a.canUpdateFile("/shared.txt"))
+	require.False(t, a.canUpdateFile("/private.txt"))
+	require.False(t, a.canUpdateFile("/error.txt"))
+	require.Equal(t, []string{"/shared.txt", "/private.txt", "/error.txt"}, listed)
+}
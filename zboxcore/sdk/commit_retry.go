@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"time"
+
+	l "github.com/0chain/gosdk/zboxcore/logger"
+)
+
+// commitRetryDelay is the delay before the first retry of the failed commits, it grows with the attempts.
+var commitRetryDelay = 10 * time.Second
+
+// resendWriteMarker commits again the pending write markers of a connection, see ResendWriteMarker.
+var resendWriteMarker = func(a *Allocation, connectionID string) error {
+	return a.ResendWriteMarker(connectionID)
+}
+
+// retryFailedCommits retries in the background the commits of an operation failed on some blobbers
+// while the consensus was reached, so the blobbers don't need a repair. EventCommitRetryDone is
+// published for the blobbers committed, EventCommitRetryFailed for the ones still failing after
+// CommitRetryAttempts attempts.
+func (a *Allocation) retryFailedCommits(commitReqs []*CommitRequest) {
+	if CommitRetryAttempts <= 0 || len(commitReqs) == 0 {
+		return
+	}
+	failed := make(map[string]bool)
+	for _, req := range commitReqs {
+		if req.result == nil || !req.result.Success {
+			failed[req.blobber.ID] = true
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+	go a.retryCommits(commitReqs[0].connectionID, failed, CommitRetryAttempts)
+}
+
+// retryCommits resends the pending write markers of a connection until the failed blobbers commit
+// or the attempts are exhausted. It stops once the allocation is shut down.
+func (a *Allocation) retryCommits(connectionID string, failed map[string]bool, attempts int) {
+	var lastErr error
+	outdated := make(map[string]bool)
+	for attempt := 1; attempt <= attempts && len(failed) > 0; attempt++ {
+		t := time.NewTimer(time.Duration(attempt) * commitRetryDelay)
+		select {
+		case <-t.C:
+		case <-a.ctx.Done():
+			t.Stop()
+			return
+		}
+		a.skipOutdatedMarkers(connectionID, failed, outdated)
+		if len(failed) == 0 {
+			break
+		}
+		lastErr = resendWriteMarker(a, connectionID)
+		if lastErr != nil {
+			l.Logger.Error("retry of the failed commits: ", connectionID, " attempt ", attempt, ": ", lastErr)
+		}
+
+		pending, err := getPendingCommitStore().List(a.ID)
+		if err != nil {
+			l.Logger.Error("list pending write markers: ", err)
+			continue
+		}
+		stillFailed := make(map[string]bool)
+		for _, pm := range pending {
+			if pm.ConnectionID == connectionID && failed[pm.BlobberID] {
+				stillFailed[pm.BlobberID] = true
+			}
+		}
+		for id := range failed {
+			if !stillFailed[id] {
+				publishEvent(Event{Type: EventCommitRetryDone, AllocationID: a.ID, BlobberID: id})
+			}
+		}
+		failed = stillFailed
+	}
+
+	if len(failed) == 0 && len(outdated) == 0 {
+		return
+	}
+	msg := "commit retries exhausted"
+	if lastErr != nil {
+		msg = lastErr.Error()
+	}
+	for id := range failed {
+		publishEvent(Event{Type: EventCommitRetryFailed, AllocationID: a.ID, BlobberID: id, Error: msg})
+	}
+	for id := range outdated {
+		publishEvent(Event{Type: EventCommitRetryFailed, AllocationID: a.ID, BlobberID: id, Error: "the write marker is outdated"})
+	}
+	publishEvent(Event{Type: EventRepairNeeded, AllocationID: a.ID})
+}
+
+// skipOutdatedMarkers drops the pending write markers of the failed blobbers older than the version of
+// the allocation, a later commit superseded them so the blobbers need a repair instead.
+//   - connectionID: the connection of the markers
+//   - failed: the failed blobbers, the ones with an outdated marker are removed
+//   - outdated: the blobbers with an outdated marker, the ones found are added
+func (a *Allocation) skipOutdatedMarkers(connectionID string, failed, outdated map[string]bool) {
+	store := getPendingCommitStore()
+	pending, err := store.List(a.ID)
+	if err != nil {
+		l.Logger.Error("list pending write markers: ", err)
+		return
+	}
+	version := a.getAllocationVersion()
+	for _, pm := range pending {
+		if pm.ConnectionID != connectionID || !failed[pm.BlobberID] || pm.Version >= version {
+			continue
+		}
+		if err := store.Remove(connectionID, pm.BlobberID); err != nil {
+			l.Logger.Error("remove outdated write marker: ", err)
+			continue
+		}
+		delete(failed, pm.BlobberID)
+		outdated[pm.BlobberID] = true
+	}
+}
@@ -35,6 +35,8 @@ type ReferencePathResult struct {
 type CommitResult struct {
 	Success      bool   `json:"success"`
 	ErrorMessage string `json:"error_msg,omitempty"`
+	// StatusCode is the http status of the last commit response of the blobber, 0 if unknown.
+	StatusCode int `json:"status_code,omitempty"`
 }
 
 func ErrorCommitResult(errMsg string) *CommitResult {
@@ -64,10 +66,17 @@ type CommitRequest struct {
 	isRepair      bool
 	repairVersion int64
 	repairOffset  string
+	// statusCode is the http status of the last commit response.
+	statusCode int
+	// release releases the worker restarted for the request once it's committed, see AddCommitRequest.
+	release func()
 }
 
 var commitChan map[string]chan *CommitRequest
-var initCommitMutex sync.Mutex
+
+// initCommitMutex guards commitChan, it's read locked while sending to the channels so they aren't
+// closed meanwhile by stopCommitWorkers.
+var initCommitMutex sync.RWMutex
 
 func InitCommitWorker(blobbers []*blockchain.StorageNode) {
 	initCommitMutex.Lock()
@@ -93,10 +102,28 @@ func startCommitWorker(blobberChan chan *CommitRequest, blobberID string) {
 			break
 		}
 		commitreq.processCommit()
+		if commitreq.release != nil {
+			// not released here as stopCommitWorkers waits for the requests being sent to this worker.
+			go commitreq.release()
+		}
 	}
 	initCommitMutex.Lock()
 	defer initCommitMutex.Unlock()
-	delete(commitChan, blobberID)
+	if commitChan[blobberID] == blobberChan {
+		delete(commitChan, blobberID)
+	}
+}
+
+// stopCommitWorkers stops the commit workers of blobbers, once their pending requests are committed.
+func stopCommitWorkers(blobbers []*blockchain.StorageNode) {
+	initCommitMutex.Lock()
+	defer initCommitMutex.Unlock()
+	for _, blobber := range blobbers {
+		if ch, ok := commitChan[blobber.ID]; ok {
+			close(ch)
+			delete(commitChan, blobber.ID)
+		}
+	}
 }
 
 func (commitreq *CommitRequest) processCommit() {
@@ -106,10 +133,12 @@ func (commitreq *CommitRequest) processCommit() {
 	err := commitreq.commitBlobber()
 	if err != nil {
 		commitreq.result = ErrorCommitResult(err.Error())
+		commitreq.result.StatusCode = commitreq.statusCode
 		return
 	}
 	l.Logger.Debug("[commitBlobber]", time.Since(start).Milliseconds())
 	commitreq.result = SuccessCommitResult()
+	commitreq.result.StatusCode = commitreq.statusCode
 }
 
 func (req *CommitRequest) commitBlobber() (err error) {
@@ -161,6 +190,7 @@ func (req *CommitRequest) commitBlobber() (err error) {
 				logger.Logger.Error("Commit: ", err)
 				return
 			}
+			req.statusCode = resp.StatusCode
 
 			if resp.Body != nil {
 				defer resp.Body.Close()
@@ -222,7 +252,27 @@ func (req *CommitRequest) commitBlobber() (err error) {
 }
 
 func AddCommitRequest(req *CommitRequest) {
-	commitChan[req.blobber.ID] <- req
+	if trySendCommitRequest(req) {
+		return
+	}
+	// the worker was stopped, see SetAllocationIdleTimeout. It's acquired for the request, so it
+	// isn't stopped until the request is committed, and released afterwards.
+	blobbers := []*blockchain.StorageNode{req.blobber}
+	acquireBlobberWorkers(blobbers)
+	req.release = func() { releaseBlobberWorkers(blobbers) }
+	trySendCommitRequest(req)
+}
+
+// trySendCommitRequest sends a request to the commit worker of its blobber, false if the worker is stopped.
+// The worker is looked up and sent to under the same lock, so it can't be stopped in between.
+func trySendCommitRequest(req *CommitRequest) bool {
+	initCommitMutex.RLock()
+	defer initCommitMutex.RUnlock()
+	ch, ok := commitChan[req.blobber.ID]
+	if ok {
+		ch <- req
+	}
+	return ok
 }
 
 func (commitreq *CommitRequest) calculateHashRequest(ctx context.Context, paths []string) error { //nolint
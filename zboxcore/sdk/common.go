@@ -114,6 +114,26 @@ type ProcessResult struct {
 
 var ErrFileNameTooLong = errors.New("invalid_parameter", "filename is longer than 150 characters")
 
+// ErrRequestTimeout is returned when the timeout given to an operation expired before the
+// blobbers answered, e.g. with WithListRequestTimeout.
+var ErrRequestTimeout = errors.New("request_timeout", "the operation timed out before the blobbers answered")
+
+// withTimeout bounds the context with the timeout, if it's set.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutError returns ErrRequestTimeout if the error is due to the expired deadline of the context.
+func timeoutError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrRequestTimeout
+	}
+	return err
+}
+
 func ValidateRemoteFileName(remotePath string) error {
 	_, fileName := path.Split(remotePath)
 
@@ -124,6 +144,11 @@ func ValidateRemoteFileName(remotePath string) error {
 	return nil
 }
 
+// subDirRequest renames, copies or moves the children of a directory: the files of all the levels
+// first, then the directories from the deepest level, the empty ones included. Each page of children
+// is processed with a multi operation, committed atomically on each blobber, the directory isn't: if a
+// page fails, the pages committed before it stay renamed, copied or moved, and the operation can be
+// run again to process the rest.
 type subDirRequest struct {
 	opType          string
 	subOpType       string
@@ -133,16 +158,54 @@ type subDirRequest struct {
 	ctx             context.Context
 	consensusThresh int
 	mask            zboxutil.Uint128
+	progress        DirOperationProgress
+	processed       int
+}
+
+// destDir returns the directory a child of the directory is renamed, copied or moved to.
+func (req *subDirRequest) destDir(refPath string) string {
+	if req.subOpType == constants.FileOperationRename {
+		return path.Dir(req.destPath + strings.TrimPrefix(refPath, req.remotefilepath))
+	}
+	basePath := strings.TrimPrefix(path.Dir(refPath), path.Dir(req.remotefilepath))
+	return path.Join(req.destPath, basePath)
+}
+
+// getSubDirRefs returns a page of the refs of the children of the directory, see Allocation.GetRefs.
+var getSubDirRefs = func(req *subDirRequest, offsetPath, fileType string, level int) (*ObjectTreeResult, error) {
+	return req.allocationObj.GetRefs(req.remotefilepath, offsetPath, "", "", fileType, fileref.REGULAR, level, getRefPageLimit, WithObjectContext(req.ctx), WithObjectConsensusThresh(req.consensusThresh), WithSingleBlobber(true), WithObjectMask(req.mask))
+}
+
+// doSubDirOperations processes a page of children of the directory, see Allocation.DoMultiOperation.
+var doSubDirOperations = func(a *Allocation, ops []OperationRequest) error {
+	return a.DoMultiOperation(ops)
+}
+
+// processPage processes a page of children and reports the progress.
+func (req *subDirRequest) processPage(ops []OperationRequest) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := doSubDirOperations(req.allocationObj, ops); err != nil {
+		return err
+	}
+	req.processed += len(ops)
+	if req.progress != nil {
+		req.progress(req.remotefilepath, req.processed)
+	}
+	return nil
 }
 
 func (req *subDirRequest) processSubDirectories() error {
-	var (
-		offsetPath string
-		pathLevel  int
-	)
+	level := len(strings.Split(strings.TrimSuffix(req.remotefilepath, "/"), "/"))
+	pathLevel, err := req.deepestDirLevel(level)
+	if err != nil {
+		return err
+	}
 
+	var offsetPath string
 	for {
-		oResult, err := req.allocationObj.GetRefs(req.remotefilepath, offsetPath, "", "", fileref.FILE, fileref.REGULAR, 0, getRefPageLimit, WithObjectContext(req.ctx), WithObjectConsensusThresh(req.consensusThresh), WithSingleBlobber(true), WithObjectMask(req.mask))
+		oResult, err := getSubDirRefs(req, offsetPath, fileref.FILE, 0)
 		if err != nil {
 			return err
 		}
@@ -155,25 +218,16 @@ func (req *subDirRequest) processSubDirectories() error {
 			if ref.Type == fileref.DIRECTORY {
 				continue
 			}
-			if ref.PathLevel > pathLevel {
-				pathLevel = ref.PathLevel
-			}
-			var destPath string
-			if req.subOpType == constants.FileOperationRename {
-				destPath = path.Dir(strings.Replace(ref.Path, req.remotefilepath, req.destPath, 1))
-			} else {
-				basePath := strings.TrimPrefix(path.Dir(ref.Path), path.Dir(req.remotefilepath))
-				destPath = path.Join(req.destPath, basePath)
-			}
 			op := OperationRequest{
-				OperationType: req.opType,
-				RemotePath:    ref.Path,
-				DestPath:      destPath,
-				Mask:          &opMask,
+				OperationType:     req.opType,
+				RemotePath:        ref.Path,
+				DestPath:          req.destDir(ref.Path),
+				Mask:              &opMask,
+				skipRetentionLock: true,
 			}
 			ops = append(ops, op)
 		}
-		err = req.allocationObj.DoMultiOperation(ops)
+		err = req.processPage(ops)
 		if err != nil {
 			return err
 		}
@@ -184,18 +238,14 @@ func (req *subDirRequest) processSubDirectories() error {
 	}
 
 	offsetPath = ""
-	level := len(strings.Split(strings.TrimSuffix(req.remotefilepath, "/"), "/"))
-	if pathLevel == 0 {
-		pathLevel = level + 1
-	}
-
 	for pathLevel > level {
-		oResult, err := req.allocationObj.GetRefs(req.remotefilepath, offsetPath, "", "", fileref.DIRECTORY, fileref.REGULAR, pathLevel, getRefPageLimit, WithObjectContext(req.ctx), WithObjectMask(req.mask), WithObjectConsensusThresh(req.consensusThresh), WithSingleBlobber(true))
+		oResult, err := getSubDirRefs(req, offsetPath, fileref.DIRECTORY, pathLevel)
 		if err != nil {
 			return err
 		}
 		if len(oResult.Refs) == 0 {
 			pathLevel--
+			offsetPath = ""
 		} else {
 			ops := make([]OperationRequest, 0, len(oResult.Refs))
 			for _, ref := range oResult.Refs {
@@ -203,31 +253,50 @@ func (req *subDirRequest) processSubDirectories() error {
 				if ref.Type == fileref.FILE {
 					continue
 				}
-				var destPath string
-				if req.subOpType == constants.FileOperationRename {
-					destPath = path.Dir(strings.Replace(ref.Path, req.remotefilepath, req.destPath, 1))
-				} else {
-					basePath := strings.TrimPrefix(path.Dir(ref.Path), path.Dir(req.remotefilepath))
-					destPath = path.Join(req.destPath, basePath)
-				}
 				op := OperationRequest{
-					OperationType: req.opType,
-					RemotePath:    ref.Path,
-					DestPath:      destPath,
-					Mask:          &opMask,
+					OperationType:     req.opType,
+					RemotePath:        ref.Path,
+					DestPath:          req.destDir(ref.Path),
+					Mask:              &opMask,
+					skipRetentionLock: true,
+					// the files of the directory are copied already
+					CopyDirOnly: req.opType == constants.FileOperationCopy,
 				}
 				ops = append(ops, op)
 			}
-			err = req.allocationObj.DoMultiOperation(ops)
+			err = req.processPage(ops)
 			if err != nil {
 				return err
 			}
 			offsetPath = oResult.Refs[len(oResult.Refs)-1].Path
 			if len(oResult.Refs) < getRefPageLimit {
 				pathLevel--
+				offsetPath = ""
 			}
 		}
 	}
 
 	return nil
 }
+
+// deepestDirLevel returns the level of the deepest subdirectory, the empty ones included, or the level
+// of the directory if it has none.
+//   - level: the level of the directory
+func (req *subDirRequest) deepestDirLevel(level int) (int, error) {
+	var offsetPath string
+	for {
+		oResult, err := getSubDirRefs(req, offsetPath, fileref.DIRECTORY, 0)
+		if err != nil {
+			return 0, err
+		}
+		for _, ref := range oResult.Refs {
+			if ref.Type == fileref.DIRECTORY && ref.PathLevel > level {
+				level = ref.PathLevel
+			}
+		}
+		if len(oResult.Refs) < getRefPageLimit {
+			return level, nil
+		}
+		offsetPath = oResult.Refs[len(oResult.Refs)-1].Path
+	}
+}
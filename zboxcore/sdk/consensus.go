@@ -1,12 +1,22 @@
 package sdk
 
-import "sync"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
 
+// Consensus counts the blobbers agreeing on the result of a request. The blobbers can vote with
+// Accept and Reject, so the request can stop once the consensus can't be reached anymore and
+// report the errors of the blobbers, see ConsensusError.
 type Consensus struct {
 	*sync.RWMutex
 	consensus       int // Total successful and valid response from blobbers
-	consensusThresh int // Consensus threshold percentage
+	consensusThresh int // Minimum number of blobbers required to agree
 	fullconsensus   int // Total number of blobbers in allocation
+	// votes are the votes of the blobbers by index, nil if the blobber accepted
+	votes map[uint64]error
 }
 
 // Done increase consensus by 1
@@ -18,8 +28,21 @@ func (c *Consensus) Done() {
 
 // Reset reset consensus to 0
 func (c *Consensus) Reset() {
+	c.resetTo(0)
+}
+
+// resetTo starts a new round of votes with n blobbers already agreeing.
+func (c *Consensus) resetTo(n int) {
+	c.Lock()
+	c.consensus = n
+	c.votes = nil
+	c.Unlock()
+}
+
+// setConsensus sets the number of blobbers agreeing, keeping their votes.
+func (c *Consensus) setConsensus(n int) {
 	c.Lock()
-	c.consensus = 0
+	c.consensus = n
 	c.Unlock()
 }
 
@@ -30,6 +53,39 @@ func (c *Consensus) Init(threshConsensus, fullConsensus int) {
 	c.Unlock()
 }
 
+// Accept records the blobber agrees, it's counted once.
+//   - pos: the index of the blobber in the allocation
+func (c *Consensus) Accept(pos uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if c.votes == nil {
+		c.votes = make(map[uint64]error)
+	}
+	if err, ok := c.votes[pos]; ok && err == nil {
+		return
+	}
+	c.votes[pos] = nil
+	c.consensus++
+}
+
+// Reject records the error of the blobber, it's ignored if the blobber already agreed.
+//   - pos: the index of the blobber in the allocation
+//   - err: the error of the blobber
+func (c *Consensus) Reject(pos uint64, err error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.votes == nil {
+		c.votes = make(map[uint64]error)
+	}
+	if prev, ok := c.votes[pos]; ok && prev == nil {
+		return
+	}
+	if err == nil {
+		err = fmt.Errorf("rejected")
+	}
+	c.votes[pos] = err
+}
+
 func (c *Consensus) getConsensus() int {
 	c.RLock()
 	defer c.RUnlock()
@@ -39,6 +95,103 @@ func (c *Consensus) getConsensus() int {
 func (c *Consensus) isConsensusOk() bool {
 	c.RLock()
 	defer c.RUnlock()
+	return c.consensus >= c.consensusThresh
+}
+
+// isConsensusReachable returns false once too many blobbers rejected the request for the
+// consensus to be reached, the request can stop early.
+func (c *Consensus) isConsensusReachable() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.fullconsensus-c.rejectedCount() >= c.consensusThresh
+}
+
+func (c *Consensus) rejectedCount() int {
+	var n int
+	for _, err := range c.votes {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// consensusError returns the decision of the consensus as an error.
+//   - op: the operation that failed, e.g. "Rename"
+func (c *Consensus) consensusError(op string) *ConsensusError {
+	c.RLock()
+	defer c.RUnlock()
+	ce := &ConsensusError{
+		Operation: op,
+		Required:  c.consensusThresh,
+		Got:       c.consensus,
+		Total:     c.fullconsensus,
+	}
+	for pos, err := range c.votes {
+		if err != nil {
+			if ce.Rejections == nil {
+				ce.Rejections = make(map[uint64]string)
+			}
+			ce.Rejections[pos] = err.Error()
+		}
+	}
+	return ce
+}
+
+// ConsensusError is returned when not enough blobbers agree on the result of a request. It
+// details the decision for debugging.
+type ConsensusError struct {
+	// Operation is the operation that failed, e.g. "Rename".
+	Operation string `json:"operation"`
+	// Required is the number of blobbers required to agree.
+	Required int `json:"required"`
+	// Got is the number of blobbers that agreed.
+	Got int `json:"got"`
+	// Total is the number of blobbers of the request.
+	Total int `json:"total"`
+	// Rejections are the errors of the blobbers that didn't agree, by blobber index.
+	Rejections map[uint64]string `json:"rejections,omitempty"`
+}
+
+func (e *ConsensusError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "consensus_not_met: %s failed. Required consensus %d got %d", e.Operation, e.Required, e.Got)
+	if len(e.Rejections) > 0 {
+		positions := make([]uint64, 0, len(e.Rejections))
+		for pos := range e.Rejections {
+			positions = append(positions, pos)
+		}
+		sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+		b.WriteString(". Blobber errors:")
+		for _, pos := range positions {
+			fmt.Fprintf(&b, " [%d] %s;", pos, e.Rejections[pos])
+		}
+	}
+	return b.String()
+}
+
+// ConsensusPolicy overrides the number of blobbers that must agree on the result of a read-only
+// request, e.g. to accept the first valid response of ListDir when speed matters, or to require all
+// the blobbers to agree for an audit. The zero value keeps the default threshold of the request.
+type ConsensusPolicy struct {
+	// Threshold is the minimum number of blobbers agreeing, 1 accepts the first valid response.
+	// It's capped to the number of blobbers of the allocation.
+	Threshold int
+	// Full requires all the blobbers of the allocation to agree, Threshold is ignored.
+	Full bool
+}
 
-	return c.getConsensus() >= c.consensusThresh
+// threshold returns the consensus threshold of the policy.
+//   - def: the default threshold of the request
+//   - full: the number of blobbers of the allocation
+func (p ConsensusPolicy) threshold(def, full int) int {
+	switch {
+	case p.Full:
+		return full
+	case p.Threshold <= 0:
+		return def
+	case p.Threshold > full:
+		return full
+	}
+	return p.Threshold
 }
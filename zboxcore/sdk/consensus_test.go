@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
@@ -58,3 +59,47 @@ func TestConsensus_isConsensusOk(t *testing.T) {
 		})
 	}
 }
+
+func TestConsensus_Votes(t *testing.T) {
+	c := &Consensus{RWMutex: &sync.RWMutex{}}
+	c.Init(3, 4)
+
+	c.Accept(0)
+	c.Accept(0)
+	c.Accept(1)
+	require.Equal(t, 2, c.getConsensus())
+	require.False(t, c.isConsensusOk())
+	require.True(t, c.isConsensusReachable())
+
+	c.Reject(1, errors.New("ignored, already accepted"))
+	c.Reject(2, errors.New("timeout"))
+	require.True(t, c.isConsensusReachable())
+	c.Reject(3, nil)
+	require.False(t, c.isConsensusReachable())
+
+	ce := c.consensusError("Rename")
+	require.Equal(t, 3, ce.Required)
+	require.Equal(t, 2, ce.Got)
+	require.Equal(t, 4, ce.Total)
+	require.Equal(t, map[uint64]string{2: "timeout", 3: "rejected"}, ce.Rejections)
+	require.Equal(t, "consensus_not_met: Rename failed. Required consensus 3 got 2. Blobber errors: [2] timeout; [3] rejected;", ce.Error())
+
+	c.resetTo(1)
+	require.Equal(t, 1, c.getConsensus())
+	require.True(t, c.isConsensusReachable())
+	require.Empty(t, c.consensusError("Rename").Rejections)
+}
+
+func TestConsensusPolicy_threshold(t *testing.T) {
+	require.Equal(t, 2, ConsensusPolicy{}.threshold(2, 4))
+	require.Equal(t, 1, ConsensusPolicy{Threshold: 1}.threshold(2, 4))
+	require.Equal(t, 4, ConsensusPolicy{Threshold: 10}.threshold(2, 4))
+	require.Equal(t, 4, ConsensusPolicy{Threshold: 1, Full: true}.threshold(2, 4))
+
+	req := &ListRequest{}
+	WithListRequestConsensus(ConsensusPolicy{Full: true})(req)
+	require.True(t, req.consensusPolicy.Full)
+	var options fileMetaOptions
+	WithFileMetaConsensus(ConsensusPolicy{Threshold: 1})(&options)
+	require.Equal(t, 1, options.consensus.Threshold)
+}
@@ -7,7 +7,6 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
-	"path"
 	"strings"
 	"sync"
 	"time"
@@ -41,6 +40,7 @@ type CopyRequest struct {
 	ctxCncl        context.CancelFunc
 	copyMask       zboxutil.Uint128
 	maskMU         *sync.Mutex
+	progress       DirOperationProgress
 	connectionID   string
 	timestamp      int64
 	Consensus
@@ -76,6 +76,7 @@ func (req *CopyRequest) copyBlobberObject(
 
 	defer func() {
 		if err != nil {
+			req.Consensus.Reject(uint64(blobberIdx), err)
 			req.maskMU.Lock()
 			// Removing blobber from mask
 			req.copyMask = req.copyMask.And(zboxutil.NewUint128(1).Lsh(uint64(blobberIdx)).Not())
@@ -146,7 +147,7 @@ func (req *CopyRequest) copyBlobberObject(
 
 			if resp.StatusCode == http.StatusOK {
 				l.Logger.Info(blobber.Baseurl, " "+req.remotefilepath, " copied.")
-				req.Consensus.Done()
+				req.Consensus.Accept(uint64(blobberIdx))
 				return
 			}
 
@@ -219,13 +220,24 @@ func (req *CopyRequest) ProcessWithBlobbers() ([]fileref.RefEntity, error) {
 		return nil, zboxutil.MajorError(blobberErrors)
 	}
 
-	if consensusRef.Type == fileref.DIRECTORY && !consensusRef.IsEmpty {
+	if consensusRef.Type == fileref.DIRECTORY && !consensusRef.IsEmpty && !req.dirOnly {
 		for ind, refEntity := range objectTreeRefs {
 			if refEntity.GetAllocationVersion() != consensusRef.AllocationVersion {
 				req.copyMask = req.copyMask.And(zboxutil.NewUint128(1).Lsh(uint64(ind)).Not())
 			}
 		}
-		err := req.copySubDirectoriees(req.dirOnly)
+		subRequest := &subDirRequest{
+			allocationObj:   req.allocationObj,
+			remotefilepath:  req.remotefilepath,
+			destPath:        req.destPath,
+			ctx:             req.ctx,
+			consensusThresh: req.consensusThresh,
+			opType:          constants.FileOperationCopy,
+			subOpType:       constants.FileOperationCopy,
+			mask:            req.copyMask,
+			progress:        req.progress,
+		}
+		err := subRequest.processSubDirectories()
 		if err != nil {
 			return nil, err
 		}
@@ -268,9 +280,7 @@ func (req *CopyRequest) ProcessCopy() error {
 			return errors.New("copy_failed", fmt.Sprintf("Copy failed. %s", err.Error()))
 		}
 
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Copy failed. Required consensus %d, got %d",
-				req.Consensus.consensusThresh, req.Consensus.consensus))
+		return req.Consensus.consensusError("Copy")
 	}
 
 	writeMarkerMutex, err := CreateWriteMarkerMutex(client.GetClient(), req.allocationObj)
@@ -329,6 +339,7 @@ func (req *CopyRequest) ProcessCopy() error {
 			connectionID: req.connectionID,
 			wg:           wg,
 			timestamp:    req.timestamp,
+			blobberInd:   pos,
 		}
 
 		commitReq.changes = append(commitReq.changes, newChange)
@@ -342,8 +353,9 @@ func (req *CopyRequest) ProcessCopy() error {
 		if commitReq.result != nil {
 			if commitReq.result.Success {
 				l.Logger.Info("Commit success", commitReq.blobber.Baseurl)
-				req.consensus++
+				req.Consensus.Accept(commitReq.blobberInd)
 			} else {
+				req.Consensus.Reject(commitReq.blobberInd, errors.New("commit_failed", commitReq.result.ErrorMessage))
 				l.Logger.Info("Commit failed", commitReq.blobber.Baseurl, commitReq.result.ErrorMessage)
 			}
 		} else {
@@ -352,9 +364,7 @@ func (req *CopyRequest) ProcessCopy() error {
 	}
 
 	if !req.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Commit on copy failed. Required consensus %d, got %d",
-				req.Consensus.consensusThresh, req.Consensus.consensus))
+		return req.Consensus.consensusError("Commit on copy")
 	}
 	return nil
 }
@@ -367,6 +377,7 @@ type CopyOperation struct {
 	ctxCncl        context.CancelFunc
 	copyMask       zboxutil.Uint128
 	maskMU         *sync.Mutex
+	progress       DirOperationProgress
 
 	Consensus
 }
@@ -391,6 +402,7 @@ func (co *CopyOperation) Process(allocObj *Allocation, connectionID string) ([]f
 		maskMU:         co.maskMU,
 		Consensus:      Consensus{RWMutex: &sync.RWMutex{}},
 		dirOnly:        co.dirOnly,
+		progress:       co.progress,
 	}
 
 	cR.consensusThresh = co.consensusThresh
@@ -407,9 +419,7 @@ func (co *CopyOperation) Process(allocObj *Allocation, connectionID string) ([]f
 			return nil, cR.copyMask, errors.New("copy_failed", fmt.Sprintf("Copy failed. %s", err.Error()))
 		}
 
-		return nil, cR.copyMask, errors.New("consensus_not_met",
-			fmt.Sprintf("Copy failed. Required consensus %d, got %d",
-				cR.Consensus.consensusThresh, cR.Consensus.consensus))
+		return nil, cR.copyMask, cR.Consensus.consensusError("Copy")
 	}
 	return objectTreeRefs, cR.copyMask, err
 
@@ -472,6 +482,7 @@ func NewCopyOperation(remotePath string, destPath string, copyMask zboxutil.Uint
 	co.maskMU = maskMU
 	co.consensusThresh = consensusTh
 	co.fullconsensus = fullConsensus
+	co.dirOnly = copyDirOnly
 	if destPath != "/" {
 		destPath = strings.TrimSuffix(destPath, "/")
 	}
@@ -480,93 +491,3 @@ func NewCopyOperation(remotePath string, destPath string, copyMask zboxutil.Uint
 	return co
 
 }
-
-func (req *CopyRequest) copySubDirectoriees(dirOnly bool) error {
-	var (
-		offsetPath string
-		pathLevel  int
-	)
-
-	for {
-		if !dirOnly {
-			oResult, err := req.allocationObj.GetRefs(req.remotefilepath, offsetPath, "", "", fileref.FILE, fileref.REGULAR, 0, getRefPageLimit, WithObjectContext(req.ctx), WithObjectConsensusThresh(req.consensusThresh), WithSingleBlobber(true))
-			if err != nil {
-				return err
-			}
-			if len(oResult.Refs) == 0 {
-				break
-			}
-			ops := make([]OperationRequest, 0, len(oResult.Refs))
-			for _, ref := range oResult.Refs {
-				opMask := req.copyMask
-				if ref.Type == fileref.DIRECTORY {
-					continue
-				}
-				if ref.PathLevel > pathLevel {
-					pathLevel = ref.PathLevel
-				}
-				basePath := strings.TrimPrefix(path.Dir(ref.Path), path.Dir(req.remotefilepath))
-				destPath := path.Join(req.destPath, basePath)
-				op := OperationRequest{
-					OperationType: constants.FileOperationCopy,
-					RemotePath:    ref.Path,
-					DestPath:      destPath,
-					Mask:          &opMask,
-				}
-				ops = append(ops, op)
-			}
-			err = req.allocationObj.DoMultiOperation(ops)
-			if err != nil {
-				return err
-			}
-			offsetPath = oResult.Refs[len(oResult.Refs)-1].Path
-			if len(oResult.Refs) < getRefPageLimit {
-				break
-			}
-		}
-	}
-
-	offsetPath = ""
-	level := len(strings.Split(strings.TrimSuffix(req.remotefilepath, "/"), "/"))
-	if pathLevel == 0 {
-		pathLevel = level + 1
-	}
-
-	for pathLevel > level {
-		oResult, err := req.allocationObj.GetRefs(req.remotefilepath, offsetPath, "", "", fileref.DIRECTORY, fileref.REGULAR, pathLevel, getRefPageLimit, WithObjectContext(req.ctx), WithObjectMask(req.copyMask), WithObjectConsensusThresh(req.consensusThresh), WithSingleBlobber(true))
-		if err != nil {
-			return err
-		}
-		if len(oResult.Refs) == 0 {
-			pathLevel--
-		} else {
-			ops := make([]OperationRequest, 0, len(oResult.Refs))
-			for _, ref := range oResult.Refs {
-				opMask := req.copyMask
-				if ref.Type == fileref.FILE {
-					continue
-				}
-				basePath := strings.TrimPrefix(path.Dir(ref.Path), path.Dir(req.remotefilepath))
-				destPath := path.Join(req.destPath, basePath)
-				op := OperationRequest{
-					OperationType: constants.FileOperationCopy,
-					RemotePath:    ref.Path,
-					DestPath:      destPath,
-					Mask:          &opMask,
-					CopyDirOnly:   true,
-				}
-				ops = append(ops, op)
-			}
-			err = req.allocationObj.DoMultiOperation(ops)
-			if err != nil {
-				return err
-			}
-			offsetPath = oResult.Refs[len(oResult.Refs)-1].Path
-			if len(oResult.Refs) < getRefPageLimit {
-				pathLevel--
-			}
-		}
-	}
-
-	return nil
-}
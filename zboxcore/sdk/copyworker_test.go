@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/zcncrypto"
 	"github.com/0chain/gosdk/dev"
 	devMock "github.com/0chain/gosdk/dev/mock"
@@ -508,3 +509,79 @@ func TestCopyRequest_ProcessCopy(t *testing.T) {
 		})
 	}
 }
+
+func TestSubDirRequest_destDir(t *testing.T) {
+	rename := &subDirRequest{remotefilepath: "/docs", destPath: "/papers", subOpType: constants.FileOperationRename}
+	require.Equal(t, "/papers", rename.destDir("/docs/a.txt"))
+	require.Equal(t, "/papers/2024", rename.destDir("/docs/2024/b.txt"))
+
+	cp := &subDirRequest{remotefilepath: "/docs", destPath: "/backup", subOpType: constants.FileOperationCopy}
+	require.Equal(t, "/backup/docs", cp.destDir("/docs/a.txt"))
+	require.Equal(t, "/backup/docs/2024", cp.destDir("/docs/2024/b.txt"))
+
+	co := NewCopyOperation("/docs", "/backup/", zboxutil.NewUint128(1), &sync.Mutex{}, 1, 1, true, context.Background())
+	require.True(t, co.dirOnly)
+	require.Equal(t, "/backup", co.destPath)
+
+	var (
+		mo        MultiOperation
+		processed []int
+	)
+	WithDirOperationProgress(func(remotePath string, n int) {
+		require.Equal(t, "/docs", remotePath)
+		processed = append(processed, n)
+	})(&mo)
+	req := &subDirRequest{remotefilepath: "/docs", progress: mo.dirProgress}
+	require.NoError(t, req.processPage(nil))
+	require.Empty(t, processed)
+}
+
+func TestSubDirRequest_processSubDirectories(t *testing.T) {
+	refs := []ORef{
+		{SimilarField: SimilarField{Path: "/docs/a.txt", Type: fileref.FILE, PathLevel: 3}},
+		{SimilarField: SimilarField{Path: "/docs/sub", Type: fileref.DIRECTORY, PathLevel: 3}},
+		{SimilarField: SimilarField{Path: "/docs/sub/deeper", Type: fileref.DIRECTORY, PathLevel: 4}},
+		{SimilarField: SimilarField{Path: "/docs/sub/deeper/empty", Type: fileref.DIRECTORY, PathLevel: 5}},
+		{SimilarField: SimilarField{Path: "/docs/sub/empty", Type: fileref.DIRECTORY, PathLevel: 4}},
+	}
+	getRefs, doOps := getSubDirRefs, doSubDirOperations
+	defer func() { getSubDirRefs, doSubDirOperations = getRefs, doOps }()
+	getSubDirRefs = func(req *subDirRequest, offsetPath, fileType string, level int) (*ObjectTreeResult, error) {
+		result := &ObjectTreeResult{}
+		for _, ref := range refs {
+			if ref.Path > offsetPath && ref.Type == fileType && (level == 0 || ref.PathLevel == level) {
+				result.Refs = append(result.Refs, ref)
+			}
+		}
+		return result, nil
+	}
+	var ops []OperationRequest
+	doSubDirOperations = func(a *Allocation, page []OperationRequest) error {
+		ops = append(ops, page...)
+		return nil
+	}
+
+	req := &subDirRequest{
+		opType:         constants.FileOperationCopy,
+		subOpType:      constants.FileOperationCopy,
+		remotefilepath: "/docs",
+		destPath:       "/backup",
+		mask:           zboxutil.NewUint128(1),
+	}
+	require.NoError(t, req.processSubDirectories())
+
+	// the files first, then the directories from the deepest level, the empty ones included.
+	var got [][2]string
+	for _, op := range ops {
+		got = append(got, [2]string{op.RemotePath, op.DestPath})
+		require.Equal(t, op.RemotePath != "/docs/a.txt", op.CopyDirOnly)
+	}
+	require.Equal(t, [][2]string{
+		{"/docs/a.txt", "/backup/docs"},
+		{"/docs/sub/deeper/empty", "/backup/docs/sub/deeper"},
+		{"/docs/sub/deeper", "/backup/docs/sub"},
+		{"/docs/sub/empty", "/backup/docs/sub"},
+		{"/docs/sub", "/backup/docs"},
+	}, got)
+	require.Equal(t, 5, req.processed)
+}
@@ -0,0 +1,155 @@
+package sdk
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+var (
+	debugMode          bool
+	debugAllocations   = make(map[string]*Allocation)
+	debugAllocationsMu sync.Mutex
+)
+
+// SetDebugMode enables or disables tracking of the initialized allocations,
+// so their transfers and blobbers are included in DumpState. The allocations are tracked while
+// their workers run, until Shutdown or the idle timeout, see SetAllocationIdleTimeout.
+//   - enabled: true to track allocations, false to stop tracking them.
+func SetDebugMode(enabled bool) {
+	debugAllocationsMu.Lock()
+	defer debugAllocationsMu.Unlock()
+	debugMode = enabled
+	if !enabled {
+		debugAllocations = make(map[string]*Allocation)
+	}
+}
+
+// trackAllocation tracks an allocation while its workers run, the workers already keep it alive until
+// they're stopped, see untrackAllocation.
+func trackAllocation(a *Allocation) {
+	debugAllocationsMu.Lock()
+	defer debugAllocationsMu.Unlock()
+	if debugMode {
+		debugAllocations[a.ID] = a
+	}
+}
+
+// untrackAllocation stops tracking an allocation once its workers are stopped, so it can be released.
+func untrackAllocation(a *Allocation) {
+	debugAllocationsMu.Lock()
+	defer debugAllocationsMu.Unlock()
+	if debugAllocations[a.ID] == a {
+		delete(debugAllocations, a.ID)
+	}
+}
+
+// SDKState is a snapshot of the internal state of the SDK, used to diagnose stuck operations.
+type SDKState struct {
+	Time time.Time `json:"time"`
+	// ActiveUploads is the list of remote paths with an upload in progress.
+	ActiveUploads []string `json:"active_uploads"`
+	// CommitQueues is the number of pending commit requests per blobber.
+	CommitQueues map[string]int `json:"commit_queues"`
+	// BlockDownloadQueues is the number of pending block download requests per blobber.
+	BlockDownloadQueues map[string]int `json:"block_download_queues"`
+	// Sharders is the health weight of each sharder, lower is less healthy.
+	Sharders map[string]int64 `json:"sharders"`
+	// Caches is the number of entries of each SDK cache.
+	Caches map[string]int `json:"caches"`
+	// Allocations is the state of the tracked allocations, see SetDebugMode.
+	Allocations []*AllocationState `json:"allocations,omitempty"`
+}
+
+// AllocationState is a snapshot of the internal state of an allocation.
+type AllocationState struct {
+	ID                string   `json:"id"`
+	ActiveDownloads   []string `json:"active_downloads"`
+	PendingDownloads  int      `json:"pending_downloads"`
+	DownloadQueue     int      `json:"download_queue"`
+	RepairInProgress  bool     `json:"repair_in_progress"`
+	SkippedBlobbers   []string `json:"skipped_blobbers"`
+	AllocationVersion int64    `json:"allocation_version"`
+}
+
+// DumpState returns a snapshot of the SDK internal state: active transfers, worker queues,
+// sharder health and cache sizes.
+func DumpState() *SDKState {
+	state := &SDKState{
+		Time:                time.Now(),
+		CommitQueues:        make(map[string]int),
+		BlockDownloadQueues: make(map[string]int),
+		Caches:              make(map[string]int),
+	}
+
+	cancelLock.Lock()
+	for remotePath := range CancelOpCtx {
+		state.ActiveUploads = append(state.ActiveUploads, remotePath)
+	}
+	cancelLock.Unlock()
+	sort.Strings(state.ActiveUploads)
+
+	initCommitMutex.Lock()
+	for blobberID, ch := range commitChan {
+		state.CommitQueues[blobberID] = len(ch)
+	}
+	initCommitMutex.Unlock()
+
+	initDownloadMutex.Lock()
+	for blobberID, ch := range downloadBlockChan {
+		state.BlockDownloadQueues[blobberID] = len(ch)
+	}
+	initDownloadMutex.Unlock()
+
+	if blockchain.Sharders != nil {
+		state.Sharders = blockchain.Sharders.Weights()
+	}
+
+	state.Caches["file_refs"] = fileref.CacheLen()
+	if zboxutil.SignCache != nil {
+		state.Caches["signatures"] = zboxutil.SignCache.Len()
+	}
+
+	debugAllocationsMu.Lock()
+	allocs := make([]*Allocation, 0, len(debugAllocations))
+	for _, a := range debugAllocations {
+		allocs = append(allocs, a)
+	}
+	debugAllocationsMu.Unlock()
+
+	for _, a := range allocs {
+		state.Allocations = append(state.Allocations, a.dumpState())
+	}
+	sort.Slice(state.Allocations, func(i, j int) bool {
+		return state.Allocations[i].ID < state.Allocations[j].ID
+	})
+	return state
+}
+
+func (a *Allocation) dumpState() *AllocationState {
+	state := &AllocationState{
+		ID:                a.ID,
+		DownloadQueue:     len(a.downloadChan),
+		AllocationVersion: a.getAllocationVersion(),
+	}
+
+	a.mutex.Lock()
+	for remotePath := range a.downloadProgressMap {
+		state.ActiveDownloads = append(state.ActiveDownloads, remotePath)
+	}
+	state.PendingDownloads = len(a.downloadRequests)
+	state.RepairInProgress = a.repairRequestInProgress != nil
+	a.mutex.Unlock()
+	sort.Strings(state.ActiveDownloads)
+
+	for _, b := range a.Blobbers {
+		if b.IsSkip() {
+			state.SkippedBlobbers = append(state.SkippedBlobbers, b.ID)
+		}
+	}
+	return state
+}
@@ -0,0 +1,66 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+package sdk
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/0chain/errors"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+)
+
+// DebugHandler returns a http handler exposing the SDK state (see DumpState) on /debug/state
+// and the go runtime profiles on /debug/pprof/.
+func DebugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(DumpState()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// StartDebugServer enables the debug mode and serves DebugHandler on the given address.
+// Only loopback addresses are accepted, e.g. "127.0.0.1:6060" or "localhost:6060".
+//   - addr: the address to listen on.
+//
+// returns the started server, it should be closed by the caller.
+func StartDebugServer(addr string) (*http.Server, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if host != "localhost" {
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return nil, errors.New("debug_server", "debug server can only listen on a loopback address")
+		}
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	SetDebugMode(true)
+	srv := &http.Server{Handler: DebugHandler()}
+	go func() {
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			l.Logger.Error("debug server: ", err)
+		}
+	}()
+	return srv, nil
+}
@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// DeleteDirError is returned by DeleteDir when some entries of the directory are not deleted.
+type DeleteDirError struct {
+	// Deleted is the number of entries deleted.
+	Deleted int
+	// Failed are the errors of the entries not deleted, by remote path. A directory is not deleted
+	// if one of its descendants failed.
+	Failed map[string]error
+}
+
+func (e *DeleteDirError) Error() string {
+	paths := make([]string, 0, len(e.Failed))
+	for p := range e.Failed {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return fmt.Sprintf("delete_dir_failed: %d entries are not deleted, %d deleted. First error: %s: %v",
+		len(paths), e.Deleted, paths[0], e.Failed[paths[0]])
+}
+
+// DeleteDir deletes a directory with all its files and subdirectories. The entries are deleted
+// bottom-up in batches of MultiOpBatchSize, each batch in a single connection. The progress is
+// reported to the status callback with the number of entries deleted, the errors for each entry
+// not deleted.
+//   - remotePath: the path of the directory
+//   - status: the callback of the progress, can be nil
+func (a *Allocation) DeleteDir(remotePath string, status StatusCallback) error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+	if !a.CanDelete() {
+		return constants.ErrFileOptionNotPermitted
+	}
+	if len(remotePath) == 0 {
+		return errors.New("invalid_path", "Invalid path for the list")
+	}
+	remotePath = zboxutil.RemoteClean(remotePath)
+	if !zboxutil.IsRemoteAbs(remotePath) {
+		return errors.New("invalid_path", "Path should be valid and absolute")
+	}
+	if remotePath == "/" {
+		return errors.New("invalid_path", "the root directory can't be deleted")
+	}
+
+	var (
+		refs       []ORef
+		offsetPath string
+	)
+	for {
+		oResult, err := a.GetRefs(remotePath, offsetPath, "", "", "", fileref.REGULAR, 0, getRefPageLimit, WithObjectContext(a.ctx))
+		if err != nil {
+			return err
+		}
+		refs = append(refs, oResult.Refs...)
+		if len(oResult.Refs) < getRefPageLimit {
+			break
+		}
+		offsetPath = oResult.Refs[len(oResult.Refs)-1].Path
+	}
+
+	entries := deleteDirOrder(remotePath, refs)
+	if status != nil {
+		status.Started(a.ID, remotePath, OpDelete, len(entries))
+	}
+
+	result := &DeleteDirError{Failed: make(map[string]error)}
+	for start := 0; start < len(entries); start += MultiOpBatchSize {
+		end := start + MultiOpBatchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		ops := make([]OperationRequest, 0, end-start)
+		for _, p := range entries[start:end] {
+			if hasFailedDescendant(p, result.Failed) {
+				result.Failed[p] = errors.New("delete_dir_failed", "a descendant of the directory is not deleted")
+				continue
+			}
+			if err := a.retentionLockError(constants.FileOperationDelete, p); err != nil {
+				result.Failed[p] = err
+				continue
+			}
+			ops = append(ops, OperationRequest{
+				OperationType: constants.FileOperationDelete,
+				RemotePath:    p,
+			})
+		}
+
+		err := a.DoMultiOperation(ops)
+		for _, op := range ops {
+			if err != nil {
+				result.Failed[op.RemotePath] = err
+				continue
+			}
+			result.Deleted++
+			if status != nil {
+				status.InProgress(a.ID, op.RemotePath, OpDelete, result.Deleted, nil)
+			}
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		if status != nil {
+			for _, p := range entries {
+				if err, ok := result.Failed[p]; ok {
+					status.Error(a.ID, p, OpDelete, err)
+				}
+			}
+		}
+		return result
+	}
+	if status != nil {
+		status.Completed(a.ID, remotePath, path.Base(remotePath), "", 0, OpDelete)
+	}
+	return nil
+}
+
+// deleteDirOrder returns the paths of the entries of the directory in the order they are deleted:
+// the files, the subdirectories from the deepest ones, then the directory.
+func deleteDirOrder(dirPath string, refs []ORef) []string {
+	var files, dirs []ORef
+	for _, ref := range refs {
+		if ref.Path == dirPath {
+			continue
+		}
+		if ref.Type == fileref.DIRECTORY {
+			dirs = append(dirs, ref)
+		} else {
+			files = append(files, ref)
+		}
+	}
+	sort.SliceStable(dirs, func(i, j int) bool {
+		return strings.Count(dirs[i].Path, "/") > strings.Count(dirs[j].Path, "/")
+	})
+
+	paths := make([]string, 0, len(files)+len(dirs)+1)
+	for _, ref := range files {
+		paths = append(paths, ref.Path)
+	}
+	for _, ref := range dirs {
+		paths = append(paths, ref.Path)
+	}
+	return append(paths, dirPath)
+}
+
+func hasFailedDescendant(dirPath string, failed map[string]error) bool {
+	for p := range failed {
+		if strings.HasPrefix(p, dirPath+"/") {
+			return true
+		}
+	}
+	return false
+}
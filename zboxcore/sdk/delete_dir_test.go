@@ -0,0 +1,30 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteDirOrder(t *testing.T) {
+	refs := []ORef{
+		{SimilarField: SimilarField{Path: "/dir", Type: fileref.DIRECTORY}},
+		{SimilarField: SimilarField{Path: "/dir/a", Type: fileref.DIRECTORY}},
+		{SimilarField: SimilarField{Path: "/dir/a/b", Type: fileref.DIRECTORY}},
+		{SimilarField: SimilarField{Path: "/dir/a/b/1.txt", Type: fileref.FILE}},
+		{SimilarField: SimilarField{Path: "/dir/2.txt", Type: fileref.FILE}},
+		{SimilarField: SimilarField{Path: "/dir/c", Type: fileref.DIRECTORY}},
+	}
+	require.Equal(t, []string{"/dir/a/b/1.txt", "/dir/2.txt", "/dir/a/b", "/dir/a", "/dir/c", "/dir"}, deleteDirOrder("/dir", refs))
+
+	failed := map[string]error{"/dir/a/b/1.txt": errors.New("", "failed")}
+	require.True(t, hasFailedDescendant("/dir/a", failed))
+	require.True(t, hasFailedDescendant("/dir", failed))
+	require.False(t, hasFailedDescendant("/dir/c", failed))
+	require.False(t, hasFailedDescendant("/dir/a/b/1", failed))
+
+	err := &DeleteDirError{Deleted: 3, Failed: failed}
+	require.Equal(t, "delete_dir_failed: 1 entries are not deleted, 3 deleted. First error: /dir/a/b/1.txt: failed", err.Error())
+}
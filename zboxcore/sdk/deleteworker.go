@@ -9,7 +9,6 @@ import (
 	"net/url"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/0chain/errors"
@@ -54,6 +53,7 @@ func (req *DeleteRequest) deleteBlobberFile(
 	defer func() {
 		if err != nil {
 			logger.Logger.Error(err)
+			req.consensus.Reject(uint64(blobberIdx), err)
 			req.maskMu.Lock()
 			req.deleteMask = req.deleteMask.And(zboxutil.NewUint128(1).Lsh(uint64(blobberIdx)).Not())
 			req.maskMu.Unlock()
@@ -102,7 +102,7 @@ func (req *DeleteRequest) deleteBlobberFile(
 			var respBody []byte
 
 			if resp.StatusCode == http.StatusOK {
-				req.consensus.Done()
+				req.consensus.Accept(uint64(blobberIdx))
 				l.Logger.Debug(blobber.Baseurl, " "+req.remotefilepath, " deleted.")
 				return
 			}
@@ -114,7 +114,7 @@ func (req *DeleteRequest) deleteBlobberFile(
 
 				// Check for the specific content in the response body
 				if string(body) == "file was deleted" {
-					req.consensus.Done()
+					req.consensus.Accept(uint64(blobberIdx))
 					l.Logger.Debug(blobber.Baseurl, " ", req.remotefilepath, " deleted.")
 				}
 			}
@@ -133,7 +133,7 @@ func (req *DeleteRequest) deleteBlobberFile(
 			}
 
 			if resp.StatusCode == http.StatusNoContent {
-				req.consensus.Done()
+				req.consensus.Accept(uint64(blobberIdx))
 				l.Logger.Info(blobber.Baseurl, " "+req.remotefilepath, " not available in blobber.")
 				return
 			}
@@ -221,13 +221,13 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 			defer req.wg.Done()
 			refEntity, err := req.getFileMetaFromBlobber(blobberIdx)
 			if err == nil {
-				req.consensus.Done()
+				req.consensus.Accept(blobberIdx)
 				objectTreeRefs[blobberIdx] = refEntity
 				return
 			}
 			//it was removed from the blobber
 			if errors.Is(err, constants.ErrNotFound) {
-				req.consensus.Done()
+				req.consensus.Accept(blobberIdx)
 				deleteMutex.Lock()
 				removedNum++
 				deleteMutex.Unlock()
@@ -239,9 +239,8 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 	}
 	req.wg.Wait()
 
-	req.consensus.consensus = removedNum
+	req.consensus.resetTo(removedNum)
 
-	var errCount int32
 	wgErrors := make(chan error)
 	wgDone := make(chan bool)
 
@@ -253,8 +252,7 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 			err = req.deleteBlobberFile(req.blobbers[blobberIdx], int(blobberIdx))
 			if err != nil {
 				logger.Logger.Error("error during deleteBlobberFile", err)
-				errC := atomic.AddInt32(&errCount, 1)
-				if errC > int32(req.consensus.fullconsensus-req.consensus.consensusThresh) {
+				if !req.consensus.isConsensusReachable() {
 					wgErrors <- err
 				}
 			}
@@ -274,9 +272,7 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 	}
 
 	if !req.consensus.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Consensus on delete failed. Required consensus %d got %d",
-				req.consensus.consensusThresh, req.consensus.getConsensus()))
+		return req.consensus.consensusError("Delete")
 	}
 
 	writeMarkerMutex, err := CreateWriteMarkerMutex(client.GetClient(), req.allocationObj)
@@ -292,7 +288,7 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 	}
 	defer writeMarkerMutex.Unlock(req.ctx, req.deleteMask, req.blobbers, time.Minute, req.connectionID) //nolint: errcheck
 
-	req.consensus.consensus = removedNum
+	req.consensus.resetTo(removedNum)
 	req.timestamp = int64(common.Now())
 	wg := &sync.WaitGroup{}
 	activeBlobbers := req.deleteMask.CountOnes()
@@ -314,6 +310,7 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 			connectionID: req.connectionID,
 			wg:           wg,
 			timestamp:    req.timestamp,
+			blobberInd:   pos,
 		}
 
 		commitReq.changes = append(commitReq.changes, newChange)
@@ -327,8 +324,9 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 		if commitReq.result != nil {
 			if commitReq.result.Success {
 				l.Logger.Info("Commit success", commitReq.blobber.Baseurl)
-				req.consensus.Done()
+				req.consensus.Accept(commitReq.blobberInd)
 			} else {
+				req.consensus.Reject(commitReq.blobberInd, errors.New("commit_failed", commitReq.result.ErrorMessage))
 				l.Logger.Info("Commit failed", commitReq.blobber.Baseurl, commitReq.result.ErrorMessage)
 			}
 		} else {
@@ -337,9 +335,7 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 	}
 
 	if !req.consensus.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Consensus on commit not met. Required %d, got %d",
-				req.consensus.consensusThresh, req.consensus.getConsensus()))
+		return req.consensus.consensusError("Commit on delete")
 	}
 	return nil
 }
@@ -389,14 +385,15 @@ func (dop *DeleteOperation) Process(allocObj *Allocation, connectionID string) (
 			defer deleteReq.wg.Done()
 			refEntity, err := deleteReq.getFileMetaFromBlobber(uint64(blobberIdx))
 			if errors.Is(err, constants.ErrNotFound) {
-				deleteReq.consensus.Done()
+				deleteReq.consensus.Accept(uint64(blobberIdx))
 				return
 			} else if err != nil {
 				blobberErrors[blobberIdx] = err
+				deleteReq.consensus.Reject(uint64(blobberIdx), err)
 				l.Logger.Error(err.Error())
 				return
 			}
-			deleteReq.consensus.Done()
+			deleteReq.consensus.Accept(uint64(blobberIdx))
 			objectTreeRefs[blobberIdx] = refEntity
 			deleteReq.maskMu.Lock()
 			versionMap[refEntity.AllocationVersion] += 1
@@ -413,9 +410,7 @@ func (dop *DeleteOperation) Process(allocObj *Allocation, connectionID string) (
 			return nil, deleteReq.deleteMask, thrown.New("delete_failed", fmt.Sprintf("Delete failed. %s", err.Error()))
 		}
 
-		return nil, deleteReq.deleteMask, thrown.New("consensus_not_met",
-			fmt.Sprintf("Delete failed. Required consensus %d, got %d",
-				deleteReq.consensus.consensusThresh, deleteReq.consensus.consensus))
+		return nil, deleteReq.deleteMask, deleteReq.consensus.consensusError("Delete")
 	}
 	if consensusRef == nil {
 		//Already deleted
@@ -466,9 +461,7 @@ func (dop *DeleteOperation) Process(allocObj *Allocation, connectionID string) (
 			return nil, deleteReq.deleteMask, thrown.New("delete_failed", fmt.Sprintf("Delete failed. %s", err.Error()))
 		}
 
-		return nil, deleteReq.deleteMask, thrown.New("consensus_not_met",
-			fmt.Sprintf("Delete failed. Required consensus %d, got %d",
-				deleteReq.consensus.consensusThresh, deleteReq.consensus.consensus))
+		return nil, deleteReq.deleteMask, deleteReq.consensus.consensusError("Delete")
 	}
 
 	l.Logger.Debug("Delete Process Ended ")
@@ -553,9 +546,10 @@ func (req *DeleteRequest) deleteSubDirectories() error {
 				pathLevel = ref.PathLevel
 			}
 			op := OperationRequest{
-				OperationType: constants.FileOperationDelete,
-				RemotePath:    ref.Path,
-				Mask:          &opMask,
+				OperationType:     constants.FileOperationDelete,
+				RemotePath:        ref.Path,
+				Mask:              &opMask,
+				skipRetentionLock: true,
 			}
 			ops = append(ops, op)
 		}
@@ -587,9 +581,10 @@ func (req *DeleteRequest) deleteSubDirectories() error {
 			for _, ref := range oResult.Refs {
 				opMask := req.deleteMask
 				op := OperationRequest{
-					OperationType: constants.FileOperationDelete,
-					RemotePath:    ref.Path,
-					Mask:          &opMask,
+					OperationType:     constants.FileOperationDelete,
+					RemotePath:        ref.Path,
+					Mask:              &opMask,
+					skipRetentionLock: true,
 				}
 				ops = append(ops, op)
 			}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// DownloadCostEstimate is the expected read cost of a download, see Allocation.EstimateDownloadCost.
+type DownloadCostEstimate struct {
+	// Cost is the read tokens the download is expected to lock from the read pool.
+	Cost common.Balance `json:"cost"`
+	// ReadPoolBalance is the balance of the read pool of the client.
+	ReadPoolBalance common.Balance `json:"read_pool_balance"`
+	// NumBlocks is the number of blocks read from each blobber.
+	NumBlocks int64 `json:"num_blocks"`
+	// Free is true if the reads of the allocation are free.
+	Free bool `json:"free"`
+}
+
+// Sufficient returns true if the read pool balance covers the cost of the download.
+func (e *DownloadCostEstimate) Sufficient() bool {
+	return e.Free || e.ReadPoolBalance >= e.Cost
+}
+
+// InsufficientReadPoolError is returned when the read pool balance doesn't cover the
+// expected cost of a download, see WithReadPoolCheck.
+type InsufficientReadPoolError struct {
+	RemotePath string
+	Cost       common.Balance
+	Balance    common.Balance
+}
+
+func (e *InsufficientReadPoolError) Error() string {
+	return fmt.Sprintf("insufficient_read_pool: downloading %s costs %d but the read pool balance is %d",
+		e.RemotePath, e.Cost, e.Balance)
+}
+
+// WithReadPoolCheck checks the read pool balance covers the expected cost of the download
+// before it's queued, the download fails with InsufficientReadPoolError otherwise.
+func WithReadPoolCheck() DownloadRequestOption {
+	return func(dr *DownloadRequest) {
+		dr.checkReadPool = true
+	}
+}
+
+// EstimateDownloadCost returns the expected read cost of downloading a file, computed from
+// the read prices of the blobbers, and the current read pool balance of the client.
+//   - remotePath: the remote path of the file
+func (a *Allocation) EstimateDownloadCost(remotePath string) (*DownloadCostEstimate, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	meta, err := a.GetFileMeta(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	return a.estimateReadCost(meta.NumBlocks)
+}
+
+// estimateReadCost returns the read cost of numBlocks blocks read from each data shard.
+func (a *Allocation) estimateReadCost(numBlocks int64) (*DownloadCostEstimate, error) {
+	est := &DownloadCostEstimate{
+		NumBlocks: numBlocks,
+		Free:      a.readFree,
+	}
+	if a.readFree {
+		return est, nil
+	}
+
+	cost, err := a.readCostForBlocks(numBlocks)
+	if err != nil {
+		return nil, err
+	}
+	est.Cost = cost
+
+	rp, err := GetReadPoolInfo(client.GetClientID())
+	if err != nil {
+		return nil, err
+	}
+	est.ReadPoolBalance = rp.Balance
+	return est, nil
+}
+
+// readCostForBlocks returns the read cost of numBlocks blocks read from each data shard. The
+// blobbers serving the download aren't known in advance, so the most expensive ones are counted.
+func (a *Allocation) readCostForBlocks(numBlocks int64) (common.Balance, error) {
+	prices := make([]common.Balance, 0, len(a.BlobberDetails))
+	for _, d := range a.BlobberDetails {
+		prices = append(prices, d.Terms.ReadPrice)
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i] > prices[j] })
+	if a.DataShards > 0 && a.DataShards < len(prices) {
+		prices = prices[:a.DataShards]
+	}
+
+	shardSize := a.sizeInGB(numBlocks * CHUNK_SIZE)
+	var cost common.Balance
+	for _, price := range prices {
+		var err error
+		cost, err = common.AddBalance(cost, common.Balance(float64(price)*shardSize))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return cost, nil
+}
+
+// checkReadPool fails with InsufficientReadPoolError if the read pool doesn't cover the download.
+func (a *Allocation) checkReadPool(remotePath string, startBlock, endBlock int64) error {
+	if a.skipReadMarkers() {
+		return nil
+	}
+	meta, err := a.GetFileMeta(remotePath)
+	if err != nil {
+		return err
+	}
+	numBlocks := meta.NumBlocks
+	if endBlock > 0 && endBlock < numBlocks {
+		numBlocks = endBlock
+	}
+	if startBlock > 1 {
+		numBlocks -= startBlock - 1
+	}
+
+	est, err := a.estimateReadCost(numBlocks)
+	if err != nil {
+		return errors.Wrap(err, "estimate download cost")
+	}
+	if !est.Sufficient() {
+		return &InsufficientReadPoolError{RemotePath: remotePath, Cost: est.Cost, Balance: est.ReadPoolBalance}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package sdk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// dirArchiveEntryFunc adds a file to an archive and returns the writer of its content.
+type dirArchiveEntryFunc func(name string, size int64, modTime time.Time) (io.Writer, error)
+
+// DownloadDirAsZip streams a zip archive of a remote directory to w. The archive is built on the fly
+// while the files are downloaded one by one, none of them is stored locally. The files are named in
+// the archive after their path relative to the parent of the directory, e.g. "photos/a.jpg" for
+// "/photos/a.jpg", and the files packed by UploadArchive are extracted from their containers.
+// The archive written to w is incomplete if an error is returned.
+//   - remoteDir: the absolute path of the remote directory.
+//   - w: the writer of the archive, e.g. a http.ResponseWriter.
+func (a *Allocation) DownloadDirAsZip(remoteDir string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	err := a.downloadDirArchive(remoteDir, func(name string, _ int64, modTime time.Time) (io.Writer, error) {
+		return zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime})
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// DownloadDirAsTar streams a tar archive of a remote directory to w, see DownloadDirAsZip.
+//   - remoteDir: the absolute path of the remote directory.
+//   - w: the writer of the archive, e.g. a http.ResponseWriter.
+func (a *Allocation) DownloadDirAsTar(remoteDir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := a.downloadDirArchive(remoteDir, func(name string, size int64, modTime time.Time) (io.Writer, error) {
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     size,
+			Mode:     0644,
+			ModTime:  modTime,
+		})
+		return tw, err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// downloadDirArchive downloads the files of a remote directory to the entries of an archive.
+func (a *Allocation) downloadDirArchive(remoteDir string, create dirArchiveEntryFunc) error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+	remoteDir = zboxutil.RemoteClean(remoteDir)
+	if !zboxutil.IsRemoteAbs(remoteDir) {
+		return errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	parent := path.Dir(remoteDir)
+	for ref := range a.ListObjects(a.ctx, remoteDir, "", "", "", fileref.FILE, fileref.REGULAR, 0, getRefPageLimit) {
+		if ref.Err != nil {
+			return ref.Err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(ref.Path, parent), "/")
+		modTime := time.Unix(int64(ref.UpdatedAt), 0)
+
+		if strings.HasPrefix(ref.Name, ArchivePrefix) {
+			if entries, err := ArchiveIndexOf(ref.CustomMeta); err == nil {
+				if err := a.writePackedEntries(ref, path.Dir(name), modTime, entries, create); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		ew, err := create(name, ref.ActualFileSize, modTime)
+		if err != nil {
+			return err
+		}
+		if ref.ActualFileSize == 0 {
+			continue
+		}
+		if err := a.streamFile(ref.Path, ref.EncryptedKey != "", ew); err != nil {
+			return errors.Wrap(err, "failed to download "+ref.Path)
+		}
+	}
+	return nil
+}
+
+// writePackedEntries downloads an archive container and adds the files packed in it to the archive.
+func (a *Allocation) writePackedEntries(ref ORef, dir string, modTime time.Time, entries []ArchiveEntry, create dirArchiveEntryFunc) error {
+	var container bytes.Buffer
+	if err := a.streamFile(ref.Path, ref.EncryptedKey != "", &container); err != nil {
+		return errors.Wrap(err, "failed to download "+ref.Path)
+	}
+	data := container.Bytes()
+	for _, e := range entries {
+		if e.Offset < 0 || e.Size < 0 || e.Offset+e.Size > int64(len(data)) {
+			return errors.New("invalid_archive", "the packed file "+e.Name+" is out of the bounds of its container")
+		}
+		ew, err := create(path.Join(dir, e.Name), e.Size, modTime)
+		if err != nil {
+			return err
+		}
+		if _, err := ew.Write(data[e.Offset : e.Offset+e.Size]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamFile downloads a file to w through a channel, without storing it.
+func (a *Allocation) streamFile(remotePath string, encrypted bool, w io.Writer) error {
+	fh := &sys.MemChanFile{
+		Buffer:         make(chan []byte, 10),
+		ChunkWriteSize: int(a.GetChunkReadSize(encrypted)),
+	}
+	status := &streamStatusCallback{done: make(chan struct{})}
+	err := a.DownloadFileToFileHandler(fh, remotePath, false, status, true, WithFileCallback(func() {
+		fh.Close() //nolint: errcheck
+	}))
+	if err != nil {
+		return err
+	}
+
+	// the channel is drained even if w fails so the download isn't blocked.
+	var writeErr error
+	for data := range fh.Buffer {
+		if writeErr == nil {
+			_, writeErr = w.Write(data)
+		}
+	}
+	<-status.done
+	if status.err != nil {
+		return status.err
+	}
+	return writeErr
+}
+
+// streamStatusCallback records the outcome of a download, see streamFile.
+type streamStatusCallback struct {
+	done chan struct{}
+	err  error
+}
+
+func (cb *streamStatusCallback) Started(string, string, int, int) {}
+
+func (cb *streamStatusCallback) InProgress(string, string, int, int, []byte) {}
+
+func (cb *streamStatusCallback) Error(_ string, _ string, _ int, err error) {
+	cb.err = err
+	close(cb.done)
+}
+
+func (cb *streamStatusCallback) Completed(string, string, string, string, int, int) {
+	close(cb.done)
+}
+
+func (cb *streamStatusCallback) RepairCompleted(int) {}
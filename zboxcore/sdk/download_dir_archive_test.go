@@ -0,0 +1,31 @@
+package sdk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_DownloadDirArchive_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	a := &Allocation{}
+	require.ErrorIs(t, a.DownloadDirAsZip("/dir", &buf), notInitialized)
+	require.ErrorIs(t, a.DownloadDirAsTar("/dir", &buf), notInitialized)
+
+	setupMockAllocation(t, a)
+	require.Error(t, a.DownloadDirAsZip("dir", &buf))
+	require.Empty(t, buf.Bytes())
+}
+
+func TestStreamStatusCallback(t *testing.T) {
+	cb := &streamStatusCallback{done: make(chan struct{})}
+	cb.Error("alloc", "/a", OpDownload, notInitialized)
+	<-cb.done
+	require.ErrorIs(t, cb.err, notInitialized)
+
+	cb = &streamStatusCallback{done: make(chan struct{})}
+	cb.Completed("alloc", "/a", "a", "text/plain", 1, OpDownload)
+	<-cb.done
+	require.NoError(t, cb.err)
+}
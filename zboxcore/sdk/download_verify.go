@@ -0,0 +1,66 @@
+package sdk
+
+import (
+	"encoding/hex"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/util"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+)
+
+// WithMerkleProofVerification requests the merkle proofs of the blocks downloaded from the blobbers
+// and verifies them against the validation root of each shard, signed by the owner of the allocation.
+// The blocks of a blobber failing the verification are rejected, the download continues with the
+// other blobbers if there are enough of them. It's not supported for enterprise allocations and
+// re-encrypted (shared) files.
+func WithMerkleProofVerification() DownloadRequestOption {
+	return func(dr *DownloadRequest) {
+		dr.verifyMerkleProofs = true
+		dr.shouldVerify = true
+	}
+}
+
+// addValidationRoot checks the signature of the validation root of the blobber's shard, then keeps
+// the root to verify the blocks downloaded from the blobber.
+func (req *DownloadRequest) addValidationRoot(blobberIdx int, fRef *fileref.FileRef) error {
+	if fRef.ValidationRoot == "" || fRef.ValidationRootSignature == "" {
+		return errors.New("invalid_validation_root", "the blobber didn't return the validation root")
+	}
+	isValid, err := sys.VerifyWith(
+		req.allocOwnerPubKey,
+		fRef.ValidationRootSignature,
+		encryption.Hash(fRef.ActualFileHashSignature+fRef.ValidationRoot),
+	)
+	if err != nil {
+		return err
+	}
+	if !isValid {
+		return errors.New("invalid_signature", "invalid validation root signature")
+	}
+	root, err := hex.DecodeString(fRef.ValidationRoot)
+	if err != nil {
+		return errors.Wrap(err, "invalid_validation_root")
+	}
+
+	if req.validationRoots == nil {
+		req.validationRoots = make(map[int]*blobberFile)
+	}
+	req.validationRoots[blobberIdx] = &blobberFile{validationRoot: root, size: fRef.Size}
+	return nil
+}
+
+// verifyBlockProofs verifies the blocks of a response against the validation root of the shard.
+func verifyBlockProofs(dR *downloadResponse, validationRoot []byte, shardSize int64) error {
+	vmp := util.MerklePathForMultiLeafVerification{
+		RootHash: validationRoot,
+		Nodes:    dR.Nodes,
+		Index:    dR.Indexes,
+		DataSize: shardSize,
+	}
+	if err := vmp.VerifyMultipleBlocks(dR.Data); err != nil {
+		return errors.New("merkle_verification_failed", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/util"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockProofs(t *testing.T) {
+	data := make([]byte, 3*util.MaxMerkleLeavesSize+100)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	vt := util.NewValidationTree(int64(len(data)))
+	_, err := vt.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, vt.Finalize())
+	root := vt.GetValidationRoot()
+
+	t.Run("valid blocks", func(t *testing.T) {
+		dR := &downloadResponse{Data: data}
+		require.NoError(t, verifyBlockProofs(dR, root, int64(len(data))))
+	})
+
+	t.Run("tampered blocks", func(t *testing.T) {
+		tampered := append([]byte{}, data...)
+		tampered[util.MaxMerkleLeavesSize+1] ^= 0xff
+		dR := &downloadResponse{Data: tampered}
+		err := verifyBlockProofs(dR, root, int64(len(data)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "merkle_verification_failed")
+	})
+}
+
+func TestDownloadRequest_AddValidationRoot_Missing(t *testing.T) {
+	req := &DownloadRequest{}
+	err := req.addValidationRoot(0, &fileref.FileRef{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid_validation_root")
+	require.Empty(t, req.validationRoots)
+}
@@ -11,7 +11,6 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -79,6 +78,8 @@ type DownloadRequest struct {
 	remotefilepathhash string
 	fileHandler        sys.File
 	localFilePath      string
+	// localFS is the file system of the local file, see Allocation.SetFS
+	localFS            sys.FS
 	startBlock         int64
 	endBlock           int64
 	chunkSize          int
@@ -92,7 +93,9 @@ type DownloadRequest struct {
 	isDownloadCanceled bool
 	completedCallback  func(remotepath string, remotepathhash string)
 	fileCallback       func()
-	contentMode        string
+	// hooks are run once the file is downloaded, see DownloadHook.
+	hooks       []DownloadHook
+	contentMode string
 	Consensus
 	effectiveBlockSize int // blocksize - encryptionOverHead
 	ecEncoder          reedsolomon.Encoder
@@ -103,6 +106,7 @@ type DownloadRequest struct {
 	connectionID       string
 	skip               bool
 	freeRead           bool
+	checkReadPool      bool
 	fRef               *fileref.FileRef
 	chunksPerShard     int64
 	size               int64
@@ -113,6 +117,16 @@ type DownloadRequest struct {
 	downloadQueue      downloadQueue // Always initialize this queue with max time taken
 	isResume           bool
 	isEnterprise       bool
+	// verifyMerkleProofs verifies the blocks against the validation roots, see WithMerkleProofVerification.
+	verifyMerkleProofs bool
+	validationRoots    map[int]*blobberFile
+	// selection restricts the blobbers the file is downloaded from.
+	selection blobberSelection
+	// byteRange restricts the download to the blocks holding the range, see UploadArchive.
+	byteRange *byteRange
+	// blobberLatencies are the round trip times to the blobbers by index, nil if not probed. The file is
+	// downloaded from the nearest blobbers first.
+	blobberLatencies []time.Duration
 }
 
 type downloadPriority struct {
@@ -260,6 +274,13 @@ func (req *DownloadRequest) downloadBlock(
 			encryptedKey:       req.encryptedKey,
 			connectionID:       req.connectionID,
 		}
+		if req.verifyMerkleProofs && req.contentMode != DOWNLOAD_CONTENT_THUMB {
+			if bf, ok := req.validationRoots[blobberIdx]; ok {
+				blockDownloadReq.shouldVerify = true
+				blockDownloadReq.validationRoot = bf.validationRoot
+				blockDownloadReq.shardSize = bf.size
+			}
+		}
 
 		if blockDownloadReq.blobber.IsSkip() {
 			rspCh <- &downloadBlock{
@@ -501,6 +522,11 @@ func (req *DownloadRequest) processDownload() {
 
 	if req.shouldVerify {
 		if req.isEnterprise || (req.authTicket != nil && req.encryptedKey != "") {
+			if req.verifyMerkleProofs {
+				req.errorCB(errors.New("merkle_verification_unsupported",
+					"the merkle proofs can't be verified for enterprise allocations and re-encrypted files"), remotePathCB)
+				return
+			}
 			req.shouldVerify = false
 		}
 	}
@@ -781,6 +807,11 @@ func (req *DownloadRequest) processDownload() {
 		elapsedGetBlocksAndWrite.Milliseconds(),
 	))
 
+	if err := req.runAfterDownload(); err != nil {
+		req.errorCB(err, remotePathCB)
+		return
+	}
+
 	if req.statusCallback != nil && !req.skip {
 		req.statusCallback.Completed(
 			req.allocationID, remotePathCB, fRef.Name, fRef.MimeType, int(size), op)
@@ -994,7 +1025,7 @@ func (req *DownloadRequest) errorCB(err error, remotePathCB string) {
 	req.skip = true
 	if req.localFilePath != "" {
 		if info, err := req.fileHandler.Stat(); err == nil && info.Size() == 0 {
-			os.Remove(req.localFilePath) //nolint: errcheck
+			req.getLocalFS().Remove(req.localFilePath) //nolint: errcheck
 		}
 	}
 	if req.fileHandler != nil {
@@ -1029,6 +1060,9 @@ func (req *DownloadRequest) calculateShardsParams(
 	req.effectiveBlockSize = int(effectiveBlockSize)
 
 	chunksPerShard = (effectivePerShardSize + effectiveBlockSize - 1) / effectiveBlockSize
+	if req.byteRange != nil {
+		req.startBlock, req.endBlock = req.byteRange.blocks(effectiveBlockSize*int64(req.datashards), chunksPerShard)
+	}
 
 	info, err := req.fileHandler.Stat()
 	if err != nil {
@@ -1120,6 +1154,7 @@ func (req *DownloadRequest) getFileRef() (fRef *fileref.FileRef, err error) {
 		sig:                req.sig,
 		blobbers:           req.blobbers,
 		authToken:          req.authTicket,
+		selection:          req.selection,
 		Consensus: Consensus{
 			RWMutex:         &sync.RWMutex{},
 			fullconsensus:   req.fullconsensus,
@@ -1148,10 +1183,14 @@ func (req *DownloadRequest) getFileRef() (fRef *fileref.FileRef, err error) {
 func (req *DownloadRequest) getFileMetaConsensus(fMetaResp []*fileMetaResponse) (*fileref.FileRef, error) {
 	var selected *fileMetaResponse
 	foundMask := zboxutil.NewUint128(0)
-	req.consensus = 0
+	req.Consensus.Reset()
 	retMap := make(map[string]int)
 	for _, fmr := range fMetaResp {
-		if fmr.err != nil || fmr.fileref == nil {
+		if fmr.err != nil {
+			req.Reject(uint64(fmr.blobberIdx), fmr.err)
+			continue
+		}
+		if fmr.fileref == nil {
 			continue
 		}
 		actualHash := fmr.fileref.ActualFileHash
@@ -1164,16 +1203,18 @@ func (req *DownloadRequest) getFileMetaConsensus(fMetaResp []*fileMetaResponse)
 		)
 		if err != nil {
 			l.Logger.Error(err)
+			req.Reject(uint64(fmr.blobberIdx), err)
 			continue
 		}
 		if !isValid {
 			l.Logger.Error("invalid signature")
+			req.Reject(uint64(fmr.blobberIdx), errors.New("invalid_signature", "invalid actual file hash signature"))
 			continue
 		}
 
 		retMap[actualFileHashSignature]++
-		if retMap[actualFileHashSignature] > req.consensus {
-			req.consensus = retMap[actualFileHashSignature]
+		if retMap[actualFileHashSignature] > req.getConsensus() {
+			req.setConsensus(retMap[actualFileHashSignature])
 		}
 		if req.isConsensusOk() {
 			selected = fmr
@@ -1183,7 +1224,7 @@ func (req *DownloadRequest) getFileMetaConsensus(fMetaResp []*fileMetaResponse)
 
 	if selected == nil {
 		l.Logger.Error("File consensus not found for ", req.remotefilepath)
-		return nil, errors.New("consensus_not_met", "")
+		return nil, req.consensusError("Download")
 	}
 
 	blobberCount := 0
@@ -1194,6 +1235,7 @@ func (req *DownloadRequest) getFileMetaConsensus(fMetaResp []*fileMetaResponse)
 	if req.freeRead {
 		countThreshold = req.fullconsensus
 	}
+	fMetaResp = req.sortNearestFirst(fMetaResp)
 	for i := 0; i < len(fMetaResp); i++ {
 		fmr := fMetaResp[i]
 		if fmr.err != nil || fmr.fileref == nil {
@@ -1204,21 +1246,28 @@ func (req *DownloadRequest) getFileMetaConsensus(fMetaResp []*fileMetaResponse)
 		if selected.fileref.ActualFileHashSignature != fRef.ActualFileHashSignature {
 			continue
 		}
+		if req.verifyMerkleProofs {
+			if err := req.addValidationRoot(fmr.blobberIdx, fRef); err != nil {
+				l.Logger.Error("validation root of blobber ", fmr.blobberIdx, ": ", err)
+				req.Reject(uint64(fmr.blobberIdx), err)
+				continue
+			}
+		}
 
 		shift := zboxutil.NewUint128(1).Lsh(uint64(fmr.blobberIdx))
 		foundMask = foundMask.Or(shift)
 		req.downloadQueue[fmr.blobberIdx] = downloadPriority{
 			blobberIdx: fmr.blobberIdx,
-			timeTaken:  60000,
+			timeTaken:  req.initialTimeTaken(fmr.blobberIdx),
 		}
 		blobberCount++
 		if blobberCount == countThreshold {
 			break
 		}
 	}
-	req.consensus = foundMask.CountOnes()
+	req.setConsensus(foundMask.CountOnes())
 	if !req.isConsensusOk() {
-		return nil, fmt.Errorf("consensus_not_met")
+		return nil, req.consensusError("Download")
 	}
 	req.downloadMask = foundMask
 	sort.Slice(req.downloadQueue, req.downloadQueue.Less)
@@ -1373,6 +1422,13 @@ func writeAtData(dest io.WriterAt, data [][][]byte, dataShards int, offset int64
 	return total, nil
 }
 
+func (req *DownloadRequest) getLocalFS() sys.FS {
+	if req.localFS != nil {
+		return req.localFS
+	}
+	return sys.Files
+}
+
 func (dr *DownloadRequest) progressID() string {
 
 	if len(dr.allocationID) > 8 {
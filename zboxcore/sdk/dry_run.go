@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// ImpactReport is the expected impact of a delete, rename, copy or move operation, see Allocation.DryRun.
+type ImpactReport struct {
+	// Operation is the type of the operation, e.g. constants.FileOperationDelete.
+	Operation  string `json:"operation"`
+	RemotePath string `json:"remote_path"`
+	// DestPath is the destination of a copy or move, the new name of a rename.
+	DestPath string `json:"dest_path,omitempty"`
+	// Type is the type of the object, fileref.FILE or fileref.DIRECTORY.
+	Type string `json:"type"`
+	// Blobbers are the ids of the blobbers having the object, the operation is sent to them.
+	Blobbers []string `json:"blobbers"`
+	// Size is the size of the object stored on each blobber.
+	Size int64 `json:"size"`
+	// ActualSize is the size of the original files.
+	ActualSize int64 `json:"actual_size"`
+	// NumFiles is the number of files affected, the files of the directory and its subdirectories.
+	NumFiles int64 `json:"num_files"`
+	// NumDirs is the number of subdirectories affected.
+	NumDirs int64 `json:"num_dirs"`
+}
+
+// DryRun reports the impact of a delete, rename, copy or move operation without running it: the
+// blobbers having the object, the affected size and the descendants of a directory. No write marker
+// is created.
+//   - op: the operation, as given to DoMultiOperation
+func (a *Allocation) DryRun(op OperationRequest) (*ImpactReport, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+
+	var permitted bool
+	switch op.OperationType {
+	case constants.FileOperationDelete:
+		permitted = a.CanDelete()
+	case constants.FileOperationRename:
+		permitted = a.CanRename()
+	case constants.FileOperationCopy:
+		permitted = a.CanCopy()
+	case constants.FileOperationMove:
+		permitted = a.CanMove()
+	default:
+		return nil, errors.New("invalid_operation", "dry run is not supported for "+op.OperationType)
+	}
+	if !permitted {
+		return nil, constants.ErrFileOptionNotPermitted
+	}
+
+	if op.RemotePath == "" {
+		return nil, errors.New("invalid_path", "Invalid path for the list")
+	}
+	remotePath := zboxutil.RemoteClean(op.RemotePath)
+	if !zboxutil.IsRemoteAbs(remotePath) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	listReq := &ListRequest{Consensus: Consensus{RWMutex: &sync.RWMutex{}}}
+	listReq.allocationID = a.ID
+	listReq.allocationTx = a.Tx
+	listReq.sig = a.sig
+	listReq.blobbers = a.Blobbers
+	listReq.fullconsensus = a.fullconsensus
+	listReq.consensusThresh = a.consensusThreshold
+	listReq.ctx = a.ctx
+	listReq.remotefilepath = remotePath
+	foundMask, _, ref, _ := listReq.getFileConsensusFromBlobbers()
+	if ref == nil {
+		return nil, errors.New("object_not_found", "object "+remotePath+" not found on the blobbers")
+	}
+
+	report := &ImpactReport{
+		Operation:  op.OperationType,
+		RemotePath: remotePath,
+		DestPath:   op.DestPath,
+		Type:       ref.Type,
+		Size:       ref.Size,
+		ActualSize: ref.ActualFileSize,
+		NumFiles:   1,
+	}
+	if op.OperationType == constants.FileOperationRename {
+		report.DestPath = op.DestName
+	}
+	for i, b := range a.Blobbers {
+		if foundMask.And(zboxutil.NewUint128(1).Lsh(uint64(i))).Equals64(0) {
+			continue
+		}
+		report.Blobbers = append(report.Blobbers, b.ID)
+	}
+
+	if ref.Type == fileref.DIRECTORY {
+		if err := a.addDescendants(a.ctx, report); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// addDescendants counts the files and subdirectories of the directory of the report, and their size.
+func (a *Allocation) addDescendants(ctx context.Context, report *ImpactReport) error {
+	report.NumFiles = 0
+	report.Size = 0
+	report.ActualSize = 0
+	var offsetPath string
+	for {
+		oResult, err := a.GetRefs(report.RemotePath, offsetPath, "", "", "", fileref.REGULAR, 0, getRefPageLimit, WithObjectContext(ctx))
+		if err != nil {
+			return err
+		}
+		for _, ref := range oResult.Refs {
+			if ref.Path == report.RemotePath {
+				continue
+			}
+			if ref.Type == fileref.DIRECTORY {
+				report.NumDirs++
+				continue
+			}
+			report.NumFiles++
+			report.Size += ref.Size
+			report.ActualSize += ref.ActualFileSize
+		}
+		if len(oResult.Refs) < getRefPageLimit {
+			return nil
+		}
+		offsetPath = oResult.Refs[len(oResult.Refs)-1].Path
+	}
+}
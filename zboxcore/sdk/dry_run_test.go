@@ -0,0 +1,78 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/mocks"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_DryRun(t *testing.T) {
+	prevSign := zclient.Sign
+	zclient.Sign = func(hash string) (string, error) { return "sig", nil }
+	defer func() { zclient.Sign = prevSign }()
+
+	var mockClient = mocks.HttpClient{}
+	rawClient := zboxutil.Client
+	zboxutil.Client = &mockClient
+	defer func() { zboxutil.Client = rawClient }()
+
+	body, err := json.Marshal(&fileref.FileRef{
+		Ref:            fileref.Ref{Type: fileref.FILE, Path: "/1.txt", Size: 512, FileMetaHash: "hash"},
+		ActualFileSize: 1024,
+	})
+	require.NoError(t, err)
+	mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return strings.HasPrefix(req.URL.String(), "http://found")
+	})).Return(func(*http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}
+	}, nil)
+	mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return strings.HasPrefix(req.URL.String(), "http://missing")
+	})).Return(func(*http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(`{"error":"not found"}`))}
+	}, nil)
+
+	a := &Allocation{ID: "alloc", Tx: "tx", FileOptions: 63, initialized: true, Blobbers: []*blockchain.StorageNode{
+		{ID: "1", Baseurl: "http://found1"},
+		{ID: "2", Baseurl: "http://missing"},
+		{ID: "3", Baseurl: "http://found3"},
+	}}
+	a.ctx = context.Background()
+	a.fullconsensus, a.consensusThreshold = 3, 2
+	a.mutex = &sync.Mutex{}
+	sdkInitialized = true
+
+	report, err := a.DryRun(OperationRequest{OperationType: constants.FileOperationCopy, RemotePath: "/1.txt", DestPath: "/dir"})
+	require.NoError(t, err)
+	require.Equal(t, &ImpactReport{
+		Operation:  constants.FileOperationCopy,
+		RemotePath: "/1.txt",
+		DestPath:   "/dir",
+		Type:       fileref.FILE,
+		Blobbers:   []string{"1", "3"},
+		Size:       512,
+		ActualSize: 1024,
+		NumFiles:   1,
+	}, report)
+
+	_, err = a.DryRun(OperationRequest{OperationType: constants.FileOperationInsert, RemotePath: "/1.txt"})
+	require.Error(t, err)
+
+	a.FileOptions = 0
+	_, err = a.DryRun(OperationRequest{OperationType: constants.FileOperationDelete, RemotePath: "/1.txt"})
+	require.ErrorIs(t, err, constants.ErrFileOptionNotPermitted)
+}
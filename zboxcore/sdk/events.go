@@ -0,0 +1,256 @@
+package sdk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+)
+
+// EventType is the type of an event published on the SDK event bus.
+type EventType string
+
+const (
+	// EventTransferStarted is published when an upload or download starts.
+	EventTransferStarted EventType = "transfer_started"
+	// EventTransferProgress is published when an upload or download progresses.
+	EventTransferProgress EventType = "transfer_progress"
+	// EventTransferFinished is published when an upload or download completes.
+	EventTransferFinished EventType = "transfer_finished"
+	// EventTransferFailed is published when an upload or download fails.
+	EventTransferFailed EventType = "transfer_failed"
+	// EventCommitDone is published when the changes of an operation are committed to the blobbers.
+	EventCommitDone EventType = "commit_done"
+	// EventRepairNeeded is published when the blobbers of an allocation are out of sync.
+	EventRepairNeeded EventType = "repair_needed"
+	// EventCommitRetryDone is published when a blobber that failed the commit of an operation
+	// committed it on retry, see CommitRetryAttempts.
+	EventCommitRetryDone EventType = "commit_retry_done"
+	// EventCommitRetryFailed is published when a blobber still fails the commit of an operation once
+	// the retries are exhausted, it needs a repair.
+	EventCommitRetryFailed EventType = "commit_retry_failed"
+	// EventShardHashUnverified is published when a blobber doesn't return the hash of an uploaded
+	// shard, so the shard is committed without being verified, see ChunkedUpload.
+	EventShardHashUnverified EventType = "shard_hash_unverified"
+	// EventBalanceLow is published when the write pool of an allocation is below the threshold
+	// set with SetLowBalanceThreshold.
+	EventBalanceLow EventType = "balance_low"
+	// EventBlobberUnhealthy is published when a blobber of an allocation can't be reached.
+	EventBlobberUnhealthy EventType = "blobber_unhealthy"
+	// EventNetworkChanged is published when the miners or sharders of the network change.
+	EventNetworkChanged EventType = "network_changed"
+	// EventNodeDown is published when a miner or sharder fails its health check.
+	EventNodeDown EventType = "node_down"
+	// EventNodeUp is published when a miner or sharder that was down passes its health check again.
+	EventNodeUp EventType = "node_up"
+)
+
+const defaultEventBufferSize = 64
+
+// Event is an event published on the SDK event bus. Only the fields relevant to its type are set.
+type Event struct {
+	Type         EventType `json:"type"`
+	Time         time.Time `json:"time"`
+	AllocationID string    `json:"allocation_id,omitempty"`
+	RemotePath   string    `json:"remote_path,omitempty"`
+	// OpCode is the operation of the transfer, e.g. OpUpload or OpDownload.
+	OpCode int `json:"op_code,omitempty"`
+	// CompletedBytes and TotalBytes are the progress of the transfer.
+	CompletedBytes int `json:"completed_bytes,omitempty"`
+	TotalBytes     int `json:"total_bytes,omitempty"`
+	// BlobberID is the blobber of a blobber event.
+	BlobberID string `json:"blobber_id,omitempty"`
+	// NodeURL is the miner or sharder of a node event.
+	NodeURL string `json:"node_url,omitempty"`
+	// Miners and Sharders are the new nodes of a network event.
+	Miners   []string `json:"miners,omitempty"`
+	Sharders []string `json:"sharders,omitempty"`
+	// Balance is the write pool balance of a balance event.
+	Balance common.Balance `json:"balance,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// EventSubscription is a subscription to the SDK event bus.
+type EventSubscription struct {
+	ch    chan Event
+	types map[EventType]bool
+	// allocationID restricts the events to the ones of an allocation, see SubscribeAllocationEvents.
+	allocationID string
+	closed       bool
+}
+
+// Events returns the channel the events are delivered to. It's closed by Unsubscribe.
+func (s *EventSubscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Unsubscribe stops the delivery of the events and closes the events channel.
+func (s *EventSubscription) Unsubscribe() {
+	eventBus.mu.Lock()
+	defer eventBus.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(eventBus.subscriptions, s)
+	close(s.ch)
+}
+
+type bus struct {
+	mu            sync.RWMutex
+	subscriptions map[*EventSubscription]struct{}
+	lowBalance    common.Balance
+}
+
+var eventBus = &bus{subscriptions: make(map[*EventSubscription]struct{})}
+
+// SubscribeEvents subscribes to the events published by the SDK. Events are delivered on a buffered
+// channel and are dropped if the subscriber doesn't keep up, so a slow UI never blocks a transfer.
+//   - bufferSize: size of the events channel, defaults to 64.
+//   - types: the event types to receive, all of them if empty.
+func SubscribeEvents(bufferSize int, types ...EventType) *EventSubscription {
+	return subscribeEvents("", bufferSize, types)
+}
+
+// SubscribeAllocationEvents subscribes to the events of an allocation, like SubscribeEvents. The events
+// of the other allocations and the ones not related to an allocation, e.g. EventNodeDown, aren't delivered.
+//   - allocationID: the ID of the allocation.
+//   - bufferSize: size of the events channel, defaults to 64.
+//   - types: the event types to receive, all of them if empty.
+func SubscribeAllocationEvents(allocationID string, bufferSize int, types ...EventType) *EventSubscription {
+	return subscribeEvents(allocationID, bufferSize, types)
+}
+
+func subscribeEvents(allocationID string, bufferSize int, types []EventType) *EventSubscription {
+	if bufferSize <= 0 {
+		bufferSize = defaultEventBufferSize
+	}
+	s := &EventSubscription{ch: make(chan Event, bufferSize), allocationID: allocationID}
+	if len(types) > 0 {
+		s.types = make(map[EventType]bool, len(types))
+		for _, t := range types {
+			s.types[t] = true
+		}
+	}
+	eventBus.mu.Lock()
+	eventBus.subscriptions[s] = struct{}{}
+	eventBus.mu.Unlock()
+	return s
+}
+
+// SetLowBalanceThreshold sets the write pool balance under which EventBalanceLow is published
+// for an allocation when it's fetched. Zero disables the event.
+//   - threshold: the balance threshold in SAS.
+func SetLowBalanceThreshold(threshold common.Balance) {
+	eventBus.mu.Lock()
+	defer eventBus.mu.Unlock()
+	eventBus.lowBalance = threshold
+}
+
+func publishEvent(e Event) {
+	eventBus.mu.RLock()
+	defer eventBus.mu.RUnlock()
+	if len(eventBus.subscriptions) == 0 {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for s := range eventBus.subscriptions {
+		if s.types != nil && !s.types[e.Type] || s.allocationID != "" && s.allocationID != e.AllocationID {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+		}
+	}
+}
+
+func checkLowBalance(a *Allocation) {
+	eventBus.mu.RLock()
+	threshold := eventBus.lowBalance
+	eventBus.mu.RUnlock()
+	if threshold > 0 && a.WritePool < threshold {
+		publishEvent(Event{
+			Type:         EventBalanceLow,
+			AllocationID: a.ID,
+			Balance:      a.WritePool,
+		})
+	}
+}
+
+// eventStatusCallback publishes the transfer events on the event bus and forwards them to the
+// status callback of the transfer, if any.
+type eventStatusCallback struct {
+	cb StatusCallback
+}
+
+func withEventBus(cb StatusCallback) StatusCallback {
+	if _, ok := cb.(*eventStatusCallback); ok {
+		return cb
+	}
+	return &eventStatusCallback{cb: cb}
+}
+
+func (e *eventStatusCallback) Started(allocationId, filePath string, op int, totalBytes int) {
+	publishEvent(Event{
+		Type:         EventTransferStarted,
+		AllocationID: allocationId,
+		RemotePath:   filePath,
+		OpCode:       op,
+		TotalBytes:   totalBytes,
+	})
+	if e.cb != nil {
+		e.cb.Started(allocationId, filePath, op, totalBytes)
+	}
+}
+
+func (e *eventStatusCallback) InProgress(allocationId, filePath string, op int, completedBytes int, data []byte) {
+	publishEvent(Event{
+		Type:           EventTransferProgress,
+		AllocationID:   allocationId,
+		RemotePath:     filePath,
+		OpCode:         op,
+		CompletedBytes: completedBytes,
+	})
+	if e.cb != nil {
+		e.cb.InProgress(allocationId, filePath, op, completedBytes, data)
+	}
+}
+
+func (e *eventStatusCallback) Error(allocationID string, filePath string, op int, err error) {
+	ev := Event{
+		Type:         EventTransferFailed,
+		AllocationID: allocationID,
+		RemotePath:   filePath,
+		OpCode:       op,
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	publishEvent(ev)
+	if e.cb != nil {
+		e.cb.Error(allocationID, filePath, op, err)
+	}
+}
+
+func (e *eventStatusCallback) Completed(allocationId, filePath string, filename string, mimetype string, size int, op int) {
+	publishEvent(Event{
+		Type:           EventTransferFinished,
+		AllocationID:   allocationId,
+		RemotePath:     filePath,
+		OpCode:         op,
+		CompletedBytes: size,
+		TotalBytes:     size,
+	})
+	if e.cb != nil {
+		e.cb.Completed(allocationId, filePath, filename, mimetype, size, op)
+	}
+}
+
+func (e *eventStatusCallback) RepairCompleted(filesRepaired int) {
+	if e.cb != nil {
+		e.cb.RepairCompleted(filesRepaired)
+	}
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBus(t *testing.T) {
+	all := SubscribeEvents(0)
+	defer all.Unsubscribe()
+	commits := SubscribeEvents(1, EventCommitDone)
+	defer commits.Unsubscribe()
+	allocEvents := SubscribeAllocationEvents("alloc", 0)
+	defer allocEvents.Unsubscribe()
+	otherEvents := SubscribeAllocationEvents("other", 0)
+	defer otherEvents.Unsubscribe()
+
+	cb := withEventBus(nil)
+	require.Same(t, cb, withEventBus(cb))
+
+	cb.Started("alloc", "/a.txt", OpUpload, 10)
+	cb.InProgress("alloc", "/a.txt", OpUpload, 5, nil)
+	cb.Error("alloc", "/a.txt", OpUpload, errors.New("failed"))
+	publishEvent(Event{Type: EventCommitDone, AllocationID: "alloc"})
+
+	for _, want := range []EventType{EventTransferStarted, EventTransferProgress, EventTransferFailed, EventCommitDone} {
+		e := <-all.Events()
+		require.Equal(t, want, e.Type)
+		require.Equal(t, "alloc", e.AllocationID)
+		require.False(t, e.Time.IsZero())
+	}
+
+	e := <-commits.Events()
+	require.Equal(t, EventCommitDone, e.Type)
+
+	// the subscriber is full, the event is dropped instead of blocking
+	publishEvent(Event{Type: EventCommitDone})
+	publishEvent(Event{Type: EventCommitDone})
+	require.Len(t, commits.Events(), 1)
+
+	// the events of the other allocations and the SDK-wide ones aren't delivered to the allocation subscribers
+	require.Len(t, allocEvents.Events(), 4)
+	require.Empty(t, otherEvents.Events())
+
+	commits.Unsubscribe()
+	commits.Unsubscribe()
+	_, ok := <-commits.Events()
+	require.True(t, ok)
+	_, ok = <-commits.Events()
+	require.False(t, ok)
+}
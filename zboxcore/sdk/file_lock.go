@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// fileLockTimeout is the timeout of the lock and unlock requests to a blobber.
+const fileLockTimeout = 30 * time.Second
+
+// ErrFileLocked is returned by LockFile when the file is locked by another client.
+var ErrFileLocked = errors.New("file_locked", "the file is locked by another client")
+
+// FileLock is an advisory lock of a file held by the client, see LockFile.
+type FileLock struct {
+	Path string `json:"path"`
+	// ID identifies the holder of the lock, two clients of the same wallet hold different locks.
+	ID        string    `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LockFile acquires the advisory lock of a file on the blobbers, so the other clients, including the
+// ones of the same wallet and the collaborators, can't lock it until it's unlocked or expired. The
+// clients modifying a file concurrently, e.g. with UpdateFile, should lock it first so they don't
+// corrupt the consensus of the blobbers. Locking a file already locked by this allocation refreshes
+// its lock. It fails with ErrFileLocked if another client holds the lock.
+//   - path: the remote path of the file.
+//   - ttl: the duration of the lock, at least a second. The lock expires unless it's refreshed.
+func (a *Allocation) LockFile(path string, ttl time.Duration) (*FileLock, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if ttl < time.Second {
+		return nil, errors.New("invalid_ttl", "the lock duration must be at least a second")
+	}
+	path = zboxutil.RemoteClean(path)
+	if !zboxutil.IsRemoteAbs(path) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	lockID := zboxutil.NewConnectionId()
+	if lock := a.fileLock(path); lock != nil {
+		lockID = lock.ID
+	}
+	expiresAt := time.Now().Add(ttl)
+	statuses := a.sendFileLockRequests(func(b *blockchain.StorageNode) (*http.Request, error) {
+		return zboxutil.NewFileLockRequest(b.Baseurl, a.ID, a.Tx, a.sig, path, lockID, ttl)
+	})
+
+	var locked, conflicts int
+	for _, status := range statuses {
+		switch status {
+		case http.StatusOK:
+			locked++
+		case http.StatusConflict:
+			conflicts++
+		}
+	}
+	if locked < a.consensusThreshold {
+		// release the locks acquired, so the file isn't left locked on some blobbers.
+		a.sendFileLockRequests(func(b *blockchain.StorageNode) (*http.Request, error) {
+			return zboxutil.NewFileUnlockRequest(b.Baseurl, a.ID, a.Tx, a.sig, path, lockID)
+		})
+		a.setFileLock(path, nil)
+		if conflicts > 0 {
+			return nil, ErrFileLocked
+		}
+		return nil, errors.New("lock_consensus_not_met",
+			fmt.Sprintf("Required consensus %d got %d", a.consensusThreshold, locked))
+	}
+
+	lock := &FileLock{Path: path, ID: lockID, ExpiresAt: expiresAt}
+	a.setFileLock(path, lock)
+	return lock, nil
+}
+
+// UnlockFile releases the advisory lock of a file acquired with LockFile.
+//   - path: the remote path of the file.
+func (a *Allocation) UnlockFile(path string) error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+	path = zboxutil.RemoteClean(path)
+	lock := a.fileLock(path)
+	if lock == nil {
+		return errors.New("file_not_locked", "the file isn't locked by this client")
+	}
+	a.setFileLock(path, nil)
+
+	statuses := a.sendFileLockRequests(func(b *blockchain.StorageNode) (*http.Request, error) {
+		return zboxutil.NewFileUnlockRequest(b.Baseurl, a.ID, a.Tx, a.sig, path, lock.ID)
+	})
+	var unlocked int
+	for _, status := range statuses {
+		// the lock may have expired already.
+		if status == http.StatusOK || status == http.StatusNoContent || status == http.StatusNotFound {
+			unlocked++
+		}
+	}
+	if unlocked < a.consensusThreshold {
+		return errors.New("unlock_consensus_not_met",
+			fmt.Sprintf("Required consensus %d got %d, the lock expires at %s", a.consensusThreshold, unlocked, lock.ExpiresAt))
+	}
+	return nil
+}
+
+// fileLock returns the lock of a file held by the allocation, nil if none or expired.
+func (a *Allocation) fileLock(path string) *FileLock {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	lock := a.fileLocks[path]
+	if lock != nil && time.Now().After(lock.ExpiresAt) {
+		delete(a.fileLocks, path)
+		return nil
+	}
+	return lock
+}
+
+// setFileLock records the lock of a file held by the allocation, nil removes it.
+func (a *Allocation) setFileLock(path string, lock *FileLock) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if lock == nil {
+		delete(a.fileLocks, path)
+		return
+	}
+	if a.fileLocks == nil {
+		a.fileLocks = make(map[string]*FileLock)
+	}
+	a.fileLocks[path] = lock
+}
+
+// sendFileLockRequests sends a lock or unlock request to all the blobbers and returns the status codes
+// of their responses, 0 for the failed requests.
+func (a *Allocation) sendFileLockRequests(newRequest func(b *blockchain.StorageNode) (*http.Request, error)) []int {
+	statuses := make([]int, len(a.Blobbers))
+	wg := &sync.WaitGroup{}
+	for i, b := range a.Blobbers {
+		wg.Add(1)
+		go func(i int, b *blockchain.StorageNode) {
+			defer wg.Done()
+			req, err := newRequest(b)
+			if err != nil {
+				logger.Logger.Error("file lock request failed: ", b.Baseurl, err)
+				return
+			}
+			ctx, cancel := context.WithTimeout(a.ctx, fileLockTimeout)
+			defer cancel()
+			resp, err := zboxutil.Client.Do(req.WithContext(ctx))
+			if err != nil {
+				logger.Logger.Error("file lock request failed: ", b.Baseurl, err)
+				return
+			}
+			if resp.Body != nil {
+				defer resp.Body.Close()
+				io.Copy(io.Discard, resp.Body) //nolint: errcheck
+			}
+			statuses[i] = resp.StatusCode
+		}(i, b)
+	}
+	wg.Wait()
+	return statuses
+}
@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/mocks"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_LockFile(t *testing.T) {
+	rawClient := zboxutil.Client
+	defer func() {
+		zboxutil.Client = rawClient
+	}()
+
+	tests := []struct {
+		name string
+		// lockStatuses are the status codes of the lock requests by blobber.
+		lockStatuses []int
+		wantErr      error
+		wantUnlocks  int
+	}{
+		{name: "Test_Success", lockStatuses: []int{http.StatusOK, http.StatusOK, http.StatusOK}},
+		{name: "Test_Consensus", lockStatuses: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusInternalServerError}},
+		{name: "Test_Locked", lockStatuses: []int{http.StatusOK, http.StatusConflict, http.StatusConflict}, wantErr: ErrFileLocked, wantUnlocks: 3},
+		{name: "Test_No_Consensus", lockStatuses: []int{http.StatusOK, http.StatusInternalServerError, http.StatusInternalServerError}, wantUnlocks: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mocks.HttpClient{}
+			zboxutil.Client = mockClient
+
+			a := &Allocation{ID: "alloc", Tx: "alloc", DataShards: 2, ParityShards: len(tt.lockStatuses) - 2}
+			for i := range tt.lockStatuses {
+				a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{
+					ID:      strconv.Itoa(i),
+					Baseurl: "http://" + tt.name + mockBlobberUrl + strconv.Itoa(i),
+				})
+			}
+			setupMockAllocation(t, a)
+
+			var unlocks int32
+			for i, status := range tt.lockStatuses {
+				url := tt.name + mockBlobberUrl + strconv.Itoa(i)
+				mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					return req.Method == http.MethodPost && strings.Contains(req.URL.String(), url) &&
+						req.URL.Query().Get("path") == "/a.txt" && req.URL.Query().Get("ttl") == "60"
+				})).Return(&http.Response{
+					StatusCode: status,
+					Body:       io.NopCloser(bytes.NewReader(nil)),
+				}, nil)
+				mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					return req.Method == http.MethodDelete && strings.Contains(req.URL.String(), url)
+				})).Run(func(mock.Arguments) {
+					atomic.AddInt32(&unlocks, 1)
+				}).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(nil)),
+				}, nil)
+			}
+
+			lock, err := a.LockFile("/a.txt", time.Minute)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantUnlocks > 0 {
+				require.Error(t, err)
+				require.EqualValues(t, tt.wantUnlocks, atomic.LoadInt32(&unlocks))
+				require.Nil(t, a.fileLock("/a.txt"))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "/a.txt", lock.Path)
+			require.NotEmpty(t, lock.ID)
+
+			// locking again refreshes the lock.
+			refreshed, err := a.LockFile("/a.txt", time.Minute)
+			require.NoError(t, err)
+			require.Equal(t, lock.ID, refreshed.ID)
+
+			require.NoError(t, a.UnlockFile("/a.txt"))
+			require.EqualValues(t, len(tt.lockStatuses), atomic.LoadInt32(&unlocks))
+			err = a.UnlockFile("/a.txt")
+			var zerr *errors.Error
+			require.True(t, errors.As(err, &zerr))
+			require.Equal(t, "file_not_locked", zerr.Code)
+		})
+	}
+}
+
+func TestAllocation_LockFile_Invalid(t *testing.T) {
+	a := &Allocation{}
+	setupMockAllocation(t, a)
+	_, err := a.LockFile("/a.txt", time.Millisecond)
+	require.Error(t, err)
+	_, err = a.LockFile("a.txt", time.Minute)
+	require.Error(t, err)
+}
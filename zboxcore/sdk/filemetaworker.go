@@ -30,6 +30,76 @@ type fileMetaByNameResponse struct {
 	err        error
 }
 
+// FileMetaOption is an option of the file meta request.
+type FileMetaOption func(o *fileMetaOptions)
+
+type fileMetaOptions struct {
+	blobberShardMeta bool
+	timeout          time.Duration
+	consensus        ConsensusPolicy
+}
+
+// WithFileMetaConsensus overrides the number of blobbers that must agree on the file meta, e.g.
+// ConsensusPolicy{Full: true} to check all the blobbers hold the same version of the file.
+//   - policy: the consensus policy of the request
+func WithFileMetaConsensus(policy ConsensusPolicy) FileMetaOption {
+	return func(o *fileMetaOptions) {
+		o.consensus = policy
+	}
+}
+
+// WithFileMetaTimeout bounds all the blobber calls of the file meta request, it fails with
+// ErrRequestTimeout once the timeout expires.
+//   - timeout: the timeout of the whole request
+func WithFileMetaTimeout(timeout time.Duration) FileMetaOption {
+	return func(o *fileMetaOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithBlobberShardMeta makes GetFileMeta return the shard metadata of each blobber
+// (shard hash, stored size, write marker and challenge results) in addition to the consolidated view.
+func WithBlobberShardMeta() FileMetaOption {
+	return func(o *fileMetaOptions) {
+		o.blobberShardMeta = true
+	}
+}
+
+// getBlobberShardMeta builds the per-blobber shard metadata from the file meta responses and the file stats of the blobbers.
+func getBlobberShardMeta(blobbers []*blockchain.StorageNode, lR []*fileMetaResponse, foundMask zboxutil.Uint128, stats map[string]*FileStats) []*BlobberShardMeta {
+	shards := make([]*BlobberShardMeta, len(blobbers))
+	for i, blobber := range blobbers {
+		shard := &BlobberShardMeta{
+			BlobberID:  blobber.ID,
+			BlobberURL: blobber.Baseurl,
+		}
+		shards[i] = shard
+
+		if i < len(lR) && lR[i] != nil {
+			if lR[i].err != nil {
+				shard.Error = lR[i].err.Error()
+			}
+			if ref := lR[i].fileref; ref != nil {
+				shard.Found = true
+				shard.Hash = ref.Hash
+				shard.FileMetaHash = ref.FileMetaHash
+				shard.Size = ref.Size
+				shard.NumBlocks = ref.NumBlocks
+				shard.AllocationVersion = ref.AllocationVersion
+			}
+		}
+		shard.InConsensus = !foundMask.And(zboxutil.NewUint128(1).Lsh(uint64(i))).Equals64(0)
+
+		if fs, ok := stats[blobber.ID]; ok {
+			shard.WriteMarkerRedeemTxn = fs.WriteMarkerRedeemTxn
+			shard.SuccessChallenges = fs.SuccessChallenges
+			shard.FailedChallenges = fs.FailedChallenges
+			shard.LastChallengeResponseTxn = fs.LastChallengeResponseTxn
+		}
+	}
+	return shards
+}
+
 func (req *ListRequest) getFileMetaInfoFromBlobber(blobber *blockchain.StorageNode, blobberIdx int, rspCh chan<- *fileMetaResponse) {
 	body := new(bytes.Buffer)
 	formWriter := multipart.NewWriter(body)
@@ -40,9 +110,11 @@ func (req *ListRequest) getFileMetaInfoFromBlobber(blobber *blockchain.StorageNo
 		rspCh <- &fileMetaResponse{fileref: fileRef, blobberIdx: blobberIdx, err: err}
 	}
 	defer fileMetaRetFn()
-	if len(req.remotefilepath) > 0 {
-		req.remotefilepathhash = fileref.GetReferenceLookup(req.allocationID, req.remotefilepath)
+	if !req.selection.selects(blobber.ID) {
+		err = errBlobberNotSelected
+		return
 	}
+	req.setRemoteFilePathHash()
 	if singleClientMode {
 		fileMetaHash := fileref.GetCacheKey(req.remotefilepathhash, blobber.ID)
 		cachedRef, ok := fileref.GetFileRef(fileMetaHash)
@@ -175,9 +247,21 @@ func (req *ListRequest) getFileMetaByNameInfoFromBlobber(blobber *blockchain.Sto
 	})
 }
 
+// setRemoteFilePathHash sets the lookup hash of the remote path, it's only written if it changes so the
+// blobber requests sent in parallel don't race once it's set.
+func (req *ListRequest) setRemoteFilePathHash() {
+	if len(req.remotefilepath) == 0 {
+		return
+	}
+	if pathHash := fileref.GetReferenceLookup(req.allocationID, req.remotefilepath); pathHash != req.remotefilepathhash {
+		req.remotefilepathhash = pathHash
+	}
+}
+
 func (req *ListRequest) getFileMetaFromBlobbers() []*fileMetaResponse {
 	numList := len(req.blobbers)
 	rspCh := make(chan *fileMetaResponse, numList)
+	req.setRemoteFilePathHash()
 	for i := 0; i < numList; i++ {
 		go req.getFileMetaInfoFromBlobber(req.blobbers[i], i, rspCh)
 	}
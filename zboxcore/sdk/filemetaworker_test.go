@@ -303,3 +303,38 @@ func TestListRequest_getFileConsensusFromBlobbers(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlobberShardMeta(t *testing.T) {
+	blobbers := []*blockchain.StorageNode{
+		{ID: "blobber0", Baseurl: "http://blobber0"},
+		{ID: "blobber1", Baseurl: "http://blobber1"},
+		{ID: "blobber2", Baseurl: "http://blobber2"},
+	}
+	lR := []*fileMetaResponse{
+		{blobberIdx: 0, fileref: &fileref.FileRef{Ref: fileref.Ref{Hash: "h0", FileMetaHash: "m", Size: 10, AllocationVersion: 2}}},
+		{blobberIdx: 1, fileref: &fileref.FileRef{Ref: fileref.Ref{Hash: "h1", FileMetaHash: "stale", Size: 8, AllocationVersion: 1}}},
+		{blobberIdx: 2, err: errors.New("", "mock error")},
+	}
+	stats := map[string]*FileStats{
+		"blobber0": {BlobberID: "blobber0", WriteMarkerRedeemTxn: "wm0", SuccessChallenges: 3, LastChallengeResponseTxn: "ch0"},
+	}
+
+	shards := getBlobberShardMeta(blobbers, lR, zboxutil.NewUint128(1), stats)
+	require.Len(t, shards, 3)
+
+	require.True(t, shards[0].Found)
+	require.True(t, shards[0].InConsensus)
+	require.Equal(t, "h0", shards[0].Hash)
+	require.Equal(t, int64(2), shards[0].AllocationVersion)
+	require.Equal(t, "wm0", shards[0].WriteMarkerRedeemTxn)
+	require.Equal(t, int64(3), shards[0].SuccessChallenges)
+	require.Equal(t, "ch0", shards[0].LastChallengeResponseTxn)
+
+	require.True(t, shards[1].Found)
+	require.False(t, shards[1].InConsensus)
+	require.Equal(t, int64(8), shards[1].Size)
+
+	require.False(t, shards[2].Found)
+	require.False(t, shards[2].InConsensus)
+	require.NotEmpty(t, shards[2].Error)
+}
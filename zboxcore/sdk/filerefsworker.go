@@ -15,6 +15,7 @@ import (
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/fileref"
 	"github.com/0chain/gosdk/zboxcore/logger"
 	l "github.com/0chain/gosdk/zboxcore/logger"
 	"github.com/0chain/gosdk/zboxcore/zboxutil"
@@ -309,25 +310,26 @@ type ORef struct {
 }
 
 type SimilarField struct {
-	FileID              string `json:"file_id"`
-	FileMetaHash        string `json:"file_meta_hash"`
-	Type                string `json:"type"`
-	AllocationID        string `json:"allocation_id"`
-	LookupHash          string `json:"lookup_hash"`
-	Name                string `json:"name"`
-	Path                string `json:"path"`
-	PathHash            string `json:"path_hash"`
-	ParentPath          string `json:"parent_path"`
-	PathLevel           int    `json:"level"`
-	Size                int64  `json:"size"`
-	EncryptedKey        string `json:"encrypted_key"`
-	EncryptedKeyPoint   string `json:"encrypted_key_point"`
-	ActualFileSize      int64  `json:"actual_file_size"`
-	ActualFileHash      string `json:"actual_file_hash"`
-	MimeType            string `json:"mimetype"`
-	ActualThumbnailSize int64  `json:"actual_thumbnail_size"`
-	ActualThumbnailHash string `json:"actual_thumbnail_hash"`
-	CustomMeta          string `json:"custom_meta"`
+	FileID              string             `json:"file_id"`
+	FileMetaHash        string             `json:"file_meta_hash"`
+	Type                string             `json:"type"`
+	AllocationID        string             `json:"allocation_id"`
+	LookupHash          string             `json:"lookup_hash"`
+	Name                string             `json:"name"`
+	Path                string             `json:"path"`
+	PathHash            string             `json:"path_hash"`
+	ParentPath          string             `json:"parent_path"`
+	PathLevel           int                `json:"level"`
+	Size                int64              `json:"size"`
+	EncryptedKey        string             `json:"encrypted_key"`
+	EncryptedKeyPoint   string             `json:"encrypted_key_point"`
+	ActualFileSize      int64              `json:"actual_file_size"`
+	ActualFileHash      string             `json:"actual_file_hash"`
+	MimeType            string             `json:"mimetype"`
+	ActualThumbnailSize int64              `json:"actual_thumbnail_size"`
+	ActualThumbnailHash string             `json:"actual_thumbnail_hash"`
+	CustomMeta          string             `json:"custom_meta"`
+	Attributes          fileref.Attributes `json:"attributes"`
 }
 
 type RecentlyAddedRefRequest struct {
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/encryption"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/klauspost/reedsolomon"
+)
+
+// FragmentEncoder erasure codes and encrypts the chunks of the uploads. By default the chunks are
+// encoded in process, SetFragmentEncoder replaces it, e.g. with a pool of web workers in the wasm sdk
+// so the encoding doesn't block the main thread of the browser.
+type FragmentEncoder interface {
+	// EncodeFragments returns the data and parity fragments of the data, the fragments of the encrypt
+	// mask are encrypted if the request has an encryption key.
+	EncodeFragments(req *EncodeFragmentsRequest) ([][]byte, error)
+}
+
+// EncodeFragmentsRequest is a chunk of an upload to erasure code and encrypt.
+type EncodeFragmentsRequest struct {
+	Data         []byte `json:"-"`
+	DataShards   int    `json:"data_shards"`
+	ParityShards int    `json:"parity_shards"`
+	// EncryptMask is the mask of the fragments to encrypt.
+	EncryptMask zboxutil.Uint128 `json:"encrypt_mask"`
+	// EncryptPrivateKey is the hex encoded encryption key of the file, the fragments are not
+	// encrypted if it's empty.
+	EncryptPrivateKey string `json:"encrypt_private_key,omitempty"`
+	EncryptedKeyPoint string `json:"encrypted_key_point,omitempty"`
+}
+
+var (
+	fragmentEncoderMu sync.RWMutex
+	fragmentEncoder   FragmentEncoder
+)
+
+// SetFragmentEncoder sets the encoder of the chunks of the uploads started afterwards, nil restores
+// the in process encoding.
+//   - enc: the fragment encoder
+func SetFragmentEncoder(enc FragmentEncoder) {
+	fragmentEncoderMu.Lock()
+	defer fragmentEncoderMu.Unlock()
+	fragmentEncoder = enc
+}
+
+func getFragmentEncoder() FragmentEncoder {
+	fragmentEncoderMu.RLock()
+	defer fragmentEncoderMu.RUnlock()
+	return fragmentEncoder
+}
+
+var (
+	encoderCacheMu sync.Mutex
+	erasureCache   = make(map[[2]int]reedsolomon.Encoder)
+)
+
+// EncodeFragments erasure codes and encrypts a chunk in process. It's the implementation of the
+// FragmentEncoder workers.
+//   - req: the chunk to encode
+func EncodeFragments(req *EncodeFragmentsRequest) ([][]byte, error) {
+	erasureEncoder, err := getErasureEncoder(req.DataShards, req.ParityShards)
+	if err != nil {
+		return nil, err
+	}
+	var encscheme encryption.EncryptionScheme
+	if req.EncryptPrivateKey != "" {
+		if encscheme, err = newEncscheme(req.EncryptPrivateKey, req.EncryptedKeyPoint); err != nil {
+			return nil, err
+		}
+	}
+	return encodeFragments(erasureEncoder, encscheme, req.EncryptMask, req.Data)
+}
+
+// getErasureEncoder returns the cached encoder of the shards, the encoders are safe for concurrent use.
+func getErasureEncoder(dataShards, parityShards int) (reedsolomon.Encoder, error) {
+	encoderCacheMu.Lock()
+	defer encoderCacheMu.Unlock()
+	key := [2]int{dataShards, parityShards}
+	if enc, ok := erasureCache[key]; ok {
+		return enc, nil
+	}
+	enc, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, err
+	}
+	erasureCache[key] = enc
+	return enc, nil
+}
+
+// newEncscheme returns the encryption scheme of a chunk. It isn't cached, so the private key isn't kept
+// in memory once the chunk is encoded.
+func newEncscheme(privateKey, keyPoint string) (encryption.EncryptionScheme, error) {
+	pk, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid encryption key")
+	}
+	encscheme := encryption.NewEncryptionScheme()
+	if err = encscheme.InitializeWithPrivateKey(pk); err != nil {
+		return nil, err
+	}
+	if err = encscheme.InitForEncryptionWithPoint("filetype:audio", keyPoint); err != nil {
+		return nil, err
+	}
+	return encscheme, nil
+}
+
+// encodeFragments erasure codes the data and encrypts the fragments of the mask if encscheme is set.
+func encodeFragments(erasureEncoder reedsolomon.Encoder, encscheme encryption.EncryptionScheme, mask zboxutil.Uint128, data []byte) ([][]byte, error) {
+	fragments, err := erasureEncoder.Split(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = erasureEncoder.Encode(fragments)
+	if err != nil {
+		return nil, err
+	}
+
+	if encscheme == nil {
+		return fragments, nil
+	}
+	var pos uint64
+	for i := mask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
+		pos = uint64(i.TrailingZeros())
+		encMsg, err := encscheme.Encrypt(fragments[pos])
+		if err != nil {
+			return nil, err
+		}
+		fragments[pos] = make([]byte, len(encMsg.EncryptedData)+EncryptionHeaderSize)
+		n := copy(fragments[pos], encMsg.MessageChecksum+encMsg.OverallChecksum)
+		copy(fragments[pos][n:], encMsg.EncryptedData)
+	}
+	return fragments, nil
+}
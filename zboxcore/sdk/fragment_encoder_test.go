@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/encryption"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/klauspost/reedsolomon"
+	"github.com/stretchr/testify/require"
+)
+
+type countingFragmentEncoder struct {
+	calls int
+}
+
+func (e *countingFragmentEncoder) EncodeFragments(req *EncodeFragmentsRequest) ([][]byte, error) {
+	e.calls++
+	return EncodeFragments(req)
+}
+
+func TestFragmentEncoder(t *testing.T) {
+	const (
+		size         = KB*64*2*3 + KB*1
+		chunkSize    = KB * 64
+		dataShards   = 2
+		parityShards = 1
+	)
+	uploadMask := zboxutil.NewUint128(1).Lsh(dataShards + parityShards).Sub64(1)
+	buf := generateRandomBytes(size)
+
+	readChunks := func(enc FragmentEncoder) [][][]byte {
+		erasureEncoder, err := reedsolomon.New(dataShards, parityShards, reedsolomon.WithAutoGoroutines(chunkSize))
+		require.NoError(t, err)
+		reader, err := createChunkReader(
+			bytes.NewReader(buf), size, chunkSize, dataShards, parityShards,
+			false, uploadMask, erasureEncoder, encryption.NewEncryptionScheme(),
+			CreateHasher(getShardSize(size, dataShards, false, DefaultChunkSize)), 100,
+		)
+		require.NoError(t, err)
+		if enc != nil {
+			reader.(*chunkedUploadChunkReader).setFragmentEncoder(enc, "", "")
+		}
+
+		var chunks [][][]byte
+		for {
+			chunk, err := reader.Next()
+			require.NoError(t, err)
+			chunks = append(chunks, chunk.Fragments)
+			if chunk.IsFinal {
+				return chunks
+			}
+		}
+	}
+
+	enc := &countingFragmentEncoder{}
+	offloaded := readChunks(enc)
+	require.Equal(t, readChunks(nil), offloaded)
+	require.Equal(t, len(offloaded), enc.calls)
+}
@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/marker"
+)
+
+// GenerateFreeStorageMarker generates a free storage marker signed by the current client,
+// which must be registered as a free storage assigner (see AddFreeStorageAssigner).
+// The returned marker is passed to CreateFreeAllocation by the recipient.
+//   - assignerName: name of the assigner, as registered in the storage smart contract.
+//   - recipientID: client ID of the recipient wallet.
+//   - freeTokens: number of tokens (in ZCN) granted to the recipient.
+//
+// returns the marker serialized as json.
+func GenerateFreeStorageMarker(assignerName, recipientID string, freeTokens float64) (string, error) {
+	if !sdkInitialized {
+		return "", sdkNotInitialized
+	}
+	if freeTokens <= 0 {
+		return "", errors.New("free_storage_marker", "free tokens should be positive")
+	}
+
+	fm := &marker.FreeStorageMarker{
+		Assigner:   assignerName,
+		Recipient:  recipientID,
+		FreeTokens: freeTokens,
+		Nonce:      time.Now().UnixNano(),
+	}
+	if err := fm.Sign(); err != nil {
+		return "", errors.Wrap(err, "signing free storage marker")
+	}
+
+	b, err := json.Marshal(fm)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ValidateFreeStorageMarker decodes the marker and verifies it's signed by the given assigner.
+//   - fm: the marker serialized as json.
+//   - assignerPublicKey: public key of the free storage assigner.
+func ValidateFreeStorageMarker(fm, assignerPublicKey string) (*marker.FreeStorageMarker, error) {
+	m, err := parseFreeStorageMarker(fm)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.VerifySignature(assignerPublicKey); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// parseFreeStorageMarker decodes the marker and checks its fields are set, whoever it's issued to.
+func parseFreeStorageMarker(fm string) (*marker.FreeStorageMarker, error) {
+	m := new(marker.FreeStorageMarker)
+	if err := json.Unmarshal([]byte(fm), m); err != nil {
+		return nil, errors.New("invalid_free_storage_marker", "Error decoding the free storage marker: "+err.Error())
+	}
+	if m.Assigner == "" || m.Signature == "" {
+		return nil, errors.New("invalid_free_storage_marker", "marker assigner and signature are required")
+	}
+	if m.FreeTokens <= 0 {
+		return nil, errors.New("invalid_free_storage_marker", "marker free tokens should be positive")
+	}
+	return m, nil
+}
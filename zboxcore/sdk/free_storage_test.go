@@ -0,0 +1,21 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFreeStorageMarker(t *testing.T) {
+	// the marker of another recipient is decoded, e.g. to be validated by the assigner.
+	m, err := parseFreeStorageMarker(`{"assigner":"assigner","recipient":"other","free_tokens":1,"signature":"sig"}`)
+	require.NoError(t, err)
+	require.Equal(t, "other", m.Recipient)
+
+	_, err = parseFreeStorageMarker(`{"assigner":"assigner","recipient":"other","free_tokens":1}`)
+	require.Error(t, err)
+	_, err = parseFreeStorageMarker(`{"assigner":"assigner","recipient":"other","signature":"sig"}`)
+	require.Error(t, err)
+	_, err = parseFreeStorageMarker(`{`)
+	require.Error(t, err)
+}
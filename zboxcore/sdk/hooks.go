@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+)
+
+// UploadHook processes the files of the uploads before they're sent to the blobbers, so integrators
+// can plug in e.g. virus scanning, watermarking or a custom encryption. The repairs don't run the hooks,
+// the content of their files being already processed.
+type UploadHook interface {
+	// BeforeUpload is called once per file before its upload starts. It returns the reader of the content
+	// to upload, r or a reader transforming it. meta can be updated, its ActualSize must be updated if the
+	// size of the content changes. An error fails the upload.
+	BeforeUpload(ctx context.Context, meta *FileMeta, r io.Reader) (io.Reader, error)
+	// OnChunk is called with the data of every chunk of the file, in order, before it's erasure coded.
+	// The data must not be modified or retained. An error fails the upload, e.g. a virus found.
+	OnChunk(ctx context.Context, meta *FileMeta, index int, data []byte) error
+}
+
+// DownloadHook processes the files of the downloads once they're downloaded, e.g. to scan them or to
+// decrypt the files encrypted by an UploadHook. It's called for the full downloads only, not for the
+// thumbnails nor the downloads of a range of blocks.
+type DownloadHook interface {
+	// AfterDownload is called once the file is written to f and before the download is reported as
+	// completed. f can be rewritten. An error fails the download. f can't be read if it's streamed, e.g.
+	// a sys.MemChanFile.
+	AfterDownload(ctx context.Context, remotePath string, f sys.File) error
+}
+
+var (
+	hooksMu       sync.RWMutex
+	uploadHooks   []UploadHook
+	downloadHooks []DownloadHook
+)
+
+// SetUploadHooks sets the hooks run by all the uploads started afterwards, before the hooks of their
+// WithUploadHooks option. No hooks restores the default.
+//   - hooks: the upload hooks, run in order.
+func SetUploadHooks(hooks ...UploadHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	uploadHooks = hooks
+}
+
+// SetDownloadHooks sets the hooks run by all the downloads started afterwards, before the hooks of their
+// WithDownloadHooks option. No hooks restores the default.
+//   - hooks: the download hooks, run in order.
+func SetDownloadHooks(hooks ...DownloadHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	downloadHooks = hooks
+}
+
+func getUploadHooks() []UploadHook {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	return uploadHooks
+}
+
+func getDownloadHooks() []DownloadHook {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	return downloadHooks
+}
+
+// WithUploadHooks adds hooks to the upload, run after the ones set with SetUploadHooks.
+//   - hooks: the upload hooks, run in order.
+func WithUploadHooks(hooks ...UploadHook) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.hooks = append(su.hooks, hooks...)
+	}
+}
+
+// WithDownloadHooks adds hooks to the download, run after the ones set with SetDownloadHooks.
+//   - hooks: the download hooks, run in order.
+func WithDownloadHooks(hooks ...DownloadHook) DownloadRequestOption {
+	return func(dr *DownloadRequest) {
+		dr.hooks = append(dr.hooks, hooks...)
+	}
+}
+
+// runBeforeUpload runs the BeforeUpload hooks of the upload on its file.
+func (su *ChunkedUpload) runBeforeUpload() error {
+	for _, hook := range su.hooks {
+		r, err := hook.BeforeUpload(su.ctx, &su.fileMeta, su.fileReader)
+		if err != nil {
+			return errors.Wrap(err, "upload hook failed")
+		}
+		su.fileReader = r
+	}
+	return nil
+}
+
+// onChunk returns the function running the OnChunk hooks of the upload, nil if it has no hooks.
+func (su *ChunkedUpload) onChunk() func(index int, data []byte) error {
+	if len(su.hooks) == 0 {
+		return nil
+	}
+	return func(index int, data []byte) error {
+		for _, hook := range su.hooks {
+			if err := hook.OnChunk(su.ctx, &su.fileMeta, index, data); err != nil {
+				return errors.Wrap(err, "upload hook failed")
+			}
+		}
+		return nil
+	}
+}
+
+// runAfterDownload runs the AfterDownload hooks of the download on its file.
+func (req *DownloadRequest) runAfterDownload() error {
+	if req.contentMode == DOWNLOAD_CONTENT_THUMB || (req.startBlock != 0 && !req.isResume) || req.endBlock < req.chunksPerShard {
+		return nil
+	}
+	for _, hook := range req.hooks {
+		if err := hook.AfterDownload(req.ctx, req.remotefilepath, req.fileHandler); err != nil {
+			return errors.Wrap(err, "download hook failed")
+		}
+	}
+	return nil
+}
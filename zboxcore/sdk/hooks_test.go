@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/klauspost/reedsolomon"
+	"github.com/stretchr/testify/require"
+)
+
+type testUploadHook struct {
+	chunks   [][]byte
+	chunkErr error
+}
+
+func (h *testUploadHook) BeforeUpload(_ context.Context, meta *FileMeta, r io.Reader) (io.Reader, error) {
+	if meta.RemoteName == "virus.exe" {
+		return nil, errors.New("virus found")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = append([]byte("watermark:"), data...)
+	meta.ActualSize = int64(len(data))
+	return bytes.NewReader(data), nil
+}
+
+func (h *testUploadHook) OnChunk(_ context.Context, _ *FileMeta, _ int, data []byte) error {
+	h.chunks = append(h.chunks, append([]byte(nil), data...))
+	return h.chunkErr
+}
+
+type testDownloadHook struct {
+	paths []string
+}
+
+func (h *testDownloadHook) AfterDownload(_ context.Context, remotePath string, _ sys.File) error {
+	h.paths = append(h.paths, remotePath)
+	return nil
+}
+
+func TestUploadHooks(t *testing.T) {
+	global, own := &testUploadHook{}, &testUploadHook{}
+	SetUploadHooks(global)
+	defer SetUploadHooks()
+
+	su := &ChunkedUpload{
+		ctx:        context.Background(),
+		fileMeta:   FileMeta{RemoteName: "a.txt", ActualSize: 3},
+		fileReader: strings.NewReader("abc"),
+		hooks:      append([]UploadHook(nil), getUploadHooks()...),
+	}
+	WithUploadHooks(own)(su)
+	require.NoError(t, su.runBeforeUpload())
+	data, err := io.ReadAll(su.fileReader)
+	require.NoError(t, err)
+	require.Equal(t, "watermark:watermark:abc", string(data))
+	require.EqualValues(t, len(data), su.fileMeta.ActualSize)
+
+	su.fileMeta.RemoteName = "virus.exe"
+	require.Error(t, su.runBeforeUpload())
+
+	// the hooks are called with the data of every chunk, before it's encoded.
+	content := generateRandomBytes(3 * KB)
+	uploadMask := zboxutil.NewUint128(1).Lsh(3).Sub64(1)
+	erasureEncoder, err := reedsolomon.New(2, 1)
+	require.NoError(t, err)
+	reader, err := createChunkReader(bytes.NewReader(content), int64(len(content)), KB, 2, 1, false, uploadMask,
+		erasureEncoder, nil, CreateHasher(getShardSize(int64(len(content)), 2, false, KB)), 100)
+	require.NoError(t, err)
+	reader.(*chunkedUploadChunkReader).onChunk = su.onChunk()
+	for {
+		chunk, err := reader.Next()
+		require.NoError(t, err)
+		if chunk.IsFinal {
+			break
+		}
+	}
+	require.Len(t, own.chunks, 2)
+	require.Equal(t, content, bytes.Join(global.chunks, nil))
+
+	own.chunkErr = errors.New("rejected")
+	reader, err = createChunkReader(bytes.NewReader(content), int64(len(content)), KB, 2, 1, false, uploadMask,
+		erasureEncoder, nil, CreateHasher(getShardSize(int64(len(content)), 2, false, KB)), 100)
+	require.NoError(t, err)
+	reader.(*chunkedUploadChunkReader).onChunk = su.onChunk()
+	_, err = reader.Next()
+	require.ErrorContains(t, err, "rejected")
+
+	require.Nil(t, (&ChunkedUpload{}).onChunk())
+}
+
+func TestDownloadHooks(t *testing.T) {
+	hook := &testDownloadHook{}
+	req := &DownloadRequest{remotefilepath: "/a.txt", contentMode: DOWNLOAD_CONTENT_FULL, endBlock: 4, chunksPerShard: 4}
+	WithDownloadHooks(hook)(req)
+	require.NoError(t, req.runAfterDownload())
+	require.Equal(t, []string{"/a.txt"}, hook.paths)
+
+	// the hooks aren't run for the ranges of blocks and the thumbnails.
+	req.startBlock = 1
+	require.NoError(t, req.runAfterDownload())
+	req.startBlock, req.endBlock = 0, 2
+	require.NoError(t, req.runAfterDownload())
+	req.endBlock, req.contentMode = 4, DOWNLOAD_CONTENT_THUMB
+	require.NoError(t, req.runAfterDownload())
+	require.Len(t, hook.paths, 1)
+}
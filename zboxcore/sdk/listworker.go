@@ -36,6 +36,14 @@ type ListRequest struct {
 	listOnly           bool
 	offset             int
 	pageLimit          int
+	// timeout bounds all the blobber calls of the request, see WithListRequestTimeout.
+	timeout time.Duration
+	// selection restricts the blobbers the request is sent to.
+	selection blobberSelection
+	// rawArchives lists the archive containers instead of their packed files, see WithListRequestRawArchives.
+	rawArchives bool
+	// consensusPolicy overrides the consensus threshold, see WithListRequestConsensus.
+	consensusPolicy ConsensusPolicy
 	Consensus
 }
 
@@ -65,6 +73,9 @@ type ListResult struct {
 	ThumbnailSize       int64  `json:"thumbnail_size"`
 	ActualThumbnailHash string `json:"actual_thumbnail_hash"`
 	ActualThumbnailSize int64  `json:"actual_thumbnail_size"`
+	CustomMeta          string `json:"custom_meta,omitempty"`
+	// Archive is the path of the archive container of a packed file, see UploadArchive.
+	Archive string `json:"archive,omitempty"`
 
 	CreatedAt  common.Timestamp `json:"created_at"`
 	UpdatedAt  common.Timestamp `json:"updated_at"`
@@ -93,6 +104,41 @@ func WithListRequestForRepair(forRepair bool) ListRequestOptions {
 	}
 }
 
+// WithListRequestTimeout bounds all the blobber calls of the list request, it fails with
+// ErrRequestTimeout once the timeout expires.
+//   - timeout: the timeout of the whole request
+func WithListRequestTimeout(timeout time.Duration) ListRequestOptions {
+	return func(req *ListRequest) {
+		req.timeout = timeout
+	}
+}
+
+// WithListRequestContext sets the context of the list request, so it can be canceled or given a deadline.
+//   - ctx: the context of the request
+func WithListRequestContext(ctx context.Context) ListRequestOptions {
+	return func(req *ListRequest) {
+		req.ctx = ctx
+	}
+}
+
+// WithListRequestRawArchives lists the archive containers of the directory as regular files instead of
+// the files packed in them, see UploadArchive.
+//   - raw: list the containers or not
+func WithListRequestRawArchives(raw bool) ListRequestOptions {
+	return func(req *ListRequest) {
+		req.rawArchives = raw
+	}
+}
+
+// WithListRequestConsensus overrides the number of blobbers that must agree on the listing, e.g.
+// ConsensusPolicy{Threshold: 1} to accept the first valid response.
+//   - policy: the consensus policy of the request
+func WithListRequestConsensus(policy ConsensusPolicy) ListRequestOptions {
+	return func(req *ListRequest) {
+		req.consensusPolicy = policy
+	}
+}
+
 func (req *ListRequest) getListInfoFromBlobber(blobber *blockchain.StorageNode, blobberIdx int, rspCh chan<- *listResponse) {
 	//body := new(bytes.Buffer)
 	//formWriter := multipart.NewWriter(body)
@@ -104,6 +150,10 @@ func (req *ListRequest) getListInfoFromBlobber(blobber *blockchain.StorageNode,
 		rspCh <- &listResponse{ref: ref, responseStr: s.String(), blobberIdx: blobberIdx, err: err}
 	}
 	defer listRetFn()
+	if !req.selection.selects(blobber.ID) {
+		err = errBlobberNotSelected
+		return
+	}
 
 	if len(req.remotefilepath) > 0 {
 		req.remotefilepathhash = fileref.GetReferenceLookup(req.allocationID, req.remotefilepath)
@@ -171,13 +221,12 @@ func (req *ListRequest) getlistFromBlobbers() ([]*listResponse, error) {
 	listInfos := make([]*listResponse, numList)
 	consensusMap := make(map[string][]*blockchain.StorageNode)
 	var consensusHash string
-	errCnt := 0
 	for i := 0; i < numList; i++ {
 		listInfos[i] = <-rspCh
 		if !req.forRepair {
 			if listInfos[i].err != nil || listInfos[i].ref == nil {
 				if listInfos[i].err != nil {
-					errCnt++
+					req.Reject(uint64(listInfos[i].blobberIdx), listInfos[i].err)
 				}
 				continue
 			}
@@ -196,11 +245,16 @@ func (req *ListRequest) getlistFromBlobbers() ([]*listResponse, error) {
 	var err error
 	listLen := len(consensusMap[consensusHash])
 	if listLen < req.consensusThresh {
-		if req.fullconsensus-errCnt >= req.consensusThresh && !req.listOnly {
+		if req.isConsensusReachable() && !req.listOnly {
 			req.listOnly = true
 			return req.getlistFromBlobbers()
 		}
-		return listInfos, listInfos[0].err
+		req.setConsensus(listLen)
+		if listInfos[0].err != nil {
+			// the error of the blobber stays on top, for the callers matching it
+			return listInfos, errors.Wrap(req.consensusError("List"), listInfos[0].err)
+		}
+		return listInfos, req.consensusError("List")
 	}
 	req.listOnly = true
 	listInfos = listInfos[:1]
@@ -226,7 +280,7 @@ func (req *ListRequest) GetListFromBlobbers() (*ListResult, error) {
 		return nil, err
 	}
 	result := &ListResult{
-		deleteMask: zboxutil.NewUint128(1).Lsh(uint64(len(req.blobbers))).Sub64(1),
+		deleteMask: req.selection.mask(req.blobbers),
 	}
 	selected := make(map[string]*ListResult)
 	childResultMap := make(map[string]*ListResult)
@@ -322,6 +376,7 @@ func (lr *ListResult) populateChildren(children []fileref.RefEntity, childResult
 			childResult.ThumbnailSize = (child.(*fileref.FileRef)).ThumbnailSize
 			childResult.ActualThumbnailHash = (child.(*fileref.FileRef)).ActualThumbnailHash
 			childResult.ActualThumbnailSize = (child.(*fileref.FileRef)).ActualThumbnailSize
+			childResult.CustomMeta = (child.(*fileref.FileRef)).CustomMeta
 		} else {
 			childResult.ActualSize = (child.(*fileref.Ref)).ActualSize
 		}
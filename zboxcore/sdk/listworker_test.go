@@ -12,6 +12,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/zcncrypto"
@@ -312,3 +313,22 @@ func TestListRequest_GetListFromBlobbers(t *testing.T) {
 		})
 	}
 }
+
+func TestListRequest_Timeout(t *testing.T) {
+	var req ListRequest
+	WithListRequestTimeout(time.Millisecond)(&req)
+	require.Equal(t, time.Millisecond, req.timeout)
+
+	ctx, cancel := withTimeout(context.Background(), 0)
+	defer cancel()
+	require.NoError(t, ctx.Err())
+	require.Equal(t, errors.New("", "blobber error"), timeoutError(ctx, errors.New("", "blobber error")))
+
+	ctx, cancel = withTimeout(context.Background(), req.timeout)
+	defer cancel()
+	<-ctx.Done()
+	require.Equal(t, ErrRequestTimeout, timeoutError(ctx, errors.New("", "blobber error")))
+	require.NoError(t, timeoutError(ctx, nil))
+
+	require.Equal(t, time.Second, multiOperationTimeout([]MultiOperationOption{WithMultiOperationTimeout(time.Second)}))
+}
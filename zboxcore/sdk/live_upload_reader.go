@@ -143,7 +143,7 @@ func (r *liveUploadReaderBase) GetFileContentType() (string, error) {
 		}
 
 		currentClips := r.GetClipsFile(r.clipsIndex)
-		reader, err := os.Open(currentClips)
+		reader, err := sys.Files.Open(currentClips)
 
 		if err == nil {
 			defer reader.Close()
@@ -0,0 +1,163 @@
+package sdk
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// ManifestEntry is a file or a directory of an allocation manifest.
+type ManifestEntry struct {
+	Path       string           `json:"path"`
+	Type       string           `json:"type"`
+	Size       int64            `json:"size"`
+	Hash       string           `json:"hash"`
+	LookupHash string           `json:"lookup_hash"`
+	MimeType   string           `json:"mimetype,omitempty"`
+	UpdatedAt  common.Timestamp `json:"updated_at"`
+}
+
+// AllocationManifest is a signed snapshot of the files of an allocation, to audit its contents later,
+// e.g. for compliance or after a migration.
+type AllocationManifest struct {
+	AllocationID string           `json:"allocation_id"`
+	CreatedAt    common.Timestamp `json:"created_at"`
+	Files        []ManifestEntry  `json:"files"`
+	// ClientID and PublicKey are the ones of the client who signed the manifest.
+	ClientID  string `json:"client_id"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+// Hash returns the hash of the manifest, its signature excluded.
+func (m *AllocationManifest) Hash() (string, error) {
+	unsigned := *m
+	unsigned.Signature = ""
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return "", err
+	}
+	return encryption.Hash(data), nil
+}
+
+// Verify verifies the signature of the manifest with its public key.
+func (m *AllocationManifest) Verify() error {
+	hash, err := m.Hash()
+	if err != nil {
+		return errors.Wrap(err, "failed to hash manifest")
+	}
+	ok, err := client.VerifySignatureWith(m.PublicKey, m.Signature, hash)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify manifest signature")
+	}
+	if !ok {
+		return errors.New("invalid_signature", "invalid manifest signature")
+	}
+	return nil
+}
+
+// ManifestReport is the result of the audit of an allocation against a manifest, by path.
+type ManifestReport struct {
+	// Missing are the files and directories of the manifest not in the allocation.
+	Missing []string `json:"missing"`
+	// Added are the files and directories of the allocation not in the manifest.
+	Added []string `json:"added"`
+	// Changed are the files whose content or metadata changed since the manifest.
+	Changed []string `json:"changed"`
+}
+
+// OK returns true if the allocation matches the manifest.
+func (r *ManifestReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Added) == 0 && len(r.Changed) == 0
+}
+
+// ExportManifest lists all the files and directories of the allocation and returns the manifest of
+// their paths, sizes and hashes signed by the client.
+func (a *Allocation) ExportManifest() (*AllocationManifest, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	files, err := getAllocationFileMap(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list allocation")
+	}
+
+	m := &AllocationManifest{
+		AllocationID: a.ID,
+		CreatedAt:    common.Now(),
+		Files:        make([]ManifestEntry, 0, len(files)),
+		ClientID:     client.GetClientID(),
+		PublicKey:    client.GetClientPublicKey(),
+	}
+	for path, f := range files {
+		m.Files = append(m.Files, ManifestEntry{
+			Path:       path,
+			Type:       f.Type,
+			Size:       f.ActualSize,
+			Hash:       f.Hash,
+			LookupHash: f.LookupHash,
+			MimeType:   f.MimeType,
+			UpdatedAt:  f.UpdatedAt,
+		})
+	}
+	sort.Slice(m.Files, func(i, j int) bool {
+		return m.Files[i].Path < m.Files[j].Path
+	})
+
+	hash, err := m.Hash()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash manifest")
+	}
+	if m.Signature, err = client.Sign(hash); err != nil {
+		return nil, errors.Wrap(err, "failed to sign manifest")
+	}
+	return m, nil
+}
+
+// VerifyAgainstManifest verifies the signature of a manifest of the allocation and compares the files
+// and directories of the allocation with it.
+//   - manifest: the manifest, see ExportManifest.
+func (a *Allocation) VerifyAgainstManifest(manifest *AllocationManifest) (*ManifestReport, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if manifest.AllocationID != a.ID {
+		return nil, errors.New("invalid_manifest", "the manifest is the one of allocation "+manifest.AllocationID)
+	}
+	if err := manifest.Verify(); err != nil {
+		return nil, err
+	}
+	files, err := getAllocationFileMap(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list allocation")
+	}
+
+	expected := make(map[string]FileInfo, len(manifest.Files))
+	for _, e := range manifest.Files {
+		expected[e.Path] = FileInfo{
+			Type:       e.Type,
+			ActualSize: e.Size,
+			Hash:       e.Hash,
+			LookupHash: e.LookupHash,
+			MimeType:   e.MimeType,
+			UpdatedAt:  e.UpdatedAt,
+		}
+	}
+
+	report := &ManifestReport{}
+	for _, e := range diffFileMaps(a.ID, expected, files) {
+		switch e.Type {
+		case FileDeleted:
+			report.Missing = append(report.Missing, e.Path)
+		case FileAdded:
+			report.Added = append(report.Added, e.Path)
+		case FileUpdated:
+			report.Changed = append(report.Changed, e.Path)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationManifest(t *testing.T) {
+	listFiles := getAllocationFileMap
+	defer func() {
+		getAllocationFileMap = listFiles
+	}()
+
+	w, err := zcncrypto.NewSignatureScheme("bls0chain").GenerateKeys()
+	require.NoError(t, err)
+	walletJSON, err := json.Marshal(w)
+	require.NoError(t, err)
+	require.NoError(t, client.PopulateClient(string(walletJSON), "bls0chain"))
+
+	files := map[string]FileInfo{
+		"/docs":       {Type: fileref.DIRECTORY, Hash: "d1"},
+		"/docs/a.txt": {Type: fileref.FILE, Hash: "a1", ActualSize: 10, LookupHash: "la"},
+		"/b.txt":      {Type: fileref.FILE, Hash: "b1", ActualSize: 5, LookupHash: "lb"},
+	}
+	getAllocationFileMap = func(a *Allocation) (map[string]FileInfo, error) {
+		return files, nil
+	}
+
+	sdkInitialized = true
+	a := &Allocation{ID: "alloc", initialized: true}
+
+	m, err := a.ExportManifest()
+	require.NoError(t, err)
+	require.Equal(t, "alloc", m.AllocationID)
+	require.Equal(t, w.ClientID, m.ClientID)
+	require.Len(t, m.Files, 3)
+	require.Equal(t, "/b.txt", m.Files[0].Path)
+	require.Equal(t, int64(10), m.Files[2].Size)
+	require.NoError(t, m.Verify())
+
+	// the manifest survives a round trip through JSON
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	var imported AllocationManifest
+	require.NoError(t, json.Unmarshal(data, &imported))
+
+	report, err := a.VerifyAgainstManifest(&imported)
+	require.NoError(t, err)
+	require.True(t, report.OK())
+
+	files = map[string]FileInfo{
+		"/docs":       {Type: fileref.DIRECTORY, Hash: "d2"},
+		"/docs/a.txt": {Type: fileref.FILE, Hash: "a2", ActualSize: 12, LookupHash: "la"},
+		"/c.txt":      {Type: fileref.FILE, Hash: "c1", LookupHash: "lc"},
+	}
+	report, err = a.VerifyAgainstManifest(&imported)
+	require.NoError(t, err)
+	require.False(t, report.OK())
+	require.Equal(t, []string{"/b.txt"}, report.Missing)
+	require.Equal(t, []string{"/c.txt"}, report.Added)
+	require.Equal(t, []string{"/docs/a.txt"}, report.Changed)
+
+	imported.Files[0].Size++
+	_, err = a.VerifyAgainstManifest(&imported)
+	require.Error(t, err)
+
+	_, err = (&Allocation{ID: "other", initialized: true}).VerifyAgainstManifest(m)
+	require.Error(t, err)
+}
@@ -39,6 +39,7 @@ type MoveRequest struct {
 	ctxCncl        context.CancelFunc
 	moveMask       zboxutil.Uint128
 	maskMU         *sync.Mutex
+	progress       DirOperationProgress
 	connectionID   string
 	timestamp      int64
 	Consensus
@@ -229,14 +230,17 @@ func (req *MoveRequest) ProcessWithBlobbers() ([]fileref.RefEntity, error) {
 			opType:          constants.FileOperationMove,
 			subOpType:       constants.FileOperationMove,
 			mask:            req.moveMask,
+			progress:        req.progress,
 		}
 		err := subRequest.processSubDirectories()
 		if err != nil {
 			return nil, err
 		}
 		op := OperationRequest{
-			OperationType: constants.FileOperationDelete,
-			RemotePath:    req.remotefilepath,
+			OperationType:     constants.FileOperationDelete,
+			RemotePath:        req.remotefilepath,
+			Mask:              &req.moveMask,
+			skipRetentionLock: true,
 		}
 		err = req.allocationObj.DoMultiOperation([]OperationRequest{op})
 		if err != nil {
@@ -372,6 +376,7 @@ type MoveOperation struct {
 	ctxCncl        context.CancelFunc
 	moveMask       zboxutil.Uint128
 	maskMU         *sync.Mutex
+	progress       DirOperationProgress
 	consensus      Consensus
 }
 
@@ -389,6 +394,7 @@ func (mo *MoveOperation) Process(allocObj *Allocation, connectionID string) ([]f
 		moveMask:       mo.moveMask,
 		maskMU:         mo.maskMU,
 		destPath:       mo.destPath,
+		progress:       mo.progress,
 		Consensus:      Consensus{RWMutex: &sync.RWMutex{}},
 	}
 	mR.Consensus.fullconsensus = mo.consensus.fullconsensus
@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0chain/errors"
@@ -41,6 +42,38 @@ func WithRepair(latestVersion int64, repairOffsetPath string) MultiOperationOpti
 	}
 }
 
+// WithMultiOperationTimeout bounds all the blobber calls of the operations, they fail with
+// ErrRequestTimeout once the timeout expires.
+//   - timeout: the timeout of all the operations
+func WithMultiOperationTimeout(timeout time.Duration) MultiOperationOption {
+	return func(mo *MultiOperation) {
+		mo.timeout = timeout
+	}
+}
+
+// DirOperationProgress is called as the children of a directory are renamed, copied or moved.
+//   - remotePath: the path of the directory.
+//   - processed: the number of files and directories processed so far.
+type DirOperationProgress func(remotePath string, processed int)
+
+// WithDirOperationProgress reports the progress of the renames, copies and moves of directories,
+// the children of large directories being processed by pages.
+//   - progress: the progress callback.
+func WithDirOperationProgress(progress DirOperationProgress) MultiOperationOption {
+	return func(mo *MultiOperation) {
+		mo.dirProgress = progress
+	}
+}
+
+// multiOperationTimeout returns the timeout set by the options.
+func multiOperationTimeout(opts []MultiOperationOption) time.Duration {
+	var mo MultiOperation
+	for _, opt := range opts {
+		opt(&mo)
+	}
+	return mo.timeout
+}
+
 type Operationer interface {
 	Process(allocObj *Allocation, connectionID string) ([]fileref.RefEntity, zboxutil.Uint128, error)
 	buildChange(refs []fileref.RefEntity, uid uuid.UUID) []allocationchange.AllocationChange
@@ -62,25 +95,33 @@ type MultiOperation struct {
 	isRepair      bool
 	repairVersion int64
 	repairOffset  string
+	// timeout bounds the operations, see WithMultiOperationTimeout.
+	timeout time.Duration
+	// dirProgress reports the progress of the directory operations, see WithDirOperationProgress.
+	dirProgress DirOperationProgress
+	// result records the outcome of the operations on each blobber, see WithOperationResult.
+	result *OperationResult
 }
 
 func (mo *MultiOperation) createConnectionObj(blobberIdx int) (err error) {
+	var (
+		resp           *http.Response
+		shouldContinue bool
+		latestRespMsg  string
+
+		latestStatusCode int
+	)
 
 	defer func() {
 		if err == nil {
 			mo.maskMU.Lock()
 			mo.operationMask = mo.operationMask.Or(zboxutil.NewUint128(1).Lsh(uint64(blobberIdx)))
 			mo.maskMU.Unlock()
+			mo.result.setOutcome(blobberIdx, OperationStageConnection, latestStatusCode, "")
+		} else {
+			mo.result.setOutcome(blobberIdx, OperationStageConnection, latestStatusCode, err.Error())
 		}
 	}()
-
-	var (
-		resp           *http.Response
-		shouldContinue bool
-		latestRespMsg  string
-
-		latestStatusCode int
-	)
 	blobber := mo.allocationObj.Blobbers[blobberIdx]
 
 	for i := 0; i < 3; i++ {
@@ -171,6 +212,7 @@ func (mo *MultiOperation) Process() error {
 	swg := sizedwaitgroup.New(BatchSize)
 	errsSlice := make([]error, len(mo.operations))
 	var changeCount int
+	connectedMask := mo.operationMask
 	for idx, op := range mo.operations {
 		swg.Add()
 		go func(op Operationer, idx int) {
@@ -188,6 +230,9 @@ func (mo *MultiOperation) Process() error {
 				if err != errFileDeleted && err != errNoChange {
 					l.Logger.Error(err)
 					errsSlice[idx] = errors.New("", err.Error())
+					mo.maskMU.Lock()
+					mo.result.setRejections(err)
+					mo.maskMU.Unlock()
 					ctxCncl(err)
 				}
 				return
@@ -202,9 +247,13 @@ func (mo *MultiOperation) Process() error {
 
 	if ctx.Err() != nil {
 		err := context.Cause(ctx)
+		mo.result.setConsensus(0, mo.consensusThresh)
 		return err
 	}
 
+	mo.result.setFailed(connectedMask, mo.operationMask, OperationStageOperation, "operation failed")
+	mo.result.setSucceeded(mo.operationMask, OperationStageOperation)
+	mo.result.setConsensus(mo.operationMask.CountOnes(), mo.consensusThresh)
 	// Check consensus
 	if mo.operationMask.CountOnes() < mo.consensusThresh {
 		majorErr := zboxutil.MajorError(errsSlice)
@@ -283,15 +332,18 @@ func (mo *MultiOperation) Process() error {
 	mo.Consensus.Reset()
 	var pos uint64
 	if !mo.isRepair {
+		activeMask := mo.operationMask
 		for i := mo.operationMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
 			pos = uint64(i.TrailingZeros())
-			if mo.allocationObj.Blobbers[pos].AllocationVersion != mo.allocationObj.allocationVersion {
+			if mo.allocationObj.Blobbers[pos].AllocationVersion != mo.allocationObj.getAllocationVersion() {
 				mo.operationMask = mo.operationMask.And(zboxutil.NewUint128(1).Lsh(pos).Not())
 			}
 		}
+		mo.result.setFailed(activeMask, mo.operationMask, OperationStageCommit, "outdated allocation version")
 	}
 	activeBlobbers := mo.operationMask.CountOnes()
 	if activeBlobbers < mo.consensusThresh {
+		mo.result.setConsensus(activeBlobbers, mo.consensusThresh)
 		return errors.New("consensus_not_met", "Active blobbers less than consensus threshold")
 	}
 	commitReqs := make([]*CommitRequest, activeBlobbers)
@@ -300,6 +352,9 @@ func (mo *MultiOperation) Process() error {
 
 	var counter = 0
 	timestamp := int64(common.Now())
+	if mo.result != nil {
+		mo.result.Timestamp = timestamp
+	}
 	for i := mo.operationMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
 		pos = uint64(i.TrailingZeros())
 		commitReq := &CommitRequest{
@@ -320,6 +375,7 @@ func (mo *MultiOperation) Process() error {
 			commitReq.repairOffset = mo.repairOffset
 		}
 		commitReqs[counter] = commitReq
+		trackCommit(commitReq)
 		l.Logger.Debug("Commit request sending to blobber ", commitReq.blobber.Baseurl)
 		go AddCommitRequest(commitReq)
 		counter++
@@ -329,6 +385,8 @@ func (mo *MultiOperation) Process() error {
 	rollbackMask := zboxutil.NewUint128(0)
 	errSlice := make([]error, len(commitReqs))
 	for idx, commitReq := range commitReqs {
+		untrackCommit(commitReq)
+		mo.result.setCommitOutcome(commitReq)
 		if commitReq.result != nil {
 			if commitReq.result.Success {
 				l.Logger.Debug("Commit success", commitReq.blobber.Baseurl)
@@ -345,6 +403,7 @@ func (mo *MultiOperation) Process() error {
 		}
 	}
 
+	mo.result.setConsensus(mo.getConsensus(), mo.consensusThresh)
 	if !mo.isConsensusOk() {
 		err = zboxutil.MajorError(errSlice)
 		if mo.getConsensus() != 0 {
@@ -359,14 +418,18 @@ func (mo *MultiOperation) Process() error {
 		for _, op := range mo.operations {
 			op.Completed(mo.allocationObj)
 		}
+		publishEvent(Event{Type: EventCommitDone, AllocationID: mo.allocationObj.ID})
+		if !mo.isRepair {
+			mo.allocationObj.retryFailedCommits(commitReqs)
+		}
 		if singleClientMode && !mo.isRepair {
 			for _, commitReq := range commitReqs {
 				if commitReq.result.Success {
 					mo.allocationObj.Blobbers[commitReq.blobberInd].AllocationVersion++
 				}
 			}
-			mo.allocationObj.allocationVersion += 1
-			logger.Logger.Info("Allocation version updated to ", mo.allocationObj.allocationVersion, " activeBlobbers ", activeBlobbers)
+			version := atomic.AddInt64(&mo.allocationObj.allocationVersion, 1)
+			logger.Logger.Info("Allocation version updated to ", version, " activeBlobbers ", activeBlobbers)
 		}
 	}
 
@@ -3,10 +3,13 @@ package sdk
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/0chain/gosdk/core/node"
@@ -26,25 +29,165 @@ type Network struct {
 	Sharders []string `json:"sharders"`
 }
 
+const (
+	defaultNetworkRefreshInterval = time.Hour
+	nodeHealthCheckTimeout        = 5 * time.Second
+
+	sharderHealthCheckEndpoint = "/v1/healthcheck"
+	minerHealthCheckEndpoint   = "/_nh/whoami"
+)
+
+var (
+	networkMu              sync.Mutex
+	networkRefreshInterval = defaultNetworkRefreshInterval
+	networkRefreshC        = make(chan struct{}, 1)
+	downNodes              = make(map[string]bool)
+)
+
+// SetNetworkRefreshInterval sets how often the network worker fetches the miners and sharders from the
+// block worker (0dns) and health-checks them. Defaults to one hour.
+//   - interval: the refresh interval
+func SetNetworkRefreshInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultNetworkRefreshInterval
+	}
+	networkMu.Lock()
+	networkRefreshInterval = interval
+	networkMu.Unlock()
+
+	// wake up the worker so the new interval applies right away
+	select {
+	case networkRefreshC <- struct{}{}:
+	default:
+	}
+}
+
+func getNetworkRefreshInterval() time.Duration {
+	networkMu.Lock()
+	defer networkMu.Unlock()
+	return networkRefreshInterval
+}
+
+// UpdateNetworkDetailsWorker refreshes the network details and health-checks the nodes at the interval
+// set with SetNetworkRefreshInterval, until the context is cancelled. Sharders that fail the health check
+// are moved to the end of the sharder list and miners that fail it are not used to submit transactions,
+// so the requests fail over to the healthy nodes.
 func UpdateNetworkDetailsWorker(ctx context.Context) {
-	ticker := time.NewTicker(time.Duration(networkWorkerTimerInHours) * time.Hour)
+	timer := time.NewTimer(getNetworkRefreshInterval())
+	defer timer.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			l.Logger.Info("Network stopped by user")
 			return
-		case <-ticker.C:
-			err := UpdateNetworkDetails()
-			if err != nil {
-				l.Logger.Error("Update network detail worker fail", zap.Error(err))
-				return
+		case <-networkRefreshC:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
 			}
+			timer.Reset(getNetworkRefreshInterval())
+			continue
+		case <-timer.C:
+		}
+
+		if err := UpdateNetworkDetails(); err != nil {
+			l.Logger.Error("Update network detail worker fail", zap.Error(err))
+		} else {
 			l.Logger.Info("Successfully updated network details")
-			return
 		}
+		CheckNetworkHealth(ctx)
+		timer.Reset(getNetworkRefreshInterval())
+	}
+}
+
+// CheckNetworkHealth health-checks the miners and sharders of the network. The sharder weights are
+// updated so the healthy sharders are queried first, transactions are submitted to healthy miners only,
+// and EventNodeDown or EventNodeUp is published when a node changes state.
+//   - ctx: the context of the health checks
+func CheckNetworkHealth(ctx context.Context) {
+	sharders := blockchain.GetAllSharders()
+	miners := blockchain.GetMiners()
+
+	sharderHealth := checkNodesHealth(ctx, sharders, sharderHealthCheckEndpoint)
+	minerHealth := checkNodesHealth(ctx, miners, minerHealthCheckEndpoint)
+
+	for _, sharder := range sharders {
+		if sharderHealth[sharder] {
+			blockchain.Sharders.Success(sharder)
+		} else {
+			blockchain.Sharders.Fail(sharder)
+		}
+	}
+
+	var healthyMiners []string
+	for _, miner := range miners {
+		if minerHealth[miner] {
+			healthyMiners = append(healthyMiners, miner)
+		}
+	}
+	blockchain.SetHealthyMiners(healthyMiners)
+
+	networkMu.Lock()
+	defer networkMu.Unlock()
+	for _, health := range []map[string]bool{sharderHealth, minerHealth} {
+		for url, healthy := range health {
+			if healthy == !downNodes[url] {
+				continue
+			}
+			ev := Event{Type: EventNodeUp, NodeURL: url}
+			if healthy {
+				delete(downNodes, url)
+			} else {
+				downNodes[url] = true
+				ev.Type = EventNodeDown
+			}
+			publishEvent(ev)
+		}
+	}
+}
+
+func checkNodesHealth(ctx context.Context, nodes []string, endpoint string) map[string]bool {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		health = make(map[string]bool, len(nodes))
+	)
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n string) {
+			defer wg.Done()
+			healthy := isNodeHealthy(ctx, n, endpoint)
+			mu.Lock()
+			health[n] = healthy
+			mu.Unlock()
+		}(n)
+	}
+	wg.Wait()
+	return health
+}
+
+func isNodeHealthy(ctx context.Context, url, endpoint string) bool {
+	ctx, cancel := context.WithTimeout(ctx, nodeHealthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(url, "/")+endpoint, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := zboxutil.Client.Do(req)
+	if err != nil {
+		l.Logger.Debug("node health check failed: ", url, " ", err)
+		return false
 	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode == http.StatusOK
 }
 
+// UpdateNetworkDetails fetches the miners and sharders from the block worker (0dns) and updates the
+// network details if they changed, publishing EventNetworkChanged.
 func UpdateNetworkDetails() error {
 	networkDetails, err := GetNetworkDetails()
 	if err != nil {
@@ -55,6 +198,11 @@ func UpdateNetworkDetails() error {
 	shouldUpdate := UpdateRequired(networkDetails)
 	if shouldUpdate {
 		forceUpdateNetworkDetails(networkDetails)
+		publishEvent(Event{
+			Type:     EventNetworkChanged,
+			Miners:   networkDetails.Miners,
+			Sharders: networkDetails.Sharders,
+		})
 	}
 	return nil
 }
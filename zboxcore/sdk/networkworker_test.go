@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckNetworkHealth(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+
+	var down int32 = 1
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer flaky.Close()
+
+	blockchain.SetSharders([]string{flaky.URL, healthy.URL})
+	// the miners are keyed with a trailing slash to tell them apart from the sharders
+	flakyMiner, healthyMiner := flaky.URL+"/", healthy.URL+"/"
+	blockchain.SetMiners([]string{flakyMiner, healthyMiner})
+
+	sub := SubscribeEvents(0, EventNodeDown, EventNodeUp)
+	defer sub.Unsubscribe()
+
+	CheckNetworkHealth(context.Background())
+	require.Equal(t, healthy.URL, blockchain.GetAllSharders()[0])
+	require.Equal(t, []string{healthyMiner}, blockchain.GetStableMiners())
+	requireNodeEvents(t, sub, EventNodeDown, flaky.URL, flakyMiner)
+
+	// no event while the state of the nodes doesn't change
+	CheckNetworkHealth(context.Background())
+	require.Len(t, sub.Events(), 0)
+
+	atomic.StoreInt32(&down, 0)
+	CheckNetworkHealth(context.Background())
+	requireNodeEvents(t, sub, EventNodeUp, flaky.URL, flakyMiner)
+}
+
+func requireNodeEvents(t *testing.T, sub *EventSubscription, typ EventType, urls ...string) {
+	var got []string
+	for range urls {
+		select {
+		case e := <-sub.Events():
+			require.Equal(t, typ, e.Type)
+			got = append(got, e.NodeURL)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("missing %s event", typ)
+		}
+	}
+	require.ElementsMatch(t, urls, got)
+}
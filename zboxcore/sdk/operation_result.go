@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// The stages of an operation on a blobber, see BlobberOutcome.
+const (
+	OperationStageConnection = "connection"
+	OperationStageOperation  = "operation"
+	OperationStageCommit     = "commit"
+)
+
+// BlobberOutcome is the outcome of the operations on a blobber, see OperationResult.
+type BlobberOutcome struct {
+	BlobberID string `json:"blobber_id"`
+	Baseurl   string `json:"url"`
+	Success   bool   `json:"success"`
+	// Stage is the last stage reached by the blobber, e.g. OperationStageCommit. The blobber failed
+	// at this stage unless Success is true.
+	Stage string `json:"stage"`
+	// StatusCode is the http status of the last response of the blobber, 0 if unknown.
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// Version is the allocation version of the version marker committed to the blobber.
+	Version int64 `json:"version,omitempty"`
+}
+
+// OperationResult describes the outcome of the operations on each blobber, so the operators can
+// diagnose which blobber is causing the failures, see DoMultiOperationWithResult. The operations
+// being committed by batches, it describes the last batch.
+type OperationResult struct {
+	// Blobbers are the outcomes by blobber, in the order of the blobbers of the allocation.
+	Blobbers []*BlobberOutcome `json:"blobbers"`
+	// Consensus is the number of blobbers agreeing on the last stage reached.
+	Consensus int `json:"consensus"`
+	// Required is the number of blobbers required to agree.
+	Required     int  `json:"required"`
+	ConsensusMet bool `json:"consensus_met"`
+	// Timestamp is the timestamp of the version markers committed, 0 if nothing was committed.
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+// WithOperationResult records the outcome of the operations on each blobber to result.
+//   - result: the result to fill, it's reset for every batch of operations.
+func WithOperationResult(result *OperationResult) MultiOperationOption {
+	return func(mo *MultiOperation) {
+		mo.result = result
+	}
+}
+
+// DoMultiOperationWithResult performs multiple operations on the allocation as DoMultiOperation and
+// returns the outcome of the operations on each blobber, e.g. for the deletes, renames and copies.
+// The result is returned even if the operations failed, nil if they failed before any blobber was
+// contacted.
+//   - operations: the operations to perform.
+//   - opts: the options of the multi operation as operation functions that customize the multi operation.
+func (a *Allocation) DoMultiOperationWithResult(operations []OperationRequest, opts ...MultiOperationOption) (*OperationResult, error) {
+	result := &OperationResult{}
+	err := a.DoMultiOperation(operations, append(opts, WithOperationResult(result))...)
+	if result.Blobbers == nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// reset starts recording the outcomes of a new batch of operations.
+func (r *OperationResult) reset(blobbers []*blockchain.StorageNode) {
+	if r == nil {
+		return
+	}
+	*r = OperationResult{Blobbers: make([]*BlobberOutcome, len(blobbers))}
+	for i, b := range blobbers {
+		r.Blobbers[i] = &BlobberOutcome{BlobberID: b.ID, Baseurl: b.Baseurl, Stage: OperationStageConnection}
+	}
+}
+
+// setOutcome records the outcome of a stage on a blobber, it succeeded if errMsg is empty.
+func (r *OperationResult) setOutcome(pos int, stage string, statusCode int, errMsg string) {
+	if r == nil || pos >= len(r.Blobbers) {
+		return
+	}
+	o := r.Blobbers[pos]
+	o.Stage = stage
+	o.StatusCode = statusCode
+	o.Success = errMsg == ""
+	o.Error = errMsg
+}
+
+// setRejections records the errors of the blobbers rejecting an operation, if err is a ConsensusError.
+func (r *OperationResult) setRejections(err error) {
+	if r == nil {
+		return
+	}
+	var ce *ConsensusError
+	if !errors.As(err, &ce) {
+		return
+	}
+	for pos, msg := range ce.Rejections {
+		r.setOutcome(int(pos), OperationStageOperation, 0, msg)
+	}
+}
+
+// setFailed records the blobbers of before missing from after failed at a stage, keeping their
+// error if it's already known.
+func (r *OperationResult) setFailed(before, after zboxutil.Uint128, stage, msg string) {
+	if r == nil {
+		return
+	}
+	var pos uint64
+	for i := before.And(after.Not()); !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
+		pos = uint64(i.TrailingZeros())
+		if int(pos) >= len(r.Blobbers) {
+			continue
+		}
+		if o := r.Blobbers[pos]; o.Stage == stage && o.Error != "" {
+			continue
+		}
+		r.setOutcome(int(pos), stage, 0, msg)
+	}
+}
+
+// setSucceeded records the blobbers of mask succeeded at a stage.
+func (r *OperationResult) setSucceeded(mask zboxutil.Uint128, stage string) {
+	if r == nil {
+		return
+	}
+	var pos uint64
+	for i := mask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
+		pos = uint64(i.TrailingZeros())
+		if int(pos) < len(r.Blobbers) {
+			r.setOutcome(int(pos), stage, r.Blobbers[pos].StatusCode, "")
+		}
+	}
+}
+
+// setCommitOutcome records the outcome of the commit of the version marker to a blobber.
+func (r *OperationResult) setCommitOutcome(req *CommitRequest) {
+	if r == nil || int(req.blobberInd) >= len(r.Blobbers) {
+		return
+	}
+	r.Blobbers[req.blobberInd].Version = req.version
+	switch {
+	case req.result == nil:
+		r.setOutcome(int(req.blobberInd), OperationStageCommit, 0, "commit result not set")
+	case req.result.Success:
+		r.setOutcome(int(req.blobberInd), OperationStageCommit, req.result.StatusCode, "")
+	case req.result.ErrorMessage == "":
+		r.setOutcome(int(req.blobberInd), OperationStageCommit, req.result.StatusCode, "commit failed")
+	default:
+		r.setOutcome(int(req.blobberInd), OperationStageCommit, req.result.StatusCode, req.result.ErrorMessage)
+	}
+}
+
+// setConsensus records the consensus of the last stage reached.
+func (r *OperationResult) setConsensus(got, required int) {
+	if r == nil {
+		return
+	}
+	r.Consensus = got
+	r.Required = required
+	r.ConsensusMet = got >= required
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationResult(t *testing.T) {
+	blobbers := []*blockchain.StorageNode{{ID: "b0"}, {ID: "b1"}, {ID: "b2"}}
+	r := &OperationResult{}
+	r.reset(blobbers)
+	require.Len(t, r.Blobbers, 3)
+	require.Equal(t, "b1", r.Blobbers[1].BlobberID)
+
+	r.setOutcome(0, OperationStageConnection, http.StatusOK, "")
+	r.setOutcome(1, OperationStageConnection, http.StatusOK, "")
+	r.setOutcome(2, OperationStageConnection, http.StatusServiceUnavailable, "response_error: unavailable")
+	connected := zboxutil.NewUint128(3)
+
+	// the rejections of the blobbers are kept, the other failures are generic.
+	r.setRejections(&ConsensusError{Rejections: map[uint64]string{1: "file not found"}})
+	r.setFailed(connected, zboxutil.NewUint128(0), OperationStageOperation, "operation failed")
+	require.Equal(t, "file not found", r.Blobbers[1].Error)
+	require.Equal(t, "operation failed", r.Blobbers[0].Error)
+	require.Equal(t, OperationStageConnection, r.Blobbers[2].Stage)
+	require.Equal(t, http.StatusServiceUnavailable, r.Blobbers[2].StatusCode)
+
+	r.setSucceeded(connected, OperationStageOperation)
+	r.setCommitOutcome(&CommitRequest{blobberInd: 0, version: 5, result: &CommitResult{Success: true, StatusCode: http.StatusOK}})
+	r.setCommitOutcome(&CommitRequest{blobberInd: 1, version: 5, result: &CommitResult{ErrorMessage: "commit_error", StatusCode: http.StatusBadRequest}})
+	r.setConsensus(1, 2)
+	require.True(t, r.Blobbers[0].Success)
+	require.EqualValues(t, 5, r.Blobbers[0].Version)
+	require.False(t, r.Blobbers[1].Success)
+	require.Equal(t, OperationStageCommit, r.Blobbers[1].Stage)
+	require.Equal(t, http.StatusBadRequest, r.Blobbers[1].StatusCode)
+	require.False(t, r.ConsensusMet)
+
+	// recording without a result is a no-op.
+	var none *OperationResult
+	none.reset(blobbers)
+	none.setOutcome(0, OperationStageCommit, 0, "")
+	none.setConsensus(1, 2)
+}
+
+func TestAllocation_DoMultiOperationWithResult(t *testing.T) {
+	a := &Allocation{}
+	result, err := a.DoMultiOperationWithResult(nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	_, err = a.DoMultiOperationWithResult([]OperationRequest{{RemotePath: "/a.txt"}})
+	require.ErrorIs(t, err, notInitialized)
+}
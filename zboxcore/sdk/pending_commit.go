@@ -0,0 +1,346 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/client"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// PendingWriteMarker is the write marker of a connection sent to a blobber but not committed yet,
+// e.g. the app crashed between the upload and the commit or the commit failed on the blobber.
+type PendingWriteMarker struct {
+	ConnectionID  string `json:"connection_id"`
+	AllocationID  string `json:"allocation_id"`
+	AllocationTx  string `json:"allocation_tx"`
+	BlobberID     string `json:"blobber_id"`
+	BlobberURL    string `json:"blobber_url"`
+	Version       int64  `json:"version"`
+	Timestamp     int64  `json:"timestamp"`
+	IsRepair      bool   `json:"is_repair,omitempty"`
+	RepairVersion int64  `json:"repair_version,omitempty"`
+	RepairOffset  string `json:"repair_offset,omitempty"`
+	// Error is the error of the last commit, empty if the commit is in progress.
+	Error string `json:"error,omitempty"`
+}
+
+// PendingCommitStore stores the pending write markers. Implementations must be safe for concurrent use.
+type PendingCommitStore interface {
+	// Save creates or replaces the pending write marker of a connection on a blobber.
+	Save(pm PendingWriteMarker) error
+	// Remove removes the pending write marker of a connection on a blobber once it's committed.
+	Remove(connectionID, blobberID string) error
+	// List returns the pending write markers of an allocation.
+	List(allocationID string) ([]PendingWriteMarker, error)
+}
+
+var (
+	pendingCommitMu    sync.RWMutex
+	pendingCommitStore PendingCommitStore = NewMemPendingCommitStore()
+)
+
+// SetPendingCommitStore sets the store of the pending write markers, they are kept in memory by
+// default. Set a FsPendingCommitStore to recover the commits after a crash of the app.
+//   - store: the store of the pending write markers
+func SetPendingCommitStore(store PendingCommitStore) {
+	if store == nil {
+		store = NewMemPendingCommitStore()
+	}
+	pendingCommitMu.Lock()
+	defer pendingCommitMu.Unlock()
+	pendingCommitStore = store
+}
+
+func getPendingCommitStore() PendingCommitStore {
+	pendingCommitMu.RLock()
+	defer pendingCommitMu.RUnlock()
+	return pendingCommitStore
+}
+
+func pendingWriteMarkerOf(req *CommitRequest) PendingWriteMarker {
+	return PendingWriteMarker{
+		ConnectionID:  req.connectionID,
+		AllocationID:  req.allocationID,
+		AllocationTx:  req.allocationTx,
+		BlobberID:     req.blobber.ID,
+		BlobberURL:    req.blobber.Baseurl,
+		Version:       req.version,
+		Timestamp:     req.timestamp,
+		IsRepair:      req.isRepair,
+		RepairVersion: req.repairVersion,
+		RepairOffset:  req.repairOffset,
+	}
+}
+
+// trackCommit records the commit request as pending until it succeeds.
+func trackCommit(req *CommitRequest) {
+	if err := getPendingCommitStore().Save(pendingWriteMarkerOf(req)); err != nil {
+		l.Logger.Error("save pending write marker: ", err)
+	}
+}
+
+// untrackCommit removes the pending write marker of a successful commit, or records the error of
+// a failed one.
+func untrackCommit(req *CommitRequest) {
+	store := getPendingCommitStore()
+	var err error
+	if req.result != nil && req.result.Success {
+		err = store.Remove(req.connectionID, req.blobber.ID)
+	} else {
+		pm := pendingWriteMarkerOf(req)
+		pm.Error = "commit result not set"
+		if req.result != nil {
+			pm.Error = req.result.ErrorMessage
+		}
+		err = store.Save(pm)
+	}
+	if err != nil {
+		l.Logger.Error("update pending write marker: ", err)
+	}
+}
+
+// GetPendingWriteMarkers returns the write markers of the allocation sent to the blobbers but not committed.
+func (a *Allocation) GetPendingWriteMarkers() ([]PendingWriteMarker, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	return getPendingCommitStore().List(a.ID)
+}
+
+// ResendWriteMarker commits again the pending write markers of a connection, so the data uploaded
+// to the blobbers before a crash or a failed commit isn't orphaned.
+//   - connectionID: the connection of the pending write markers, see GetPendingWriteMarkers
+func (a *Allocation) ResendWriteMarker(connectionID string) error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+	pending, err := getPendingCommitStore().List(a.ID)
+	if err != nil {
+		return err
+	}
+
+	var commitReqs []*CommitRequest
+	mask := zboxutil.NewUint128(0)
+	wg := &sync.WaitGroup{}
+	for _, pm := range pending {
+		if pm.ConnectionID != connectionID {
+			continue
+		}
+		pos, blobber := a.blobberByID(pm.BlobberID)
+		if blobber == nil {
+			return errors.New("blobber_not_found", "blobber "+pm.BlobberID+" is not in the allocation")
+		}
+		mask = mask.Or(zboxutil.NewUint128(1).Lsh(uint64(pos)))
+		commitReqs = append(commitReqs, &CommitRequest{
+			allocationID:  a.ID,
+			allocationTx:  a.Tx,
+			sig:           a.sig,
+			blobber:       blobber,
+			connectionID:  connectionID,
+			wg:            wg,
+			timestamp:     pm.Timestamp,
+			blobberInd:    uint64(pos),
+			version:       pm.Version,
+			isRepair:      pm.IsRepair,
+			repairVersion: pm.RepairVersion,
+			repairOffset:  pm.RepairOffset,
+		})
+	}
+	if len(commitReqs) == 0 {
+		return errors.New("no_pending_write_marker", "no pending write marker for connection "+connectionID)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if singleClientMode {
+		a.commitMutex.Lock()
+		defer a.commitMutex.Unlock()
+	} else {
+		writeMarkerMutex, err := CreateWriteMarkerMutex(client.GetClient(), a)
+		if err != nil {
+			return err
+		}
+		cons := &Consensus{RWMutex: &sync.RWMutex{}, consensusThresh: len(commitReqs), fullconsensus: len(commitReqs)}
+		err = writeMarkerMutex.Lock(ctx, &mask, &sync.Mutex{}, a.Blobbers, cons, 0, time.Minute, connectionID)
+		if err != nil {
+			return err
+		}
+		defer writeMarkerMutex.Unlock(ctx, mask, a.Blobbers, time.Minute, connectionID) //nolint: errcheck
+	}
+
+	wg.Add(len(commitReqs))
+	for _, req := range commitReqs {
+		go AddCommitRequest(req)
+	}
+	wg.Wait()
+
+	errSlice := make([]error, 0, len(commitReqs))
+	for _, req := range commitReqs {
+		untrackCommit(req)
+		if req.result == nil || !req.result.Success {
+			msg := "commit result not set"
+			if req.result != nil {
+				msg = req.result.ErrorMessage
+			}
+			errSlice = append(errSlice, errors.New("commit_failed", req.blobber.Baseurl+": "+msg))
+		}
+	}
+	if len(errSlice) > 0 {
+		return zboxutil.MajorError(errSlice)
+	}
+	return nil
+}
+
+func (a *Allocation) blobberByID(id string) (int, *blockchain.StorageNode) {
+	for i, b := range a.Blobbers {
+		if b.ID == id {
+			return i, b
+		}
+	}
+	return -1, nil
+}
+
+// MemPendingCommitStore is a PendingCommitStore in memory, the default one.
+type MemPendingCommitStore struct {
+	mu      sync.Mutex
+	markers map[string]PendingWriteMarker
+}
+
+// NewMemPendingCommitStore creates a PendingCommitStore in memory.
+func NewMemPendingCommitStore() *MemPendingCommitStore {
+	return &MemPendingCommitStore{markers: make(map[string]PendingWriteMarker)}
+}
+
+func (s *MemPendingCommitStore) Save(pm PendingWriteMarker) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markers[pendingWriteMarkerKey(pm.ConnectionID, pm.BlobberID)] = pm
+	return nil
+}
+
+func (s *MemPendingCommitStore) Remove(connectionID, blobberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.markers, pendingWriteMarkerKey(connectionID, blobberID))
+	return nil
+}
+
+func (s *MemPendingCommitStore) List(allocationID string) ([]PendingWriteMarker, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var list []PendingWriteMarker
+	for _, pm := range s.markers {
+		if pm.AllocationID == allocationID {
+			list = append(list, pm)
+		}
+	}
+	return list, nil
+}
+
+// pendingCommitsFile is the file of the pending write markers of a FsPendingCommitStore.
+const pendingCommitsFile = "pending_commits.json"
+
+// FsPendingCommitStore is a PendingCommitStore storing the pending write markers in a json file of a
+// directory, so they survive a crash of the app. The file is only readable by the owner, and stored with
+// the progress files of the file system, e.g. in the local storage of the browser with sys.MemFS.
+type FsPendingCommitStore struct {
+	mu      sync.Mutex
+	fs      sys.FS
+	path    string
+	markers map[string]PendingWriteMarker
+}
+
+// NewFsPendingCommitStore creates a pending commit store in the directory, it's created if missing.
+// The pending write markers already stored in the directory are loaded.
+//   - fs: the file system of the directory, sys.Files if nil.
+//   - dir: the directory of the pending write markers, e.g. ~/.zcn/commits
+func NewFsPendingCommitStore(fs sys.FS, dir string) (*FsPendingCommitStore, error) {
+	if fs == nil {
+		fs = sys.Files
+	}
+	if err := fs.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "create pending commit store")
+	}
+	s := &FsPendingCommitStore{
+		fs:      fs,
+		path:    filepath.Join(dir, pendingCommitsFile),
+		markers: make(map[string]PendingWriteMarker),
+	}
+	s.load()
+	return s, nil
+}
+
+// load loads the pending write markers, from the temporary file if the app crashed while writing the file.
+func (s *FsPendingCommitStore) load() {
+	for _, p := range []string{s.path, s.path + ".tmp"} {
+		buf, err := s.fs.LoadProgress(p)
+		if err != nil {
+			continue
+		}
+		var markers map[string]PendingWriteMarker
+		if err = json.Unmarshal(buf, &markers); err != nil {
+			l.Logger.Error("invalid pending write markers ", p, ": ", err)
+			continue
+		}
+		if markers != nil {
+			s.markers = markers
+		}
+		return
+	}
+}
+
+// save writes the pending write markers to the temporary file then to the file, so one of them is
+// complete if the app crashes.
+func (s *FsPendingCommitStore) save() error {
+	buf, err := json.Marshal(s.markers)
+	if err != nil {
+		return err
+	}
+	if err = s.fs.SaveProgress(s.path+".tmp", buf, 0600); err != nil {
+		return err
+	}
+	return s.fs.SaveProgress(s.path, buf, 0600)
+}
+
+func (s *FsPendingCommitStore) Save(pm PendingWriteMarker) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markers[pendingWriteMarkerKey(pm.ConnectionID, pm.BlobberID)] = pm
+	return s.save()
+}
+
+func (s *FsPendingCommitStore) Remove(connectionID, blobberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := pendingWriteMarkerKey(connectionID, blobberID)
+	if _, ok := s.markers[key]; !ok {
+		return nil
+	}
+	delete(s.markers, key)
+	return s.save()
+}
+
+func (s *FsPendingCommitStore) List(allocationID string) ([]PendingWriteMarker, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var list []PendingWriteMarker
+	for _, pm := range s.markers {
+		if pm.AllocationID == allocationID {
+			list = append(list, pm)
+		}
+	}
+	return list, nil
+}
+
+func pendingWriteMarkerKey(connectionID, blobberID string) string {
+	return encryption.Hash(connectionID + ":" + blobberID)
+}
@@ -0,0 +1,163 @@
+package sdk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingCommitStore(t *testing.T) {
+	dir := t.TempDir()
+	fsStore, err := NewFsPendingCommitStore(nil, dir)
+	require.NoError(t, err)
+
+	for name, store := range map[string]PendingCommitStore{
+		"mem": NewMemPendingCommitStore(),
+		"fs":  fsStore,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Save(PendingWriteMarker{ConnectionID: "c1", AllocationID: "a1", BlobberID: "b1", Version: 2}))
+			require.NoError(t, store.Save(PendingWriteMarker{ConnectionID: "c1", AllocationID: "a1", BlobberID: "b2", Version: 2}))
+			require.NoError(t, store.Save(PendingWriteMarker{ConnectionID: "c2", AllocationID: "a2", BlobberID: "b1", Version: 5}))
+
+			list, err := store.List("a1")
+			require.NoError(t, err)
+			require.Len(t, list, 2)
+
+			require.NoError(t, store.Save(PendingWriteMarker{ConnectionID: "c1", AllocationID: "a1", BlobberID: "b1", Version: 2, Error: "failed"}))
+			require.NoError(t, store.Remove("c1", "b2"))
+			require.NoError(t, store.Remove("c1", "b3"))
+
+			list, err = store.List("a1")
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			require.Equal(t, "b1", list[0].BlobberID)
+			require.Equal(t, "failed", list[0].Error)
+		})
+	}
+
+	// the pending write markers are loaded again, from a file readable by the owner only.
+	info, err := os.Stat(filepath.Join(dir, pendingCommitsFile))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	fsStore, err = NewFsPendingCommitStore(nil, dir)
+	require.NoError(t, err)
+	list, err := fsStore.List("a1")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	// the temporary file is used if the file is corrupted.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, pendingCommitsFile), []byte("{"), 0600))
+	fsStore, err = NewFsPendingCommitStore(nil, dir)
+	require.NoError(t, err)
+	list, err = fsStore.List("a2")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
+
+func TestTrackCommit(t *testing.T) {
+	store := NewMemPendingCommitStore()
+	SetPendingCommitStore(store)
+	defer SetPendingCommitStore(nil)
+
+	ok := &CommitRequest{allocationID: "a1", connectionID: "c1", blobber: &blockchain.StorageNode{ID: "b1"}, version: 3}
+	failed := &CommitRequest{allocationID: "a1", connectionID: "c1", blobber: &blockchain.StorageNode{ID: "b2"}, version: 3}
+	trackCommit(ok)
+	trackCommit(failed)
+
+	list, err := store.List("a1")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	ok.result = SuccessCommitResult()
+	failed.result = ErrorCommitResult("commit_error")
+	untrackCommit(ok)
+	untrackCommit(failed)
+
+	list, err = store.List("a1")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "b2", list[0].BlobberID)
+	require.Equal(t, int64(3), list[0].Version)
+	require.Equal(t, "commit_error", list[0].Error)
+}
+
+func TestAllocation_RetryFailedCommits(t *testing.T) {
+	store := NewMemPendingCommitStore()
+	SetPendingCommitStore(store)
+	defer SetPendingCommitStore(nil)
+	delay, resend, attemptsCount := commitRetryDelay, resendWriteMarker, CommitRetryAttempts
+	defer func() { commitRetryDelay, resendWriteMarker, CommitRetryAttempts = delay, resend, attemptsCount }()
+	commitRetryDelay = time.Millisecond
+	CommitRetryAttempts = 3
+
+	// b1 commits on the first retry, b2 never does.
+	var attempts int32
+	resendWriteMarker = func(a *Allocation, connectionID string) error {
+		atomic.AddInt32(&attempts, 1)
+		require.NoError(t, store.Remove(connectionID, "b1"))
+		return errors.New("commit_failed", "b2 is down")
+	}
+	a := &Allocation{ID: "alloc", ctx: context.Background()}
+	commitReqs := []*CommitRequest{
+		{connectionID: "c1", blobber: &blockchain.StorageNode{ID: "b0"}, result: SuccessCommitResult()},
+		{connectionID: "c1", blobber: &blockchain.StorageNode{ID: "b1"}, result: ErrorCommitResult("timeout")},
+		{connectionID: "c1", blobber: &blockchain.StorageNode{ID: "b2"}},
+	}
+	for _, id := range []string{"b1", "b2"} {
+		require.NoError(t, store.Save(PendingWriteMarker{ConnectionID: "c1", AllocationID: "alloc", BlobberID: id}))
+	}
+
+	sub := SubscribeEvents(10, EventCommitRetryDone, EventCommitRetryFailed, EventRepairNeeded)
+	defer sub.Unsubscribe()
+	a.retryFailedCommits(commitReqs)
+
+	var events []Event
+	for len(events) < 3 {
+		select {
+		case e := <-sub.Events():
+			events = append(events, e)
+		case <-time.After(5 * time.Second):
+			t.Fatal("missing events", events)
+		}
+	}
+	require.Equal(t, EventCommitRetryDone, events[0].Type)
+	require.Equal(t, "b1", events[0].BlobberID)
+	require.Equal(t, EventCommitRetryFailed, events[1].Type)
+	require.Equal(t, "b2", events[1].BlobberID)
+	require.Contains(t, events[1].Error, "b2 is down")
+	require.Equal(t, EventRepairNeeded, events[2].Type)
+	require.EqualValues(t, CommitRetryAttempts, atomic.LoadInt32(&attempts))
+
+	// the markers superseded by a later commit aren't resent.
+	atomic.StoreInt32(&attempts, 0)
+	a.allocationVersion = 5
+	require.NoError(t, store.Save(PendingWriteMarker{ConnectionID: "c2", AllocationID: "alloc", BlobberID: "b1", Version: 4}))
+	a.retryCommits("c2", map[string]bool{"b1": true}, 3)
+	e := <-sub.Events()
+	require.Equal(t, EventCommitRetryFailed, e.Type)
+	require.Equal(t, "b1", e.BlobberID)
+	require.Contains(t, e.Error, "outdated")
+	require.Equal(t, EventRepairNeeded, (<-sub.Events()).Type)
+	require.Zero(t, atomic.LoadInt32(&attempts))
+	list, err := store.List("alloc")
+	require.NoError(t, err)
+	for _, pm := range list {
+		require.NotEqual(t, "c2", pm.ConnectionID)
+	}
+
+	// the retries stop once the allocation is shut down.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a.ctx = ctx
+	commitRetryDelay = time.Hour
+	a.retryCommits("c1", map[string]bool{"b2": true}, 3)
+	require.Zero(t, atomic.LoadInt32(&attempts))
+}
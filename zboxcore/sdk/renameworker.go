@@ -41,6 +41,7 @@ type RenameRequest struct {
 	wg             *sync.WaitGroup
 	renameMask     zboxutil.Uint128
 	maskMU         *sync.Mutex
+	progress       DirOperationProgress
 	connectionID   string
 	consensus      Consensus
 	timestamp      int64
@@ -74,6 +75,7 @@ func (req *RenameRequest) renameBlobberObject(
 
 	defer func() {
 		if err != nil {
+			req.consensus.Reject(uint64(blobberIdx), err)
 			req.maskMU.Lock()
 			req.renameMask = req.renameMask.And(zboxutil.NewUint128(1).Lsh(uint64(blobberIdx)).Not())
 			req.maskMU.Unlock()
@@ -140,13 +142,13 @@ func (req *RenameRequest) renameBlobberObject(
 			latestStatusCode = resp.StatusCode
 
 			if resp.StatusCode == http.StatusOK {
-				req.consensus.Done()
+				req.consensus.Accept(uint64(blobberIdx))
 				l.Logger.Info(blobber.Baseurl, " "+req.remotefilepath, " renamed.")
 				return
 			}
 
 			if strings.Contains(latestRespMsg, alreadyExists) {
-				req.consensus.Done()
+				req.consensus.Accept(uint64(blobberIdx))
 				return
 			}
 
@@ -230,15 +232,17 @@ func (req *RenameRequest) ProcessWithBlobbers() ([]fileref.RefEntity, error) {
 			opType:          constants.FileOperationMove,
 			subOpType:       constants.FileOperationRename,
 			mask:            req.renameMask,
+			progress:        req.progress,
 		}
 		err := subRequest.processSubDirectories()
 		if err != nil {
 			return nil, err
 		}
 		op := OperationRequest{
-			OperationType: constants.FileOperationDelete,
-			RemotePath:    req.remotefilepath,
-			Mask:          &req.renameMask,
+			OperationType:     constants.FileOperationDelete,
+			RemotePath:        req.remotefilepath,
+			Mask:              &req.renameMask,
+			skipRetentionLock: true,
 		}
 		err = req.allocationObj.DoMultiOperation([]OperationRequest{op})
 		if err != nil {
@@ -277,9 +281,7 @@ func (req *RenameRequest) ProcessRename() error {
 				fmt.Sprintf("Rename failed. %s", err.Error()))
 		}
 
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Rename failed. Required consensus %d got %d",
-				req.consensus.consensusThresh, req.consensus.getConsensus()))
+		return req.consensus.consensusError("Rename")
 	}
 
 	writeMarkerMutex, err := CreateWriteMarkerMutex(client.GetClient(), req.allocationObj)
@@ -339,6 +341,7 @@ func (req *RenameRequest) ProcessRename() error {
 			connectionID: req.connectionID,
 			wg:           wg,
 			timestamp:    req.timestamp,
+			blobberInd:   pos,
 		}
 		commitReq.changes = append(commitReq.changes, newChange)
 		commitReqs[counter] = commitReq
@@ -350,14 +353,13 @@ func (req *RenameRequest) ProcessRename() error {
 
 	wg.Wait()
 
-	var errMessages string
 	for _, commitReq := range commitReqs {
 		if commitReq.result != nil {
 			if commitReq.result.Success {
 				l.Logger.Info("Commit success", commitReq.blobber.Baseurl)
-				req.consensus.Done()
+				req.consensus.Accept(commitReq.blobberInd)
 			} else {
-				errMessages += commitReq.result.ErrorMessage + "\t"
+				req.consensus.Reject(commitReq.blobberInd, errors.New("commit_failed", commitReq.result.ErrorMessage))
 				l.Logger.Info("Commit failed", commitReq.blobber.Baseurl, commitReq.result.ErrorMessage)
 			}
 		} else {
@@ -366,9 +368,7 @@ func (req *RenameRequest) ProcessRename() error {
 	}
 
 	if !req.consensus.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Required consensus %d got %d. Error: %s",
-				req.consensus.consensusThresh, req.consensus.consensus, errMessages))
+		return req.consensus.consensusError("Commit on rename")
 	}
 	return nil
 }
@@ -380,6 +380,7 @@ type RenameOperation struct {
 	renameMask     zboxutil.Uint128
 	newName        string
 	maskMU         *sync.Mutex
+	progress       DirOperationProgress
 
 	consensus Consensus
 }
@@ -399,6 +400,7 @@ func (ro *RenameOperation) Process(allocObj *Allocation, connectionID string) ([
 		ctxCncl:        ro.ctxCncl,
 		renameMask:     ro.renameMask,
 		maskMU:         ro.maskMU,
+		progress:       ro.progress,
 		wg:             &sync.WaitGroup{},
 		consensus:      Consensus{RWMutex: &sync.RWMutex{}},
 	}
@@ -418,9 +420,7 @@ func (ro *RenameOperation) Process(allocObj *Allocation, connectionID string) ([
 			return nil, rR.renameMask, errors.New("rename_failed", fmt.Sprintf("Renamed failed. %s", err.Error()))
 		}
 
-		return nil, rR.renameMask, errors.New("consensus_not_met",
-			fmt.Sprintf("Rename failed. Required consensus %d, got %d",
-				rR.consensus.consensusThresh, rR.consensus.consensus))
+		return nil, rR.renameMask, rR.consensus.consensusError("Rename")
 	}
 	return objectTreeRefs, rR.renameMask, err
 }
@@ -0,0 +1,276 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+)
+
+// DefaultRepairInterval is the default interval between the checks of a Repairer.
+const DefaultRepairInterval = time.Hour
+
+// RepairerConfig configures a Repairer.
+type RepairerConfig struct {
+	// Interval is the interval between the checks of the allocation, DefaultRepairInterval if zero.
+	Interval time.Duration
+	// BandwidthLimit is the maximum rate the repaired files are read at in bytes per second, 0 for no limit.
+	BandwidthLimit int64
+	// MaxBytesPerRun is the budget of a repair in bytes, the repair is stopped once it's exceeded
+	// and resumed by the next run. 0 for no budget.
+	MaxBytesPerRun int64
+	// WindowStart and WindowEnd are the times of the day, from midnight in local time, the repairs
+	// are allowed in, e.g. 22h and 6h for the nights. The repair in progress is stopped at the end
+	// of the window. The repairs are allowed all day if they're equal.
+	WindowStart time.Duration
+	WindowEnd   time.Duration
+	// Path is the remote path to repair, "/" if empty.
+	Path string
+	// StatusCB receives the status of the repairs, optional.
+	StatusCB StatusCallback
+}
+
+// Repairer periodically checks an allocation and repairs the files its blobbers are out of sync on,
+// under a bandwidth budget and a time window. It's intended for the long running deployments, e.g.
+// the gateways, so the allocations don't accumulate repair debt.
+type Repairer struct {
+	allocation *Allocation
+	cfg        RepairerConfig
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+	// now returns the current time, replaced by the tests.
+	now func() time.Time
+}
+
+// NewRepairer creates a repairer of an allocation, see Start.
+//   - a: the allocation to repair.
+//   - cfg: the configuration of the repairer.
+func NewRepairer(a *Allocation, cfg RepairerConfig) *Repairer {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultRepairInterval
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/"
+	}
+	if cfg.StatusCB == nil {
+		cfg.StatusCB = nopStatusCallback{}
+	}
+	return &Repairer{allocation: a, cfg: cfg, now: time.Now}
+}
+
+// Start starts checking the allocation in the background, the first check being immediate.
+func (r *Repairer) Start() error {
+	if !r.allocation.isInitialized() {
+		return notInitialized
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return errors.New("repairer_started", "the repairer is already started")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go r.loop(ctx, r.done)
+	return nil
+}
+
+// Stop stops the repairer and the repair in progress, if any.
+func (r *Repairer) Stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (r *Repairer) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := r.run(ctx); err != nil {
+			l.Logger.Error("repairer: ", r.allocation.ID, ": ", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run repairs the allocation if it's in the window and its blobbers are out of sync.
+func (r *Repairer) run(ctx context.Context) error {
+	untilEnd, ok := r.window(r.now())
+	if !ok {
+		return nil
+	}
+	status, _, err := r.allocation.CheckAllocStatus()
+	if err != nil {
+		return err
+	}
+	if status != Repair {
+		return nil
+	}
+
+	dir := "/tmp"
+	if !IsWasm {
+		if dir, err = os.Getwd(); err != nil {
+			return err
+		}
+	}
+	bandwidth := newBandwidthLimiter(r.cfg.BandwidthLimit, r.cfg.MaxBytesPerRun)
+	req, repaired, err := r.allocation.startRepair(dir, r.cfg.Path, r.cfg.StatusCB, bandwidth)
+	if err != nil {
+		return err
+	}
+
+	var windowEnd <-chan time.Time
+	if untilEnd > 0 {
+		timer := time.NewTimer(untilEnd)
+		defer timer.Stop()
+		windowEnd = timer.C
+	}
+	select {
+	case <-repaired:
+		return nil
+	case <-ctx.Done():
+	case <-windowEnd:
+		l.Logger.Info("repairer: ", r.allocation.ID, ": the repair window is over")
+	}
+	req.isRepairCanceled = true
+	<-repaired
+	return nil
+}
+
+// window returns whether t is in the repair window and the time left until its end, 0 if the
+// repairs are allowed all day.
+func (r *Repairer) window(t time.Time) (time.Duration, bool) {
+	start, end := r.cfg.WindowStart, r.cfg.WindowEnd
+	if start == end {
+		return 0, true
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	tod := t.Sub(midnight)
+	if start < end {
+		if tod >= start && tod < end {
+			return end - tod, true
+		}
+		return 0, false
+	}
+	// the window wraps around midnight
+	switch {
+	case tod >= start:
+		return 24*time.Hour - tod + end, true
+	case tod < end:
+		return end - tod, true
+	}
+	return 0, false
+}
+
+// bandwidthLimiter limits the rate of the bytes read and counts them against a budget.
+type bandwidthLimiter struct {
+	mu sync.Mutex
+	// rate is the limit in bytes per second, 0 for no limit.
+	rate int64
+	// budget is the limit of the bytes read, 0 for no limit.
+	budget int64
+	used   int64
+	// next is the time the bytes read so far are allowed at.
+	next time.Time
+}
+
+func newBandwidthLimiter(rate, budget int64) *bandwidthLimiter {
+	if rate <= 0 && budget <= 0 {
+		return nil
+	}
+	return &bandwidthLimiter{rate: rate, budget: budget}
+}
+
+// wait waits until n more bytes are allowed by the rate.
+func (b *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	b.mu.Lock()
+	b.used += int64(n)
+	if b.rate <= 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	if b.next.Before(now) {
+		b.next = now
+	}
+	b.next = b.next.Add(time.Duration(int64(n) * int64(time.Second) / b.rate))
+	delay := b.next.Sub(now)
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// exceeded returns whether the bytes read exceeded the budget.
+func (b *bandwidthLimiter) exceeded() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.budget > 0 && b.used >= b.budget
+}
+
+// reader returns a reader of r limited by the rate.
+func (b *bandwidthLimiter) reader(ctx context.Context, r io.Reader) io.Reader {
+	return &limitedReader{ctx: ctx, r: r, limiter: b}
+}
+
+type limitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *bandwidthLimiter
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	n, err := lr.r.Read(p)
+	if n > 0 {
+		if werr := lr.limiter.wait(lr.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// withBandwidthLimiter limits the rate the file is read at, see Repairer.
+func withBandwidthLimiter(b *bandwidthLimiter) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.bandwidth = b
+	}
+}
+
+// nopStatusCallback ignores the status of the operations.
+type nopStatusCallback struct{}
+
+func (nopStatusCallback) Started(string, string, int, int) {}
+
+func (nopStatusCallback) InProgress(string, string, int, int, []byte) {}
+
+func (nopStatusCallback) Error(string, string, int, error) {}
+
+func (nopStatusCallback) Completed(string, string, string, string, int, int) {}
+
+func (nopStatusCallback) RepairCompleted(int) {}
@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairer_window(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2024, 1, 1, h, m, 0, 0, time.Local)
+	}
+	r := NewRepairer(&Allocation{}, RepairerConfig{})
+	left, ok := r.window(at(12, 0))
+	require.True(t, ok)
+	require.Zero(t, left)
+
+	r = NewRepairer(&Allocation{}, RepairerConfig{WindowStart: 2 * time.Hour, WindowEnd: 4 * time.Hour})
+	left, ok = r.window(at(3, 30))
+	require.True(t, ok)
+	require.Equal(t, 30*time.Minute, left)
+	_, ok = r.window(at(4, 0))
+	require.False(t, ok)
+
+	// the window wraps around midnight
+	r = NewRepairer(&Allocation{}, RepairerConfig{WindowStart: 22 * time.Hour, WindowEnd: 6 * time.Hour})
+	left, ok = r.window(at(23, 0))
+	require.True(t, ok)
+	require.Equal(t, 7*time.Hour, left)
+	left, ok = r.window(at(5, 0))
+	require.True(t, ok)
+	require.Equal(t, time.Hour, left)
+	_, ok = r.window(at(12, 0))
+	require.False(t, ok)
+}
+
+func TestRepairer_Start(t *testing.T) {
+	r := NewRepairer(&Allocation{}, RepairerConfig{})
+	require.ErrorIs(t, r.Start(), notInitialized)
+
+	a := &Allocation{}
+	setupMockAllocation(t, a)
+	// out of the window, the allocation isn't checked.
+	r = NewRepairer(a, RepairerConfig{Interval: time.Millisecond, WindowStart: time.Hour, WindowEnd: 2 * time.Hour})
+	r.now = func() time.Time { return time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local) }
+	require.NoError(t, r.Start())
+	require.Error(t, r.Start())
+	time.Sleep(10 * time.Millisecond)
+	r.Stop()
+	r.Stop()
+}
+
+func TestBandwidthLimiter(t *testing.T) {
+	require.Nil(t, newBandwidthLimiter(0, 0))
+
+	b := newBandwidthLimiter(100*KB, 150*KB)
+	data := generateRandomBytes(100 * KB)
+	start := time.Now()
+	read, err := io.ReadAll(b.reader(context.Background(), bytes.NewReader(data)))
+	require.NoError(t, err)
+	require.Equal(t, data, read)
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	require.False(t, b.exceeded())
+
+	// the budget is counted without a rate.
+	b = newBandwidthLimiter(0, 150*KB)
+	_, err = io.ReadAll(b.reader(context.Background(), bytes.NewReader(generateRandomBytes(200*KB))))
+	require.NoError(t, err)
+	require.True(t, b.exceeded())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b = newBandwidthLimiter(KB, 0)
+	_, err = io.ReadAll(b.reader(ctx, bytes.NewReader(data)))
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -27,6 +27,8 @@ type RepairRequest struct {
 	repairPath        string
 	versionMap        map[int64]zboxutil.Uint128
 	resMap            map[int64]*getRes
+	// bandwidth limits the uploads of the repair, nil for no limit, see Repairer.
+	bandwidth *bandwidthLimiter
 }
 
 type RepairStatusCB struct {
@@ -148,7 +150,7 @@ func (r *RepairRequest) Size(ctx context.Context, dir *ListResult) (RepairSize,
 
 func (r *RepairRequest) iterateDir(ctx context.Context) {
 	r.versionMap = make(map[int64]zboxutil.Uint128)
-	latestVersion := r.allocation.allocationVersion
+	latestVersion := r.allocation.getAllocationVersion()
 	for idx, blobber := range r.allocation.Blobbers {
 		r.versionMap[blobber.AllocationVersion] = r.versionMap[blobber.AllocationVersion].Or(zboxutil.NewUint128(1).Lsh(uint64(idx)))
 	}
@@ -176,7 +178,7 @@ func (r *RepairRequest) iterateDir(ctx context.Context) {
 	l.Logger.Debug("repair directory completed")
 
 	r.allocation.CheckAllocStatus() //nolint:errcheck
-	if r.allocation.allocationVersion != latestVersion {
+	if r.allocation.getAllocationVersion() != latestVersion {
 		l.Logger.Error("Allocation version changed during repair operation")
 		if r.statusCB != nil {
 			r.statusCB.Error(r.allocation.ID, r.repairPath, OpRepair, errors.New("allocation version changed during repair operation"))
@@ -233,6 +235,9 @@ func (r *RepairRequest) uploadFileOp(file ORef, opMask zboxutil.Uint128) Operati
 		ChunkWriteSize: int(r.allocation.GetChunkReadSize(file.EncryptedKey != "")),
 	}
 	op := r.allocation.RepairFile(memFile, file.Path, statusCB, opMask, file)
+	if r.bandwidth != nil {
+		op.Opts = append(op.Opts, withBandwidthLimiter(r.bandwidth))
+	}
 	if op.FileMeta.ActualSize > 0 {
 		op.DownloadFile = true
 	}
@@ -316,9 +321,10 @@ func (r *RepairRequest) repairObjects(ctx context.Context, latestVersion int64,
 						//delete the file
 						opMask := r.versionMap[version]
 						op := OperationRequest{
-							OperationType: constants.FileOperationDelete,
-							RemotePath:    res.oTR.Refs[res.idx].Path,
-							Mask:          &opMask,
+							OperationType:     constants.FileOperationDelete,
+							RemotePath:        res.oTR.Refs[res.idx].Path,
+							Mask:              &opMask,
+							skipRetentionLock: true,
 						}
 						res.idx++
 						ops = append(ops, op)
@@ -370,9 +376,10 @@ func (r *RepairRequest) repairObjects(ctx context.Context, latestVersion int64,
 					l.Logger.Debug("Deleting file: ", res.oTR.Refs[res.idx].Path)
 					opMask := r.versionMap[version]
 					op := OperationRequest{
-						OperationType: constants.FileOperationDelete,
-						RemotePath:    res.oTR.Refs[res.idx].Path,
-						Mask:          &opMask,
+						OperationType:     constants.FileOperationDelete,
+						RemotePath:        res.oTR.Refs[res.idx].Path,
+						Mask:              &opMask,
+						skipRetentionLock: true,
 					}
 					res.idx++
 					ops = append(ops, op)
@@ -513,6 +520,10 @@ func (r *RepairRequest) repairOperation(ops []OperationRequest, latestVersion in
 }
 
 func (r *RepairRequest) checkForCancel(a *Allocation) bool {
+	if r.bandwidth != nil && r.bandwidth.exceeded() {
+		l.Logger.Info("Repair stopped, the bandwidth budget is exceeded")
+		r.isRepairCanceled = true
+	}
 	if r.isRepairCanceled {
 		l.Logger.Info("Repair Cancelled by the user")
 		if r.statusCB != nil {
@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// ErrRetentionLocked is returned when an update, delete, rename or move targets an object under a
+// retention lock. Test it with errors.Is, the returned errors detail the lock.
+var ErrRetentionLocked = errors.New("retention_locked", "the object is under a retention lock")
+
+// RetentionLock makes a file, a directory or the whole allocation immutable until a date, for
+// the write once read many (WORM) compliance of backups.
+type RetentionLock struct {
+	// Path is the remote path of the locked file or directory, empty or "/" for the whole allocation.
+	// The lock of a directory applies to its descendants.
+	Path string `json:"path"`
+	// Until is the date the lock expires at.
+	Until common.Timestamp `json:"until"`
+}
+
+// IsActive returns true if the lock hasn't expired.
+func (l RetentionLock) IsActive() bool {
+	return common.Now() < l.Until
+}
+
+// lockPath returns the cleaned path of the lock, empty for the whole allocation.
+func (l RetentionLock) lockPath() string {
+	lockPath := strings.TrimSuffix(zboxutil.RemoteClean(l.Path), "/")
+	if lockPath == "." {
+		return ""
+	}
+	return lockPath
+}
+
+// covers returns true if the lock applies to the remote path: the path is locked, or it's an ancestor
+// of a locked object, which would be destroyed by deleting, renaming or moving the path.
+func (l RetentionLock) covers(remotePath string) bool {
+	lockPath := l.lockPath()
+	remotePath = strings.TrimSuffix(remotePath, "/")
+	if lockPath == "" || remotePath == "" {
+		return true
+	}
+	return remotePath == lockPath || strings.HasPrefix(remotePath, lockPath+"/") ||
+		strings.HasPrefix(lockPath, remotePath+"/")
+}
+
+// SetRetentionLock locks a file, a directory or the whole allocation until a date (txn: `storagesc.set_retention_lock`).
+// The lock can be extended but not shortened or removed before it expires.
+//   - allocID: the id of the allocation
+//   - remotePath: the remote path to lock, empty to lock the whole allocation
+//   - until: the date the lock expires at
+//
+// returns the hash of the transaction, the nonce of the transaction and an error if any.
+func SetRetentionLock(allocID, remotePath string, until common.Timestamp) (hash string, nonce int64, err error) {
+	if !sdkInitialized {
+		return "", 0, sdkNotInitialized
+	}
+	if until <= common.Now() {
+		return "", 0, errors.New("invalid_retention", "the retention lock must expire in the future")
+	}
+	if remotePath != "" {
+		remotePath = zboxutil.RemoteClean(remotePath)
+		if !zboxutil.IsRemoteAbs(remotePath) {
+			return "", 0, errors.New("invalid_path", "Path should be valid and absolute")
+		}
+	}
+	var sn = transaction.SmartContractTxnData{
+		Name: transaction.STORAGESC_SET_RETENTION_LOCK,
+		InputArgs: map[string]interface{}{
+			"allocation_id": allocID,
+			"path":          remotePath,
+			"until":         until,
+		},
+	}
+	hash, _, nonce, _, err = storageSmartContractTxn(sn)
+	return
+}
+
+// GetRetentionLock returns the active retention lock applying to the remote path, nil if it isn't locked.
+// The locks of the descendants of a directory apply to it too, it can't be deleted, renamed or moved
+// while they're active. The lock expiring last is returned if several locks apply.
+//   - remotePath: the remote path of the file or directory
+func (a *Allocation) GetRetentionLock(remotePath string) *RetentionLock {
+	remotePath = zboxutil.RemoteClean(remotePath)
+	var found *RetentionLock
+	for i, l := range a.RetentionLocks {
+		if !l.IsActive() || !l.covers(remotePath) {
+			continue
+		}
+		if found == nil || l.Until > found.Until {
+			found = &a.RetentionLocks[i]
+		}
+	}
+	return found
+}
+
+// checkRetentionLock rejects the operations modifying an object under a retention lock. The
+// repairs made by the sdk are allowed, they restore the same content.
+func (a *Allocation) checkRetentionLock(op OperationRequest) error {
+	if len(a.RetentionLocks) == 0 || op.skipRetentionLock {
+		return nil
+	}
+	var remotePath string
+	switch op.OperationType {
+	case constants.FileOperationDelete, constants.FileOperationRename, constants.FileOperationMove:
+		remotePath = op.RemotePath
+	case constants.FileOperationUpdate:
+		remotePath = op.FileMeta.RemotePath
+		if remotePath == "" {
+			remotePath = op.RemotePath
+		}
+	default:
+		return nil
+	}
+	return a.retentionLockError(op.OperationType, remotePath)
+}
+
+func (a *Allocation) retentionLockError(operation, remotePath string) error {
+	l := a.GetRetentionLock(remotePath)
+	if l == nil {
+		return nil
+	}
+	until := time.Unix(int64(l.Until), 0).UTC().Format(time.RFC3339)
+	if lockPath := l.lockPath(); lockPath != "" && strings.HasPrefix(lockPath, strings.TrimSuffix(zboxutil.RemoteClean(remotePath), "/")+"/") {
+		return errors.New(ErrRetentionLocked.Code, fmt.Sprintf("can't %s %s, %s is locked until %s",
+			operation, remotePath, lockPath, until))
+	}
+	return errors.New(ErrRetentionLocked.Code, fmt.Sprintf("can't %s %s, it's locked until %s",
+		operation, remotePath, until))
+}
@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_checkRetentionLock(t *testing.T) {
+	now := common.Now()
+	a := &Allocation{RetentionLocks: []RetentionLock{
+		{Path: "/backups", Until: now + 3600},
+		{Path: "/backups/2024", Until: now + 7200},
+		{Path: "/tmp", Until: now - 1},
+	}}
+
+	require.Nil(t, a.GetRetentionLock("/backups2"))
+	require.Nil(t, a.GetRetentionLock("/tmp/1.txt"))
+	require.Equal(t, now+3600, a.GetRetentionLock("/backups/1.txt").Until)
+	require.Equal(t, now+7200, a.GetRetentionLock("/backups/2024/1.txt").Until)
+
+	mask := zboxutil.NewUint128(1)
+
+	for _, op := range []OperationRequest{
+		{OperationType: constants.FileOperationDelete, RemotePath: "/backups/1.txt"},
+		{OperationType: constants.FileOperationRename, RemotePath: "/backups", DestName: "old"},
+		{OperationType: constants.FileOperationMove, RemotePath: "/backups/1.txt", DestPath: "/"},
+		{OperationType: constants.FileOperationUpdate, FileMeta: FileMeta{RemotePath: "/backups/1.txt"}},
+		// the exported fields of the repairs don't exempt the operations.
+		{OperationType: constants.FileOperationUpdate, FileMeta: FileMeta{RemotePath: "/backups/1.txt"}, IsRepair: true},
+		{OperationType: constants.FileOperationDelete, RemotePath: "/backups/1.txt", Mask: &mask},
+	} {
+		err := a.checkRetentionLock(op)
+		require.Error(t, err, op.OperationType)
+		require.True(t, errors.Is(err, ErrRetentionLocked))
+	}
+
+	for _, op := range []OperationRequest{
+		{OperationType: constants.FileOperationInsert, FileMeta: FileMeta{RemotePath: "/backups/2.txt"}},
+		{OperationType: constants.FileOperationCopy, RemotePath: "/backups/1.txt", DestPath: "/"},
+		{OperationType: constants.FileOperationDelete, RemotePath: "/tmp/1.txt"},
+		{OperationType: constants.FileOperationUpdate, FileMeta: FileMeta{RemotePath: "/backups/1.txt"}, skipRetentionLock: true},
+	} {
+		require.NoError(t, a.checkRetentionLock(op), op.OperationType)
+	}
+
+	// the ancestors of a locked file can't be deleted, renamed or moved either.
+	a.RetentionLocks = []RetentionLock{{Path: "/dir/sub/file", Until: now + 3600}}
+	require.Equal(t, now+3600, a.GetRetentionLock("/dir").Until)
+	require.Nil(t, a.GetRetentionLock("/dir/sub2"))
+	require.Nil(t, a.GetRetentionLock("/di"))
+	for _, op := range []OperationRequest{
+		{OperationType: constants.FileOperationDelete, RemotePath: "/dir"},
+		{OperationType: constants.FileOperationDelete, RemotePath: "/"},
+		{OperationType: constants.FileOperationMove, RemotePath: "/dir/sub", DestPath: "/other"},
+		{OperationType: constants.FileOperationRename, RemotePath: "/dir", DestName: "old"},
+	} {
+		err := a.checkRetentionLock(op)
+		require.Error(t, err, op.RemotePath)
+		require.True(t, errors.Is(err, ErrRetentionLocked))
+		require.Contains(t, err.Error(), "/dir/sub/file is locked")
+	}
+	require.NoError(t, a.checkRetentionLock(OperationRequest{OperationType: constants.FileOperationDelete, RemotePath: "/dir/other.txt"}))
+
+	a.RetentionLocks = append(a.RetentionLocks, RetentionLock{Until: now + 60})
+	require.Error(t, a.checkRetentionLock(OperationRequest{OperationType: constants.FileOperationDelete, RemotePath: "/tmp/1.txt"}))
+}
+
+func TestAllocation_DeleteFileRetentionLockedDescendant(t *testing.T) {
+	prevInitialized := sdkInitialized
+	sdkInitialized = true
+	defer func() { sdkInitialized = prevInitialized }()
+
+	a := &Allocation{
+		initialized:    true,
+		FileOptions:    63,
+		RetentionLocks: []RetentionLock{{Path: "/dir/file", Until: common.Now() + 3600}},
+	}
+	err := a.DeleteFile("/dir")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrRetentionLocked))
+}
+
+func TestAllocation_UpdateFileRetentionLocked(t *testing.T) {
+	prevInitialized := sdkInitialized
+	sdkInitialized = true
+	defer func() { sdkInitialized = prevInitialized }()
+
+	localPath := filepath.Join(t.TempDir(), "1.txt")
+	require.NoError(t, os.WriteFile(localPath, []byte("new content"), 0644))
+
+	a := &Allocation{
+		initialized:    true,
+		FileOptions:    63,
+		RetentionLocks: []RetentionLock{{Path: "/backups", Until: common.Now() + 3600}},
+	}
+	err := a.UpdateFile(t.TempDir(), localPath, "/backups/1.txt", nil)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrRetentionLocked))
+}
@@ -256,6 +256,12 @@ func (a *Allocation) CheckAllocStatus() (AllocStatus, []BlobberStatus, error) {
 				markerError = err
 				l.Logger.Error("error during getWritemarker", zap.Error(err))
 				blobStatus.Status = "unavailable"
+				publishEvent(Event{
+					Type:         EventBlobberUnhealthy,
+					AllocationID: a.ID,
+					BlobberID:    blobber.ID,
+					Error:        err.Error(),
+				})
 			}
 			if lvm == nil || lvm.VersionMarker == nil {
 				markerChan <- nil
@@ -315,7 +321,7 @@ func (a *Allocation) CheckAllocStatus() (AllocStatus, []BlobberStatus, error) {
 	}
 
 	if consensusReached {
-		a.allocationVersion = latestVersion
+		atomic.StoreInt64(&a.allocationVersion, latestVersion)
 		return Commit, blobberRes, nil
 	}
 
@@ -323,6 +329,7 @@ func (a *Allocation) CheckAllocStatus() (AllocStatus, []BlobberStatus, error) {
 		for _, rb := range versionMap[prevVersion] {
 			blobberRes[rb.blobIndex].Status = "repair"
 		}
+		publishEvent(Event{Type: EventRepairNeeded, AllocationID: a.ID})
 		return Repair, blobberRes, nil
 	}
 
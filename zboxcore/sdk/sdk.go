@@ -15,6 +15,7 @@ import (
 	"github.com/0chain/gosdk/core/conf"
 	"github.com/0chain/gosdk/core/logger"
 	"github.com/0chain/gosdk/core/node"
+	"github.com/0chain/gosdk/core/restclient"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/0chain/gosdk/core/common"
@@ -36,12 +37,16 @@ const ZCNSC_SCADDRESS = "6dba10422e368813802877a85039d3985d96760ed844092319743fb
 var sdkNotInitialized = errors.New("sdk_not_initialized", "SDK is not initialised")
 var allocationNotFound = errors.New("couldnt_find_allocation", "Couldn't find the allocation required for update")
 
+// scRestClient is the typed client of the smart contract REST endpoints of the sharders.
+var scRestClient = zboxutil.NewSCRestClient(nil)
+
 const (
 	OpUpload            int = 0
 	OpDownload          int = 1
 	OpRepair            int = 2
 	OpUpdate            int = 3
 	opThumbnailDownload int = 4
+	OpDelete            int = 5
 )
 
 type StatusCallback interface {
@@ -53,11 +58,10 @@ type StatusCallback interface {
 }
 
 var (
-	numBlockDownloads         = 100
-	sdkInitialized            = false
-	networkWorkerTimerInHours = 1
-	singleClientMode          = false
-	shouldVerifyHash          = true
+	numBlockDownloads = 100
+	sdkInitialized    = false
+	singleClientMode  = false
+	shouldVerifyHash  = true
 )
 
 func SetSingleClietnMode(mode bool) {
@@ -363,6 +367,64 @@ func GetStakePoolUserInfo(clientID string, offset, limit int) (info *StakePoolUs
 	return
 }
 
+// StakePoolRewards is the reward breakdown of a provider stake pool.
+type StakePoolRewards struct {
+	ProviderID   string       `json:"provider_id"`
+	ProviderType ProviderType `json:"provider_type"`
+	// ServiceCharge is the uncollected reward of the provider itself (its service charge).
+	ServiceCharge common.Balance `json:"service_charge"`
+	// TotalRewards is the total rewards of the stake pool for all time.
+	TotalRewards common.Balance `json:"total_rewards"`
+	// Delegates is the reward breakdown of each delegate of the stake pool.
+	Delegates []StakePoolDelegateRewards `json:"delegates"`
+}
+
+// StakePoolDelegateRewards is the reward breakdown of a single delegate of a stake pool.
+type StakePoolDelegateRewards struct {
+	DelegateID common.Key     `json:"delegate_id"`
+	Stake      common.Balance `json:"stake"`
+	// Uncollected is the reward that can be collected by the delegate.
+	Uncollected common.Balance `json:"uncollected"`
+	TotalReward common.Balance `json:"total_reward"`
+	// TotalPenalty is the total penalty (slashed tokens) of the delegate.
+	TotalPenalty common.Balance `json:"total_penalty"`
+	Status       string         `json:"status"`
+}
+
+// GetStakePoolRewards retrieves the reward breakdown of the stake pool of a provider.
+// If delegateID is not empty, only the rewards of the given delegate are returned.
+//   - providerType: provider type
+//   - providerID: provider ID
+//   - delegateID: delegate (client) ID to filter by, or empty for all delegates
+func GetStakePoolRewards(providerType ProviderType, providerID, delegateID string) (*StakePoolRewards, error) {
+	info, err := GetStakePoolInfo(providerType, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := &StakePoolRewards{
+		ProviderID:    providerID,
+		ProviderType:  providerType,
+		ServiceCharge: info.Rewards,
+		TotalRewards:  info.TotalRewards,
+		Delegates:     make([]StakePoolDelegateRewards, 0, len(info.Delegate)),
+	}
+	for _, d := range info.Delegate {
+		if delegateID != "" && string(d.DelegateID) != delegateID {
+			continue
+		}
+		rewards.Delegates = append(rewards.Delegates, StakePoolDelegateRewards{
+			DelegateID:   d.DelegateID,
+			Stake:        d.Balance,
+			Uncollected:  d.Rewards,
+			TotalReward:  d.TotalReward,
+			TotalPenalty: d.TotalPenalty,
+			Status:       d.Status,
+		})
+	}
+	return rewards, nil
+}
+
 type stakePoolRequest struct {
 	ProviderType ProviderType `json:"provider_type,omitempty"`
 	ProviderID   string       `json:"provider_id,omitempty"`
@@ -616,33 +678,6 @@ func (v *UpdateValidator) ConvertToValidationNode() *blockchain.UpdateValidation
 	return blockValidator
 }
 
-func getBlobbersInternal(active, stakable bool, limit, offset int) (bs []*Blobber, err error) {
-	type nodes struct {
-		Nodes []*Blobber
-	}
-
-	url := fmt.Sprintf("/getblobbers?active=%s&limit=%d&offset=%d&stakable=%s",
-		strconv.FormatBool(active),
-		limit,
-		offset,
-		strconv.FormatBool(stakable),
-	)
-	b, err := zboxutil.MakeSCRestAPICall(STORAGE_SCADDRESS, url, nil, nil)
-	var wrap nodes
-	if err != nil {
-		return nil, errors.Wrap(err, "error requesting blobbers:")
-	}
-	if len(b) == 0 {
-		return nil, errors.New("", "empty response")
-	}
-
-	if err = json.Unmarshal(b, &wrap); err != nil {
-		return nil, errors.Wrap(err, "error decoding response:")
-	}
-
-	return wrap.Nodes, nil
-}
-
 // GetBlobbers returns list of blobbers.
 //   - active: if true then only active blobbers are returned
 //   - stakable: if true then only stakable blobbers are returned
@@ -651,31 +686,21 @@ func GetBlobbers(active, stakable bool) (bs []*Blobber, err error) {
 		return nil, sdkNotInitialized
 	}
 
-	limit, offset := 20, 0
-
-	blobbers, err := getBlobbersInternal(active, stakable, limit, offset)
-	if err != nil {
-		return nil, err
+	params := map[string]string{
+		"active":   strconv.FormatBool(active),
+		"stakable": strconv.FormatBool(stakable),
 	}
-
-	var blobbersSl []*Blobber
-	blobbersSl = append(blobbersSl, blobbers...)
-	for {
-		// if the len of output returned is less than the limit it means this is the last round of pagination
-		if len(blobbers) < limit {
-			break
+	bs, err = restclient.Paginate(context.TODO(), scRestClient, STORAGE_SCADDRESS, "/getblobbers", params, func(b []byte) ([]*Blobber, error) {
+		var wrap struct {
+			Nodes []*Blobber
 		}
-
-		// get the next set of blobbers
-		offset += 20
-		blobbers, err = getBlobbersInternal(active, stakable, limit, offset)
-		if err != nil {
-			return blobbers, err
-		}
-		blobbersSl = append(blobbersSl, blobbers...)
-
+		err := json.Unmarshal(b, &wrap)
+		return wrap.Nodes, err
+	})
+	if err != nil {
+		return bs, errors.Wrap(err, "error requesting blobbers:")
 	}
-	return blobbersSl, nil
+	return bs, nil
 }
 
 // GetBlobber retrieve blobber by id.
@@ -684,22 +709,11 @@ func GetBlobber(blobberID string) (blob *Blobber, err error) {
 	if !sdkInitialized {
 		return nil, sdkNotInitialized
 	}
-	var b []byte
-	b, err = zboxutil.MakeSCRestAPICall(
-		STORAGE_SCADDRESS,
-		"/getBlobber",
-		map[string]string{"blobber_id": blobberID},
-		nil)
+	blob, err = restclient.Get[*Blobber](context.TODO(), scRestClient, STORAGE_SCADDRESS, "/getBlobber",
+		map[string]string{"blobber_id": blobberID})
 	if err != nil {
 		return nil, errors.Wrap(err, "requesting blobber:")
 	}
-	if len(b) == 0 {
-		return nil, errors.New("", "empty response from sharders")
-	}
-	blob = new(Blobber)
-	if err = json.Unmarshal(b, blob); err != nil {
-		return nil, errors.Wrap(err, "decoding response:")
-	}
 	return
 }
 
@@ -709,22 +723,11 @@ func GetValidator(validatorID string) (validator *Validator, err error) {
 	if !sdkInitialized {
 		return nil, sdkNotInitialized
 	}
-	var b []byte
-	b, err = zboxutil.MakeSCRestAPICall(
-		STORAGE_SCADDRESS,
-		"/get_validator",
-		map[string]string{"validator_id": validatorID},
-		nil)
+	validator, err = restclient.Get[*Validator](context.TODO(), scRestClient, STORAGE_SCADDRESS, "/get_validator",
+		map[string]string{"validator_id": validatorID})
 	if err != nil {
 		return nil, errors.Wrap(err, "requesting validator:")
 	}
-	if len(b) == 0 {
-		return nil, errors.New("", "empty response from sharders")
-	}
-	validator = new(Validator)
-	if err = json.Unmarshal(b, validator); err != nil {
-		return nil, errors.Wrap(err, "decoding response:")
-	}
 	return
 }
 
@@ -734,23 +737,13 @@ func GetValidators(stakable bool) (validators []*Validator, err error) {
 	if !sdkInitialized {
 		return nil, sdkNotInitialized
 	}
-	var b []byte
-	b, err = zboxutil.MakeSCRestAPICall(
-		STORAGE_SCADDRESS,
-		"/validators",
+	validators, err = restclient.Get[[]*Validator](context.TODO(), scRestClient, STORAGE_SCADDRESS, "/validators",
 		map[string]string{
 			"stakable": strconv.FormatBool(stakable),
-		},
-		nil)
+		})
 	if err != nil {
 		return nil, errors.Wrap(err, "requesting validator list")
 	}
-	if len(b) == 0 {
-		return nil, errors.New("", "empty response from sharders")
-	}
-	if err = json.Unmarshal(b, &validators); err != nil {
-		return nil, errors.Wrap(err, "decoding response:")
-	}
 	return
 }
 
@@ -797,16 +790,9 @@ func GetAllocation(allocationID string) (*Allocation, error) {
 	if !sdkInitialized {
 		return nil, sdkNotInitialized
 	}
-	params := make(map[string]string)
-	params["allocation"] = allocationID
-	allocationBytes, err := zboxutil.MakeSCRestAPICall(STORAGE_SCADDRESS, "/allocation", params, nil)
+	allocationObj, err := fetchAllocation(allocationID)
 	if err != nil {
-		return nil, errors.New("allocation_fetch_error", "Error fetching the allocation."+err.Error())
-	}
-	allocationObj := &Allocation{}
-	err = json.Unmarshal(allocationBytes, allocationObj)
-	if err != nil {
-		return nil, errors.New("allocation_decode_error", "Error decoding the allocation: "+err.Error()+" "+string(allocationBytes))
+		return nil, err
 	}
 	hashdata := allocationObj.Tx
 	sig, ok := zboxutil.SignCache.Get(hashdata)
@@ -821,6 +807,23 @@ func GetAllocation(allocationID string) (*Allocation, error) {
 	allocationObj.sig = sig
 	allocationObj.numBlockDownloads = numBlockDownloads
 	allocationObj.InitAllocation()
+	checkLowBalance(allocationObj)
+	return allocationObj, nil
+}
+
+// fetchAllocation fetches an allocation from the sharders, not initialized.
+func fetchAllocation(allocationID string) (*Allocation, error) {
+	params := make(map[string]string)
+	params["allocation"] = allocationID
+	allocationBytes, err := zboxutil.MakeSCRestAPICall(STORAGE_SCADDRESS, "/allocation", params, nil)
+	if err != nil {
+		return nil, errors.New("allocation_fetch_error", "Error fetching the allocation."+err.Error())
+	}
+	allocationObj := &Allocation{}
+	err = json.Unmarshal(allocationBytes, allocationObj)
+	if err != nil {
+		return nil, errors.New("allocation_decode_error", "Error decoding the allocation: "+err.Error()+" "+string(allocationBytes))
+	}
 	return allocationObj, nil
 }
 
@@ -876,7 +879,7 @@ func SetNumBlockDownloads(num int) {
 //
 // returns the list of allocations and error if any
 func GetAllocations() ([]*Allocation, error) {
-	return GetAllocationsForClient(client.GetClientID())
+	return GetAllocationsForClient(client.GetClientID(), nil)
 }
 
 func getAllocationsInternal(clientID string, limit, offset int) ([]*Allocation, error) {
@@ -884,50 +887,112 @@ func getAllocationsInternal(clientID string, limit, offset int) ([]*Allocation,
 	params["client"] = clientID
 	params["limit"] = fmt.Sprint(limit)
 	params["offset"] = fmt.Sprint(offset)
-	allocationsBytes, err := zboxutil.MakeSCRestAPICall(STORAGE_SCADDRESS, "/allocations", params, nil)
+	allocations, err := restclient.Get[[]*Allocation](context.TODO(), scRestClient, STORAGE_SCADDRESS, "/allocations", params)
 	if err != nil {
 		return nil, errors.New("allocations_fetch_error", "Error fetching the allocations."+err.Error())
 	}
-	allocations := make([]*Allocation, 0)
-	err = json.Unmarshal(allocationsBytes, &allocations)
-	if err != nil {
-		return nil, errors.New("allocations_decode_error", "Error decoding the allocations."+err.Error())
-	}
 	return allocations, nil
 }
 
-// GetAllocationsForClient - get all allocations for given client id
+// AllocationStatus is the status of an allocation, see AllocationFilters.
+type AllocationStatus string
+
+const (
+	// AllocationActive is the status of an allocation not expired yet.
+	AllocationActive AllocationStatus = "active"
+	// AllocationExpired is the status of an allocation expired but not finalized yet.
+	AllocationExpired AllocationStatus = "expired"
+	// AllocationFinalized is the status of an allocation finalized or canceled.
+	AllocationFinalized AllocationStatus = "finalized"
+)
+
+// StatusAt returns the status of the allocation at a point in time.
+//   - now: the point in time, e.g. common.Now().
+func (a *Allocation) StatusAt(now common.Timestamp) AllocationStatus {
+	switch {
+	case a.Finalized || a.Canceled:
+		return AllocationFinalized
+	case a.Expiration <= int64(now):
+		return AllocationExpired
+	}
+	return AllocationActive
+}
+
+// AllocationFilters are the filters of the allocations listed by GetAllocationsForClient.
+type AllocationFilters struct {
+	// Statuses are the statuses of the allocations listed, all of them if empty.
+	Statuses []AllocationStatus
+	// Offset is the number of matching allocations skipped, to list them by pages.
+	Offset int
+	// Limit is the maximum number of allocations listed, all of them if not positive.
+	Limit int
+}
+
+func (f *AllocationFilters) match(alloc *Allocation, now common.Timestamp) bool {
+	if len(f.Statuses) == 0 {
+		return true
+	}
+	status := alloc.StatusAt(now)
+	for _, s := range f.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAllocationsForClient - get the allocations owned by the given client id, filtered by status and paginated.
+// The allocations are indexed by owner by the sharders, the ones shared with the client through auth tickets
+// or as a collaborator aren't listed.
 //
 //   - clientID: the client id
+//   - filters: the filters of the allocations, all of them are listed if nil
 //
 // returns the list of allocations and error if any
-func GetAllocationsForClient(clientID string) ([]*Allocation, error) {
+func GetAllocationsForClient(clientID string, filters *AllocationFilters) ([]*Allocation, error) {
 	if !sdkInitialized {
 		return nil, sdkNotInitialized
 	}
-	limit, offset := 20, 0
-
-	allocations, err := getAllocationsInternal(clientID, limit, offset)
-	if err != nil {
-		return nil, err
+	if filters == nil {
+		filters = &AllocationFilters{}
 	}
+	return collectAllocations(filters, common.Now(), func(limit, offset int) ([]*Allocation, error) {
+		return getAllocationsInternal(clientID, limit, offset)
+	})
+}
+
+// collectAllocations collects the pages of allocations returned by fetch matching the filters.
+func collectAllocations(filters *AllocationFilters, now common.Timestamp, fetch func(limit, offset int) ([]*Allocation, error)) ([]*Allocation, error) {
+	limit, offset := 20, 0
+	skip := filters.Offset
 
 	var allocationsFin []*Allocation
-	allocationsFin = append(allocationsFin, allocations...)
 	for {
+		allocations, err := fetch(limit, offset)
+		if err != nil {
+			return allocationsFin, err
+		}
+		for _, alloc := range allocations {
+			if !filters.match(alloc, now) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			allocationsFin = append(allocationsFin, alloc)
+			if filters.Limit > 0 && len(allocationsFin) == filters.Limit {
+				return allocationsFin, nil
+			}
+		}
+
 		// if the len of output returned is less than the limit it means this is the last round of pagination
 		if len(allocations) < limit {
 			break
 		}
 
-		// get the next set of blobbers
-		offset += 20
-		allocations, err = getAllocationsInternal(clientID, limit, offset)
-		if err != nil {
-			return allocations, err
-		}
-		allocationsFin = append(allocationsFin, allocations...)
-
+		// get the next set of allocations
+		offset += limit
 	}
 	return allocationsFin, nil
 }
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectAllocations(t *testing.T) {
+	const now = common.Timestamp(1000)
+	var all []*Allocation
+	for i := 0; i < 45; i++ {
+		alloc := &Allocation{ID: fmt.Sprint(i), Expiration: int64(now) + 1}
+		switch i % 3 {
+		case 1:
+			alloc.Expiration = int64(now)
+		case 2:
+			alloc.Finalized = true
+		}
+		all = append(all, alloc)
+	}
+	var offsets []int
+	fetch := func(limit, offset int) ([]*Allocation, error) {
+		offsets = append(offsets, offset)
+		if offset >= len(all) {
+			return nil, nil
+		}
+		return all[offset:min(offset+limit, len(all))], nil
+	}
+	ids := func(allocs []*Allocation) (ids []string) {
+		for _, alloc := range allocs {
+			ids = append(ids, alloc.ID)
+		}
+		return
+	}
+
+	allocs, err := collectAllocations(&AllocationFilters{}, now, fetch)
+	require.NoError(t, err)
+	require.Len(t, allocs, 45)
+	require.Equal(t, []int{0, 20, 40}, offsets)
+
+	offsets = nil
+	allocs, err = collectAllocations(&AllocationFilters{Statuses: []AllocationStatus{AllocationExpired}, Offset: 2, Limit: 3}, now, fetch)
+	require.NoError(t, err)
+	require.Equal(t, []string{"7", "10", "13"}, ids(allocs))
+	require.Equal(t, []int{0}, offsets)
+
+	allocs, err = collectAllocations(&AllocationFilters{Statuses: []AllocationStatus{AllocationActive, AllocationFinalized}, Offset: 28}, now, fetch)
+	require.NoError(t, err)
+	require.Equal(t, []string{"42", "44"}, ids(allocs))
+
+	_, err = collectAllocations(&AllocationFilters{}, now, func(int, int) ([]*Allocation, error) {
+		return nil, errors.New("allocations_fetch_error")
+	})
+	require.Error(t, err)
+
+	require.Equal(t, AllocationFinalized, (&Allocation{Canceled: true, Expiration: int64(now) + 1}).StatusAt(now))
+}
@@ -0,0 +1,200 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/marker"
+)
+
+// shareLinkTimeout is the timeout of the requests to the share link resolver.
+const shareLinkTimeout = 30 * time.Second
+
+// ShareLinkResolver exchanges the auth tickets for short codes, so the apps share human-sized links
+// instead of the base64 encoded tickets. Implementations must be safe for concurrent use.
+type ShareLinkResolver interface {
+	// Register stores an auth ticket and returns its short code.
+	Register(ctx context.Context, authTicket string) (string, error)
+	// Resolve returns the auth ticket of a short code.
+	Resolve(ctx context.Context, code string) (string, error)
+}
+
+var (
+	shareLinkMu       sync.RWMutex
+	shareLinkResolver ShareLinkResolver
+)
+
+// SetShareLinkResolver sets the resolver of the share links, e.g. a HTTPShareLinkResolver. There's no
+// resolver by default.
+//   - resolver: the resolver of the share links, nil unsets it
+func SetShareLinkResolver(resolver ShareLinkResolver) {
+	shareLinkMu.Lock()
+	defer shareLinkMu.Unlock()
+	shareLinkResolver = resolver
+}
+
+func getShareLinkResolver() (ShareLinkResolver, error) {
+	shareLinkMu.RLock()
+	defer shareLinkMu.RUnlock()
+	if shareLinkResolver == nil {
+		return nil, errors.New("share_link_resolver_not_set", "no share link resolver, see SetShareLinkResolver")
+	}
+	return shareLinkResolver, nil
+}
+
+// CreateShareLink registers an auth ticket with the share link resolver and returns its short code,
+// see GetAuthTicket and ResolveShareLink.
+//   - authTicket: the auth ticket, base64 encoded
+func CreateShareLink(authTicket string) (string, error) {
+	if _, err := decodeAuthTicket(authTicket); err != nil {
+		return "", err
+	}
+	resolver, err := getShareLinkResolver()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shareLinkTimeout)
+	defer cancel()
+	code, err := resolver.Register(ctx, authTicket)
+	if err != nil {
+		return "", errors.Wrap(err, "share link registration failed")
+	}
+	return code, nil
+}
+
+// ResolveShareLink returns the auth ticket of the short code of a share link, see CreateShareLink.
+//   - code: the short code of the share link
+func ResolveShareLink(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "", errors.New("invalid_share_link", "empty share link code")
+	}
+	resolver, err := getShareLinkResolver()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shareLinkTimeout)
+	defer cancel()
+	authTicket, err := resolver.Resolve(ctx, code)
+	if err != nil {
+		return "", errors.Wrap(err, "share link resolution failed")
+	}
+	// the resolver isn't trusted to return a valid ticket.
+	if _, err = decodeAuthTicket(authTicket); err != nil {
+		return "", err
+	}
+	return authTicket, nil
+}
+
+// decodeAuthTicket decodes a base64 encoded auth ticket.
+func decodeAuthTicket(authTicket string) (*marker.AuthTicket, error) {
+	sEnc, err := base64.StdEncoding.DecodeString(authTicket)
+	if err != nil {
+		return nil, errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+	}
+	at := &marker.AuthTicket{}
+	if err = json.Unmarshal(sEnc, at); err != nil {
+		return nil, errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+	}
+	return at, nil
+}
+
+// HTTPShareLinkResolver is a share link resolver service. The auth tickets are registered with a POST
+// request to the endpoint, with a {"auth_ticket": ...} body answered by {"code": ...}, and resolved with
+// a GET request to the endpoint followed by the code, answered by {"auth_ticket": ...}.
+type HTTPShareLinkResolver struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPShareLinkResolver creates a resolver of the share links of a service.
+//   - endpoint: the url of the share links of the service, e.g. https://example.com/v1/sharelink
+func NewHTTPShareLinkResolver(endpoint string) (*HTTPShareLinkResolver, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("invalid_share_link_endpoint", fmt.Sprintf("invalid share link endpoint %q", endpoint))
+	}
+	return &HTTPShareLinkResolver{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		client:   &http.Client{},
+	}, nil
+}
+
+type shareLinkBody struct {
+	AuthTicket string `json:"auth_ticket,omitempty"`
+	Code       string `json:"code,omitempty"`
+}
+
+// Register stores an auth ticket with the service and returns its short code.
+//   - ctx: the context of the request
+//   - authTicket: the auth ticket, base64 encoded
+func (r *HTTPShareLinkResolver) Register(ctx context.Context, authTicket string) (string, error) {
+	body, err := json.Marshal(shareLinkBody{AuthTicket: authTicket})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.do(req)
+	if err != nil {
+		return "", err
+	}
+	if resp.Code == "" {
+		return "", errors.New("invalid_share_link_response", "no code in the response of the share link service")
+	}
+	return resp.Code, nil
+}
+
+// Resolve returns the auth ticket of a short code from the service.
+//   - ctx: the context of the request
+//   - code: the short code of the share link
+func (r *HTTPShareLinkResolver) Resolve(ctx context.Context, code string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint+"/"+url.PathEscape(code), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.do(req)
+	if err != nil {
+		return "", err
+	}
+	if resp.AuthTicket == "" {
+		return "", errors.New("invalid_share_link_response", "no auth ticket in the response of the share link service")
+	}
+	return resp.AuthTicket, nil
+}
+
+func (r *HTTPShareLinkResolver) do(req *http.Request) (*shareLinkBody, error) {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.New("share_link_not_found", "the share link doesn't exist or expired")
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, errors.New("share_link_service_error", fmt.Sprintf("share link service response %d: %s", resp.StatusCode, string(respBody)))
+	}
+	var body shareLinkBody
+	if err = json.Unmarshal(respBody, &body); err != nil {
+		return nil, errors.Wrap(err, "invalid_share_link_response")
+	}
+	return &body, nil
+}
@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/marker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLink(t *testing.T) {
+	var mu sync.Mutex
+	tickets := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPost {
+			var body shareLinkBody
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			tickets["abc123"] = body.AuthTicket
+			json.NewEncoder(w).Encode(shareLinkBody{Code: "abc123"}) //nolint: errcheck
+			return
+		}
+		ticket, ok := tickets[strings.TrimPrefix(r.URL.Path, "/v1/sharelink/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(shareLinkBody{AuthTicket: ticket}) //nolint: errcheck
+	}))
+	defer server.Close()
+	defer SetShareLinkResolver(nil)
+
+	data, err := json.Marshal(marker.AuthTicket{AllocationID: "alloc", FileName: "a.txt"})
+	require.NoError(t, err)
+	authTicket := base64.StdEncoding.EncodeToString(data)
+
+	_, err = CreateShareLink(authTicket)
+	require.Error(t, err)
+
+	_, err = NewHTTPShareLinkResolver("not a url")
+	require.Error(t, err)
+	resolver, err := NewHTTPShareLinkResolver(server.URL + "/v1/sharelink/")
+	require.NoError(t, err)
+	SetShareLinkResolver(resolver)
+
+	_, err = CreateShareLink("invalid")
+	require.Error(t, err)
+	code, err := CreateShareLink(authTicket)
+	require.NoError(t, err)
+	require.Equal(t, "abc123", code)
+
+	resolved, err := ResolveShareLink(code)
+	require.NoError(t, err)
+	require.Equal(t, authTicket, resolved)
+
+	_, err = ResolveShareLink("unknown")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "share_link_not_found")
+}
@@ -115,7 +115,7 @@ func (a *Allocation) GetRemoteFileMap(exclMap map[string]int, remotepath string)
 }
 
 func calcFileHash(filePath string) string {
-	fp, err := os.Open(filePath)
+	fp, err := sys.Files.Open(filePath)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -380,7 +380,7 @@ func (a *Allocation) SaveRemoteSnapshot(pathToSave string, remoteExcludePath []s
 
 	// Now we got the list from remote, delete the file if exists
 	if bIsFileExists {
-		err = os.Remove(pathToSave)
+		err = sys.Files.Remove(pathToSave)
 		if err != nil {
 			return errors.Wrap(err, "error deleting previous cache.")
 		}
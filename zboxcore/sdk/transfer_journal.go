@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+)
+
+// Transfer directions of the transfer records.
+const (
+	TransferUpload   = "upload"
+	TransferDownload = "download"
+)
+
+// TransferRecord is an in-progress upload or download recorded in the transfer journal, with what is
+// needed to start it again. The chunks already transferred are skipped by the upload and download
+// progress storers when it's restarted.
+type TransferRecord struct {
+	ID           string `json:"id"`
+	Direction    string `json:"direction"`
+	AllocationID string `json:"allocation_id"`
+	RemotePath   string `json:"remote_path,omitempty"`
+	LocalPath    string `json:"local_path"`
+	Workdir      string `json:"workdir,omitempty"`
+
+	// upload options
+	ThumbnailPath string `json:"thumbnail_path,omitempty"`
+	Encrypt       bool   `json:"encrypt,omitempty"`
+	WebStreaming  bool   `json:"web_streaming,omitempty"`
+	IsUpdate      bool   `json:"is_update,omitempty"`
+
+	// download from an auth ticket
+	AuthTicket     string `json:"auth_ticket,omitempty"`
+	LookupHash     string `json:"lookup_hash,omitempty"`
+	RemoteFileName string `json:"remote_file_name,omitempty"`
+
+	// Offset is the number of bytes transferred when the record was last saved.
+	Offset int64 `json:"offset"`
+	// TotalBytes is the size of the transfer, 0 until it's started.
+	TotalBytes int64 `json:"total_bytes"`
+}
+
+// TransferID returns the id of the transfer record of a file, a transfer of the same file replaces
+// the previous record.
+//   - direction: TransferUpload or TransferDownload
+//   - allocationID: the allocation of the file
+//   - remotePath: the remote path of the file, or the lookup hash for the downloads from an auth ticket
+//   - localPath: the local path of the file
+func TransferID(direction, allocationID, remotePath, localPath string) string {
+	return encryption.Hash(direction + ":" + allocationID + ":" + remotePath + ":" + localPath)
+}
+
+// TransferJournal persists the in-progress transfers so apps killed by the OS, e.g. mobile apps,
+// can resume them on the next launch. Implementations must be safe for concurrent use.
+type TransferJournal interface {
+	// Save creates or replaces the record of a transfer.
+	Save(rec TransferRecord) error
+	// Remove removes the record of a transfer, e.g. once it's completed.
+	Remove(id string) error
+	// List returns the records of the transfers not completed.
+	List() ([]TransferRecord, error)
+}
+
+// FsTransferJournal is a TransferJournal storing each record in a json file of a directory.
+type FsTransferJournal struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFsTransferJournal creates a transfer journal in the directory, it's created if missing.
+//   - dir: the directory of the records, e.g. ~/.zcn/transfers
+func NewFsTransferJournal(dir string) (*FsTransferJournal, error) {
+	if err := os.MkdirAll(dir, 0766); err != nil {
+		return nil, errors.Wrap(err, "create transfer journal")
+	}
+	return &FsTransferJournal{dir: dir}, nil
+}
+
+// Save creates or replaces the record of a transfer.
+func (j *FsTransferJournal) Save(rec TransferRecord) error {
+	if rec.ID == "" {
+		return errors.New("transfer_journal", "missing transfer id")
+	}
+	buf, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	// write then rename, so a killed app doesn't leave a truncated record
+	tmp := j.path(rec.ID) + ".tmp"
+	if err = os.WriteFile(tmp, buf, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path(rec.ID))
+}
+
+// Remove removes the record of a transfer.
+func (j *FsTransferJournal) Remove(id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	err := os.Remove(j.path(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List returns the records of the transfers not completed, the unreadable records are skipped.
+func (j *FsTransferJournal) List() ([]TransferRecord, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []TransferRecord
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		buf, err := os.ReadFile(filepath.Join(j.dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var rec TransferRecord
+		if err = json.Unmarshal(buf, &rec); err != nil {
+			continue
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func (j *FsTransferJournal) path(id string) string {
+	return filepath.Join(j.dir, id+".json")
+}
@@ -0,0 +1,46 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFsTransferJournal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "transfers")
+	j, err := NewFsTransferJournal(dir)
+	require.NoError(t, err)
+
+	up := TransferRecord{
+		ID:           TransferID(TransferUpload, "alloc", "/a.txt", "/tmp/a.txt"),
+		Direction:    TransferUpload,
+		AllocationID: "alloc",
+		RemotePath:   "/a.txt",
+		LocalPath:    "/tmp/a.txt",
+	}
+	down := up
+	down.ID = TransferID(TransferDownload, "alloc", "/a.txt", "/tmp/a.txt")
+	down.Direction = TransferDownload
+	require.NotEqual(t, up.ID, down.ID)
+
+	require.NoError(t, j.Save(up))
+	require.NoError(t, j.Save(down))
+	up.Offset = 64 * KB
+	require.NoError(t, j.Save(up))
+	// the unreadable records are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0666))
+
+	records, err := j.List()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []TransferRecord{up, down}, records)
+
+	require.NoError(t, j.Remove(down.ID))
+	require.NoError(t, j.Remove(down.ID), "removing a missing record is not an error")
+	records, err = j.List()
+	require.NoError(t, err)
+	require.Equal(t, []TransferRecord{up}, records)
+
+	require.Error(t, j.Save(TransferRecord{}))
+}
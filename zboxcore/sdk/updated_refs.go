@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/marker"
+)
+
+// refsDateFormat is the format of the dates of the refs requests.
+const refsDateFormat = "2006-01-02T15:04:05.99999Z07:00"
+
+const defaultUpdatedRefsPageLimit = 100
+
+// UpdatedRefsResult is the result of an incremental scan of an allocation.
+type UpdatedRefsResult struct {
+	// Refs are the files and directories updated since the scan point, by update time and path.
+	Refs []ORef `json:"refs"`
+	// Until is the latest update time of the refs, pass it as the scan point of the next scan.
+	// It's the scan point if no ref was updated.
+	Until common.Timestamp `json:"until"`
+}
+
+// GetUpdatedRefs gets the files and directories of the allocation updated since a point in time, so backup
+// tools can scan the allocation incrementally instead of walking the whole tree. The refs are paginated
+// from the blobbers with consensus, the pages are collected.
+// The refs updated at the scan point itself are returned again, a ref updated in the same second as the
+// previous scan isn't missed. The deleted refs aren't returned, compare the listings for them.
+//   - ctx: the context of the requests.
+//   - since: the scan point, e.g. the Until of the previous scan or the timestamp of a write marker,
+//     see UpdatedSinceWriteMarker.
+//   - pageLimit: the number of refs requested per page, 100 if not positive.
+func (a *Allocation) GetUpdatedRefs(ctx context.Context, since common.Timestamp, pageLimit int) (*UpdatedRefsResult, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if pageLimit <= 0 {
+		pageLimit = defaultUpdatedRefsPageLimit
+	}
+
+	return collectUpdatedRefs(ctx, since, pageLimit, func(offsetPath, offsetDate string) (*ObjectTreeResult, error) {
+		return a.getRefs("/", "", "", offsetPath, formatRefsDate(since), offsetDate, "", "", 0, pageLimit, WithObjectContext(ctx))
+	})
+}
+
+// UpdatedSinceWriteMarker returns the scan point of GetUpdatedRefs of a write marker, the changes
+// committed with the write marker included.
+//   - wm: the write marker, e.g. the latest one of a blobber when the previous scan finished.
+func UpdatedSinceWriteMarker(wm *marker.WriteMarker) common.Timestamp {
+	return common.Timestamp(wm.Timestamp)
+}
+
+func formatRefsDate(t common.Timestamp) string {
+	return time.Unix(int64(t), 0).UTC().Format(refsDateFormat)
+}
+
+// collectUpdatedRefs collects the pages of updated refs returned by fetch, which gets the page after
+// an offset path and date.
+func collectUpdatedRefs(ctx context.Context, since common.Timestamp, pageLimit int, fetch func(offsetPath, offsetDate string) (*ObjectTreeResult, error)) (*UpdatedRefsResult, error) {
+	refs := make(map[string]ORef)
+	var offsetPath, offsetDate string
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := fetch(offsetPath, offsetDate)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get updated refs")
+		}
+		for _, ref := range page.Refs {
+			// the pages overlap when several refs are updated at the offset date
+			if prev, ok := refs[ref.Path]; !ok || ref.UpdatedAt >= prev.UpdatedAt {
+				refs[ref.Path] = ref
+			}
+		}
+		if len(page.Refs) < pageLimit {
+			break
+		}
+
+		last := page.Refs[len(page.Refs)-1]
+		nextPath, nextDate := page.OffsetPath, page.OffsetDate
+		if nextPath == "" {
+			nextPath = last.Path
+		}
+		if nextDate == "" {
+			nextDate = formatRefsDate(last.UpdatedAt)
+		}
+		if nextPath == offsetPath && nextDate == offsetDate {
+			break
+		}
+		offsetPath, offsetDate = nextPath, nextDate
+	}
+
+	result := &UpdatedRefsResult{Refs: make([]ORef, 0, len(refs)), Until: since}
+	for _, ref := range refs {
+		result.Refs = append(result.Refs, ref)
+		if ref.UpdatedAt > result.Until {
+			result.Until = ref.UpdatedAt
+		}
+	}
+	sort.Slice(result.Refs, func(i, j int) bool {
+		if result.Refs[i].UpdatedAt != result.Refs[j].UpdatedAt {
+			return result.Refs[i].UpdatedAt < result.Refs[j].UpdatedAt
+		}
+		return result.Refs[i].Path < result.Refs[j].Path
+	})
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/marker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectUpdatedRefs(t *testing.T) {
+	ref := func(path string, updatedAt common.Timestamp) ORef {
+		return ORef{SimilarField: SimilarField{Path: path}, UpdatedAt: updatedAt}
+	}
+	pages := []*ObjectTreeResult{
+		{Refs: []ORef{ref("/b", 10), ref("/a", 11)}, OffsetPath: "/a"},
+		// the second page starts again at the offset date
+		{Refs: []ORef{ref("/a", 11), ref("/c", 12)}},
+		{Refs: []ORef{ref("/d", 12)}},
+	}
+	var offsets [][2]string
+	result, err := collectUpdatedRefs(context.Background(), 5, 2, func(offsetPath, offsetDate string) (*ObjectTreeResult, error) {
+		offsets = append(offsets, [2]string{offsetPath, offsetDate})
+		page := pages[0]
+		pages = pages[1:]
+		return page, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][2]string{
+		{"", ""},
+		{"/a", formatRefsDate(11)},
+		{"/c", formatRefsDate(12)},
+	}, offsets)
+
+	var paths []string
+	for _, r := range result.Refs {
+		paths = append(paths, r.Path)
+	}
+	require.Equal(t, []string{"/b", "/a", "/c", "/d"}, paths)
+	require.Equal(t, common.Timestamp(12), result.Until)
+
+	result, err = collectUpdatedRefs(context.Background(), 5, 2, func(string, string) (*ObjectTreeResult, error) {
+		return &ObjectTreeResult{}, nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Refs)
+	require.Equal(t, common.Timestamp(5), result.Until)
+
+	_, err = collectUpdatedRefs(context.Background(), 5, 2, func(string, string) (*ObjectTreeResult, error) {
+		return nil, errors.New("consensus_failed")
+	})
+	require.Error(t, err)
+
+	require.Equal(t, common.Timestamp(42), UpdatedSinceWriteMarker(&marker.WriteMarker{Timestamp: 42}))
+	require.Equal(t, "1970-01-01T00:00:11Z", formatRefsDate(11))
+}
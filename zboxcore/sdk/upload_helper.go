@@ -22,4 +22,6 @@ type UploadResult struct {
 	ShardSize  int64  `json:"size"`
 	Hash       string `json:"content_hash,omitempty"`
 	MerkleRoot string `json:"merkle_root,omitempty"`
+	// DataHash is the hash of the shard stored, computed by the blobber, returned once the upload is final.
+	DataHash string `json:"data_hash,omitempty"`
 }
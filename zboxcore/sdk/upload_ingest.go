@@ -0,0 +1,540 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+const (
+	// TusVersion is the version of the TUS resumable upload protocol supported by TusHandler.
+	TusVersion = "1.0.0"
+	// DefaultTusUploadExpiry is the default time a TUS upload is kept without receiving any data,
+	// see TusHandler.SetUploadExpiry.
+	DefaultTusUploadExpiry = time.Hour
+
+	tusExtensions       = "creation,termination,expiration"
+	tusOffsetOctetMedia = "application/offset+octet-stream"
+	defaultMimeType     = "application/octet-stream"
+)
+
+// IngestedFile is a file received by an ingestion helper and uploaded to the allocation.
+type IngestedFile struct {
+	RemotePath string `json:"remote_path"`
+	MimeType   string `json:"mimetype"`
+	Size       int64  `json:"size"`
+}
+
+// UploadMultipartForm streams every file part of a multipart/form-data request to the allocation,
+// without buffering the files in memory or on disk. Non-file form fields are ignored.
+// Parts are uploaded one by one in the order they appear in the request body.
+//   - r: the multipart/form-data request.
+//   - workdir: the working directory used by the chunked uploader.
+//   - remoteDir: the absolute remote directory the files are uploaded to.
+//   - encrypt: encrypt the files on upload or not.
+//   - opts: the options of the upload operations.
+//
+// returns the uploaded files. On error, the files uploaded before the failed part are returned as well.
+func (a *Allocation) UploadMultipartForm(r *http.Request, workdir, remoteDir string, encrypt bool, opts ...ChunkedUploadOption) ([]*IngestedFile, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if !a.CanUpload() {
+		return nil, constants.ErrFileOptionNotPermitted
+	}
+	remoteDir = zboxutil.RemoteClean(remoteDir)
+	if !zboxutil.IsRemoteAbs(remoteDir) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid multipart request")
+	}
+
+	var files []*IngestedFile
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return files, errors.Wrap(err, "failed to read multipart request")
+		}
+		if part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		fileName := path.Base(part.FileName())
+		cr := &countingReader{r: part}
+		file := &IngestedFile{
+			RemotePath: path.Join(remoteDir, fileName),
+			MimeType:   ingestMimeType(fileName, part.Header.Get("Content-Type")),
+		}
+		err = a.ingestFile(workdir, file, cr, 0, encrypt, opts)
+		part.Close()
+		if err != nil {
+			return files, err
+		}
+		file.Size = cr.n
+		files = append(files, file)
+	}
+}
+
+// MultipartUploadHandler returns a http handler accepting multipart/form-data uploads with
+// UploadMultipartForm. The uploaded files are written as json in the response.
+//   - workdir: the working directory used by the chunked uploader.
+//   - remoteDir: the absolute remote directory the files are uploaded to.
+//   - encrypt: encrypt the files on upload or not.
+//   - opts: the options of the upload operations.
+func (a *Allocation) MultipartUploadHandler(workdir, remoteDir string, encrypt bool, opts ...ChunkedUploadOption) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodPut {
+			w.Header().Set("Allow", "POST, PUT")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		files, err := a.UploadMultipartForm(r, workdir, remoteDir, encrypt, opts...)
+		if err != nil {
+			l.Logger.Error("multipart upload failed: ", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(files) //nolint: errcheck
+	})
+}
+
+// ingestFile uploads the content of reader to file.RemotePath. size is the expected size of the file,
+// zero if it's unknown.
+func (a *Allocation) ingestFile(workdir string, file *IngestedFile, reader io.Reader, size int64, encrypt bool, opts []ChunkedUploadOption) error {
+	options := []ChunkedUploadOption{WithEncrypt(encrypt)}
+	options = append(options, opts...)
+
+	_, fileName := path.Split(file.RemotePath)
+	return a.DoMultiOperation([]OperationRequest{
+		{
+			OperationType: constants.FileOperationInsert,
+			Workdir:       workdir,
+			RemotePath:    file.RemotePath,
+			FileMeta: FileMeta{
+				ActualSize: size,
+				MimeType:   file.MimeType,
+				RemoteName: fileName,
+				RemotePath: file.RemotePath,
+			},
+			FileReader:   reader,
+			StreamUpload: size == 0,
+			Opts:         options,
+		},
+	})
+}
+
+// TusHandler is a http handler implementing the core, creation, termination and expiration parts of
+// the TUS resumable upload protocol (https://tus.io/protocols/resumable-upload). Every upload is piped
+// straight into the chunked uploader of the allocation while its PATCH requests are received,
+// so the file is never stored on the server.
+//
+// The offset of an upload is the number of bytes accepted by the chunked uploader. Because the
+// uploader keeps a single stream to the blobbers, an upload is lost if the server is restarted
+// and it has to be started over by the client.
+type TusHandler struct {
+	allocation *Allocation
+	workdir    string
+	remoteDir  string
+	encrypt    bool
+	opts       []ChunkedUploadOption
+	// expiry is the time an upload is kept without receiving any data.
+	expiry time.Duration
+
+	mu      sync.Mutex
+	uploads map[string]*tusUpload
+}
+
+type tusUpload struct {
+	// mu is held by the PATCH request being served.
+	mu sync.Mutex
+
+	file   *IngestedFile
+	length int64
+	offset int64
+	// chunkDataSize is the size of the original data consumed by a single chunk of the uploader.
+	chunkDataSize int64
+
+	pw   *io.PipeWriter
+	done chan struct{}
+	err  error
+
+	// expiry is the time the upload is kept without receiving any data.
+	expiry time.Duration
+	// expireTimer expires the upload once idle, see TusHandler.expire.
+	expireTimer *time.Timer
+	// expires is the unix time in nanoseconds the upload expires at, unless it receives data.
+	expires int64
+}
+
+// TusUploadStatus is the state of a TUS upload in progress.
+type TusUploadStatus struct {
+	ID         string `json:"id"`
+	RemotePath string `json:"remote_path"`
+	Length     int64  `json:"length"`
+	Offset     int64  `json:"offset"`
+	// ChunkIndex is the index of the last chunk fully received by the uploader, -1 if none.
+	ChunkIndex int `json:"chunk_index"`
+}
+
+// NewTusHandler creates a TusHandler uploading files to the allocation. Files are named after the
+// "filename" key of the Upload-Metadata header of their creation request.
+//   - workdir: the working directory used by the chunked uploader.
+//   - remoteDir: the absolute remote directory the files are uploaded to.
+//   - encrypt: encrypt the files on upload or not.
+//   - opts: the options of the upload operations.
+func (a *Allocation) NewTusHandler(workdir, remoteDir string, encrypt bool, opts ...ChunkedUploadOption) (*TusHandler, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	remoteDir = zboxutil.RemoteClean(remoteDir)
+	if !zboxutil.IsRemoteAbs(remoteDir) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+	return &TusHandler{
+		allocation: a,
+		workdir:    workdir,
+		remoteDir:  remoteDir,
+		encrypt:    encrypt,
+		opts:       opts,
+		expiry:     DefaultTusUploadExpiry,
+		uploads:    make(map[string]*tusUpload),
+	}, nil
+}
+
+// SetUploadExpiry sets the time an upload is kept without receiving any data. Once expired, the
+// upload is canceled and its resources are released. It applies to the uploads created afterwards.
+//   - expiry: the expiry of the idle uploads, DefaultTusUploadExpiry by default.
+func (h *TusHandler) SetUploadExpiry(expiry time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.expiry = expiry
+}
+
+// Uploads returns the status of the uploads in progress.
+func (h *TusHandler) Uploads() []TusUploadStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	list := make([]TusUploadStatus, 0, len(h.uploads))
+	for id, u := range h.uploads {
+		offset := u.currentOffset()
+		list = append(list, TusUploadStatus{
+			ID:         id,
+			RemotePath: u.file.RemotePath,
+			Length:     u.length,
+			Offset:     offset,
+			ChunkIndex: tusChunkIndex(offset, u.chunkDataSize),
+		})
+	}
+	return list
+}
+
+// ServeHTTP implements http.Handler.
+func (h *TusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Tus-Resumable", TusVersion)
+
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Tus-Version", TusVersion)
+		w.Header().Set("Tus-Extension", tusExtensions)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Header.Get("Tus-Resumable") != TusVersion {
+		w.Header().Set("Tus-Version", TusVersion)
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		h.create(w, r)
+	case http.MethodHead:
+		h.head(w, r)
+	case http.MethodPatch:
+		h.patch(w, r)
+	case http.MethodDelete:
+		h.terminate(w, r)
+	default:
+		w.Header().Set("Allow", "OPTIONS, POST, HEAD, PATCH, DELETE")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *TusHandler) create(w http.ResponseWriter, r *http.Request) {
+	length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		http.Error(w, "invalid Upload-Length", http.StatusBadRequest)
+		return
+	}
+	metadata, err := parseTusMetadata(r.Header.Get("Upload-Metadata"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fileName := path.Base(metadata["filename"])
+	if fileName == "" || fileName == "." || fileName == "/" {
+		http.Error(w, "filename is required in Upload-Metadata", http.StatusBadRequest)
+		return
+	}
+
+	chunkDataSize := int64(DefaultChunkSize)
+	if h.encrypt {
+		chunkDataSize -= (EncryptedDataPaddingSize + EncryptionHeaderSize)
+	}
+	pr, pw := io.Pipe()
+	u := &tusUpload{
+		file: &IngestedFile{
+			RemotePath: path.Join(h.remoteDir, fileName),
+			MimeType:   ingestMimeType(fileName, metadata["filetype"]),
+			Size:       length,
+		},
+		length:        length,
+		chunkDataSize: chunkDataSize * int64(h.allocation.DataShards),
+		pw:            pw,
+		done:          make(chan struct{}),
+	}
+
+	id := zboxutil.NewConnectionId()
+	h.add(id, u)
+
+	go func() {
+		defer close(u.done)
+		u.err = h.allocation.ingestFile(h.workdir, u.file, pr, length, h.encrypt, h.opts)
+		if u.err != nil {
+			l.Logger.Error("tus upload failed: ", u.file.RemotePath, " ", u.err)
+			pr.CloseWithError(u.err)
+			return
+		}
+		pr.Close()
+	}()
+
+	if length == 0 {
+		pw.Close()
+		<-u.done
+		h.remove(id)
+		if u.err != nil {
+			http.Error(w, u.err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+id)
+	if length > 0 {
+		w.Header().Set("Upload-Expires", u.expiresAt().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *TusHandler) head(w http.ResponseWriter, r *http.Request) {
+	u := h.get(r)
+	if u == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Upload-Offset", strconv.FormatInt(u.currentOffset(), 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(u.length, 10))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *TusHandler) patch(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != tusOffsetOctetMedia {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	u := h.get(r)
+	if u == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !u.mu.TryLock() {
+		http.Error(w, "upload is locked by another request", http.StatusConflict)
+		return
+	}
+	defer u.mu.Unlock()
+
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset != u.offset {
+		w.Header().Set("Upload-Offset", strconv.FormatInt(u.offset, 10))
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	// the uploader reads the pipe in chunks, so every accepted byte is part of a chunk checkpoint
+	n, err := io.Copy(u.pw, io.LimitReader(r.Body, u.length-u.offset))
+	u.setOffset(u.offset + n)
+	u.touch()
+	w.Header().Set("Upload-Offset", strconv.FormatInt(u.offset, 10))
+	if err != nil {
+		select {
+		case <-u.done:
+			h.remove(path.Base(r.URL.Path))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		default:
+			// the client connection was interrupted, it can resume from the current offset
+			w.Header().Set("Upload-Expires", u.expiresAt().Format(http.TimeFormat))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	if u.offset == u.length {
+		u.pw.Close()
+		<-u.done
+		h.remove(path.Base(r.URL.Path))
+		if u.err != nil {
+			http.Error(w, u.err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		w.Header().Set("Upload-Expires", u.expiresAt().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TusHandler) terminate(w http.ResponseWriter, r *http.Request) {
+	u := h.get(r)
+	if u == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	h.remove(path.Base(r.URL.Path))
+	u.pw.CloseWithError(errors.New("upload_terminated", "upload is terminated by the client"))
+	// the uploader is stopped before the termination is acknowledged
+	<-u.done
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// expire cancels an upload which didn't receive any data for its expiry.
+func (h *TusHandler) expire(id string, u *tusUpload) {
+	h.mu.Lock()
+	current := h.uploads[id]
+	h.mu.Unlock()
+	if current != u {
+		return
+	}
+	if !u.mu.TryLock() {
+		// a PATCH request is being served, the upload isn't idle
+		u.touch()
+		return
+	}
+	defer u.mu.Unlock()
+	h.remove(id)
+	l.Logger.Info("tus upload expired: ", u.file.RemotePath)
+	u.pw.CloseWithError(errors.New("upload_expired", "upload didn't receive any data before expiring"))
+}
+
+func (h *TusHandler) get(r *http.Request) *tusUpload {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.uploads[path.Base(r.URL.Path)]
+}
+
+// add adds an upload, expiring if it doesn't receive any data for the expiry of the handler.
+func (h *TusHandler) add(id string, u *tusUpload) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.uploads[id] = u
+	u.expiry = h.expiry
+	atomic.StoreInt64(&u.expires, time.Now().Add(u.expiry).UnixNano())
+	u.expireTimer = time.AfterFunc(u.expiry, func() { h.expire(id, u) })
+}
+
+func (h *TusHandler) remove(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if u, ok := h.uploads[id]; ok {
+		u.expireTimer.Stop()
+		delete(h.uploads, id)
+	}
+}
+
+// currentOffset can be called without holding mu, offset is only written by the PATCH request holding it.
+func (u *tusUpload) currentOffset() int64 {
+	return atomic.LoadInt64(&u.offset)
+}
+
+func (u *tusUpload) setOffset(offset int64) {
+	atomic.StoreInt64(&u.offset, offset)
+}
+
+// touch postpones the expiry of the upload as it received data.
+func (u *tusUpload) touch() {
+	atomic.StoreInt64(&u.expires, time.Now().Add(u.expiry).UnixNano())
+	u.expireTimer.Reset(u.expiry)
+}
+
+func (u *tusUpload) expiresAt() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&u.expires)).UTC()
+}
+
+// tusChunkIndex maps a TUS offset to the index of the last chunk fully read by the uploader.
+func tusChunkIndex(offset, chunkDataSize int64) int {
+	if chunkDataSize <= 0 {
+		return -1
+	}
+	return int(offset/chunkDataSize) - 1
+}
+
+// parseTusMetadata parses the Upload-Metadata header, a comma separated list of
+// key and base64 encoded value pairs.
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, " ")
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.New("invalid_metadata", "invalid Upload-Metadata value of "+key)
+		}
+		metadata[key] = string(decoded)
+	}
+	return metadata, nil
+}
+
+func ingestMimeType(fileName, contentType string) string {
+	if contentType != "" && contentType != defaultMimeType {
+		return contentType
+	}
+	if mimeType := mime.TypeByExtension(path.Ext(fileName)); mimeType != "" {
+		return mimeType
+	}
+	return defaultMimeType
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,115 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+package sdk
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTusMetadata(t *testing.T) {
+	header := "filename " + base64.StdEncoding.EncodeToString([]byte("photo.png")) +
+		", filetype " + base64.StdEncoding.EncodeToString([]byte("image/png")) + ",is_confidential"
+	metadata, err := parseTusMetadata(header)
+	require.NoError(t, err)
+	require.Equal(t, "photo.png", metadata["filename"])
+	require.Equal(t, "image/png", metadata["filetype"])
+	require.Contains(t, metadata, "is_confidential")
+
+	_, err = parseTusMetadata("filename not-base64!")
+	require.Error(t, err)
+}
+
+func TestTusChunkIndex(t *testing.T) {
+	require.Equal(t, -1, tusChunkIndex(0, 100))
+	require.Equal(t, -1, tusChunkIndex(99, 100))
+	require.Equal(t, 0, tusChunkIndex(100, 100))
+	require.Equal(t, 2, tusChunkIndex(350, 100))
+}
+
+func TestTusHandlerProtocol(t *testing.T) {
+	h := &TusHandler{uploads: make(map[string]*tusUpload)}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodOptions, "/files", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, TusVersion, w.Header().Get("Tus-Version"))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/files/unknown", nil))
+	require.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	r := httptest.NewRequest(http.MethodHead, "/files/unknown", nil)
+	r.Header.Set("Tus-Resumable", TusVersion)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	r = httptest.NewRequest(http.MethodPost, "/files", nil)
+	r.Header.Set("Tus-Resumable", TusVersion)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTusHandlerExpiry(t *testing.T) {
+	h := &TusHandler{uploads: make(map[string]*tusUpload), expiry: 20 * time.Millisecond}
+	newUpload := func(id string) *tusUpload {
+		pr, pw := io.Pipe()
+		u := &tusUpload{file: &IngestedFile{RemotePath: "/" + id}, pw: pw, done: make(chan struct{})}
+		go func() {
+			defer close(u.done)
+			_, u.err = io.Copy(io.Discard, pr)
+		}()
+		h.add(id, u)
+		return u
+	}
+
+	// the idle uploads are canceled.
+	u := newUpload("idle")
+	<-u.done
+	require.ErrorContains(t, u.err, "upload_expired")
+	require.Empty(t, h.Uploads())
+
+	// the terminated uploads are stopped before the response.
+	h.expiry = time.Hour
+	u = newUpload("terminated")
+	require.WithinDuration(t, time.Now().Add(time.Hour), u.expiresAt(), time.Second)
+	r := httptest.NewRequest(http.MethodDelete, "/files/terminated", nil)
+	r.Header.Set("Tus-Resumable", TusVersion)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	select {
+	case <-u.done:
+	default:
+		require.Fail(t, "the upload is still running")
+	}
+	require.ErrorContains(t, u.err, "upload_terminated")
+	require.Empty(t, h.Uploads())
+}
+
+func TestSniffMimeType(t *testing.T) {
+	content := "name,size\n" + strings.Repeat("photo.png,10\n", 2000)
+	mimeType, r := sniffMimeType("report", strings.NewReader(content))
+	require.Equal(t, "text/plain; charset=utf-8", mimeType)
+	// the sniffed bytes are uploaded as well
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, content, string(data))
+
+	mimeType, _ = sniffMimeType("report.csv", strings.NewReader(content))
+	require.Equal(t, "text/csv", mimeType)
+
+	su := &ChunkedUpload{fileMeta: FileMeta{MimeType: "text/plain"}}
+	WithMimeType("text/csv")(su)
+	require.Equal(t, "text/csv", su.fileMeta.MimeType)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 
@@ -27,6 +28,28 @@ type UploadOperation struct {
 
 var ErrPauseUpload = errors.New("upload paused by user")
 
+// ErrCancelUpload is the error of an upload canceled with CancelUpload.
+var ErrCancelUpload = errors.New("upload canceled by user")
+
+// UploadCanceledError is the error of an upload canceled with CancelUpload while requests to some
+// blobbers were in flight, it wraps ErrCancelUpload. These requests are abandoned, not aborted: the
+// blobbers may still store their shards, which aren't committed and are discarded once the
+// connection expires.
+type UploadCanceledError struct {
+	ConnectionID string
+	// BlobberIDs are the ids of the blobbers with requests abandoned in flight.
+	BlobberIDs []string
+}
+
+func (e *UploadCanceledError) Error() string {
+	return fmt.Sprintf("%s, requests abandoned in flight on %d blobbers for the connection %s",
+		ErrCancelUpload, len(e.BlobberIDs), e.ConnectionID)
+}
+
+func (e *UploadCanceledError) Unwrap() error {
+	return ErrCancelUpload
+}
+
 func (uo *UploadOperation) Process(allocObj *Allocation, connectionID string) ([]fileref.RefEntity, zboxutil.Uint128, error) {
 	if uo.isDownload {
 		if f, ok := uo.chunkedUpload.fileReader.(*sys.MemChanFile); ok {
@@ -0,0 +1,206 @@
+package sdk
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+)
+
+// usageCacheTTL is how long the directory sizes computed by GetUsage are cached.
+var usageCacheTTL = 5 * time.Minute
+
+var (
+	usageCacheMu sync.Mutex
+	usageCache   = make(map[string]dirSizesEntry)
+)
+
+type dirSizesEntry struct {
+	sizes     map[string]int64
+	expiresAt time.Time
+}
+
+// AllocationUsage is the usage of an allocation, to render the storage quota of an app.
+type AllocationUsage struct {
+	// Size is the size of the allocation.
+	Size int64 `json:"size"`
+	// UsedSize is the size used on the blobbers, including the parity shards.
+	UsedSize int64 `json:"used_size"`
+	// Remaining is the size left in the allocation.
+	Remaining int64 `json:"remaining"`
+	// Blobbers is the usage of each blobber of the allocation.
+	Blobbers []BlobberUsage `json:"blobbers"`
+	// Directories are the sizes of the files under each top-level directory, by path. The files of
+	// the root directory are reported by their own path.
+	Directories map[string]int64 `json:"directories"`
+	// Expiration is the expiration date of the allocation.
+	Expiration int64 `json:"expiration_date"`
+	// ExpiresIn is the time left until the allocation expires, 0 if it's expired.
+	ExpiresIn time.Duration `json:"expires_in"`
+}
+
+// BlobberUsage is the usage of the allocation on a blobber.
+type BlobberUsage struct {
+	BlobberID  string `json:"blobber_id"`
+	BlobberURL string `json:"blobber_url"`
+	// Size is the size of the allocation on the blobber.
+	Size int64 `json:"size"`
+	// UsedSize is the size used on the blobber, -1 if the blobber didn't answer.
+	UsedSize int64 `json:"used_size"`
+}
+
+// UsageOption is an option of GetUsage.
+type UsageOption func(o *usageOptions)
+
+type usageOptions struct {
+	refresh bool
+}
+
+// WithUsageRefresh computes the directory sizes again instead of using the cached ones.
+func WithUsageRefresh() UsageOption {
+	return func(o *usageOptions) {
+		o.refresh = true
+	}
+}
+
+// GetUsage returns the usage of the allocation: the size used on each blobber, the size of the
+// top-level directories, the remaining size and the time to expiry. The directory sizes are
+// computed by listing all the files, they're cached for a few minutes or until a change of the
+// allocation by this client.
+//   - opts: the options of the request, e.g. WithUsageRefresh
+func (a *Allocation) GetUsage(opts ...UsageOption) (*AllocationUsage, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	var options usageOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	dirSizes, err := a.getDirSizes(options.refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &AllocationUsage{
+		Size:        a.Size,
+		Blobbers:    a.getBlobberUsage(),
+		Directories: dirSizes,
+		Expiration:  a.Expiration,
+	}
+	if a.Stats != nil {
+		usage.UsedSize = a.Stats.UsedSize
+	} else {
+		for _, b := range usage.Blobbers {
+			if b.UsedSize > 0 {
+				usage.UsedSize += b.UsedSize
+			}
+		}
+	}
+	if usage.Remaining = a.Size - usage.UsedSize; usage.Remaining < 0 {
+		usage.Remaining = 0
+	}
+	if expiresIn := time.Until(time.Unix(a.Expiration, 0)); expiresIn > 0 {
+		usage.ExpiresIn = expiresIn
+	}
+	return usage, nil
+}
+
+func (a *Allocation) getBlobberUsage() []BlobberUsage {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(a.Blobbers))
+	rspCh := make(chan *BlobberAllocationStats, len(a.Blobbers))
+	for _, blobber := range a.Blobbers {
+		go getAllocationDataFromBlobber(blobber, a.ID, a.Tx, rspCh, wg)
+	}
+	wg.Wait()
+	close(rspCh)
+	stats := make(map[string]*BlobberAllocationStats, len(a.Blobbers))
+	for rsp := range rspCh {
+		stats[rsp.BlobberID] = rsp
+	}
+
+	usage := make([]BlobberUsage, 0, len(a.Blobbers))
+	for _, blobber := range a.Blobbers {
+		bu := BlobberUsage{BlobberID: blobber.ID, BlobberURL: blobber.Baseurl, UsedSize: -1}
+		for _, d := range a.BlobberDetails {
+			if d.BlobberID == blobber.ID {
+				bu.Size = d.Size
+				break
+			}
+		}
+		if s, ok := stats[blobber.ID]; ok {
+			bu.UsedSize = int64(s.UsedSize)
+		}
+		usage = append(usage, bu)
+	}
+	return usage
+}
+
+// getDirSizes returns the sizes of the top-level directories, from the cache if not refreshed.
+func (a *Allocation) getDirSizes(refresh bool) (map[string]int64, error) {
+	usageCacheMu.Lock()
+	entry, ok := usageCache[a.ID]
+	usageCacheMu.Unlock()
+	if ok && !refresh && time.Now().Before(entry.expiresAt) {
+		return copyDirSizes(entry.sizes), nil
+	}
+
+	var (
+		refs       []ORef
+		offsetPath string
+	)
+	for {
+		oResult, err := a.GetRefs("/", offsetPath, "", "", "", fileref.REGULAR, 0, getRefPageLimit, WithObjectContext(a.ctx))
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, oResult.Refs...)
+		if len(oResult.Refs) < getRefPageLimit {
+			break
+		}
+		offsetPath = oResult.Refs[len(oResult.Refs)-1].Path
+	}
+
+	sizes := topLevelSizes(refs)
+	usageCacheMu.Lock()
+	usageCache[a.ID] = dirSizesEntry{sizes: sizes, expiresAt: time.Now().Add(usageCacheTTL)}
+	usageCacheMu.Unlock()
+	return copyDirSizes(sizes), nil
+}
+
+// invalidateUsage removes the cached directory sizes of the allocation once it's changed.
+func invalidateUsage(allocationID string) {
+	usageCacheMu.Lock()
+	delete(usageCache, allocationID)
+	usageCacheMu.Unlock()
+}
+
+// topLevelSizes sums the actual size of the files under each top-level entry of the allocation.
+func topLevelSizes(refs []ORef) map[string]int64 {
+	sizes := make(map[string]int64)
+	for _, ref := range refs {
+		if ref.Type == fileref.DIRECTORY {
+			// the empty top-level directories are reported too
+			if _, ok := sizes[ref.Path]; !ok && ref.Path != "/" && strings.Count(ref.Path, "/") == 1 {
+				sizes[ref.Path] = 0
+			}
+			continue
+		}
+		top := ref.Path
+		if i := strings.Index(strings.TrimPrefix(top, "/"), "/"); i >= 0 {
+			top = top[:i+1]
+		}
+		sizes[top] += ref.ActualFileSize
+	}
+	return sizes
+}
+
+func copyDirSizes(sizes map[string]int64) map[string]int64 {
+	c := make(map[string]int64, len(sizes))
+	for p, s := range sizes {
+		c[p] = s
+	}
+	return c
+}
@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopLevelSizes(t *testing.T) {
+	ref := func(path, refType string, size int64) ORef {
+		return ORef{SimilarField: SimilarField{Path: path, Type: refType, ActualFileSize: size}}
+	}
+	refs := []ORef{
+		ref("/", fileref.DIRECTORY, 0),
+		ref("/photos", fileref.DIRECTORY, 0),
+		ref("/photos/2024", fileref.DIRECTORY, 0),
+		ref("/photos/2024/1.jpg", fileref.FILE, 100),
+		ref("/photos/2.jpg", fileref.FILE, 50),
+		ref("/empty", fileref.DIRECTORY, 0),
+		ref("/notes.txt", fileref.FILE, 10),
+	}
+	require.Equal(t, map[string]int64{
+		"/photos":    150,
+		"/empty":     0,
+		"/notes.txt": 10,
+	}, topLevelSizes(refs))
+}
+
+func TestAllocation_getDirSizes_cache(t *testing.T) {
+	a := &Allocation{ID: "usage_alloc"}
+	usageCacheMu.Lock()
+	usageCache[a.ID] = dirSizesEntry{sizes: map[string]int64{"/a": 1}, expiresAt: time.Now().Add(time.Minute)}
+	usageCacheMu.Unlock()
+
+	sizes, err := a.getDirSizes(false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"/a": 1}, sizes)
+
+	// the cached sizes can't be changed by the caller
+	sizes["/a"] = 2
+	sizes, err = a.getDirSizes(false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), sizes["/a"])
+
+	invalidateUsage(a.ID)
+	usageCacheMu.Lock()
+	_, ok := usageCache[a.ID]
+	usageCacheMu.Unlock()
+	require.False(t, ok)
+}
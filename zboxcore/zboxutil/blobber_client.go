@@ -0,0 +1,76 @@
+package zboxutil
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hitenjain14/fasthttp"
+)
+
+// blobberClient paces the requests to the blobbers throttling them.
+type blobberClient struct {
+	next HttpClient
+}
+
+func (c *blobberClient) Do(req *http.Request) (*http.Response, error) {
+	if err := waitBlobber(req.Context(), req.URL); err != nil {
+		return nil, err
+	}
+	resp, err := c.next.Do(req)
+	if err == nil {
+		observeBlobber(req.URL, resp.StatusCode, resp.Header.Get)
+	}
+	return resp, err
+}
+
+// blobberFastClient paces the fasthttp requests to the blobbers throttling them.
+type blobberFastClient struct {
+	next FastClient
+}
+
+func (c *blobberFastClient) DoTimeout(req *fasthttp.Request, resp *fasthttp.Response, timeout time.Duration) error {
+	u, err := url.Parse(req.URI().String())
+	if err != nil {
+		return c.next.DoTimeout(req, resp, timeout)
+	}
+	// the wait for the blobber counts in the timeout of the request.
+	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	if err = waitBlobber(ctx, u); err != nil {
+		return fasthttp.ErrTimeout
+	}
+	err = c.next.DoTimeout(req, resp, time.Until(deadline))
+	if err == nil {
+		observeBlobber(u, resp.StatusCode(), func(key string) string {
+			return string(resp.Header.Peek(key))
+		})
+	}
+	return err
+}
+
+// FastGetWithRequest sends a request with fastClient and returns the status code and the body of the
+// response, written in buf. The request waits if the blobber is throttling the requests.
+//   - fastClient: the client of the HTTP requests.
+//   - req: the request.
+//   - buf: the buffer of the body of the response.
+func FastGetWithRequest(fastClient *fasthttp.Client, req *fasthttp.Request, buf []byte) (int, []byte, error) {
+	u, err := url.Parse(req.URI().String())
+	if err != nil {
+		return fastClient.GetWithRequest(req, buf)
+	}
+	if err = waitBlobber(context.Background(), u); err != nil {
+		return 0, buf, err
+	}
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+	if err = fastClient.Do(req, resp); err != nil {
+		return 0, buf, err
+	}
+	observeBlobber(u, resp.StatusCode(), func(key string) string {
+		return string(resp.Header.Peek(key))
+	})
+	return resp.StatusCode(), append(buf[:0], resp.Body()...), nil
+}
@@ -0,0 +1,168 @@
+package zboxutil
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// memResponseCacheSize is the number of responses kept by a MemResponseCache, the least recently
+// used ones are evicted first.
+const memResponseCacheSize = 1000
+
+// ResponseCache caches the responses of the sharder reads made with MakeSCRestAPICall.
+// Implementations must be safe for concurrent use, they can be backed by a persistent store
+// so the cache survives restarts of short-lived processes like CLI tools.
+type ResponseCache interface {
+	// Get returns the cached response of the key, false if it's missing or expired.
+	Get(key string) ([]byte, bool)
+	// Set caches the response of the key for the ttl.
+	Set(key string, data []byte, ttl time.Duration)
+	// Invalidate removes the cached responses with keys starting with the prefix.
+	Invalidate(prefix string)
+}
+
+var (
+	responseCacheMu sync.RWMutex
+	responseCache   ResponseCache
+	// responseCacheTTLs are the cached endpoints, see SetResponseCacheTTL.
+	responseCacheTTLs = map[string]time.Duration{
+		"/allocation":     10 * time.Second,
+		"/getblobbers":    30 * time.Second,
+		"/getBlobber":     30 * time.Second,
+		"/storage-config": time.Minute,
+	}
+)
+
+// SetResponseCache sets the cache of the sharder reads. The cache is disabled by default, nil disables it.
+//   - cache: the cache, e.g. NewMemResponseCache()
+func SetResponseCache(cache ResponseCache) {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+	responseCache = cache
+}
+
+// SetResponseCacheTTL sets the TTL of the cached responses of an endpoint. Zero stops caching the endpoint.
+//   - relativePath: the relative path of the endpoint, without query parameters, e.g. "/allocation"
+//   - ttl: the time the responses are cached
+func SetResponseCacheTTL(relativePath string, ttl time.Duration) {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+	if ttl <= 0 {
+		delete(responseCacheTTLs, relativePath)
+		return
+	}
+	responseCacheTTLs[relativePath] = ttl
+}
+
+// InvalidateResponseCache removes the cached responses of a smart contract, e.g. after a transaction
+// changed its state.
+//   - scAddress: the smart contract address, all the responses are removed if empty.
+func InvalidateResponseCache(scAddress string) {
+	responseCacheMu.RLock()
+	cache := responseCache
+	responseCacheMu.RUnlock()
+	if cache != nil {
+		cache.Invalidate(scAddress)
+	}
+}
+
+// MakeSCRestAPICallNoCache is MakeSCRestAPICall bypassing the response cache. The response still
+// refreshes the cache so the next cached reads get it.
+//   - scAddress is the address of the smart contract
+//   - relativePath is the relative path of the api
+//   - params is the query parameters
+//   - handler is the handler function to handle the response
+func MakeSCRestAPICallNoCache(scAddress string, relativePath string, params map[string]string, handler SCRestAPIHandler) ([]byte, error) {
+	b, err := makeSCRestAPICall(scAddress, relativePath, params, handler)
+	if err == nil {
+		cacheResponse(scAddress, relativePath, params, b)
+	}
+	return b, err
+}
+
+// getCachedResponse returns the cached response of the request, false if the endpoint isn't cached.
+func getCachedResponse(scAddress, relativePath string, params map[string]string) ([]byte, bool) {
+	cache, key, _ := responseCacheFor(scAddress, relativePath, params)
+	if cache == nil {
+		return nil, false
+	}
+	return cache.Get(key)
+}
+
+func cacheResponse(scAddress, relativePath string, params map[string]string, data []byte) {
+	cache, key, ttl := responseCacheFor(scAddress, relativePath, params)
+	if cache != nil {
+		cache.Set(key, data, ttl)
+	}
+}
+
+func responseCacheFor(scAddress, relativePath string, params map[string]string) (ResponseCache, string, time.Duration) {
+	responseCacheMu.RLock()
+	defer responseCacheMu.RUnlock()
+	if responseCache == nil {
+		return nil, "", 0
+	}
+
+	path, rawQuery, _ := strings.Cut(relativePath, "?")
+	ttl, ok := responseCacheTTLs[path]
+	if !ok {
+		return nil, "", 0
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, "", 0
+	}
+	for k, v := range params {
+		query.Add(k, v)
+	}
+	// Encode sorts the parameters, so the key doesn't depend on their order
+	return responseCache, scAddress + path + "?" + query.Encode(), ttl
+}
+
+type cachedResponse struct {
+	data    []byte
+	expires time.Time
+}
+
+// MemResponseCache is an in-memory ResponseCache, bounded to the most recently used responses.
+type MemResponseCache struct {
+	entries *lru.Cache[string, cachedResponse]
+}
+
+// NewMemResponseCache creates an in-memory response cache.
+func NewMemResponseCache() *MemResponseCache {
+	entries, _ := lru.New[string, cachedResponse](memResponseCacheSize)
+	return &MemResponseCache{entries: entries}
+}
+
+// Get returns the cached response of the key, false if it's missing or expired.
+func (c *MemResponseCache) Get(key string) ([]byte, bool) {
+	e, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	return e.data, true
+}
+
+// Set caches the response of the key for the ttl.
+func (c *MemResponseCache) Set(key string, data []byte, ttl time.Duration) {
+	c.entries.Add(key, cachedResponse{data: data, expires: time.Now().Add(ttl)})
+}
+
+// Invalidate removes the cached responses with keys starting with the prefix.
+func (c *MemResponseCache) Invalidate(prefix string) {
+	for _, key := range c.entries.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			c.entries.Remove(key)
+		}
+	}
+}
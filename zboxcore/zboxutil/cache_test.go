@@ -0,0 +1,65 @@
+package zboxutil
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	_, ok := getCachedResponse("sc", "/allocation", map[string]string{"allocation": "a"})
+	require.False(t, ok, "the cache is disabled by default")
+
+	SetResponseCache(NewMemResponseCache())
+	defer SetResponseCache(nil)
+
+	cacheResponse("sc", "/allocation", map[string]string{"allocation": "a"}, []byte("alloc a"))
+	cacheResponse("sc", "/getblobbers?limit=20&active=true", nil, []byte("blobbers"))
+	cacheResponse("sc", "/getReadPoolStat", nil, []byte("not cached"))
+
+	b, ok := getCachedResponse("sc", "/allocation", map[string]string{"allocation": "a"})
+	require.True(t, ok)
+	require.Equal(t, "alloc a", string(b))
+
+	_, ok = getCachedResponse("sc", "/allocation", map[string]string{"allocation": "b"})
+	require.False(t, ok)
+
+	// the order of the parameters doesn't matter
+	b, ok = getCachedResponse("sc", "/getblobbers?active=true", map[string]string{"limit": "20"})
+	require.True(t, ok)
+	require.Equal(t, "blobbers", string(b))
+
+	_, ok = getCachedResponse("sc", "/getReadPoolStat", nil)
+	require.False(t, ok)
+
+	InvalidateResponseCache("other")
+	_, ok = getCachedResponse("sc", "/allocation", map[string]string{"allocation": "a"})
+	require.True(t, ok)
+
+	InvalidateResponseCache("sc")
+	_, ok = getCachedResponse("sc", "/allocation", map[string]string{"allocation": "a"})
+	require.False(t, ok)
+
+	SetResponseCacheTTL("/allocation", time.Millisecond)
+	defer SetResponseCacheTTL("/allocation", 10*time.Second)
+	cacheResponse("sc", "/allocation", nil, []byte("alloc"))
+	time.Sleep(5 * time.Millisecond)
+	_, ok = getCachedResponse("sc", "/allocation", nil)
+	require.False(t, ok, "the response expired")
+}
+
+func TestMemResponseCacheSize(t *testing.T) {
+	c := NewMemResponseCache()
+	for i := 0; i <= memResponseCacheSize; i++ {
+		c.Set(strconv.Itoa(i), []byte("response"), time.Minute)
+	}
+	require.Equal(t, memResponseCacheSize, c.entries.Len())
+
+	// the least recently used response is evicted first.
+	_, ok := c.Get("0")
+	require.False(t, ok)
+	_, ok = c.Get(strconv.Itoa(memResponseCacheSize))
+	require.True(t, ok)
+}
@@ -0,0 +1,407 @@
+package zboxutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/hitenjain14/fasthttp"
+)
+
+// FixtureMode is the mode of the HTTP fixtures of the blobber and sharder requests, see StartRecording
+// and StartReplay.
+type FixtureMode int
+
+const (
+	// FixtureModeOff sends the requests to the network, it's the default.
+	FixtureModeOff FixtureMode = iota
+	// FixtureModeRecord sends the requests to the network and records the interactions.
+	FixtureModeRecord
+	// FixtureModeReplay answers the requests with the recorded interactions, without the network.
+	FixtureModeReplay
+)
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+}
+
+// fixtureFile is a fixture file, it holds the interactions of a key in the order they were recorded.
+type fixtureFile struct {
+	Key          string         `json:"key"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// fixtures records or replays the interactions of a fixture directory.
+type fixtures struct {
+	mode FixtureMode
+	dir  string
+
+	mu           sync.Mutex
+	interactions map[string][]*Interaction // by key
+	replayed     map[string]int            // by key
+	// volatile are the values of the volatile parameters seen in the requests, by value.
+	volatile map[string]string
+	// recording are the requests being recorded, saved once they're done.
+	recording sync.WaitGroup
+
+	// the clients replaced by the fixtures
+	client         HttpClient
+	fastHttpClient FastClient
+	sharderClient  HttpClient
+}
+
+var (
+	fixturesMu     sync.Mutex
+	activeFixtures *fixtures
+
+	// volatileParams are the query parameters differing between a recording and its replay.
+	volatileParams = map[string]bool{
+		"connection_id": true,
+		"auth_token":    true,
+	}
+	fixtureBlobbersMu sync.Mutex
+	// fixtureBlobbers are the ids of the blobbers by base url, see RegisterFixtureBlobber.
+	fixtureBlobbers = make(map[string]string)
+)
+
+// RegisterFixtureBlobber matches the requests to a blobber by its id instead of its url, which can differ
+// between a recording and its replay, e.g. for the blobbers of a test network listening on random
+// ports. The blobbers of the allocations are registered by InitAllocation.
+//   - baseURL: the base url of the blobber
+//   - id: the id of the blobber
+func RegisterFixtureBlobber(baseURL, id string) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return
+	}
+	fixtureBlobbersMu.Lock()
+	defer fixtureBlobbersMu.Unlock()
+	fixtureBlobbers[u.Host+u.Path] = id
+}
+
+// fixtureHost returns the name of the server of a request in the keys: the id of its blobber if
+// registered, the host otherwise. The path of the base url of the blobber is removed from the path.
+func fixtureHost(u *url.URL) (string, string) {
+	fixtureBlobbersMu.Lock()
+	defer fixtureBlobbersMu.Unlock()
+	for base, id := range fixtureBlobbers {
+		baseHost, basePath, _ := strings.Cut(base, "/")
+		if basePath != "" {
+			basePath = "/" + basePath
+		}
+		if baseHost == u.Host && (u.Path == basePath || strings.HasPrefix(u.Path, basePath+"/")) {
+			return "blobber:" + id, strings.TrimPrefix(u.Path, basePath)
+		}
+	}
+	return u.Host, u.Path
+}
+
+// AddFixtureVolatileParam adds a query parameter differing between a recording and its replay, e.g. a
+// random id. The parameter is ignored to match the requests, and its values are ignored in the paths
+// of the next requests. The connection_id and auth_token parameters are volatile by default.
+//   - name: the name of the query parameter
+func AddFixtureVolatileParam(name string) {
+	fixturesMu.Lock()
+	defer fixturesMu.Unlock()
+	volatileParams[name] = true
+}
+
+// StartRecording records the interactions of the blobber and sharder requests to a fixture directory,
+// they are written by StopFixtures. The requests are still sent to the network. The replaced clients
+// are restored by StopFixtures.
+//   - dir: the fixture directory, created if it doesn't exist
+func StartRecording(dir string) error {
+	return startFixtures(&fixtures{
+		mode:         FixtureModeRecord,
+		dir:          dir,
+		interactions: make(map[string][]*Interaction),
+	})
+}
+
+// StartReplay answers the blobber and sharder requests with the interactions recorded in a fixture
+// directory, so the recorded flows run without the network. The requests are matched by method, host,
+// path and query parameters, the volatile ones excepted. The identical requests get the recorded
+// responses in order, the last one once they are all replayed. A request never recorded fails.
+//   - dir: the fixture directory
+func StartReplay(dir string) error {
+	f := &fixtures{
+		mode:         FixtureModeReplay,
+		dir:          dir,
+		interactions: make(map[string][]*Interaction),
+		replayed:     make(map[string]int),
+	}
+	if err := f.load(); err != nil {
+		return err
+	}
+	return startFixtures(f)
+}
+
+// StopFixtures stops recording or replaying the interactions, and writes the recorded ones once the
+// requests being recorded are done.
+func StopFixtures() error {
+	fixturesMu.Lock()
+	f := activeFixtures
+	activeFixtures = nil
+	fixturesMu.Unlock()
+	if f == nil {
+		return nil
+	}
+
+	Client, FastHttpClient, sharderClient = f.client, f.fastHttpClient, f.sharderClient
+	if f.mode == FixtureModeRecord {
+		f.recording.Wait()
+		return f.save()
+	}
+	return nil
+}
+
+// GetFixtureMode returns the mode of the fixtures.
+func GetFixtureMode() FixtureMode {
+	fixturesMu.Lock()
+	defer fixturesMu.Unlock()
+	if activeFixtures == nil {
+		return FixtureModeOff
+	}
+	return activeFixtures.mode
+}
+
+func startFixtures(f *fixtures) error {
+	fixturesMu.Lock()
+	defer fixturesMu.Unlock()
+	if activeFixtures != nil {
+		return errors.New("fixtures_started", "the fixtures are already started, stop them first")
+	}
+	f.volatile = make(map[string]string)
+	f.client, f.fastHttpClient, f.sharderClient = Client, FastHttpClient, sharderClient
+
+	fastClient := newFastHTTPClient()
+	fastClient.ConfigureClient = func(hc *fasthttp.HostClient) error {
+		hc.Transport = &fixtureFastTransport{fixtures: f, next: fasthttp.DefaultTransport}
+		return nil
+	}
+	Client = &fixtureClient{fixtures: f, next: f.client}
+	FastHttpClient = fastClient
+	sharderClient = &fixtureClient{fixtures: f, next: f.sharderClient, sharder: true}
+	activeFixtures = f
+	return nil
+}
+
+// key returns the key matching a request, without the volatile parameters and their values. The
+// blobbers are named by their id, see RegisterFixtureBlobber, and the sharders aren't named as they
+// answer the same.
+//   - sharder: true for the requests to the sharders
+func (f *fixtures) key(method, rawURL string, sharder bool) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return method + " " + rawURL
+	}
+	host, path := "sharder", u.Path
+	if !sharder {
+		host, path = fixtureHost(u)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fixturesMu.Lock()
+	q := u.Query()
+	for name := range q {
+		if !volatileParams[name] {
+			continue
+		}
+		for _, v := range q[name] {
+			if v != "" {
+				f.volatile[v] = "{" + name + "}"
+			}
+		}
+		q.Del(name)
+	}
+	fixturesMu.Unlock()
+
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if placeholder, ok := f.volatile[s]; ok {
+			segments[i] = placeholder
+		}
+	}
+	key := method + " " + host + strings.Join(segments, "/")
+	if len(q) > 0 {
+		key += "?" + q.Encode()
+	}
+	return key
+}
+
+func (f *fixtures) record(key string, in *Interaction) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.interactions[key] = append(f.interactions[key], in)
+}
+
+func (f *fixtures) replay(key string) (*Interaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	interactions := f.interactions[key]
+	if len(interactions) == 0 {
+		return nil, errors.New("fixture_not_found", "no recorded interaction for "+key)
+	}
+	i := f.replayed[key]
+	if i >= len(interactions) {
+		return interactions[len(interactions)-1], nil
+	}
+	f.replayed[key] = i + 1
+	return interactions[i], nil
+}
+
+func (f *fixtures) load() error {
+	files, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("fixtures_not_found", "no fixture in "+f.dir)
+	}
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		var file fixtureFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return errors.Wrap(err, "invalid fixture "+name)
+		}
+		f.interactions[file.Key] = append(f.interactions[file.Key], file.Interactions...)
+	}
+	return nil
+}
+
+func (f *fixtures) save() error {
+	if err := os.MkdirAll(f.dir, 0755); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.interactions))
+	for key := range f.interactions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		data, err := json.MarshalIndent(&fixtureFile{Key: key, Interactions: f.interactions[key]}, "", "  ")
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(f.dir, encryption.Hash(key)[:16]+".json")
+		if err := os.WriteFile(name, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fixtureClient records or replays the requests of a HttpClient.
+type fixtureClient struct {
+	fixtures *fixtures
+	next     HttpClient
+	// sharder is true for the client of the sharder requests.
+	sharder bool
+}
+
+func (c *fixtureClient) Do(req *http.Request) (*http.Response, error) {
+	key := c.fixtures.key(req.Method, req.URL.String(), c.sharder)
+	if c.fixtures.mode == FixtureModeReplay {
+		in, err := c.fixtures.replay(key)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:        http.StatusText(in.Status),
+			StatusCode:    in.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(in.Body)),
+			ContentLength: int64(len(in.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	c.fixtures.recording.Add(1)
+	defer c.fixtures.recording.Done()
+	resp, err := c.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	c.fixtures.record(key, &Interaction{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: resp.Header.Clone(),
+		Body:   body,
+	})
+	return resp, nil
+}
+
+// fixtureFastTransport records or replays the requests of the fasthttp clients, the uploads and the downloads.
+type fixtureFastTransport struct {
+	fixtures *fixtures
+	next     fasthttp.RoundTripper
+}
+
+func (t *fixtureFastTransport) RoundTrip(hc *fasthttp.HostClient, req *fasthttp.Request, resp *fasthttp.Response) (bool, error) {
+	method, rawURL := string(req.Header.Method()), req.URI().String()
+	key := t.fixtures.key(method, rawURL, false)
+	if t.fixtures.mode == FixtureModeReplay {
+		in, err := t.fixtures.replay(key)
+		if err != nil {
+			return false, err
+		}
+		resp.Reset()
+		resp.SetStatusCode(in.Status)
+		for name, values := range in.Header {
+			for _, v := range values {
+				resp.Header.Add(name, v)
+			}
+		}
+		resp.SetBody(in.Body)
+		return false, nil
+	}
+
+	t.fixtures.recording.Add(1)
+	defer t.fixtures.recording.Done()
+	retry, err := t.next.RoundTrip(hc, req, resp)
+	if err != nil {
+		return retry, err
+	}
+	header := make(http.Header)
+	resp.Header.VisitAll(func(name, value []byte) {
+		header.Add(string(name), string(value))
+	})
+	t.fixtures.record(key, &Interaction{
+		Method: method,
+		URL:    rawURL,
+		Status: resp.StatusCode(),
+		Header: header,
+		Body:   append([]byte(nil), resp.Body()...),
+	})
+	return retry, nil
+}
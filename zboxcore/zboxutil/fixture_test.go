@@ -0,0 +1,23 @@
+package zboxutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixturesKey(t *testing.T) {
+	f := &fixtures{volatile: make(map[string]string)}
+	RegisterFixtureBlobber("http://127.0.0.1:4001/blobber01/", "b1")
+	RegisterFixtureBlobber("http://127.0.0.1:5123/blobber01", "b1")
+	RegisterFixtureBlobber("http://127.0.0.1:4001/blobber02", "b2")
+
+	recorded := f.key("GET", "http://127.0.0.1:4001/blobber01/v1/file/list/alloc?path=%2F", false)
+	require.Equal(t, "GET blobber:b1/v1/file/list/alloc?path=%2F", recorded)
+	require.Equal(t, recorded, f.key("GET", "http://127.0.0.1:5123/blobber01/v1/file/list/alloc?path=%2F", false))
+	require.NotEqual(t, recorded, f.key("GET", "http://127.0.0.1:4001/blobber02/v1/file/list/alloc?path=%2F", false))
+	require.Equal(t, "GET 127.0.0.1:6000/v1/file/list/alloc", f.key("GET", "http://127.0.0.1:6000/v1/file/list/alloc", false))
+
+	require.Equal(t, f.key("GET", "http://127.0.0.1:7001/v1/screst/sc/getAllocation", true),
+		f.key("GET", "http://127.0.0.1:7002/v1/screst/sc/getAllocation", true))
+}
@@ -53,8 +53,10 @@ type FastClient interface {
 var (
 	Client         HttpClient
 	FastHttpClient FastClient
-	log            logger.Logger
-	SignCache      simplelru.LRUCache[string, string]
+	// sharderClient is the client of the sharder requests made with MakeSCRestAPICall.
+	sharderClient HttpClient
+	log           logger.Logger
+	SignCache     simplelru.LRUCache[string, string]
 )
 
 const (
@@ -69,6 +71,7 @@ const (
 	ALLOCATION_ENDPOINT          = "/allocation"
 	UPLOAD_ENDPOINT              = "/v1/file/upload/"
 	RENAME_ENDPOINT              = "/v1/file/rename/"
+	ATTRIBUTES_ENDPOINT          = "/v1/file/attributes/"
 	COPY_ENDPOINT                = "/v1/file/copy/"
 	MOVE_ENDPOINT                = "/v1/file/move/"
 	LIST_ENDPOINT                = "/v1/file/list/"
@@ -89,6 +92,7 @@ const (
 	PLAYLIST_LATEST_ENDPOINT     = "/v1/playlist/latest/"
 	PLAYLIST_FILE_ENDPOINT       = "/v1/playlist/file/"
 	WM_LOCK_ENDPOINT             = "/v1/writemarker/lock/"
+	FILE_LOCK_ENDPOINT           = "/v1/file/lock/"
 	CREATE_CONNECTION_ENDPOINT   = "/v1/connection/create/"
 	LATEST_WRITE_MARKER_ENDPOINT = "/v1/file/latestwritemarker/"
 	ROLLBACK_ENDPOINT            = "/v1/connection/rollback/"
@@ -143,7 +147,11 @@ func (pfe *proxyFromEnv) isLoopback(host string) (ok bool) {
 }
 
 func GetFastHTTPClient() *fasthttp.Client {
-	fc, ok := FastHttpClient.(*fasthttp.Client)
+	fastClient := FastHttpClient
+	if bc, ok := fastClient.(*blobberFastClient); ok {
+		fastClient = bc.next
+	}
+	fc, ok := fastClient.(*fasthttp.Client)
 	if ok {
 		return fc
 	}
@@ -166,11 +174,27 @@ func (pfe *proxyFromEnv) Proxy(req *http.Request) (proxy *url.URL, err error) {
 var envProxy proxyFromEnv
 
 func init() {
-	Client = &http.Client{
+	Client = &blobberClient{next: &http.Client{
+		Transport: DefaultTransport,
+	}}
+
+	sharderClient = &http.Client{
 		Transport: DefaultTransport,
 	}
 
-	FastHttpClient = &fasthttp.Client{
+	FastHttpClient = &blobberFastClient{next: newFastHTTPClient()}
+	fasthttp.SetBodySizePoolLimit(respBodyPoolLimit, respBodyPoolLimit)
+	envProxy.initialize()
+	log.Init(logger.DEBUG, "0box-sdk")
+	c, err := lru.New[string, string](1000)
+	if err != nil {
+		panic(err)
+	}
+	SignCache = c
+}
+
+func newFastHTTPClient() *fasthttp.Client {
+	return &fasthttp.Client{
 		MaxIdleConnDuration:           45 * time.Second,
 		NoDefaultUserAgentHeader:      true, // Don't send: User-Agent: fasthttp
 		DisableHeaderNamesNormalizing: true, // If you set the case on your headers correctly you can enable this
@@ -186,14 +210,6 @@ func init() {
 		MaxResponseBodySize: 1024 * 1024 * 64, //64MB
 		MaxConnsPerHost:     1024,
 	}
-	fasthttp.SetBodySizePoolLimit(respBodyPoolLimit, respBodyPoolLimit)
-	envProxy.initialize()
-	log.Init(logger.DEBUG, "0box-sdk")
-	c, err := lru.New[string, string](1000)
-	if err != nil {
-		panic(err)
-	}
-	SignCache = c
 }
 
 func NewHTTPRequest(method string, url string, data []byte) (*http.Request, context.Context, context.CancelFunc, error) {
@@ -213,27 +229,37 @@ func NewHTTPRequest(method string, url string, data []byte) (*http.Request, cont
 	return req, ctx, cncl, err
 }
 
-func setClientInfo(req *http.Request) {
-	req.Header.Set("X-App-Client-ID", client.GetClientID())
-	req.Header.Set("X-App-Client-Key", client.GetClientPublicKey())
+func setClientInfo(req *http.Request, allocationTx string) error {
+	return prepareBlobberRequest(&BlobberRequest{
+		Header:       req.Header,
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		Body:         httpRequestBody(req),
+		AllocationTx: allocationTx,
+	})
 }
 
-func setClientInfoWithSign(req *http.Request, sig, allocation, baseURL string) error {
-	setClientInfo(req)
-	req.Header.Set(CLIENT_SIGNATURE_HEADER, sig)
+// SignBlobberRequest adds the client and signature headers of the allocation to a request to a blobber,
+// e.g. a request of the blobberclient package.
+//   - req: the request.
+//   - sig: the signature of the allocation.
+//   - allocationTx: the allocation transaction.
+//   - baseURL: the base url of the blobber.
+func SignBlobberRequest(req *http.Request, sig, allocationTx, baseURL string) error {
+	return setClientInfoWithSign(req, sig, allocationTx, baseURL)
+}
 
-	hashData := allocation + baseURL
-	sig2, ok := SignCache.Get(hashData)
-	if !ok {
-		var err error
-		sig2, err = client.Sign(encryption.Hash(hashData))
-		SignCache.Add(hashData, sig2)
-		if err != nil {
-			return err
-		}
-	}
-	req.Header.Set(CLIENT_SIGNATURE_HEADER_V2, sig2)
-	return nil
+func setClientInfoWithSign(req *http.Request, sig, allocation, baseURL string) error {
+	return prepareBlobberRequest(&BlobberRequest{
+		Header:       req.Header,
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		Body:         httpRequestBody(req),
+		BaseURL:      baseURL,
+		AllocationTx: allocation,
+		Signed:       true,
+		Signature:    sig,
+	})
 }
 
 func NewCommitRequest(baseUrl, allocationID string, allocationTx string, body io.Reader) (*http.Request, error) {
@@ -246,7 +272,9 @@ func NewCommitRequest(baseUrl, allocationID string, allocationTx string, body io
 	if err != nil {
 		return nil, err
 	}
-	setClientInfo(req)
+	if err := setClientInfo(req, allocationTx); err != nil {
+		return nil, err
+	}
 
 	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
 
@@ -298,7 +326,9 @@ func NewCalculateHashRequest(baseUrl, allocationID string, allocationTx string,
 	if err != nil {
 		return nil, err
 	}
-	setClientInfo(req)
+	if err := setClientInfo(req, allocationTx); err != nil {
+		return nil, err
+	}
 
 	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
 
@@ -397,7 +427,9 @@ func NewAllocationRequest(baseUrl, allocationID, allocationTx string) (*http.Req
 	if err != nil {
 		return nil, err
 	}
-	setClientInfo(req)
+	if err := setClientInfo(req, allocationTx); err != nil {
+		return nil, err
+	}
 
 	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
 	return req, nil
@@ -521,7 +553,9 @@ func NewListRequest(baseUrl, allocationID, allocationTx, path, pathHash, auth_to
 	if err != nil {
 		return nil, err
 	}
-	setClientInfo(req)
+	if err := setClientInfo(req, allocationTx); err != nil {
+		return nil, err
+	}
 
 	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
 
@@ -603,6 +637,70 @@ func NewWriteMarkerUnLockRequest(
 	return req, nil
 }
 
+// NewFileLockRequest creates a request acquiring or refreshing the advisory lock of a file on a blobber.
+//   - baseURL: the base url of the blobber.
+//   - allocationID, allocationTx: the id and the transaction of the allocation.
+//   - sig: the signature of the allocation transaction.
+//   - remotePath: the path of the file.
+//   - lockID: the id of the lock, identifying its holder.
+//   - ttl: the duration of the lock, it expires unless it's refreshed.
+func NewFileLockRequest(baseURL, allocationID, allocationTx, sig, remotePath, lockID string, ttl time.Duration) (*http.Request, error) {
+	u, err := joinUrl(baseURL, FILE_LOCK_ENDPOINT, allocationTx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("path", remotePath)
+	params.Add("lock_id", lockID)
+	params.Add("ttl", strconv.FormatInt(int64(ttl/time.Second), 10))
+	u.RawQuery = params.Encode() // Escape Query Parameters
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setClientInfoWithSign(req, sig, allocationTx, baseURL); err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
+
+	return req, nil
+}
+
+// NewFileUnlockRequest creates a request releasing the advisory lock of a file on a blobber.
+//   - baseURL: the base url of the blobber.
+//   - allocationID, allocationTx: the id and the transaction of the allocation.
+//   - sig: the signature of the allocation transaction.
+//   - remotePath: the path of the file.
+//   - lockID: the id of the lock.
+func NewFileUnlockRequest(baseURL, allocationID, allocationTx, sig, remotePath, lockID string) (*http.Request, error) {
+	u, err := joinUrl(baseURL, FILE_LOCK_ENDPOINT, allocationTx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("path", remotePath)
+	params.Add("lock_id", lockID)
+	u.RawQuery = params.Encode() // Escape Query Parameters
+
+	req, err := http.NewRequest(http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setClientInfoWithSign(req, sig, allocationTx, baseURL); err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
+
+	return req, nil
+}
+
 func NewFastUploadRequest(baseURL, allocationID string, allocationTx string, body []byte, method string) (*fasthttp.Request, error) {
 	u, err := joinUrl(baseURL, UPLOAD_ENDPOINT, allocationTx)
 	if err != nil {
@@ -625,25 +723,20 @@ func NewFastUploadRequest(baseURL, allocationID string, allocationTx string, bod
 }
 
 func setFastClientInfoWithSign(req *fasthttp.Request, allocation, baseURL string) error {
-	req.Header.Set("X-App-Client-ID", client.GetClientID())
-	req.Header.Set("X-App-Client-Key", client.GetClientPublicKey())
-
 	sign, err := client.Sign(encryption.Hash(allocation))
 	if err != nil {
 		return err
 	}
-	req.Header.Set(CLIENT_SIGNATURE_HEADER, sign)
-	hashData := allocation + baseURL
-	sig2, ok := SignCache.Get(hashData)
-	if !ok {
-		sig2, err = client.Sign(encryption.Hash(hashData))
-		SignCache.Add(hashData, sig2)
-		if err != nil {
-			return err
-		}
-	}
-	req.Header.Set(CLIENT_SIGNATURE_HEADER_V2, sig2)
-	return nil
+	return prepareBlobberRequest(&BlobberRequest{
+		Header:       &req.Header,
+		Method:       string(req.Header.Method()),
+		URL:          req.URI().String(),
+		Body:         func() ([]byte, error) { return req.Body(), nil },
+		BaseURL:      baseURL,
+		AllocationTx: allocation,
+		Signed:       true,
+		Signature:    sign,
+	})
 }
 
 func NewUploadRequest(baseUrl, allocationID, allocationTx, sig string, body io.Reader, update bool) (*http.Request, error) {
@@ -711,6 +804,26 @@ func NewRenameRequest(baseUrl, allocationID, allocationTx, sig string, body io.R
 	return req, nil
 }
 
+func NewAttributesRequest(baseUrl, allocationID, allocationTx, sig string, body io.Reader) (*http.Request, error) {
+	u, err := joinUrl(baseUrl, ATTRIBUTES_ENDPOINT, allocationTx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setClientInfoWithSign(req, sig, allocationTx, baseUrl); err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
+
+	return req, nil
+}
+
 func NewCopyRequest(baseUrl, allocationID, allocationTx, sig string, body io.Reader) (*http.Request, error) {
 	u, err := joinUrl(baseUrl, COPY_ENDPOINT, allocationTx)
 	if err != nil {
@@ -784,11 +897,11 @@ func NewFastDownloadRequest(baseUrl, allocationID, allocationTx string) (*fastht
 	}
 
 	req := fasthttp.AcquireRequest()
+	req.SetRequestURI(u.String())
 
 	if err := setFastClientInfoWithSign(req, allocationTx, baseUrl); err != nil {
 		return nil, err
 	}
-	req.SetRequestURI(u.String())
 	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
 
 	return req, nil
@@ -804,7 +917,9 @@ func NewRedeemRequest(baseUrl, allocationID, allocationTx string) (*http.Request
 	if err != nil {
 		return nil, err
 	}
-	setClientInfo(req)
+	if err := setClientInfo(req, allocationTx); err != nil {
+		return nil, err
+	}
 	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
 	return req, nil
 }
@@ -921,7 +1036,9 @@ func NewRollbackRequest(baseUrl, allocationID string, allocationTx string, body
 	if err != nil {
 		return nil, err
 	}
-	setClientInfo(req)
+	if err := setClientInfo(req, allocationTx); err != nil {
+		return nil, err
+	}
 
 	req.Header.Set(ALLOCATION_ID_HEADER, allocationID)
 
@@ -933,7 +1050,20 @@ func NewRollbackRequest(baseUrl, allocationID string, allocationTx string, body
 //   - relativePath is the relative path of the api
 //   - params is the query parameters
 //   - handler is the handler function to handle the response
+//
+// Responses of the endpoints with a TTL are served from the response cache when one is set, see
+// SetResponseCache. Calls with a handler always query the sharders as the handler needs their responses.
 func MakeSCRestAPICall(scAddress string, relativePath string, params map[string]string, handler SCRestAPIHandler) ([]byte, error) {
+	if handler != nil {
+		return makeSCRestAPICall(scAddress, relativePath, params, handler)
+	}
+	if b, ok := getCachedResponse(scAddress, relativePath, params); ok {
+		return b, nil
+	}
+	return MakeSCRestAPICallNoCache(scAddress, relativePath, params, nil)
+}
+
+func makeSCRestAPICall(scAddress string, relativePath string, params map[string]string, handler SCRestAPIHandler) ([]byte, error) {
 	numSharders := len(blockchain.GetSharders())
 	sharders := blockchain.GetSharders()
 	responses := make(map[int]int)
@@ -964,8 +1094,12 @@ func MakeSCRestAPICall(scAddress string, relativePath string, params map[string]
 				q.Add(k, v)
 			}
 			urlObj.RawQuery = q.Encode()
-			client := &http.Client{Transport: DefaultTransport}
-			response, err := client.Get(urlObj.String())
+			req, err := http.NewRequest(http.MethodGet, urlObj.String(), nil)
+			if err != nil {
+				log.Error(err)
+				return
+			}
+			response, err := sharderClient.Do(req)
 			if err != nil {
 				blockchain.Sharders.Fail(sharder)
 				return
@@ -1060,6 +1194,58 @@ func HttpDo(ctx context.Context, cncl context.CancelFunc, req *http.Request, f f
 	}
 }
 
+// AbandonedRequestError is returned by FastDoContext when the context is done while the request is
+// in flight. The request isn't aborted, the server may still process it.
+type AbandonedRequestError struct {
+	// Cause is the cause of the cancellation of the context.
+	Cause error
+}
+
+func (e *AbandonedRequestError) Error() string {
+	return "request abandoned in flight: " + e.Cause.Error()
+}
+
+func (e *AbandonedRequestError) Unwrap() error {
+	return e.Cause
+}
+
+// FastDoContext sends a request with FastHttpClient like DoTimeout, but returns as soon as the
+// context is done, without waiting for the response: the cause of the cancellation if the request
+// wasn't sent, an AbandonedRequestError wrapping it if it was. fasthttp can't abort a request, so
+// it's then left to complete or time out in the background. The request is released once sent, its
+// body must not be reused if the context is done. The response is copied to resp.
+//   - ctx: the context of the request.
+//   - req: the request, released by FastDoContext.
+//   - resp: the response.
+//   - timeout: the timeout of the request.
+func FastDoContext(ctx context.Context, req *fasthttp.Request, resp *fasthttp.Response, timeout time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		fasthttp.ReleaseRequest(req)
+		return context.Cause(ctx)
+	}
+
+	r := fasthttp.AcquireResponse()
+	done := make(chan error, 1)
+	go func() {
+		err := FastHttpClient.DoTimeout(req, r, timeout)
+		fasthttp.ReleaseRequest(req)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		r.CopyTo(resp)
+		fasthttp.ReleaseResponse(r)
+		return err
+	case <-ctx.Done():
+		go func() {
+			<-done
+			fasthttp.ReleaseResponse(r)
+		}()
+		return &AbandonedRequestError{Cause: context.Cause(ctx)}
+	}
+}
+
 // isCurrentDominantStatus determines whether the current response status is the dominant status among responses.
 //
 // The dominant status is where the response status is counted the most.
@@ -1,9 +1,16 @@
 package zboxutil
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/hitenjain14/fasthttp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsCurrentDominantStatus(t *testing.T) {
@@ -65,3 +72,43 @@ func TestIsCurrentDominantStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestFastDoContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-release
+		}
+		w.Write([]byte("ok")) //nolint:errcheck
+	}))
+	defer server.Close()
+	defer close(release)
+
+	req := fasthttp.AcquireRequest()
+	req.SetRequestURI(server.URL + "/fast")
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+	require.NoError(t, FastDoContext(context.Background(), req, resp, time.Minute))
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, "ok", string(resp.Body()))
+
+	// the request is abandoned once the context is canceled.
+	canceled := errors.New("canceled")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	time.AfterFunc(50*time.Millisecond, func() { cancel(canceled) })
+	req = fasthttp.AcquireRequest()
+	req.SetRequestURI(server.URL + "/slow")
+	start := time.Now()
+	err := FastDoContext(ctx, req, resp, time.Minute)
+	require.ErrorIs(t, err, canceled)
+	var abandoned *AbandonedRequestError
+	require.ErrorAs(t, err, &abandoned)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	// the requests not sent aren't abandoned.
+	req = fasthttp.AcquireRequest()
+	req.SetRequestURI(server.URL + "/fast")
+	err = FastDoContext(ctx, req, resp, time.Minute)
+	require.ErrorIs(t, err, canceled)
+	require.False(t, errors.As(err, &abandoned))
+}
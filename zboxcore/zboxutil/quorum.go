@@ -0,0 +1,120 @@
+package zboxutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/restclient"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+)
+
+const defaultQuorumTimeout = 30 * time.Second
+
+var (
+	quorumMu              sync.RWMutex
+	defaultQuorumSharders int
+	defaultQuorum         int
+)
+
+// QuorumOption overrides the default quorum settings of a single query.
+type QuorumOption func(q *queryQuorum)
+
+type queryQuorum struct {
+	sharders int
+	quorum   int
+	timeout  time.Duration
+}
+
+// SetDefaultQuorum sets the default settings of MakeSCRestAPICallWithQuorum.
+//   - sharders: number of sharders queried, the healthiest first. Zero queries all the sharders.
+//   - quorum: number of identical responses required. Zero requires a majority of the queried sharders.
+func SetDefaultQuorum(sharders, quorum int) {
+	quorumMu.Lock()
+	defer quorumMu.Unlock()
+	defaultQuorumSharders = sharders
+	defaultQuorum = quorum
+}
+
+// WithQuorumSharders sets the number of sharders queried.
+func WithQuorumSharders(sharders int) QuorumOption {
+	return func(q *queryQuorum) {
+		q.sharders = sharders
+	}
+}
+
+// WithQuorum sets the number of identical responses required.
+func WithQuorum(quorum int) QuorumOption {
+	return func(q *queryQuorum) {
+		q.quorum = quorum
+	}
+}
+
+// WithQuorumTimeout sets the timeout of the query. Defaults to 30 seconds.
+func WithQuorumTimeout(timeout time.Duration) QuorumOption {
+	return func(q *queryQuorum) {
+		q.timeout = timeout
+	}
+}
+
+// MakeSCRestAPICallWithQuorum fans out a smart contract REST query to the sharders and returns the
+// response only if enough sharders agree on it, see restclient.QuorumTransport.
+//   - ctx: the context of the query.
+//   - scAddress: the smart contract address.
+//   - relativePath: the relative path of the endpoint.
+//   - params: the query parameters.
+//   - opts: options overriding the default quorum settings, see SetDefaultQuorum.
+func MakeSCRestAPICallWithQuorum(ctx context.Context, scAddress, relativePath string, params map[string]string, opts ...QuorumOption) ([]byte, error) {
+	return newQuorumTransport(opts...).Query(ctx, scAddress, relativePath, params)
+}
+
+func newQuorumTransport(opts ...QuorumOption) *restclient.QuorumTransport {
+	quorumMu.RLock()
+	q := &queryQuorum{
+		sharders: defaultQuorumSharders,
+		quorum:   defaultQuorum,
+		timeout:  defaultQuorumTimeout,
+	}
+	quorumMu.RUnlock()
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	return &restclient.QuorumTransport{
+		Sharders:    blockchain.GetAllSharders,
+		HTTPClient:  Client,
+		MaxSharders: q.sharders,
+		Quorum:      q.quorum,
+		Timeout:     q.timeout,
+		// the sharders are resolved at each query, they may be updated after the transport is created
+		OnSuccess: func(sharder string) { blockchain.Sharders.Success(sharder) },
+		OnFailure: func(sharder string) { blockchain.Sharders.Fail(sharder) },
+	}
+}
+
+// NewSCRestClient returns a typed client of the smart contract REST endpoints of the sharders of the
+// network. The client queries the sharders with MakeSCRestAPICall, so the response cache applies,
+// see SetResponseCache, unless quorum options are given, see MakeSCRestAPICallWithQuorum.
+//   - quorumOpts: the quorum options of the queries, if any.
+//   - opts: the options of the client, e.g. its retry policy.
+func NewSCRestClient(quorumOpts []QuorumOption, opts ...restclient.Option) *restclient.Client {
+	if len(quorumOpts) > 0 {
+		return restclient.New(newQuorumTransport(quorumOpts...), opts...)
+	}
+	return restclient.New(restclient.TransportFunc(func(_ context.Context, scAddress, relativePath string, params map[string]string) ([]byte, error) {
+		b, err := MakeSCRestAPICall(scAddress, relativePath, params, nil)
+		var scErr *errors.Error
+		if errors.As(err, &scErr) && scErr.Code == "" {
+			// the error response of the smart contract the sharders agreed on, not to be retried
+			return nil, &restclient.ResponseError{Message: scErr.Msg}
+		}
+		return b, err
+	}), opts...)
+}
+
+// CanonicalJSONHash returns the hash of the canonical form of a json document: keys sorted and
+// no insignificant whitespace. Data that is not valid json is hashed as is.
+func CanonicalJSONHash(data []byte) string {
+	return restclient.CanonicalJSONHash(data)
+}
@@ -0,0 +1,54 @@
+package zboxutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSONHash(t *testing.T) {
+	require.Equal(t,
+		CanonicalJSONHash([]byte(`{"a": 1, "b": [1, 2]}`)),
+		CanonicalJSONHash([]byte(`{"b":[1,2],"a":1}`)))
+	require.NotEqual(t,
+		CanonicalJSONHash([]byte(`{"a": 1}`)),
+		CanonicalJSONHash([]byte(`{"a": 2}`)))
+	require.NotEmpty(t, CanonicalJSONHash([]byte("not json")))
+}
+
+func TestMakeSCRestAPICallWithQuorum(t *testing.T) {
+	newSharder := func(status int, body string) string {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(body)) //nolint: errcheck
+		}))
+		t.Cleanup(s.Close)
+		return s.URL
+	}
+
+	sharders := []string{
+		newSharder(http.StatusOK, `{"id":"alloc","size":10}`),
+		newSharder(http.StatusOK, `{"size": 10, "id": "alloc"}`),
+		newSharder(http.StatusOK, `{"id":"alloc","size":20}`),
+		newSharder(http.StatusInternalServerError, `unavailable`),
+	}
+	blockchain.SetSharders(sharders)
+	prevClient := Client
+	Client = http.DefaultClient
+	defer func() { Client = prevClient }()
+
+	// 2 of 4 sharders agree, the default quorum is a majority of 3
+	_, err := MakeSCRestAPICallWithQuorum(context.Background(), "sc", "/allocation", nil)
+	require.Error(t, err)
+
+	resp, err := MakeSCRestAPICallWithQuorum(context.Background(), "sc", "/allocation", nil, WithQuorum(2))
+	require.NoError(t, err)
+	require.Equal(t, CanonicalJSONHash([]byte(`{"id":"alloc","size":10}`)), CanonicalJSONHash(resp))
+
+	_, err = MakeSCRestAPICallWithQuorum(context.Background(), "sc", "/allocation", nil, WithQuorum(5))
+	require.Error(t, err)
+}
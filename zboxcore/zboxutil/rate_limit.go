@@ -0,0 +1,195 @@
+package zboxutil
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// minBlobberBackoff is the first wait after a blobber throttled a request without a Retry-After
+	// header, doubled on each throttled request up to maxBlobberBackoff.
+	minBlobberBackoff = 500 * time.Millisecond
+	// maxBlobberBackoff is the longest wait before the next request to a throttled blobber.
+	maxBlobberBackoff = time.Minute
+	// blobberPacingWindow is how long the requests to a blobber are paced by its rate limit after it
+	// throttled a request.
+	blobberPacingWindow = time.Minute
+)
+
+// blobberPacer paces the requests to a blobber which throttled them, with a 429 or 503 response.
+type blobberPacer struct {
+	mu sync.Mutex
+	// next is the earliest time the next request can be sent.
+	next time.Time
+	// interval is the time between the requests given by the rate limit of the blobber, until pacedUntil.
+	interval   time.Duration
+	pacedUntil time.Time
+	// backoff is the last wait of the throttled requests without a Retry-After header.
+	backoff time.Duration
+}
+
+var (
+	blobberPacersMu sync.Mutex
+	// blobberPacers are the pacers of the blobbers by host and base path, see blobberPacerKey.
+	blobberPacers = make(map[string]*blobberPacer)
+	// blobberPacersSweptAt is the last time the idle pacers were removed from blobberPacers.
+	blobberPacersSweptAt time.Time
+)
+
+// blobberPacerKey returns the key of the blobber of a request url, its host and the path of its base url,
+// as the blobbers sharing a host have their own rate limits.
+func blobberPacerKey(u *url.URL) string {
+	path := u.Path
+	if i := strings.Index(path, "/v1/"); i >= 0 {
+		path = path[:i]
+	} else {
+		path = strings.TrimSuffix(path, ALLOCATION_ENDPOINT)
+	}
+	return u.Host + strings.TrimRight(path, "/")
+}
+
+func blobberPacerOf(key string, now time.Time) *blobberPacer {
+	blobberPacersMu.Lock()
+	defer blobberPacersMu.Unlock()
+	if now.Sub(blobberPacersSweptAt) >= blobberPacingWindow {
+		sweepBlobberPacers(now)
+	}
+	p, ok := blobberPacers[key]
+	if !ok {
+		p = &blobberPacer{}
+		blobberPacers[key] = p
+	}
+	return p
+}
+
+// sweepBlobberPacers removes the pacers no longer delaying nor pacing the requests to their blobber,
+// with blobberPacersMu held.
+func sweepBlobberPacers(now time.Time) {
+	for key, p := range blobberPacers {
+		if p.idle(now) {
+			delete(blobberPacers, key)
+		}
+	}
+	blobberPacersSweptAt = now
+}
+
+// waitBlobber waits until a request can be sent to the blobber of a url, returns the cause of the context
+// if it's done first.
+func waitBlobber(ctx context.Context, u *url.URL) error {
+	now := time.Now()
+	d := blobberPacerOf(blobberPacerKey(u), now).reserve(now)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// observeBlobber adapts the pacing of the requests to the blobber of a url to its response.
+//   - u: the url of the request
+//   - status: the status code of the response
+//   - header: the getter of the headers of the response
+func observeBlobber(u *url.URL, status int, header func(key string) string) {
+	now := time.Now()
+	blobberPacerOf(blobberPacerKey(u), now).observe(now, status, header)
+}
+
+// reserve reserves the slot of a request and returns the time to wait for it.
+func (p *blobberPacer) reserve(now time.Time) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.interval > 0 && now.After(p.pacedUntil) {
+		p.interval = 0
+	}
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	if p.interval > 0 {
+		p.next = at.Add(p.interval)
+	}
+	return at.Sub(now)
+}
+
+// idle returns true if the next request can be sent and the requests aren't paced anymore.
+func (p *blobberPacer) idle(now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !now.Before(p.next) && !now.Before(p.pacedUntil)
+}
+
+func (p *blobberPacer) observe(now time.Time, status int, header func(key string) string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+		if status < http.StatusInternalServerError {
+			p.backoff = 0
+		}
+		return
+	}
+
+	wait := ParseRetryAfter(header("Retry-After"), now)
+	if wait <= 0 {
+		p.backoff *= 2
+		if p.backoff < minBlobberBackoff {
+			p.backoff = minBlobberBackoff
+		}
+		wait = p.backoff
+	}
+	if wait > maxBlobberBackoff {
+		wait = maxBlobberBackoff
+	}
+	if next := now.Add(wait); next.After(p.next) {
+		p.next = next
+	}
+	if interval := rateLimitInterval(header("X-Rate-Limit-Limit"), header("X-Rate-Limit-Duration")); interval > 0 {
+		p.interval = interval
+		p.pacedUntil = now.Add(blobberPacingWindow)
+	}
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, in seconds or an HTTP date, and returns the
+// time to wait, 0 if invalid or missing.
+//   - value: the value of the header
+//   - now: the time the response was received
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// rateLimitInterval returns the time between the requests allowed by the X-Rate-Limit-Limit and
+// X-Rate-Limit-Duration headers, the number of requests allowed per duration in seconds.
+func rateLimitInterval(limit, duration string) time.Duration {
+	rl, err := strconv.ParseFloat(limit, 64)
+	if err != nil || rl <= 0 {
+		return 0
+	}
+	dur, err := strconv.ParseFloat(duration, 64)
+	if err != nil || dur <= 0 {
+		return 0
+	}
+	return time.Duration(dur / rl * float64(time.Second))
+}
@@ -0,0 +1,153 @@
+package zboxutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hitenjain14/fasthttp"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 3*time.Second, ParseRetryAfter("3", now))
+	require.Equal(t, 90*time.Second, ParseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	require.Zero(t, ParseRetryAfter("", now))
+	require.Zero(t, ParseRetryAfter("-1", now))
+	require.Zero(t, ParseRetryAfter("soon", now))
+	require.Zero(t, ParseRetryAfter(now.Add(-time.Second).Format(http.TimeFormat), now))
+}
+
+func TestBlobberPacer(t *testing.T) {
+	now := time.Now()
+	headers := map[string]string{}
+	header := func(key string) string { return headers[key] }
+
+	p := &blobberPacer{}
+	require.Zero(t, p.reserve(now))
+
+	// the Retry-After header is honored, then the requests are paced by the rate limit.
+	headers["Retry-After"] = "2"
+	headers["X-Rate-Limit-Limit"] = "4"
+	headers["X-Rate-Limit-Duration"] = "1"
+	p.observe(now, http.StatusTooManyRequests, header)
+	require.Equal(t, 2*time.Second, p.reserve(now))
+	require.Equal(t, 2250*time.Millisecond, p.reserve(now))
+	require.Equal(t, 2500*time.Millisecond, p.reserve(now))
+
+	// the pacing ends after the pacing window.
+	later := now.Add(blobberPacingWindow + 3*time.Second)
+	require.Zero(t, p.reserve(later))
+	require.Zero(t, p.reserve(later))
+
+	// without headers, the wait is doubled on each throttled request until a success.
+	headers = map[string]string{}
+	p.observe(later, http.StatusServiceUnavailable, header)
+	require.Equal(t, minBlobberBackoff, p.reserve(later))
+	p.observe(later, http.StatusServiceUnavailable, header)
+	require.Equal(t, 2*minBlobberBackoff, p.reserve(later))
+	p.observe(later, http.StatusOK, header)
+	p.observe(later, http.StatusServiceUnavailable, header)
+	require.Equal(t, 2*minBlobberBackoff, p.reserve(later))
+}
+
+func TestBlobberClientPacing(t *testing.T) {
+	var sent int
+	client := &blobberClient{next: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{"Retry-After": {"30"}},
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	})}}
+	u, _ := url.Parse("http://pacing/blobber01/v1/file/list/alloc")
+	defer func() {
+		blobberPacersMu.Lock()
+		delete(blobberPacers, blobberPacerKey(u))
+		blobberPacersMu.Unlock()
+	}()
+
+	req, _ := http.NewRequest(http.MethodGet, u.String(), nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// the next request to the blobber waits, the other blobbers of the host aren't paced.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, "http://pacing/blobber01/v1/file/upload/alloc", nil)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://pacing/blobber02/v1/file/list/alloc", nil)
+	_, err = client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+}
+
+func TestBlobberPacersSweep(t *testing.T) {
+	now := time.Now()
+	blobberPacersMu.Lock()
+	prevPacers, prevSweptAt := blobberPacers, blobberPacersSweptAt
+	blobberPacers = map[string]*blobberPacer{
+		"idle":      {},
+		"throttled": {next: now.Add(time.Second)},
+		"paced":     {interval: time.Second, pacedUntil: now.Add(time.Second)},
+	}
+	blobberPacersSweptAt = now
+	blobberPacersMu.Unlock()
+	defer func() {
+		blobberPacersMu.Lock()
+		blobberPacers, blobberPacersSweptAt = prevPacers, prevSweptAt
+		blobberPacersMu.Unlock()
+	}()
+
+	// the pacers are swept at most once per pacing window.
+	blobberPacerOf("new", now)
+	require.Len(t, blobberPacers, 4)
+
+	blobberPacerOf("new", now.Add(blobberPacingWindow))
+	require.Len(t, blobberPacers, 1)
+	require.Contains(t, blobberPacers, "new")
+}
+
+func TestFastGetWithRequestPacing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("slow down")) //nolint:errcheck
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL + "/v1/file/download/alloc")
+	defer func() {
+		blobberPacersMu.Lock()
+		delete(blobberPacers, blobberPacerKey(u))
+		blobberPacersMu.Unlock()
+	}()
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.SetRequestURI(u.String())
+	statusCode, body, err := FastGetWithRequest(&fasthttp.Client{}, req, make([]byte, 0, 16))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, statusCode)
+	require.Equal(t, "slow down", string(body))
+
+	// the Retry-After header of the download is honored.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, waitBlobber(ctx, u), context.DeadlineExceeded)
+}
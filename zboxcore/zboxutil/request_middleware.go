@@ -0,0 +1,198 @@
+package zboxutil
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// Headers of the replay protection, see SetReplayProtection.
+const (
+	REQUEST_TIMESTAMP_HEADER = "X-App-Request-Timestamp"
+	REQUEST_NONCE_HEADER     = "X-App-Request-Nonce"
+	REQUEST_SIGNATURE_HEADER = "X-App-Request-Signature"
+)
+
+// HeaderSetter is the header of a blobber request, http.Header or fasthttp.RequestHeader.
+type HeaderSetter interface {
+	Set(key, value string)
+}
+
+// BlobberRequest is a request to a blobber prepared by the request middlewares.
+type BlobberRequest struct {
+	Header HeaderSetter
+	Method string
+	// URL is the full url of the request.
+	URL string
+	// Body returns the body of the request, nil if it has none.
+	Body func() ([]byte, error)
+	// BaseURL is the url of the blobber.
+	BaseURL      string
+	AllocationTx string
+	// Signed is true for the requests signed by the client.
+	Signed bool
+	// Signature is the signature of the allocation given by the caller of a signed request.
+	Signature string
+}
+
+// RequestMiddleware prepares a blobber request before it's sent, e.g. to add the headers of an auth scheme.
+type RequestMiddleware func(req *BlobberRequest) error
+
+var (
+	requestMiddlewaresMu sync.RWMutex
+	requestMiddlewares   []RequestMiddleware
+	replayProtection     bool
+)
+
+// UseRequestMiddleware adds a middleware to all the blobber requests, it runs after the built-in ones
+// adding the client info, the signatures and the replay protection.
+//   - mw: the middleware
+func UseRequestMiddleware(mw RequestMiddleware) {
+	requestMiddlewaresMu.Lock()
+	defer requestMiddlewaresMu.Unlock()
+	requestMiddlewares = append(requestMiddlewares, mw)
+}
+
+// ResetRequestMiddlewares removes the middlewares added with UseRequestMiddleware.
+func ResetRequestMiddlewares() {
+	requestMiddlewaresMu.Lock()
+	defer requestMiddlewaresMu.Unlock()
+	requestMiddlewares = nil
+}
+
+// SetReplayProtection adds a timestamp, a nonce and their signature to the signed and the mutating
+// blobber requests, so the blobbers supporting it reject a captured request sent again. The signature
+// covers the method, the full url and the hash of the body of the request, see ReplayProtectionPayload.
+//   - enabled: true to protect the requests, it's disabled by default
+func SetReplayProtection(enabled bool) {
+	requestMiddlewaresMu.Lock()
+	defer requestMiddlewaresMu.Unlock()
+	replayProtection = enabled
+}
+
+// prepareBlobberRequest runs the middleware chain on the request.
+func prepareBlobberRequest(req *BlobberRequest) error {
+	requestMiddlewaresMu.RLock()
+	chain := make([]RequestMiddleware, 0, 3+len(requestMiddlewares))
+	chain = append(chain, clientInfoMiddleware)
+	if req.Signed {
+		chain = append(chain, signatureMiddleware)
+	}
+	if replayProtection && (req.Signed || isMutating(req.Method)) {
+		chain = append(chain, replayProtectionMiddleware)
+	}
+	chain = append(chain, requestMiddlewares...)
+	requestMiddlewaresMu.RUnlock()
+
+	for _, mw := range chain {
+		if err := mw(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func clientInfoMiddleware(req *BlobberRequest) error {
+	req.Header.Set("X-App-Client-ID", client.GetClientID())
+	req.Header.Set("X-App-Client-Key", client.GetClientPublicKey())
+	return nil
+}
+
+func signatureMiddleware(req *BlobberRequest) error {
+	req.Header.Set(CLIENT_SIGNATURE_HEADER, req.Signature)
+
+	hashData := req.AllocationTx + req.BaseURL
+	sig2, ok := SignCache.Get(hashData)
+	if !ok {
+		var err error
+		sig2, err = client.Sign(encryption.Hash(hashData))
+		if err != nil {
+			return err
+		}
+		SignCache.Add(hashData, sig2)
+	}
+	req.Header.Set(CLIENT_SIGNATURE_HEADER_V2, sig2)
+	return nil
+}
+
+func replayProtectionMiddleware(req *BlobberRequest) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	nonceHex := hex.EncodeToString(nonce)
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = req.Body(); err != nil {
+			return err
+		}
+	}
+
+	sig, err := client.Sign(encryption.Hash(ReplayProtectionPayload(req.Method, req.URL, body, req.AllocationTx, ts, nonceHex)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(REQUEST_TIMESTAMP_HEADER, ts)
+	req.Header.Set(REQUEST_NONCE_HEADER, nonceHex)
+	req.Header.Set(REQUEST_SIGNATURE_HEADER, sig)
+	return nil
+}
+
+// ReplayProtectionPayload returns the data signed by the replay protection of a request, its hash is
+// signed in the REQUEST_SIGNATURE_HEADER.
+//   - method: the method of the request
+//   - rawURL: the full url of the request, with its query
+//   - body: the body of the request, its hash is signed
+//   - allocationTx: the allocation transaction
+//   - timestamp: the REQUEST_TIMESTAMP_HEADER
+//   - nonce: the REQUEST_NONCE_HEADER
+func ReplayProtectionPayload(method, rawURL string, body []byte, allocationTx, timestamp, nonce string) string {
+	if method == "" {
+		method = http.MethodGet
+	}
+	return method + ":" + rawURL + ":" + encryption.Hash(body) + ":" + allocationTx + ":" + timestamp + ":" + nonce
+}
+
+// isMutating returns true for the methods of the requests changing the state of a blobber.
+func isMutating(method string) bool {
+	return method != "" && method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+// httpRequestBody returns the body getter of a blobber request. The body is buffered if it can't be
+// read again, so the request still sends it.
+func httpRequestBody(req *http.Request) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if req.Body == nil || req.Body == http.NoBody {
+			return nil, nil
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			defer body.Close()
+			return io.ReadAll(body)
+		}
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+		req.ContentLength = int64(len(data))
+		return data, nil
+	}
+}
@@ -0,0 +1,78 @@
+package zboxutil
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMiddlewares(t *testing.T) {
+	prevSign := client.Sign
+	client.Sign = func(hash string) (string, error) {
+		return "sig:" + hash, nil
+	}
+	defer func() { client.Sign = prevSign }()
+
+	req, err := NewRedeemRequest("http://blobber", "alloc", "tx")
+	require.NoError(t, err)
+	require.Empty(t, req.Header.Get(CLIENT_SIGNATURE_HEADER), "the unsigned requests have no signature")
+
+	req, err = NewDeleteRequest("http://blobber", "alloc", "tx", "allocsig", &url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, "allocsig", req.Header.Get(CLIENT_SIGNATURE_HEADER))
+	require.NotEmpty(t, req.Header.Get(CLIENT_SIGNATURE_HEADER_V2))
+	require.Empty(t, req.Header.Get(REQUEST_NONCE_HEADER))
+
+	SetReplayProtection(true)
+	defer SetReplayProtection(false)
+	req, err = NewDeleteRequest("http://blobber", "alloc", "tx", "allocsig", &url.Values{"path": {"/a"}})
+	require.NoError(t, err)
+	ts, nonce := req.Header.Get(REQUEST_TIMESTAMP_HEADER), req.Header.Get(REQUEST_NONCE_HEADER)
+	require.NotEmpty(t, ts)
+	require.NotEmpty(t, nonce)
+	payload := ReplayProtectionPayload(req.Method, req.URL.String(), nil, "tx", ts, nonce)
+	require.Contains(t, payload, "path=%2Fa", "the query is signed")
+	require.Equal(t, "sig:"+encryption.Hash(payload), req.Header.Get(REQUEST_SIGNATURE_HEADER))
+
+	// the unsigned mutating requests are protected too, with the hash of their body.
+	body := "connection_id=c1"
+	req, err = NewCommitRequest("http://blobber", "alloc", "tx", io.MultiReader(strings.NewReader(body)))
+	require.NoError(t, err)
+	ts, nonce = req.Header.Get(REQUEST_TIMESTAMP_HEADER), req.Header.Get(REQUEST_NONCE_HEADER)
+	payload = ReplayProtectionPayload(req.Method, req.URL.String(), []byte(body), "tx", ts, nonce)
+	require.Equal(t, "sig:"+encryption.Hash(payload), req.Header.Get(REQUEST_SIGNATURE_HEADER))
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(sent), "the body is still sent")
+	req, err = NewRedeemRequest("http://blobber", "alloc", "tx")
+	require.NoError(t, err)
+	require.NotEmpty(t, req.Header.Get(REQUEST_SIGNATURE_HEADER))
+	req, err = NewListRequest("http://blobber", "alloc", "tx", "/", "", "", false, 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, req.Header.Get(REQUEST_SIGNATURE_HEADER), "the unsigned reads aren't protected")
+
+	req2, err := NewDeleteRequest("http://blobber", "alloc", "tx", "allocsig", &url.Values{})
+	require.NoError(t, err)
+	require.NotEqual(t, nonce, req2.Header.Get(REQUEST_NONCE_HEADER), "each request has its own nonce")
+
+	UseRequestMiddleware(func(r *BlobberRequest) error {
+		r.Header.Set("X-Custom-Auth", "token")
+		return nil
+	})
+	defer ResetRequestMiddlewares()
+	req, err = NewRedeemRequest("http://blobber", "alloc", "tx")
+	require.NoError(t, err)
+	require.Equal(t, "token", req.Header.Get("X-Custom-Auth"))
+
+	UseRequestMiddleware(func(r *BlobberRequest) error {
+		return errors.New("denied")
+	})
+	_, err = NewRedeemRequest("http://blobber", "alloc", "tx")
+	require.EqualError(t, err, "denied")
+}
@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"errors"
 
@@ -74,27 +75,52 @@ func (b *lazybuf) string() string {
 //   - out is the file content
 func GetFileContentType(ext string, out io.ReadSeeker) (string, error) {
 
-	if ext != "" {
-		if content, ok := mimeDB[strings.TrimPrefix(ext, ".")]; ok {
-			return content.ContentType, nil
-		}
+	if contentType := contentTypeByExt(ext); contentType != "" {
+		return contentType, nil
 	}
 
-	buffer := make([]byte, 10240)
-	n, err := out.Read(buffer)
+	buffer := make([]byte, ContentTypeSniffLen)
+	n, err := io.ReadFull(out, buffer)
 	defer out.Seek(0, 0) //nolint
 
-	if err != nil && err != io.EOF {
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return "", err
 	}
-	buffer = buffer[:n]
 
-	kind, _ := filetype.Match(buffer)
-	if kind == filetype.Unknown {
-		return "application/octet-stream", nil
+	return DetectContentType(ext, buffer[:n]), nil
+}
+
+// ContentTypeSniffLen is the number of bytes of a file its content type is detected from.
+const ContentTypeSniffLen = 10240
+
+// DetectContentType returns the content type of a file from its extension, or from its first bytes
+// if the extension is unknown: the signatures of the binary formats are checked first, then the
+// text formats are detected.
+//   - ext is the extension of the file, may be empty
+//   - head is the first bytes of the file, see ContentTypeSniffLen
+func DetectContentType(ext string, head []byte) string {
+	if contentType := contentTypeByExt(ext); contentType != "" {
+		return contentType
+	}
+
+	if kind, _ := filetype.Match(head); kind != filetype.Unknown {
+		return kind.MIME.Value
 	}
+	if len(head) > 0 {
+		// detects the text formats the signatures don't cover, e.g. plain text and HTML
+		return http.DetectContentType(head)
+	}
+	return "application/octet-stream"
+}
 
-	return kind.MIME.Value, nil
+func contentTypeByExt(ext string) string {
+	if ext == "" {
+		return ""
+	}
+	if content, ok := mimeDB[strings.ToLower(strings.TrimPrefix(ext, "."))]; ok {
+		return content.ContentType
+	}
+	return ""
 }
 
 // GetFullRemotePath returns the full remote path by combining the local path and remote path
@@ -116,6 +142,16 @@ func NewConnectionId() string {
 	return shortuuid.New()
 }
 
+// NewDeterministicConnectionId derives a connection id from an upload, in the short uuid format too. An
+// upload retried, e.g. after a crash, gets the connection id of the previous attempt, so the blobbers
+// deduplicate the shards already received instead of storing them again.
+//   - allocationID: the id of the allocation.
+//   - remotePath: the remote path of the file.
+//   - contentHash: the hash of the content of the file.
+func NewDeterministicConnectionId(allocationID, remotePath, contentHash string) string {
+	return shortuuid.NewWithNamespace(allocationID + ":" + remotePath + ":" + contentHash)
+}
+
 // IsRemoteAbs returns true if the path is remote absolute path
 //   - path is the path to check
 func IsRemoteAbs(path string) bool {
@@ -249,7 +285,13 @@ func GetActiveBlobbers(dirMask uint32, blobbers []*blockchain.StorageNode) []*bl
 	return r
 }
 
+// GetRateLimitValue returns the seconds to wait before retrying a throttled request, given by the
+// Retry-After header of the response or its rate limit.
 func GetRateLimitValue(r *http.Response) (int, error) {
+	if wait := ParseRetryAfter(r.Header.Get("Retry-After"), time.Now()); wait > 0 {
+		return int(math.Ceil(wait.Seconds())), nil
+	}
+
 	rlStr := r.Header.Get("X-Rate-Limit-Limit")
 	durStr := r.Header.Get("X-Rate-Limit-Duration")
 
@@ -266,7 +308,13 @@ func GetRateLimitValue(r *http.Response) (int, error) {
 	return int(math.Ceil(rl / dur)), nil
 }
 
+// GetFastRateLimitValue returns the seconds to wait before retrying a throttled request, given by the
+// Retry-After header of the response or its rate limit.
 func GetFastRateLimitValue(r *fasthttp.Response) (int, error) {
+	if wait := ParseRetryAfter(string(r.Header.Peek("Retry-After")), time.Now()); wait > 0 {
+		return int(math.Ceil(wait.Seconds())), nil
+	}
+
 	rlStr := r.Header.Peek("X-Rate-Limit-Limit")
 	durStr := r.Header.Peek("X-Rate-Limit-Duration")
 
@@ -1,6 +1,7 @@
 package zboxutil
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -61,3 +62,42 @@ func TestScryptEncryption(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	testCases := []struct {
+		name string
+		ext  string
+		head []byte
+		want string
+	}{
+		{name: "known extension", ext: ".PDF", head: png, want: "application/pdf"},
+		{name: "binary signature", head: png, want: "image/png"},
+		{name: "plain text", head: []byte("hello world\n"), want: "text/plain; charset=utf-8"},
+		{name: "unknown extension", ext: ".unknownext", head: []byte("<html><body></body></html>"), want: "text/html; charset=utf-8"},
+		{name: "empty", want: "application/octet-stream"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.want, DetectContentType(tc.ext, tc.head))
+		})
+	}
+
+	contentType, err := GetFileContentType("", bytes.NewReader([]byte("hello world\n")))
+	require.NoError(t, err)
+	require.Equal(t, "text/plain; charset=utf-8", contentType)
+}
+
+func TestNewDeterministicConnectionId(t *testing.T) {
+	id := NewDeterministicConnectionId("alloc", "/a.txt", "hash")
+	require.Equal(t, id, NewDeterministicConnectionId("alloc", "/a.txt", "hash"))
+	require.Len(t, id, len(NewConnectionId()))
+
+	require.NotEqual(t, id, NewDeterministicConnectionId("alloc", "/a.txt", "other"))
+	require.NotEqual(t, id, NewDeterministicConnectionId("alloc", "/b.txt", "hash"))
+	require.NotEqual(t, id, NewDeterministicConnectionId("other", "/a.txt", "hash"))
+}
@@ -0,0 +1,663 @@
+package zboxtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/marker"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/gorilla/mux"
+)
+
+// Blobber is an in-process fake blobber, it stores the allocations in memory. It serves the
+// endpoints of the uploads (connections, write marker locks, uploads, commits and rollbacks), the
+// listings, the file metas, the downloads and the shares, the other endpoints can be added to its router.
+// The validation roots aren't computed, the downloads can't verify the merkle proofs.
+type Blobber struct {
+	*httptest.Server
+	*mux.Router
+
+	// ID is the id of the blobber.
+	ID string
+
+	mu          sync.Mutex
+	allocations map[string]*allocation // by allocation tx
+}
+
+// allocation is the state of an allocation on a blobber.
+type allocation struct {
+	id, tx                  string
+	ownerID, ownerPublicKey string
+
+	vm     *marker.VersionMarker
+	refs   map[string]*fileref.FileRef // by path, the directories included
+	data   map[string][]byte           // the shards by path
+	thumbs map[string][]byte           // the thumbnail shards by path
+
+	// prev is the state before the last commit, restored by a rollback
+	prev *snapshot
+	// lock is the connection holding the write marker lock
+	lock        string
+	connections map[string]*connection
+	shares      map[string]*marker.AuthTicket // by file path hash
+}
+
+type snapshot struct {
+	vm     *marker.VersionMarker
+	refs   map[string]*fileref.FileRef
+	data   map[string][]byte
+	thumbs map[string][]byte
+}
+
+// connection holds the uploads of a connection until they're committed.
+type connection struct {
+	uploads map[string]*upload // by path
+}
+
+type upload struct {
+	meta  sdk.UploadFormData
+	data  []byte
+	thumb []byte
+}
+
+// NewBlobber starts a fake blobber.
+//   - id: the id of the blobber
+func NewBlobber(id string) *Blobber {
+	router := mux.NewRouter()
+	b := &Blobber{
+		Router:      router,
+		Server:      httptest.NewServer(router),
+		ID:          id,
+		allocations: make(map[string]*allocation),
+	}
+
+	router.HandleFunc("/v1/connection/create/{allocation}", b.withAllocation(b.createConnection)).Methods(http.MethodPost)
+	router.HandleFunc("/v1/file/upload/{allocation}", b.withAllocation(b.upload)).Methods(http.MethodPost, http.MethodPut)
+	router.HandleFunc("/v1/writemarker/lock/{allocation}", b.withAllocation(b.lock)).Methods(http.MethodPost)
+	router.HandleFunc("/v1/writemarker/lock/{allocation}/{connection}", b.withAllocation(b.unlock)).Methods(http.MethodDelete)
+	router.HandleFunc("/v1/file/latestwritemarker/{allocation}", b.withAllocation(b.latestVersionMarker)).Methods(http.MethodGet)
+	router.HandleFunc("/v1/connection/commit/{allocation}", b.withAllocation(b.commit)).Methods(http.MethodPost)
+	router.HandleFunc("/v1/connection/rollback/{allocation}", b.withAllocation(b.rollback)).Methods(http.MethodPost)
+	router.HandleFunc("/v1/file/list/{allocation}", b.withAllocation(b.list)).Methods(http.MethodGet)
+	router.HandleFunc("/v1/file/meta/{allocation}", b.withAllocation(b.fileMeta)).Methods(http.MethodPost)
+	router.HandleFunc("/v1/file/download/{allocation}", b.withAllocation(b.download)).Methods(http.MethodGet)
+	router.HandleFunc("/v1/marketplace/shareinfo/{allocation}", b.withAllocation(b.share)).Methods(http.MethodPost)
+
+	return b
+}
+
+// Version returns the version of an allocation on the blobber, the number of its commits.
+//   - allocationID: the id of the allocation
+func (b *Blobber) Version(allocationID string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, a := range b.allocations {
+		if a.id == allocationID && a.vm != nil {
+			return a.vm.Version
+		}
+	}
+	return 0
+}
+
+// addAllocation adds an empty allocation to the blobber.
+func (b *Blobber) addAllocation(id, tx, ownerID, ownerPublicKey string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	a := &allocation{
+		id:             id,
+		tx:             tx,
+		ownerID:        ownerID,
+		ownerPublicKey: ownerPublicKey,
+		data:           make(map[string][]byte),
+		thumbs:         make(map[string][]byte),
+		connections:    make(map[string]*connection),
+		shares:         make(map[string]*marker.AuthTicket),
+		refs:           make(map[string]*fileref.FileRef),
+	}
+	a.refs["/"] = a.newDir("/", 0)
+	b.allocations[tx] = a
+}
+
+// withAllocation serves a request of an allocation of the blobber, the requests are served one at a time.
+func (b *Blobber) withAllocation(handler func(w http.ResponseWriter, r *http.Request, a *allocation)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		a, ok := b.allocations[mux.Vars(r)["allocation"]]
+		if !ok {
+			http.Error(w, "invalid_allocation: allocation not found", http.StatusBadRequest)
+			return
+		}
+		handler(w, r, a)
+	}
+}
+
+func (b *Blobber) createConnection(w http.ResponseWriter, r *http.Request, a *allocation) {
+	if !a.isOwner(r) {
+		http.Error(w, "invalid_client: only the owner can write to the allocation", http.StatusUnauthorized)
+		return
+	}
+	connID := r.FormValue("connection_id")
+	if connID == "" {
+		http.Error(w, "invalid_parameters: connection_id is required", http.StatusBadRequest)
+		return
+	}
+	if _, ok := a.connections[connID]; !ok {
+		a.connections[connID] = &connection{uploads: make(map[string]*upload)}
+	}
+	writeJSON(w, map[string]string{"connection_id": connID})
+}
+
+func (b *Blobber) upload(w http.ResponseWriter, r *http.Request, a *allocation) {
+	if !a.isOwner(r) {
+		http.Error(w, "invalid_client: only the owner can write to the allocation", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "invalid_parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var meta sdk.UploadFormData
+	if err := json.Unmarshal([]byte(r.FormValue("uploadMeta")), &meta); err != nil {
+		http.Error(w, "invalid_parameters: invalid upload meta: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	conn, ok := a.connections[meta.ConnectionID]
+	if !ok {
+		http.Error(w, "invalid_connection: connection not found", http.StatusBadRequest)
+		return
+	}
+	data, err := readFormFile(r, "uploadFile")
+	if err != nil {
+		http.Error(w, "invalid_parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	thumb, err := readFormFile(r, "uploadThumbnailFile")
+	if err != nil {
+		http.Error(w, "invalid_parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	u, ok := conn.uploads[meta.Path]
+	if !ok {
+		u = &upload{}
+		conn.uploads[meta.Path] = u
+	}
+	// the bodies of a chunk are sent in parallel, they're written at their offset
+	if end := meta.UploadOffset + int64(len(data)); end > int64(len(u.data)) {
+		u.data = append(u.data, make([]byte, end-int64(len(u.data)))...)
+	}
+	copy(u.data[meta.UploadOffset:], data)
+	if len(thumb) > 0 {
+		u.thumb = thumb
+	}
+	if meta.IsFinal || u.meta.Path == "" {
+		u.meta = meta
+	}
+	writeJSON(w, map[string]string{"filename": meta.Filename})
+}
+
+func (b *Blobber) lock(w http.ResponseWriter, r *http.Request, a *allocation) {
+	connID := r.FormValue("connection_id")
+	status := sdk.WMLockStatusOK
+	if a.lock != "" && a.lock != connID {
+		status = sdk.WMLockStatusPending
+	} else {
+		a.lock = connID
+	}
+	writeJSON(w, &sdk.WMLockResult{Status: status})
+}
+
+func (b *Blobber) unlock(w http.ResponseWriter, r *http.Request, a *allocation) {
+	if a.lock == mux.Vars(r)["connection"] {
+		a.lock = ""
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Blobber) latestVersionMarker(w http.ResponseWriter, r *http.Request, a *allocation) {
+	writeJSON(w, &sdk.LatestVersionMarker{VersionMarker: a.vm})
+}
+
+func (b *Blobber) commit(w http.ResponseWriter, r *http.Request, a *allocation) {
+	connID := r.FormValue("connection_id")
+	conn, ok := a.connections[connID]
+	if !ok {
+		http.Error(w, "invalid_connection: connection not found", http.StatusBadRequest)
+		return
+	}
+	if a.lock != "" && a.lock != connID {
+		http.Error(w, "lock_error: the write marker is locked by another connection", http.StatusBadRequest)
+		return
+	}
+	vm, err := a.versionMarker(r, b.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if vm.Version != a.version()+1 {
+		http.Error(w, fmt.Sprintf("invalid_version_marker: expected version %d, got %d", a.version()+1, vm.Version), http.StatusBadRequest)
+		return
+	}
+
+	a.prev = a.snapshot()
+	paths := make([]string, 0, len(conn.uploads))
+	for path := range conn.uploads {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		a.apply(conn.uploads[path], vm)
+	}
+	a.updateDirs(vm)
+	a.vm = vm
+	delete(a.connections, connID)
+	writeJSON(w, map[string]interface{}{"success": true})
+}
+
+func (b *Blobber) rollback(w http.ResponseWriter, r *http.Request, a *allocation) {
+	vm, err := a.versionMarker(r, b.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if a.prev == nil || vm.Version != a.version()-1 {
+		http.Error(w, fmt.Sprintf("invalid_version_marker: can't roll back to version %d", vm.Version), http.StatusBadRequest)
+		return
+	}
+	a.vm, a.refs, a.data, a.thumbs = a.prev.vm, a.prev.refs, a.prev.data, a.prev.thumbs
+	a.prev = nil
+	writeJSON(w, map[string]interface{}{"success": true})
+}
+
+func (b *Blobber) list(w http.ResponseWriter, r *http.Request, a *allocation) {
+	ref := a.ref(r.FormValue("path"), r.FormValue("path_hash"))
+	if ref == nil {
+		http.Error(w, "invalid_parameters: invalid path", http.StatusBadRequest)
+		return
+	}
+	if err := a.authorize(r, r.FormValue("auth_token"), ref); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	list := make([]map[string]interface{}, 0)
+	if ref.Type == fileref.DIRECTORY {
+		for _, child := range a.children(ref.Path) {
+			list = append(list, toMap(child))
+		}
+		offset, _ := strconv.Atoi(r.FormValue("offset"))
+		limit, _ := strconv.Atoi(r.FormValue("limit"))
+		if offset > len(list) {
+			offset = len(list)
+		}
+		list = list[offset:]
+		if limit > 0 && limit < len(list) {
+			list = list[:limit]
+		}
+	}
+	writeJSON(w, &fileref.ListResult{
+		AllocationRoot: a.refs["/"].Hash,
+		Meta:           toMap(ref),
+		Entities:       list,
+	})
+}
+
+func (b *Blobber) fileMeta(w http.ResponseWriter, r *http.Request, a *allocation) {
+	ref := a.ref(r.FormValue("path"), r.FormValue("path_hash"))
+	if ref == nil {
+		http.Error(w, "invalid_parameters: file not found", http.StatusBadRequest)
+		return
+	}
+	if err := a.authorize(r, r.FormValue("auth_token"), ref); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, ref)
+}
+
+func (b *Blobber) download(w http.ResponseWriter, r *http.Request, a *allocation) {
+	ref := a.ref("", r.Header.Get("X-Path-Hash"))
+	if ref == nil || ref.Type != fileref.FILE {
+		http.Error(w, "invalid_parameters: file not found", http.StatusBadRequest)
+		return
+	}
+	var authToken string
+	if token := r.Header.Get("X-Auth-Token"); token != "" {
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			http.Error(w, "invalid_parameters: invalid auth token", http.StatusBadRequest)
+			return
+		}
+		authToken = string(decoded)
+	}
+	if err := a.authorize(r, authToken, ref); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	data := a.data[ref.Path]
+	if r.Header.Get("X-Mode") == "thumbnail" {
+		data = a.thumbs[ref.Path]
+	} else {
+		// the blocks are numbered from 0, the sdk omits the header for the first block
+		blockNum, _ := strconv.ParseInt(r.Header.Get("X-Block-Num"), 10, 64)
+		numBlocks, _ := strconv.ParseInt(r.Header.Get("X-Num-Blocks"), 10, 64)
+		if numBlocks < 1 {
+			numBlocks = 1
+		}
+		chunkSize := ref.ChunkSize
+		if chunkSize == 0 {
+			chunkSize = fileref.CHUNK_SIZE
+		}
+		data = window(data, blockNum*chunkSize, (blockNum+numBlocks)*chunkSize)
+	}
+
+	if r.Header.Get("X-Verify-Download") == "true" {
+		writeJSON(w, map[string]interface{}{"Data": data})
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(data) //nolint: errcheck
+}
+
+func (b *Blobber) share(w http.ResponseWriter, r *http.Request, a *allocation) {
+	if !a.isOwner(r) {
+		http.Error(w, "invalid_client: only the owner can share the files of the allocation", http.StatusUnauthorized)
+		return
+	}
+	at := &marker.AuthTicket{}
+	if err := json.Unmarshal([]byte(r.FormValue("auth_ticket")), at); err != nil {
+		http.Error(w, "invalid_parameters: invalid auth ticket", http.StatusBadRequest)
+		return
+	}
+	if err := a.verifyAuthTicket(at); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.shares[at.FilePathHash] = at
+	writeJSON(w, map[string]string{"message": "share info added successfully"})
+}
+
+func (a *allocation) version() int64 {
+	if a.vm == nil {
+		return 0
+	}
+	return a.vm.Version
+}
+
+func (a *allocation) isOwner(r *http.Request) bool {
+	return r.Header.Get("X-App-Client-ID") == a.ownerID
+}
+
+// versionMarker reads the version marker of a commit or a rollback and verifies it.
+func (a *allocation) versionMarker(r *http.Request, blobberID string) (*marker.VersionMarker, error) {
+	vm := &marker.VersionMarker{}
+	if err := json.Unmarshal([]byte(r.FormValue("version_marker")), vm); err != nil {
+		return nil, fmt.Errorf("invalid_parameters: invalid version marker: %v", err)
+	}
+	if vm.AllocationID != a.id || vm.BlobberID != blobberID || vm.ClientID != a.ownerID {
+		return nil, fmt.Errorf("invalid_version_marker: the version marker isn't for the allocation on the blobber")
+	}
+	ok, err := sys.VerifyWith(a.ownerPublicKey, vm.Signature, vm.GetHash())
+	if err != nil || !ok {
+		return nil, fmt.Errorf("invalid_version_marker: invalid signature")
+	}
+	return vm, nil
+}
+
+// authorize checks a client can read a ref, the owner can read all the refs and the other clients
+// the refs shared with them.
+func (a *allocation) authorize(r *http.Request, authToken string, ref *fileref.FileRef) error {
+	if authToken == "" {
+		if !a.isOwner(r) {
+			return fmt.Errorf("invalid_client: an auth ticket is required")
+		}
+		return nil
+	}
+	at := &marker.AuthTicket{}
+	if err := json.Unmarshal([]byte(authToken), at); err != nil {
+		return fmt.Errorf("invalid_auth_ticket: %v", err)
+	}
+	if err := a.verifyAuthTicket(at); err != nil {
+		return err
+	}
+	if _, ok := a.shares[at.FilePathHash]; !ok {
+		return fmt.Errorf("invalid_auth_ticket: the file isn't shared")
+	}
+	if at.ClientID != "" && at.ClientID != r.Header.Get("X-App-Client-ID") {
+		return fmt.Errorf("invalid_auth_ticket: the file isn't shared with the client")
+	}
+	shared := a.ref("", at.FilePathHash)
+	if shared == nil {
+		return fmt.Errorf("invalid_auth_ticket: the shared file doesn't exist")
+	}
+	if at.RefType == fileref.FILE && shared.Path != ref.Path ||
+		at.RefType == fileref.DIRECTORY && !isUnder(ref.Path, shared.Path) {
+		return fmt.Errorf("invalid_auth_ticket: the auth ticket doesn't give access to %s", ref.Path)
+	}
+	return nil
+}
+
+func (a *allocation) verifyAuthTicket(at *marker.AuthTicket) error {
+	if at.AllocationID != a.id || at.OwnerID != a.ownerID {
+		return fmt.Errorf("invalid_auth_ticket: the auth ticket isn't for the allocation")
+	}
+	ok, err := sys.VerifyWith(a.ownerPublicKey, at.Signature, encryption.Hash(at.GetHashData()))
+	if err != nil || !ok {
+		return fmt.Errorf("invalid_auth_ticket: invalid signature")
+	}
+	return nil
+}
+
+// ref returns the ref of a path or of a lookup hash, nil if there is none.
+func (a *allocation) ref(path, pathHash string) *fileref.FileRef {
+	if path != "" {
+		return a.refs[path]
+	}
+	for _, ref := range a.refs {
+		if ref.LookupHash == pathHash {
+			return ref
+		}
+	}
+	return nil
+}
+
+// children returns the children of a directory, sorted by path.
+func (a *allocation) children(dir string) []*fileref.FileRef {
+	var children []*fileref.FileRef
+	for path, ref := range a.refs {
+		if path != dir && parentPath(path) == dir {
+			children = append(children, ref)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Path < children[j].Path })
+	return children
+}
+
+func (a *allocation) snapshot() *snapshot {
+	s := &snapshot{
+		vm:     a.vm,
+		refs:   make(map[string]*fileref.FileRef, len(a.refs)),
+		data:   make(map[string][]byte, len(a.data)),
+		thumbs: make(map[string][]byte, len(a.thumbs)),
+	}
+	for path, ref := range a.refs {
+		copied := *ref
+		s.refs[path] = &copied
+	}
+	for path, data := range a.data {
+		s.data[path] = data
+	}
+	for path, thumb := range a.thumbs {
+		s.thumbs[path] = thumb
+	}
+	return s
+}
+
+// apply stores an upload committed with a version marker.
+func (a *allocation) apply(u *upload, vm *marker.VersionMarker) {
+	path := u.meta.Path
+	ref := &fileref.FileRef{
+		Ref: fileref.Ref{
+			Type:                fileref.FILE,
+			AllocationID:        a.id,
+			Name:                u.meta.Filename,
+			Path:                path,
+			Size:                int64(len(u.data)),
+			ChunkSize:           u.meta.ChunkSize,
+			PathHash:            fileref.GetReferenceLookup(a.id, path),
+			LookupHash:          fileref.GetReferenceLookup(a.id, path),
+			ThumbnailHash:       u.meta.ThumbnailContentHash,
+			ThumbnailSize:       int64(len(u.thumb)),
+			ActualThumbnailHash: u.meta.ActualThumbHash,
+			ActualThumbnailSize: u.meta.ActualThumbSize,
+			AllocationVersion:   vm.Version,
+			CreatedAt:           common.Timestamp(vm.Timestamp),
+			UpdatedAt:           common.Timestamp(vm.Timestamp),
+		},
+		CustomMeta:              u.meta.CustomMeta,
+		ThumbnailSize:           int64(len(u.thumb)),
+		ThumbnailHash:           u.meta.ThumbnailContentHash,
+		ActualFileSize:          u.meta.ActualSize,
+		ActualFileHash:          u.meta.ActualHash,
+		ActualFileHashSignature: u.meta.ActualFileHashSignature,
+		ActualThumbnailSize:     u.meta.ActualThumbSize,
+		ActualThumbnailHash:     u.meta.ActualThumbHash,
+		MimeType:                u.meta.MimeType,
+		EncryptedKey:            u.meta.EncryptedKey,
+		EncryptedKeyPoint:       u.meta.EncryptedKeyPoint,
+	}
+	ref.ActualSize = u.meta.ActualSize
+	if prev, ok := a.refs[path]; ok {
+		ref.CreatedAt = prev.CreatedAt
+	}
+	ref.CalculateHash()
+	a.refs[path] = ref
+	a.data[path] = u.data
+	if len(u.thumb) > 0 {
+		a.thumbs[path] = u.thumb
+	} else {
+		delete(a.thumbs, path)
+	}
+	for dir := parentPath(path); ; dir = parentPath(dir) {
+		if _, ok := a.refs[dir]; !ok {
+			a.refs[dir] = a.newDir(dir, vm.Timestamp)
+		}
+		if dir == "/" {
+			break
+		}
+	}
+}
+
+func (a *allocation) newDir(path string, timestamp int64) *fileref.FileRef {
+	name := path
+	if path != "/" {
+		name = path[strings.LastIndex(path, "/")+1:]
+	}
+	return &fileref.FileRef{Ref: fileref.Ref{
+		Type:         fileref.DIRECTORY,
+		AllocationID: a.id,
+		Name:         name,
+		Path:         path,
+		PathHash:     fileref.GetReferenceLookup(a.id, path),
+		LookupHash:   fileref.GetReferenceLookup(a.id, path),
+		CreatedAt:    common.Timestamp(timestamp),
+		UpdatedAt:    common.Timestamp(timestamp),
+	}}
+}
+
+// updateDirs computes the sizes and the hashes of the directories after a commit.
+func (a *allocation) updateDirs(vm *marker.VersionMarker) {
+	dirs := make([]string, 0)
+	for path, ref := range a.refs {
+		if ref.Type == fileref.DIRECTORY {
+			dirs = append(dirs, path)
+		}
+	}
+	// the deepest directories first, so the hashes of the children are up to date
+	sort.Slice(dirs, func(i, j int) bool {
+		if di, dj := strings.Count(dirs[i], "/"), strings.Count(dirs[j], "/"); di != dj {
+			return di > dj
+		}
+		return dirs[i] < dirs[j]
+	})
+	for _, path := range dirs {
+		dir := a.refs[path]
+		dir.Size, dir.ActualSize, dir.NumBlocks = 0, 0, 0
+		var hashes, metaHashes []string
+		for _, child := range a.children(path) {
+			dir.Size += child.Size
+			dir.ActualSize += child.ActualSize
+			dir.NumBlocks += child.NumBlocks
+			hashes = append(hashes, child.Hash)
+			metaHashes = append(metaHashes, child.FileMetaHash)
+		}
+		hash := encryption.Hash(path + ":" + strings.Join(hashes, ":"))
+		if hash != dir.Hash {
+			dir.Hash = hash
+			dir.FileMetaHash = encryption.Hash(path + ":" + strings.Join(metaHashes, ":"))
+			dir.AllocationVersion = vm.Version
+			dir.UpdatedAt = common.Timestamp(vm.Timestamp)
+		}
+	}
+}
+
+func readFormFile(r *http.Request, name string) ([]byte, error) {
+	f, _, err := r.FormFile(name)
+	if err == http.ErrMissingFile {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) //nolint: errcheck
+}
+
+// toMap returns the fields of a ref as the blobbers list them.
+func toMap(ref *fileref.FileRef) map[string]interface{} {
+	data, _ := json.Marshal(ref)
+	m := make(map[string]interface{})
+	json.Unmarshal(data, &m) //nolint: errcheck
+	return m
+}
+
+func window(data []byte, start, end int64) []byte {
+	if start > int64(len(data)) {
+		start = int64(len(data))
+	}
+	if end > int64(len(data)) {
+		end = int64(len(data))
+	}
+	return data[start:end]
+}
+
+func parentPath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return "/"
+	}
+	return path[:i]
+}
+
+func isUnder(path, dir string) bool {
+	return path == dir || dir == "/" || strings.HasPrefix(path, dir+"/")
+}
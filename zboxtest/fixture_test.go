@@ -0,0 +1,65 @@
+package zboxtest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures(t *testing.T) {
+	n, err := NewNetwork()
+	require.NoError(t, err)
+	defer n.Close()
+	require.NoError(t, n.InitSDK())
+
+	content := bytes.Repeat([]byte("fixtures"), 20*1024+5)
+	flow := func(t *testing.T) {
+		alloc, err := sdk.GetAllocation(n.AllocationID)
+		require.NoError(t, err)
+		err = alloc.DoMultiOperation([]sdk.OperationRequest{{
+			OperationType: constants.FileOperationInsert,
+			RemotePath:    "/file.txt",
+			FileMeta: sdk.FileMeta{
+				ActualSize: int64(len(content)),
+				MimeType:   "text/plain",
+				RemoteName: "file.txt",
+				RemotePath: "/file.txt",
+			},
+			FileReader: bytes.NewReader(content),
+		}})
+		require.NoError(t, err)
+
+		list, err := alloc.ListDir("/")
+		require.NoError(t, err)
+		require.Len(t, list.Children, 1)
+		require.Equal(t, "file.txt", list.Children[0].Name)
+
+		localPath := filepath.Join(t.TempDir(), "file.txt")
+		cb := newStatusCallback()
+		require.NoError(t, alloc.DownloadFile(localPath, "/file.txt", false, cb, true))
+		cb.wait(t)
+		downloaded, err := os.ReadFile(localPath)
+		require.NoError(t, err)
+		require.Equal(t, content, downloaded)
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, zboxutil.StartRecording(dir))
+	require.Equal(t, zboxutil.FixtureModeRecord, zboxutil.GetFixtureMode())
+	flow(t)
+	require.NoError(t, zboxutil.StopFixtures())
+	require.Equal(t, zboxutil.FixtureModeOff, zboxutil.GetFixtureMode())
+
+	// the replay doesn't need the network
+	n.Close()
+	require.NoError(t, zboxutil.StartReplay(dir))
+	defer zboxutil.StopFixtures() //nolint: errcheck
+	require.Error(t, zboxutil.StartRecording(dir))
+	flow(t)
+}
@@ -0,0 +1,183 @@
+// Package zboxtest provides an in-process fake network, sharders and blobbers, to test the upload,
+// download and share flows of the storage SDK without a devnet.
+//
+//	n, err := zboxtest.NewNetwork(zboxtest.WithShards(2, 1))
+//	...
+//	defer n.Close()
+//	err = n.InitSDK()
+//	...
+//	alloc, err := sdk.GetAllocation(n.AllocationID)
+//
+// The ids of the network are deterministic, they only depend on the wallet and on the number of
+// networks created before in the process.
+package zboxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/0chain/gosdk/core/conf"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+)
+
+const (
+	// ChainID is the id of the chain of the networks.
+	ChainID = "zboxtest"
+
+	// Mnemonic is the mnemonic of the default wallet of the networks, an ed25519 wallet.
+	Mnemonic = "travel twenty hen negative fresh sentence hen flat swift embody increase juice " +
+		"eternal satisfy want vessel matter honey video begin dutch trigger romance assault"
+
+	allocationSize       = 1 << 30
+	allocationExpiration = 4102444800 // 2100-01-01
+	allFileOptions       = 63
+)
+
+// networks counts the networks created in the process, it seeds their ids.
+var networks int64
+
+// Network is a fake network with an allocation of its wallet on all its blobbers.
+type Network struct {
+	Sharders []*Sharder
+	Blobbers []*Blobber
+
+	// Wallet is the wallet owning the allocation.
+	Wallet *zcncrypto.Wallet
+	// SignatureScheme is the signature scheme of the wallet.
+	SignatureScheme string
+	// AllocationID is the id of the allocation.
+	AllocationID string
+
+	numSharders  int
+	dataShards   int
+	parityShards int
+}
+
+// Option configures a network.
+type Option func(n *Network)
+
+// WithShards sets the data and parity shards of the allocation, a blobber is started for each shard.
+// The allocation has 2 data and 1 parity shards by default.
+//   - dataShards: the number of data shards
+//   - parityShards: the number of parity shards
+func WithShards(dataShards, parityShards int) Option {
+	return func(n *Network) {
+		n.dataShards = dataShards
+		n.parityShards = parityShards
+	}
+}
+
+// WithSharders sets the number of sharders of the network, 1 by default.
+//   - num: the number of sharders
+func WithSharders(num int) Option {
+	return func(n *Network) {
+		n.numSharders = num
+	}
+}
+
+// WithWallet sets the wallet owning the allocation, the wallet recovered from Mnemonic by default.
+//   - w: the wallet
+//   - signatureScheme: the signature scheme of the wallet
+func WithWallet(w *zcncrypto.Wallet, signatureScheme string) Option {
+	return func(n *Network) {
+		n.Wallet = w
+		n.SignatureScheme = signatureScheme
+	}
+}
+
+// NewNetwork starts the sharders and the blobbers of a network, and creates the allocation of its wallet.
+// The network should be closed once it's not used anymore.
+//   - opts: the options of the network
+func NewNetwork(opts ...Option) (*Network, error) {
+	n := &Network{
+		numSharders:  1,
+		dataShards:   2,
+		parityShards: 1,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if n.numSharders < 1 || n.dataShards < 1 || n.parityShards < 0 {
+		return nil, fmt.Errorf("zboxtest: invalid network, %d sharders, %d data and %d parity shards",
+			n.numSharders, n.dataShards, n.parityShards)
+	}
+	if n.Wallet == nil {
+		n.SignatureScheme = "ed25519"
+		w, err := zcncrypto.NewSignatureScheme(n.SignatureScheme).RecoverKeys(Mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		n.Wallet = w
+	}
+
+	seed := atomic.AddInt64(&networks, 1)
+	network := sdk.Network{Miners: []string{}}
+	for i := 0; i < n.numSharders; i++ {
+		s := NewSharder()
+		n.Sharders = append(n.Sharders, s)
+		network.Sharders = append(network.Sharders, s.URL)
+	}
+	for _, s := range n.Sharders {
+		s.setNetwork(network)
+	}
+
+	n.AllocationID = encryption.Hash(fmt.Sprintf("zboxtest:%d:allocation:%s", seed, n.Wallet.ClientID))
+	alloc := &allocationInfo{
+		ID:             n.AllocationID,
+		Tx:             n.AllocationID,
+		DataShards:     n.dataShards,
+		ParityShards:   n.parityShards,
+		Size:           allocationSize,
+		Expiration:     allocationExpiration,
+		Owner:          n.Wallet.ClientID,
+		OwnerPublicKey: n.Wallet.ClientKey,
+		FileOptions:    allFileOptions,
+	}
+	for i := 0; i < n.dataShards+n.parityShards; i++ {
+		b := NewBlobber(encryption.Hash(fmt.Sprintf("zboxtest:%d:blobber:%d", seed, i)))
+		b.addAllocation(alloc.ID, alloc.Tx, alloc.Owner, alloc.OwnerPublicKey)
+		n.Blobbers = append(n.Blobbers, b)
+		alloc.Blobbers = append(alloc.Blobbers, &blockchain.StorageNode{ID: b.ID, Baseurl: b.URL})
+	}
+	for _, s := range n.Sharders {
+		s.addAllocation(alloc)
+	}
+	return n, nil
+}
+
+// BlockWorker returns the url of the block worker of the network.
+func (n *Network) BlockWorker() string {
+	return n.Sharders[0].URL
+}
+
+// WalletJSON returns the json of the wallet owning the allocation.
+func (n *Network) WalletJSON() string {
+	data, _ := json.Marshal(n.Wallet)
+	return string(data)
+}
+
+// InitSDK initializes the storage SDK with the wallet and the block worker of the network. The client
+// config is only initialized if it's not initialized yet.
+func (n *Network) InitSDK() error {
+	conf.InitClientConfig(&conf.Config{
+		BlockWorker:       n.BlockWorker(),
+		ChainID:           ChainID,
+		SignatureScheme:   n.SignatureScheme,
+		SharderConsensous: len(n.Sharders),
+	})
+	return sdk.InitStorageSDK(n.WalletJSON(), n.BlockWorker(), ChainID, n.SignatureScheme, nil, 0)
+}
+
+// Close stops the sharders and the blobbers of the network.
+func (n *Network) Close() {
+	for _, b := range n.Blobbers {
+		b.Close()
+	}
+	for _, s := range n.Sharders {
+		s.Close()
+	}
+}
@@ -0,0 +1,101 @@
+package zboxtest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+type statusCallback struct {
+	done chan error
+}
+
+func newStatusCallback() *statusCallback {
+	return &statusCallback{done: make(chan error, 1)}
+}
+
+func (cb *statusCallback) Started(allocationID, filePath string, op int, totalBytes int) {}
+func (cb *statusCallback) InProgress(allocationID, filePath string, op int, completedBytes int, data []byte) {
+}
+func (cb *statusCallback) RepairCompleted(filesRepaired int) {}
+func (cb *statusCallback) Completed(allocationID, filePath, filename, mimetype string, size int, op int) {
+	cb.done <- nil
+}
+func (cb *statusCallback) Error(allocationID string, filePath string, op int, err error) {
+	cb.done <- err
+}
+
+func (cb *statusCallback) wait(t *testing.T) {
+	select {
+	case err := <-cb.done:
+		require.NoError(t, err)
+	case <-time.After(time.Minute):
+		t.Fatal("timeout")
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	n, err := NewNetwork(WithShards(2, 1))
+	require.NoError(t, err)
+	defer n.Close()
+	require.NoError(t, n.InitSDK())
+
+	alloc, err := sdk.GetAllocation(n.AllocationID)
+	require.NoError(t, err)
+	require.Len(t, alloc.Blobbers, 3)
+
+	// several blocks, the last one partial
+	content := bytes.Repeat([]byte("zboxtest"), 40*1024+3)
+	err = alloc.DoMultiOperation([]sdk.OperationRequest{{
+		OperationType: constants.FileOperationInsert,
+		RemotePath:    "/docs/file.txt",
+		FileMeta: sdk.FileMeta{
+			ActualSize: int64(len(content)),
+			MimeType:   "text/plain",
+			RemoteName: "file.txt",
+			RemotePath: "/docs/file.txt",
+		},
+		FileReader: bytes.NewReader(content),
+	}})
+	require.NoError(t, err)
+	for _, b := range n.Blobbers {
+		require.Equal(t, int64(1), b.Version(n.AllocationID))
+	}
+
+	list, err := alloc.ListDir("/")
+	require.NoError(t, err)
+	require.Len(t, list.Children, 1)
+	require.Equal(t, "/docs", list.Children[0].Path)
+	list, err = alloc.ListDir("/docs")
+	require.NoError(t, err)
+	require.Len(t, list.Children, 1)
+	require.Equal(t, "file.txt", list.Children[0].Name)
+	require.Equal(t, int64(len(content)), list.Children[0].ActualSize)
+
+	dir := t.TempDir()
+	cb := newStatusCallback()
+	require.NoError(t, alloc.DownloadFile(filepath.Join(dir, "owner.txt"), "/docs/file.txt", false, cb, true))
+	cb.wait(t)
+	downloaded, err := os.ReadFile(filepath.Join(dir, "owner.txt"))
+	require.NoError(t, err)
+	require.Equal(t, content, downloaded)
+
+	ticket, err := alloc.GetAuthTicketForShare("/docs/file.txt", "file.txt", fileref.FILE, "")
+	require.NoError(t, err)
+	shared, err := sdk.GetAllocationFromAuthTicket(ticket)
+	require.NoError(t, err)
+	cb = newStatusCallback()
+	lookupHash := fileref.GetReferenceLookup(n.AllocationID, "/docs/file.txt")
+	require.NoError(t, shared.DownloadFromAuthTicket(filepath.Join(dir, "shared.txt"), ticket, lookupHash, "file.txt", false, cb, true))
+	cb.wait(t)
+	downloaded, err = os.ReadFile(filepath.Join(dir, "shared.txt"))
+	require.NoError(t, err)
+	require.Equal(t, content, downloaded)
+}
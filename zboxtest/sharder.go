@@ -0,0 +1,89 @@
+package zboxtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/gorilla/mux"
+)
+
+// Sharder is an in-process fake sharder, it serves the allocations of the storage smart contract.
+// It's the block worker of the network too, it serves the miners and the sharders of the network.
+// The other endpoints can be added to its router.
+type Sharder struct {
+	*httptest.Server
+	*mux.Router
+
+	mu          sync.Mutex
+	network     sdk.Network
+	allocations map[string]*allocationInfo // by id
+}
+
+// allocationInfo is the allocation as the storage smart contract returns it.
+type allocationInfo struct {
+	ID             string                    `json:"id"`
+	Tx             string                    `json:"tx"`
+	DataShards     int                       `json:"data_shards"`
+	ParityShards   int                       `json:"parity_shards"`
+	Size           int64                     `json:"size"`
+	Expiration     int64                     `json:"expiration_date"`
+	Owner          string                    `json:"owner_id"`
+	OwnerPublicKey string                    `json:"owner_public_key"`
+	Blobbers       []*blockchain.StorageNode `json:"blobbers"`
+	FileOptions    uint16                    `json:"file_options"`
+	StartTime      int64                     `json:"start_time"`
+}
+
+// NewSharder starts a fake sharder.
+func NewSharder() *Sharder {
+	router := mux.NewRouter()
+	s := &Sharder{
+		Router:      router,
+		Server:      httptest.NewServer(router),
+		allocations: make(map[string]*allocationInfo),
+	}
+
+	router.HandleFunc(sdk.NETWORK_ENDPOINT, s.getNetwork).Methods(http.MethodGet)
+	router.HandleFunc("/v1/screst/{sc}/allocation", s.getAllocation).Methods(http.MethodGet)
+
+	return s
+}
+
+// setNetwork sets the miners and the sharders the sharder serves as a block worker.
+func (s *Sharder) setNetwork(network sdk.Network) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.network = network
+}
+
+func (s *Sharder) addAllocation(a *allocationInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allocations[a.ID] = a
+}
+
+func (s *Sharder) getNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, &s.network)
+}
+
+func (s *Sharder) getAllocation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if mux.Vars(r)["sc"] != sdk.STORAGE_SCADDRESS {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	a, ok := s.allocations[r.FormValue("allocation")]
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"allocation not found"}`)) //nolint: errcheck
+		return
+	}
+	writeJSON(w, a)
+}
@@ -0,0 +1,129 @@
+package authorizer
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zcnbridge"
+	"github.com/0chain/gosdk/zcnbridge/errors"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EthereumMintMessageHash returns the hash of the WZCN mint of a proof of ZCN burn, the messageHash of the
+// authorizers contract: keccak256(abi.encodePacked(to, amount, txid, nonce)).
+//   - pb the proof of burn
+func EthereumMintMessageHash(pb *ProofOfBurn) ([]byte, error) {
+	if !common.IsHexAddress(pb.EthereumAddress) {
+		return nil, errors.NewError("ethereum_mint_message", "invalid Ethereum address "+pb.EthereumAddress)
+	}
+	txnID, err := hex.DecodeString(pb.TxnID)
+	if err != nil {
+		return nil, errors.Wrap("ethereum_mint_message", "invalid 0chain txn id "+pb.TxnID, err)
+	}
+
+	return crypto.Keccak256(
+		common.HexToAddress(pb.EthereumAddress).Bytes(),
+		math.U256Bytes(big.NewInt(pb.Amount)),
+		txnID,
+		math.U256Bytes(big.NewInt(pb.Nonce)),
+	), nil
+}
+
+// SignEthereumMint signs the WZCN mint of a proof of ZCN burn with the Ethereum key of the authorizer,
+// the signature being accepted by the authorize call of the authorizers contract.
+//   - pb the proof of burn, its signature is set
+//   - key the Ethereum key of the authorizer
+func (pb *ProofOfBurn) SignEthereumMint(key *ecdsa.PrivateKey) error {
+	hash, err := EthereumMintMessageHash(pb)
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(accounts.TextHash(hash), key)
+	if err != nil {
+		return errors.Wrap("signature_ethereum", "failed to sign proof-of-burn ticket", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	pb.Signature = sig
+
+	return nil
+}
+
+// VerifyEthereumMintSignature checks the signature of the WZCN mint of a proof of ZCN burn was made
+// by the authorizer.
+//   - authorizer the Ethereum address of the authorizer
+func (pb *ProofOfBurn) VerifyEthereumMintSignature(authorizer common.Address) error {
+	if len(pb.Signature) != crypto.SignatureLength {
+		return errors.NewError("verify_signature_ethereum", "invalid signature length")
+	}
+	hash, err := EthereumMintMessageHash(pb)
+	if err != nil {
+		return err
+	}
+
+	sig := make([]byte, len(pb.Signature))
+	copy(sig, pb.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash(hash), sig)
+	if err != nil {
+		return errors.Wrap("verify_signature_ethereum", "failed to recover signer", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != authorizer {
+		return errors.NewError("verify_signature_ethereum", "signed by "+signer.Hex()+" instead of "+authorizer.Hex())
+	}
+	return nil
+}
+
+// ZCNMintMessage returns the message of the ZCN mint of a WZCN burn signed by the authorizers,
+// the message the zcnsc smart contract verifies the signatures of the mint with.
+//   - burn the verified WZCN burn, see VerifyEthereumBurn
+func ZCNMintMessage(burn *EthereumBurn) string {
+	return fmt.Sprintf("%v:%v:%v:%v", burn.TxnID, burn.Amount, burn.Nonce, burn.ReceivingClientID)
+}
+
+// SignZCNMint signs the ZCN mint of a verified WZCN burn with the ZChain wallet of the authorizer and
+// returns the ticket the authorizers serve to the clients.
+//   - burn the verified WZCN burn, see VerifyEthereumBurn
+//   - w the wallet of the authorizer
+func SignZCNMint(burn *EthereumBurn, w *zcncrypto.Wallet) (*zcnbridge.ProofEthereumBurn, error) {
+	sig, err := zcncore.SignWith0Wallet(zcncrypto.Sha3Sum256(ZCNMintMessage(burn)), w)
+	if err != nil {
+		return nil, errors.Wrap("signature_0chain", "failed to sign mint ticket using wallet ID "+w.ClientID, err)
+	}
+
+	return &zcnbridge.ProofEthereumBurn{
+		TxnID:             burn.TxnID,
+		Nonce:             burn.Nonce,
+		Amount:            burn.Amount,
+		ReceivingClientID: burn.ReceivingClientID,
+		Signature:         sig,
+	}, nil
+}
+
+// VerifyZCNMintSignature checks the signature of a ZCN mint ticket was made by the authorizer.
+//   - ticket the mint ticket
+//   - publicKey the ZChain public key of the authorizer
+func VerifyZCNMintSignature(ticket *zcnbridge.ProofEthereumBurn, publicKey string) error {
+	message := ZCNMintMessage(&EthereumBurn{
+		TxnID:             ticket.TxnID,
+		Nonce:             ticket.Nonce,
+		Amount:            ticket.Amount,
+		ReceivingClientID: ticket.ReceivingClientID,
+	})
+	ok, err := zcncore.VerifyWithKey(publicKey, ticket.Signature, zcncrypto.Sha3Sum256(message))
+	if err != nil {
+		return errors.Wrap("verify_signature_0chain", "failed to verify mint ticket signature", err)
+	}
+	if !ok {
+		return errors.NewError("verify_signature_0chain", "invalid mint ticket signature")
+	}
+	return nil
+}
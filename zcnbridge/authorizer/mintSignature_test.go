@@ -0,0 +1,57 @@
+package authorizer_test
+
+import (
+	"github.com/0chain/gosdk/zcnbridge/authorizer"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *TicketTestSuite) TestEthereumMintSignature() {
+	t := suite.T()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	pb := &authorizer.ProofOfBurn{
+		TxnID:           zcnTxnID,
+		Nonce:           7,
+		Amount:          1000,
+		EthereumAddress: ethereumAddress,
+	}
+	require.NoError(t, pb.SignEthereumMint(key))
+	require.Len(t, pb.Signature, crypto.SignatureLength)
+	require.Contains(t, []byte{27, 28}, pb.Signature[crypto.RecoveryIDOffset])
+
+	require.NoError(t, pb.VerifyEthereumMintSignature(crypto.PubkeyToAddress(key.PublicKey)))
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.Error(t, pb.VerifyEthereumMintSignature(crypto.PubkeyToAddress(other.PublicKey)))
+
+	pb.Amount = 1001
+	require.Error(t, pb.VerifyEthereumMintSignature(crypto.PubkeyToAddress(key.PublicKey)))
+
+	pb.TxnID = "not hex"
+	require.Error(t, pb.SignEthereumMint(key))
+}
+
+func (suite *TicketTestSuite) TestZCNMintSignature() {
+	t := suite.T()
+	zcncore.InitSignatureScheme("bls0chain")
+
+	burn := &authorizer.EthereumBurn{
+		TxnID:             "0x0000000000000000000000000000000000000000000000000000000000000001",
+		Nonce:             3,
+		Amount:            500,
+		ReceivingClientID: suite.w.ClientID,
+	}
+	ticket, err := authorizer.SignZCNMint(burn, suite.w)
+	require.NoError(t, err)
+	require.Equal(t, burn.TxnID, ticket.TxnID)
+	require.Equal(t, burn.ReceivingClientID, ticket.ReceivingClientID)
+
+	require.NoError(t, authorizer.VerifyZCNMintSignature(ticket, suite.w.ClientKey))
+
+	ticket.Amount = 501
+	require.Error(t, authorizer.VerifyZCNMintSignature(ticket, suite.w.ClientKey))
+}
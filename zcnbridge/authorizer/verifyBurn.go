@@ -0,0 +1,159 @@
+package authorizer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/zcnbridge/errors"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/wallet"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EthereumReceiptReader reads the receipts and the headers of the Ethereum chain, the ethclient.Client implements it.
+type EthereumReceiptReader interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// EthereumBurn is a WZCN burn verified on Ethereum.
+type EthereumBurn struct {
+	TxnID             string `json:"ethereum_txn_id"`
+	From              string `json:"from"`
+	Nonce             int64  `json:"nonce"`
+	Amount            int64  `json:"amount"`
+	ReceivingClientID string `json:"receiving_client_id"`
+	Block             uint64 `json:"block"`
+}
+
+// VerifyEthereumBurn verifies a WZCN burn transaction: it succeeded, it is confirmed by enough blocks
+// and it emitted a single Burned event of the bridge contract, which is returned.
+//   - ctx go context instance to run the requests
+//   - client the Ethereum client
+//   - bridgeAddress the address of the bridge contract
+//   - txnID the hash of the burn transaction
+//   - confirmations the number of blocks the transaction should be included in, including its own
+func VerifyEthereumBurn(ctx context.Context, client EthereumReceiptReader, bridgeAddress, txnID string, confirmations uint64) (*EthereumBurn, error) {
+	hash := common.HexToHash(txnID)
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, errors.Wrap("verify_ethereum_burn", "failed to get transaction receipt of "+txnID, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, errors.NewError("verify_ethereum_burn", "transaction "+txnID+" failed")
+	}
+
+	if confirmations > 0 {
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, errors.Wrap("verify_ethereum_burn", "failed to get latest header", err)
+		}
+		if receipt.BlockNumber == nil || head.Number.Cmp(receipt.BlockNumber) < 0 ||
+			new(big.Int).Sub(head.Number, receipt.BlockNumber).Uint64()+1 < confirmations {
+			return nil, errors.NewErrorf("verify_ethereum_burn", "transaction %s has less than %d confirmations", txnID, confirmations)
+		}
+	}
+
+	contract := common.HexToAddress(bridgeAddress)
+	filterer, err := bridge.NewBridgeFilterer(contract, nil)
+	if err != nil {
+		return nil, errors.Wrap("verify_ethereum_burn", "failed to create bridge filterer", err)
+	}
+	abi, err := bridge.BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap("verify_ethereum_burn", "failed to get bridge ABI", err)
+	}
+
+	var burn *EthereumBurn
+	for _, log := range receipt.Logs {
+		if log.Address != contract || len(log.Topics) == 0 || log.Topics[0] != abi.Events["Burned"].ID {
+			continue
+		}
+		if burn != nil {
+			return nil, errors.NewError("verify_ethereum_burn", "transaction "+txnID+" emitted several burn events")
+		}
+		event, err := filterer.ParseBurned(*log)
+		if err != nil {
+			return nil, errors.Wrap("verify_ethereum_burn", "failed to parse burn event", err)
+		}
+		if !event.Amount.IsInt64() || !event.Nonce.IsInt64() {
+			return nil, errors.NewError("verify_ethereum_burn", "burn amount or nonce out of range")
+		}
+		burn = &EthereumBurn{
+			TxnID:             hash.Hex(),
+			From:              event.From.Hex(),
+			Nonce:             event.Nonce.Int64(),
+			Amount:            event.Amount.Int64(),
+			ReceivingClientID: hex.EncodeToString(event.ClientId),
+			Block:             log.BlockNumber,
+		}
+	}
+	if burn == nil {
+		return nil, errors.NewError("verify_ethereum_burn", "transaction "+txnID+" emitted no burn event of the bridge")
+	}
+	return burn, nil
+}
+
+// VerifyZCNBurnTicket verifies a proof of burn with the ZChain burn transaction: the transaction succeeded,
+// burned the amount with the zcnsc smart contract for the Ethereum address, and its output reports the
+// same ticket.
+//   - pb the proof of burn
+//   - txn the burn transaction, see VerifyZCNBurn
+func VerifyZCNBurnTicket(pb *ProofOfBurn, txn *transaction.Transaction) error {
+	if err := pb.Verify(); err != nil {
+		return err
+	}
+
+	switch {
+	case txn.Hash != pb.TxnID:
+		return errors.NewError("verify_zcn_burn", "transaction hash "+txn.Hash+" doesn't match the ticket")
+	case txn.Status != transaction.TxnSuccess:
+		return errors.NewError("verify_zcn_burn", "transaction "+txn.Hash+" failed")
+	case txn.ToClientID != wallet.ZCNSCSmartContractAddress:
+		return errors.NewError("verify_zcn_burn", "transaction "+txn.Hash+" isn't a zcnsc transaction")
+	case txn.Value != uint64(pb.Amount):
+		return errors.NewErrorf("verify_zcn_burn", "transaction %s burned %d instead of %d", txn.Hash, txn.Value, pb.Amount)
+	}
+
+	var data struct {
+		Name  string `json:"name"`
+		Input struct {
+			EthereumAddress string `json:"ethereum_address"`
+		} `json:"input"`
+	}
+	if err := json.Unmarshal([]byte(txn.TransactionData), &data); err != nil {
+		return errors.Wrap("verify_zcn_burn", "failed to decode transaction data", err)
+	}
+	if data.Name != wallet.BurnFunc {
+		return errors.NewError("verify_zcn_burn", "transaction "+txn.Hash+" isn't a burn")
+	}
+	if !strings.EqualFold(data.Input.EthereumAddress, pb.EthereumAddress) {
+		return errors.NewError("verify_zcn_burn", "transaction "+txn.Hash+" burned for "+data.Input.EthereumAddress)
+	}
+
+	output := &ProofOfBurn{}
+	if err := output.Decode([]byte(txn.TransactionOutput)); err != nil {
+		return errors.Wrap("verify_zcn_burn", "failed to decode transaction output", err)
+	}
+	if output.Nonce != pb.Nonce || output.Amount != pb.Amount || !strings.EqualFold(output.EthereumAddress, pb.EthereumAddress) {
+		return errors.NewError("verify_zcn_burn", "transaction "+txn.Hash+" output doesn't match the ticket")
+	}
+	return nil
+}
+
+// VerifyZCNBurn gets the ZChain burn transaction of the proof of burn from the sharders and verifies the
+// proof of burn with it, see VerifyZCNBurnTicket.
+//   - pb the proof of burn
+func VerifyZCNBurn(pb *ProofOfBurn) error {
+	txn, err := transaction.VerifyTransaction(pb.TxnID, zcncore.GetNetwork().Sharders)
+	if err != nil {
+		return errors.Wrap("verify_zcn_burn", "failed to get transaction "+pb.TxnID, err)
+	}
+	return VerifyZCNBurnTicket(pb, txn)
+}
@@ -0,0 +1,140 @@
+package authorizer_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/zcnbridge/authorizer"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	bridgeAddress   = "0x7bbbEa24ac1751317D7669f05558632c4A9113D7"
+	ethereumAddress = "0xD8c9156e782C68EE671C09b6b92de76C97948432"
+	zcnTxnID        = "b26abeb31fcee5d2e75b26717722938a06fa5ce4a5b5e68ddad68357432caace"
+)
+
+// receiptReader returns a single receipt at a fixed head.
+type receiptReader struct {
+	receipt *types.Receipt
+	head    int64
+}
+
+func (r *receiptReader) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return r.receipt, nil
+}
+
+func (r *receiptReader) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(r.head)}, nil
+}
+
+func (suite *TicketTestSuite) burnedLog(amount, nonce int64) *types.Log {
+	abi, err := bridge.BridgeMetaData.GetAbi()
+	require.NoError(suite.T(), err)
+	clientID, _ := hex.DecodeString(suite.w.ClientID)
+	data, err := abi.Events["Burned"].Inputs.NonIndexed().Pack(big.NewInt(amount), clientID)
+	require.NoError(suite.T(), err)
+
+	return &types.Log{
+		Address: common.HexToAddress(bridgeAddress),
+		Topics: []common.Hash{
+			abi.Events["Burned"].ID,
+			common.BytesToHash(common.HexToAddress(ethereumAddress).Bytes()),
+			common.BytesToHash(math.U256Bytes(big.NewInt(nonce))),
+		},
+		Data:        data,
+		BlockNumber: 100,
+	}
+}
+
+func (suite *TicketTestSuite) TestVerifyEthereumBurn() {
+	t := suite.T()
+	txnID := common.BigToHash(big.NewInt(1)).Hex()
+	reader := &receiptReader{
+		receipt: &types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+			Logs:        []*types.Log{suite.burnedLog(500, 3)},
+		},
+		head: 111,
+	}
+
+	burn, err := authorizer.VerifyEthereumBurn(context.Background(), reader, bridgeAddress, txnID, 12)
+	require.NoError(t, err)
+	require.Equal(t, &authorizer.EthereumBurn{
+		TxnID:             txnID,
+		From:              ethereumAddress,
+		Nonce:             3,
+		Amount:            500,
+		ReceivingClientID: suite.w.ClientID,
+		Block:             100,
+	}, burn)
+
+	_, err = authorizer.VerifyEthereumBurn(context.Background(), reader, bridgeAddress, txnID, 13)
+	require.Error(t, err, "not enough confirmations")
+
+	_, err = authorizer.VerifyEthereumBurn(context.Background(), reader, ethereumAddress, txnID, 0)
+	require.Error(t, err, "burn event of another contract")
+
+	reader.receipt.Logs = append(reader.receipt.Logs, suite.burnedLog(500, 4))
+	_, err = authorizer.VerifyEthereumBurn(context.Background(), reader, bridgeAddress, txnID, 0)
+	require.Error(t, err, "several burn events")
+
+	reader.receipt.Status = types.ReceiptStatusFailed
+	_, err = authorizer.VerifyEthereumBurn(context.Background(), reader, bridgeAddress, txnID, 0)
+	require.Error(t, err, "failed transaction")
+}
+
+func (suite *TicketTestSuite) TestVerifyZCNBurnTicket() {
+	t := suite.T()
+	pb := &authorizer.ProofOfBurn{
+		TxnID:           zcnTxnID,
+		Nonce:           7,
+		Amount:          1000,
+		EthereumAddress: ethereumAddress,
+	}
+
+	data, err := json.Marshal(transaction.SmartContractTxnData{
+		Name:      wallet.BurnFunc,
+		InputArgs: map[string]string{"ethereum_address": ethereumAddress},
+	})
+	require.NoError(t, err)
+	output, err := json.Marshal(pb)
+	require.NoError(t, err)
+
+	newTxn := func() *transaction.Transaction {
+		return &transaction.Transaction{
+			Hash:              zcnTxnID,
+			ToClientID:        wallet.ZCNSCSmartContractAddress,
+			TransactionData:   string(data),
+			TransactionOutput: string(output),
+			Value:             1000,
+			Status:            transaction.TxnSuccess,
+		}
+	}
+	require.NoError(t, authorizer.VerifyZCNBurnTicket(pb, newTxn()))
+
+	txn := newTxn()
+	txn.Status = transaction.TxnFail
+	require.Error(t, authorizer.VerifyZCNBurnTicket(pb, txn))
+
+	txn = newTxn()
+	txn.Value = 999
+	require.Error(t, authorizer.VerifyZCNBurnTicket(pb, txn))
+
+	txn = newTxn()
+	txn.ToClientID = suite.w.ClientID
+	require.Error(t, authorizer.VerifyZCNBurnTicket(pb, txn))
+
+	forged := *pb
+	forged.Nonce = 8
+	require.Error(t, authorizer.VerifyZCNBurnTicket(&forged, newTxn()))
+}
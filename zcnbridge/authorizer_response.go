@@ -84,6 +84,9 @@ func (r *WZCNBurnEvent) SetAuthorizerID(id string) {
 }
 
 func (r *WZCNBurnEvent) Error() error {
+	if r.Err == nil {
+		return nil
+	}
 	return r.Err
 }
 
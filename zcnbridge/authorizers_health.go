@@ -0,0 +1,139 @@
+package zcnbridge
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// AuthorizerHealthCheckPath is the path of the authorizers queried by the preflight check.
+	AuthorizerHealthCheckPath = "/v1/healthcheck"
+	// DefaultAuthorizerHealthTimeout is the timeout of the preflight check of an authorizer.
+	DefaultAuthorizerHealthTimeout = 5 * time.Second
+)
+
+// AuthorizerHealth is the preflight check result of an authorizer.
+type AuthorizerHealth struct {
+	ID  string
+	URL string
+	// Alive tells if the authorizer answered, any status but a server error.
+	Alive bool
+	// Latency is the response time of the authorizer.
+	Latency time.Duration
+	// Err is the error of the authorizers not alive.
+	Err error
+}
+
+// AuthorizersReport is the report of the preflight check of the authorizers.
+type AuthorizersReport struct {
+	Authorizers []*AuthorizerHealth
+	Alive       int
+	Total       int
+	// Threshold is the consensus threshold, in percents of the authorizers.
+	Threshold float64
+}
+
+// Quorum tells if enough authorizers are alive to reach the consensus threshold.
+func (r *AuthorizersReport) Quorum() bool {
+	if r.Alive == 0 {
+		return false
+	}
+	return math.Ceil(float64(r.Alive)*100/float64(r.Total)) >= r.Threshold
+}
+
+// AuthorizersQuorumError is the error of the bridge operations not started because not enough
+// authorizers are alive to sign them.
+type AuthorizersQuorumError struct {
+	Report *AuthorizersReport
+}
+
+// Error implements error interface.
+func (e *AuthorizersQuorumError) Error() string {
+	var down []string
+	for _, a := range e.Report.Authorizers {
+		if !a.Alive {
+			down = append(down, a.ID)
+		}
+	}
+	return fmt.Sprintf("authorizers_quorum: %d of %d authorizers alive, threshold %.f%%, down: %s",
+		e.Report.Alive, e.Report.Total, e.Report.Threshold, strings.Join(down, ", "))
+}
+
+// CheckAuthorizers checks the liveness of the active authorizers before requesting their signatures.
+// It returns the report, with an *AuthorizersQuorumError if the consensus threshold can't be reached.
+//   - ctx go context instance to run the requests
+func (b *BridgeClient) CheckAuthorizers(ctx context.Context) (*AuthorizersReport, error) {
+	authorizers, err := getAuthorizers(true)
+	if err != nil {
+		return nil, errors.Wrap("get_authorizers", "failed to get authorizers", err)
+	}
+	return b.checkAuthorizers(ctx, authorizers)
+}
+
+func (b *BridgeClient) checkAuthorizers(ctx context.Context, authorizers []*AuthorizerNode) (*AuthorizersReport, error) {
+	report := &AuthorizersReport{
+		Authorizers: make([]*AuthorizerHealth, len(authorizers)),
+		Total:       len(authorizers),
+		Threshold:   b.ConsensusThreshold,
+	}
+
+	var wg sync.WaitGroup
+	for i, authorizer := range authorizers {
+		wg.Add(1)
+		go func(i int, authorizer *AuthorizerNode) {
+			defer wg.Done()
+			report.Authorizers[i] = checkAuthorizer(ctx, authorizer)
+		}(i, authorizer)
+	}
+	wg.Wait()
+
+	for _, health := range report.Authorizers {
+		if health.Alive {
+			report.Alive++
+		}
+	}
+
+	if !report.Quorum() {
+		return report, &AuthorizersQuorumError{Report: report}
+	}
+	return report, nil
+}
+
+func checkAuthorizer(ctx context.Context, authorizer *AuthorizerNode) *AuthorizerHealth {
+	health := &AuthorizerHealth{ID: authorizer.ID, URL: authorizer.URL}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultAuthorizerHealthTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(authorizer.URL, "/")+AuthorizerHealthCheckPath, nil)
+	if err != nil {
+		health.Err = err
+		return health
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	health.Latency = time.Since(start)
+	if err != nil {
+		health.Err = err
+	} else {
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			health.Err = fmt.Errorf("status %d", resp.StatusCode)
+		}
+	}
+	health.Alive = health.Err == nil
+
+	if !health.Alive {
+		Logger.Error("authorizer is down", zap.String("node.id", authorizer.ID), zap.String("node.url", authorizer.URL), zap.Error(health.Err))
+	}
+	return health
+}
@@ -0,0 +1,61 @@
+package zcnbridge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckAuthorizers(t *testing.T) {
+	alive := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, AuthorizerHealthCheckPath, r.URL.Path)
+	}))
+	defer alive.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	down := httptest.NewServer(nil)
+	down.Close()
+
+	authorizers := []*AuthorizerNode{
+		{ID: "alive", URL: alive.URL + "/"},
+		{ID: "failing", URL: failing.URL},
+		{ID: "down", URL: down.URL},
+	}
+
+	t.Run("should report the authorizers alive", func(t *testing.T) {
+		client := &BridgeClient{ConsensusThreshold: 30}
+		report, err := client.checkAuthorizers(context.Background(), authorizers)
+		require.NoError(t, err)
+		require.True(t, report.Quorum())
+		require.Equal(t, 1, report.Alive)
+		require.Equal(t, 3, report.Total)
+
+		require.True(t, report.Authorizers[0].Alive)
+		require.False(t, report.Authorizers[1].Alive)
+		require.Error(t, report.Authorizers[1].Err)
+		require.False(t, report.Authorizers[2].Alive)
+	})
+
+	t.Run("should fail below the consensus threshold", func(t *testing.T) {
+		client := &BridgeClient{ConsensusThreshold: 70}
+		report, err := client.checkAuthorizers(context.Background(), authorizers)
+
+		var quorumErr *AuthorizersQuorumError
+		require.True(t, errors.As(err, &quorumErr))
+		require.Equal(t, report, quorumErr.Report)
+		require.Contains(t, err.Error(), "1 of 3 authorizers alive")
+		require.Contains(t, err.Error(), "failing, down")
+	})
+
+	t.Run("should fail without authorizers", func(t *testing.T) {
+		client := &BridgeClient{}
+		_, err := client.checkAuthorizers(context.Background(), nil)
+		require.Error(t, err)
+	})
+}
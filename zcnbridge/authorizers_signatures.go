@@ -0,0 +1,207 @@
+package zcnbridge
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	h "github.com/0chain/gosdk/zcnbridge/http"
+	"go.uber.org/zap"
+)
+
+type requestHandler struct {
+	path        string
+	values      map[string]string
+	bodyDecoder func([]byte) (JobResult, error)
+}
+
+// SignatureCollector describes how the burn tickets signed by the authorizers are collected.
+type SignatureCollector struct {
+	// Timeout is the timeout of each request to an authorizer.
+	Timeout time.Duration
+	// Attempts is the number of requests to an authorizer before giving up on it.
+	Attempts int
+	// Backoff is the wait before the second request to an authorizer, doubled at each attempt.
+	Backoff time.Duration
+}
+
+// DefaultSignatureCollector is the signature collector of the bridge clients without one.
+var DefaultSignatureCollector = SignatureCollector{
+	Timeout:  30 * time.Second,
+	Attempts: 3,
+	Backoff:  time.Second,
+}
+
+// AuthorizerError is the error of an authorizer which didn't return its signed ticket.
+type AuthorizerError struct {
+	ID  string
+	URL string
+	// Attempts is the number of requests sent to the authorizer.
+	Attempts int
+	// Err is the error of the last request.
+	Err error
+}
+
+// Error implements error interface.
+func (e *AuthorizerError) Error() string {
+	return fmt.Sprintf("authorizer %s (%s) failed after %d attempts: %v", e.ID, e.URL, e.Attempts, e.Err)
+}
+
+// Unwrap implements error unwrap interface.
+func (e *AuthorizerError) Unwrap() error {
+	return e.Err
+}
+
+// SignatureCollectionError is the error of the signature collections under the consensus threshold.
+// The signatures collected are returned with it.
+type SignatureCollectionError struct {
+	Collected int
+	Total     int
+	// Threshold is the consensus threshold, in percents of the authorizers.
+	Threshold float64
+	// Failed are the errors of the authorizers which didn't sign.
+	Failed []*AuthorizerError
+}
+
+// Error implements error interface.
+func (e *SignatureCollectionError) Error() string {
+	msgs := make([]string, 0, len(e.Failed))
+	for _, failed := range e.Failed {
+		msgs = append(msgs, failed.Error())
+	}
+	return fmt.Sprintf("failed to reach the quorum. #Success: %d from #Total: %d, threshold %.f%%: %s",
+		e.Collected, e.Total, e.Threshold, strings.Join(msgs, "; "))
+}
+
+// collectSignatures queries all the authorizers and returns their results. It fails with a
+// *SignatureCollectionError, with the results collected, if the consensus threshold isn't reached.
+func (b *BridgeClient) collectSignatures(ctx context.Context, authorizers []*AuthorizerNode, handler *requestHandler) ([]JobResult, error) {
+	collector := DefaultSignatureCollector
+	if b.SignatureCollector != nil {
+		collector = *b.SignatureCollector
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]JobResult, len(authorizers))
+		errs    = make([]*AuthorizerError, len(authorizers))
+		client  = h.CleanClient()
+	)
+	for i, authorizer := range authorizers {
+		wg.Add(1)
+		go func(i int, authorizer *AuthorizerNode) {
+			defer wg.Done()
+			results[i], errs[i] = collector.query(ctx, client, authorizer, handler)
+		}(i, authorizer)
+	}
+	wg.Wait()
+
+	var (
+		collected []JobResult
+		failed    []*AuthorizerError
+	)
+	for i := range authorizers {
+		if errs[i] != nil {
+			failed = append(failed, errs[i])
+			continue
+		}
+		collected = append(collected, results[i])
+	}
+
+	total := len(authorizers)
+	if len(collected) == 0 || math.Ceil(float64(len(collected))*100/float64(total)) < b.ConsensusThreshold {
+		return collected, &SignatureCollectionError{
+			Collected: len(collected),
+			Total:     total,
+			Threshold: b.ConsensusThreshold,
+			Failed:    failed,
+		}
+	}
+	return collected, nil
+}
+
+// query requests the result of an authorizer, retrying with backoff.
+func (c SignatureCollector) query(ctx context.Context, client *http.Client, au *AuthorizerNode, handler *requestHandler) (JobResult, *AuthorizerError) {
+	attempts := c.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := c.Backoff
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, &AuthorizerError{ID: au.ID, URL: au.URL, Attempts: i, Err: ctx.Err()}
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var result JobResult
+		result, err = c.queryOnce(ctx, client, au, handler)
+		if err == nil {
+			return result, nil
+		}
+		Logger.Error(
+			"failed to query authorizer",
+			zap.Error(err),
+			zap.String("node.id", au.ID),
+			zap.String("node.url", au.URL),
+			zap.Int("attempt", i+1),
+		)
+	}
+	return nil, &AuthorizerError{ID: au.ID, URL: au.URL, Attempts: attempts, Err: err}
+}
+
+func (c SignatureCollector) queryOnce(ctx context.Context, client *http.Client, au *AuthorizerNode, handler *requestHandler) (JobResult, error) {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(au.URL, "/")+handler.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	for k, v := range handler.values {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+	Logger.Info("Query from authorizer", zap.String("ID", au.ID), zap.String("URL", req.URL.String()))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, body)
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty body")
+	}
+
+	result, err := handler.bodyDecoder(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode body %q: %w", body, err)
+	}
+	if err := result.Error(); err != nil {
+		return nil, fmt.Errorf("authorizer job failed: %w", err)
+	}
+	result.SetAuthorizerID(au.ID)
+	return result, nil
+}
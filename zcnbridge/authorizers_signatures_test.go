@@ -0,0 +1,98 @@
+package zcnbridge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/wallet"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CollectSignatures(t *testing.T) {
+	ticket := `{"0chain_txn_id":"txn","to":"0x1","nonce":1,"amount":10,"signature":"c2ln"}`
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, wallet.BurnNativeTicketPath, r.URL.Path)
+		require.Equal(t, "txn", r.URL.Query().Get("hash"))
+		_, _ = w.Write([]byte(ticket))
+	}))
+	defer healthy.Close()
+
+	var flakyCalls int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&flakyCalls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(ticket))
+	}))
+	defer flaky.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("ticket not found"))
+	}))
+	defer failing.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(ticket))
+	}))
+	defer slow.Close()
+
+	handler := &requestHandler{
+		path:   wallet.BurnNativeTicketPath,
+		values: map[string]string{"hash": "txn"},
+		bodyDecoder: func(body []byte) (JobResult, error) {
+			ev := &ProofZCNBurn{}
+			err := json.Unmarshal(body, ev)
+			return ev, err
+		},
+	}
+
+	client := &BridgeClient{
+		ConsensusThreshold: 50,
+		SignatureCollector: &SignatureCollector{Timeout: 50 * time.Millisecond, Attempts: 2, Backoff: time.Millisecond},
+	}
+
+	t.Run("should retry the failed authorizers", func(t *testing.T) {
+		results, err := client.collectSignatures(context.Background(), []*AuthorizerNode{
+			{ID: "healthy", URL: healthy.URL},
+			{ID: "flaky", URL: flaky.URL},
+		}, handler)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "healthy", results[0].GetAuthorizerID())
+		require.Equal(t, "flaky", results[1].GetAuthorizerID())
+		require.Equal(t, []byte("sig"), results[0].(*ProofZCNBurn).Signature)
+		require.Equal(t, int32(2), atomic.LoadInt32(&flakyCalls))
+	})
+
+	t.Run("should report the failed authorizers under the threshold", func(t *testing.T) {
+		results, err := client.collectSignatures(context.Background(), []*AuthorizerNode{
+			{ID: "healthy", URL: healthy.URL},
+			{ID: "failing", URL: failing.URL},
+			{ID: "slow", URL: slow.URL},
+		}, handler)
+		require.Len(t, results, 1)
+
+		var collectionErr *SignatureCollectionError
+		require.True(t, errors.As(err, &collectionErr))
+		require.Equal(t, 1, collectionErr.Collected)
+		require.Equal(t, 3, collectionErr.Total)
+		require.Len(t, collectionErr.Failed, 2)
+
+		require.Equal(t, "failing", collectionErr.Failed[0].ID)
+		require.Equal(t, 2, collectionErr.Failed[0].Attempts)
+		require.Contains(t, collectionErr.Failed[0].Error(), "status 404: ticket not found")
+
+		require.Equal(t, "slow", collectionErr.Failed[1].ID)
+		require.ErrorIs(t, collectionErr.Failed[1], context.DeadlineExceeded)
+	})
+}
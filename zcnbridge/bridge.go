@@ -72,6 +72,20 @@ var (
 // - client - Ethereum client
 // - gasLimitUnits - gas limit in units
 func (b *BridgeClient) CreateSignedTransactionFromKeyStore(client EthereumClient, gasLimitUnits uint64) *bind.TransactOpts {
+	opts, err := b.createSignedTransaction(context.Background(), client, gasLimitUnits)
+	if err != nil {
+		Logger.Fatal(err)
+	}
+	return opts
+}
+
+// createSignedTransaction creates the options of a transaction signed with the key store, priced
+// with the fees of the context or of the fee oracle, see WithFees and SetFeeOracle.
+func (b *BridgeClient) createSignedTransaction(ctx context.Context, client EthereumClient, gasLimitUnits uint64) (*bind.TransactOpts, error) {
+	if b.ReadOnly() {
+		return nil, ErrReadOnlyClient
+	}
+
 	var (
 		signerAddress = common.HexToAddress(b.EthereumAddress)
 		password      = b.Password
@@ -83,39 +97,48 @@ func (b *BridgeClient) CreateSignedTransactionFromKeyStore(client EthereumClient
 
 	signerAcc, err := b.keyStore.Find(signer)
 	if err != nil {
-		Logger.Fatal(errors.Wrapf(err, "signer: %s", signerAddress.Hex()))
+		return nil, errors.Wrapf(err, "signer: %s", signerAddress.Hex())
 	}
 
 	chainID, err := client.ChainID(context.Background())
 	if err != nil {
-		Logger.Fatal(errors.Wrap(err, "failed to get chain ID"))
+		return nil, errors.Wrap(err, "failed to get chain ID")
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), signerAddress)
-	if err != nil {
-		Logger.Fatal(err)
-	}
+	// the nonce is reserved when the transaction is sent if the nonces are managed, see ManageNonces
+	b.trackerMu.Lock()
+	managed := b.nonces != nil
+	b.trackerMu.Unlock()
 
-	gasPriceWei, err := client.SuggestGasPrice(context.Background())
-	if err != nil {
-		Logger.Fatal(err)
+	nonce, ok := ctx.Value(nonceKey{}).(uint64)
+	if !ok && !managed {
+		nonce, err = client.PendingNonceAt(context.Background(), signerAddress)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get nonce")
+		}
 	}
 
 	err = b.keyStore.TimedUnlock(signer, password, time.Second*2)
 	if err != nil {
-		Logger.Fatal(err)
+		return nil, errors.Wrap(err, "failed to unlock signer")
 	}
 
-	opts, err := bind.NewKeyStoreTransactorWithChainID(b.keyStore.GetEthereumKeyStore(), signerAcc, chainID)
+	opts, err := newTransactor(b.keyStore, signerAcc, chainID)
 	if err != nil {
-		Logger.Fatal(err)
+		return nil, errors.Wrap(err, "failed to create transactor")
 	}
 
-	opts.Nonce = big.NewInt(int64(nonce))
+	if ok || !managed {
+		opts.Nonce = big.NewInt(int64(nonce))
+	}
 	opts.GasLimit = gasLimitUnits // in units
-	opts.GasPrice = gasPriceWei   // wei
 
-	return opts
+	// gas price or EIP-1559 fees, in wei
+	if err := b.setFees(ctx, client, opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
 }
 
 // AddEthereumAuthorizer Adds authorizer to Ethereum bridge. Only contract deployer can call this method
@@ -201,6 +224,10 @@ func (b *BridgeClient) AddEthereumAuthorizers(configDir string) {
 }
 
 func (b *BridgeClient) prepareNFTConfig(ctx context.Context, method string, params ...interface{}) (*nftconfig.NFTConfig, *bind.TransactOpts, error) {
+	if b.ReadOnly() {
+		return nil, nil, ErrReadOnlyClient
+	}
+
 	// To (contract)
 	contractAddress := common.HexToAddress(b.NFTConfigAddress)
 
@@ -231,10 +258,13 @@ func (b *BridgeClient) prepareNFTConfig(ctx context.Context, method string, para
 	// Update gas limits + 10%
 	gasLimitUnits = addPercents(gasLimitUnits, 10).Uint64()
 
-	transactOpts := b.CreateSignedTransactionFromKeyStore(b.ethereumClient, gasLimitUnits)
+	transactOpts, err := b.createSignedTransaction(ctx, b.ethereumClient, gasLimitUnits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create transaction")
+	}
 
 	// NFTConfig instance
-	cfg, err := nftconfig.NewNFTConfig(contractAddress, b.ethereumClient)
+	cfg, err := nftconfig.NewNFTConfig(contractAddress, b.contractBackend())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to create nftconfig instance")
 	}
@@ -452,6 +482,10 @@ func (b *BridgeClient) VerifyZCNTransaction(ctx context.Context, hash string) (t
 // SignWithEthereumChain signs the digest with Ethereum chain signer taking key from the current user key storage
 //   - message message to sign
 func (b *BridgeClient) SignWithEthereumChain(message string) ([]byte, error) {
+	if b.ReadOnly() {
+		return nil, ErrReadOnlyClient
+	}
+
 	hash := crypto.Keccak256Hash([]byte(message))
 
 	signer := accounts.Account{
@@ -852,6 +886,10 @@ func (b *BridgeClient) SwapUSDC(ctx context.Context, source uint64, target uint6
 
 // prepareUniswapNetwork performs uniswap network smart contract preparation actions.
 func (b *BridgeClient) prepareUniswapNetwork(ctx context.Context, value *big.Int, method string, params ...interface{}) (*uniswapnetwork.Uniswap, *bind.TransactOpts, error) {
+	if b.ReadOnly() {
+		return nil, nil, ErrReadOnlyClient
+	}
+
 	// 1. Uniswap smart contract address
 	contractAddress := common.HexToAddress(b.UniswapAddress)
 
@@ -883,14 +921,17 @@ func (b *BridgeClient) prepareUniswapNetwork(ctx context.Context, value *big.Int
 		opts.Value = value
 	}
 
-	transactOpts := b.CreateSignedTransactionFromKeyStore(b.ethereumClient, 0)
+	transactOpts, err := b.createSignedTransaction(ctx, b.ethereumClient, 0)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create transaction")
+	}
 	if value.Int64() != 0 {
 		transactOpts.Value = value
 	}
 
 	var uniswapNetworkInstance *uniswapnetwork.Uniswap
 
-	uniswapNetworkInstance, err = uniswapnetwork.NewUniswap(contractAddress, b.ethereumClient)
+	uniswapNetworkInstance, err = uniswapnetwork.NewUniswap(contractAddress, b.contractBackend())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to initialize uniswapnetwork instance")
 	}
@@ -899,6 +940,10 @@ func (b *BridgeClient) prepareUniswapNetwork(ctx context.Context, value *big.Int
 }
 
 func (b *BridgeClient) prepareToken(ctx context.Context, method string, tokenAddress common.Address, params ...interface{}) (*zcntoken.Token, *bind.TransactOpts, error) {
+	if b.ReadOnly() {
+		return nil, nil, ErrReadOnlyClient
+	}
+
 	abi, err := zcntoken.TokenMetaData.GetAbi()
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to get zcntoken abi")
@@ -922,11 +967,14 @@ func (b *BridgeClient) prepareToken(ctx context.Context, method string, tokenAdd
 
 	gasLimitUnits = addPercents(gasLimitUnits, 10).Uint64()
 
-	transactOpts := b.CreateSignedTransactionFromKeyStore(b.ethereumClient, gasLimitUnits)
+	transactOpts, err := b.createSignedTransaction(ctx, b.ethereumClient, gasLimitUnits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create transaction")
+	}
 
 	var tokenInstance *zcntoken.Token
 
-	tokenInstance, err = zcntoken.NewToken(tokenAddress, b.ethereumClient)
+	tokenInstance, err = zcntoken.NewToken(tokenAddress, b.contractBackend())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to initialize zcntoken instance")
 	}
@@ -935,6 +983,10 @@ func (b *BridgeClient) prepareToken(ctx context.Context, method string, tokenAdd
 }
 
 func (b *BridgeClient) prepareAuthorizers(ctx context.Context, method string, params ...interface{}) (*authorizers.Authorizers, *bind.TransactOpts, error) {
+	if b.ReadOnly() {
+		return nil, nil, ErrReadOnlyClient
+	}
+
 	// To (contract)
 	contractAddress := common.HexToAddress(b.AuthorizersAddress)
 
@@ -965,10 +1017,13 @@ func (b *BridgeClient) prepareAuthorizers(ctx context.Context, method string, pa
 	// Update gas limits + 10%
 	gasLimitUnits = addPercents(gasLimitUnits, 10).Uint64()
 
-	transactOpts := b.CreateSignedTransactionFromKeyStore(b.ethereumClient, gasLimitUnits)
+	transactOpts, err := b.createSignedTransaction(ctx, b.ethereumClient, gasLimitUnits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create transaction")
+	}
 
 	// Authorizers instance
-	authorizersInstance, err := authorizers.NewAuthorizers(contractAddress, b.ethereumClient)
+	authorizersInstance, err := authorizers.NewAuthorizers(contractAddress, b.contractBackend())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to create authorizers instance")
 	}
@@ -977,6 +1032,10 @@ func (b *BridgeClient) prepareAuthorizers(ctx context.Context, method string, pa
 }
 
 func (b *BridgeClient) prepareBridge(ctx context.Context, ethereumAddress, method string, params ...interface{}) (*bridge.Bridge, *bind.TransactOpts, error) {
+	if b.ReadOnly() {
+		return nil, nil, ErrReadOnlyClient
+	}
+
 	// To (contract)
 	contractAddress := common.HexToAddress(b.BridgeAddress)
 
@@ -1007,10 +1066,13 @@ func (b *BridgeClient) prepareBridge(ctx context.Context, ethereumAddress, metho
 	//Update gas limits + 10%
 	gasLimitUnits = addPercents(gasLimitUnits, 10).Uint64()
 
-	transactOpts := b.CreateSignedTransactionFromKeyStore(b.ethereumClient, gasLimitUnits)
+	transactOpts, err := b.createSignedTransaction(ctx, b.ethereumClient, gasLimitUnits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create transaction")
+	}
 
 	// BridgeClient instance
-	bridgeInstance, err := bridge.NewBridge(contractAddress, b.ethereumClient)
+	bridgeInstance, err := bridge.NewBridge(contractAddress, b.contractBackend())
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to create bridge instance")
 	}
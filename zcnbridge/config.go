@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 	"path"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 
@@ -38,6 +40,8 @@ type BridgeSDKConfig struct {
 	ConfigChainFile *string
 	ConfigDir       *string
 	Development     *bool
+	// Network selects a network of the "networks" section of the chain config, overriding its "network" key.
+	Network *string
 }
 
 // EthereumClient describes Ethereum JSON-RPC client generealized interface
@@ -52,6 +56,12 @@ type BridgeClient struct {
 	keyStore            KeyStore
 	transactionProvider transaction.TransactionProvider
 	ethereumClient      EthereumClient
+	feeOracle           FeeOracle
+
+	// trackerMu guards the tracker and the nonce manager.
+	trackerMu sync.Mutex
+	tracker   *TransactionTracker
+	nonces    *NonceManager
 
 	BridgeAddress,
 	TokenAddress,
@@ -64,8 +74,30 @@ type BridgeClient struct {
 
 	BancorAPIURL string
 
+	// HistoryStartBlock is the first Ethereum block read by GetBridgeHistory.
+	HistoryStartBlock uint64
+
+	// Network is the name of the network selected with UseNetwork, empty for the configured node.
+	Network string
+
 	ConsensusThreshold float64
 	GasLimit           uint64
+
+	// SignatureCollector sets the timeouts and retries of the requests to the authorizers,
+	// DefaultSignatureCollector if nil.
+	SignatureCollector *SignatureCollector
+
+	// GasStrategy scales the suggested gas prices, GasStrategyStandard by default.
+	GasStrategy GasStrategy
+	// MaxGasPrice caps the gas price, or the GasFeeCap of the EIP-1559 transactions, in wei.
+	// The transactions over the cap fail with ErrGasPriceOverCap. No cap if nil.
+	MaxGasPrice *big.Int
+	// QueueOverGasCap makes the transactions over MaxGasPrice wait for the gas price to drop
+	// instead of failing, until their context is done.
+	QueueOverGasCap bool
+	// GasCapPollInterval is the interval the queued transactions check the gas price again,
+	// DefaultGasCapPollInterval by default.
+	GasCapPollInterval time.Duration
 }
 
 // NewBridgeClient creates BridgeClient with the given parameters.
@@ -142,10 +174,27 @@ func SetupBridgeClientSDK(cfg *BridgeSDKConfig) *BridgeClient {
 	chainCfg := initChainConfig(cfg)
 
 	ethereumNodeURL := chainCfg.GetString("ethereum_node_url")
+	// the calls fail over the fallback endpoints
+	ethereumNodeURLs := chainCfg.GetStringSlice("ethereum_node_urls")
+	if ethereumNodeURL == "" && len(ethereumNodeURLs) > 0 {
+		ethereumNodeURL = ethereumNodeURLs[0]
+		ethereumNodeURLs = ethereumNodeURLs[1:]
+	}
 
-	ethereumClient, err := ethclient.Dial(ethereumNodeURL)
-	if err != nil {
-		Logger.Error(err)
+	var (
+		ethereumClient EthereumClient
+		err            error
+	)
+	if len(ethereumNodeURLs) > 0 {
+		ethereumClient, err = NewFailoverClient(context.Background(), append([]string{ethereumNodeURL}, ethereumNodeURLs...)...)
+		if err != nil {
+			Logger.Error(err)
+		}
+	} else if ethereumNodeURL != "" {
+		ethereumClient, err = ethclient.Dial(ethereumNodeURL)
+		if err != nil {
+			Logger.Error(err)
+		}
 	}
 
 	transactionProvider := transaction.NewTransactionProvider()
@@ -156,8 +205,14 @@ func SetupBridgeClientSDK(cfg *BridgeSDKConfig) *BridgeClient {
 	}
 
 	keyStore := NewKeyStore(path.Join(homedir, EthereumWalletStorageDir))
+	if kind := chainCfg.GetString("bridge.hardware_wallet"); kind != "" {
+		keyStore, err = NewHardwareKeyStore(kind)
+		if err != nil {
+			log.Logger.Fatal(err.Error())
+		}
+	}
 
-	return NewBridgeClient(
+	bridgeClient := NewBridgeClient(
 		chainCfg.GetString("bridge.bridge_address"),
 		chainCfg.GetString("bridge.token_address"),
 		chainCfg.GetString("bridge.authorizers_address"),
@@ -171,4 +226,40 @@ func SetupBridgeClientSDK(cfg *BridgeSDKConfig) *BridgeClient {
 		transactionProvider,
 		keyStore,
 	)
+	bridgeClient.GasStrategy, err = ParseGasStrategy(chainCfg.GetString("bridge.gas_strategy"))
+	if err != nil {
+		log.Logger.Fatal(err.Error())
+	}
+	if maxGasPrice := chainCfg.GetString("bridge.max_gas_price"); maxGasPrice != "" {
+		maxGasPriceWei, ok := new(big.Int).SetString(maxGasPrice, 10)
+		if !ok {
+			log.Logger.Fatal(fmt.Sprintf("invalid bridge.max_gas_price %q", maxGasPrice))
+		}
+		bridgeClient.MaxGasPrice = maxGasPriceWei
+	}
+	bridgeClient.QueueOverGasCap = chainCfg.GetBool("bridge.queue_over_gas_cap")
+
+	network := chainCfg.GetString("network")
+	if cfg.Network != nil && *cfg.Network != "" {
+		network = *cfg.Network
+	}
+	if network != "" {
+		registry, err := LoadChainRegistry(chainCfg)
+		if err != nil {
+			log.Logger.Fatal(err.Error())
+		}
+		if err = bridgeClient.UseNetwork(context.Background(), registry, network); err != nil {
+			log.Logger.Fatal(err.Error())
+		}
+	}
+
+	if chainCfg.GetBool("bridge.manage_nonces") {
+		bridgeClient.ManageNonces()
+	}
+
+	if chainCfg.GetBool("bridge.dynamic_fees") {
+		bridgeClient.SetFeeOracle(NewNodeFeeOracle(bridgeClient.ethereumClient))
+	}
+
+	return bridgeClient
 }
@@ -0,0 +1,142 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/zcntoken"
+	"github.com/0chain/gosdk/zcnbridge/wallet"
+	"github.com/0chain/gosdk/zcncore"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMintWZCNGasUnits is the expected gas of a WZCN mint, which can't be estimated before
+	// the authorizers signed the burn ticket.
+	DefaultMintWZCNGasUnits uint64 = 300000
+	// DefaultBurnWZCNGasUnits is the expected gas of a WZCN burn, which can't be estimated before
+	// the burner allowance is increased.
+	DefaultBurnWZCNGasUnits uint64 = 100000
+)
+
+// BridgeCost is the estimated cost of a bridge operation.
+type BridgeCost struct {
+	Direction BridgeDirection
+	// EthereumGas is the gas limit of the Ethereum transactions of the operation, in units.
+	EthereumGas uint64
+	// GasPrice is the price per gas unit, in wei, the GasFeeCap of the EIP-1559 transactions.
+	GasPrice *big.Int
+	// EthereumFee is the maximum Ethereum cost, EthereumGas × GasPrice, in wei.
+	EthereumFee *big.Int
+	// ZCNFee is the fee of the ZChain transaction of the operation, in SAS.
+	ZCNFee uint64
+	// OverGasCap tells if the gas price is over MaxGasPrice, the transactions failing or
+	// waiting for the fees to drop.
+	OverGasCap bool
+}
+
+// getZCNFeesTable returns the fees of the ZChain transactions by smart contract and function.
+var getZCNFeesTable = func() (map[string]map[string]int64, error) {
+	return transaction.GetFeesTable(zcncore.GetNetwork().Miners)
+}
+
+// EstimateBridgeCost estimates the cost of a bridge operation before starting it: the Ethereum gas
+// priced with the current fees and the gas strategy, plus the fee of the ZChain transaction.
+// BridgeToEthereum costs the ZCN burn and the WZCN mint, BridgeToZCN costs the allowance increase
+// if needed, the WZCN burn and the ZCN mint.
+//   - ctx go context instance to run the queries
+//   - direction the direction of the operation
+//   - amount the amount bridged, in SAS for BridgeToEthereum, in wei for BridgeToZCN
+func (b *BridgeClient) EstimateBridgeCost(ctx context.Context, direction BridgeDirection, amount uint64) (*BridgeCost, error) {
+	cost := &BridgeCost{Direction: direction}
+
+	var (
+		gas     uint64
+		zcnFunc string
+		err     error
+	)
+	switch direction {
+	case BridgeToEthereum:
+		gas, zcnFunc = DefaultMintWZCNGasUnits, wallet.BurnFunc
+	case BridgeToZCN:
+		gas, err = b.estimateBurnWZCNGas(ctx, amount)
+		if err != nil {
+			return nil, err
+		}
+		zcnFunc = wallet.MintFunc
+	default:
+		return nil, errors.Errorf("unknown bridge direction %q", direction)
+	}
+	cost.EthereumGas = addPercents(gas, 10).Uint64()
+
+	opts := &bind.TransactOpts{}
+	err = b.suggestFees(ctx, b.ethereumClient, opts)
+	if err != nil && !errors.Is(err, ErrGasPriceOverCap) {
+		return nil, err
+	}
+	cost.OverGasCap = err != nil
+	cost.GasPrice = opts.GasPrice
+	if cost.GasPrice == nil {
+		cost.GasPrice = opts.GasFeeCap
+	}
+	cost.EthereumFee = new(big.Int).Mul(cost.GasPrice, new(big.Int).SetUint64(cost.EthereumGas))
+
+	table, err := getZCNFeesTable()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get fees table")
+	}
+	fees, ok := table[wallet.ZCNSCSmartContractAddress]
+	if !ok {
+		return nil, errors.New("no fees of the zcnsc smart contract in the fees table")
+	}
+	cost.ZCNFee = uint64(fees[zcnFunc])
+
+	return cost, nil
+}
+
+// estimateBurnWZCNGas estimates the gas of the WZCN burn, and of the allowance increase it needs.
+func (b *BridgeClient) estimateBurnWZCNGas(ctx context.Context, amount uint64) (uint64, error) {
+	allowance, err := b.GetBurnerAllowance(ctx)
+	if err != nil {
+		return 0, err
+	}
+	value := new(big.Int).SetUint64(amount)
+	if allowance.Cmp(value) < 0 {
+		approveGas, err := b.estimateGas(ctx, b.TokenAddress, zcntoken.TokenMetaData, "increaseApproval", common.HexToAddress(b.BridgeAddress), value)
+		if err != nil {
+			return 0, err
+		}
+		return approveGas + DefaultBurnWZCNGasUnits, nil
+	}
+
+	clientID := DefaultClientIDEncoder(zcncore.GetClientWalletID())
+	return b.estimateGas(ctx, b.BridgeAddress, bridge.BridgeMetaData, "burn", value, clientID)
+}
+
+func (b *BridgeClient) estimateGas(ctx context.Context, contract string, metadata *bind.MetaData, method string, params ...interface{}) (uint64, error) {
+	abi, err := metadata.GetAbi()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get ABI")
+	}
+
+	pack, err := abi.Pack(method, params...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to pack arguments")
+	}
+
+	to := common.HexToAddress(contract)
+	gas, err := b.ethereumClient.EstimateGas(ctx, eth.CallMsg{
+		To:   &to,
+		From: common.HexToAddress(b.EthereumAddress),
+		Data: pack,
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to estimate gas of %s", method)
+	}
+	return gas, nil
+}
@@ -0,0 +1,66 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/wallet"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EstimateBridgeCost(t *testing.T) {
+	feesTable := getZCNFeesTable
+	defer func() { getZCNFeesTable = feesTable }()
+	getZCNFeesTable = func() (map[string]map[string]int64, error) {
+		return map[string]map[string]int64{
+			wallet.ZCNSCSmartContractAddress: {wallet.BurnFunc: 1000, wallet.MintFunc: 2000},
+		}, nil
+	}
+
+	newClient := func(allowance int64) *BridgeClient {
+		ethereumClient := getEthereumClient(t)
+		ethereumClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+		ethereumClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(10), nil)
+		ethereumClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(callResult(allowance), nil)
+		return NewReadOnlyBridgeClient(bridgeAddress, tokenAddress, authorizersAddress, ethereumAddress, alchemyEthereumNodeURL, ethereumClient)
+	}
+
+	t.Run("should estimate the mint of WZCN", func(t *testing.T) {
+		cost, err := newClient(0).EstimateBridgeCost(context.Background(), BridgeToEthereum, 100)
+		require.NoError(t, err)
+		require.Equal(t, addPercents(DefaultMintWZCNGasUnits, 10).Uint64(), cost.EthereumGas)
+		require.Equal(t, big.NewInt(10), cost.GasPrice)
+		require.Equal(t, new(big.Int).SetUint64(cost.EthereumGas*10), cost.EthereumFee)
+		require.Equal(t, uint64(1000), cost.ZCNFee)
+		require.False(t, cost.OverGasCap)
+	})
+
+	t.Run("should estimate the burn of WZCN with the allowance increase", func(t *testing.T) {
+		cost, err := newClient(50).EstimateBridgeCost(context.Background(), BridgeToZCN, 100)
+		require.NoError(t, err)
+		require.Equal(t, addPercents(50000+DefaultBurnWZCNGasUnits, 10).Uint64(), cost.EthereumGas)
+		require.Equal(t, uint64(2000), cost.ZCNFee)
+	})
+
+	t.Run("should estimate the burn of WZCN allowed", func(t *testing.T) {
+		cost, err := newClient(100).EstimateBridgeCost(context.Background(), BridgeToZCN, 100)
+		require.NoError(t, err)
+		require.Equal(t, addPercents(50000, 10).Uint64(), cost.EthereumGas)
+	})
+
+	t.Run("should report the gas price over the cap", func(t *testing.T) {
+		client := newClient(0)
+		client.MaxGasPrice = big.NewInt(5)
+		cost, err := client.EstimateBridgeCost(context.Background(), BridgeToEthereum, 100)
+		require.NoError(t, err)
+		require.True(t, cost.OverGasCap)
+		require.Equal(t, big.NewInt(10), cost.GasPrice)
+	})
+
+	t.Run("should fail with an unknown direction", func(t *testing.T) {
+		_, err := newClient(0).EstimateBridgeCost(context.Background(), "sideways", 100)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,301 @@
+package zcnbridge
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultFailoverCooldown is the time a failing endpoint of a FailoverClient is skipped.
+	DefaultFailoverCooldown = 30 * time.Second
+
+	maxEndpointScore = 100
+)
+
+// EndpointHealth describes the health of an endpoint of a FailoverClient.
+type EndpointHealth struct {
+	URL string
+	// Score is the health score of the endpoint, from 0 to 100. It rises with the successful calls
+	// and halves at each connection error or rate limit.
+	Score int
+	// Available is false while the endpoint cools down after a failure.
+	Available bool
+}
+
+type rpcEndpoint struct {
+	url       string
+	client    EthereumClient
+	score     int
+	skipUntil time.Time
+}
+
+// FailoverClient is an Ethereum client calling several RPC endpoints: the calls failing with connection
+// errors or rate limits are retried on the next endpoint, the healthiest endpoints being called first.
+// The other errors, e.g. reverted calls, are returned as is.
+type FailoverClient struct {
+	// Cooldown is the time a failing endpoint is skipped, DefaultFailoverCooldown by default.
+	// The endpoints cooling down are still called when all the others fail.
+	Cooldown time.Duration
+
+	mu        sync.Mutex
+	endpoints []*rpcEndpoint
+}
+
+// NewFailoverClient connects to the Ethereum RPC endpoints.
+//   - ctx go context instance to connect to the endpoints
+//   - urls the URLs of the endpoints, in the order of preference
+func NewFailoverClient(ctx context.Context, urls ...string) (*FailoverClient, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no RPC endpoint")
+	}
+	clients := make([]EthereumClient, 0, len(urls))
+	for _, url := range urls {
+		client, err := dialEthereumClient(ctx, url)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to %s", url)
+		}
+		clients = append(clients, client)
+	}
+	return newFailoverClient(urls, clients), nil
+}
+
+func newFailoverClient(urls []string, clients []EthereumClient) *FailoverClient {
+	c := &FailoverClient{Cooldown: DefaultFailoverCooldown}
+	for i, url := range urls {
+		c.endpoints = append(c.endpoints, &rpcEndpoint{url: url, client: clients[i], score: maxEndpointScore})
+	}
+	return c
+}
+
+// Health returns the health of the endpoints, in the order they are called.
+func (c *FailoverClient) Health() []EndpointHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var health []EndpointHealth
+	now := time.Now()
+	for _, e := range c.ordered(now) {
+		health = append(health, EndpointHealth{URL: e.url, Score: e.score, Available: !now.Before(e.skipUntil)})
+	}
+	return health
+}
+
+// ordered returns the available endpoints by score, then the endpoints cooling down.
+func (c *FailoverClient) ordered(now time.Time) []*rpcEndpoint {
+	endpoints := make([]*rpcEndpoint, len(c.endpoints))
+	copy(endpoints, c.endpoints)
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		ai, aj := !now.Before(endpoints[i].skipUntil), !now.Before(endpoints[j].skipUntil)
+		if ai != aj {
+			return ai
+		}
+		return endpoints[i].score > endpoints[j].score
+	})
+	return endpoints
+}
+
+func (c *FailoverClient) report(e *rpcEndpoint, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !failed {
+		e.score += 10
+		if e.score > maxEndpointScore {
+			e.score = maxEndpointScore
+		}
+		return
+	}
+	e.score /= 2
+	cooldown := c.Cooldown
+	if cooldown <= 0 {
+		cooldown = DefaultFailoverCooldown
+	}
+	e.skipUntil = time.Now().Add(cooldown)
+}
+
+// do calls the endpoints until one doesn't fail with a failover error.
+func (c *FailoverClient) do(ctx context.Context, call func(client EthereumClient) error) error {
+	c.mu.Lock()
+	endpoints := c.ordered(time.Now())
+	c.mu.Unlock()
+
+	var err error
+	for _, e := range endpoints {
+		err = call(e.client)
+		if ctx.Err() != nil {
+			return err
+		}
+		failover := isFailoverError(err)
+		c.report(e, failover)
+		if !failover {
+			return err
+		}
+		Logger.Info("Ethereum endpoint failed, trying the next one", zap.String("url", e.url), zap.Error(err))
+	}
+	return errors.Wrap(err, "all Ethereum endpoints failed")
+}
+
+// isFailoverError tells if the call should be retried on another endpoint: the connection errors,
+// the rate limits and the server errors.
+func isFailoverError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32005 { // limit exceeded
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"rate limit", "too many requests", "connection refused", "connection reset", "no such host", "capacity exceeded"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// CodeAt returns the code of the given account.
+func (c *FailoverClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		code, err = client.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// CallContract executes a contract call.
+func (c *FailoverClient) CallContract(ctx context.Context, call eth.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		result, err = client.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// HeaderByNumber returns a block header, the latest one if number is nil.
+func (c *FailoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// PendingCodeAt returns the code of the given account in the pending state.
+func (c *FailoverClient) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		code, err = client.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// PendingNonceAt returns the nonce of the next transaction of the account.
+func (c *FailoverClient) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// SuggestGasPrice returns the gas price of a timely execution of the transactions.
+func (c *FailoverClient) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+// SuggestGasTipCap returns the gas tip cap of a timely execution of the EIP-1559 transactions.
+func (c *FailoverClient) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		tip, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+// EstimateGas estimates the gas of a transaction.
+func (c *FailoverClient) EstimateGas(ctx context.Context, call eth.CallMsg) (gas uint64, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		gas, err = client.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction sends a signed transaction. A transaction already known by the next endpoint
+// was sent by the failed one.
+func (c *FailoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	retried := false
+	return c.do(ctx, func(client EthereumClient) error {
+		err := client.SendTransaction(ctx, tx)
+		if retried && err != nil && strings.Contains(strings.ToLower(err.Error()), "already known") {
+			return nil
+		}
+		retried = true
+		return err
+	})
+}
+
+// FilterLogs returns the logs matching the query.
+func (c *FailoverClient) FilterLogs(ctx context.Context, query eth.FilterQuery) (logs []types.Log, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		logs, err = client.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs subscribes to the logs matching the query.
+func (c *FailoverClient) SubscribeFilterLogs(ctx context.Context, query eth.FilterQuery, ch chan<- types.Log) (sub eth.Subscription, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		sub, err = client.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
+
+// ChainID returns the chain ID of the network.
+func (c *FailoverClient) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		chainID, err = client.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+// TransactionReceipt returns the receipt of a mined transaction, see TransactionReceiptReader.
+// It fails if the endpoints don't read the receipts.
+func (c *FailoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = c.do(ctx, func(client EthereumClient) (err error) {
+		reader, ok := client.(TransactionReceiptReader)
+		if !ok {
+			return errors.New("the ethereum client doesn't read the transaction receipts")
+		}
+		receipt, err = reader.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
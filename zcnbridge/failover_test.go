@@ -0,0 +1,81 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FailoverClient(t *testing.T) {
+	rateLimited := getEthereumClient(t)
+	rateLimited.On("SuggestGasPrice", mock.Anything).Return(nil, rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"})
+	rateLimited.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(0), errors.New("execution reverted"))
+	rateLimited.On("SendTransaction", mock.Anything, mock.Anything).Return(&net.OpError{Op: "read", Err: errors.New("connection reset by peer")})
+
+	healthy := getEthereumClient(t)
+	healthy.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(400000), nil)
+	healthy.On("SendTransaction", mock.Anything, mock.Anything).Return(errors.New("already known"))
+
+	client := newFailoverClient([]string{"https://first", "https://second"}, []EthereumClient{rateLimited, healthy})
+
+	t.Run("should retry the rate limited calls on the next endpoint", func(t *testing.T) {
+		price, err := client.SuggestGasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(400000), price)
+
+		require.Equal(t, []EndpointHealth{
+			{URL: "https://second", Score: 100, Available: true},
+			{URL: "https://first", Score: 50, Available: false},
+		}, client.Health())
+	})
+
+	t.Run("should call the healthiest endpoints first", func(t *testing.T) {
+		_, err := client.SuggestGasPrice(context.Background())
+		require.NoError(t, err)
+		rateLimited.AssertNumberOfCalls(t, "SuggestGasPrice", 1)
+		healthy.AssertNumberOfCalls(t, "SuggestGasPrice", 2)
+	})
+
+	t.Run("should call the endpoints again after the cooldown", func(t *testing.T) {
+		client := newFailoverClient([]string{"https://first"}, []EthereumClient{rateLimited})
+		client.Cooldown = time.Millisecond
+
+		_, err := client.SuggestGasPrice(context.Background())
+		require.Error(t, err)
+		require.False(t, client.Health()[0].Available)
+
+		time.Sleep(5 * time.Millisecond)
+		require.True(t, client.Health()[0].Available)
+	})
+
+	t.Run("should return the other errors", func(t *testing.T) {
+		client := newFailoverClient([]string{"https://first", "https://second"}, []EthereumClient{rateLimited, healthy})
+		_, err := client.EstimateGas(context.Background(), eth.CallMsg{})
+		require.EqualError(t, err, "execution reverted")
+		healthy.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should accept the transactions sent by the failed endpoint", func(t *testing.T) {
+		client := newFailoverClient([]string{"https://first", "https://second"}, []EthereumClient{rateLimited, healthy})
+		require.NoError(t, client.SendTransaction(context.Background(), types.NewTx(&types.LegacyTx{})))
+
+		client = newFailoverClient([]string{"https://second"}, []EthereumClient{healthy})
+		require.Error(t, client.SendTransaction(context.Background(), types.NewTx(&types.LegacyTx{})))
+	})
+
+	t.Run("should fail when all the endpoints fail", func(t *testing.T) {
+		client := newFailoverClient([]string{"https://first"}, []EthereumClient{rateLimited})
+		_, err := client.SuggestGasPrice(context.Background())
+		require.Error(t, err)
+		require.Equal(t, 50, client.Health()[0].Score)
+	})
+}
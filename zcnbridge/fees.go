@@ -0,0 +1,221 @@
+package zcnbridge
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Fees describes the fees of an Ethereum transaction, in wei per gas unit.
+// GasPrice is the price of a legacy transaction, GasFeeCap (maxFeePerGas) and GasTipCap
+// (maxPriorityFeePerGas) are the fees of an EIP-1559 dynamic fee transaction.
+type Fees struct {
+	GasPrice  *big.Int
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+}
+
+// FeeOracle suggests the fees of the EIP-1559 transactions sent by the bridge client.
+type FeeOracle interface {
+	// SuggestFees returns the GasFeeCap and the GasTipCap of the next transaction.
+	SuggestFees(ctx context.Context) (*Fees, error)
+}
+
+// NodeFeeOracle suggests the fees with the Ethereum node, the tip it suggests on top of
+// the base fee of the latest block multiplied by BaseFeeMultiplier, so the transaction stays
+// includable while the base fee rises.
+type NodeFeeOracle struct {
+	client EthereumClient
+
+	// BaseFeeMultiplier is the multiplier of the base fee in the GasFeeCap, 2 by default.
+	BaseFeeMultiplier int64
+	// MaxGasFeeCap caps the GasFeeCap if set.
+	MaxGasFeeCap *big.Int
+}
+
+// NewNodeFeeOracle creates a fee oracle suggesting the fees with an Ethereum node.
+//   - client is the Ethereum JSON-RPC client.
+func NewNodeFeeOracle(client EthereumClient) *NodeFeeOracle {
+	return &NodeFeeOracle{
+		client:            client,
+		BaseFeeMultiplier: 2,
+	}
+}
+
+// SuggestFees returns the fees of the next transaction. It fails if the network doesn't support EIP-1559.
+//   - ctx go context instance to run the requests
+func (o *NodeFeeOracle) SuggestFees(ctx context.Context) (*Fees, error) {
+	head, err := o.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest header")
+	}
+	if head.BaseFee == nil {
+		return nil, errors.New("the network doesn't support EIP-1559 transactions")
+	}
+
+	tip, err := o.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to suggest gas tip cap")
+	}
+
+	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(o.BaseFeeMultiplier))
+	feeCap.Add(feeCap, tip)
+	if o.MaxGasFeeCap != nil && feeCap.Cmp(o.MaxGasFeeCap) > 0 {
+		feeCap = new(big.Int).Set(o.MaxGasFeeCap)
+	}
+	if feeCap.Cmp(tip) < 0 {
+		return nil, errors.Errorf("gas fee cap %s lower than gas tip cap %s", feeCap, tip)
+	}
+
+	return &Fees{GasFeeCap: feeCap, GasTipCap: tip}, nil
+}
+
+// GasStrategy scales the suggested gas price of the legacy transactions, or the suggested tip of the
+// EIP-1559 transactions, in percents. The zero value is GasStrategyStandard.
+type GasStrategy int64
+
+const (
+	// GasStrategySlow pays 90% of the suggested price, the transactions may wait a few blocks.
+	GasStrategySlow GasStrategy = 90
+	// GasStrategyStandard pays the suggested price.
+	GasStrategyStandard GasStrategy = 100
+	// GasStrategyFast pays 125% of the suggested price to be included quickly.
+	GasStrategyFast GasStrategy = 125
+)
+
+// DefaultGasCapPollInterval is the interval the transactions over MaxGasPrice check the gas price
+// again, if QueueOverGasCap is set.
+const DefaultGasCapPollInterval = 15 * time.Second
+
+// ErrGasPriceOverCap is the error of the transactions over MaxGasPrice.
+var ErrGasPriceOverCap = errors.New("gas price over cap")
+
+// GasStrategyMultiplier returns a custom strategy multiplying the suggested price.
+//   - multiplier the multiplier of the suggested price, e.g. 1.5
+func GasStrategyMultiplier(multiplier float64) GasStrategy {
+	return GasStrategy(math.Round(multiplier * 100))
+}
+
+// ParseGasStrategy parses a strategy: slow, standard, fast or a multiplier of the suggested price.
+//   - s the strategy, standard if empty
+func ParseGasStrategy(s string) (GasStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "standard":
+		return GasStrategyStandard, nil
+	case "slow":
+		return GasStrategySlow, nil
+	case "fast":
+		return GasStrategyFast, nil
+	}
+	multiplier, err := strconv.ParseFloat(s, 64)
+	if err != nil || multiplier <= 0 {
+		return 0, errors.Errorf("invalid gas strategy %q, expected slow, standard, fast or a positive multiplier", s)
+	}
+	return GasStrategyMultiplier(multiplier), nil
+}
+
+// apply scales the suggested price.
+func (g GasStrategy) apply(price *big.Int) *big.Int {
+	if g <= 0 || g == GasStrategyStandard {
+		return price
+	}
+	scaled := new(big.Int).Mul(price, big.NewInt(int64(g)))
+	return scaled.Div(scaled, big.NewInt(100))
+}
+
+type feesKey struct{}
+
+// WithFees overrides the fees of the transactions sent by the bridge client with the context.
+// A GasPrice sends a legacy transaction, a GasFeeCap or a GasTipCap sends an EIP-1559 transaction,
+// the missing fees are suggested by the fee oracle.
+//   - ctx go context instance to run the transactions
+//   - fees the fees of the transactions
+func WithFees(ctx context.Context, fees Fees) context.Context {
+	return context.WithValue(ctx, feesKey{}, fees)
+}
+
+// SetFeeOracle makes the bridge client send EIP-1559 dynamic fee transactions with the fees
+// suggested by the oracle. The client sends legacy transactions priced with SuggestGasPrice
+// by default, or if the oracle is nil.
+//   - oracle the fee oracle, e.g. NewNodeFeeOracle
+func (b *BridgeClient) SetFeeOracle(oracle FeeOracle) {
+	b.feeOracle = oracle
+}
+
+// setFees sets the fees of the transaction options, see WithFees, SetFeeOracle, GasStrategy and MaxGasPrice.
+// The transactions over MaxGasPrice wait for the fees to drop if QueueOverGasCap is set.
+func (b *BridgeClient) setFees(ctx context.Context, client EthereumClient, opts *bind.TransactOpts) error {
+	for {
+		err := b.suggestFees(ctx, client, opts)
+		if !b.QueueOverGasCap || !errors.Is(err, ErrGasPriceOverCap) {
+			return err
+		}
+
+		Logger.Info("Waiting for the gas price to drop", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), err.Error())
+		case <-time.After(b.gasCapPollInterval()):
+		}
+	}
+}
+
+func (b *BridgeClient) suggestFees(ctx context.Context, client EthereumClient, opts *bind.TransactOpts) error {
+	override, _ := ctx.Value(feesKey{}).(Fees)
+	if override.GasPrice != nil {
+		opts.GasPrice = override.GasPrice
+		return b.checkGasCap(opts.GasPrice)
+	}
+
+	if b.feeOracle == nil && override.GasFeeCap == nil && override.GasTipCap == nil {
+		gasPriceWei, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to suggest gas price")
+		}
+		opts.GasPrice = b.GasStrategy.apply(gasPriceWei)
+		return b.checkGasCap(opts.GasPrice)
+	}
+
+	opts.GasFeeCap, opts.GasTipCap = override.GasFeeCap, override.GasTipCap
+	if opts.GasFeeCap == nil || opts.GasTipCap == nil {
+		oracle := b.feeOracle
+		if oracle == nil {
+			oracle = NewNodeFeeOracle(client)
+		}
+		fees, err := oracle.SuggestFees(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to suggest fees")
+		}
+		if opts.GasTipCap == nil {
+			// the fee cap keeps the room of the tip
+			tip := b.GasStrategy.apply(fees.GasTipCap)
+			fees.GasFeeCap = new(big.Int).Add(fees.GasFeeCap, new(big.Int).Sub(tip, fees.GasTipCap))
+			opts.GasTipCap = tip
+		}
+		if opts.GasFeeCap == nil {
+			opts.GasFeeCap = fees.GasFeeCap
+		}
+	}
+	return b.checkGasCap(opts.GasFeeCap)
+}
+
+func (b *BridgeClient) checkGasCap(price *big.Int) error {
+	if b.MaxGasPrice == nil || price.Cmp(b.MaxGasPrice) <= 0 {
+		return nil
+	}
+	return errors.Wrapf(ErrGasPriceOverCap, "gas price %s wei, cap %s wei", price, b.MaxGasPrice)
+}
+
+func (b *BridgeClient) gasCapPollInterval() time.Duration {
+	if b.GasCapPollInterval > 0 {
+		return b.GasCapPollInterval
+	}
+	return DefaultGasCapPollInterval
+}
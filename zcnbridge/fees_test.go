@@ -0,0 +1,154 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Fees(t *testing.T) {
+	ethereumClient := getEthereumClient(t)
+	ethereumClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(400000), nil)
+	ethereumClient.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(2000), nil)
+	ethereumClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(&types.Header{BaseFee: big.NewInt(100000)}, nil)
+
+	bridgeClient := &BridgeClient{}
+
+	t.Run("should suggest EIP-1559 fees with the node", func(t *testing.T) {
+		oracle := NewNodeFeeOracle(ethereumClient)
+		fees, err := oracle.SuggestFees(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(202000), fees.GasFeeCap)
+		require.Equal(t, big.NewInt(2000), fees.GasTipCap)
+
+		oracle.MaxGasFeeCap = big.NewInt(150000)
+		fees, err = oracle.SuggestFees(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(150000), fees.GasFeeCap)
+	})
+
+	t.Run("should reject networks without base fee", func(t *testing.T) {
+		legacyClient := getEthereumClient(t)
+		legacyClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(&types.Header{}, nil)
+
+		_, err := NewNodeFeeOracle(legacyClient).SuggestFees(context.Background())
+		require.Error(t, err)
+	})
+
+	t.Run("should send legacy transactions by default", func(t *testing.T) {
+		opts := &bind.TransactOpts{}
+		require.NoError(t, bridgeClient.setFees(context.Background(), ethereumClient, opts))
+		require.Equal(t, big.NewInt(400000), opts.GasPrice)
+		require.Nil(t, opts.GasFeeCap)
+		require.Nil(t, opts.GasTipCap)
+	})
+
+	t.Run("should send dynamic fee transactions with a fee oracle", func(t *testing.T) {
+		bridgeClient.SetFeeOracle(NewNodeFeeOracle(ethereumClient))
+		defer bridgeClient.SetFeeOracle(nil)
+
+		opts := &bind.TransactOpts{}
+		require.NoError(t, bridgeClient.setFees(context.Background(), ethereumClient, opts))
+		require.Nil(t, opts.GasPrice)
+		require.Equal(t, big.NewInt(202000), opts.GasFeeCap)
+		require.Equal(t, big.NewInt(2000), opts.GasTipCap)
+	})
+
+	t.Run("should override the fees per call", func(t *testing.T) {
+		opts := &bind.TransactOpts{}
+		ctx := WithFees(context.Background(), Fees{GasTipCap: big.NewInt(5000)})
+		require.NoError(t, bridgeClient.setFees(ctx, ethereumClient, opts))
+		require.Nil(t, opts.GasPrice)
+		require.Equal(t, big.NewInt(202000), opts.GasFeeCap)
+		require.Equal(t, big.NewInt(5000), opts.GasTipCap)
+
+		bridgeClient.SetFeeOracle(NewNodeFeeOracle(ethereumClient))
+		defer bridgeClient.SetFeeOracle(nil)
+
+		opts = &bind.TransactOpts{}
+		ctx = WithFees(context.Background(), Fees{GasPrice: big.NewInt(300000)})
+		require.NoError(t, bridgeClient.setFees(ctx, ethereumClient, opts))
+		require.Equal(t, big.NewInt(300000), opts.GasPrice)
+		require.Nil(t, opts.GasFeeCap)
+	})
+
+	t.Run("should parse the gas strategies", func(t *testing.T) {
+		for s, want := range map[string]GasStrategy{
+			"":       GasStrategyStandard,
+			"slow":   GasStrategySlow,
+			"Fast":   GasStrategyFast,
+			"1.5":    GasStrategy(150),
+			"0.8":    GasStrategy(80),
+			"normal": 0,
+			"-1":     0,
+		} {
+			strategy, err := ParseGasStrategy(s)
+			if want == 0 {
+				require.Error(t, err, s)
+				continue
+			}
+			require.NoError(t, err, s)
+			require.Equal(t, want, strategy, s)
+		}
+	})
+
+	t.Run("should scale the suggested prices with the gas strategy", func(t *testing.T) {
+		bridgeClient.GasStrategy = GasStrategyFast
+		defer func() { bridgeClient.GasStrategy = 0 }()
+
+		opts := &bind.TransactOpts{}
+		require.NoError(t, bridgeClient.setFees(context.Background(), ethereumClient, opts))
+		require.Equal(t, big.NewInt(500000), opts.GasPrice)
+
+		bridgeClient.SetFeeOracle(NewNodeFeeOracle(ethereumClient))
+		defer bridgeClient.SetFeeOracle(nil)
+
+		opts = &bind.TransactOpts{}
+		require.NoError(t, bridgeClient.setFees(context.Background(), ethereumClient, opts))
+		require.Equal(t, big.NewInt(2500), opts.GasTipCap)
+		require.Equal(t, big.NewInt(202500), opts.GasFeeCap)
+	})
+
+	t.Run("should reject the transactions over the gas price cap", func(t *testing.T) {
+		bridgeClient.MaxGasPrice = big.NewInt(300000)
+		defer func() { bridgeClient.MaxGasPrice = nil }()
+
+		err := bridgeClient.setFees(context.Background(), ethereumClient, &bind.TransactOpts{})
+		require.ErrorIs(t, err, ErrGasPriceOverCap)
+
+		bridgeClient.SetFeeOracle(NewNodeFeeOracle(ethereumClient))
+		defer bridgeClient.SetFeeOracle(nil)
+		require.NoError(t, bridgeClient.setFees(context.Background(), ethereumClient, &bind.TransactOpts{}))
+	})
+
+	t.Run("should queue the transactions over the gas price cap", func(t *testing.T) {
+		bridgeClient.MaxGasPrice = big.NewInt(300000)
+		bridgeClient.QueueOverGasCap = true
+		bridgeClient.GasCapPollInterval = 10 * time.Millisecond
+		defer func() {
+			bridgeClient.MaxGasPrice = nil
+			bridgeClient.QueueOverGasCap = false
+			bridgeClient.GasCapPollInterval = 0
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := bridgeClient.setFees(ctx, ethereumClient, &bind.TransactOpts{})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		droppingClient := getEthereumClient(t)
+		droppingClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(400000), nil).Twice()
+		droppingClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(250000), nil)
+
+		opts := &bind.TransactOpts{}
+		require.NoError(t, bridgeClient.setFees(context.Background(), droppingClient, opts))
+		require.Equal(t, big.NewInt(250000), opts.GasPrice)
+		droppingClient.AssertNumberOfCalls(t, "SuggestGasPrice", 3)
+	})
+}
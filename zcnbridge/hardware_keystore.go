@@ -0,0 +1,170 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LedgerWallet is the kind of the Ledger devices.
+	LedgerWallet = "ledger"
+	// TrezorWallet is the kind of the Trezor devices.
+	TrezorWallet = "trezor"
+
+	// DefaultHardwareAccountsScanned is the number of accounts of the derivation paths scanned by Find.
+	DefaultHardwareAccountsScanned = 10
+)
+
+// TransactionSigner is implemented by the key stores signing the transactions themselves instead of
+// exposing an Ethereum KeyStore, e.g. the hardware wallets.
+type TransactionSigner interface {
+	SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// HardwareKeyStore is a KeyStore signing the transactions on a Ledger or Trezor device, each transaction
+// being confirmed on the device. The accounts are looked up in the first AccountsScanned accounts of the
+// standard derivation path (m/44'/60'/0'/0/x) and of the Ledger Live one (m/44'/60'/x'/0/0).
+// Hardware wallets don't sign raw hashes, so SignWithEthereumChain isn't supported.
+type HardwareKeyStore struct {
+	hub *usbwallet.Hub
+
+	// AccountsScanned is the number of accounts scanned by derivation path, DefaultHardwareAccountsScanned by default.
+	AccountsScanned int
+
+	mu      sync.Mutex
+	wallets map[common.Address]accounts.Wallet
+}
+
+// NewHardwareKeyStore creates a KeyStore backed by the USB hardware wallets of a kind.
+//   - kind the kind of the devices, LedgerWallet or TrezorWallet
+func NewHardwareKeyStore(kind string) (*HardwareKeyStore, error) {
+	var (
+		hub *usbwallet.Hub
+		err error
+	)
+	switch strings.ToLower(kind) {
+	case LedgerWallet:
+		hub, err = usbwallet.NewLedgerHub()
+	case TrezorWallet:
+		hub, err = usbwallet.NewTrezorHubWithWebUSB()
+		if err != nil {
+			hub, err = usbwallet.NewTrezorHubWithHID()
+		}
+	default:
+		return nil, errors.Errorf("unknown hardware wallet %q, expected %s or %s", kind, LedgerWallet, TrezorWallet)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s hub", kind)
+	}
+	return &HardwareKeyStore{
+		hub:             hub,
+		AccountsScanned: DefaultHardwareAccountsScanned,
+		wallets:         make(map[common.Address]accounts.Wallet),
+	}, nil
+}
+
+// Find looks up the account on the connected devices, the devices are opened if needed.
+//   - account the account, only its address is used
+func (k *HardwareKeyStore) Find(account accounts.Account) (accounts.Account, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if wallet, ok := k.wallets[account.Address]; ok {
+		for _, a := range wallet.Accounts() {
+			if a.Address == account.Address {
+				return a, nil
+			}
+		}
+	}
+
+	scanned := k.AccountsScanned
+	if scanned <= 0 {
+		scanned = DefaultHardwareAccountsScanned
+	}
+	for _, wallet := range k.hub.Wallets() {
+		if err := wallet.Open(""); err != nil && err != accounts.ErrWalletAlreadyOpen {
+			return accounts.Account{}, errors.Wrapf(err, "failed to open %s", wallet.URL())
+		}
+		iterators := []func() accounts.DerivationPath{
+			accounts.DefaultIterator(accounts.DefaultBaseDerivationPath),
+			accounts.LedgerLiveIterator(accounts.DefaultBaseDerivationPath),
+		}
+		for _, next := range iterators {
+			for i := 0; i < scanned; i++ {
+				path := next()
+				found, err := wallet.Derive(path, false)
+				if err != nil {
+					return accounts.Account{}, errors.Wrapf(err, "failed to derive account of %s", wallet.URL())
+				}
+				if found.Address != account.Address {
+					continue
+				}
+				// pin the account so the wallet signs with it
+				if found, err = wallet.Derive(path, true); err != nil {
+					return accounts.Account{}, errors.Wrapf(err, "failed to derive account of %s", wallet.URL())
+				}
+				k.wallets[account.Address] = wallet
+				return found, nil
+			}
+		}
+	}
+	return accounts.Account{}, errors.Errorf("account %s not found on the %d hardware wallets", account.Address.Hex(), len(k.hub.Wallets()))
+}
+
+// TimedUnlock does nothing, the hardware wallets confirm each transaction on the device.
+func (k *HardwareKeyStore) TimedUnlock(accounts.Account, string, time.Duration) error {
+	return nil
+}
+
+// SignHash isn't supported by the hardware wallets.
+func (k *HardwareKeyStore) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	return nil, errors.Wrap(accounts.ErrNotSupported, "hardware wallets don't sign hashes")
+}
+
+// GetEthereumKeyStore returns nil, the hardware wallets sign the transactions with SignTx.
+func (k *HardwareKeyStore) GetEthereumKeyStore() *keystore.KeyStore {
+	return nil
+}
+
+// SignTx signs the transaction on the device of the account, once confirmed by the user.
+//   - account the account found with Find
+//   - tx the transaction to sign
+//   - chainID the chain ID of the network
+func (k *HardwareKeyStore) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	k.mu.Lock()
+	wallet, ok := k.wallets[account.Address]
+	k.mu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("account %s not found on the hardware wallets", account.Address.Hex())
+	}
+	return wallet.SignTx(account, tx, chainID)
+}
+
+// newTransactor creates the options of the transactions signed by the key store of the account.
+func newTransactor(ks KeyStore, account accounts.Account, chainID *big.Int) (*bind.TransactOpts, error) {
+	signer, ok := ks.(TransactionSigner)
+	if !ok {
+		return bind.NewKeyStoreTransactorWithChainID(ks.GetEthereumKeyStore(), account, chainID)
+	}
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(account, tx, chainID)
+		},
+		Context: context.Background(),
+	}, nil
+}
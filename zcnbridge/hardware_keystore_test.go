@@ -0,0 +1,75 @@
+package zcnbridge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// deviceKeyStore is a key store signing the transactions itself, like the hardware wallets.
+type deviceKeyStore struct {
+	key    *ecdsa.PrivateKey
+	signed int
+}
+
+func (d *deviceKeyStore) Find(account accounts.Account) (accounts.Account, error) {
+	return accounts.Account{Address: crypto.PubkeyToAddress(d.key.PublicKey)}, nil
+}
+
+func (d *deviceKeyStore) TimedUnlock(accounts.Account, string, time.Duration) error {
+	return nil
+}
+
+func (d *deviceKeyStore) SignHash(accounts.Account, []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+func (d *deviceKeyStore) GetEthereumKeyStore() *keystore.KeyStore {
+	return nil
+}
+
+func (d *deviceKeyStore) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	d.signed++
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), d.key)
+}
+
+func Test_TransactionSigner(t *testing.T) {
+	ethereumClient := getEthereumClient(t)
+	prepareEthereumClientGeneralMockCalls(&ethereumClient.Mock)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	device := &deviceKeyStore{key: key}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	bridgeClient := getBridgeClient(alchemyEthereumNodeURL, ethereumClient, getTransactionProvider(t), device)
+	bridgeClient.EthereumAddress = address.Hex()
+
+	opts, err := bridgeClient.createSignedTransaction(context.Background(), ethereumClient, 50000)
+	require.NoError(t, err)
+	require.Equal(t, address, opts.From)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: opts.Nonce.Uint64(), GasPrice: opts.GasPrice, Gas: opts.GasLimit})
+	signed, err := opts.Signer(opts.From, tx)
+	require.NoError(t, err)
+	require.Equal(t, 1, device.signed)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(400000)), signed)
+	require.NoError(t, err)
+	require.Equal(t, address, sender)
+
+	_, err = opts.Signer(common.HexToAddress(bridgeAddress), tx)
+	require.Error(t, err)
+
+	_, err = NewHardwareKeyStore("keepkey")
+	require.Error(t, err)
+}
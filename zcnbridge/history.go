@@ -0,0 +1,205 @@
+package zcnbridge
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/wallet"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// BridgeDirection is the direction of a bridge operation.
+type BridgeDirection string
+
+const (
+	// BridgeToEthereum is the direction of the ZCN burned on the ZChain and minted as WZCN on Ethereum.
+	BridgeToEthereum BridgeDirection = "zcn_to_eth"
+	// BridgeToZCN is the direction of the WZCN burned on Ethereum and minted as ZCN on the ZChain.
+	BridgeToZCN BridgeDirection = "eth_to_zcn"
+)
+
+// BridgeStatus is the status of a bridge operation.
+type BridgeStatus string
+
+const (
+	// BridgeStatusPending is the status of the burns not minted yet.
+	BridgeStatusPending BridgeStatus = "pending"
+	// BridgeStatusCompleted is the status of the burns minted.
+	BridgeStatusCompleted BridgeStatus = "completed"
+	// BridgeStatusUnknown is the status of the WZCN burns to the ZChain clients other than the wallet,
+	// their mints can't be checked.
+	BridgeStatusUnknown BridgeStatus = "unknown"
+)
+
+// BridgeOperation is an operation of the bridge history.
+type BridgeOperation struct {
+	Direction BridgeDirection `json:"direction"`
+	Status    BridgeStatus    `json:"status"`
+	// Amount is the amount bridged, in wei for WZCN and SAS for ZCN.
+	Amount int64 `json:"amount"`
+	// Nonce is the bridge nonce of the burn.
+	Nonce int64 `json:"nonce"`
+	// BurnHash is the hash of the burn transaction, on the ZChain for BridgeToEthereum, on Ethereum for BridgeToZCN.
+	BurnHash string `json:"burn_hash"`
+	// MintHash is the hash of the mint transaction on Ethereum, the ZChain mints are not indexed by burn.
+	MintHash string `json:"mint_hash,omitempty"`
+	// ClientID is the ZChain client receiving the BridgeToZCN operations.
+	ClientID string `json:"client_id,omitempty"`
+	// Block is the Ethereum block of the operation, 0 for the pending BridgeToEthereum operations.
+	Block uint64 `json:"block,omitempty"`
+
+	logIndex uint
+}
+
+// getMintNonce returns the nonce of the last WZCN burn minted for the wallet on the ZChain.
+var getMintNonce = func() (int64, error) {
+	var mintNonce int64
+	cb := wallet.NewZCNStatus(&mintNonce)
+	cb.Begin()
+	if err := zcncore.GetMintNonce(cb); err != nil {
+		return 0, errors.Wrap(err, "failed to get mint nonce")
+	}
+	if err := cb.Wait(); err != nil {
+		return 0, errors.Wrap(err, "failed to get mint nonce")
+	}
+	return mintNonce, nil
+}
+
+// getNotProcessedZCNBurnTickets returns the ZCN burn tickets of the Ethereum address not minted yet.
+var getNotProcessedZCNBurnTickets = func(ethereumAddress, startNonce string) ([]zcncore.BurnTicket, error) {
+	var tickets []zcncore.BurnTicket
+	cb := wallet.NewZCNStatus(&tickets)
+	cb.Begin()
+	if err := zcncore.GetNotProcessedZCNBurnTickets(ethereumAddress, startNonce, cb); err != nil {
+		return nil, errors.Wrap(err, "failed to get not processed burn tickets")
+	}
+	if err := cb.Wait(); err != nil {
+		return nil, errors.Wrap(err, "failed to get not processed burn tickets")
+	}
+	return tickets, nil
+}
+
+// GetBridgeHistory returns the bridge operations of an Ethereum address, the pending ZCN burns first,
+// then the Ethereum mints and burns from the newest. The Ethereum logs are read from HistoryStartBlock.
+//   - ctx go context instance to run the queries
+//   - address the Ethereum address
+//   - limit the maximum number of operations, no limit if 0
+//   - offset the number of operations skipped
+func (b *BridgeClient) GetBridgeHistory(ctx context.Context, address string, limit, offset int) ([]*BridgeOperation, error) {
+	filterer, err := bridge.NewBridgeFilterer(common.HexToAddress(b.BridgeAddress), b.ethereumClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create bridge filterer")
+	}
+	account := []common.Address{common.HexToAddress(address)}
+	opts := &bind.FilterOpts{Start: b.HistoryStartBlock, Context: ctx}
+
+	var history []*BridgeOperation
+
+	minted, err := filterer.FilterMinted(opts, account, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to filter mint events")
+	}
+	for minted.Next() {
+		ev := minted.Event
+		history = append(history, &BridgeOperation{
+			Direction: BridgeToEthereum,
+			Status:    BridgeStatusCompleted,
+			Amount:    ev.Amount.Int64(),
+			Nonce:     ev.Nonce.Int64(),
+			BurnHash:  hex.EncodeToString(ev.Txid),
+			MintHash:  ev.Raw.TxHash.Hex(),
+			Block:     ev.Raw.BlockNumber,
+			logIndex:  ev.Raw.Index,
+		})
+	}
+	err = minted.Error()
+	minted.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read mint events")
+	}
+
+	burned, err := filterer.FilterBurned(opts, account, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to filter burn events")
+	}
+	var burns []*BridgeOperation
+	for burned.Next() {
+		ev := burned.Event
+		burns = append(burns, &BridgeOperation{
+			Direction: BridgeToZCN,
+			Status:    BridgeStatusUnknown,
+			Amount:    ev.Amount.Int64(),
+			Nonce:     ev.Nonce.Int64(),
+			BurnHash:  ev.Raw.TxHash.Hex(),
+			ClientID:  hex.EncodeToString(ev.ClientId),
+			Block:     ev.Raw.BlockNumber,
+			logIndex:  ev.Raw.Index,
+		})
+	}
+	err = burned.Error()
+	burned.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read burn events")
+	}
+
+	if walletID := zcncore.GetClientWalletID(); len(burns) > 0 && walletID != "" {
+		mintNonce, err := getMintNonce()
+		if err != nil {
+			return nil, err
+		}
+		for _, burn := range burns {
+			if burn.ClientID != walletID {
+				continue
+			}
+			burn.Status = BridgeStatusPending
+			if burn.Nonce <= mintNonce {
+				burn.Status = BridgeStatusCompleted
+			}
+		}
+	}
+	history = append(history, burns...)
+
+	sort.SliceStable(history, func(i, j int) bool {
+		if history[i].Block != history[j].Block {
+			return history[i].Block > history[j].Block
+		}
+		return history[i].logIndex > history[j].logIndex
+	})
+
+	userNonce, err := b.GetUserNonceMinted(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := getNotProcessedZCNBurnTickets(address, userNonce.String())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].Nonce > tickets[j].Nonce
+	})
+	pending := make([]*BridgeOperation, 0, len(tickets)+len(history))
+	for _, ticket := range tickets {
+		pending = append(pending, &BridgeOperation{
+			Direction: BridgeToEthereum,
+			Status:    BridgeStatusPending,
+			Amount:    ticket.Amount,
+			Nonce:     ticket.Nonce,
+			BurnHash:  ticket.Hash,
+		})
+	}
+	history = append(pending, history...)
+
+	if offset >= len(history) {
+		return []*BridgeOperation{}, nil
+	}
+	history = history[offset:]
+	if limit > 0 && limit < len(history) {
+		history = history[:limit]
+	}
+	return history, nil
+}
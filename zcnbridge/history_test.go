@@ -0,0 +1,99 @@
+package zcnbridge
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcncore"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetBridgeHistory(t *testing.T) {
+	bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+	require.NoError(t, err)
+
+	account := common.HexToAddress(ethereumAddress)
+	newLog := func(event string, block uint64, amount, nonce int64, data []byte) types.Log {
+		packed, err := bridgeABI.Events[event].Inputs.NonIndexed().Pack(big.NewInt(amount), data)
+		require.NoError(t, err)
+		return types.Log{
+			Topics: []common.Hash{
+				bridgeABI.Events[event].ID,
+				common.BytesToHash(account.Bytes()),
+				common.BytesToHash(math.U256Bytes(big.NewInt(nonce))),
+			},
+			Data:        packed,
+			BlockNumber: block,
+			TxHash:      common.BigToHash(big.NewInt(int64(block))),
+		}
+	}
+	zcnTxn, _ := hex.DecodeString(zcnTxnID)
+	client, _ := hex.DecodeString(clientId)
+	otherClient, _ := hex.DecodeString(zcnTxnID)
+
+	ethereumClient := getEthereumClient(t)
+	ethereumClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q eth.FilterQuery) bool {
+		return q.Topics[0][0] == bridgeABI.Events["Minted"].ID
+	})).Return([]types.Log{newLog("Minted", 10, 100, 1, zcnTxn)}, nil)
+	ethereumClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q eth.FilterQuery) bool {
+		return q.Topics[0][0] == bridgeABI.Events["Burned"].ID
+	})).Return([]types.Log{
+		newLog("Burned", 11, 200, 1, client),
+		newLog("Burned", 12, 300, 2, client),
+		newLog("Burned", 13, 400, 1, otherClient),
+	}, nil)
+	ethereumClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(callResult(1), nil)
+
+	require.NoError(t, zcncore.SetWallet(zcncrypto.Wallet{ClientID: clientId}, false))
+	defer func() { _ = zcncore.SetWallet(zcncrypto.Wallet{}, false) }()
+
+	mintNonce, notProcessed := getMintNonce, getNotProcessedZCNBurnTickets
+	defer func() { getMintNonce, getNotProcessedZCNBurnTickets = mintNonce, notProcessed }()
+	getMintNonce = func() (int64, error) { return 1, nil }
+	getNotProcessedZCNBurnTickets = func(address, startNonce string) ([]zcncore.BurnTicket, error) {
+		require.Equal(t, ethereumAddress, address)
+		require.Equal(t, "1", startNonce)
+		return []zcncore.BurnTicket{{Hash: "ticket", Amount: 500, Nonce: 2}}, nil
+	}
+
+	bridgeClient := NewReadOnlyBridgeClient(bridgeAddress, tokenAddress, authorizersAddress, ethereumAddress, alchemyEthereumNodeURL, ethereumClient)
+
+	history, err := bridgeClient.GetBridgeHistory(context.Background(), ethereumAddress, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 5)
+
+	require.Equal(t, &BridgeOperation{Direction: BridgeToEthereum, Status: BridgeStatusPending, Amount: 500, Nonce: 2, BurnHash: "ticket"}, history[0])
+
+	require.Equal(t, BridgeToZCN, history[1].Direction)
+	require.Equal(t, BridgeStatusUnknown, history[1].Status)
+	require.Equal(t, uint64(13), history[1].Block)
+
+	require.Equal(t, BridgeStatusPending, history[2].Status)
+	require.Equal(t, int64(300), history[2].Amount)
+	require.Equal(t, clientId, history[2].ClientID)
+
+	require.Equal(t, BridgeStatusCompleted, history[3].Status)
+	require.Equal(t, int64(1), history[3].Nonce)
+
+	require.Equal(t, BridgeToEthereum, history[4].Direction)
+	require.Equal(t, BridgeStatusCompleted, history[4].Status)
+	require.Equal(t, zcnTxnID, history[4].BurnHash)
+	require.Equal(t, common.BigToHash(big.NewInt(10)).Hex(), history[4].MintHash)
+
+	page, err := bridgeClient.GetBridgeHistory(context.Background(), ethereumAddress, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, history[3:], page)
+
+	page, err = bridgeClient.GetBridgeHistory(context.Background(), ethereumAddress, 2, 5)
+	require.NoError(t, err)
+	require.Empty(t, page)
+}
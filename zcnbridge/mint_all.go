@@ -0,0 +1,139 @@
+package zcnbridge
+
+import (
+	"context"
+	"sort"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrMintSkipped is the error of the burn tickets not minted by MintAllPendingWZCN after a failed mint,
+// the bridge contract accepting the mints of a wallet in the order of their nonces.
+var ErrMintSkipped = errors.New("mint skipped after a failed mint")
+
+// WZCNMintResult is the result of the mint of a ZCN burn ticket.
+type WZCNMintResult struct {
+	// Ticket is the burn ticket.
+	Ticket zcncore.BurnTicket
+	// Transaction is the mint transaction, nil if the mint failed.
+	Transaction *types.Transaction
+	// Err is the error of the failed mint.
+	Err error
+}
+
+// WZCNMintSummary reports the mints of MintAllPendingWZCN.
+type WZCNMintSummary struct {
+	// Results are the results of the tickets, in the order of their nonces.
+	Results []*WZCNMintResult
+	// Minted is the number of tickets minted.
+	Minted int
+	// Failed is the number of tickets failed or skipped.
+	Failed int
+	// MintedAmount is the total amount minted.
+	MintedAmount int64
+}
+
+type nonceKey struct{}
+
+// withNonce sets the Ethereum nonce of the transaction sent by the bridge client with the context.
+func withNonce(ctx context.Context, nonce uint64) context.Context {
+	return context.WithValue(ctx, nonceKey{}, nonce)
+}
+
+// MintAllPendingWZCN mints the WZCN of all the ZCN burn tickets of the wallet not minted yet.
+// The signatures of each ticket are aggregated from the authorizers and the mint transactions are sent
+// sequentially, with consecutive nonces. The mints stop at the first failure, the following tickets
+// being rejected by the bridge contract until the failed one is minted.
+//   - ctx go context instance to run the transactions
+func (b *BridgeClient) MintAllPendingWZCN(ctx context.Context) (*WZCNMintSummary, error) {
+	userNonce, err := b.GetUserNonceMinted(ctx, b.EthereumAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := getNotProcessedZCNBurnTickets(b.EthereumAddress, userNonce.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return b.mintTickets(ctx, tickets, b.QueryEthereumMintPayload)
+}
+
+func (b *BridgeClient) mintTickets(ctx context.Context, tickets []zcncore.BurnTicket, query func(string) (*ethereum.MintPayload, error)) (*WZCNMintSummary, error) {
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].Nonce < tickets[j].Nonce
+	})
+
+	summary := &WZCNMintSummary{}
+	if len(tickets) == 0 {
+		return summary, nil
+	}
+
+	b.trackerMu.Lock()
+	managed := b.nonces != nil
+	b.trackerMu.Unlock()
+
+	// the pending nonce of the node may lag behind the mints just sent, the nonce manager
+	// reserves the nonces otherwise
+	var nonce uint64
+	if !managed {
+		var err error
+		nonce, err = b.ethereumClient.PendingNonceAt(ctx, common.HexToAddress(b.EthereumAddress))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get nonce")
+		}
+	}
+
+	var failed error
+	for _, ticket := range tickets {
+		result := &WZCNMintResult{Ticket: ticket}
+		summary.Results = append(summary.Results, result)
+
+		if failed == nil {
+			failed = ctx.Err()
+		}
+		if failed != nil {
+			result.Err = ErrMintSkipped
+			summary.Failed++
+			continue
+		}
+
+		mintCtx := ctx
+		if !managed {
+			mintCtx = withNonce(ctx, nonce)
+		}
+		result.Transaction, result.Err = b.mintTicket(mintCtx, ticket, query)
+		if result.Err != nil {
+			Logger.Error("failed to mint burn ticket", zap.String("hash", ticket.Hash), zap.Int64("nonce", ticket.Nonce), zap.Error(result.Err))
+			failed = result.Err
+			summary.Failed++
+			continue
+		}
+
+		nonce++
+		summary.Minted++
+		summary.MintedAmount += ticket.Amount
+	}
+
+	Logger.Info(
+		"Minted pending WZCN",
+		zap.Int("minted", summary.Minted),
+		zap.Int("failed", summary.Failed),
+		zap.Int64("amount", summary.MintedAmount),
+	)
+
+	return summary, nil
+}
+
+func (b *BridgeClient) mintTicket(ctx context.Context, ticket zcncore.BurnTicket, query func(string) (*ethereum.MintPayload, error)) (*types.Transaction, error) {
+	payload, err := query(ticket.Hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get mint payload of %s", ticket.Hash)
+	}
+	return b.MintWZCN(ctx, payload)
+}
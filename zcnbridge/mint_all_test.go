@@ -0,0 +1,67 @@
+package zcnbridge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MintAllPendingWZCN(t *testing.T) {
+	ethereumClient := getEthereumClient(t)
+	prepareEthereumClientGeneralMockCalls(&ethereumClient.Mock)
+
+	keyStore := getKeyStore(t)
+	prepareKeyStoreGeneralMockCalls(keyStore)
+
+	bridgeClient := getBridgeClient(alchemyEthereumNodeURL, ethereumClient, getTransactionProvider(t), keyStore)
+
+	query := func(hash string) (*ethereum.MintPayload, error) {
+		if hash == "failed" {
+			return nil, errors.New("not enough signatures")
+		}
+		return &ethereum.MintPayload{ZCNTxnID: hash, Amount: amount, To: ethereumAddress, Nonce: nonce}, nil
+	}
+
+	t.Run("should mint the tickets in the order of their nonces", func(t *testing.T) {
+		summary, err := bridgeClient.mintTickets(context.Background(), []zcncore.BurnTicket{
+			{Hash: "second", Amount: 20, Nonce: 2},
+			{Hash: "first", Amount: 10, Nonce: 1},
+		}, query)
+		require.NoError(t, err)
+		require.Equal(t, 2, summary.Minted)
+		require.Zero(t, summary.Failed)
+		require.Equal(t, int64(30), summary.MintedAmount)
+
+		require.Len(t, summary.Results, 2)
+		require.Equal(t, "first", summary.Results[0].Ticket.Hash)
+		require.Equal(t, uint64(nonce), summary.Results[0].Transaction.Nonce())
+		require.Equal(t, "second", summary.Results[1].Ticket.Hash)
+		require.Equal(t, uint64(nonce+1), summary.Results[1].Transaction.Nonce())
+	})
+
+	t.Run("should skip the tickets after a failed mint", func(t *testing.T) {
+		summary, err := bridgeClient.mintTickets(context.Background(), []zcncore.BurnTicket{
+			{Hash: "first", Amount: 10, Nonce: 1},
+			{Hash: "failed", Amount: 20, Nonce: 2},
+			{Hash: "third", Amount: 30, Nonce: 3},
+		}, query)
+		require.NoError(t, err)
+		require.Equal(t, 1, summary.Minted)
+		require.Equal(t, 2, summary.Failed)
+		require.Equal(t, int64(10), summary.MintedAmount)
+
+		require.NotNil(t, summary.Results[0].Transaction)
+		require.Error(t, summary.Results[1].Err)
+		require.ErrorIs(t, summary.Results[2].Err, ErrMintSkipped)
+	})
+
+	t.Run("should report no mints without tickets", func(t *testing.T) {
+		summary, err := bridgeClient.mintTickets(context.Background(), nil, query)
+		require.NoError(t, err)
+		require.Empty(t, summary.Results)
+	})
+}
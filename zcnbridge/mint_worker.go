@@ -0,0 +1,223 @@
+package zcnbridge
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/zcnsc"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// MintCallback is notified of the mints of a MintWorker, with the error of the failed ones.
+type MintCallback func(burn *bridge.BridgeBurned, mintHash string, err error)
+
+// MintWorker watches the WZCN burns of the wallet on the bridge contract and mints the burned amount
+// on the ZChain, so the burns don't need to be minted by hand with QueryZChainMintPayload and MintZCN.
+// The failed mints are retried at the next polls.
+type MintWorker struct {
+	b *BridgeClient
+
+	// ClientID is the id of the ZChain client receiving the burns, the wallet of the SDK by default.
+	ClientID string
+	// StartBlock is the first block scanned for burns, the latest block by default.
+	StartBlock uint64
+	// Confirmations is the number of blocks mined on top of a burn before it's minted, 6 by default.
+	Confirmations uint64
+	// PollInterval is the interval the burn events are queried, 15 seconds by default.
+	PollInterval time.Duration
+	// TicketAttempts is the number of times the burn tickets are queried to the authorizers, 20 by default.
+	TicketAttempts int
+	// TicketRetryInterval is the interval between the burn ticket queries, 10 seconds by default.
+	TicketRetryInterval time.Duration
+	// OnMint is notified of the mints.
+	OnMint MintCallback
+
+	queryMintPayload func(ethBurnHash string) (*zcnsc.MintPayload, error)
+
+	mu     sync.Mutex
+	minted map[common.Hash]bool
+	failed map[common.Hash]*bridge.BridgeBurned
+}
+
+// NewMintWorker creates a worker minting the WZCN burns of the wallet, see MintWorker.
+func (b *BridgeClient) NewMintWorker() *MintWorker {
+	return &MintWorker{
+		b:                   b,
+		Confirmations:       6,
+		PollInterval:        15 * time.Second,
+		TicketAttempts:      20,
+		TicketRetryInterval: 10 * time.Second,
+		queryMintPayload:    b.QueryZChainMintPayload,
+		minted:              make(map[common.Hash]bool),
+		failed:              make(map[common.Hash]*bridge.BridgeBurned),
+	}
+}
+
+// Run mints the burns until the context is done.
+//   - ctx go context instance to run the worker
+func (w *MintWorker) Run(ctx context.Context) error {
+	filterer, err := bridge.NewBridgeFilterer(common.HexToAddress(w.b.BridgeAddress), w.b.ethereumClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to create bridge filterer")
+	}
+
+	next := w.StartBlock
+	if next == 0 {
+		head, err := w.b.ethereumClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get latest header")
+		}
+		next = head.Number.Uint64()
+	}
+
+	for {
+		next, err = w.poll(ctx, filterer, next)
+		if err != nil {
+			Logger.Error("failed to poll burn events", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(w.PollInterval):
+		}
+	}
+}
+
+// poll mints the burns of the confirmed blocks from the next one, and the failed burns again.
+// It returns the next block to poll.
+func (w *MintWorker) poll(ctx context.Context, filterer *bridge.BridgeFilterer, next uint64) (uint64, error) {
+	w.mu.Lock()
+	var burns []*bridge.BridgeBurned
+	for _, burn := range w.failed {
+		burns = append(burns, burn)
+	}
+	w.mu.Unlock()
+
+	head, err := w.b.ethereumClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		w.mintAll(ctx, burns)
+		return next, errors.Wrap(err, "failed to get latest header")
+	}
+	latest := head.Number.Uint64()
+	if latest < next+w.Confirmations {
+		w.mintAll(ctx, burns)
+		return next, nil
+	}
+	end := latest - w.Confirmations
+
+	it, err := filterer.FilterBurned(&bind.FilterOpts{Start: next, End: &end, Context: ctx}, nil, nil)
+	if err != nil {
+		w.mintAll(ctx, burns)
+		return next, errors.Wrap(err, "failed to filter burn events")
+	}
+	defer it.Close()
+
+	receiver := w.ClientID
+	if receiver == "" {
+		receiver = zcncore.GetClientWalletID()
+	}
+	clientID := DefaultClientIDEncoder(receiver)
+	for it.Next() {
+		if bytes.Equal(it.Event.ClientId, clientID) {
+			burns = append(burns, it.Event)
+		}
+	}
+	if err := it.Error(); err != nil {
+		w.mintAll(ctx, burns)
+		return next, errors.Wrap(err, "failed to read burn events")
+	}
+
+	w.mintAll(ctx, burns)
+	return end + 1, nil
+}
+
+func (w *MintWorker) mintAll(ctx context.Context, burns []*bridge.BridgeBurned) {
+	for _, burn := range burns {
+		if ctx.Err() != nil {
+			return
+		}
+		w.mint(ctx, burn)
+	}
+}
+
+func (w *MintWorker) mint(ctx context.Context, burn *bridge.BridgeBurned) {
+	hash := burn.Raw.TxHash
+	w.mu.Lock()
+	minted := w.minted[hash]
+	w.mu.Unlock()
+	if minted {
+		return
+	}
+
+	mintHash, err := w.b.mintBurn(ctx, hash.Hex(), w.queryMintPayload, w.TicketAttempts, w.TicketRetryInterval)
+
+	w.mu.Lock()
+	if err != nil {
+		w.failed[hash] = burn
+	} else {
+		delete(w.failed, hash)
+		w.minted[hash] = true
+	}
+	w.mu.Unlock()
+
+	if err != nil {
+		Logger.Error("failed to mint burn", zap.String("hash", hash.Hex()), zap.Error(err))
+	}
+	if w.OnMint != nil {
+		w.OnMint(burn, mintHash, err)
+	}
+}
+
+// MintBurnedWZCN mints on the ZChain the amount of a WZCN burn, once the authorizers signed its burn ticket.
+//   - ctx go context instance to run the transaction
+//   - ethBurnHash the hash of the burn transaction on Ethereum
+func (b *BridgeClient) MintBurnedWZCN(ctx context.Context, ethBurnHash string) (string, error) {
+	return b.mintBurn(ctx, ethBurnHash, b.QueryZChainMintPayload, 20, 10*time.Second)
+}
+
+// BurnAndMintWZCN burns WZCN tokens and mints them on the ZChain, it combines BurnWZCN and MintBurnedWZCN.
+//   - ctx go context instance to run the transactions
+//   - amountTokens amount of tokens to burn
+func (b *BridgeClient) BurnAndMintWZCN(ctx context.Context, amountTokens uint64) (string, error) {
+	tx, err := b.BurnWZCN(ctx, amountTokens)
+	if err != nil {
+		return "", err
+	}
+	return b.MintBurnedWZCN(ctx, tx.Hash().Hex())
+}
+
+func (b *BridgeClient) mintBurn(ctx context.Context, ethBurnHash string, query func(string) (*zcnsc.MintPayload, error), attempts int, interval time.Duration) (string, error) {
+	var (
+		payload *zcnsc.MintPayload
+		err     error
+	)
+	if attempts < 1 {
+		attempts = 1
+	}
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+		payload, err = query(ethBurnHash)
+		if err == nil {
+			break
+		}
+		Logger.Info("Waiting for the burn ticket", zap.String("hash", ethBurnHash), zap.Int("attempt", i+1), zap.Error(err))
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get burn ticket of %s", ethBurnHash)
+	}
+
+	return b.MintZCN(ctx, payload)
+}
@@ -0,0 +1,96 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	binding "github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/zcnsc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MintWorker(t *testing.T) {
+	ethereumClient := getEthereumClient(t)
+	ethereumClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(&types.Header{Number: big.NewInt(110)}, nil)
+
+	tx := getTransaction(t)
+	prepareTransactionGeneralMockCalls(&tx.Mock)
+	transactionProvider := getTransactionProvider(t)
+	prepareTransactionProviderGeneralMockCalls(&transactionProvider.Mock, tx)
+
+	bridgeClient := getBridgeClient(alchemyEthereumNodeURL, ethereumClient, transactionProvider, getKeyStore(t))
+
+	bridgeABI, err := binding.BridgeMetaData.GetAbi()
+	require.NoError(t, err)
+	burnedLog := func(txHash common.Hash, clientID string, amount int64) types.Log {
+		event := bridgeABI.Events["Burned"]
+		data, err := event.Inputs.NonIndexed().Pack(big.NewInt(amount), DefaultClientIDEncoder(clientID))
+		require.NoError(t, err)
+		return types.Log{
+			Address: common.HexToAddress(bridgeAddress),
+			Topics: []common.Hash{
+				event.ID,
+				common.HexToAddress(ethereumAddress).Hash(),
+				common.BigToHash(big.NewInt(nonce)),
+			},
+			Data:        data,
+			BlockNumber: 100,
+			TxHash:      txHash,
+		}
+	}
+	ours, others := common.HexToHash("0x01"), common.HexToHash("0x02")
+	ethereumClient.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log{
+		burnedLog(ours, clientId, amount),
+		burnedLog(others, zcnTxnID, amount),
+	}, nil)
+
+	worker := bridgeClient.NewMintWorker()
+	worker.ClientID = clientId
+	worker.TicketRetryInterval = time.Millisecond
+	queried := 0
+	worker.queryMintPayload = func(ethBurnHash string) (*zcnsc.MintPayload, error) {
+		require.Equal(t, ours.Hex(), ethBurnHash)
+		queried++
+		if queried < 3 {
+			return nil, errors.New("ticket not found")
+		}
+		return &zcnsc.MintPayload{EthereumTxnID: ethBurnHash, Amount: amount, Nonce: nonce}, nil
+	}
+	var mints []string
+	worker.OnMint = func(burn *binding.BridgeBurned, mintHash string, err error) {
+		require.NoError(t, err)
+		require.Equal(t, ours, burn.Raw.TxHash)
+		require.Equal(t, big.NewInt(amount), burn.Amount)
+		mints = append(mints, mintHash)
+	}
+
+	filterer, err := binding.NewBridgeFilterer(common.HexToAddress(bridgeAddress), ethereumClient)
+	require.NoError(t, err)
+
+	t.Run("should wait for the confirmations", func(t *testing.T) {
+		next, err := worker.poll(context.Background(), filterer, 105)
+		require.NoError(t, err)
+		require.Equal(t, uint64(105), next)
+		require.Empty(t, mints)
+	})
+
+	t.Run("should mint the burns of the wallet", func(t *testing.T) {
+		next, err := worker.poll(context.Background(), filterer, 100)
+		require.NoError(t, err)
+		require.Equal(t, uint64(105), next)
+		require.Equal(t, []string{zcnTxnID}, mints)
+		require.Equal(t, 3, queried)
+	})
+
+	t.Run("should mint the burns once", func(t *testing.T) {
+		_, err := worker.poll(context.Background(), filterer, 100)
+		require.NoError(t, err)
+		require.Len(t, mints, 1)
+	})
+}
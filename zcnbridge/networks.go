@@ -0,0 +1,211 @@
+package zcnbridge
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// KnownChainIDs are the chain IDs of the well known EVM networks, used when the configuration
+// of a network with one of these names doesn't set its chain ID.
+var KnownChainIDs = map[string]int64{
+	"ethereum":    1,
+	"sepolia":     11155111,
+	"holesky":     17000,
+	"polygon":     137,
+	"amoy":        80002,
+	"bsc":         56,
+	"bsc-testnet": 97,
+}
+
+// Network describes an EVM network the bridge contracts are deployed on.
+type Network struct {
+	// Name is the name the network is selected with, e.g. polygon.
+	Name string
+	// ChainID is the chain ID of the network, checked against the nodes.
+	ChainID int64
+	// RPCURLs are the URLs of the JSON-RPC nodes, in the order of preference.
+	RPCURLs []string
+
+	BridgeAddress,
+	TokenAddress,
+	AuthorizersAddress,
+	UniswapAddress string
+}
+
+// ChainRegistry registers the networks the bridge client can be switched to by name.
+type ChainRegistry struct {
+	mu       sync.RWMutex
+	networks map[string]Network
+}
+
+// NewChainRegistry creates an empty chain registry.
+func NewChainRegistry() *ChainRegistry {
+	return &ChainRegistry{networks: make(map[string]Network)}
+}
+
+// Register adds a network to the registry, or replaces the network of the same name.
+//   - network the network, with a name, a chain ID, an RPC URL and the bridge and token addresses
+func (r *ChainRegistry) Register(network Network) error {
+	network.Name = strings.ToLower(strings.TrimSpace(network.Name))
+	if network.Name == "" {
+		return errors.New("network name is empty")
+	}
+	if network.ChainID == 0 {
+		network.ChainID = KnownChainIDs[network.Name]
+	}
+	if network.ChainID <= 0 {
+		return errors.Errorf("network %s: chain ID is not set", network.Name)
+	}
+	if len(network.RPCURLs) == 0 {
+		return errors.Errorf("network %s: no RPC URL", network.Name)
+	}
+	if network.BridgeAddress == "" || network.TokenAddress == "" {
+		return errors.Errorf("network %s: bridge and token addresses must be set", network.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.networks[network.Name] = network
+	return nil
+}
+
+// Get returns a registered network.
+//   - name the name of the network, case insensitive
+func (r *ChainRegistry) Get(name string) (Network, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	network, ok := r.networks[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Network{}, errors.Errorf("unknown network %q, registered networks: %s", name, strings.Join(r.names(), ", "))
+	}
+	return network, nil
+}
+
+// Names returns the sorted names of the registered networks.
+func (r *ChainRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.names()
+}
+
+func (r *ChainRegistry) names() []string {
+	names := make([]string, 0, len(r.networks))
+	for name := range r.networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadChainRegistry reads the networks of the "networks" section of the chain config.
+//   - cfg the chain config
+//
+// Example:
+//
+//	networks:
+//	  polygon:
+//	    chain_id: 137
+//	    rpc_urls: ["https://polygon-rpc.com"]
+//	    bridge_address: 0x...
+//	    token_address: 0x...
+//	    authorizers_address: 0x...
+func LoadChainRegistry(cfg *viper.Viper) (*ChainRegistry, error) {
+	registry := NewChainRegistry()
+	for name := range cfg.GetStringMap("networks") {
+		sub := cfg.Sub("networks." + name)
+		if sub == nil {
+			continue
+		}
+		err := registry.Register(Network{
+			Name:               name,
+			ChainID:            sub.GetInt64("chain_id"),
+			RPCURLs:            sub.GetStringSlice("rpc_urls"),
+			BridgeAddress:      sub.GetString("bridge_address"),
+			TokenAddress:       sub.GetString("token_address"),
+			AuthorizersAddress: sub.GetString("authorizers_address"),
+			UniswapAddress:     sub.GetString("uniswap_address"),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// dialEthereumClient connects to an Ethereum JSON-RPC node.
+var dialEthereumClient = func(ctx context.Context, url string) (EthereumClient, error) {
+	return ethclient.DialContext(ctx, url)
+}
+
+// UseNetwork switches the bridge client to a network of the registry: it connects to the RPC nodes
+// of the network serving its chain ID, failing over them with a FailoverClient, and uses the contracts
+// of the network.
+// It fails if the transactions of the client are tracked or their nonces managed, see TrackTransactions
+// and ManageNonces.
+//   - ctx go context instance to connect to the nodes
+//   - registry the chain registry
+//   - name the name of the network
+func (b *BridgeClient) UseNetwork(ctx context.Context, registry *ChainRegistry, name string) error {
+	network, err := registry.Get(name)
+	if err != nil {
+		return err
+	}
+
+	b.trackerMu.Lock()
+	tracked, managed := b.tracker != nil, b.nonces != nil
+	b.trackerMu.Unlock()
+	if tracked {
+		return errors.New("can't switch network while tracking transactions")
+	}
+	if managed {
+		return errors.New("can't switch network while managing nonces")
+	}
+
+	var (
+		urls    []string
+		clients []EthereumClient
+		lastErr error
+	)
+	for _, url := range network.RPCURLs {
+		client, err := dialEthereumClient(ctx, url)
+		if err != nil {
+			lastErr = errors.Wrapf(err, "failed to connect to %s", url)
+			continue
+		}
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			lastErr = errors.Wrapf(err, "failed to get chain ID of %s", url)
+			continue
+		}
+		if chainID.Int64() != network.ChainID {
+			lastErr = errors.Errorf("%s serves chain ID %s, expected %d", url, chainID, network.ChainID)
+			continue
+		}
+		urls = append(urls, url)
+		clients = append(clients, client)
+	}
+	if len(clients) == 0 {
+		return errors.Wrapf(lastErr, "network %s unreachable", network.Name)
+	}
+
+	b.ethereumClient = clients[0]
+	if len(clients) > 1 {
+		b.ethereumClient = newFailoverClient(urls, clients)
+	}
+	b.EthereumNodeURL = urls[0]
+	b.Network = network.Name
+	b.BridgeAddress = network.BridgeAddress
+	b.TokenAddress = network.TokenAddress
+	b.AuthorizersAddress = network.AuthorizersAddress
+	b.UniswapAddress = network.UniswapAddress
+
+	Logger.Info("Switched bridge network", zap.String("network", network.Name), zap.Strings("urls", urls))
+	return nil
+}
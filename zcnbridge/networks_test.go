@@ -0,0 +1,86 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const networksConfig = `
+networks:
+  polygon:
+    rpc_urls: ["https://down.polygon", "https://wrong.polygon", "https://up.polygon"]
+    bridge_address: "0x1"
+    token_address: "0x2"
+    authorizers_address: "0x3"
+  devnet:
+    chain_id: 1337
+    rpc_urls: ["http://localhost:8545"]
+    bridge_address: "0x4"
+    token_address: "0x5"
+`
+
+func Test_ChainRegistry(t *testing.T) {
+	cfg := viper.New()
+	cfg.SetConfigType("yaml")
+	require.NoError(t, cfg.ReadConfig(strings.NewReader(networksConfig)))
+
+	registry, err := LoadChainRegistry(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"devnet", "polygon"}, registry.Names())
+
+	t.Run("should fill the chain IDs of the known networks", func(t *testing.T) {
+		polygon, err := registry.Get("Polygon")
+		require.NoError(t, err)
+		require.Equal(t, int64(137), polygon.ChainID)
+		require.Len(t, polygon.RPCURLs, 3)
+		require.Equal(t, "0x3", polygon.AuthorizersAddress)
+
+		devnet, err := registry.Get("devnet")
+		require.NoError(t, err)
+		require.Equal(t, int64(1337), devnet.ChainID)
+
+		_, err = registry.Get("bsc")
+		require.Error(t, err)
+	})
+
+	t.Run("should reject the incomplete networks", func(t *testing.T) {
+		require.Error(t, registry.Register(Network{Name: "unknown", RPCURLs: []string{"http://localhost"}, BridgeAddress: "0x1", TokenAddress: "0x2"}))
+		require.Error(t, registry.Register(Network{Name: "bsc", BridgeAddress: "0x1", TokenAddress: "0x2"}))
+		require.Error(t, registry.Register(Network{Name: "bsc", RPCURLs: []string{"http://localhost"}}))
+	})
+
+	t.Run("should switch to the first node serving the chain", func(t *testing.T) {
+		dial := dialEthereumClient
+		defer func() { dialEthereumClient = dial }()
+		dialEthereumClient = func(ctx context.Context, url string) (EthereumClient, error) {
+			client := getEthereumClient(t)
+			switch url {
+			case "https://down.polygon":
+				return nil, errors.New("connection refused")
+			case "https://wrong.polygon":
+				client.On("ChainID", mock.Anything).Return(big.NewInt(1), nil)
+			default:
+				client.On("ChainID", mock.Anything).Return(big.NewInt(137), nil)
+			}
+			return client, nil
+		}
+
+		bridgeClient := &BridgeClient{}
+		require.NoError(t, bridgeClient.UseNetwork(context.Background(), registry, "polygon"))
+		require.Equal(t, "polygon", bridgeClient.Network)
+		require.Equal(t, "https://up.polygon", bridgeClient.EthereumNodeURL)
+		require.Equal(t, "0x1", bridgeClient.BridgeAddress)
+		require.Equal(t, "0x2", bridgeClient.TokenAddress)
+
+		err := bridgeClient.UseNetwork(context.Background(), registry, "devnet")
+		require.Error(t, err)
+		require.Equal(t, "polygon", bridgeClient.Network)
+	})
+}
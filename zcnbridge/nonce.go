@@ -0,0 +1,219 @@
+package zcnbridge
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultNonceReservationTimeout is the time a nonce reserved for a transaction never sent is
+	// handed out again after, e.g. if the signature was rejected on a hardware wallet.
+	DefaultNonceReservationTimeout = 2 * time.Minute
+	// DefaultNonceResyncAfter is the time the pending nonce of the node may stay behind the nonces sent
+	// before the manager resyncs with it, the transactions sent being dropped, e.g. by a reorg.
+	DefaultNonceResyncAfter = time.Minute
+)
+
+// NonceManager hands out the nonces of the transactions of an Ethereum account, so the transactions
+// sent concurrently by a bridge client don't get the same nonce. It follows the pending nonce of the
+// node when transactions are sent outside of it, and resyncs with the node when the transactions sent
+// are dropped.
+type NonceManager struct {
+	client  EthereumClient
+	account common.Address
+
+	// ReservationTimeout is the time a reserved nonce not sent is handed out again after,
+	// DefaultNonceReservationTimeout by default.
+	ReservationTimeout time.Duration
+	// ResyncAfter is the time the pending nonce of the node may stay behind the nonces sent,
+	// DefaultNonceResyncAfter by default.
+	ResyncAfter time.Duration
+
+	mu       sync.Mutex
+	synced   bool
+	next     uint64
+	reserved map[uint64]time.Time // nonces handed out and not sent yet, by reservation time
+	released []uint64             // nonces handed out and not sent, handed out again first, sorted
+	lastSent time.Time
+}
+
+// NewNonceManager creates a nonce manager of an Ethereum account.
+//   - client the Ethereum client
+//   - account the Ethereum account
+func NewNonceManager(client EthereumClient, account common.Address) *NonceManager {
+	return &NonceManager{
+		client:             client,
+		account:            account,
+		ReservationTimeout: DefaultNonceReservationTimeout,
+		ResyncAfter:        DefaultNonceResyncAfter,
+		reserved:           make(map[uint64]time.Time),
+	}
+}
+
+// ManageNonces makes the bridge client reserve the nonces of its transactions with a NonceManager,
+// so concurrent mints, burns and allowance increases don't collide. It returns the manager.
+func (b *BridgeClient) ManageNonces() *NonceManager {
+	b.trackerMu.Lock()
+	defer b.trackerMu.Unlock()
+	if b.nonces == nil {
+		b.nonces = NewNonceManager(b.ethereumClient, common.HexToAddress(b.EthereumAddress))
+	}
+	return b.nonces
+}
+
+// Reserve reserves the next nonce of the account. The nonce should be passed to MarkSent once the
+// transaction is sent, or to Release if it isn't.
+//   - ctx go context instance to query the node
+func (m *NonceManager) Reserve(ctx context.Context) (uint64, error) {
+	pending, err := m.client.PendingNonceAt(ctx, m.account)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get nonce")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.expire(now)
+
+	switch {
+	case !m.synced:
+		m.resync(pending)
+	case pending > m.next:
+		// transactions sent outside of the manager
+		m.resync(pending)
+	case pending < m.next && len(m.reserved) == 0 && now.Sub(m.lastSent) > m.resyncAfter():
+		Logger.Info("Resyncing dropped Ethereum nonces", zap.Uint64("pending", pending), zap.Uint64("next", m.next))
+		m.resync(pending)
+	}
+
+	for len(m.released) > 0 && m.released[0] < pending {
+		m.released = m.released[1:]
+	}
+
+	var nonce uint64
+	if len(m.released) > 0 {
+		nonce, m.released = m.released[0], m.released[1:]
+	} else {
+		nonce = m.next
+		m.next++
+	}
+	m.reserved[nonce] = now
+	return nonce, nil
+}
+
+// MarkSent marks the transaction with a reserved nonce sent.
+//   - nonce the nonce of the transaction
+func (m *NonceManager) MarkSent(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reserved, nonce)
+	m.lastSent = time.Now()
+}
+
+// Release hands out again a reserved nonce whose transaction wasn't sent.
+//   - nonce the nonce of the transaction
+func (m *NonceManager) Release(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.reserved[nonce]; !ok {
+		return
+	}
+	delete(m.reserved, nonce)
+	m.release(nonce)
+}
+
+// Resync drops the reservations and restarts from the pending nonce of the node.
+//   - ctx go context instance to query the node
+func (m *NonceManager) Resync(ctx context.Context) error {
+	pending, err := m.client.PendingNonceAt(ctx, m.account)
+	if err != nil {
+		return errors.Wrap(err, "failed to get nonce")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resync(pending)
+	return nil
+}
+
+func (m *NonceManager) resync(pending uint64) {
+	m.synced = true
+	m.next = pending
+	m.released = nil
+	m.reserved = make(map[uint64]time.Time)
+}
+
+func (m *NonceManager) release(nonce uint64) {
+	i := sort.Search(len(m.released), func(i int) bool { return m.released[i] >= nonce })
+	if i < len(m.released) && m.released[i] == nonce {
+		return
+	}
+	m.released = append(m.released, 0)
+	copy(m.released[i+1:], m.released[i:])
+	m.released[i] = nonce
+}
+
+// expire releases the reservations older than the reservation timeout.
+func (m *NonceManager) expire(now time.Time) {
+	timeout := m.ReservationTimeout
+	if timeout <= 0 {
+		timeout = DefaultNonceReservationTimeout
+	}
+	for nonce, reservedAt := range m.reserved {
+		if now.Sub(reservedAt) > timeout {
+			delete(m.reserved, nonce)
+			m.release(nonce)
+		}
+	}
+}
+
+func (m *NonceManager) resyncAfter() time.Duration {
+	if m.ResyncAfter > 0 {
+		return m.ResyncAfter
+	}
+	return DefaultNonceResyncAfter
+}
+
+// sent marks the nonce of a transaction sent, or releases it if the transaction failed.
+// The manager resyncs if the nonce was used already.
+func (m *NonceManager) sent(tx *types.Transaction, err error) {
+	switch {
+	case err == nil:
+		m.MarkSent(tx.Nonce())
+	case strings.Contains(strings.ToLower(err.Error()), "nonce too low"):
+		m.mu.Lock()
+		m.synced = false
+		delete(m.reserved, tx.Nonce())
+		m.mu.Unlock()
+	default:
+		m.Release(tx.Nonce())
+	}
+}
+
+// nonceBackend reserves the nonces of the transactions sent by the contracts with the nonce manager.
+type nonceBackend struct {
+	EthereumClient
+	nonces *NonceManager
+}
+
+func (n *nonceBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if account != n.nonces.account {
+		return n.EthereumClient.PendingNonceAt(ctx, account)
+	}
+	return n.nonces.Reserve(ctx)
+}
+
+func (n *nonceBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	err := n.EthereumClient.SendTransaction(ctx, tx)
+	n.nonces.sent(tx, err)
+	return err
+}
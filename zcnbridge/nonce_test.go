@@ -0,0 +1,162 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	bridgemocks "github.com/0chain/gosdk/zcnbridge/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingNonceClient is an Ethereum client with a settable pending nonce.
+type pendingNonceClient struct {
+	*bridgemocks.EthereumClient
+
+	mu      sync.Mutex
+	pending uint64
+}
+
+func (c *pendingNonceClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pending, nil
+}
+
+func (c *pendingNonceClient) setPending(nonce uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = nonce
+}
+
+func Test_NonceManager(t *testing.T) {
+	newManager := func(pending uint64) (*NonceManager, *pendingNonceClient) {
+		client := &pendingNonceClient{EthereumClient: getEthereumClient(t), pending: pending}
+		return NewNonceManager(client, common.HexToAddress(ethereumAddress)), client
+	}
+	ctx := context.Background()
+
+	t.Run("should reserve distinct nonces concurrently", func(t *testing.T) {
+		m, _ := newManager(5)
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			nonces = make(map[uint64]bool)
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				nonce, err := m.Reserve(ctx)
+				require.NoError(t, err)
+				m.MarkSent(nonce)
+				mu.Lock()
+				nonces[nonce] = true
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, nonces, 10)
+		for nonce := uint64(5); nonce < 15; nonce++ {
+			require.True(t, nonces[nonce])
+		}
+	})
+
+	t.Run("should hand out the released nonces first", func(t *testing.T) {
+		m, _ := newManager(5)
+		first, _ := m.Reserve(ctx)
+		second, _ := m.Reserve(ctx)
+		require.Equal(t, []uint64{5, 6}, []uint64{first, second})
+
+		m.Release(first)
+		nonce, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		require.Equal(t, first, nonce)
+
+		nonce, err = m.Reserve(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), nonce)
+	})
+
+	t.Run("should follow the transactions sent outside of the manager", func(t *testing.T) {
+		m, client := newManager(5)
+		nonce, _ := m.Reserve(ctx)
+		m.MarkSent(nonce)
+
+		client.setPending(20)
+		nonce, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(20), nonce)
+	})
+
+	t.Run("should resync with the dropped transactions", func(t *testing.T) {
+		m, _ := newManager(5)
+		m.ResyncAfter = time.Millisecond
+		for i := 0; i < 3; i++ {
+			nonce, _ := m.Reserve(ctx)
+			m.MarkSent(nonce)
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		nonce, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), nonce)
+	})
+
+	t.Run("should hand out again the expired reservations", func(t *testing.T) {
+		m, _ := newManager(5)
+		m.ReservationTimeout = time.Millisecond
+		_, _ = m.Reserve(ctx)
+
+		time.Sleep(5 * time.Millisecond)
+		nonce, err := m.Reserve(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), nonce)
+	})
+
+	t.Run("should reserve the nonces of the bridge transactions", func(t *testing.T) {
+		ethereumClient := &pendingNonceClient{EthereumClient: getEthereumClient(t), pending: 5}
+		ethereumClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(400000), nil)
+		ethereumClient.On("ChainID", mock.Anything).Return(big.NewInt(400000), nil)
+		ethereumClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(400000), nil)
+
+		var (
+			mu   sync.Mutex
+			sent = make(map[uint64]bool)
+		)
+		ethereumClient.On("SendTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+			sent[args.Get(1).(*types.Transaction).Nonce()] = true
+		}).Return(nil)
+
+		keyStore := getKeyStore(t)
+		prepareKeyStoreGeneralMockCalls(keyStore)
+
+		bridgeClient := getBridgeClient(alchemyEthereumNodeURL, ethereumClient, getTransactionProvider(t), keyStore)
+		bridgeClient.ManageNonces()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := bridgeClient.IncreaseBurnerAllowance(ctx, amount)
+				require.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, sent, 5)
+		for nonce := uint64(5); nonce < 10; nonce++ {
+			require.True(t, sent[nonce])
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/authorizers"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/zcntoken"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ErrReadOnlyClient is the error of the transactions and signatures of a read-only bridge client.
+var ErrReadOnlyClient = errors.New("read-only bridge client, no key store to sign with")
+
+// NewReadOnlyBridgeClient creates a BridgeClient querying the bridge contracts without a key store,
+// e.g. for monitoring tools. The transactions and signatures fail with ErrReadOnlyClient.
+//   - bridgeAddress is the address of the bridge smart contract on the Ethereum network.
+//   - tokenAddress is the address of the token smart contract on the Ethereum network.
+//   - authorizersAddress is the address of the authorizers smart contract on the Ethereum network.
+//   - ethereumAddress is the address of the Ethereum wallet queried, may be empty.
+//   - ethereumNodeURL is the URL of the Ethereum node.
+//   - ethereumClient is the Ethereum JSON-RPC client.
+func NewReadOnlyBridgeClient(
+	bridgeAddress,
+	tokenAddress,
+	authorizersAddress,
+	ethereumAddress,
+	ethereumNodeURL string,
+	ethereumClient EthereumClient) *BridgeClient {
+	return &BridgeClient{
+		BridgeAddress:      bridgeAddress,
+		TokenAddress:       tokenAddress,
+		AuthorizersAddress: authorizersAddress,
+		EthereumAddress:    ethereumAddress,
+		EthereumNodeURL:    ethereumNodeURL,
+		ethereumClient:     ethereumClient,
+	}
+}
+
+// ReadOnly tells if the client has no key store to sign the transactions with.
+func (b *BridgeClient) ReadOnly() bool {
+	return b.keyStore == nil
+}
+
+// GetBurnerAllowance returns the amount of WZCN of the wallet the bridge is allowed to burn, in wei.
+//   - ctx go context instance to run the call
+func (b *BridgeClient) GetBurnerAllowance(ctx context.Context) (*big.Int, error) {
+	tokenInstance, err := zcntoken.NewToken(common.HexToAddress(b.TokenAddress), b.ethereumClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize zcntoken instance")
+	}
+
+	wei, err := tokenInstance.Allowance(&bind.CallOpts{Context: ctx}, common.HexToAddress(b.EthereumAddress), common.HexToAddress(b.BridgeAddress))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to call `Allowance` for %s", b.EthereumAddress)
+	}
+
+	return wei, nil
+}
+
+// GetTokenTotalSupply returns the amount of WZCN minted by the bridge and not burned, in wei.
+//   - ctx go context instance to run the call
+func (b *BridgeClient) GetTokenTotalSupply(ctx context.Context) (*big.Int, error) {
+	tokenInstance, err := zcntoken.NewToken(common.HexToAddress(b.TokenAddress), b.ethereumClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize zcntoken instance")
+	}
+
+	wei, err := tokenInstance.TotalSupply(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call `TotalSupply`")
+	}
+
+	return wei, nil
+}
+
+// EthereumAuthorizersInfo describes the authorizer set of the authorizers contract.
+type EthereumAuthorizersInfo struct {
+	// Count is the number of authorizers.
+	Count *big.Int
+	// MinThreshold is the minimum number of authorizer signatures of a mint.
+	MinThreshold *big.Int
+}
+
+// GetEthereumAuthorizersInfo returns the authorizer set of the authorizers contract.
+//   - ctx go context instance to run the calls
+func (b *BridgeClient) GetEthereumAuthorizersInfo(ctx context.Context) (*EthereumAuthorizersInfo, error) {
+	authorizersInstance, err := authorizers.NewAuthorizers(common.HexToAddress(b.AuthorizersAddress), b.ethereumClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize authorizers instance")
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	count, err := authorizersInstance.AuthorizerCount(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call `AuthorizerCount`")
+	}
+	threshold, err := authorizersInstance.MinThreshold(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call `MinThreshold`")
+	}
+
+	return &EthereumAuthorizersInfo{Count: count, MinThreshold: threshold}, nil
+}
+
+// IsEthereumAuthorizer tells if the address is an authorizer of the authorizers contract.
+//   - ctx go context instance to run the call
+//   - address the Ethereum address of the authorizer
+func (b *BridgeClient) IsEthereumAuthorizer(ctx context.Context, address common.Address) (bool, error) {
+	authorizersInstance, err := authorizers.NewAuthorizers(common.HexToAddress(b.AuthorizersAddress), b.ethereumClient)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to initialize authorizers instance")
+	}
+
+	authorizer, err := authorizersInstance.Authorizers(&bind.CallOpts{Context: ctx}, address)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to call `Authorizers` for %s", address.Hex())
+	}
+
+	return authorizer.IsAuthorizer, nil
+}
+
+// IsBurnTicketMinted tells if the WZCN of the ZCN burn ticket of the wallet were minted, the bridge
+// contract minting the tickets of a wallet in the order of their nonces.
+//   - ctx go context instance to run the call
+//   - nonce the nonce of the burn ticket
+func (b *BridgeClient) IsBurnTicketMinted(ctx context.Context, nonce int64) (bool, error) {
+	minted, err := b.GetUserNonceMinted(ctx, b.EthereumAddress)
+	if err != nil {
+		return false, err
+	}
+	return minted.Cmp(big.NewInt(nonce)) >= 0, nil
+}
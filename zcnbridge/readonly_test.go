@@ -0,0 +1,79 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// callResult encodes the uint256 words returned by a contract call.
+func callResult(words ...int64) []byte {
+	var out []byte
+	for _, word := range words {
+		out = append(out, math.U256Bytes(big.NewInt(word))...)
+	}
+	return out
+}
+
+func Test_ReadOnlyBridgeClient(t *testing.T) {
+	newClient := func(words ...int64) *BridgeClient {
+		ethereumClient := getEthereumClient(t)
+		ethereumClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(callResult(words...), nil)
+		return NewReadOnlyBridgeClient(bridgeAddress, tokenAddress, authorizersAddress, ethereumAddress, alchemyEthereumNodeURL, ethereumClient)
+	}
+
+	t.Run("should query the token", func(t *testing.T) {
+		client := newClient(500)
+		require.True(t, client.ReadOnly())
+
+		allowance, err := client.GetBurnerAllowance(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(500), allowance)
+
+		supply, err := client.GetTokenTotalSupply(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(500), supply)
+
+		balance, err := client.GetTokenBalance()
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(500), balance)
+	})
+
+	t.Run("should query the authorizers", func(t *testing.T) {
+		info, err := newClient(3).GetEthereumAuthorizersInfo(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(3), info.Count)
+		require.Equal(t, big.NewInt(3), info.MinThreshold)
+
+		isAuthorizer, err := newClient(0, 1).IsEthereumAuthorizer(context.Background(), common.HexToAddress(ethereumAddress))
+		require.NoError(t, err)
+		require.True(t, isAuthorizer)
+	})
+
+	t.Run("should tell the minted burn tickets", func(t *testing.T) {
+		client := newClient(3)
+
+		minted, err := client.IsBurnTicketMinted(context.Background(), 3)
+		require.NoError(t, err)
+		require.True(t, minted)
+
+		minted, err = client.IsBurnTicketMinted(context.Background(), 4)
+		require.NoError(t, err)
+		require.False(t, minted)
+	})
+
+	t.Run("should reject the transactions", func(t *testing.T) {
+		client := newClient()
+
+		_, err := client.BurnWZCN(context.Background(), amount)
+		require.ErrorIs(t, err, ErrReadOnlyClient)
+
+		_, err = client.SignWithEthereumChain("message")
+		require.ErrorIs(t, err, ErrReadOnlyClient)
+	})
+}
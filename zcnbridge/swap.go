@@ -0,0 +1,306 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/uniswaprouter"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/zcntoken"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SwapToken is a token swapped to WZCN on the DEX.
+type SwapToken string
+
+const (
+	// SwapTokenETH swaps ETH to WZCN.
+	SwapTokenETH SwapToken = "ETH"
+	// SwapTokenUSDC swaps USDC to WZCN, the DEX being approved to spend the USDC first.
+	SwapTokenUSDC SwapToken = "USDC"
+)
+
+// DefaultSwapSlippageBps is the slippage of the swap quotes without one, in basis points (0.5%).
+const DefaultSwapSlippageBps = 50
+
+// swapPollInterval is the interval the swap flow checks if its transactions are mined.
+var swapPollInterval = 5 * time.Second
+
+// SwapQuote is a quote of a swap to WZCN on the DEX.
+type SwapQuote struct {
+	Token SwapToken
+	// AmountIn is the amount of the token swapped, in its smallest unit.
+	AmountIn *big.Int
+	// AmountOut is the amount of WZCN quoted, in wei.
+	AmountOut *big.Int
+	// MinAmountOut is the amount of WZCN the swap reverts under, AmountOut minus the slippage.
+	MinAmountOut *big.Int
+	// SlippageBps is the slippage accepted, in basis points.
+	SlippageBps uint64
+}
+
+// SwapAndBridgeResult reports the transactions of SwapAndBridge.
+type SwapAndBridgeResult struct {
+	Quote *SwapQuote
+	// Swap is the swap transaction.
+	Swap *types.Transaction
+	// Swapped is the amount of WZCN received from the swap, in wei.
+	Swapped *big.Int
+	// Approve is the transaction increasing the burner allowance.
+	Approve *types.Transaction
+	// Burn is the WZCN burn transaction, its hash being the proof of burn of the ZCN mint.
+	Burn *types.Transaction
+}
+
+// ParseSwapToken parses a token swapped to WZCN, ETH or USDC.
+//   - s the symbol of the token
+func ParseSwapToken(s string) (SwapToken, error) {
+	switch token := SwapToken(strings.ToUpper(strings.TrimSpace(s))); token {
+	case SwapTokenETH, SwapTokenUSDC:
+		return token, nil
+	}
+	return "", errors.Errorf("unsupported swap token %q, expected ETH or USDC", s)
+}
+
+// swapPath returns the DEX path from the token to WZCN.
+func (b *BridgeClient) swapPath(token SwapToken) ([]common.Address, error) {
+	switch token {
+	case SwapTokenETH:
+		return []common.Address{
+			common.HexToAddress(WethTokenAddress),
+			common.HexToAddress(b.TokenAddress),
+		}, nil
+	case SwapTokenUSDC:
+		return []common.Address{
+			common.HexToAddress(UsdcTokenAddress),
+			common.HexToAddress(WethTokenAddress),
+			common.HexToAddress(b.TokenAddress),
+		}, nil
+	}
+	return nil, errors.Errorf("unsupported swap token %q", token)
+}
+
+// QuoteSwapToWZCN quotes the amount of WZCN received for an amount of ETH or USDC on the DEX.
+//   - ctx go context instance to run the call
+//   - token the token swapped
+//   - amountIn the amount of the token swapped, in wei for ETH and in the smallest unit for USDC
+//   - slippageBps the slippage accepted in basis points, DefaultSwapSlippageBps if 0
+func (b *BridgeClient) QuoteSwapToWZCN(ctx context.Context, token SwapToken, amountIn *big.Int, slippageBps uint64) (*SwapQuote, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, errors.New("amount must be greater than zero")
+	}
+	if slippageBps == 0 {
+		slippageBps = DefaultSwapSlippageBps
+	}
+	if slippageBps >= 10000 {
+		return nil, errors.Errorf("slippage of %d basis points over 100%%", slippageBps)
+	}
+
+	path, err := b.swapPath(token)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := uniswaprouter.NewUniswaprouter(common.HexToAddress(UniswapRouterAddress), b.ethereumClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize uniswaprouter instance")
+	}
+
+	amounts, err := router.GetAmountsOut(&bind.CallOpts{Context: ctx, From: common.HexToAddress(b.EthereumAddress)}, amountIn, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute GetAmountsOut call")
+	}
+	if len(amounts) != len(path) {
+		return nil, errors.Errorf("unexpected GetAmountsOut result of %d amounts", len(amounts))
+	}
+
+	amountOut := amounts[len(amounts)-1]
+	minAmountOut := new(big.Int).Mul(amountOut, big.NewInt(int64(10000-slippageBps)))
+	minAmountOut.Div(minAmountOut, big.NewInt(10000))
+
+	return &SwapQuote{
+		Token:        token,
+		AmountIn:     new(big.Int).Set(amountIn),
+		AmountOut:    amountOut,
+		MinAmountOut: minAmountOut,
+		SlippageBps:  slippageBps,
+	}, nil
+}
+
+// SwapToWZCN swaps the amount of the quote to WZCN on the DEX, reverting under the minimum amount
+// of the quote. The DEX is approved to spend the USDC first if needed.
+//   - ctx go context instance to run the transactions
+//   - quote the quote of the swap, see QuoteSwapToWZCN
+func (b *BridgeClient) SwapToWZCN(ctx context.Context, quote *SwapQuote) (*types.Transaction, error) {
+	switch quote.Token {
+	case SwapTokenETH:
+		instance, transactOpts, err := b.prepareUniswapNetwork(ctx, quote.AmountIn, "swapETHForZCNExactAmountIn", quote.MinAmountOut)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to prepare uniswapnetwork")
+		}
+
+		Logger.Info("Starting SwapToWZCN", zap.String("token", string(quote.Token)), zap.String("source", quote.AmountIn.String()), zap.String("min_target", quote.MinAmountOut.String()))
+
+		tran, err := instance.SwapETHForZCNExactAmountIn(transactOpts, quote.MinAmountOut)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to execute swapETHForZCNExactAmountIn transaction")
+		}
+		return tran, nil
+	case SwapTokenUSDC:
+		if err := b.approveSwap(ctx, common.HexToAddress(UsdcTokenAddress), quote.AmountIn); err != nil {
+			return nil, err
+		}
+
+		instance, transactOpts, err := b.prepareUniswapNetwork(ctx, big.NewInt(0), "swapUSDCForZCNExactAmountIn", quote.AmountIn, quote.MinAmountOut)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to prepare uniswapnetwork")
+		}
+
+		Logger.Info("Starting SwapToWZCN", zap.String("token", string(quote.Token)), zap.String("source", quote.AmountIn.String()), zap.String("min_target", quote.MinAmountOut.String()))
+
+		tran, err := instance.SwapUSDCForZCNExactAmountIn(transactOpts, quote.AmountIn, quote.MinAmountOut)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to execute swapUSDCForZCNExactAmountIn transaction")
+		}
+		return tran, nil
+	}
+	return nil, errors.Errorf("unsupported swap token %q", quote.Token)
+}
+
+// approveSwap approves the DEX to spend the amount of the token, and waits for the approval to be mined.
+func (b *BridgeClient) approveSwap(ctx context.Context, tokenAddress common.Address, amount *big.Int) error {
+	spender := common.HexToAddress(b.UniswapAddress)
+
+	token, err := zcntoken.NewToken(tokenAddress, b.ethereumClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize token instance")
+	}
+	allowance, err := token.Allowance(&bind.CallOpts{Context: ctx}, common.HexToAddress(b.EthereumAddress), spender)
+	if err != nil {
+		return errors.Wrap(err, "failed to call `Allowance`")
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	tokenInstance, transactOpts, err := b.prepareToken(ctx, "approve", tokenAddress, spender, amount)
+	if err != nil {
+		return errors.Wrap(err, "failed to prepare token")
+	}
+	tran, err := tokenInstance.Approve(transactOpts, spender, amount)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute approve transaction")
+	}
+	_, err = b.waitMined(ctx, tran)
+	return err
+}
+
+// SwapAndBridge swaps ETH or USDC to WZCN on the DEX and burns the WZCN received to the ZChain wallet,
+// waiting for each transaction to be mined. The ZCN are minted with QueryZChainMintPayload and MintZCN
+// once the authorizers confirmed the burn. The result reports the transactions sent before a failure.
+//   - ctx go context instance to run the transactions
+//   - quote the quote of the swap, see QuoteSwapToWZCN
+func (b *BridgeClient) SwapAndBridge(ctx context.Context, quote *SwapQuote) (*SwapAndBridgeResult, error) {
+	result := &SwapAndBridgeResult{Quote: quote}
+
+	var err error
+	result.Swap, err = b.SwapToWZCN(ctx, quote)
+	if err != nil {
+		return result, err
+	}
+	receipt, err := b.waitMined(ctx, result.Swap)
+	if err != nil {
+		return result, err
+	}
+
+	result.Swapped, err = b.receivedWZCN(receipt)
+	if err != nil {
+		return result, err
+	}
+	if result.Swapped.Sign() == 0 || !result.Swapped.IsUint64() {
+		return result, errors.Errorf("unexpected swapped amount %s", result.Swapped)
+	}
+	swapped := result.Swapped.Uint64()
+
+	result.Approve, err = b.IncreaseBurnerAllowance(ctx, swapped)
+	if err != nil {
+		return result, err
+	}
+	if _, err = b.waitMined(ctx, result.Approve); err != nil {
+		return result, err
+	}
+
+	result.Burn, err = b.BurnWZCN(ctx, swapped)
+	if err != nil {
+		return result, err
+	}
+	if _, err = b.waitMined(ctx, result.Burn); err != nil {
+		return result, err
+	}
+
+	Logger.Info(
+		"Swapped and bridged WZCN",
+		zap.String("token", string(quote.Token)),
+		zap.String("source", quote.AmountIn.String()),
+		zap.Uint64("burned", swapped),
+		zap.String("burn_hash", result.Burn.Hash().Hex()),
+	)
+
+	return result, nil
+}
+
+// receivedWZCN sums the WZCN transferred to the wallet by the transaction.
+func (b *BridgeClient) receivedWZCN(receipt *types.Receipt) (*big.Int, error) {
+	tokenAddress := common.HexToAddress(b.TokenAddress)
+	filterer, err := zcntoken.NewTokenFilterer(tokenAddress, b.ethereumClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize zcntoken filterer")
+	}
+
+	wallet := common.HexToAddress(b.EthereumAddress)
+	received := new(big.Int)
+	for _, log := range receipt.Logs {
+		if log.Address != tokenAddress {
+			continue
+		}
+		transfer, err := filterer.ParseTransfer(*log)
+		if err != nil || transfer.To != wallet {
+			continue
+		}
+		received.Add(received, transfer.Value)
+	}
+	return received, nil
+}
+
+// waitMined waits for the transaction to be mined, it fails if the transaction reverted.
+func (b *BridgeClient) waitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	reader, ok := b.ethereumClient.(TransactionReceiptReader)
+	if !ok {
+		return nil, errors.New("the ethereum client doesn't read the transaction receipts")
+	}
+
+	for {
+		receipt, err := reader.TransactionReceipt(ctx, tx.Hash())
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, errors.Errorf("transaction %s reverted", tx.Hash().Hex())
+			}
+			return receipt, nil
+		}
+		if !errors.Is(err, eth.NotFound) {
+			return nil, errors.Wrapf(err, "failed to get receipt of %s", tx.Hash().Hex())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "transaction %s not mined", tx.Hash().Hex())
+		case <-time.After(swapPollInterval):
+		}
+	}
+}
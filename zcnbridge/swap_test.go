@@ -0,0 +1,132 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/zcntoken"
+	bridgemocks "github.com/0chain/gosdk/zcnbridge/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// swapClient is an Ethereum client mining every transaction, the WZCN of the swaps being
+// transferred to the wallet.
+type swapClient struct {
+	*bridgemocks.EthereumClient
+
+	transfer *types.Log
+}
+
+func (c *swapClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return &types.Receipt{TxHash: txHash, Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{c.transfer}}, nil
+}
+
+func Test_Swap(t *testing.T) {
+	t.Run("should parse the swap tokens", func(t *testing.T) {
+		token, err := ParseSwapToken(" usdc")
+		require.NoError(t, err)
+		require.Equal(t, SwapTokenUSDC, token)
+
+		_, err = ParseSwapToken("BNT")
+		require.Error(t, err)
+	})
+
+	t.Run("should quote the swap with the slippage", func(t *testing.T) {
+		ethereumClient := getEthereumClient(t)
+		// uint256[] of the amounts of the path
+		ethereumClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(callResult(32, 2, 1000, 5000), nil)
+
+		bridgeClient := NewReadOnlyBridgeClient(bridgeAddress, tokenAddress, authorizersAddress, ethereumAddress, alchemyEthereumNodeURL, ethereumClient)
+
+		quote, err := bridgeClient.QuoteSwapToWZCN(context.Background(), SwapTokenETH, big.NewInt(1000), 0)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(5000), quote.AmountOut)
+		require.Equal(t, big.NewInt(4975), quote.MinAmountOut)
+		require.Equal(t, uint64(DefaultSwapSlippageBps), quote.SlippageBps)
+
+		_, err = bridgeClient.QuoteSwapToWZCN(context.Background(), SwapTokenETH, big.NewInt(1000), 10000)
+		require.Error(t, err)
+
+		// the USDC path goes through WETH
+		_, err = bridgeClient.QuoteSwapToWZCN(context.Background(), SwapTokenUSDC, big.NewInt(1000), 100)
+		require.Error(t, err)
+	})
+
+	t.Run("should swap and burn the WZCN received", func(t *testing.T) {
+		tokenABI, err := zcntoken.TokenMetaData.GetAbi()
+		require.NoError(t, err)
+		data, err := tokenABI.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(5000))
+		require.NoError(t, err)
+
+		ethereumClient := &swapClient{
+			EthereumClient: getEthereumClient(t),
+			transfer: &types.Log{
+				Address: common.HexToAddress(tokenAddress),
+				Topics: []common.Hash{
+					tokenABI.Events["Transfer"].ID,
+					common.BytesToHash(common.HexToAddress(UniswapRouterAddress).Bytes()),
+					common.BytesToHash(common.HexToAddress(ethereumAddress).Bytes()),
+				},
+				Data: data,
+			},
+		}
+		prepareEthereumClientGeneralMockCalls(&ethereumClient.Mock)
+		ethereumClient.On("PendingCodeAt", mock.Anything, mock.Anything).Return([]byte{1}, nil)
+
+		keyStore := getKeyStore(t)
+		prepareKeyStoreGeneralMockCalls(keyStore)
+
+		bridgeClient := getBridgeClient(alchemyEthereumNodeURL, ethereumClient, getTransactionProvider(t), keyStore)
+
+		quote := &SwapQuote{
+			Token:        SwapTokenETH,
+			AmountIn:     big.NewInt(1000),
+			AmountOut:    big.NewInt(5000),
+			MinAmountOut: big.NewInt(4975),
+			SlippageBps:  DefaultSwapSlippageBps,
+		}
+		result, err := bridgeClient.SwapAndBridge(context.Background(), quote)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(5000), result.Swapped)
+		require.Equal(t, big.NewInt(1000), result.Swap.Value())
+		require.NotNil(t, result.Approve)
+
+		bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+		require.NoError(t, err)
+		args, err := bridgeABI.Methods["burn"].Inputs.Unpack(result.Burn.Data()[4:])
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(5000), args[0])
+	})
+
+	t.Run("should fail with the reverted swap", func(t *testing.T) {
+		ethereumClient := &receiptsClient{
+			EthereumClient: getEthereumClient(t),
+			receipts:       make(map[common.Hash]*types.Receipt),
+		}
+		ethereumClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(400000), nil)
+		ethereumClient.On("ChainID", mock.Anything).Return(big.NewInt(400000), nil)
+		ethereumClient.On("PendingNonceAt", mock.Anything, mock.Anything).Return(uint64(nonce), nil)
+		ethereumClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(400000), nil)
+		ethereumClient.On("PendingCodeAt", mock.Anything, mock.Anything).Return([]byte{1}, nil)
+		ethereumClient.On("SendTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			ethereumClient.mine(args.Get(1).(*types.Transaction), types.ReceiptStatusFailed)
+		}).Return(nil)
+
+		keyStore := getKeyStore(t)
+		prepareKeyStoreGeneralMockCalls(keyStore)
+
+		bridgeClient := getBridgeClient(alchemyEthereumNodeURL, ethereumClient, getTransactionProvider(t), keyStore)
+
+		quote := &SwapQuote{Token: SwapTokenETH, AmountIn: big.NewInt(1000), MinAmountOut: math.MaxBig256}
+		result, err := bridgeClient.SwapAndBridge(context.Background(), quote)
+		require.Error(t, err)
+		require.NotNil(t, result.Swap)
+		require.Nil(t, result.Burn)
+	})
+}
@@ -0,0 +1,458 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// TxStatus is the status of a tracked Ethereum transaction.
+type TxStatus int
+
+const (
+	// TxStatusPending is the status of a sent transaction waiting to be mined.
+	TxStatusPending TxStatus = iota
+	// TxStatusStuck is the status of a pending transaction priced below the current fees.
+	TxStatusStuck
+	// TxStatusMined is the status of a transaction mined successfully.
+	TxStatusMined
+	// TxStatusFailed is the status of a transaction mined but reverted.
+	TxStatusFailed
+	// TxStatusReplaced is the status of a transaction replaced by another one with the same nonce.
+	TxStatusReplaced
+	// TxStatusCancelled is the status of a transaction cancelled with CancelTransaction, once the cancellation is mined.
+	TxStatusCancelled
+)
+
+// String returns the name of the status.
+func (s TxStatus) String() string {
+	switch s {
+	case TxStatusPending:
+		return "pending"
+	case TxStatusStuck:
+		return "stuck"
+	case TxStatusMined:
+		return "mined"
+	case TxStatusFailed:
+		return "failed"
+	case TxStatusReplaced:
+		return "replaced"
+	case TxStatusCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// Final reports whether the status won't change anymore.
+func (s TxStatus) Final() bool {
+	return s != TxStatusPending && s != TxStatusStuck
+}
+
+// DefaultReplacementBump is the fee bump of the replacement transactions in percents, the nodes
+// require at least 10% to replace a pending transaction.
+const DefaultReplacementBump = 12
+
+// TransactionReceiptReader reads the receipts of the Ethereum transactions, the ethclient.Client implements it.
+type TransactionReceiptReader interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// TrackedTransaction is an Ethereum transaction watched by a TransactionTracker.
+type TrackedTransaction struct {
+	Tx     *types.Transaction
+	Status TxStatus
+	SentAt time.Time
+	// ReplacedBy is the hash of the transaction with the same nonce mined instead, or sent to replace it.
+	ReplacedBy common.Hash
+	// Receipt is the receipt of the mined transaction.
+	Receipt *types.Receipt
+
+	cancel bool
+}
+
+// TxStatusCallback is notified of the status changes of the tracked transactions. It gets a copy of the
+// tracked transaction, it shouldn't block.
+type TxStatusCallback func(tx TrackedTransaction)
+
+// TransactionTracker watches the Ethereum transactions sent by a bridge client until they are mined,
+// detects the ones stuck below the current fees and speeds them up if AutoSpeedUp is set.
+// The final transactions are notified to the callbacks and forgotten.
+type TransactionTracker struct {
+	b      *BridgeClient
+	reader TransactionReceiptReader
+
+	// StuckAfter is the time a pending transaction priced below the current fees is stuck after, 3 minutes by default.
+	StuckAfter time.Duration
+	// PollInterval is the interval Run checks the transactions, 15 seconds by default.
+	PollInterval time.Duration
+	// AutoSpeedUp replaces the stuck transactions with SpeedUpTransaction.
+	AutoSpeedUp bool
+
+	mu        sync.Mutex
+	txs       map[uint64][]*TrackedTransaction // by nonce, in the order they were sent
+	callbacks []TxStatusCallback
+}
+
+// TrackTransactions makes the bridge client track the transactions it sends, it returns the tracker.
+// The Ethereum client of the bridge client should read the receipts, see TransactionReceiptReader.
+func (b *BridgeClient) TrackTransactions() (*TransactionTracker, error) {
+	b.trackerMu.Lock()
+	defer b.trackerMu.Unlock()
+	if b.tracker != nil {
+		return b.tracker, nil
+	}
+	reader, ok := b.ethereumClient.(TransactionReceiptReader)
+	if !ok {
+		return nil, errors.New("the ethereum client doesn't read the transaction receipts")
+	}
+	b.tracker = &TransactionTracker{
+		b:            b,
+		reader:       reader,
+		StuckAfter:   3 * time.Minute,
+		PollInterval: 15 * time.Second,
+		txs:          make(map[uint64][]*TrackedTransaction),
+	}
+	return b.tracker, nil
+}
+
+// contractBackend returns the backend of the contracts sending the transactions, it tracks them
+// if TrackTransactions was called and reserves their nonces if ManageNonces was called.
+func (b *BridgeClient) contractBackend() EthereumClient {
+	b.trackerMu.Lock()
+	defer b.trackerMu.Unlock()
+	backend := b.ethereumClient
+	if b.nonces != nil {
+		backend = &nonceBackend{EthereumClient: backend, nonces: b.nonces}
+	}
+	if b.tracker != nil {
+		backend = &trackingBackend{EthereumClient: backend, tracker: b.tracker}
+	}
+	return backend
+}
+
+// trackingBackend tracks the transactions sent successfully.
+type trackingBackend struct {
+	EthereumClient
+	tracker *TransactionTracker
+}
+
+func (t *trackingBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := t.EthereumClient.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	t.tracker.Track(tx)
+	return nil
+}
+
+// OnStatus adds a callback notified of the status changes of the tracked transactions.
+//   - cb the callback
+func (t *TransactionTracker) OnStatus(cb TxStatusCallback) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.callbacks = append(t.callbacks, cb)
+}
+
+// Track watches a sent transaction. The transactions sent by the bridge client are tracked already.
+//   - tx the sent transaction
+func (t *TransactionTracker) Track(tx *types.Transaction) {
+	t.track(tx, false)
+}
+
+func (t *TransactionTracker) track(tx *types.Transaction, cancel bool) {
+	t.mu.Lock()
+	tracked := &TrackedTransaction{Tx: tx, Status: TxStatusPending, SentAt: time.Now(), cancel: cancel}
+	group := t.txs[tx.Nonce()]
+	var replaced []TrackedTransaction
+	for _, prev := range group {
+		if !prev.Status.Final() {
+			prev.Status = TxStatusReplaced
+			prev.ReplacedBy = tx.Hash()
+			replaced = append(replaced, *prev)
+		}
+	}
+	t.txs[tx.Nonce()] = append(group, tracked)
+	t.mu.Unlock()
+
+	t.notify(append(replaced, *tracked)...)
+}
+
+// Pending returns the pending and stuck transactions, by nonce.
+func (t *TransactionTracker) Pending() []TrackedTransaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var pending []TrackedTransaction
+	for _, group := range t.txs {
+		for _, tracked := range group {
+			if !tracked.Status.Final() {
+				pending = append(pending, *tracked)
+			}
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Tx.Nonce() < pending[j].Tx.Nonce() })
+	return pending
+}
+
+// Run checks the tracked transactions every PollInterval until the context is done.
+//   - ctx go context instance to run the checks
+func (t *TransactionTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := t.Check(ctx); err != nil {
+			Logger.Error("failed to check the tracked transactions", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check updates the status of the tracked transactions once: the mined ones are final and replace the
+// other transactions with their nonce, the pending ones priced below the current fees since StuckAfter
+// are stuck and sped up if AutoSpeedUp is set.
+//   - ctx go context instance to run the checks
+func (t *TransactionTracker) Check(ctx context.Context) error {
+	t.mu.Lock()
+	nonces := make(map[uint64][]*TrackedTransaction, len(t.txs))
+	for nonce, group := range t.txs {
+		nonces[nonce] = append([]*TrackedTransaction(nil), group...)
+	}
+	t.mu.Unlock()
+
+	for nonce, group := range nonces {
+		mined, receipt, err := t.minedTransaction(ctx, group)
+		if err != nil {
+			return err
+		}
+		if mined != nil {
+			t.settle(nonce, mined, receipt)
+			continue
+		}
+
+		latest := group[len(group)-1]
+		t.mu.Lock()
+		waiting := !latest.Status.Final() && time.Since(latest.SentAt) >= t.StuckAfter
+		t.mu.Unlock()
+		if !waiting {
+			continue
+		}
+		underpriced, err := t.b.underpriced(ctx, latest.Tx)
+		if err != nil {
+			return err
+		}
+		if !underpriced {
+			continue
+		}
+		t.mu.Lock()
+		stuck := latest.Status == TxStatusPending
+		latest.Status = TxStatusStuck
+		snapshot := *latest
+		t.mu.Unlock()
+		if stuck {
+			t.notify(snapshot)
+		}
+		if t.AutoSpeedUp {
+			if _, err := t.b.SpeedUpTransaction(ctx, latest.Tx); err != nil {
+				Logger.Error("failed to speed up stuck transaction",
+					zap.String("hash", latest.Tx.Hash().Hex()), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+func (t *TransactionTracker) minedTransaction(ctx context.Context, group []*TrackedTransaction) (*TrackedTransaction, *types.Receipt, error) {
+	for _, tracked := range group {
+		receipt, err := t.reader.TransactionReceipt(ctx, tracked.Tx.Hash())
+		if errors.Is(err, eth.NotFound) || (err == nil && receipt == nil) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get receipt of %s", tracked.Tx.Hash().Hex())
+		}
+		return tracked, receipt, nil
+	}
+	return nil, nil, nil
+}
+
+// settle sets the final status of the transactions of a nonce and forgets them.
+func (t *TransactionTracker) settle(nonce uint64, mined *TrackedTransaction, receipt *types.Receipt) {
+	t.mu.Lock()
+	var changed []TrackedTransaction
+	for _, tracked := range t.txs[nonce] {
+		if tracked == mined {
+			continue
+		}
+		if tracked.Status != TxStatusReplaced || tracked.ReplacedBy != mined.Tx.Hash() {
+			tracked.Status = TxStatusReplaced
+			tracked.ReplacedBy = mined.Tx.Hash()
+			changed = append(changed, *tracked)
+		}
+	}
+	mined.Receipt = receipt
+	switch {
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		mined.Status = TxStatusFailed
+	case mined.cancel:
+		mined.Status = TxStatusCancelled
+	default:
+		mined.Status = TxStatusMined
+	}
+	changed = append(changed, *mined)
+	delete(t.txs, nonce)
+	t.mu.Unlock()
+
+	t.notify(changed...)
+}
+
+func (t *TransactionTracker) notify(txs ...TrackedTransaction) {
+	t.mu.Lock()
+	callbacks := append([]TxStatusCallback(nil), t.callbacks...)
+	t.mu.Unlock()
+	for _, tx := range txs {
+		for _, cb := range callbacks {
+			cb(tx)
+		}
+	}
+}
+
+// SpeedUpTransaction replaces a pending transaction with the same one priced higher: its fees bumped by
+// DefaultReplacementBump percents, or the current fees if they are higher.
+//   - ctx go context instance to run the transaction
+//   - tx the pending transaction
+func (b *BridgeClient) SpeedUpTransaction(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return b.replaceTransaction(ctx, tx, false)
+}
+
+// CancelTransaction replaces a pending transaction with an empty transfer to the sender priced higher,
+// so the transaction is never mined. The cancellation is only effective once it's mined, see TrackTransactions.
+//   - ctx go context instance to run the transaction
+//   - tx the pending transaction
+func (b *BridgeClient) CancelTransaction(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return b.replaceTransaction(ctx, tx, true)
+}
+
+func (b *BridgeClient) replaceTransaction(ctx context.Context, tx *types.Transaction, cancel bool) (*types.Transaction, error) {
+	opts, err := b.createSignedTransaction(ctx, b.ethereumClient, tx.Gas())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create transaction")
+	}
+
+	var (
+		to    = tx.To()
+		value = tx.Value()
+		data  = tx.Data()
+		gas   = tx.Gas()
+	)
+	if cancel {
+		from := opts.From
+		to, value, data, gas = &from, new(big.Int), nil, 21000
+	}
+
+	var replacement *types.Transaction
+	if tx.Type() == types.DynamicFeeTxType {
+		tip := maxBig(bumpPercents(tx.GasTipCap(), DefaultReplacementBump), opts.GasTipCap)
+		feeCap := maxBig(bumpPercents(tx.GasFeeCap(), DefaultReplacementBump), opts.GasFeeCap, opts.GasPrice, tip)
+		if err := b.checkGasCap(feeCap); err != nil {
+			return nil, err
+		}
+		replacement = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+	} else {
+		price := maxBig(bumpPercents(tx.GasPrice(), DefaultReplacementBump), opts.GasPrice, opts.GasFeeCap)
+		if err := b.checkGasCap(price); err != nil {
+			return nil, err
+		}
+		replacement = types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: price,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		})
+	}
+
+	signed, err := opts.Signer(opts.From, replacement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign replacement transaction")
+	}
+	if err := b.ethereumClient.SendTransaction(ctx, signed); err != nil {
+		return nil, errors.Wrapf(err, "failed to replace transaction %s", tx.Hash().Hex())
+	}
+
+	b.trackerMu.Lock()
+	tracker := b.tracker
+	b.trackerMu.Unlock()
+	if tracker != nil {
+		tracker.track(signed, cancel)
+	}
+
+	Logger.Info("Replaced Ethereum transaction",
+		zap.String("hash", tx.Hash().Hex()),
+		zap.String("replacement", signed.Hash().Hex()),
+		zap.Bool("cancel", cancel),
+	)
+
+	return signed, nil
+}
+
+// underpriced reports whether a transaction is priced below the current fees of the network.
+func (b *BridgeClient) underpriced(ctx context.Context, tx *types.Transaction) (bool, error) {
+	if tx.Type() != types.DynamicFeeTxType {
+		price, err := b.ethereumClient.SuggestGasPrice(ctx)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to suggest gas price")
+		}
+		return tx.GasPrice().Cmp(price) < 0, nil
+	}
+
+	head, err := b.ethereumClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get latest header")
+	}
+	if head.BaseFee != nil && tx.GasFeeCap().Cmp(head.BaseFee) < 0 {
+		return true, nil
+	}
+	tip, err := b.ethereumClient.SuggestGasTipCap(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to suggest gas tip cap")
+	}
+	return tx.GasTipCap().Cmp(tip) < 0, nil
+}
+
+// bumpPercents returns the value increased by the percents, rounded up.
+func bumpPercents(value *big.Int, percents int64) *big.Int {
+	bumped := new(big.Int).Mul(value, big.NewInt(100+percents))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// maxBig returns the highest of the values, the nil ones ignored.
+func maxBig(values ...*big.Int) *big.Int {
+	var max *big.Int
+	for _, v := range values {
+		if v != nil && (max == nil || v.Cmp(max) > 0) {
+			max = v
+		}
+	}
+	return new(big.Int).Set(max)
+}
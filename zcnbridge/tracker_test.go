@@ -0,0 +1,153 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	bridgemocks "github.com/0chain/gosdk/zcnbridge/mocks"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// receiptsClient is an Ethereum client reading the receipts of the mined transactions.
+type receiptsClient struct {
+	*bridgemocks.EthereumClient
+
+	mu       sync.Mutex
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (c *receiptsClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	receipt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, eth.NotFound
+	}
+	return receipt, nil
+}
+
+func (c *receiptsClient) mine(tx *types.Transaction, status uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.receipts[tx.Hash()] = &types.Receipt{TxHash: tx.Hash(), Status: status}
+}
+
+func Test_TransactionTracker(t *testing.T) {
+	ethereumClient := &receiptsClient{
+		EthereumClient: getEthereumClient(t),
+		receipts:       make(map[common.Hash]*types.Receipt),
+	}
+	prepareEthereumClientGeneralMockCalls(&ethereumClient.Mock)
+
+	keyStore := getKeyStore(t)
+	prepareKeyStoreGeneralMockCalls(keyStore)
+
+	bridgeClient := getBridgeClient(alchemyEthereumNodeURL, ethereumClient, getTransactionProvider(t), keyStore)
+
+	tracker, err := bridgeClient.TrackTransactions()
+	require.NoError(t, err)
+	tracker.StuckAfter = 0
+
+	var (
+		mu       sync.Mutex
+		statuses = make(map[common.Hash][]TxStatus)
+	)
+	tracker.OnStatus(func(tx TrackedTransaction) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses[tx.Tx.Hash()] = append(statuses[tx.Tx.Hash()], tx.Status)
+	})
+	statusesOf := func(tx *types.Transaction) []TxStatus {
+		mu.Lock()
+		defer mu.Unlock()
+		return statuses[tx.Hash()]
+	}
+
+	to := common.HexToAddress(bridgeAddress)
+	newTx := func(nonce uint64, gasPrice int64) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(gasPrice), Gas: 50000, To: &to, Data: []byte{1}})
+	}
+
+	t.Run("should track the transactions until they are mined", func(t *testing.T) {
+		tx := newTx(1, 500000)
+		require.NoError(t, bridgeClient.contractBackend().SendTransaction(context.Background(), tx))
+		require.Len(t, tracker.Pending(), 1)
+
+		require.NoError(t, tracker.Check(context.Background()))
+		require.Equal(t, []TxStatus{TxStatusPending}, statusesOf(tx))
+
+		ethereumClient.mine(tx, types.ReceiptStatusSuccessful)
+		require.NoError(t, tracker.Check(context.Background()))
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusMined}, statusesOf(tx))
+		require.Empty(t, tracker.Pending())
+	})
+
+	t.Run("should detect the stuck transactions and speed them up", func(t *testing.T) {
+		tx := newTx(2, 300000)
+		tracker.Track(tx)
+
+		require.NoError(t, tracker.Check(context.Background()))
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusStuck}, statusesOf(tx))
+
+		replacement, err := bridgeClient.SpeedUpTransaction(context.Background(), tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Nonce(), replacement.Nonce())
+		require.Equal(t, tx.Data(), replacement.Data())
+		// the current gas price is higher than the bumped one
+		require.Equal(t, big.NewInt(400000), replacement.GasPrice())
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusStuck, TxStatusReplaced}, statusesOf(tx))
+
+		pending := tracker.Pending()
+		require.Len(t, pending, 1)
+		require.Equal(t, replacement.Hash(), pending[0].Tx.Hash())
+
+		ethereumClient.mine(replacement, types.ReceiptStatusSuccessful)
+		require.NoError(t, tracker.Check(context.Background()))
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusMined}, statusesOf(replacement))
+	})
+
+	t.Run("should cancel the transactions", func(t *testing.T) {
+		tx := newTx(3, 500000)
+		tracker.Track(tx)
+
+		cancellation, err := bridgeClient.CancelTransaction(context.Background(), tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Nonce(), cancellation.Nonce())
+		require.Equal(t, common.HexToAddress(ethereumAddress), *cancellation.To())
+		require.Zero(t, cancellation.Value().Sign())
+		require.Empty(t, cancellation.Data())
+		require.Equal(t, bumpPercents(big.NewInt(500000), DefaultReplacementBump), cancellation.GasPrice())
+
+		ethereumClient.mine(cancellation, types.ReceiptStatusSuccessful)
+		require.NoError(t, tracker.Check(context.Background()))
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusCancelled}, statusesOf(cancellation))
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusReplaced}, statusesOf(tx))
+	})
+
+	t.Run("should settle the nonce with the transaction mined", func(t *testing.T) {
+		tx := newTx(4, 500000)
+		tracker.Track(tx)
+		replacement := newTx(4, 600000)
+		tracker.Track(replacement)
+
+		ethereumClient.mine(tx, types.ReceiptStatusFailed)
+		require.NoError(t, tracker.Check(context.Background()))
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusReplaced, TxStatusFailed}, statusesOf(tx))
+		require.Equal(t, []TxStatus{TxStatusPending, TxStatusReplaced}, statusesOf(replacement))
+		require.Empty(t, tracker.Pending())
+	})
+
+	t.Run("should require a client reading the receipts", func(t *testing.T) {
+		client := getBridgeClient(alchemyEthereumNodeURL, getEthereumClient(t), getTransactionProvider(t), keyStore)
+		_, err := client.TrackTransactions()
+		require.Error(t, err)
+	})
+
+	ethereumClient.AssertCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
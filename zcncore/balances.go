@@ -0,0 +1,139 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/sync/errgroup"
+)
+
+// AggregatedBalances are the holdings of the wallet on the ZChain and of its Ethereum address.
+// The ZChain amounts are in SAS, the WZCN amount is in wei.
+type AggregatedBalances struct {
+	// ZCN is the balance of the wallet.
+	ZCN common.Balance `json:"zcn"`
+	// WZCN is the WZCN ERC-20 balance of the Ethereum address, nil without Ethereum address.
+	WZCN *big.Int `json:"wzcn,omitempty"`
+	// StorageLocked is the total locked by the wallet in the storage smart contract pools.
+	StorageLocked common.Balance `json:"storage_locked"`
+	// InterestLocked is the total locked by the wallet in the interest pool smart contract.
+	InterestLocked common.Balance `json:"interest_locked"`
+	// VestingLocked is the total balance of the vesting pools of the wallet.
+	VestingLocked common.Balance `json:"vesting_locked"`
+}
+
+// Locked returns the total ZCN locked in the pools.
+func (b *AggregatedBalances) Locked() common.Balance {
+	return b.StorageLocked + b.InterestLocked + b.VestingLocked
+}
+
+// TotalZCN returns the ZCN balance and the ZCN locked in the pools, the WZCN excluded.
+func (b *AggregatedBalances) TotalZCN() common.Balance {
+	return b.ZCN + b.Locked()
+}
+
+// GetAggregatedBalances gets the balance of the wallet, the ZCN it locked in the storage, interest and
+// vesting pools and the WZCN balance of its Ethereum address, querying them concurrently.
+//   - ethAddress: the Ethereum address of the wallet, the WZCN balance isn't queried if empty.
+//   - wzcnTokenAddress: the address of the WZCN token contract, see the bridge.token_address config.
+func GetAggregatedBalances(ethAddress, wzcnTokenAddress string) (*AggregatedBalances, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	clientID := _config.wallet.ClientID
+
+	var (
+		balances AggregatedBalances
+		g        errgroup.Group
+	)
+	g.Go(func() error {
+		balance, _, err := getWalletBalance(clientID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get balance")
+		}
+		balances.ZCN = balance
+		return nil
+	})
+	g.Go(func() error {
+		total, err := GetUserLockedTotal(clientID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get storage locked total")
+		}
+		balances.StorageLocked = common.Balance(total)
+		return nil
+	})
+	g.Go(func() error {
+		stats, err := GetInterestPools(clientID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get interest pools")
+		}
+		for _, pool := range stats.Pools {
+			if pool.Locked {
+				balances.InterestLocked += pool.Balance
+			}
+		}
+		return nil
+	})
+	g.Go(func() error {
+		list, err := GetVestingClientPools(clientID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get vesting pools")
+		}
+		for _, poolID := range list.Pools {
+			pool, err := GetVestingPool(string(poolID))
+			if err != nil {
+				return errors.Wrap(err, "failed to get vesting pool "+string(poolID))
+			}
+			balances.VestingLocked += pool.Balance
+		}
+		return nil
+	})
+	if ethAddress != "" {
+		g.Go(func() error {
+			client, err := getEthClient()
+			if err != nil {
+				return err
+			}
+			balance, err := erc20BalanceOf(context.Background(), client, wzcnTokenAddress, ethAddress)
+			if err != nil {
+				return errors.Wrap(err, "failed to get WZCN balance")
+			}
+			balances.WZCN = balance
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return &balances, nil
+}
+
+// erc20BalanceOf calls the balanceOf method of an ERC-20 token contract.
+func erc20BalanceOf(ctx context.Context, caller ethereum.ContractCaller, tokenAddress, owner string) (*big.Int, error) {
+	if !ethcommon.IsHexAddress(tokenAddress) {
+		return nil, errors.New("invalid_token_address", "invalid token address "+tokenAddress)
+	}
+	if !ethcommon.IsHexAddress(owner) {
+		return nil, errors.New("invalid_eth_address", "invalid Ethereum address "+owner)
+	}
+
+	data := append(crypto.Keccak256([]byte("balanceOf(address)"))[:4], ethcommon.LeftPadBytes(ethcommon.HexToAddress(owner).Bytes(), 32)...)
+	token := ethcommon.HexToAddress(tokenAddress)
+	out, err := caller.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != 32 {
+		return nil, errors.Newf("invalid_balance", "unexpected balanceOf result of %d bytes", len(out))
+	}
+	return new(big.Int).SetBytes(out), nil
+}
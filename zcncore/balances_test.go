@@ -0,0 +1,50 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+// balanceCaller answers the balanceOf calls of a token.
+type balanceCaller struct {
+	msg     ethereum.CallMsg
+	balance *big.Int
+}
+
+func (c *balanceCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.msg = msg
+	return math.U256Bytes(new(big.Int).Set(c.balance)), nil
+}
+
+func TestERC20BalanceOf(t *testing.T) {
+	const (
+		token = "0x28b149020d2152179873ec60bed6bf7cd705775d"
+		owner = "0xD8c9156e782C68EE671C09b6b92de76C97948432"
+	)
+	caller := &balanceCaller{balance: big.NewInt(1234)}
+
+	balance, err := erc20BalanceOf(context.Background(), caller, token, owner)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1234), balance)
+	require.Equal(t, common.HexToAddress(token), *caller.msg.To)
+	require.Equal(t, "70a08231", common.Bytes2Hex(caller.msg.Data[:4]))
+	require.Equal(t, common.HexToAddress(owner), common.BytesToAddress(caller.msg.Data[4:]))
+
+	_, err = erc20BalanceOf(context.Background(), caller, "token", owner)
+	require.Error(t, err)
+}
+
+func TestAggregatedBalances(t *testing.T) {
+	b := &AggregatedBalances{ZCN: 10, StorageLocked: 1, InterestLocked: 2, VestingLocked: 3, WZCN: big.NewInt(100)}
+	require.EqualValues(t, 6, b.Locked())
+	require.EqualValues(t, 16, b.TotalZCN())
+}
@@ -0,0 +1,135 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/block"
+)
+
+const (
+	defaultBlockPollInterval = time.Second
+	maxBlockPollBackoff      = time.Minute
+	blockSubscriptionBuffer  = 16
+)
+
+// getLatestFinalizedHeader and getBlockHeaderByRound query the sharders, replaced by the tests.
+var (
+	getLatestFinalizedHeader = func(ctx context.Context) (*block.Header, error) {
+		return GetLatestFinalized(ctx, 1)
+	}
+	getBlockHeaderByRound = func(ctx context.Context, round int64) (*block.Header, error) {
+		b, err := GetBlockByRound(ctx, 1, round)
+		if err != nil {
+			return nil, err
+		}
+		return b.Header, nil
+	}
+)
+
+// BlockSubscriptionOption is an option of SubscribeFinalizedBlocks.
+type BlockSubscriptionOption func(*blockSubscription)
+
+// WithBlockPollInterval sets the interval the sharders are polled at for new finalized blocks,
+// one second by default.
+//   - interval: the polling interval.
+func WithBlockPollInterval(interval time.Duration) BlockSubscriptionOption {
+	return func(s *blockSubscription) {
+		if interval > 0 {
+			s.interval = interval
+		}
+	}
+}
+
+// WithBlockStartRound streams the finalized blocks from a round, e.g. the round following the last
+// block processed by an indexer, instead of the latest finalized block.
+//   - round: the round of the first block streamed.
+func WithBlockStartRound(round int64) BlockSubscriptionOption {
+	return func(s *blockSubscription) {
+		s.next = round
+	}
+}
+
+type blockSubscription struct {
+	interval time.Duration
+	// next is the round of the next block to stream, 0 to start from the latest finalized block.
+	next   int64
+	blocks chan *block.Header
+}
+
+// SubscribeFinalizedBlocks streams the headers of the finalized blocks, in the order of the rounds
+// and without gaps, by polling the sharders. The sharders failing, it retries with a backoff and
+// resumes from the last block streamed, so no block is missed. The channel is closed once the
+// context is done.
+//   - ctx: the context of the subscription, cancel it to stop streaming.
+//   - opts: the options of the subscription, e.g. WithBlockStartRound.
+func SubscribeFinalizedBlocks(ctx context.Context, opts ...BlockSubscriptionOption) (<-chan *block.Header, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	s := &blockSubscription{
+		interval: defaultBlockPollInterval,
+		blocks:   make(chan *block.Header, blockSubscriptionBuffer),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.next < 0 {
+		return nil, errors.New("invalid_round", "the start round can't be negative")
+	}
+	go s.run(ctx)
+	return s.blocks, nil
+}
+
+func (s *blockSubscription) run(ctx context.Context) {
+	defer close(s.blocks)
+	delay := s.interval
+	for {
+		if err := s.poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logging.Error("finalized blocks subscription: ", err)
+			if delay *= 2; delay > maxBlockPollBackoff {
+				delay = maxBlockPollBackoff
+			}
+		} else {
+			delay = s.interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// poll streams the blocks finalized since the last block streamed.
+func (s *blockSubscription) poll(ctx context.Context) error {
+	latest, err := getLatestFinalizedHeader(ctx)
+	if err != nil {
+		return err
+	}
+	if s.next == 0 {
+		s.next = latest.Round
+	}
+	for ; s.next <= latest.Round; s.next++ {
+		h := latest
+		if s.next != latest.Round {
+			if h, err = getBlockHeaderByRound(ctx, s.next); err != nil {
+				return errors.Wrap(err, "failed to get the block of the round")
+			}
+		}
+		select {
+		case s.blocks <- h:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
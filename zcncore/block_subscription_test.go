@@ -0,0 +1,52 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/block"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockSubscription(t *testing.T) {
+	rawLatest, rawByRound := getLatestFinalizedHeader, getBlockHeaderByRound
+	defer func() {
+		getLatestFinalizedHeader, getBlockHeaderByRound = rawLatest, rawByRound
+	}()
+
+	// the latest round grows by 3 at every poll, the first lookup of round 12 fails.
+	var latest, failures int64 = 7, 0
+	getLatestFinalizedHeader = func(ctx context.Context) (*block.Header, error) {
+		return &block.Header{Round: atomic.AddInt64(&latest, 3)}, nil
+	}
+	getBlockHeaderByRound = func(ctx context.Context, round int64) (*block.Header, error) {
+		if round == 12 && atomic.AddInt64(&failures, 1) == 1 {
+			return nil, errors.New("", "round info not found")
+		}
+		return &block.Header{Round: round}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &blockSubscription{interval: time.Millisecond, next: 9, blocks: make(chan *block.Header)}
+	go s.run(ctx)
+
+	for round := int64(9); round < 20; round++ {
+		select {
+		case h := <-s.blocks:
+			require.Equal(t, round, h.Round)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no block streamed")
+		}
+	}
+	require.EqualValues(t, 2, atomic.LoadInt64(&failures))
+
+	cancel()
+	for range s.blocks {
+	}
+}
@@ -0,0 +1,87 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+// Client is a wallet held by the process next to the wallet of the SDK, e.g. the wallet of a
+// tenant of a backend service. The transactions of a client are signed with its wallet, the
+// network configuration is shared by all the clients. The clients are safe for concurrent use.
+type Client struct {
+	wallet zcncrypto.Wallet
+}
+
+// NewClient creates a client from a wallet, isolated from the wallet set with SetWallet or SetWalletInfo.
+// The SDK should be initialized with InitZCNSDK first. The split key wallets aren't supported.
+//   - walletJSON: the json of the wallet
+func NewClient(walletJSON string) (*Client, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+	c := &Client{}
+	if err := json.Unmarshal([]byte(walletJSON), &c.wallet); err != nil {
+		return nil, errors.Wrap(err, "invalid wallet")
+	}
+	if c.wallet.ClientID == "" || c.wallet.ClientKey == "" || len(c.wallet.Keys) == 0 {
+		return nil, errors.New("invalid_wallet", "the wallet should have a client id and keys")
+	}
+	if c.wallet.IsSplit {
+		return nil, errors.New("invalid_wallet", "split key wallets aren't supported by the clients")
+	}
+	return c, nil
+}
+
+// ClientID returns the id of the wallet of the client.
+func (c *Client) ClientID() string {
+	return c.wallet.ClientID
+}
+
+// ClientKey returns the public key of the wallet of the client.
+func (c *Client) ClientKey() string {
+	return c.wallet.ClientKey
+}
+
+// Sign signs a hash with the wallet of the client.
+//   - hash: the hash to sign
+func (c *Client) Sign(hash string) (string, error) {
+	return signWithWallet(hash, &c.wallet)
+}
+
+// NewTransaction creates a transaction signed with the wallet of the client.
+//   - cb: the callback of the transaction state
+//   - txnFee: the transaction fee (in SAS tokens), 0 to estimate it
+//   - nonce: the nonce of the transaction, 0 to use the next nonce of the wallet
+func (c *Client) NewTransaction(cb TransactionCallback, txnFee uint64, nonce int64) (*Transaction, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+	t := &Transaction{wallet: &c.wallet}
+	t.txn = transaction.NewTransactionEntity(c.wallet.ClientID, _config.chain.ChainID, c.wallet.ClientKey, nonce)
+	t.txnStatus, t.verifyStatus = StatusUnknown, StatusUnknown
+	t.txnCb = cb
+	t.txn.TransactionNonce = nonce
+	t.txn.TransactionFee = txnFee
+	return t, nil
+}
+
+// GetBalance returns the balance and the nonce of the wallet of the client.
+func (c *Client) GetBalance() (common.Balance, int64, error) {
+	return getWalletBalance(c.wallet.ClientID)
+}
+
+// GetNonce returns the nonce of the wallet of the client.
+func (c *Client) GetNonce() (int64, error) {
+	if err := checkSdkInit(); err != nil {
+		return 0, err
+	}
+	nonce, _, err := getNonceFromSharders(c.wallet.ClientID)
+	return nonce, err
+}
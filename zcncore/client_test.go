@@ -0,0 +1,66 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	saved := _config
+	defer func() { _config = saved }()
+	_config.isConfigured = true
+	_config.chain.ChainID = "chain id"
+	_config.chain.Miners = []string{"miner"}
+	_config.chain.Sharders = []string{"sharder"}
+	_config.chain.SignatureScheme = "ed25519"
+
+	newWallet := func() *zcncrypto.Wallet {
+		w, err := zcncrypto.NewSignatureScheme("ed25519").GenerateKeys()
+		require.NoError(t, err)
+		return w
+	}
+	sdkWallet, clientWallet := newWallet(), newWallet()
+	require.NoError(t, SetWallet(*sdkWallet, false))
+
+	_, err := NewClient(`{"client_id":"client id"}`)
+	require.Error(t, err)
+
+	walletJSON, err := json.Marshal(clientWallet)
+	require.NoError(t, err)
+	c, err := NewClient(string(walletJSON))
+	require.NoError(t, err)
+	require.Equal(t, clientWallet.ClientID, c.ClientID())
+
+	hash := encryption.Hash("data")
+	sig, err := c.Sign(hash)
+	require.NoError(t, err)
+	ok, err := verifyWith(clientWallet.ClientKey, sig, hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, _ = verifyWith(sdkWallet.ClientKey, sig, hash)
+	require.False(t, ok)
+
+	txn, err := c.NewTransaction(nil, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, clientWallet.ClientID, txn.txn.ClientID)
+	require.Equal(t, clientWallet.ClientKey, txn.txn.PublicKey)
+	require.NoError(t, txn.txn.ComputeHashAndSignWithWallet(signWithWallet, txn.wallet))
+	ok, err = verifyWith(clientWallet.ClientKey, txn.txn.Signature, txn.txn.Hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func verifyWith(publicKey, signature, hash string) (bool, error) {
+	ss := zcncrypto.NewSignatureScheme("ed25519")
+	if err := ss.SetPublicKey(publicKey); err != nil {
+		return false, err
+	}
+	return ss.Verify(signature, hash)
+}
@@ -0,0 +1,90 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"time"
+
+	"github.com/0chain/errors"
+)
+
+// ErrFaucetDisabled is returned by FaucetTopUp when the faucet isn't enabled for the network, see WithFaucet.
+var ErrFaucetDisabled = errors.New("faucet_disabled", "the faucet isn't enabled for the network")
+
+// faucetTopUpTimeout bounds the wait for the execution and the confirmation of a top up.
+var faucetTopUpTimeout = 2 * time.Minute
+
+// FaucetTopUp requests tokens from the faucet smart contract for the wallet of the client and
+// waits for the transaction to be confirmed, so integration tests and devnet tools can fund their
+// wallets. It's only allowed once the faucet is enabled with WithFaucet.
+//   - amount: the amount of tokens requested (in SAS), the faucet may limit it
+//
+// returns the hash of the confirmed transaction.
+func FaucetTopUp(amount uint64) (string, error) {
+	if err := CheckConfig(); err != nil {
+		return "", err
+	}
+	if !_config.chain.FaucetEnabled {
+		return "", ErrFaucetDisabled
+	}
+	if amount == 0 {
+		return "", errors.New("invalid_amount", "the amount should be positive")
+	}
+
+	cb := newWaitTxnCallback()
+	txn, err := newTransaction(cb, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	if _, err = txn.ExecuteSmartContractCall(&FaucetSCPourInput{}, amount); err != nil {
+		return "", err
+	}
+	if err = cb.wait(cb.txnCh, faucetTopUpTimeout); err != nil {
+		return "", errors.Wrap(err, "faucet top up failed: "+txn.GetTransactionError())
+	}
+
+	if err = txn.Verify(); err != nil {
+		return "", err
+	}
+	if err = cb.wait(cb.verifyCh, faucetTopUpTimeout); err != nil {
+		return "", errors.Wrap(err, "faucet top up verification failed: "+txn.GetVerifyError())
+	}
+	return txn.Hash(), nil
+}
+
+// waitTxnCallback is a TransactionCallback to wait for the completion of a transaction.
+type waitTxnCallback struct {
+	txnCh    chan int
+	verifyCh chan int
+}
+
+func newWaitTxnCallback() *waitTxnCallback {
+	return &waitTxnCallback{
+		txnCh:    make(chan int, 1),
+		verifyCh: make(chan int, 1),
+	}
+}
+
+func (cb *waitTxnCallback) OnTransactionComplete(t *Transaction, status int) {
+	cb.txnCh <- status
+}
+
+func (cb *waitTxnCallback) OnVerifyComplete(t *Transaction, status int) {
+	cb.verifyCh <- status
+}
+
+func (cb *waitTxnCallback) OnAuthComplete(t *Transaction, status int) {}
+
+// wait waits for the status of the transaction, it fails if the status isn't StatusSuccess.
+func (cb *waitTxnCallback) wait(ch <-chan int, timeout time.Duration) error {
+	select {
+	case status := <-ch:
+		if status != StatusSuccess {
+			return errors.Newf("transaction_failed", "status %d", status)
+		}
+		return nil
+	case <-time.After(timeout):
+		return errors.New("transaction_timeout", "the transaction isn't confirmed after "+timeout.String())
+	}
+}
@@ -0,0 +1,180 @@
+package zcncore
+
+import (
+	"context"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// ========================================================================== //
+//                               interest pool                                //
+// ========================================================================== //
+
+const (
+	yearDuration = 365 * 24 * time.Hour
+
+	defaultLockMaturityInterval = time.Minute
+)
+
+// InterestPoolConfig is the configuration of the interest pool smart contract.
+type InterestPoolConfig struct {
+	MinLock       common.Balance `json:"min_lock"`
+	MinLockPeriod time.Duration  `json:"min_lock_period"`
+	MaxMint       common.Balance `json:"max_mint"`
+	APR           float64        `json:"apr"`
+}
+
+// InterestPoolStat is a token lock of a client in the interest pool smart contract.
+type InterestPoolStat struct {
+	ID           common.Key       `json:"pool_id"`
+	StartTime    common.Timestamp `json:"start_time"`
+	Duration     time.Duration    `json:"duration"`
+	TimeLeft     time.Duration    `json:"time_left"`
+	Locked       bool             `json:"locked"`
+	APR          float64          `json:"apr"`
+	TokensEarned common.Balance   `json:"tokens_earned"`
+	Balance      common.Balance   `json:"balance"`
+}
+
+// InterestPoolStats is the list of token locks of a client.
+type InterestPoolStats struct {
+	Pools []*InterestPoolStat `json:"stats"`
+}
+
+// MaturityDate returns the time the locked tokens can be unlocked.
+func (s *InterestPoolStat) MaturityDate() time.Time {
+	return s.StartTime.ToTime().Add(s.Duration)
+}
+
+// IsMatured returns true if the lock duration has passed and the tokens can be unlocked.
+func (s *InterestPoolStat) IsMatured() bool {
+	return !s.Locked || !time.Now().Before(s.MaturityDate())
+}
+
+// ExpectedInterest returns the interest the lock earns for its whole duration.
+func (s *InterestPoolStat) ExpectedInterest() common.Balance {
+	return common.Balance(float64(s.Balance) * s.APR * float64(s.Duration) / float64(yearDuration))
+}
+
+// GetLockConfig gets the configuration of the interest pool smart contract.
+//   - cb: info callback instance, carries the response of the GET request to the sharders
+func GetLockConfig(cb GetInfoCallback) (err error) {
+	if err = CheckConfig(); err != nil {
+		return
+	}
+	go GetInfoFromSharders(GET_LOCK_CONFIG, OpGetTokenLockConfig, cb)
+	return
+}
+
+// GetLockedTokens gets the token locks of a client in the interest pool smart contract.
+//   - clientID: client id, if empty the client id of the wallet is used.
+//   - cb: info callback instance, carries the response of the GET request to the sharders
+func GetLockedTokens(clientID string, cb GetInfoCallback) (err error) {
+	if err = CheckConfig(); err != nil {
+		return
+	}
+	if clientID == "" {
+		clientID = _config.wallet.ClientID
+	}
+	go GetInfoFromSharders(withParams(GET_LOCKED_TOKENS, Params{
+		"client_id": clientID,
+	}), OpGetLockedTokens, cb)
+	return
+}
+
+// GetInterestPoolConfig is the typed version of GetLockConfig, it waits for the response of the sharders.
+func GetInterestPoolConfig() (*InterestPoolConfig, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	conf := new(InterestPoolConfig)
+	if err := getTypedInfoFromSharders(GET_LOCK_CONFIG, OpGetTokenLockConfig, conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// GetInterestPools is the typed version of GetLockedTokens, it waits for the response of the sharders.
+//   - clientID: client id, if empty the client id of the wallet is used.
+func GetInterestPools(clientID string) (*InterestPoolStats, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	if clientID == "" {
+		clientID = _config.wallet.ClientID
+	}
+	stats := new(InterestPoolStats)
+	err := getTypedInfoFromSharders(withParams(GET_LOCKED_TOKENS, Params{
+		"client_id": clientID,
+	}), OpGetLockedTokens, stats)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// LockMaturityCallback is notified when the token locks of a client mature.
+type LockMaturityCallback interface {
+	// OnLockMatured is called once for every matured lock, the tokens can be unlocked with UnlockTokens.
+	OnLockMatured(pool *InterestPoolStat)
+}
+
+// WatchLockMaturity polls the token locks of a client and calls the callback once for every lock
+// that reaches its maturity date, until the context is cancelled.
+//   - ctx: the context of the watcher, cancel it to stop watching.
+//   - clientID: client id, if empty the client id of the wallet is used.
+//   - interval: the polling interval, defaults to one minute.
+//   - cb: the callback notified of matured locks.
+func WatchLockMaturity(ctx context.Context, clientID string, interval time.Duration, cb LockMaturityCallback) error {
+	if err := CheckConfig(); err != nil {
+		return err
+	}
+	if cb == nil {
+		return errors.New("invalid_callback", "lock maturity callback is required")
+	}
+	if interval <= 0 {
+		interval = defaultLockMaturityInterval
+	}
+
+	go func() {
+		notified := make(map[common.Key]bool)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			stats, err := GetInterestPools(clientID)
+			if err != nil {
+				logging.Error("watch lock maturity: ", err)
+			} else {
+				notifyMaturedLocks(stats.Pools, notified, cb)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// notifyMaturedLocks calls the callback for the matured pools that have not been notified yet.
+// Pools that don't exist anymore are dropped from notified.
+func notifyMaturedLocks(pools []*InterestPoolStat, notified map[common.Key]bool, cb LockMaturityCallback) {
+	current := make(map[common.Key]bool, len(pools))
+	for _, pool := range pools {
+		current[pool.ID] = true
+		if notified[pool.ID] || !pool.IsMatured() {
+			continue
+		}
+		notified[pool.ID] = true
+		cb.OnLockMatured(pool)
+	}
+	for id := range notified {
+		if !current[id] {
+			delete(notified, id)
+		}
+	}
+}
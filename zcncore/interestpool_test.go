@@ -0,0 +1,39 @@
+package zcncore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+type lockMaturityRecorder struct {
+	matured []common.Key
+}
+
+func (r *lockMaturityRecorder) OnLockMatured(pool *InterestPoolStat) {
+	r.matured = append(r.matured, pool.ID)
+}
+
+func TestNotifyMaturedLocks(t *testing.T) {
+	now := common.Now()
+	matured := &InterestPoolStat{ID: "matured", StartTime: now - 3600, Duration: time.Minute, Locked: true}
+	locked := &InterestPoolStat{ID: "locked", StartTime: now, Duration: time.Hour, Locked: true}
+	unlocked := &InterestPoolStat{ID: "unlocked", StartTime: now, Duration: time.Hour}
+
+	cb := &lockMaturityRecorder{}
+	notified := make(map[common.Key]bool)
+	notifyMaturedLocks([]*InterestPoolStat{matured, locked, unlocked}, notified, cb)
+	require.Equal(t, []common.Key{"matured", "unlocked"}, cb.matured)
+
+	// the matured locks are notified once, the ones maturing later are notified then.
+	locked.StartTime = now - 7200
+	notifyMaturedLocks([]*InterestPoolStat{matured, locked, unlocked}, notified, cb)
+	require.Equal(t, []common.Key{"matured", "unlocked", "locked"}, cb.matured)
+
+	// the unlocked pools are forgotten.
+	notifyMaturedLocks([]*InterestPoolStat{locked}, notified, cb)
+	require.Equal(t, map[common.Key]bool{"locked": true}, notified)
+	require.Len(t, cb.matured, 3)
+}
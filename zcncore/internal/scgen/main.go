@@ -0,0 +1,207 @@
+// Command scgen generates the typed inputs of the smart contract calls of zcncore from a schema.
+//
+//	go run ./internal/scgen -schema sc_schema.json -out sc_calls.go
+//
+// Each method of the schema generates a struct <Contract><Method>Input implementing
+// zcncore.SmartContractCall, with a Validate method checking the constraints of its fields:
+//   - required: the field can't be the zero value
+//   - min, max: the bounds of a numeric field
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+type schema struct {
+	Contracts []contract `json:"contracts"`
+}
+
+type contract struct {
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	Methods []method `json:"methods"`
+}
+
+type method struct {
+	Name   string  `json:"name"`
+	Method string  `json:"method"`
+	Doc    string  `json:"doc"`
+	Fields []field `json:"fields"`
+}
+
+type field struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	JSON     string   `json:"json"`
+	Required bool     `json:"required"`
+	Min      *float64 `json:"min"`
+	Max      *float64 `json:"max"`
+	Doc      string   `json:"doc"`
+}
+
+// numericTypes are the types the min and max constraints apply to.
+var numericTypes = map[string]bool{
+	"int": true, "int64": true, "uint64": true, "float64": true,
+	"Provider": true, "time.Duration": true, "common.Timestamp": true, "common.Balance": true,
+}
+
+// typeImports are the imports of the qualified types of the fields.
+var typeImports = map[string]string{
+	"time":   "time",
+	"common": "github.com/0chain/gosdk/core/common",
+}
+
+func main() {
+	schemaPath := flag.String("schema", "sc_schema.json", "the schema of the smart contract calls")
+	out := flag.String("out", "sc_calls.go", "the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Fatalf("parse %s: %v", *schemaPath, err)
+	}
+	src, err := generate(&s, *schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(s *schema, schemaPath string) ([]byte, error) {
+	imports := map[string]bool{
+		"github.com/0chain/errors":                 true,
+		"github.com/0chain/gosdk/core/transaction": true,
+	}
+	seen := make(map[string]bool)
+	for _, c := range s.Contracts {
+		for _, m := range c.Methods {
+			name := c.Name + m.Name + "Input"
+			if seen[name] {
+				return nil, fmt.Errorf("duplicate method %s", name)
+			}
+			seen[name] = true
+			for _, f := range m.Fields {
+				if (f.Min != nil || f.Max != nil) && !numericTypes[f.Type] {
+					return nil, fmt.Errorf("%s.%s: min and max only apply to numeric fields", name, f.Name)
+				}
+				if i := strings.Index(f.Type, "."); i > 0 {
+					pkg := strings.TrimPrefix(f.Type[:i], "[]")
+					path, ok := typeImports[pkg]
+					if !ok {
+						return nil, fmt.Errorf("%s.%s: unknown package %s", name, f.Name, pkg)
+					}
+					imports[path] = true
+				}
+			}
+		}
+	}
+	var std, paths []string
+	for p := range imports {
+		if strings.Contains(p, ".") {
+			paths = append(paths, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]interface{}{
+		"Schema":     schemaPath,
+		"StdImports": std,
+		"Imports":    paths,
+		"Contracts":  s.Contracts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format the generated code: %v\n%s", err, buf.String())
+	}
+	return src, nil
+}
+
+// checks returns the conditions of a field failing its constraints, with their error messages.
+func checks(f field) [][2]string {
+	var res [][2]string
+	if f.Required {
+		zero := `in.` + f.Name + ` == 0`
+		switch {
+		case f.Type == "string":
+			zero = `in.` + f.Name + ` == ""`
+		case strings.HasPrefix(f.Type, "[]") || strings.HasPrefix(f.Type, "map["):
+			zero = `len(in.` + f.Name + `) == 0`
+		}
+		res = append(res, [2]string{zero, jsonName(f) + " is required"})
+	}
+	if f.Min != nil {
+		res = append(res, [2]string{fmt.Sprintf("in.%s < %v", f.Name, *f.Min), fmt.Sprintf("%s should be at least %v", jsonName(f), *f.Min)})
+	}
+	if f.Max != nil {
+		res = append(res, [2]string{fmt.Sprintf("in.%s > %v", f.Name, *f.Max), fmt.Sprintf("%s should be at most %v", jsonName(f), *f.Max)})
+	}
+	return res
+}
+
+func jsonName(f field) string {
+	return strings.Split(f.JSON, ",")[0]
+}
+
+var tmpl = template.Must(template.New("calls").Funcs(template.FuncMap{"checks": checks}).Parse(`// Code generated by scgen from {{.Schema}}. DO NOT EDIT.
+
+package zcncore
+
+import (
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+{{range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range $c := .Contracts}}{{range $m := $c.Methods}}
+// {{$c.Name}}{{$m.Name}}Input is the input of the {{$m.Method}} method of the {{$c.Name}}, it {{$m.Doc}}.
+type {{$c.Name}}{{$m.Name}}Input struct {
+{{- range $m.Fields}}
+	// {{.Name}} is {{.Doc}}.
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + `
+{{- end}}
+}
+
+// SmartContractAddress returns the address of the {{$c.Name}}.
+func (in *{{$c.Name}}{{$m.Name}}Input) SmartContractAddress() string {
+	return {{$c.Address}}
+}
+
+// MethodName returns transaction.{{$m.Method}}.
+func (in *{{$c.Name}}{{$m.Name}}Input) MethodName() string {
+	return transaction.{{$m.Method}}
+}
+
+// Validate checks the fields of the input.
+func (in *{{$c.Name}}{{$m.Name}}Input) Validate() error {
+{{- range $f := $m.Fields}}{{range checks $f}}
+	if {{index . 0}} {
+		return errors.New("invalid_input", "{{index . 1}}")
+	}
+{{- end}}{{end}}
+	return nil
+}
+{{end}}{{end}}`))
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedCallsUpToDate fails if sc_calls.go wasn't regenerated after a change of the schema,
+// run go generate ./zcncore to fix it.
+func TestGeneratedCallsUpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../sc_schema.json")
+	require.NoError(t, err)
+	var s schema
+	require.NoError(t, json.Unmarshal(data, &s))
+
+	src, err := generate(&s, "sc_schema.json")
+	require.NoError(t, err)
+	generated, err := os.ReadFile("../../sc_calls.go")
+	require.NoError(t, err)
+	require.Equal(t, string(generated), string(src))
+}
+
+func TestGenerate_InvalidSchema(t *testing.T) {
+	min := 1.0
+	_, err := generate(&schema{Contracts: []contract{{
+		Name: "StorageSC",
+		Methods: []method{{
+			Name:   "FinalizeAllocation",
+			Fields: []field{{Name: "AllocationID", Type: "string", JSON: "allocation_id", Min: &min}},
+		}},
+	}}}, "sc_schema.json")
+	require.Error(t, err)
+}
@@ -0,0 +1,133 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/block"
+)
+
+// lightClient caches the magic blocks used to verify the blocks, they only change on view changes.
+type lightClient struct {
+	mu          sync.Mutex
+	magicBlocks map[string]*block.MagicBlock
+}
+
+var defaultLightClient = &lightClient{magicBlocks: make(map[string]*block.MagicBlock)}
+
+// VerifiedConfirmation is a transaction confirmation verified locally against the magic block.
+type VerifiedConfirmation struct {
+	// Block is the block the transaction is included in, its signature has been verified.
+	Block *block.Block
+	// MagicBlock is the magic block holding the miner set the block has been verified against.
+	MagicBlock *block.MagicBlock
+}
+
+// VerifyBlock verifies that the block was signed by a miner of the active miner set. The magic block
+// of the block is fetched from the sharders once and cached.
+//   - ctx: the context of the sharder requests.
+//   - b: the block to verify, e.g. from GetBlockByRound.
+func VerifyBlock(ctx context.Context, b *block.Block) (*block.MagicBlock, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New("verify_block", "nil block")
+	}
+	mb, err := defaultLightClient.magicBlockFor(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	if err = mb.VerifyBlockSignature(b); err != nil {
+		return nil, err
+	}
+	return mb, nil
+}
+
+// VerifyTransactionConfirmation gets the confirmation of a transaction from a single sharder and
+// verifies it locally instead of trusting the sharder: the merkle paths of the transaction and its
+// receipt against the roots of the block, the hash of the block, and the signature of the block
+// against the miner set of the magic block.
+//   - ctx: the context of the sharder requests.
+//   - txnHash: the hash of the transaction.
+func VerifyTransactionConfirmation(ctx context.Context, txnHash string) (*VerifiedConfirmation, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	tq, err := NewTransactionQuery(Sharders.Healthy(), _config.chain.Miners)
+	if err != nil {
+		return nil, err
+	}
+	// the merkle paths and the block hash are verified while parsing the confirmation
+	header, _, _, err := tq.getFastConfirmation(ctx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, ErrTransactionNotConfirmed
+	}
+
+	b, err := GetBlockByRound(ctx, 0, header.Round)
+	if err != nil {
+		return nil, errors.Wrap(err, "verify_confirmation: get block")
+	}
+	if string(b.Hash) != header.Hash {
+		return nil, errors.Newf("verify_confirmation", "confirmation block %s doesn't match block %s of round %d", header.Hash, b.Hash, header.Round)
+	}
+	if string(b.MinerID) != header.MinerId {
+		return nil, errors.New("verify_confirmation", "confirmation miner doesn't match the block miner")
+	}
+
+	mb, err := VerifyBlock(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifiedConfirmation{Block: b, MagicBlock: mb}, nil
+}
+
+// magicBlockFor returns the magic block the block has been generated under. Starting from the latest
+// finalized magic block, the previous magic blocks are fetched until the one covering the round of
+// the block.
+func (lc *lightClient) magicBlockFor(ctx context.Context, b *block.Block) (*block.MagicBlock, error) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if mb, ok := lc.magicBlocks[b.MagicBlockHash]; ok {
+		return mb, nil
+	}
+
+	mb, err := GetLatestFinalizedMagicBlock(ctx, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "verify_block: get latest finalized magic block")
+	}
+	lc.add(mb)
+
+	for mb.StartingRound > b.Round {
+		if mb.MagicBlockNumber <= 1 {
+			return nil, errors.Newf("verify_block", "no magic block found for round %d", b.Round)
+		}
+		prev, err := GetMagicBlockByNumber(ctx, 0, mb.MagicBlockNumber-1)
+		if err != nil {
+			return nil, errors.Wrap(err, "verify_block: get magic block")
+		}
+		if prev.Hash != mb.PreviousMagicBlockHash {
+			return nil, errors.Newf("verify_block", "magic block %d doesn't extend magic block %d", mb.MagicBlockNumber, prev.MagicBlockNumber)
+		}
+		lc.add(prev)
+		mb = prev
+	}
+	if b.MagicBlockHash != "" && mb.Hash != b.MagicBlockHash {
+		return nil, errors.Newf("verify_block", "magic block %s of block %s not found", b.MagicBlockHash, b.Hash)
+	}
+	return mb, nil
+}
+
+func (lc *lightClient) add(mb *block.MagicBlock) {
+	if mb != nil && mb.Hash != "" {
+		lc.magicBlocks[mb.Hash] = mb
+	}
+}
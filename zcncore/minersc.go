@@ -0,0 +1,91 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"strconv"
+)
+
+// defaultNodesPageLimit is the page size used by GetAllMiners and GetAllSharders.
+const defaultNodesPageLimit = 20
+
+// GetMinerList is the typed version of GetMiners, it waits for the response of the sharders.
+//   - limit: how many miners should be fetched
+//   - offset: how many miners should be skipped
+//   - active: retrieve only active miners
+//   - stakable: retrieve only stakable miners
+func GetMinerList(limit, offset int, active, stakable bool) (*MinerSCNodes, error) {
+	return getNodeList(GET_MINERSC_MINERS, limit, offset, active, stakable)
+}
+
+// GetSharderList is the typed version of GetSharders, it waits for the response of the sharders.
+//   - limit: how many sharders should be fetched
+//   - offset: how many sharders should be skipped
+//   - active: retrieve only active sharders
+//   - stakable: retrieve only stakable sharders
+func GetSharderList(limit, offset int, active, stakable bool) (*MinerSCNodes, error) {
+	return getNodeList(GET_MINERSC_SHARDERS, limit, offset, active, stakable)
+}
+
+// GetAllMiners fetches the miners page by page and returns all of them.
+//   - active: retrieve only active miners
+//   - stakable: retrieve only stakable miners
+func GetAllMiners(active, stakable bool) ([]Node, error) {
+	return getAllNodes(GET_MINERSC_MINERS, active, stakable)
+}
+
+// GetAllSharders fetches the sharders page by page and returns all of them.
+//   - active: retrieve only active sharders
+//   - stakable: retrieve only stakable sharders
+func GetAllSharders(active, stakable bool) ([]Node, error) {
+	return getAllNodes(GET_MINERSC_SHARDERS, active, stakable)
+}
+
+// GetMinerSCNode is the typed version of GetMinerSCNodeInfo, it returns the settings,
+// geolocation and stake pool of a miner or sharder.
+//   - id: the id of the miner or sharder
+func GetMinerSCNode(id string) (*Node, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	node := new(Node)
+	err := getTypedInfoFromSharders(withParams(GET_MINERSC_NODE, Params{
+		"id": id,
+	}), 0, node)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func getNodeList(uri string, limit, offset int, active, stakable bool) (*MinerSCNodes, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	nodes := new(MinerSCNodes)
+	err := getTypedInfoFromSharders(withParams(uri, Params{
+		"active":   strconv.FormatBool(active),
+		"stakable": strconv.FormatBool(stakable),
+		"offset":   strconv.FormatInt(int64(offset), 10),
+		"limit":    strconv.FormatInt(int64(limit), 10),
+	}), 0, nodes)
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func getAllNodes(uri string, active, stakable bool) ([]Node, error) {
+	var all []Node
+	for offset := 0; ; offset += defaultNodesPageLimit {
+		page, err := getNodeList(uri, defaultNodesPageLimit, offset, active, stakable)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Nodes...)
+		if len(page.Nodes) < defaultNodesPageLimit {
+			return all, nil
+		}
+	}
+}
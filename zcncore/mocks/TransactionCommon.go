@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	transaction "github.com/0chain/gosdk/core/transaction"
 	mock "github.com/stretchr/testify/mock"
 
@@ -145,6 +147,20 @@ func (_m *TransactionCommon) GetVerifyConfirmationStatus() zcncore.ConfirmationS
 	return r0
 }
 
+// LockTokens provides a mock function with given fields: val, duration
+func (_m *TransactionCommon) LockTokens(val uint64, duration time.Duration) error {
+	ret := _m.Called(val, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, time.Duration) error); ok {
+		r0 = rf(val, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MinerSCCollectReward provides a mock function with given fields: providerID, providerType
 func (_m *TransactionCommon) MinerSCCollectReward(providerID string, providerType zcncore.Provider) error {
 	ret := _m.Called(providerID, providerType)
@@ -397,6 +413,20 @@ func (_m *TransactionCommon) StorageScUpdateConfig(_a0 *zcncore.InputMap) error
 	return r0
 }
 
+// UnlockTokens provides a mock function with given fields: poolID
+func (_m *TransactionCommon) UnlockTokens(poolID string) error {
+	ret := _m.Called(poolID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(poolID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateAllocation provides a mock function with given fields: allocID, sizeDiff, expirationDiff, lock
 func (_m *TransactionCommon) UpdateAllocation(allocID string, sizeDiff int64, expirationDiff int64, lock uint64) error {
 	ret := _m.Called(allocID, sizeDiff, expirationDiff, lock)
@@ -0,0 +1,124 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"strconv"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+const (
+	// snapshotsPageLimit is the page size of the global snapshots.
+	snapshotsPageLimit = 20
+	// snapshotsLookback is the number of rounds before the current round the latest global snapshot
+	// is looked for first, the window grows until a snapshot is found.
+	snapshotsLookback    = 1000
+	maxSnapshotsLookback = 64 * snapshotsLookback
+)
+
+// GlobalSnapshot is an aggregate of the network at a round, as recorded by the sharders.
+// The balances are in SAS, the storage sizes in bytes.
+type GlobalSnapshot struct {
+	Round int64 `json:"round"`
+
+	ZCNSupply   common.Balance `json:"zcn_supply"`
+	TotalMint   common.Balance `json:"total_mint"`
+	MinedTotal  common.Balance `json:"mined_total"`
+	TotalStaked common.Balance `json:"total_staked"`
+	// StorageTokenStake is the stake of the blobbers.
+	StorageTokenStake   common.Balance `json:"storage_token_stake"`
+	TotalRewards        common.Balance `json:"total_rewards"`
+	TotalChallengePools common.Balance `json:"total_challenge_pools"`
+	ClientLocks         common.Balance `json:"client_locks"`
+	// AverageWritePrice is the average write price of the blobbers, per GB and time unit.
+	AverageWritePrice common.Balance `json:"average_write_price"`
+
+	MaxCapacityStorage int64 `json:"max_capacity_storage"`
+	AllocatedStorage   int64 `json:"allocated_storage"`
+	StakedStorage      int64 `json:"staked_storage"`
+	UsedStorage        int64 `json:"used_storage"`
+
+	SuccessfulChallenges int64 `json:"successful_challenges"`
+	TotalChallenges      int64 `json:"total_challenges"`
+	TransactionsCount    int64 `json:"transactions_count"`
+	UniqueAddresses      int64 `json:"unique_addresses"`
+	BlockCount           int64 `json:"block_count"`
+
+	BlobberCount    int64 `json:"blobber_count"`
+	MinerCount      int64 `json:"miner_count"`
+	SharderCount    int64 `json:"sharder_count"`
+	ValidatorCount  int64 `json:"validator_count"`
+	AuthorizerCount int64 `json:"authorizer_count"`
+
+	CreatedAt common.Timestamp `json:"created_at"`
+}
+
+// GetGlobalSnapshots is the typed version of GetSnapshots, it waits for the response of a sharder.
+//   - round: round number to start fetching snapshots
+//   - limit: how many snapshots should be fetched
+func GetGlobalSnapshots(round, limit int64) ([]*GlobalSnapshot, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	var snapshots []*GlobalSnapshot
+	err := getTypedInfoFromAnySharder(withParams(STORAGE_GET_SNAPSHOT, Params{
+		"round": strconv.FormatInt(round, 10),
+		"limit": strconv.FormatInt(limit, 10),
+	}), OpStorageSCGetSnapshots, &snapshots)
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// GetNetworkStats gets the latest global snapshot of the network, e.g. the total staked, the total
+// storage capacity, the number of active blobbers and their average write price.
+func GetNetworkStats() (*GlobalSnapshot, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	round, err := GetRoundFromSharders()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the current round")
+	}
+	return latestSnapshot(round, func(round int64) ([]*GlobalSnapshot, error) {
+		return GetGlobalSnapshots(round, snapshotsPageLimit)
+	})
+}
+
+// latestSnapshot looks for the latest snapshot before the current round, in a window of rounds
+// growing until a snapshot is found.
+func latestSnapshot(current int64, getSnapshots func(round int64) ([]*GlobalSnapshot, error)) (*GlobalSnapshot, error) {
+	for lookback := int64(snapshotsLookback); ; lookback *= 4 {
+		from := current - lookback
+		if from < 0 {
+			from = 0
+		}
+
+		var latest *GlobalSnapshot
+		for round := from; ; {
+			snapshots, err := getSnapshots(round)
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range snapshots {
+				if latest == nil || s.Round > latest.Round {
+					latest = s
+				}
+			}
+			if len(snapshots) < snapshotsPageLimit || latest.Round <= round {
+				break
+			}
+			round = latest.Round
+		}
+		if latest != nil {
+			return latest, nil
+		}
+		if from == 0 || lookback >= maxSnapshotsLookback {
+			return nil, errors.New("snapshot_not_found", "no global snapshot found")
+		}
+	}
+}
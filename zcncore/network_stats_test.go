@@ -0,0 +1,40 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestSnapshot(t *testing.T) {
+	// snapshots returns the snapshots taken every interval rounds up to last, from a round.
+	snapshots := func(interval, last int64) func(round int64) ([]*GlobalSnapshot, error) {
+		return func(round int64) ([]*GlobalSnapshot, error) {
+			var list []*GlobalSnapshot
+			for r := (round + interval - 1) / interval * interval; r <= last && len(list) < snapshotsPageLimit; r += interval {
+				list = append(list, &GlobalSnapshot{Round: r})
+			}
+			return list, nil
+		}
+	}
+
+	s, err := latestSnapshot(5000, snapshots(100, 4900))
+	require.NoError(t, err)
+	require.EqualValues(t, 4900, s.Round)
+
+	// several pages of snapshots.
+	s, err = latestSnapshot(5000, snapshots(10, 4990))
+	require.NoError(t, err)
+	require.EqualValues(t, 4990, s.Round)
+
+	// no snapshot in the first window.
+	s, err = latestSnapshot(5000, snapshots(500, 1000))
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, s.Round)
+
+	_, err = latestSnapshot(5000, snapshots(100, -1))
+	require.Error(t, err)
+}
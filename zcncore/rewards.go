@@ -0,0 +1,193 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// stakePoolUserInfoPageLimit is the page size of the delegate pools of the storage smart contract.
+const stakePoolUserInfoPageLimit = 20
+
+// claimRewardTimeout bounds the wait for the execution of a collect reward transaction.
+var claimRewardTimeout = 2 * time.Minute
+
+// ClaimableReward is the uncollected reward of the wallet in the stake pool of a provider.
+type ClaimableReward struct {
+	ProviderID   string         `json:"provider_id"`
+	ProviderType Provider       `json:"provider_type"`
+	Amount       common.Balance `json:"amount"`
+	// Hash is the hash of the collect reward transaction, empty until the reward is claimed.
+	Hash string `json:"hash,omitempty"`
+	// Error is the error of the claim, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ClaimableRewards are the uncollected rewards of a wallet, one by provider.
+type ClaimableRewards struct {
+	Rewards []*ClaimableReward `json:"rewards"`
+	Total   common.Balance     `json:"total"`
+}
+
+// delegatePoolReward is the reward of a delegate pool, see the getUserPools and getUserStakePoolStat
+// endpoints of the miner and storage smart contracts.
+type delegatePoolReward struct {
+	ProviderID   string         `json:"provider_id"`
+	ProviderType Provider       `json:"provider_type"`
+	Rewards      common.Balance `json:"rewards"`
+}
+
+type userPoolsRewards struct {
+	Pools map[string][]*delegatePoolReward `json:"pools"`
+}
+
+// GetClaimableRewards gets the uncollected rewards of a client in the stake pools of the miners,
+// sharders, blobbers and validators it delegates to, e.g. its own providers for the operators.
+//   - clientID: client id, if empty the client id of the wallet is used.
+func GetClaimableRewards(clientID string) (*ClaimableRewards, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	if clientID == "" {
+		clientID = _config.wallet.ClientID
+	}
+
+	var pools []*userPoolsRewards
+	minerPools := new(userPoolsRewards)
+	err := getTypedInfoFromSharders(withParams(GET_MINERSC_USER, Params{
+		"client_id": clientID,
+	}), 0, minerPools)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get miner SC pools")
+	}
+	pools = append(pools, minerPools)
+
+	for offset := 0; ; offset += stakePoolUserInfoPageLimit {
+		page := new(userPoolsRewards)
+		err = getTypedInfoFromSharders(withParams(STORAGESC_GET_STAKE_POOL_USER_INFO, Params{
+			"client_id": clientID,
+			"offset":    strconv.Itoa(offset),
+			"limit":     strconv.Itoa(stakePoolUserInfoPageLimit),
+		}), OpStorageSCGetStakePoolInfo, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get storage SC pools")
+		}
+		pools = append(pools, page)
+
+		var n int
+		for _, dps := range page.Pools {
+			n += len(dps)
+		}
+		if n < stakePoolUserInfoPageLimit {
+			break
+		}
+	}
+	return claimableRewards(pools...), nil
+}
+
+// claimableRewards sums the rewards of the delegate pools by provider, dropping the providers
+// without reward. They're sorted by provider type and id.
+func claimableRewards(pools ...*userPoolsRewards) *ClaimableRewards {
+	type providerKey struct {
+		id  string
+		typ Provider
+	}
+	byProvider := make(map[providerKey]*ClaimableReward)
+	for _, up := range pools {
+		for _, dps := range up.Pools {
+			for _, dp := range dps {
+				if dp.Rewards <= 0 || dp.ProviderID == "" {
+					continue
+				}
+				key := providerKey{id: dp.ProviderID, typ: dp.ProviderType}
+				if r, ok := byProvider[key]; ok {
+					r.Amount += dp.Rewards
+					continue
+				}
+				byProvider[key] = &ClaimableReward{ProviderID: dp.ProviderID, ProviderType: dp.ProviderType, Amount: dp.Rewards}
+			}
+		}
+	}
+
+	rewards := &ClaimableRewards{Rewards: make([]*ClaimableReward, 0, len(byProvider))}
+	for _, r := range byProvider {
+		rewards.Rewards = append(rewards.Rewards, r)
+		rewards.Total += r.Amount
+	}
+	sort.Slice(rewards.Rewards, func(i, j int) bool {
+		ri, rj := rewards.Rewards[i], rewards.Rewards[j]
+		if ri.ProviderType != rj.ProviderType {
+			return ri.ProviderType < rj.ProviderType
+		}
+		return ri.ProviderID < rj.ProviderID
+	})
+	return rewards
+}
+
+// collectRewardCall returns the collect reward call of the smart contract of the provider.
+func collectRewardCall(r *ClaimableReward) (SmartContractCall, error) {
+	switch r.ProviderType {
+	case ProviderMiner, ProviderSharder:
+		return &MinerSCCollectRewardInput{ProviderID: r.ProviderID, ProviderType: r.ProviderType}, nil
+	case ProviderBlobber, ProviderValidator:
+		return &StorageSCCollectRewardInput{ProviderID: r.ProviderID, ProviderType: r.ProviderType}, nil
+	case ProviderAuthorizer:
+		return &ZCNSCCollectRewardInput{ProviderID: r.ProviderID, ProviderType: r.ProviderType}, nil
+	}
+	return nil, errors.Newf("invalid_provider_type", "unknown provider type %d", r.ProviderType)
+}
+
+// ClaimAllRewards collects the rewards of the wallet in all the stake pools it delegates to, with a
+// collect reward transaction by provider with rewards. The transactions are executed one after the
+// other, a failed claim doesn't stop the others.
+//   - dryRun: if true, only the expected rewards are returned, no transaction is sent.
+//
+// returns the rewards by provider, with the hash or the error of their transaction, and an error if
+// any claim failed.
+func ClaimAllRewards(dryRun bool) (*ClaimableRewards, error) {
+	rewards, err := GetClaimableRewards("")
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return rewards, nil
+	}
+
+	var failed int
+	for _, r := range rewards.Rewards {
+		if r.Hash, err = claimReward(r); err != nil {
+			r.Error = err.Error()
+			failed++
+		}
+	}
+	if failed > 0 {
+		return rewards, errors.Newf("claim_rewards_failed", "%d of %d claims failed", failed, len(rewards.Rewards))
+	}
+	return rewards, nil
+}
+
+// claimReward executes the collect reward transaction of a provider and waits for its execution.
+func claimReward(r *ClaimableReward) (string, error) {
+	call, err := collectRewardCall(r)
+	if err != nil {
+		return "", err
+	}
+	cb := newWaitTxnCallback()
+	txn, err := newTransaction(cb, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	if _, err = txn.ExecuteSmartContractCall(call, 0); err != nil {
+		return "", err
+	}
+	if err = cb.wait(cb.txnCh, claimRewardTimeout); err != nil {
+		return "", errors.Wrap(err, "collect reward failed: "+txn.GetTransactionError())
+	}
+	return txn.Hash(), nil
+}
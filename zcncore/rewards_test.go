@@ -0,0 +1,38 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimableRewards(t *testing.T) {
+	miner := new(userPoolsRewards)
+	require.NoError(t, json.Unmarshal([]byte(`{"pools":{"1":[
+		{"provider_id":"m1","provider_type":1,"rewards":10},
+		{"provider_id":"m1","provider_type":1,"rewards":5},
+		{"provider_id":"m2","provider_type":1,"rewards":0}]}}`), miner))
+	storage := new(userPoolsRewards)
+	require.NoError(t, json.Unmarshal([]byte(`{"pools":{
+		"3":[{"provider_id":"b1","provider_type":3,"rewards":7}],
+		"4":[{"provider_id":"v1","provider_type":4,"rewards":3}]}}`), storage))
+
+	rewards := claimableRewards(miner, storage)
+	require.EqualValues(t, 25, rewards.Total)
+	require.Len(t, rewards.Rewards, 3)
+	require.Equal(t, &ClaimableReward{ProviderID: "m1", ProviderType: ProviderMiner, Amount: 15}, rewards.Rewards[0])
+	require.Equal(t, "b1", rewards.Rewards[1].ProviderID)
+	require.Equal(t, "v1", rewards.Rewards[2].ProviderID)
+
+	for _, r := range rewards.Rewards {
+		call, err := collectRewardCall(r)
+		require.NoError(t, err)
+		require.NoError(t, call.Validate())
+	}
+	_, err := collectRewardCall(&ClaimableReward{ProviderID: "x", ProviderType: 9})
+	require.Error(t, err)
+}
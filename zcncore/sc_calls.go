@@ -0,0 +1,684 @@
+// Code generated by scgen from sc_schema.json. DO NOT EDIT.
+
+package zcncore
+
+import (
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/transaction"
+)
+
+// StorageSCFinalizeAllocationInput is the input of the STORAGESC_FINALIZE_ALLOCATION method of the StorageSC, it finalizes an expired allocation.
+type StorageSCFinalizeAllocationInput struct {
+	// AllocationID is the id of the allocation.
+	AllocationID string `json:"allocation_id"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCFinalizeAllocationInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_FINALIZE_ALLOCATION.
+func (in *StorageSCFinalizeAllocationInput) MethodName() string {
+	return transaction.STORAGESC_FINALIZE_ALLOCATION
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCFinalizeAllocationInput) Validate() error {
+	if in.AllocationID == "" {
+		return errors.New("invalid_input", "allocation_id is required")
+	}
+	return nil
+}
+
+// StorageSCCancelAllocationInput is the input of the STORAGESC_CANCEL_ALLOCATION method of the StorageSC, it cancels an allocation.
+type StorageSCCancelAllocationInput struct {
+	// AllocationID is the id of the allocation.
+	AllocationID string `json:"allocation_id"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCCancelAllocationInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_CANCEL_ALLOCATION.
+func (in *StorageSCCancelAllocationInput) MethodName() string {
+	return transaction.STORAGESC_CANCEL_ALLOCATION
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCCancelAllocationInput) Validate() error {
+	if in.AllocationID == "" {
+		return errors.New("invalid_input", "allocation_id is required")
+	}
+	return nil
+}
+
+// StorageSCWritePoolLockInput is the input of the STORAGESC_WRITE_POOL_LOCK method of the StorageSC, it locks tokens in the write pool of an allocation.
+type StorageSCWritePoolLockInput struct {
+	// AllocationID is the id of the allocation.
+	AllocationID string `json:"allocation_id"`
+	// BlobberID is the id of the blobber, empty for all the blobbers.
+	BlobberID string `json:"blobber_id,omitempty"`
+	// Duration is the duration of the lock.
+	Duration time.Duration `json:"duration"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCWritePoolLockInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_WRITE_POOL_LOCK.
+func (in *StorageSCWritePoolLockInput) MethodName() string {
+	return transaction.STORAGESC_WRITE_POOL_LOCK
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCWritePoolLockInput) Validate() error {
+	if in.AllocationID == "" {
+		return errors.New("invalid_input", "allocation_id is required")
+	}
+	if in.Duration < 0 {
+		return errors.New("invalid_input", "duration should be at least 0")
+	}
+	return nil
+}
+
+// StorageSCWritePoolUnlockInput is the input of the STORAGESC_WRITE_POOL_UNLOCK method of the StorageSC, it unlocks the tokens of the write pool of an allocation.
+type StorageSCWritePoolUnlockInput struct {
+	// AllocationID is the id of the allocation.
+	AllocationID string `json:"allocation_id"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCWritePoolUnlockInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_WRITE_POOL_UNLOCK.
+func (in *StorageSCWritePoolUnlockInput) MethodName() string {
+	return transaction.STORAGESC_WRITE_POOL_UNLOCK
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCWritePoolUnlockInput) Validate() error {
+	if in.AllocationID == "" {
+		return errors.New("invalid_input", "allocation_id is required")
+	}
+	return nil
+}
+
+// StorageSCStakePoolLockInput is the input of the STORAGESC_STAKE_POOL_LOCK method of the StorageSC, it locks tokens in the stake pool of a blobber or a validator.
+type StorageSCStakePoolLockInput struct {
+	// ProviderType is the type of the provider, ProviderBlobber or ProviderValidator.
+	ProviderType Provider `json:"provider_type"`
+	// ProviderID is the id of the provider.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCStakePoolLockInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_STAKE_POOL_LOCK.
+func (in *StorageSCStakePoolLockInput) MethodName() string {
+	return transaction.STORAGESC_STAKE_POOL_LOCK
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCStakePoolLockInput) Validate() error {
+	if in.ProviderType < 3 {
+		return errors.New("invalid_input", "provider_type should be at least 3")
+	}
+	if in.ProviderType > 4 {
+		return errors.New("invalid_input", "provider_type should be at most 4")
+	}
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// StorageSCStakePoolUnlockInput is the input of the STORAGESC_STAKE_POOL_UNLOCK method of the StorageSC, it unlocks the tokens of the stake pool of a blobber or a validator.
+type StorageSCStakePoolUnlockInput struct {
+	// ProviderType is the type of the provider, ProviderBlobber or ProviderValidator.
+	ProviderType Provider `json:"provider_type"`
+	// ProviderID is the id of the provider.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCStakePoolUnlockInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_STAKE_POOL_UNLOCK.
+func (in *StorageSCStakePoolUnlockInput) MethodName() string {
+	return transaction.STORAGESC_STAKE_POOL_UNLOCK
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCStakePoolUnlockInput) Validate() error {
+	if in.ProviderType < 3 {
+		return errors.New("invalid_input", "provider_type should be at least 3")
+	}
+	if in.ProviderType > 4 {
+		return errors.New("invalid_input", "provider_type should be at most 4")
+	}
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// StorageSCCollectRewardInput is the input of the STORAGESC_COLLECT_REWARD method of the StorageSC, it collects the rewards of a blobber or a validator.
+type StorageSCCollectRewardInput struct {
+	// ProviderID is the id of the provider.
+	ProviderID string `json:"provider_id"`
+	// ProviderType is the type of the provider, ProviderBlobber or ProviderValidator.
+	ProviderType Provider `json:"provider_type"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCCollectRewardInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_COLLECT_REWARD.
+func (in *StorageSCCollectRewardInput) MethodName() string {
+	return transaction.STORAGESC_COLLECT_REWARD
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCCollectRewardInput) Validate() error {
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	if in.ProviderType < 3 {
+		return errors.New("invalid_input", "provider_type should be at least 3")
+	}
+	if in.ProviderType > 4 {
+		return errors.New("invalid_input", "provider_type should be at most 4")
+	}
+	return nil
+}
+
+// StorageSCKillBlobberInput is the input of the STORAGESC_KILL_BLOBBER method of the StorageSC, it kills a blobber, only the owner of the smart contract can.
+type StorageSCKillBlobberInput struct {
+	// ProviderID is the id of the blobber.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCKillBlobberInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_KILL_BLOBBER.
+func (in *StorageSCKillBlobberInput) MethodName() string {
+	return transaction.STORAGESC_KILL_BLOBBER
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCKillBlobberInput) Validate() error {
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// StorageSCShutdownBlobberInput is the input of the STORAGESC_SHUTDOWN_BLOBBER method of the StorageSC, it shuts down a blobber, only its delegate wallet can.
+type StorageSCShutdownBlobberInput struct {
+	// ProviderID is the id of the blobber.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCShutdownBlobberInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_SHUTDOWN_BLOBBER.
+func (in *StorageSCShutdownBlobberInput) MethodName() string {
+	return transaction.STORAGESC_SHUTDOWN_BLOBBER
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCShutdownBlobberInput) Validate() error {
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// StorageSCSetRetentionLockInput is the input of the STORAGESC_SET_RETENTION_LOCK method of the StorageSC, it locks a file, a directory or a whole allocation until a date.
+type StorageSCSetRetentionLockInput struct {
+	// AllocationID is the id of the allocation.
+	AllocationID string `json:"allocation_id"`
+	// Path is the remote path to lock, empty for the whole allocation.
+	Path string `json:"path"`
+	// Until is the date the lock expires at.
+	Until common.Timestamp `json:"until"`
+}
+
+// SmartContractAddress returns the address of the StorageSC.
+func (in *StorageSCSetRetentionLockInput) SmartContractAddress() string {
+	return StorageSmartContractAddress
+}
+
+// MethodName returns transaction.STORAGESC_SET_RETENTION_LOCK.
+func (in *StorageSCSetRetentionLockInput) MethodName() string {
+	return transaction.STORAGESC_SET_RETENTION_LOCK
+}
+
+// Validate checks the fields of the input.
+func (in *StorageSCSetRetentionLockInput) Validate() error {
+	if in.AllocationID == "" {
+		return errors.New("invalid_input", "allocation_id is required")
+	}
+	if in.Until == 0 {
+		return errors.New("invalid_input", "until is required")
+	}
+	return nil
+}
+
+// MinerSCLockInput is the input of the MINERSC_LOCK method of the MinerSC, it locks tokens in the stake pool of a miner or a sharder.
+type MinerSCLockInput struct {
+	// ProviderType is the type of the provider, ProviderMiner or ProviderSharder.
+	ProviderType Provider `json:"provider_type"`
+	// ProviderID is the id of the provider.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the MinerSC.
+func (in *MinerSCLockInput) SmartContractAddress() string {
+	return MinerSmartContractAddress
+}
+
+// MethodName returns transaction.MINERSC_LOCK.
+func (in *MinerSCLockInput) MethodName() string {
+	return transaction.MINERSC_LOCK
+}
+
+// Validate checks the fields of the input.
+func (in *MinerSCLockInput) Validate() error {
+	if in.ProviderType < 1 {
+		return errors.New("invalid_input", "provider_type should be at least 1")
+	}
+	if in.ProviderType > 2 {
+		return errors.New("invalid_input", "provider_type should be at most 2")
+	}
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// MinerSCUnlockInput is the input of the MINERSC_UNLOCK method of the MinerSC, it unlocks the tokens of the stake pool of a miner or a sharder.
+type MinerSCUnlockInput struct {
+	// ProviderType is the type of the provider, ProviderMiner or ProviderSharder.
+	ProviderType Provider `json:"provider_type"`
+	// ProviderID is the id of the provider.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the MinerSC.
+func (in *MinerSCUnlockInput) SmartContractAddress() string {
+	return MinerSmartContractAddress
+}
+
+// MethodName returns transaction.MINERSC_UNLOCK.
+func (in *MinerSCUnlockInput) MethodName() string {
+	return transaction.MINERSC_UNLOCK
+}
+
+// Validate checks the fields of the input.
+func (in *MinerSCUnlockInput) Validate() error {
+	if in.ProviderType < 1 {
+		return errors.New("invalid_input", "provider_type should be at least 1")
+	}
+	if in.ProviderType > 2 {
+		return errors.New("invalid_input", "provider_type should be at most 2")
+	}
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// MinerSCCollectRewardInput is the input of the MINERSC_COLLECT_REWARD method of the MinerSC, it collects the rewards of a miner or a sharder.
+type MinerSCCollectRewardInput struct {
+	// ProviderID is the id of the provider.
+	ProviderID string `json:"provider_id"`
+	// ProviderType is the type of the provider, ProviderMiner or ProviderSharder.
+	ProviderType Provider `json:"provider_type"`
+}
+
+// SmartContractAddress returns the address of the MinerSC.
+func (in *MinerSCCollectRewardInput) SmartContractAddress() string {
+	return MinerSmartContractAddress
+}
+
+// MethodName returns transaction.MINERSC_COLLECT_REWARD.
+func (in *MinerSCCollectRewardInput) MethodName() string {
+	return transaction.MINERSC_COLLECT_REWARD
+}
+
+// Validate checks the fields of the input.
+func (in *MinerSCCollectRewardInput) Validate() error {
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	if in.ProviderType < 1 {
+		return errors.New("invalid_input", "provider_type should be at least 1")
+	}
+	if in.ProviderType > 2 {
+		return errors.New("invalid_input", "provider_type should be at most 2")
+	}
+	return nil
+}
+
+// MinerSCKillMinerInput is the input of the MINERSC_KILL_MINER method of the MinerSC, it kills a miner, only the owner of the smart contract can.
+type MinerSCKillMinerInput struct {
+	// ProviderID is the id of the miner.
+	ProviderID string `json:"provider_id"`
+	// ProviderType is the type of the provider, ProviderMiner.
+	ProviderType Provider `json:"provider_type"`
+}
+
+// SmartContractAddress returns the address of the MinerSC.
+func (in *MinerSCKillMinerInput) SmartContractAddress() string {
+	return MinerSmartContractAddress
+}
+
+// MethodName returns transaction.MINERSC_KILL_MINER.
+func (in *MinerSCKillMinerInput) MethodName() string {
+	return transaction.MINERSC_KILL_MINER
+}
+
+// Validate checks the fields of the input.
+func (in *MinerSCKillMinerInput) Validate() error {
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	if in.ProviderType < 1 {
+		return errors.New("invalid_input", "provider_type should be at least 1")
+	}
+	if in.ProviderType > 1 {
+		return errors.New("invalid_input", "provider_type should be at most 1")
+	}
+	return nil
+}
+
+// MinerSCKillSharderInput is the input of the MINERSC_KILL_SHARDER method of the MinerSC, it kills a sharder, only the owner of the smart contract can.
+type MinerSCKillSharderInput struct {
+	// ProviderID is the id of the sharder.
+	ProviderID string `json:"provider_id"`
+	// ProviderType is the type of the provider, ProviderSharder.
+	ProviderType Provider `json:"provider_type"`
+}
+
+// SmartContractAddress returns the address of the MinerSC.
+func (in *MinerSCKillSharderInput) SmartContractAddress() string {
+	return MinerSmartContractAddress
+}
+
+// MethodName returns transaction.MINERSC_KILL_SHARDER.
+func (in *MinerSCKillSharderInput) MethodName() string {
+	return transaction.MINERSC_KILL_SHARDER
+}
+
+// Validate checks the fields of the input.
+func (in *MinerSCKillSharderInput) Validate() error {
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	if in.ProviderType < 2 {
+		return errors.New("invalid_input", "provider_type should be at least 2")
+	}
+	if in.ProviderType > 2 {
+		return errors.New("invalid_input", "provider_type should be at most 2")
+	}
+	return nil
+}
+
+// InterestPoolLockInput is the input of the LOCK_TOKEN method of the InterestPool, it locks tokens in the interest pool to earn interest.
+type InterestPoolLockInput struct {
+	// Duration is the duration of the lock.
+	Duration time.Duration `json:"duration"`
+}
+
+// SmartContractAddress returns the address of the InterestPool.
+func (in *InterestPoolLockInput) SmartContractAddress() string {
+	return InterestPoolSmartContractAddress
+}
+
+// MethodName returns transaction.LOCK_TOKEN.
+func (in *InterestPoolLockInput) MethodName() string {
+	return transaction.LOCK_TOKEN
+}
+
+// Validate checks the fields of the input.
+func (in *InterestPoolLockInput) Validate() error {
+	if in.Duration < 1 {
+		return errors.New("invalid_input", "duration should be at least 1")
+	}
+	return nil
+}
+
+// InterestPoolUnlockInput is the input of the UNLOCK_TOKEN method of the InterestPool, it unlocks the tokens of a matured interest pool lock.
+type InterestPoolUnlockInput struct {
+	// PoolID is the id of the lock.
+	PoolID string `json:"pool_id"`
+}
+
+// SmartContractAddress returns the address of the InterestPool.
+func (in *InterestPoolUnlockInput) SmartContractAddress() string {
+	return InterestPoolSmartContractAddress
+}
+
+// MethodName returns transaction.UNLOCK_TOKEN.
+func (in *InterestPoolUnlockInput) MethodName() string {
+	return transaction.UNLOCK_TOKEN
+}
+
+// Validate checks the fields of the input.
+func (in *InterestPoolUnlockInput) Validate() error {
+	if in.PoolID == "" {
+		return errors.New("invalid_input", "pool_id is required")
+	}
+	return nil
+}
+
+// FaucetSCPourInput is the input of the FAUCETSC_POUR method of the FaucetSC, it pours tokens from the faucet to the client.
+type FaucetSCPourInput struct {
+}
+
+// SmartContractAddress returns the address of the FaucetSC.
+func (in *FaucetSCPourInput) SmartContractAddress() string {
+	return FaucetSmartContractAddress
+}
+
+// MethodName returns transaction.FAUCETSC_POUR.
+func (in *FaucetSCPourInput) MethodName() string {
+	return transaction.FAUCETSC_POUR
+}
+
+// Validate checks the fields of the input.
+func (in *FaucetSCPourInput) Validate() error {
+	return nil
+}
+
+// FaucetSCUpdateSettingsInput is the input of the FAUCETSC_UPDATE_SETTINGS method of the FaucetSC, it updates the settings of the faucet, only the owner of the smart contract can.
+type FaucetSCUpdateSettingsInput struct {
+	// Fields is the settings to update, by name.
+	Fields map[string]string `json:"Fields"`
+}
+
+// SmartContractAddress returns the address of the FaucetSC.
+func (in *FaucetSCUpdateSettingsInput) SmartContractAddress() string {
+	return FaucetSmartContractAddress
+}
+
+// MethodName returns transaction.FAUCETSC_UPDATE_SETTINGS.
+func (in *FaucetSCUpdateSettingsInput) MethodName() string {
+	return transaction.FAUCETSC_UPDATE_SETTINGS
+}
+
+// Validate checks the fields of the input.
+func (in *FaucetSCUpdateSettingsInput) Validate() error {
+	if len(in.Fields) == 0 {
+		return errors.New("invalid_input", "Fields is required")
+	}
+	return nil
+}
+
+// ZCNSCDeleteAuthorizerInput is the input of the ZCNSC_DELETE_AUTHORIZER method of the ZCNSC, it deletes an authorizer.
+type ZCNSCDeleteAuthorizerInput struct {
+	// ID is the id of the authorizer.
+	ID string `json:"id"`
+}
+
+// SmartContractAddress returns the address of the ZCNSC.
+func (in *ZCNSCDeleteAuthorizerInput) SmartContractAddress() string {
+	return ZCNSCSmartContractAddress
+}
+
+// MethodName returns transaction.ZCNSC_DELETE_AUTHORIZER.
+func (in *ZCNSCDeleteAuthorizerInput) MethodName() string {
+	return transaction.ZCNSC_DELETE_AUTHORIZER
+}
+
+// Validate checks the fields of the input.
+func (in *ZCNSCDeleteAuthorizerInput) Validate() error {
+	if in.ID == "" {
+		return errors.New("invalid_input", "id is required")
+	}
+	return nil
+}
+
+// ZCNSCAuthorizerHealthCheckInput is the input of the ZCNSC_AUTHORIZER_HEALTH_CHECK method of the ZCNSC, it reports an authorizer is alive.
+type ZCNSCAuthorizerHealthCheckInput struct {
+	// ID is the id of the authorizer.
+	ID string `json:"id"`
+}
+
+// SmartContractAddress returns the address of the ZCNSC.
+func (in *ZCNSCAuthorizerHealthCheckInput) SmartContractAddress() string {
+	return ZCNSCSmartContractAddress
+}
+
+// MethodName returns transaction.ZCNSC_AUTHORIZER_HEALTH_CHECK.
+func (in *ZCNSCAuthorizerHealthCheckInput) MethodName() string {
+	return transaction.ZCNSC_AUTHORIZER_HEALTH_CHECK
+}
+
+// Validate checks the fields of the input.
+func (in *ZCNSCAuthorizerHealthCheckInput) Validate() error {
+	if in.ID == "" {
+		return errors.New("invalid_input", "id is required")
+	}
+	return nil
+}
+
+// ZCNSCLockInput is the input of the ZCNSC_LOCK method of the ZCNSC, it locks tokens in the stake pool of an authorizer.
+type ZCNSCLockInput struct {
+	// ProviderType is the type of the provider, ProviderAuthorizer.
+	ProviderType Provider `json:"provider_type"`
+	// ProviderID is the id of the authorizer.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the ZCNSC.
+func (in *ZCNSCLockInput) SmartContractAddress() string {
+	return ZCNSCSmartContractAddress
+}
+
+// MethodName returns transaction.ZCNSC_LOCK.
+func (in *ZCNSCLockInput) MethodName() string {
+	return transaction.ZCNSC_LOCK
+}
+
+// Validate checks the fields of the input.
+func (in *ZCNSCLockInput) Validate() error {
+	if in.ProviderType < 5 {
+		return errors.New("invalid_input", "provider_type should be at least 5")
+	}
+	if in.ProviderType > 5 {
+		return errors.New("invalid_input", "provider_type should be at most 5")
+	}
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// ZCNSCUnlockInput is the input of the ZCNSC_UNLOCK method of the ZCNSC, it unlocks the tokens of the stake pool of an authorizer.
+type ZCNSCUnlockInput struct {
+	// ProviderType is the type of the provider, ProviderAuthorizer.
+	ProviderType Provider `json:"provider_type"`
+	// ProviderID is the id of the authorizer.
+	ProviderID string `json:"provider_id"`
+}
+
+// SmartContractAddress returns the address of the ZCNSC.
+func (in *ZCNSCUnlockInput) SmartContractAddress() string {
+	return ZCNSCSmartContractAddress
+}
+
+// MethodName returns transaction.ZCNSC_UNLOCK.
+func (in *ZCNSCUnlockInput) MethodName() string {
+	return transaction.ZCNSC_UNLOCK
+}
+
+// Validate checks the fields of the input.
+func (in *ZCNSCUnlockInput) Validate() error {
+	if in.ProviderType < 5 {
+		return errors.New("invalid_input", "provider_type should be at least 5")
+	}
+	if in.ProviderType > 5 {
+		return errors.New("invalid_input", "provider_type should be at most 5")
+	}
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	return nil
+}
+
+// ZCNSCCollectRewardInput is the input of the ZCNSC_COLLECT_REWARD method of the ZCNSC, it collects the rewards of an authorizer.
+type ZCNSCCollectRewardInput struct {
+	// ProviderID is the id of the authorizer.
+	ProviderID string `json:"provider_id"`
+	// ProviderType is the type of the provider, ProviderAuthorizer.
+	ProviderType Provider `json:"provider_type"`
+}
+
+// SmartContractAddress returns the address of the ZCNSC.
+func (in *ZCNSCCollectRewardInput) SmartContractAddress() string {
+	return ZCNSCSmartContractAddress
+}
+
+// MethodName returns transaction.ZCNSC_COLLECT_REWARD.
+func (in *ZCNSCCollectRewardInput) MethodName() string {
+	return transaction.ZCNSC_COLLECT_REWARD
+}
+
+// Validate checks the fields of the input.
+func (in *ZCNSCCollectRewardInput) Validate() error {
+	if in.ProviderID == "" {
+		return errors.New("invalid_input", "provider_id is required")
+	}
+	if in.ProviderType < 5 {
+		return errors.New("invalid_input", "provider_type should be at least 5")
+	}
+	if in.ProviderType > 5 {
+		return errors.New("invalid_input", "provider_type should be at most 5")
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package zcncore
+
+//go:generate go run ./internal/scgen -schema sc_schema.json -out sc_calls.go
+
+// SmartContractCall is the typed input of a smart contract method. The implementations are
+// generated from sc_schema.json, e.g. StorageSCFinalizeAllocationInput, and executed with
+// Transaction.ExecuteSmartContractCall.
+type SmartContractCall interface {
+	// SmartContractAddress returns the address of the smart contract.
+	SmartContractAddress() string
+	// MethodName returns the name of the smart contract method.
+	MethodName() string
+	// Validate checks the input before the transaction is created.
+	Validate() error
+}
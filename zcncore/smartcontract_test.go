@@ -0,0 +1,72 @@
+package zcncore
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmartContractCall_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    SmartContractCall
+		wantErr string
+	}{
+		{
+			name: "valid",
+			call: &StorageSCStakePoolLockInput{ProviderType: ProviderBlobber, ProviderID: "blobber"},
+		},
+		{
+			name:    "missing required field",
+			call:    &StorageSCFinalizeAllocationInput{},
+			wantErr: "allocation_id is required",
+		},
+		{
+			name:    "below min",
+			call:    &MinerSCLockInput{ProviderType: 0, ProviderID: "miner"},
+			wantErr: "provider_type should be at least 1",
+		},
+		{
+			name:    "above max",
+			call:    &MinerSCLockInput{ProviderType: ProviderBlobber, ProviderID: "miner"},
+			wantErr: "provider_type should be at most 2",
+		},
+		{
+			name:    "empty map",
+			call:    &FaucetSCUpdateSettingsInput{},
+			wantErr: "Fields is required",
+		},
+		{
+			name: "no fields",
+			call: &FaucetSCPourInput{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSmartContractCall_Payload(t *testing.T) {
+	call := &StorageSCWritePoolLockInput{AllocationID: "alloc", Duration: time.Minute}
+	require.Equal(t, StorageSmartContractAddress, call.SmartContractAddress())
+	require.Equal(t, transaction.STORAGESC_WRITE_POOL_LOCK, call.MethodName())
+
+	data, err := json.Marshal(call)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"allocation_id":"alloc","duration":60000000000}`, string(data))
+
+	data, err = json.Marshal(&InterestPoolUnlockInput{PoolID: "pool"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"pool_id":"pool"}`, string(data))
+}
@@ -0,0 +1,109 @@
+package zcncore
+
+import (
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// SplitWalletKeys splits the key of a bls0chain wallet in two shares for the split key (2-of-2)
+// signing: the device keeps a share and a signing service (zauth) the other one, the signatures of
+// both shares adding up to the signature of the key of the wallet. Neither share can sign alone.
+// The wallet of the device doesn't keep the mnemonic, which recovers the whole key, so it should be
+// kept offline.
+//   - walletJSON: the json of the wallet to split, with its private key
+//
+// returns the wallet of the device, see EnableSplitKeySigning, and the split wallet of the signing
+// service, see CallZauthSetup.
+func SplitWalletKeys(walletJSON string) (*zcncrypto.Wallet, *SplitWallet, error) {
+	var w zcncrypto.Wallet
+	if err := json.Unmarshal([]byte(walletJSON), &w); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid wallet")
+	}
+	if w.IsSplit {
+		return nil, nil, errors.New("invalid_wallet", "the wallet keys are already split")
+	}
+	if w.ClientID == "" || w.ClientKey == "" || len(w.Keys) == 0 {
+		return nil, nil, errors.New("invalid_wallet", "the wallet should have a client id and keys")
+	}
+
+	split, err := SplitKeysWallet(w.Keys[0].PrivateKey, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	device, peer := split.Keys[0], split.Keys[1]
+	return &zcncrypto.Wallet{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		PeerPublicKey: peer.PublicKey,
+		Keys:          []zcncrypto.KeyPair{device},
+		IsSplit:       true,
+		Version:       w.Version,
+		DateCreated:   w.DateCreated,
+	}, &SplitWallet{
+		ClientID:      w.ClientID,
+		ClientKey:     w.ClientKey,
+		PublicKey:     peer.PublicKey,
+		PrivateKey:    peer.PrivateKey,
+		PeerPublicKey: device.PublicKey,
+	}, nil
+}
+
+// EnableSplitKeySigning sets the wallet of the device and signs the transactions and the messages
+// cooperatively with the signing service holding the other share of the key.
+//   - serverAddr: the address of the signing service (zauth)
+//   - device: the wallet of the device, see SplitWalletKeys
+func EnableSplitKeySigning(serverAddr string, device *zcncrypto.Wallet) error {
+	if device == nil || !device.IsSplit || device.PeerPublicKey == "" || len(device.Keys) == 0 {
+		return errors.New("invalid_wallet", "the wallet isn't the device wallet of a split key")
+	}
+	if _config.chain.SignatureScheme != "bls0chain" {
+		return errors.New("invalid_signature_scheme", "the split keys require the bls0chain signature scheme")
+	}
+	data, err := json.Marshal(device)
+	if err != nil {
+		return errors.Wrap(err, "wallet encoding failed")
+	}
+
+	if err = SetWallet(*device, true); err != nil {
+		return err
+	}
+	if err = SetAuthUrl(serverAddr); err != nil {
+		return err
+	}
+	// the zauth requests are authenticated by the key of the device held by the storage client.
+	if err = client.PopulateClient(string(data), _config.chain.SignatureScheme); err != nil {
+		return err
+	}
+	sys.SetAuthorize(ZauthSignTxn(serverAddr))
+	sys.SetAuthCommon(ZauthAuthCommon(serverAddr))
+	sys.SignWithAuth = ZauthSignMsg(serverAddr)
+	return nil
+}
+
+// SetupSplitKeyWallet splits the key of a wallet, registers the share of the signing service with it
+// and enables the split key signing on the device, see SplitWalletKeys.
+//   - serverAddr: the address of the signing service (zauth)
+//   - token: the token authenticating the setup request to the signing service
+//   - walletJSON: the json of the wallet to split, with its private key
+//
+// returns the json of the wallet of the device, to store instead of the wallet.
+func SetupSplitKeyWallet(serverAddr, token, walletJSON string) (string, error) {
+	if err := checkSdkInit(); err != nil {
+		return "", err
+	}
+	device, peer, err := SplitWalletKeys(walletJSON)
+	if err != nil {
+		return "", err
+	}
+	if err = CallZauthSetup(serverAddr, token, *peer); err != nil {
+		return "", errors.Wrap(err, "failed to register the key share with the signing service")
+	}
+	if err = EnableSplitKeySigning(serverAddr, device); err != nil {
+		return "", err
+	}
+	return device.Marshal()
+}
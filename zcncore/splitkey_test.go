@@ -0,0 +1,51 @@
+package zcncore
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitWalletKeys(t *testing.T) {
+	if _config.chain.SignatureScheme != "bls0chain" {
+		raw := _config.chain.SignatureScheme
+		_config.chain.SignatureScheme = "bls0chain"
+		defer func() { _config.chain.SignatureScheme = raw }()
+	}
+
+	w, err := zcncrypto.NewSignatureScheme("bls0chain").GenerateKeys()
+	require.NoError(t, err)
+	walletJSON, err := w.Marshal()
+	require.NoError(t, err)
+
+	device, peer, err := SplitWalletKeys(walletJSON)
+	require.NoError(t, err)
+	require.True(t, device.IsSplit)
+	require.Empty(t, device.Mnemonic)
+	require.Equal(t, w.ClientID, device.ClientID)
+	require.Equal(t, w.ClientKey, peer.ClientKey)
+	require.Equal(t, peer.PublicKey, device.PeerPublicKey)
+	require.Equal(t, device.Keys[0].PublicKey, peer.PeerPublicKey)
+
+	// the signatures of the shares add up to the signature of the wallet key.
+	hash := encryption.Hash("data")
+	peerSig, err := SignWithKey(peer.PrivateKey, hash)
+	require.NoError(t, err)
+	sig, err := AddSignature(device.Keys[0].PrivateKey, peerSig, hash)
+	require.NoError(t, err)
+	ok, err := VerifyWithKey(w.ClientKey, sig, hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// a share alone can't sign.
+	ok, err = VerifyWithKey(w.ClientKey, peerSig, hash)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	deviceJSON, err := device.Marshal()
+	require.NoError(t, err)
+	_, _, err = SplitWalletKeys(deviceJSON)
+	require.Error(t, err)
+}
@@ -54,15 +54,24 @@ const (
 )
 
 type Miner struct {
-	ID         string      `json:"id"`
-	N2NHost    string      `json:"n2n_host"`
-	Host       string      `json:"host"`
-	Port       int         `json:"port"`
-	PublicKey  string      `json:"public_key"`
-	ShortName  string      `json:"short_name"`
-	BuildTag   string      `json:"build_tag"`
-	TotalStake int64       `json:"total_stake"`
-	Stat       interface{} `json:"stat"`
+	ID              string           `json:"id"`
+	N2NHost         string           `json:"n2n_host"`
+	Host            string           `json:"host"`
+	Port            int              `json:"port"`
+	Geolocation     NodeGeolocation  `json:"geolocation"`
+	PublicKey       string           `json:"public_key"`
+	ShortName       string           `json:"short_name"`
+	BuildTag        string           `json:"build_tag"`
+	TotalStake      int64            `json:"total_stake"`
+	Delete          bool             `json:"delete"`
+	LastHealthCheck common.Timestamp `json:"last_health_check"`
+	Stat            interface{}      `json:"stat"`
+}
+
+// NodeGeolocation represents the geolocation of a miner or sharder.
+type NodeGeolocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 // Node represents a node (miner or sharder) in the network.
@@ -128,6 +137,11 @@ type TransactionCommon interface {
 
 	VestingAdd(ar *VestingAddRequest, value uint64) error
 
+	// LockTokens locks tokens in the interest pool for the given duration
+	LockTokens(val uint64, duration time.Duration) error
+	// UnlockTokens unlocks the tokens of a matured interest pool lock
+	UnlockTokens(poolID string) error
+
 	MinerSCLock(providerId string, providerType Provider, lock uint64) error
 	MinerSCUnlock(providerId string, providerType Provider) error
 	MinerSCCollectReward(providerID string, providerType Provider) error
@@ -388,6 +402,14 @@ func (t *Transaction) createSmartContractTxn(address, methodName string, input i
 	return nil
 }
 
+// createSmartContractCall validates the typed input of a smart contract call, then creates the transaction.
+func (t *Transaction) createSmartContractCall(call SmartContractCall, value uint64, opts ...FeeOption) error {
+	if err := call.Validate(); err != nil {
+		return err
+	}
+	return t.createSmartContractTxn(call.SmartContractAddress(), call.MethodName(), call, value, opts...)
+}
+
 func (t *Transaction) createFaucetSCWallet(walletStr string, methodName string, input []byte) (*zcncrypto.Wallet, error) {
 	w, err := getWallet(walletStr)
 	if err != nil {
@@ -412,6 +434,19 @@ func (t *Transaction) ExecuteSmartContract(address, methodName string, input int
 	return t.txn, nil
 }
 
+// ExecuteSmartContractCall validates the typed input of a smart contract call, then executes it.
+//   - call: the input of the call, e.g. &StorageSCFinalizeAllocationInput{AllocationID: allocID}
+//   - val: the value of the transaction
+//   - opts: the fee options
+func (t *Transaction) ExecuteSmartContractCall(call SmartContractCall, val uint64, opts ...FeeOption) (*transaction.Transaction, error) {
+	err := t.createSmartContractCall(call, val, opts...)
+	if err != nil {
+		return nil, err
+	}
+	go t.setNonceAndSubmit()
+	return t.txn, nil
+}
+
 func (t *Transaction) Send(toClientID string, val uint64, desc string) error {
 	txnData, err := json.Marshal(transaction.SmartContractTxnData{Name: "transfer", InputArgs: SendTxnData{Note: desc}})
 	if err != nil {
@@ -487,6 +522,35 @@ func (t *Transaction) VestingAdd(ar *VestingAddRequest, value uint64) (
 	return
 }
 
+// LockTokens locks tokens in the interest pool smart contract to earn interest.
+// The tokens can be unlocked with UnlockTokens once the lock is matured.
+//   - val: amount of tokens to lock (in SAS)
+//   - duration: lock duration
+func (t *Transaction) LockTokens(val uint64, duration time.Duration) error {
+	if duration <= 0 {
+		return errors.New("invalid_duration", "lock duration should be positive")
+	}
+	err := t.createSmartContractCall(&InterestPoolLockInput{Duration: duration}, val)
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { t.setNonceAndSubmit() }()
+	return nil
+}
+
+// UnlockTokens unlocks the tokens of a matured interest pool lock.
+//   - poolID: id of the interest pool lock
+func (t *Transaction) UnlockTokens(poolID string) error {
+	err := t.createSmartContractCall(&InterestPoolUnlockInput{PoolID: poolID}, 0)
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { t.setNonceAndSubmit() }()
+	return nil
+}
+
 func (t *Transaction) VestingStop(sr *VestingStopRequest) (err error) {
 	err = t.createSmartContractTxn(VestingSmartContractAddress,
 		transaction.VESTING_STOP, sr, 0)
@@ -627,13 +691,7 @@ func (t *Transaction) StorageSCCollectReward(providerId string, providerType Pro
 func (t *Transaction) FinalizeAllocation(allocID string) (
 	err error) {
 
-	type finiRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err = t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_FINALIZE_ALLOCATION, &finiRequest{
-			AllocationID: allocID,
-		}, 0)
+	err = t.createSmartContractCall(&StorageSCFinalizeAllocationInput{AllocationID: allocID}, 0)
 	if err != nil {
 		logging.Error(err)
 		return
@@ -647,13 +705,7 @@ func (t *Transaction) FinalizeAllocation(allocID string) (
 func (t *Transaction) CancelAllocation(allocID string) (
 	err error) {
 
-	type cancelRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err = t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_CANCEL_ALLOCATION, &cancelRequest{
-			AllocationID: allocID,
-		}, 0)
+	err = t.createSmartContractCall(&StorageSCCancelAllocationInput{AllocationID: allocID}, 0)
 	if err != nil {
 		logging.Error(err)
 		return
@@ -1596,6 +1648,15 @@ func WithIsSplitWallet(v bool) func(c *ChainConfig) error {
 	}
 }
 
+// WithFaucet enables FaucetTopUp, it should only be enabled for the devnets and the test networks.
+//   - enabled: true to enable the faucet
+func WithFaucet(enabled bool) func(c *ChainConfig) error {
+	return func(c *ChainConfig) error {
+		c.FaucetEnabled = enabled
+		return nil
+	}
+}
+
 // UpdateValidatorSettings update settings of a validator.
 func (t *Transaction) UpdateValidatorSettings(v *Validator) (err error) {
 
@@ -60,6 +60,8 @@ type ChainConfig struct {
 	EthNode                 string   `json:"eth_node"`
 	SharderConsensous       int      `json:"sharder_consensous"`
 	IsSplitWallet           bool     `json:"is_split_wallet"`
+	// FaucetEnabled allows FaucetTopUp, for the devnets.
+	FaucetEnabled bool `json:"faucet_enabled"`
 }
 
 var Sharders *node.NodeHolder
@@ -167,6 +169,8 @@ type Transaction struct {
 	verifyConfirmationStatus int
 	verifyOut                string
 	verifyError              error
+	// wallet signs the transaction instead of the wallet of the SDK, see Client.
+	wallet *zcncrypto.Wallet
 }
 
 type SendTxnData struct {
@@ -325,7 +329,12 @@ func (t *Transaction) submitTxn() {
 
 	// If Signature is not passed compute signature
 	if t.txn.Signature == "" {
-		err := t.txn.ComputeHashAndSign(SignFn)
+		var err error
+		if t.wallet != nil {
+			err = t.txn.ComputeHashAndSignWithWallet(signWithWallet, t.wallet)
+		} else {
+			err = t.txn.ComputeHashAndSign(SignFn)
+		}
 		if err != nil {
 			t.completeTxn(StatusError, "", err)
 			node.Cache.Evict(t.txn.ClientID)
@@ -0,0 +1,201 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// The statuses of the transactions of the history, see TransactionHistoryEntry.
+const (
+	TransactionStatusSuccess = 1
+	TransactionStatusFailure = 2
+)
+
+// TransactionDirection selects the transactions of a wallet by direction, see TransactionFilter.
+type TransactionDirection int
+
+const (
+	// TransactionsAll selects the transactions sent and received by the wallet.
+	TransactionsAll TransactionDirection = iota
+	// TransactionsSent selects the transactions sent by the wallet.
+	TransactionsSent
+	// TransactionsReceived selects the transactions received by the wallet.
+	TransactionsReceived
+)
+
+// defaultTransactionHistoryLimit is the number of transactions returned if the limit isn't set.
+const defaultTransactionHistoryLimit = 20
+
+// TransactionHistoryEntry is a transaction of the history of a wallet, as indexed by the sharders.
+type TransactionHistoryEntry struct {
+	Hash       string `json:"hash"`
+	BlockHash  string `json:"block_hash"`
+	Round      int64  `json:"round"`
+	ClientID   string `json:"client_id"`
+	ToClientID string `json:"to_client_id"`
+	// TransactionType is the type of the transaction, e.g. transaction.TxnTypeSend.
+	TransactionType   int              `json:"transaction_type"`
+	TransactionData   string           `json:"transaction_data"`
+	TransactionOutput string           `json:"transaction_output"`
+	Value             common.Balance   `json:"value"`
+	Fee               common.Balance   `json:"fee"`
+	Nonce             int64            `json:"nonce"`
+	CreationDate      common.Timestamp `json:"creation_date"`
+	// Status is TransactionStatusSuccess or TransactionStatusFailure.
+	Status int `json:"status"`
+}
+
+// IsSuccess returns true if the transaction was executed successfully.
+func (t *TransactionHistoryEntry) IsSuccess() bool {
+	return t.Status == TransactionStatusSuccess
+}
+
+// TransactionFilter filters the transactions of the history of a wallet.
+type TransactionFilter struct {
+	// Direction selects the transactions sent, received or both.
+	Direction TransactionDirection
+	// BlockHash selects the transactions of a block, optional.
+	BlockHash string
+	// Types selects the transactions of these types, all the types if empty. The transactions are
+	// filtered after being fetched, so a page may have fewer transactions than the limit.
+	Types []int
+}
+
+// Pagination is a page of a list.
+type Pagination struct {
+	Offset int
+	// Limit is the maximum number of items of the page, 20 if not set.
+	Limit int
+	// Ascending sorts the items from the oldest, from the newest otherwise.
+	Ascending bool
+}
+
+// GetTransactionHistory gets the transactions of a wallet from the event database of the sharders,
+// the typed version of GetTransactions. The transactions are sorted by round.
+//   - clientID: client id, if empty the client id of the wallet is used.
+//   - filter: the filter of the transactions.
+//   - page: the page of the transactions.
+func GetTransactionHistory(clientID string, filter TransactionFilter, page Pagination) ([]*TransactionHistoryEntry, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	if clientID == "" {
+		clientID = _config.wallet.ClientID
+	}
+	if page.Offset < 0 || page.Limit < 0 {
+		return nil, errors.New("invalid_pagination", "the offset and the limit can't be negative")
+	}
+	if page.Limit == 0 {
+		page.Limit = defaultTransactionHistoryLimit
+	}
+
+	var lists [][]*TransactionHistoryEntry
+	switch filter.Direction {
+	case TransactionsSent, TransactionsReceived:
+		txns, err := getTransactionHistory(clientID, filter.Direction, filter.BlockHash, page)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, txns)
+	case TransactionsAll:
+		// both lists are fetched from the start to merge them into the page.
+		merged := Pagination{Limit: page.Offset + page.Limit, Ascending: page.Ascending}
+		for _, direction := range []TransactionDirection{TransactionsSent, TransactionsReceived} {
+			txns, err := getTransactionHistory(clientID, direction, filter.BlockHash, merged)
+			if err != nil {
+				return nil, err
+			}
+			lists = append(lists, txns)
+		}
+	default:
+		return nil, errors.Newf("invalid_direction", "unknown transaction direction %d", filter.Direction)
+	}
+
+	txns := mergeTransactionHistory(lists, page.Ascending)
+	if filter.Direction == TransactionsAll {
+		if page.Offset >= len(txns) {
+			txns = nil
+		} else {
+			txns = txns[page.Offset:]
+		}
+		if len(txns) > page.Limit {
+			txns = txns[:page.Limit]
+		}
+	}
+	return filterTransactionTypes(txns, filter.Types), nil
+}
+
+// getTransactionHistory gets a page of the transactions sent or received by a client.
+func getTransactionHistory(clientID string, direction TransactionDirection, blockHash string, page Pagination) ([]*TransactionHistoryEntry, error) {
+	params := Params{
+		"offset": strconv.Itoa(page.Offset),
+		"limit":  strconv.Itoa(page.Limit),
+		"sort":   "desc",
+	}
+	if page.Ascending {
+		params["sort"] = "asc"
+	}
+	if direction == TransactionsSent {
+		params["client_id"] = clientID
+	} else {
+		params["to_client_id"] = clientID
+	}
+	if blockHash != "" {
+		params["block_hash"] = blockHash
+	}
+
+	var txns []*TransactionHistoryEntry
+	if err := getTypedInfoFromSharders(withParams(STORAGESC_GET_TRANSACTIONS, params), OpStorageSCGetTransactions, &txns); err != nil {
+		return nil, errors.Wrap(err, "failed to get transactions")
+	}
+	return txns, nil
+}
+
+// mergeTransactionHistory merges lists of transactions sorted by round, dropping the duplicates,
+// e.g. the transactions a wallet sent to itself.
+func mergeTransactionHistory(lists [][]*TransactionHistoryEntry, ascending bool) []*TransactionHistoryEntry {
+	if len(lists) == 1 {
+		return lists[0]
+	}
+	seen := make(map[string]bool)
+	var txns []*TransactionHistoryEntry
+	for _, list := range lists {
+		for _, txn := range list {
+			if seen[txn.Hash] {
+				continue
+			}
+			seen[txn.Hash] = true
+			txns = append(txns, txn)
+		}
+	}
+	sort.SliceStable(txns, func(i, j int) bool {
+		if ascending {
+			return txns[i].Round < txns[j].Round
+		}
+		return txns[i].Round > txns[j].Round
+	})
+	return txns
+}
+
+// filterTransactionTypes keeps the transactions of the types, all of them if types is empty.
+func filterTransactionTypes(txns []*TransactionHistoryEntry, types []int) []*TransactionHistoryEntry {
+	if len(types) == 0 {
+		return txns
+	}
+	filtered := txns[:0]
+	for _, txn := range txns {
+		for _, typ := range types {
+			if txn.TransactionType == typ {
+				filtered = append(filtered, txn)
+				break
+			}
+		}
+	}
+	return filtered
+}
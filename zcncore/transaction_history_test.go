@@ -0,0 +1,34 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTransactionHistory(t *testing.T) {
+	sent := []*TransactionHistoryEntry{
+		{Hash: "a", Round: 9, TransactionType: transaction.TxnTypeSend},
+		{Hash: "self", Round: 5, TransactionType: transaction.TxnTypeSend},
+		{Hash: "b", Round: 2, TransactionType: transaction.TxnTypeSmartContract},
+	}
+	received := []*TransactionHistoryEntry{
+		{Hash: "c", Round: 7, TransactionType: transaction.TxnTypeSend},
+		{Hash: "self", Round: 5, TransactionType: transaction.TxnTypeSend},
+	}
+
+	txns := mergeTransactionHistory([][]*TransactionHistoryEntry{sent, received}, false)
+	var hashes []string
+	for _, txn := range txns {
+		hashes = append(hashes, txn.Hash)
+	}
+	require.Equal(t, []string{"a", "c", "self", "b"}, hashes)
+
+	txns = filterTransactionTypes(txns, []int{transaction.TxnTypeSmartContract})
+	require.Len(t, txns, 1)
+	require.Equal(t, "b", txns[0].Hash)
+}
@@ -59,6 +59,11 @@ type TransactionCommon interface {
 
 	VestingAdd(ar VestingAddRequest, value string) error
 
+	// LockTokens locks tokens in the interest pool for the given duration (in nanoseconds)
+	LockTokens(val string, duration int64) error
+	// UnlockTokens unlocks the tokens of a matured interest pool lock
+	UnlockTokens(poolID string) error
+
 	MinerSCLock(providerId string, providerType int, lock string) error
 	MinerSCUnlock(providerId string, providerType int) error
 	MinerSCCollectReward(providerId string, providerType int) error
@@ -479,6 +484,26 @@ func (t *Transaction) createFaucetSCWallet(walletStr string, methodName string,
 	return w, nil
 }
 
+// createSmartContractCall validates the typed input of a smart contract call, then creates the transaction.
+func (t *Transaction) createSmartContractCall(call SmartContractCall, value string, opts ...FeeOption) error {
+	if err := call.Validate(); err != nil {
+		return err
+	}
+	return t.createSmartContractTxn(call.SmartContractAddress(), call.MethodName(), call, value, opts...)
+}
+
+// ExecuteSmartContractCall validates the typed input of a smart contract call, then executes it.
+//   - call: the input of the call, e.g. &StorageSCFinalizeAllocationInput{AllocationID: allocID}
+//   - val: the value of the transaction
+func (t *Transaction) ExecuteSmartContractCall(call SmartContractCall, val string) error {
+	err := t.createSmartContractCall(call, val)
+	if err != nil {
+		return err
+	}
+	go t.setNonceAndSubmit()
+	return nil
+}
+
 // ExecuteSmartContract prepare and send a smart contract transaction to the blockchain
 func (t *Transaction) ExecuteSmartContract(address string, methodName string, input string, val string) error {
 	err := t.createSmartContractTxn(address, methodName, input, val)
@@ -545,6 +570,35 @@ func (t *Transaction) VestingAdd(ar VestingAddRequest, value string) (
 	return
 }
 
+// LockTokens locks tokens in the interest pool smart contract to earn interest.
+// The tokens can be unlocked with UnlockTokens once the lock is matured.
+//   - val: amount of tokens to lock (in SAS)
+//   - duration: lock duration (in nanoseconds)
+func (t *Transaction) LockTokens(val string, duration int64) error {
+	if duration <= 0 {
+		return errors.New("invalid_duration", "lock duration should be positive")
+	}
+	err := t.createSmartContractCall(&InterestPoolLockInput{Duration: time.Duration(duration)}, val)
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { t.setNonceAndSubmit() }()
+	return nil
+}
+
+// UnlockTokens unlocks the tokens of a matured interest pool lock.
+//   - poolID: id of the interest pool lock
+func (t *Transaction) UnlockTokens(poolID string) error {
+	err := t.createSmartContractCall(&InterestPoolUnlockInput{PoolID: poolID}, "0")
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { t.setNonceAndSubmit() }()
+	return nil
+}
+
 func (t *Transaction) VestingStop(sr *VestingStopRequest) (err error) {
 	err = t.createSmartContractTxn(VestingSmartContractAddress,
 		transaction.VESTING_STOP, sr, "0")
@@ -655,13 +709,7 @@ func (t *Transaction) StorageSCCollectReward(providerId string, providerType int
 
 // FinalizeAllocation transaction.
 func (t *Transaction) FinalizeAllocation(allocID string) (err error) {
-	type finiRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err = t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_FINALIZE_ALLOCATION, &finiRequest{
-			AllocationID: allocID,
-		}, "0")
+	err = t.createSmartContractCall(&StorageSCFinalizeAllocationInput{AllocationID: allocID}, "0")
 	if err != nil {
 		logging.Error(err)
 		return
@@ -672,13 +720,7 @@ func (t *Transaction) FinalizeAllocation(allocID string) (err error) {
 
 // CancelAllocation transaction.
 func (t *Transaction) CancelAllocation(allocID string) error {
-	type cancelRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err := t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_CANCEL_ALLOCATION, &cancelRequest{
-			AllocationID: allocID,
-		}, "0")
+	err := t.createSmartContractCall(&StorageSCCancelAllocationInput{AllocationID: allocID}, "0")
 	if err != nil {
 		logging.Error(err)
 		return err
@@ -43,6 +43,35 @@ func GetUserLockedTotal(clientID string) (int64, error) {
 
 }
 
+// getTypedInfoFromSharders queries the sharders and decodes the json response into result.
+func getTypedInfoFromSharders(url string, op int, result interface{}) error {
+	cb := createGetInfoCallback()
+	go GetInfoFromSharders(url, op, cb)
+	info, err := cb.Wait()
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal([]byte(info), result); err != nil {
+		return thrown.Wrap(err, "invalid json format")
+	}
+	return nil
+}
+
+// getTypedInfoFromAnySharder queries any healthy sharder and decodes the json response into result,
+// for the data of the event database of the sharders, which can lag behind each other.
+func getTypedInfoFromAnySharder(url string, op int, result interface{}) error {
+	cb := createGetInfoCallback()
+	go GetInfoFromAnySharder(url, op, cb)
+	info, err := cb.Wait()
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal([]byte(info), result); err != nil {
+		return thrown.Wrap(err, "invalid json format")
+	}
+	return nil
+}
+
 func createGetInfoCallback() *getInfoCallback {
 	return &getInfoCallback{
 		callback: make(chan bool),
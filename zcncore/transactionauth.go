@@ -70,6 +70,29 @@ func (ta *TransactionWithAuth) VestingAdd(ar *VestingAddRequest,
 	return
 }
 
+func (ta *TransactionWithAuth) LockTokens(val uint64, duration time.Duration) error {
+	if duration <= 0 {
+		return errors.New("invalid_duration", "lock duration should be positive")
+	}
+	err := ta.t.createSmartContractCall(&InterestPoolLockInput{Duration: duration}, val)
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { ta.submitTxn() }()
+	return nil
+}
+
+func (ta *TransactionWithAuth) UnlockTokens(poolID string) error {
+	err := ta.t.createSmartContractCall(&InterestPoolUnlockInput{PoolID: poolID}, 0)
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { ta.submitTxn() }()
+	return nil
+}
+
 func (ta *TransactionWithAuth) MinerSCLock(providerId string, providerType Provider, lock uint64) error {
 	if lock > math.MaxInt64 {
 		return errors.New("invalid_lock", "int64 overflow on lock value")
@@ -108,13 +131,7 @@ func (ta *TransactionWithAuth) MinerSCUnlock(providerId string, providerType Pro
 func (ta *TransactionWithAuth) FinalizeAllocation(allocID string) (
 	err error) {
 
-	type finiRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err = ta.t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_FINALIZE_ALLOCATION, &finiRequest{
-			AllocationID: allocID,
-		}, 0)
+	err = ta.t.createSmartContractCall(&StorageSCFinalizeAllocationInput{AllocationID: allocID}, 0)
 	if err != nil {
 		logging.Error(err)
 		return
@@ -127,13 +144,7 @@ func (ta *TransactionWithAuth) FinalizeAllocation(allocID string) (
 func (ta *TransactionWithAuth) CancelAllocation(allocID string) (
 	err error) {
 
-	type cancelRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err = ta.t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_CANCEL_ALLOCATION, &cancelRequest{
-			AllocationID: allocID,
-		}, 0)
+	err = ta.t.createSmartContractCall(&StorageSCCancelAllocationInput{AllocationID: allocID}, 0)
 	if err != nil {
 		logging.Error(err)
 		return
@@ -60,6 +60,29 @@ func (ta *TransactionWithAuth) VestingAdd(ar VestingAddRequest, value string) er
 	return nil
 }
 
+func (ta *TransactionWithAuth) LockTokens(val string, duration int64) error {
+	if duration <= 0 {
+		return errors.New("invalid_duration", "lock duration should be positive")
+	}
+	err := ta.t.createSmartContractCall(&InterestPoolLockInput{Duration: time.Duration(duration)}, val)
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { ta.submitTxn() }()
+	return nil
+}
+
+func (ta *TransactionWithAuth) UnlockTokens(poolID string) error {
+	err := ta.t.createSmartContractCall(&InterestPoolUnlockInput{PoolID: poolID}, "0")
+	if err != nil {
+		logging.Error(err)
+		return err
+	}
+	go func() { ta.submitTxn() }()
+	return nil
+}
+
 func (ta *TransactionWithAuth) MinerSCLock(providerId string, providerType int, lock string) error {
 	pr := stakePoolRequest{
 		ProviderType: providerType,
@@ -93,13 +116,7 @@ func (ta *TransactionWithAuth) MinerSCUnlock(providerId string, providerType int
 
 // FinalizeAllocation transaction.
 func (ta *TransactionWithAuth) FinalizeAllocation(allocID string) error {
-	type finiRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err := ta.t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_FINALIZE_ALLOCATION, &finiRequest{
-			AllocationID: allocID,
-		}, "0")
+	err := ta.t.createSmartContractCall(&StorageSCFinalizeAllocationInput{AllocationID: allocID}, "0")
 	if err != nil {
 		logging.Error(err)
 		return err
@@ -110,13 +127,7 @@ func (ta *TransactionWithAuth) FinalizeAllocation(allocID string) error {
 
 // CancelAllocation transaction.
 func (ta *TransactionWithAuth) CancelAllocation(allocID string) error {
-	type cancelRequest struct {
-		AllocationID string `json:"allocation_id"`
-	}
-	err := ta.t.createSmartContractTxn(StorageSmartContractAddress,
-		transaction.STORAGESC_CANCEL_ALLOCATION, &cancelRequest{
-			AllocationID: allocID,
-		}, "0")
+	err := ta.t.createSmartContractCall(&StorageSCCancelAllocationInput{AllocationID: allocID}, "0")
 	if err != nil {
 		logging.Error(err)
 		return err
@@ -0,0 +1,115 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// NewVestingAddRequest creates a request for a new vesting pool, destinations are added with AddDestinations.
+// The request is submitted with Transaction.VestingAdd, its value should be the Total of the request.
+//   - desc: description of the vesting pool, can be empty
+//   - startTime: the time the vesting starts
+//   - duration: the vesting duration
+func NewVestingAddRequest(desc string, startTime common.Timestamp, duration time.Duration) *VestingAddRequest {
+	return &VestingAddRequest{
+		Description: desc,
+		StartTime:   startTime,
+		Duration:    duration,
+	}
+}
+
+// AddDestinations adds a destination to vest tokens to.
+//   - id: client id of the destination
+//   - amount: amount of tokens (in SAS) vested to the destination for the whole duration
+func (ar *VestingAddRequest) AddDestinations(id string, amount common.Balance) {
+	ar.Destinations = append(ar.Destinations, &VestingDest{ID: id, Amount: amount})
+}
+
+// Total returns the amount of tokens vested to all the destinations of the request.
+func (ar *VestingAddRequest) Total() (total common.Balance) {
+	for _, d := range ar.Destinations {
+		total += d.Amount
+	}
+	return
+}
+
+// Validate checks the request against the vesting SC configuration.
+//   - conf: the vesting SC configuration, see GetVestingConfig
+func (ar *VestingAddRequest) Validate(conf *VestingSCConfig) error {
+	if len(ar.Destinations) == 0 {
+		return errors.New("invalid_vesting_request", "no destinations")
+	}
+	if conf.MaxDestinations > 0 && len(ar.Destinations) > conf.MaxDestinations {
+		return errors.Newf("invalid_vesting_request", "too many destinations: %d > %d", len(ar.Destinations), conf.MaxDestinations)
+	}
+	if conf.MaxDescriptionLength > 0 && len(ar.Description) > conf.MaxDescriptionLength {
+		return errors.New("invalid_vesting_request", "description is too long")
+	}
+	if ar.Duration < conf.MinDuration || (conf.MaxDuration > 0 && ar.Duration > conf.MaxDuration) {
+		return errors.Newf("invalid_vesting_request", "duration should be in range [%v, %v]", conf.MinDuration, conf.MaxDuration)
+	}
+	for _, d := range ar.Destinations {
+		if d.ID == "" {
+			return errors.New("invalid_vesting_request", "empty destination id")
+		}
+		if d.Amount == 0 {
+			return errors.New("invalid_vesting_request", "destination amount should be positive: "+d.ID)
+		}
+	}
+	if ar.Total() < conf.MinLock {
+		return errors.Newf("invalid_vesting_request", "insufficient amount to lock: %d < %d", ar.Total(), conf.MinLock)
+	}
+	return nil
+}
+
+// GetVestingConfig is the typed version of GetVestingSCConfig, it waits for the response of the sharders.
+func GetVestingConfig() (*VestingSCConfig, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	conf := new(VestingSCConfig)
+	if err := getTypedInfoFromSharders(GET_VESTING_CONFIG, 0, conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// GetVestingPool is the typed version of GetVestingPoolInfo, it waits for the response of the sharders.
+//   - poolID: id of the vesting pool
+func GetVestingPool(poolID string) (*VestingPoolInfo, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	info := new(VestingPoolInfo)
+	err := getTypedInfoFromSharders(WithParams(GET_VESTING_POOL_INFO, Params{
+		"pool_id": poolID,
+	}), 0, info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetVestingClientPools is the typed version of GetVestingClientList, it waits for the response of the sharders.
+//   - clientID: client id, if empty the client id of the wallet is used.
+func GetVestingClientPools(clientID string) (*VestingClientList, error) {
+	if err := CheckConfig(); err != nil {
+		return nil, err
+	}
+	if clientID == "" {
+		clientID = _config.wallet.ClientID
+	}
+	list := new(VestingClientList)
+	err := getTypedInfoFromSharders(WithParams(GET_VESTING_CLIENT_POOLS, Params{
+		"client_id": clientID,
+	}), 0, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -47,6 +47,12 @@ const (
 	GET_VESTING_POOL_INFO    = VESTINGSC_PFX + `/getPoolInfo`
 	GET_VESTING_CLIENT_POOLS = VESTINGSC_PFX + `/getClientPools`
 
+	// interest pool SC
+
+	INTERESTPOOLSC_PFX = `/v1/screst/` + InterestPoolSmartContractAddress
+	GET_LOCK_CONFIG    = INTERESTPOOLSC_PFX + `/getLockConfig`
+	GET_LOCKED_TOKENS  = INTERESTPOOLSC_PFX + `/getPoolsStats`
+
 	// faucet sc
 
 	FAUCETSC_PFX        = `/v1/screst/` + FaucetSmartContractAddress
@@ -97,14 +103,15 @@ const (
 )
 
 const (
-	StorageSmartContractAddress  = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7`
-	VestingSmartContractAddress  = `2bba5b05949ea59c80aed3ac3474d7379d3be737e8eb5a968c52295e48333ead`
-	FaucetSmartContractAddress   = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d3`
-	MultiSigSmartContractAddress = `27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7`
-	MinerSmartContractAddress    = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9`
-	ZCNSCSmartContractAddress    = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0`
-	MultiSigRegisterFuncName     = "register"
-	MultiSigVoteFuncName         = "vote"
+	StorageSmartContractAddress      = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7`
+	VestingSmartContractAddress      = `2bba5b05949ea59c80aed3ac3474d7379d3be737e8eb5a968c52295e48333ead`
+	FaucetSmartContractAddress       = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d3`
+	MultiSigSmartContractAddress     = `27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7`
+	MinerSmartContractAddress        = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9`
+	ZCNSCSmartContractAddress        = `6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0`
+	InterestPoolSmartContractAddress = `cf8d0df9bd8cc637a4ff4e792ffe3686da6220c45f0e1103baa609f3f1751ef4`
+	MultiSigRegisterFuncName         = "register"
+	MultiSigVoteFuncName             = "vote"
 )
 
 // In percentage
@@ -466,6 +473,42 @@ func RecoverOfflineWallet(mnemonic string) (string, error) {
 	return walletString, nil
 }
 
+// ErrWalletNotRegistered is returned by RecoverRegisteredWallet when the client of the recovered
+// wallet isn't registered on the chain.
+var ErrWalletNotRegistered = errors.New("wallet_not_registered", "the recovered wallet isn't registered on the chain, check the mnemonic")
+
+// getClientDetails gets the details of a registered client, replaced by the tests.
+var getClientDetails = GetClientDetails
+
+// RecoverRegisteredWallet recovers a wallet using the mnemonic, then checks its client is registered
+// on the chain, so a mistyped mnemonic doesn't silently produce a new empty wallet. The mnemonic is
+// validated first, see zcncrypto.ValidateMnemonic for the errors describing the invalid words.
+//   - mnemonic: mnemonics to recover
+//
+// returns the wallet, ErrWalletNotRegistered if its client isn't registered.
+func RecoverRegisteredWallet(mnemonic string) (string, error) {
+	if err := CheckConfig(); err != nil {
+		return "", err
+	}
+	if err := zcncrypto.ValidateMnemonic(mnemonic); err != nil {
+		return "", err
+	}
+
+	sigScheme := zcncrypto.NewSignatureScheme(_config.chain.SignatureScheme)
+	wallet, err := sigScheme.RecoverKeys(strings.Join(strings.Fields(mnemonic), " "))
+	if err != nil {
+		return "", err
+	}
+	details, err := getClientDetails(wallet.ClientID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the client details")
+	}
+	if details.ID != wallet.ClientID {
+		return "", ErrWalletNotRegistered
+	}
+	return wallet.Marshal()
+}
+
 // RecoverWallet recovers the previously generated wallet using the mnemonic.
 // It also registers the wallet again to block chain.
 func RecoverWallet(mnemonic string, statusCb WalletCallback) error {
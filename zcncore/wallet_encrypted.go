@@ -0,0 +1,164 @@
+package zcncore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/0chain/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrInvalidPassphrase is returned by LoadWalletEncrypted when the wallet can't be decrypted with the passphrase.
+var ErrInvalidPassphrase = errors.New("invalid_passphrase", "the wallet can't be decrypted with the passphrase")
+
+const (
+	encryptedWalletVersion = 1
+	encryptedWalletKDF     = "argon2id"
+
+	defaultWalletKDFTime   uint32 = 3
+	defaultWalletKDFMemory uint32 = 64 * 1024 // KiB
+	// maxWalletKDFTime and maxWalletKDFMemory bound the argon2id parameters read from the files, so a
+	// crafted file can't make the key derivation exhaust the memory or the cpu of the device.
+	maxWalletKDFTime   = 4 * defaultWalletKDFTime
+	maxWalletKDFMemory = 4 * defaultWalletKDFMemory
+)
+
+// argon2id parameters of the new encrypted wallets, the parameters are stored in the file so
+// they can be changed without breaking the existing wallets.
+var (
+	walletKDFTime          = defaultWalletKDFTime
+	walletKDFMemory        = defaultWalletKDFMemory
+	walletKDFThreads uint8 = 4
+)
+
+// encryptedWallet is the file format of a wallet encrypted with a passphrase.
+type encryptedWallet struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Nonce   []byte `json:"nonce"`
+	// Ciphertext is the wallet JSON encrypted with AES-256-GCM.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// SaveWalletEncrypted saves the wallet set with SetWallet or SetWalletInfo to a file, encrypted with
+// a key derived from the passphrase (argon2id, AES-256-GCM). The file is only readable by the user.
+//   - path: the path of the file, it's overwritten if it exists
+//   - passphrase: the passphrase to encrypt the wallet with
+func SaveWalletEncrypted(path, passphrase string) error {
+	if err := checkWalletConfig(); err != nil {
+		return err
+	}
+	walletJSON, err := json.Marshal(_config.wallet)
+	if err != nil {
+		return err
+	}
+	data, err := encryptWallet(walletJSON, passphrase)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so the existing wallet isn't lost if the write fails
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint: errcheck
+	if err = tmp.Chmod(0600); err == nil {
+		_, err = tmp.Write(data)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadWalletEncrypted loads a wallet saved with SaveWalletEncrypted. The wallet isn't set, pass it
+// to SetWalletInfo to use it.
+//   - path: the path of the file
+//   - passphrase: the passphrase the wallet was encrypted with
+//
+// returns the wallet JSON, ErrInvalidPassphrase if the passphrase is wrong.
+func LoadWalletEncrypted(path, passphrase string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	walletJSON, err := decryptWallet(data, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return string(walletJSON), nil
+}
+
+func encryptWallet(walletJSON []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("invalid_passphrase", "the passphrase can't be empty")
+	}
+	ew := encryptedWallet{
+		Version: encryptedWalletVersion,
+		KDF:     encryptedWalletKDF,
+		Salt:    make([]byte, 16),
+		Time:    walletKDFTime,
+		Memory:  walletKDFMemory,
+		Threads: walletKDFThreads,
+	}
+	if _, err := rand.Read(ew.Salt); err != nil {
+		return nil, err
+	}
+	gcm, err := ew.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	ew.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(ew.Nonce); err != nil {
+		return nil, err
+	}
+	ew.Ciphertext = gcm.Seal(nil, ew.Nonce, walletJSON, nil)
+	return json.Marshal(&ew)
+}
+
+func decryptWallet(data []byte, passphrase string) ([]byte, error) {
+	var ew encryptedWallet
+	if err := json.Unmarshal(data, &ew); err != nil {
+		return nil, errors.Wrap(err, "invalid encrypted wallet")
+	}
+	if ew.Version != encryptedWalletVersion || ew.KDF != encryptedWalletKDF {
+		return nil, errors.Newf("invalid_encrypted_wallet", "unsupported version %d with kdf %q", ew.Version, ew.KDF)
+	}
+	if ew.Threads < 1 || ew.Time < 1 || ew.Time > maxWalletKDFTime || ew.Memory > maxWalletKDFMemory {
+		return nil, errors.Newf("invalid_encrypted_wallet", "invalid kdf parameters: time %d, memory %d KiB, threads %d", ew.Time, ew.Memory, ew.Threads)
+	}
+	gcm, err := ew.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(ew.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid_encrypted_wallet", "invalid nonce size")
+	}
+	walletJSON, err := gcm.Open(nil, ew.Nonce, ew.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	return walletJSON, nil
+}
+
+// cipher derives the key from the passphrase with the argon2id parameters of the wallet.
+func (ew *encryptedWallet) cipher(passphrase string) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), ew.Salt, ew.Time, ew.Memory, ew.Threads, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,68 @@
+package zcncore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletEncrypted(t *testing.T) {
+	// cheap key derivation for the test
+	memory, time := walletKDFMemory, walletKDFTime
+	walletKDFMemory, walletKDFTime = 1024, 1
+	defer func() { walletKDFMemory, walletKDFTime = memory, time }()
+
+	wallet := zcncrypto.Wallet{
+		ClientID:  "client id",
+		ClientKey: "client key",
+		Keys:      []zcncrypto.KeyPair{{PublicKey: "public key", PrivateKey: "private key"}},
+		Mnemonic:  "mnemonic",
+		Version:   "1.0",
+	}
+	require.NoError(t, SetWallet(wallet, false))
+
+	path := filepath.Join(t.TempDir(), "wallet.json")
+	require.NoError(t, SaveWalletEncrypted(path, "passphrase"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "private key")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	walletJSON, err := LoadWalletEncrypted(path, "passphrase")
+	require.NoError(t, err)
+	var got zcncrypto.Wallet
+	require.NoError(t, json.Unmarshal([]byte(walletJSON), &got))
+	require.Equal(t, wallet, got)
+
+	_, err = LoadWalletEncrypted(path, "wrong passphrase")
+	require.True(t, errors.Is(err, ErrInvalidPassphrase))
+
+	_, err = encryptWallet([]byte("{}"), "")
+	require.Error(t, err)
+
+	// the kdf parameters of the files are bounded
+	var ew encryptedWallet
+	require.NoError(t, json.Unmarshal(data, &ew))
+	for _, tamper := range []func(ew *encryptedWallet){
+		func(ew *encryptedWallet) { ew.Threads = 0 },
+		func(ew *encryptedWallet) { ew.Time = 0 },
+		func(ew *encryptedWallet) { ew.Time = maxWalletKDFTime + 1 },
+		func(ew *encryptedWallet) { ew.Memory = maxWalletKDFMemory + 1 },
+	} {
+		tampered := ew
+		tamper(&tampered)
+		tamperedData, err := json.Marshal(&tampered)
+		require.NoError(t, err)
+		_, err = decryptWallet(tamperedData, "passphrase")
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrInvalidPassphrase))
+	}
+}